@@ -8,23 +8,38 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/gogo/protobuf/jsonpb"
+	gogoproto "github.com/gogo/protobuf/proto"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
 	"google.golang.org/grpc"
 
 	"github.com/nginx/agent/sdk/v2/client"
 	sdkGRPC "github.com/nginx/agent/sdk/v2/grpc"
+	"github.com/nginx/agent/sdk/v2/proto"
 	"github.com/nginx/agent/v2/src/core"
 	"github.com/nginx/agent/v2/src/core/config"
 	"github.com/nginx/agent/v2/src/core/logger"
+	"github.com/nginx/agent/v2/src/core/systemd"
+	"github.com/nginx/agent/v2/src/core/tracing"
 	"github.com/nginx/agent/v2/src/plugins"
 )
 
@@ -38,6 +53,45 @@ const (
 	DEFAULT_PLUGIN_SIZE = 100
 )
 
+// Exit codes of the one-shot commands, so automation can branch on the reason
+// a command failed instead of parsing its output
+const (
+	exitFailure          = 1
+	exitConfigInvalid    = 2
+	exitConnectivity     = 3
+	exitPermissionDenied = 4
+	exitNoNginx          = 5
+)
+
+// exitCodeError carries the exit code a one-shot command should fail with
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+
+func exitWithCode(code int, err error) error {
+	return &exitCodeError{code: code, err: err}
+}
+
+// exitCodeFor maps an error to its exit code: an explicit code when the
+// command set one, otherwise recognizable causes, otherwise a plain failure
+func exitCodeFor(err error) int {
+	var coded *exitCodeError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	if errors.Is(err, core.ErrNoNginxInstances) {
+		return exitNoNginx
+	}
+	if errors.Is(err, os.ErrPermission) {
+		return exitPermissionDenied
+	}
+	return exitFailure
+}
+
 func init() {
 	config.SetVersion(version, commit)
 	config.SetDefaults()
@@ -51,6 +105,13 @@ func init() {
 }
 
 func main() {
+	config.ROOT_COMMAND.AddCommand(debugBundleCommand())
+	config.ROOT_COMMAND.AddCommand(statusCommand())
+	config.ROOT_COMMAND.AddCommand(configDiffCommand())
+	config.ROOT_COMMAND.AddCommand(collectCommand())
+	config.ROOT_COMMAND.AddCommand(checkConfigCommand())
+	config.ROOT_COMMAND.AddCommand(registerCommand())
+	config.ROOT_COMMAND.AddCommand(generateDocsCommand())
 	config.RegisterRunner(func(cmd *cobra.Command, _ []string) {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
@@ -63,7 +124,16 @@ func main() {
 		}
 
 		logger.SetLogLevel(loadedConfig.Log.Level)
-		logFile := logger.SetLogFile(loadedConfig.Log.Path)
+		logger.SetLogFormat(loadedConfig.Log.Format)
+		if loadedConfig.Log.Journal {
+			if err := logger.EnableJournal(); err != nil {
+				log.Warnf("Unable to enable journal logging: %v", err)
+			}
+		}
+		logger.WatchLogLevelSignals(ctx, loadedConfig.Log.Level)
+		logFile := logger.SetRotatingLogFile(
+			loadedConfig.Log.Path, loadedConfig.Log.MaxSize, loadedConfig.Log.MaxBackups, loadedConfig.Log.MaxAge,
+		)
 		if logFile != nil {
 			defer logFile.Close()
 		}
@@ -71,9 +141,39 @@ func main() {
 			loadedConfig.DisplayName = env.GetHostname()
 			log.Infof("setting displayName to %s", loadedConfig.DisplayName)
 		}
+		env.SetNginxBinarySearchPaths(loadedConfig.Nginx.BinarySearchPaths)
+
+		if loadedConfig.Vault.Enable {
+			if err := config.ResolveVaultSecrets(ctx, loadedConfig); err != nil {
+				log.Fatalf("Failed to resolve secrets from Vault: %v", err)
+			}
+		}
+
+		if loadedConfig.Tracing.Enable {
+			shutdownTracing, err := tracing.Init(loadedConfig.Tracing.Endpoint, loadedConfig.Tracing.ServiceName)
+			if err != nil {
+				log.Warnf("Unable to set up tracing: %v", err)
+			} else {
+				defer shutdownTracing(context.Background())
+			}
+		}
+
+		if err := loadedConfig.ValidateLabels(); err != nil {
+			log.Fatalf("Invalid labels configured: %v", err)
+		}
+
+		if loadedConfig.FIPSMode {
+			if err := loadedConfig.ValidateFIPSMode(); err != nil {
+				log.Fatalf("Invalid configuration for FIPS mode: %v", err)
+			}
+			core.EnableFIPSMode()
+			sdkGRPC.EnableFIPSMode()
+			log.Info("FIPS mode enabled")
+		}
 
 		log.Infof("NGINX Agent %s at %s with pid %d, clientID=%s name=%s features=%v",
 			version, commit, os.Getpid(), loadedConfig.ClientID, loadedConfig.DisplayName, loadedConfig.Features)
+		config.LogMaturityNotices(loadedConfig)
 		sdkGRPC.InitMeta(loadedConfig.ClientID, loadedConfig.CloudAccountID)
 
 		controller, commander, reporter := createGrpcClients(ctx, loadedConfig)
@@ -89,7 +189,7 @@ func main() {
 
 		corePlugins := loadPlugins(commander, binary, env, reporter, loadedConfig)
 
-		pipe := initializeMessagePipe(ctx, corePlugins)
+		pipe := initializeMessagePipe(ctx, corePlugins, loadedConfig)
 
 		pipe.Process(core.NewMessage(core.AgentStarted,
 			plugins.NewAgentEventMeta(version, strconv.Itoa(os.Getpid()))),
@@ -97,11 +197,469 @@ func main() {
 
 		handleSignals(ctx, commander, loadedConfig, env, pipe, cancel)
 
+		systemd.NotifyReady()
+		systemd.NotifyStatus(fmt.Sprintf("NGINX Agent %s running, pid %d", version, os.Getpid()))
+		if systemd.StartWatchdog(ctx) {
+			log.Info("systemd watchdog keep-alives started")
+		}
+
 		pipe.Run()
 	})
 
 	if err := config.Execute(); err != nil {
-		log.Fatal(err)
+		log.Error(err)
+		os.Exit(exitCodeFor(err))
+	}
+}
+
+// debugBundleCommand is the local verb for collecting the same diagnostic bundle the
+// management server can request, written into the current directory
+func debugBundleCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "debug-bundle",
+		Short: "Collect a diagnostic bundle (pprof profiles, goroutine dump, redacted agent config, recent logs and nginx -T) for support cases.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			env := &core.EnvironmentType{}
+			loadedConfig, err := config.GetConfig(env.GetSystemUUID())
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+			binary := core.NewNginxBinary(env, loadedConfig)
+
+			bundlePath := core.DebugBundleName()
+			bundle, err := os.OpenFile(bundlePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640)
+			if err != nil {
+				return fmt.Errorf("unable to create debug bundle %s: %w", bundlePath, err)
+			}
+			defer bundle.Close()
+
+			if err := core.WriteDebugBundle(loadedConfig, env, binary, core.DefaultDebugLogLines, bundle); err != nil {
+				os.Remove(bundlePath)
+				return fmt.Errorf("unable to collect debug bundle: %w", err)
+			}
+
+			fmt.Printf("Debug bundle written to %s\n", bundlePath)
+			return nil
+		},
+	}
+}
+
+// configDiffCommand is the local verb for showing how the on-disk NGINX
+// configuration differs from what the running master has loaded, or from a
+// reference directory or zip archive, with secrets redacted
+func configDiffCommand() *cobra.Command {
+	var against string
+	cmd := &cobra.Command{
+		Use:   "config-diff",
+		Short: "Show a unified diff of the NGINX configuration against the running instance, or against a reference directory or zip archive.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			env := &core.EnvironmentType{}
+			loadedConfig, err := config.GetConfig(env.GetSystemUUID())
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+			binary := core.NewNginxBinary(env, loadedConfig)
+
+			diff, err := core.ConfigDiff(env, binary, against)
+			if err != nil {
+				return err
+			}
+			if diff == "" {
+				fmt.Println("No configuration differences found.")
+				return nil
+			}
+			fmt.Print(diff)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&against, "against", "", "Directory or zip archive to diff against instead of the running instance.")
+	return cmd
+}
+
+// generateDocsCommand generates the man pages and shell completion scripts
+// from the command tree. Packaging runs it at build time; the completion
+// subcommand stays available for interactive use.
+func generateDocsCommand() *cobra.Command {
+	var outDir string
+	cmd := &cobra.Command{
+		Use:    "generate-docs",
+		Short:  "Generate man pages and bash/zsh/fish completion scripts into a directory.",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			root := cmd.Root()
+			manDir := filepath.Join(outDir, "man")
+			completionDir := filepath.Join(outDir, "completions")
+			for _, dir := range []string{manDir, completionDir} {
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					return err
+				}
+			}
+
+			header := &doc.GenManHeader{
+				Title:   "NGINX-AGENT",
+				Section: "1",
+				Source:  strings.TrimSpace("nginx-agent " + version),
+				Manual:  "NGINX Agent Manual",
+			}
+			if err := doc.GenManTree(root, header, manDir); err != nil {
+				return fmt.Errorf("unable to generate man pages: %w", err)
+			}
+
+			if err := root.GenBashCompletionFile(filepath.Join(completionDir, "nginx-agent.bash")); err != nil {
+				return fmt.Errorf("unable to generate bash completion: %w", err)
+			}
+			if err := root.GenZshCompletionFile(filepath.Join(completionDir, "_nginx-agent")); err != nil {
+				return fmt.Errorf("unable to generate zsh completion: %w", err)
+			}
+			if err := root.GenFishCompletionFile(filepath.Join(completionDir, "nginx-agent.fish"), true); err != nil {
+				return fmt.Errorf("unable to generate fish completion: %w", err)
+			}
+
+			fmt.Printf("Man pages written to %s\n", manDir)
+			fmt.Printf("Completion scripts written to %s\n", completionDir)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&outDir, "output", "o", "build/docs", "Directory the man pages and completion scripts are written into.")
+	return cmd
+}
+
+// registerCommand is the first-run helper: it writes the control plane
+// settings into the configuration file, verifies that a connection can be
+// established, and prints the IDs the agent will register with. Without flags
+// it prompts for the settings instead.
+func registerCommand() *cobra.Command {
+	var (
+		server     string
+		token      string
+		ca         string
+		skipVerify bool
+		timeout    time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "register",
+		Short: "Configure the control plane connection, verify it and print the system and NGINX instance IDs.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			reader := bufio.NewReader(os.Stdin)
+			if server == "" {
+				fmt.Print("Control plane host:port: ")
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return fmt.Errorf("--server is required")
+				}
+				server = strings.TrimSpace(line)
+			}
+			if server == "" {
+				return fmt.Errorf("--server is required")
+			}
+			if token == "" {
+				fmt.Print("Auth token (leave empty for none): ")
+				if line, err := reader.ReadString('\n'); err == nil {
+					token = strings.TrimSpace(line)
+				}
+			}
+
+			host, portValue, err := net.SplitHostPort(server)
+			if err != nil {
+				return fmt.Errorf("--server must be a host:port pair: %v", err)
+			}
+			port, err := strconv.Atoi(portValue)
+			if err != nil {
+				return fmt.Errorf("--server must have a numeric port: %v", err)
+			}
+
+			path := config.Viper.GetString(config.ConfigPathKey)
+			if path == "" {
+				path = filepath.Join("/etc/nginx-agent", config.ConfigFileName)
+			}
+			if err := config.WriteRegistrationSettings(path, host, port, token, ca, skipVerify); err != nil {
+				return exitWithCode(exitConfigInvalid, err)
+			}
+			fmt.Printf("Control plane settings written to %s\n", path)
+
+			if err := config.LoadPropertiesFromFile(path); err != nil {
+				return fmt.Errorf("unable to reload configuration: %v", err)
+			}
+			env := &core.EnvironmentType{}
+			loadedConfig, err := config.GetConfig(env.GetSystemUUID())
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			sdkGRPC.InitMeta(loadedConfig.ClientID, loadedConfig.CloudAccountID)
+			controller, _, _ := createGrpcClients(ctx, loadedConfig)
+			if controller == nil {
+				return fmt.Errorf("the control plane connection is not configured")
+			}
+			if err := controller.Connect(); err != nil {
+				return exitWithCode(exitConnectivity, fmt.Errorf("unable to connect to %s: %w", loadedConfig.Server.Target, err))
+			}
+			defer controller.Close()
+			fmt.Printf("Connected to %s\n", loadedConfig.Server.Target)
+
+			fmt.Printf("System ID: %s\n", env.GetSystemUUID())
+			binary := core.NewNginxBinary(env, loadedConfig)
+			for _, process := range env.Processes() {
+				if !process.IsMaster {
+					continue
+				}
+				if detail := binary.GetNginxDetailsFromProcess(process); detail != nil {
+					fmt.Printf("NGINX instance ID: %s (version %s)\n", detail.GetNginxId(), detail.GetVersion())
+				}
+			}
+
+			fmt.Println("Start the nginx-agent service to complete registration.")
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&server, "server", "", "Control plane gRPC endpoint as host:port.")
+	cmd.Flags().StringVar(&token, "token", "", "Token the control plane requires, if any.")
+	cmd.Flags().StringVar(&ca, "ca", "", "CA certificate file used to verify the control plane, enables TLS.")
+	cmd.Flags().BoolVar(&skipVerify, "insecure-skip-verify", false, "Enable TLS but skip certificate verification.")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "How long to wait for the connection check.")
+	return cmd
+}
+
+// checkConfigCommand is the local verb for validating the agent configuration
+// file, so typos surface before a restart instead of being silently ignored
+func checkConfigCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check-config",
+		Short: "Validate the agent configuration file, reporting unknown keys and type errors with line numbers.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			path := config.Viper.GetString(config.ConfigPathKey)
+			if path == "" {
+				return exitWithCode(exitConfigInvalid, fmt.Errorf("no configuration file found"))
+			}
+
+			findings, err := config.CheckConfigFile(path)
+			if err != nil {
+				return exitWithCode(exitConfigInvalid, err)
+			}
+			if len(findings) == 0 {
+				fmt.Printf("%s is valid\n", path)
+				return nil
+			}
+
+			errorCount := 0
+			for _, finding := range findings {
+				fmt.Printf("%s: %s\n", path, finding)
+				if finding.Severity == config.ErrorSeverity {
+					errorCount++
+				}
+			}
+			if errorCount > 0 {
+				return exitWithCode(exitConfigInvalid, fmt.Errorf("%s has %d error(s)", path, errorCount))
+			}
+			return nil
+		},
+	}
+}
+
+// collectCommand is the local verb for running the SDK config collection once
+// and writing the NginxConfig payload the agent would report, without
+// connecting to a control plane
+func collectCommand() *cobra.Command {
+	var (
+		nginxID string
+		format  string
+		out     string
+	)
+	cmd := &cobra.Command{
+		Use:   "collect",
+		Short: "Collect the NGINX configuration locally and write the payload the agent would report, as JSON or binary protobuf.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			env := &core.EnvironmentType{}
+			loadedConfig, err := config.GetConfig(env.GetSystemUUID())
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+			binary := core.NewNginxBinary(env, loadedConfig)
+
+			detail, err := selectNginxInstance(env, binary, nginxID)
+			if err != nil {
+				return err
+			}
+
+			nginxConfig, err := binary.ReadConfig(detail.GetConfPath(), detail.GetNginxId(), env.GetSystemUUID())
+			if err != nil {
+				return fmt.Errorf("unable to collect config of NGINX instance %s: %w", detail.GetNginxId(), err)
+			}
+
+			writer := os.Stdout
+			if out != "" {
+				writer, err = os.OpenFile(out, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640)
+				if err != nil {
+					return fmt.Errorf("unable to create output file %s: %w", out, err)
+				}
+				defer writer.Close()
+			}
+
+			switch format {
+			case "json":
+				marshaler := &jsonpb.Marshaler{Indent: "  "}
+				if err := marshaler.Marshal(writer, nginxConfig); err != nil {
+					return err
+				}
+				fmt.Fprintln(writer)
+				return nil
+			case "proto":
+				payload, err := gogoproto.Marshal(nginxConfig)
+				if err != nil {
+					return err
+				}
+				_, err = writer.Write(payload)
+				return err
+			default:
+				return fmt.Errorf("unknown format %q, expected json or proto", format)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&nginxID, "nginx-id", "", "NGINX instance to collect; may be omitted when only one instance is running.")
+	cmd.Flags().StringVar(&format, "format", "json", "Output format, json or proto.")
+	cmd.Flags().StringVarP(&out, "output", "o", "", "File to write the payload to instead of stdout.")
+	return cmd
+}
+
+// selectNginxInstance resolves the --nginx-id flag to a running master,
+// defaulting to the only instance when the flag is omitted
+func selectNginxInstance(env core.Environment, binary core.NginxBinary, nginxID string) (*proto.NginxDetails, error) {
+	var details []*proto.NginxDetails
+	for _, process := range env.Processes() {
+		if !process.IsMaster {
+			continue
+		}
+		if detail := binary.GetNginxDetailsFromProcess(process); detail != nil {
+			details = append(details, detail)
+		}
+	}
+
+	switch {
+	case len(details) == 0:
+		return nil, core.ErrNoNginxInstances
+	case nginxID == "" && len(details) == 1:
+		return details[0], nil
+	case nginxID == "":
+		ids := make([]string, 0, len(details))
+		for _, detail := range details {
+			ids = append(ids, detail.GetNginxId())
+		}
+		return nil, fmt.Errorf("multiple NGINX instances running (%s), specify one with --nginx-id", strings.Join(ids, ", "))
+	}
+
+	for _, detail := range details {
+		if detail.GetNginxId() == nginxID {
+			return detail, nil
+		}
+	}
+	return nil, fmt.Errorf("no running NGINX instance with id %s", nginxID)
+}
+
+// statusCommand is the local verb for checking what the agent is doing, in a
+// stable schema provisioning tools can assert on. It asks the running agent
+// through the Agent API when one is configured; otherwise it reports what can
+// be discovered locally.
+func statusCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the agent version, control plane connectivity and NGINX instance status.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			env := &core.EnvironmentType{}
+			loadedConfig, err := config.GetConfig(env.GetSystemUUID())
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			status := fetchAgentStatus(loadedConfig)
+			if status == nil {
+				binary := core.NewNginxBinary(env, loadedConfig)
+				status = plugins.AgentStatus(version, loadedConfig, env, binary)
+			}
+
+			switch output {
+			case "json":
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(status)
+			case "text":
+				printAgentStatus(status)
+				return nil
+			default:
+				return fmt.Errorf("unknown output format %q, expected text or json", output)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&output, "output", "text", "Output format, text or json.")
+	return cmd
+}
+
+// fetchAgentStatus asks the running agent for its status through the Agent
+// API, returning nil when there is no agent to ask
+func fetchAgentStatus(loadedConfig *config.Config) *plugins.AgentStatusResponse {
+	if loadedConfig.AgentAPI.Port == 0 {
+		return nil
+	}
+
+	scheme := "http"
+	client := &http.Client{Timeout: 5 * time.Second}
+	if loadedConfig.AgentAPI.Cert != "" && loadedConfig.AgentAPI.Key != "" {
+		scheme = "https"
+		// the agent's API certificate is rarely issued for 127.0.0.1
+		client.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+
+	response, err := client.Get(fmt.Sprintf("%s://127.0.0.1:%d/status/", scheme, loadedConfig.AgentAPI.Port))
+	if err != nil {
+		log.Debugf("Unable to query the running agent for status: %v", err)
+		return nil
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		log.Debugf("Unable to query the running agent for status: %s", response.Status)
+		return nil
+	}
+
+	status := &plugins.AgentStatusResponse{}
+	if err := json.NewDecoder(response.Body).Decode(status); err != nil {
+		log.Debugf("Unable to decode the running agent's status: %v", err)
+		return nil
+	}
+	return status
+}
+
+func printAgentStatus(status *plugins.AgentStatusResponse) {
+	fmt.Printf("NGINX Agent %s\n", status.Version)
+	if status.Connectivity.Server == "" {
+		fmt.Println("Control plane: not configured")
+	} else if status.Connectivity.Registered {
+		fmt.Printf("Control plane: %s (registered, last connected %s)\n", status.Connectivity.Server, status.Connectivity.LastConnected)
+	} else {
+		fmt.Printf("Control plane: %s (not registered)\n", status.Connectivity.Server)
+	}
+	if len(status.Features) > 0 {
+		fmt.Printf("Features: %s\n", strings.Join(status.Features, ", "))
+	}
+	if len(status.Extensions) > 0 {
+		fmt.Printf("Extensions: %s\n", strings.Join(status.Extensions, ", "))
+	}
+
+	if len(status.NginxInstances) == 0 {
+		fmt.Println("No NGINX instances found")
+		return
+	}
+	for _, instance := range status.NginxInstances {
+		fmt.Printf("NGINX %s: version %s, pid %s, conf %s", instance.NginxId, instance.Version, instance.ProcessId, instance.ConfPath)
+		if instance.ConfVersion != "" {
+			fmt.Printf(", conf version %s", instance.ConfVersion)
+		}
+		if instance.LastConfigApply != nil {
+			fmt.Printf(", last config apply %s", instance.LastConfigApply.Status)
+		}
+		fmt.Println()
 	}
 }
 
@@ -131,9 +689,12 @@ func handleSignals(
 			}
 
 			log.Warn("NGINX Agent exiting")
+			systemd.NotifyStopping()
 			cancel()
 
-			timeout := time.Second * 5
+			// give the message pipe its full grace period to drain and the
+			// plugins a little extra time to close before forcing the exit
+			timeout := loadedConfig.ShutdownGracePeriod + time.Second*5
 			time.Sleep(timeout)
 			log.Fatalf("Failed to gracefully shutdown within timeout of %v. Exiting", timeout)
 		case <-ctx.Done():
@@ -148,26 +709,47 @@ func createGrpcClients(ctx context.Context, loadedConfig *config.Config) (client
 	}
 
 	grpcDialOptions := setDialOptions(loadedConfig)
-	secureMetricsDialOpts, err := sdkGRPC.SecureDialOptions(
-		loadedConfig.TLS.Enable,
-		loadedConfig.TLS.Cert,
-		loadedConfig.TLS.Key,
-		loadedConfig.TLS.Ca,
-		loadedConfig.Server.Metrics,
-		loadedConfig.TLS.SkipVerify)
-	if err != nil {
-		log.Fatalf("Failed to load secure metric gRPC dial options: %v", err)
-	}
 
-	secureCmdDialOpts, err := sdkGRPC.SecureDialOptions(
-		loadedConfig.TLS.Enable,
-		loadedConfig.TLS.Cert,
-		loadedConfig.TLS.Key,
-		loadedConfig.TLS.Ca,
-		loadedConfig.Server.Command,
-		loadedConfig.TLS.SkipVerify)
-	if err != nil {
-		log.Fatalf("Failed to load secure command gRPC dial options: %v", err)
+	var secureMetricsDialOpts, secureCmdDialOpts grpc.DialOption
+	var err error
+
+	if loadedConfig.TLS.Enable && loadedConfig.TLS.SpiffeSocket != "" {
+		spiffeSource, spiffeErr := sdkGRPC.NewSPIFFESource(ctx, loadedConfig.TLS.SpiffeSocket)
+		if spiffeErr != nil {
+			log.Fatalf("Failed to create SPIFFE workload identity source: %v", spiffeErr)
+		}
+
+		secureMetricsDialOpts, err = sdkGRPC.SecureSPIFFEDialOptions(spiffeSource, loadedConfig.Server.Metrics, loadedConfig.TLS.SkipVerify)
+		if err != nil {
+			log.Fatalf("Failed to load secure metric gRPC dial options: %v", err)
+		}
+
+		secureCmdDialOpts, err = sdkGRPC.SecureSPIFFEDialOptions(spiffeSource, loadedConfig.Server.Command, loadedConfig.TLS.SkipVerify)
+		if err != nil {
+			log.Fatalf("Failed to load secure command gRPC dial options: %v", err)
+		}
+	} else {
+		secureMetricsDialOpts, err = sdkGRPC.SecureDialOptions(
+			loadedConfig.TLS.Enable,
+			loadedConfig.TLS.Cert,
+			loadedConfig.TLS.Key,
+			loadedConfig.TLS.Ca,
+			loadedConfig.Server.Metrics,
+			loadedConfig.TLS.SkipVerify)
+		if err != nil {
+			log.Fatalf("Failed to load secure metric gRPC dial options: %v", err)
+		}
+
+		secureCmdDialOpts, err = sdkGRPC.SecureDialOptions(
+			loadedConfig.TLS.Enable,
+			loadedConfig.TLS.Cert,
+			loadedConfig.TLS.Key,
+			loadedConfig.TLS.Ca,
+			loadedConfig.Server.Command,
+			loadedConfig.TLS.SkipVerify)
+		if err != nil {
+			log.Fatalf("Failed to load secure command gRPC dial options: %v", err)
+		}
 	}
 
 	controller := client.NewClientController()
@@ -190,9 +772,16 @@ func createGrpcClients(ctx context.Context, loadedConfig *config.Config) (client
 func loadPlugins(commander client.Commander, binary *core.NginxBinaryType, env *core.EnvironmentType, reporter client.MetricReporter, loadedConfig *config.Config) []core.Plugin {
 	var corePlugins []core.Plugin
 
+	// a single budget is shared by everything that sends, so the priorities
+	// hold across plugins
+	var bandwidthBudget *core.BandwidthBudget
+	if loadedConfig.BandwidthBudget.Enable {
+		bandwidthBudget = core.NewBandwidthBudget(int64(loadedConfig.BandwidthBudget.BytesPerInterval), loadedConfig.BandwidthBudget.Interval)
+	}
+
 	if commander != nil {
 		corePlugins = append(corePlugins,
-			plugins.NewCommander(commander, loadedConfig),
+			plugins.NewCommander(commander, loadedConfig, bandwidthBudget),
 			plugins.NewFileWatcher(loadedConfig, env),
 			plugins.NewFileWatchThrottle(),
 		)
@@ -200,12 +789,17 @@ func loadPlugins(commander client.Commander, binary *core.NginxBinaryType, env *
 
 	if reporter != nil {
 		corePlugins = append(corePlugins,
-			plugins.NewMetricsSender(reporter),
+			plugins.NewMetricsSender(reporter, loadedConfig, bandwidthBudget),
 		)
 	}
 
+	if commander != nil && loadedConfig.Failover.Enable && len(loadedConfig.Failover.Servers) > 0 {
+		corePlugins = append(corePlugins, plugins.NewFailover(loadedConfig, commander, reporter))
+	}
+
 	corePlugins = append(corePlugins,
 		plugins.NewConfigReader(loadedConfig),
+		plugins.NewConfigReloader(loadedConfig, env),
 		plugins.NewNginx(commander, binary, env, loadedConfig),
 		plugins.NewOneTimeRegistration(loadedConfig, binary, env, sdkGRPC.NewMessageMeta(uuid.NewString()), version),
 		plugins.NewMetrics(loadedConfig, env, binary),
@@ -214,14 +808,88 @@ func loadPlugins(commander client.Commander, binary *core.NginxBinaryType, env *
 		plugins.NewProcessWatcher(env, binary),
 		plugins.NewExtensions(loadedConfig, env),
 		plugins.NewEvents(loadedConfig, env, sdkGRPC.NewMessageMeta(uuid.NewString()), binary),
+		plugins.NewCertExpiryMonitor(loadedConfig, env, binary, sdkGRPC.NewMessageMeta(uuid.NewString())),
+		plugins.NewResolverMonitor(loadedConfig, env, binary, sdkGRPC.NewMessageMeta(uuid.NewString())),
+		plugins.NewSyntheticChecks(loadedConfig, env),
+		plugins.NewCrashLoopMonitor(loadedConfig, env, binary, sdkGRPC.NewMessageMeta(uuid.NewString())),
+		plugins.NewCertDeploy(loadedConfig, env, binary),
+		plugins.NewTLSPosture(loadedConfig, env, sdkGRPC.NewMessageMeta(uuid.NewString())),
+		plugins.NewConfigConflicts(loadedConfig, env, sdkGRPC.NewMessageMeta(uuid.NewString())),
+		plugins.NewDebugCapture(loadedConfig, env, binary, sdkGRPC.NewMessageMeta(uuid.NewString())),
+		plugins.NewKeyvalSync(loadedConfig, env, binary),
+		plugins.NewUpstreamDrain(loadedConfig, env, binary),
+		plugins.NewTrafficMirror(loadedConfig, env, binary),
+		plugins.NewUpstreamSwitch(loadedConfig, env, binary),
+		plugins.NewAgentUpdater(loadedConfig, env, version),
+		plugins.NewCollectionBackoff(loadedConfig, env, sdkGRPC.NewMessageMeta(uuid.NewString())),
+		plugins.NewDiskBudget(loadedConfig, env, sdkGRPC.NewMessageMeta(uuid.NewString())),
 	)
 
 	if loadedConfig.AgentAPI.Port != 0 {
-		corePlugins = append(corePlugins, plugins.NewAgentAPI(loadedConfig, env, binary))
+		corePlugins = append(corePlugins, plugins.NewAgentAPI(loadedConfig, env, binary, version))
 	} else {
 		log.Info("Agent API not configured")
 	}
 
+	if loadedConfig.ErrorLogEvents.Enable {
+		corePlugins = append(corePlugins, plugins.NewErrorLogTailer(loadedConfig, env, binary, sdkGRPC.NewMessageMeta(uuid.NewString())))
+	}
+
+	if loadedConfig.LogForwarding.Enable {
+		forwarder, err := plugins.NewLogForwarder(loadedConfig, env, binary, bandwidthBudget)
+		if err != nil {
+			log.Errorf("Unable to load the Log Forwarder plugin due to the following error: %v", err)
+		} else {
+			corePlugins = append(corePlugins, forwarder)
+		}
+	}
+
+	if loadedConfig.Docker.Enable {
+		dockerDiscovery, err := plugins.NewDockerDiscovery(loadedConfig, env)
+		if err != nil {
+			log.Errorf("Unable to load the Docker Discovery plugin due to the following error: %v", err)
+		} else {
+			corePlugins = append(corePlugins, dockerDiscovery)
+		}
+	}
+
+	if loadedConfig.Consul.Enable {
+		corePlugins = append(corePlugins, plugins.NewConsulRegistration(loadedConfig, env, binary))
+	}
+
+	if loadedConfig.Supervisor.Enable {
+		corePlugins = append(corePlugins, plugins.NewSupervisor(loadedConfig))
+	}
+
+	if loadedConfig.Export.Enable {
+		export, err := plugins.NewExport(loadedConfig)
+		if err != nil {
+			log.Errorf("Unable to load the Export plugin due to the following error: %v", err)
+		} else {
+			corePlugins = append(corePlugins, export)
+		}
+	}
+
+	if loadedConfig.Webhook.Enable {
+		corePlugins = append(corePlugins, plugins.NewWebhook(loadedConfig, env))
+	}
+
+	if loadedConfig.SNMP.Enable {
+		corePlugins = append(corePlugins, plugins.NewSNMP(loadedConfig))
+	}
+
+	if loadedConfig.Unit.Enable {
+		corePlugins = append(corePlugins, plugins.NewUnit(loadedConfig, env))
+	}
+
+	if loadedConfig.ObjectStore.Enable {
+		corePlugins = append(corePlugins, plugins.NewObjectStore(loadedConfig, env, binary))
+	}
+
+	if loadedConfig.Acme.Enabled {
+		corePlugins = append(corePlugins, plugins.NewAcme(loadedConfig, env, binary))
+	}
+
 	if len(loadedConfig.Nginx.NginxCountingSocket) > 0 {
 		corePlugins = append(corePlugins, plugins.NewNginxCounter(loadedConfig, binary, env))
 	}
@@ -251,8 +919,11 @@ func loadPlugins(commander client.Commander, binary *core.NginxBinaryType, env *
 	return corePlugins
 }
 
-func initializeMessagePipe(ctx context.Context, corePlugins []core.Plugin) core.MessagePipeInterface {
+func initializeMessagePipe(ctx context.Context, corePlugins []core.Plugin, loadedConfig *config.Config) core.MessagePipeInterface {
 	pipe := core.NewMessagePipe(ctx)
+	if loadedConfig.ShutdownGracePeriod > 0 {
+		pipe.SetDrainTimeout(loadedConfig.ShutdownGracePeriod)
+	}
 	err := pipe.Register(DEFAULT_PLUGIN_SIZE, corePlugins...)
 	if err != nil {
 		log.Warnf("Failed to start agent successfully, error loading plugins %v", err)
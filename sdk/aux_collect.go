@@ -0,0 +1,149 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/nginx/agent/sdk/v2/zip"
+)
+
+// defaultAuxFileIOWorkers is how many aux files are read concurrently while a
+// config payload is collected, unless SetAuxFileIOWorkers was called
+const defaultAuxFileIOWorkers = 4
+
+var (
+	auxFileIOWorkers      = defaultAuxFileIOWorkers
+	auxFileIOWorkersMutex sync.RWMutex
+)
+
+// SetAuxFileIOWorkers configures how many aux files are statted and read
+// concurrently while a config payload is collected. Values below one restore
+// the default.
+func SetAuxFileIOWorkers(workers int) {
+	if workers < 1 {
+		workers = defaultAuxFileIOWorkers
+	}
+	auxFileIOWorkersMutex.Lock()
+	auxFileIOWorkers = workers
+	auxFileIOWorkersMutex.Unlock()
+}
+
+func getAuxFileIOWorkers() int {
+	auxFileIOWorkersMutex.RLock()
+	defer auxFileIOWorkersMutex.RUnlock()
+	return auxFileIOWorkers
+}
+
+// auxFileJob carries one file through the collection pipeline: the walk fills
+// in the path and info, a worker reads the contents, and the caller consumes
+// the jobs back in walk order
+type auxFileJob struct {
+	path     string
+	info     fs.FileInfo
+	contents []byte
+	err      error
+	done     chan struct{}
+}
+
+// collectAuxFiles appends the given files to the directory map and the aux
+// writer. The file reads are spread over a bounded number of workers, since
+// aux payloads can hold thousands of small files, while the directory map and
+// the compressed payload are still built in the order given so the output
+// stays deterministic. The bounded hand-off channels also cap how many file
+// contents are held in memory at once.
+func collectAuxFiles(paths []string, infos []fs.FileInfo, aux *zip.Writer, directoryMap *DirectoryMap, warnings CollectionWarnings) error {
+	workers := getAuxFileIOWorkers()
+	if workers > len(paths) {
+		workers = len(paths)
+	}
+	if workers == 0 {
+		return nil
+	}
+
+	ordered := make(chan *auxFileJob, workers)
+	work := make(chan *auxFileJob, workers)
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+
+	go func() {
+		defer close(ordered)
+		defer close(work)
+		for i := range paths {
+			job := &auxFileJob{path: paths[i], info: infos[i], done: make(chan struct{})}
+			select {
+			case ordered <- job:
+			case <-stop:
+				return
+			}
+			select {
+			case work <- job:
+			case <-stop:
+				// the job is already queued for the consumer but will never
+				// reach a worker, so release it here
+				close(job.done)
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range work {
+				select {
+				case <-stop:
+					// the consumer already failed, so just release the job
+				default:
+					job.contents, job.err = os.ReadFile(job.path)
+				}
+				close(job.done)
+			}
+		}()
+	}
+
+	var firstErr error
+	for job := range ordered {
+		<-job.done
+		if firstErr != nil {
+			continue
+		}
+		// an unreadable file is skipped and recorded, it does not fail the
+		// collection
+		if job.err != nil {
+			warnings[job.path] = job.err.Error()
+			job.contents = nil
+			continue
+		}
+		if err := appendAuxFile(job, aux, directoryMap); err != nil {
+			firstErr = err
+			stopOnce.Do(func() { close(stop) })
+		}
+		job.contents = nil
+	}
+	return firstErr
+}
+
+func appendAuxFile(job *auxFileJob, aux *zip.Writer, directoryMap *DirectoryMap) error {
+	if job.err != nil {
+		return fmt.Errorf("could read file(%s): %s", job.path, job.err)
+	}
+
+	if err := directoryMap.appendFile(filepath.Dir(job.path), job.info); err != nil {
+		return err
+	}
+
+	if err := aux.Add(job.path, job.info.Mode(), bytes.NewReader(job.contents)); err != nil {
+		return fmt.Errorf("adding auxillary file error: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/zip"
+)
+
+// writeAuxTestFiles creates count small files in dir and returns their paths
+// and infos in name order
+func writeAuxTestFiles(t *testing.T, dir string, count int) ([]string, []fs.FileInfo) {
+	t.Helper()
+	paths := []string{}
+	infos := []fs.FileInfo{}
+	for i := 0; i < count; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("policy-%03d.json", i))
+		require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf(`{"policy":%d}`, i)), 0644))
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		paths = append(paths, path)
+		infos = append(infos, info)
+	}
+	return paths, infos
+}
+
+func collectAuxPayload(t *testing.T, dir string, paths []string, infos []fs.FileInfo) ([]byte, []string) {
+	t.Helper()
+	aux, err := zip.NewWriter(dir)
+	require.NoError(t, err)
+	require.NoError(t, collectAuxFiles(paths, infos, aux, newDirectoryMap(), CollectionWarnings{}))
+
+	payload, err := aux.Proto()
+	require.NoError(t, err)
+	files, err := zip.UnPack(payload)
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, file := range files {
+		names = append(names, file.GetName())
+	}
+	return payload.GetContents(), names
+}
+
+func TestCollectAuxFilesDeterministic(t *testing.T) {
+	dir := t.TempDir()
+	paths, infos := writeAuxTestFiles(t, dir, 50)
+
+	contents, names := collectAuxPayload(t, dir, paths, infos)
+	assert.Equal(t, paths, names)
+
+	// the reads are concurrent, but the payload must come out identical run to run
+	for i := 0; i < 5; i++ {
+		repeatContents, repeatNames := collectAuxPayload(t, dir, paths, infos)
+		assert.Equal(t, names, repeatNames)
+		assert.Equal(t, contents, repeatContents)
+	}
+}
+
+func TestCollectAuxFilesReadError(t *testing.T) {
+	dir := t.TempDir()
+	paths, infos := writeAuxTestFiles(t, dir, 10)
+
+	missing := paths[4]
+	require.NoError(t, os.Remove(missing))
+
+	aux, err := zip.NewWriter(dir)
+	require.NoError(t, err)
+	warnings := CollectionWarnings{}
+	err = collectAuxFiles(paths, infos, aux, newDirectoryMap(), warnings)
+
+	// the unreadable file is skipped with a warning, the rest is collected
+	require.NoError(t, err)
+	assert.Contains(t, warnings, missing)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, len(paths)-1, aux.FileLen())
+}
+
+func TestSetAuxFileIOWorkers(t *testing.T) {
+	defer SetAuxFileIOWorkers(defaultAuxFileIOWorkers)
+
+	SetAuxFileIOWorkers(16)
+	assert.Equal(t, 16, getAuxFileIOWorkers())
+
+	// values below one restore the default
+	SetAuxFileIOWorkers(0)
+	assert.Equal(t, defaultAuxFileIOWorkers, getAuxFileIOWorkers())
+}
@@ -25,6 +25,19 @@ func WaitUntil(
 	maxInterval time.Duration,
 	maxElapsedTime time.Duration,
 	operation backoff.Operation,
+) error {
+	return WaitUntilWithClock(ctx, NewRealClock(), initialInterval, maxInterval, maxElapsedTime, operation)
+}
+
+// WaitUntilWithClock is WaitUntil with the time source injected, so tests can
+// drive the retry schedule deterministically instead of sleeping through it.
+func WaitUntilWithClock(
+	ctx context.Context,
+	clock Clock,
+	initialInterval time.Duration,
+	maxInterval time.Duration,
+	maxElapsedTime time.Duration,
+	operation backoff.Operation,
 ) error {
 	exponentialBackoff := backoff.NewExponentialBackOff()
 	exponentialBackoff.InitialInterval = initialInterval
@@ -32,13 +45,32 @@ func WaitUntil(
 	exponentialBackoff.MaxElapsedTime = maxElapsedTime
 	exponentialBackoff.RandomizationFactor = BACKOFF_JITTER
 	exponentialBackoff.Multiplier = BACKOFF_MULTIPLIER
+	exponentialBackoff.Clock = clock
+	exponentialBackoff.Reset()
 
 	expoBackoffWithContext := backoff.WithContext(exponentialBackoff, ctx)
 
-	err := backoff.Retry(backoff.Operation(operation), expoBackoffWithContext)
+	err := backoff.RetryNotifyWithTimer(backoff.Operation(operation), expoBackoffWithContext, nil, &clockTimer{clock: clock})
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// clockTimer adapts a Clock to the timer the backoff library waits on between
+// attempts.
+type clockTimer struct {
+	clock Clock
+	ch    <-chan time.Time
+}
+
+func (t *clockTimer) Start(duration time.Duration) {
+	t.ch = t.clock.After(duration)
+}
+
+func (t *clockTimer) Stop() {}
+
+func (t *clockTimer) C() <-chan time.Time {
+	return t.ch
+}
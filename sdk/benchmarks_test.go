@@ -0,0 +1,79 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeBenchmarkConfig lays out a representative dataplane config: a conf file
+// with a handful of server blocks and a root directory holding many small
+// NAP-style json files that end up in the aux payload.
+func writeBenchmarkConfig(b *testing.B, serverCount, auxFileCount int) (confFile string, allowedDirs map[string]struct{}) {
+	b.Helper()
+	dir := b.TempDir()
+
+	rootDir := filepath.Join(dir, "html")
+	if err := os.MkdirAll(rootDir, 0755); err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < auxFileCount; i++ {
+		path := filepath.Join(rootDir, fmt.Sprintf("policy-%04d.json", i))
+		if err := os.WriteFile(path, []byte(fmt.Sprintf(`{"policy":%d}`, i)), 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	conf := "events {}\nhttp {\n"
+	for i := 0; i < serverCount; i++ {
+		conf += fmt.Sprintf(`    server {
+        listen 9%03d;
+        server_name server-%d.example.com;
+        access_log %s/access-%d.log;
+        location / {
+            root %s;
+        }
+    }
+`, i, i, dir, i, rootDir)
+	}
+	conf += "}\n"
+
+	confFile = filepath.Join(dir, "nginx.conf")
+	if err := os.WriteFile(confFile, []byte(conf), 0644); err != nil {
+		b.Fatal(err)
+	}
+
+	return confFile, map[string]struct{}{dir: {}}
+}
+
+func BenchmarkGetNginxConfig(b *testing.B) {
+	confFile, allowedDirs := writeBenchmarkConfig(b, 10, 500)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := GetNginxConfig(confFile, "b1", "s1", allowedDirs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetServerNames(b *testing.B) {
+	confFile, _ := writeBenchmarkConfig(b, 50, 0)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := GetServerNames(confFile); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -8,13 +8,72 @@
 package sdk
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+const (
+	// RevocationStatusGood indicates the certificate was confirmed as not revoked via OCSP or CRL
+	RevocationStatusGood = "good"
+	// RevocationStatusRevoked indicates the certificate was reported as revoked via OCSP or CRL
+	RevocationStatusRevoked = "revoked"
+	// RevocationStatusUnknown indicates the revocation status could not be determined, e.g. no responder was reachable
+	RevocationStatusUnknown = "unknown"
+
+	// ChainValidationStatusValid indicates the presented chain verified against the trusted roots
+	ChainValidationStatusValid = "valid"
+
+	revocationCheckTimeout = 2 * time.Second
+
+	// certificateValidationCacheTTL bounds how long a validation result is reused
+	// before the OCSP/CRL endpoints are consulted again
+	certificateValidationCacheTTL = 5 * time.Minute
+)
+
+var revocationHTTPClient = &http.Client{Timeout: revocationCheckTimeout}
+
+// certificateCache caches parsed certificates by file path, keyed on modification
+// time and size, so a certificate referenced from many server blocks and instances
+// is only re-read and re-parsed when it changes on disk.
+var (
+	certificateCacheMutex sync.Mutex
+	certificateCache      = make(map[string]*certificateCacheEntry)
+)
+
+type certificateCacheEntry struct {
+	modTime time.Time
+	size    int64
+	cert    *x509.Certificate
+}
+
+// certificateValidationCache caches chain validation and revocation results by
+// certificate fingerprint, so the same certificate is not re-validated against the
+// responder endpoints on every config scan.
+var (
+	certificateValidationCacheMutex sync.Mutex
+	certificateValidationCache      = make(map[string]*certificateValidationCacheEntry)
 )
 
+type certificateValidationCacheEntry struct {
+	expires          time.Time
+	chainValidation  string
+	revocationStatus string
+}
+
 func LoadCertificates(certPath, keyPath string) (*tls.Certificate, *x509.CertPool, error) {
 	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
 	if err != nil {
@@ -32,7 +91,271 @@ func LoadCertificates(certPath, keyPath string) (*tls.Certificate, *x509.CertPoo
 	return &cert, pool, nil
 }
 
+// LoadCertificateChain reads every PEM encoded certificate from the file at certPath.
+// The first certificate is expected to be the leaf, any remaining certificates are
+// treated as the presented intermediate chain.
+func LoadCertificateChain(certPath string) ([]*x509.Certificate, error) {
+	fileContents, err := ioutil.ReadFile(certPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []*x509.Certificate
+	for len(fileContents) > 0 {
+		var block *pem.Block
+		block, fileContents = pem.Decode(fileContents)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("could not decode: cert was not PEM format")
+	}
+
+	return certs, nil
+}
+
+// UpdateCertificateValidation validates the certificate chain presented in the file
+// referenced by certProto against the system roots and the provided CA bundle files,
+// and checks OCSP/CRL revocation when a responder is reachable. The result is recorded
+// on the SslCertificate proto.
+func UpdateCertificateValidation(certProto *proto.SslCertificate, caBundles []string) {
+	UpdateCertificateValidationWithContext(context.Background(), certProto, caBundles)
+}
+
+// UpdateCertificateValidationWithContext is UpdateCertificateValidation honoring
+// cancellation of ctx: pending OCSP and CRL requests are abandoned once ctx is done
+func UpdateCertificateValidationWithContext(ctx context.Context, certProto *proto.SslCertificate, caBundles []string) {
+	fingerprint := certProto.GetFingerprint()
+	if fingerprint != "" {
+		certificateValidationCacheMutex.Lock()
+		entry, ok := certificateValidationCache[fingerprint]
+		certificateValidationCacheMutex.Unlock()
+		if ok && time.Now().Before(entry.expires) {
+			certProto.ChainValidationStatus = entry.chainValidation
+			certProto.RevocationStatus = entry.revocationStatus
+			return
+		}
+	}
+
+	validateCertificate(ctx, certProto, caBundles)
+
+	if fingerprint != "" {
+		certificateValidationCacheMutex.Lock()
+		certificateValidationCache[fingerprint] = &certificateValidationCacheEntry{
+			expires:          time.Now().Add(certificateValidationCacheTTL),
+			chainValidation:  certProto.GetChainValidationStatus(),
+			revocationStatus: certProto.GetRevocationStatus(),
+		}
+		certificateValidationCacheMutex.Unlock()
+	}
+}
+
+func validateCertificate(ctx context.Context, certProto *proto.SslCertificate, caBundles []string) {
+	certProto.RevocationStatus = RevocationStatusUnknown
+
+	chain, err := LoadCertificateChain(certProto.GetFileName())
+	if err != nil {
+		certProto.ChainValidationStatus = err.Error()
+		return
+	}
+	leaf := chain[0]
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	for _, bundle := range caBundles {
+		if pemBytes, readErr := ioutil.ReadFile(bundle); readErr == nil {
+			roots.AppendCertsFromPEM(pemBytes)
+		}
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	verifiedChains, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		certProto.ChainValidationStatus = err.Error()
+		return
+	}
+	certProto.ChainValidationStatus = ChainValidationStatusValid
+
+	var issuer *x509.Certificate
+	if len(verifiedChains) > 0 && len(verifiedChains[0]) > 1 {
+		issuer = verifiedChains[0][1]
+	}
+	certProto.RevocationStatus = checkRevocation(ctx, leaf, issuer)
+}
+
+// subjectAltNames returns the DNS names and IP addresses of the certificate, falling
+// back to the subject common name when no SAN extension is present.
+func subjectAltNames(cert *x509.Certificate) []string {
+	names := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	if len(names) == 0 && cert.Subject.CommonName != "" {
+		names = append(names, cert.Subject.CommonName)
+	}
+	return names
+}
+
+// extendedKeyUsages returns the string representation of the extended key usages of
+// the certificate.
+func extendedKeyUsages(cert *x509.Certificate) []string {
+	usageNames := map[x509.ExtKeyUsage]string{
+		x509.ExtKeyUsageAny:             "Any",
+		x509.ExtKeyUsageServerAuth:      "ServerAuth",
+		x509.ExtKeyUsageClientAuth:      "ClientAuth",
+		x509.ExtKeyUsageCodeSigning:     "CodeSigning",
+		x509.ExtKeyUsageEmailProtection: "EmailProtection",
+		x509.ExtKeyUsageTimeStamping:    "TimeStamping",
+		x509.ExtKeyUsageOCSPSigning:     "OCSPSigning",
+	}
+
+	usages := []string{}
+	for _, usage := range cert.ExtKeyUsage {
+		if name, ok := usageNames[usage]; ok {
+			usages = append(usages, name)
+		}
+	}
+	return usages
+}
+
+// certificateCoversNames returns true if every concrete server name is matched by one
+// of the certificate's subject alternative names, honouring wildcard names. Default,
+// variable and regular expression server names are ignored.
+func certificateCoversNames(certProto *proto.SslCertificate, serverNames []string) bool {
+	for _, serverName := range serverNames {
+		if serverName == "_" || strings.HasPrefix(serverName, "$") || strings.HasPrefix(serverName, "~") {
+			continue
+		}
+		if !certificateMatchesName(certProto.GetSubjAltNames(), serverName) {
+			return false
+		}
+	}
+	return true
+}
+
+func certificateMatchesName(subjAltNames []string, serverName string) bool {
+	for _, san := range subjAltNames {
+		if strings.EqualFold(san, serverName) {
+			return true
+		}
+		if strings.HasPrefix(san, "*.") {
+			if dot := strings.Index(serverName, "."); dot > 0 && strings.EqualFold(san[1:], serverName[dot:]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkRevocation determines the revocation status of leaf via OCSP, falling back to
+// the CRL distribution points. An unreachable responder results in an unknown status
+// rather than an error.
+func checkRevocation(ctx context.Context, leaf, issuer *x509.Certificate) string {
+	if issuer != nil && len(leaf.OCSPServer) > 0 {
+		if status := checkOCSP(ctx, leaf, issuer); status != RevocationStatusUnknown {
+			return status
+		}
+	}
+	return checkCRL(ctx, leaf)
+}
+
+func checkOCSP(ctx context.Context, leaf, issuer *x509.Certificate) string {
+	request, err := ocsp.CreateRequest(leaf, issuer, nil)
+	if err != nil {
+		return RevocationStatusUnknown
+	}
+
+	for _, server := range leaf.OCSPServer {
+		httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, server, bytes.NewReader(request))
+		if err != nil {
+			continue
+		}
+		httpRequest.Header.Set("Content-Type", "application/ocsp-request")
+		resp, err := revocationHTTPClient.Do(httpRequest)
+		if err != nil {
+			continue
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			continue
+		}
+		response, err := ocsp.ParseResponseForCert(body, leaf, issuer)
+		if err != nil {
+			continue
+		}
+		switch response.Status {
+		case ocsp.Good:
+			return RevocationStatusGood
+		case ocsp.Revoked:
+			return RevocationStatusRevoked
+		}
+	}
+	return RevocationStatusUnknown
+}
+
+func checkCRL(ctx context.Context, leaf *x509.Certificate) string {
+	for _, crlURL := range leaf.CRLDistributionPoints {
+		httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, crlURL, nil)
+		if err != nil {
+			continue
+		}
+		resp, err := revocationHTTPClient.Do(httpRequest)
+		if err != nil {
+			continue
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			continue
+		}
+		crl, err := x509.ParseCRL(body)
+		if err != nil {
+			continue
+		}
+		for _, revoked := range crl.TBSCertList.RevokedCertificates {
+			if revoked.SerialNumber.Cmp(leaf.SerialNumber) == 0 {
+				return RevocationStatusRevoked
+			}
+		}
+		return RevocationStatusGood
+	}
+	return RevocationStatusUnknown
+}
+
 func LoadCertificate(certPath string) (*x509.Certificate, error) {
+	info, err := os.Stat(certPath)
+	if err != nil {
+		return nil, err
+	}
+
+	certificateCacheMutex.Lock()
+	entry, ok := certificateCache[certPath]
+	certificateCacheMutex.Unlock()
+	if ok && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+		return entry.cert, nil
+	}
+
 	fileContents, err := ioutil.ReadFile(certPath)
 	if err != nil {
 		return nil, err
@@ -48,5 +371,13 @@ func LoadCertificate(certPath string) (*x509.Certificate, error) {
 		return nil, err
 	}
 
+	certificateCacheMutex.Lock()
+	certificateCache[certPath] = &certificateCacheEntry{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		cert:    cert,
+	}
+	certificateCacheMutex.Unlock()
+
 	return cert, nil
 }
@@ -19,6 +19,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/nginx/agent/sdk/v2/proto"
 )
 
 func TestLoadCertificates(t *testing.T) {
@@ -107,6 +109,91 @@ func TestLoadCertificates(t *testing.T) {
 	}
 }
 
+func TestUpdateCertificateValidation(t *testing.T) {
+	tmpDir := t.TempDir()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed generate key, %v", err)
+	}
+
+	tml := x509.Certificate{
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(5, 0, 0),
+		SerialNumber: big.NewInt(123123),
+		Subject: pkix.Name{
+			CommonName:   "New Name",
+			Organization: []string{"New Org."},
+		},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, &tml, &tml, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed create cert, %v", err)
+	}
+
+	certPem := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: cert,
+	})
+
+	certFile := tmpDir + "/cert.pem"
+	err = os.WriteFile(certFile, certPem, 0644)
+	if err != nil {
+		t.Fatalf("Failed create cert file, %v", err)
+	}
+
+	t.Run("self signed cert is not trusted by system roots", func(t *testing.T) {
+		certProto := &proto.SslCertificate{FileName: certFile}
+		UpdateCertificateValidation(certProto, []string{})
+		assert.NotEqual(t, ChainValidationStatusValid, certProto.ChainValidationStatus)
+		assert.NotEmpty(t, certProto.ChainValidationStatus)
+		assert.Equal(t, RevocationStatusUnknown, certProto.RevocationStatus)
+	})
+
+	t.Run("cert is valid when its issuer is a configured CA bundle", func(t *testing.T) {
+		certProto := &proto.SslCertificate{FileName: certFile}
+		UpdateCertificateValidation(certProto, []string{certFile})
+		assert.Equal(t, ChainValidationStatusValid, certProto.ChainValidationStatus)
+		// no OCSP responder or CRL distribution point is reachable for the cert
+		assert.Equal(t, RevocationStatusUnknown, certProto.RevocationStatus)
+	})
+
+	t.Run("missing cert file reports the error", func(t *testing.T) {
+		certProto := &proto.SslCertificate{FileName: "/invalid/cert.pem"}
+		UpdateCertificateValidation(certProto, []string{})
+		assert.NotEqual(t, ChainValidationStatusValid, certProto.ChainValidationStatus)
+		assert.Equal(t, RevocationStatusUnknown, certProto.RevocationStatus)
+	})
+}
+
+func TestCertificateCoversNames(t *testing.T) {
+	certProto := &proto.SslCertificate{
+		SubjAltNames: []string{"example.com", "*.example.net"},
+	}
+
+	testCases := []struct {
+		testName    string
+		serverNames []string
+		expected    bool
+	}{
+		{"exact match", []string{"example.com"}, true},
+		{"wildcard match", []string{"www.example.net"}, true},
+		{"wildcard does not cover bare domain", []string{"example.net"}, false},
+		{"uncovered name", []string{"example.org"}, false},
+		{"partially covered", []string{"example.com", "example.org"}, false},
+		{"default and regex names are ignored", []string{"_", "~^www", "$hostname"}, true},
+		{"no server names", []string{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, certificateCoversNames(certProto, tc.serverNames))
+		})
+	}
+}
+
 func TestLoadCertificate(t *testing.T) {
 	tmpDir := t.TempDir()
 	key, err := rsa.GenerateKey(rand.Reader, 2048)
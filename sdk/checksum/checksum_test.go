@@ -11,6 +11,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/nginx/agent/sdk/v2/internal/perf"
 )
 
 func TestChecksum(t *testing.T) {
@@ -92,3 +94,38 @@ func TestHexChunk(t *testing.T) {
 		assert.Equal(t, test.expected, result)
 	}
 }
+
+func BenchmarkChecksum(b *testing.B) {
+	payload := make([]byte, 32*1024)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(payload)))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		Checksum(payload)
+	}
+}
+
+func BenchmarkHexChecksum(b *testing.B) {
+	payload := make([]byte, 32*1024)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(payload)))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		HexChecksum(payload)
+	}
+}
+
+func TestHexChecksumAllocBudget(t *testing.T) {
+	payload := make([]byte, 32*1024)
+	perf.RequireAllocBudget(t, "HexChecksum", 8, func() {
+		HexChecksum(payload)
+	})
+}
@@ -28,8 +28,16 @@ import (
 
 const (
 	DefaultChunkSize = 4 * 1024
+
+	// maxDownloadPayloadSize bounds the reassembled download body so a
+	// misbehaving control plane cannot exhaust the agent's memory
+	maxDownloadPayloadSize = 128 * 1024 * 1024
 )
 
+// NewCommanderClient returns an unconfigured commander client set up through
+// the chained With* setters.
+//
+// Deprecated: use NewCommander with functional options instead.
 func NewCommanderClient() Commander {
 	return &commander{
 		recvChan:        make(chan Message, 1),
@@ -193,6 +201,9 @@ func (c *commander) Download(ctx context.Context, metadata *proto.Metadata) (*pr
 				header = dataChunk
 			case *proto.DataChunk_Data:
 				body = append(body, dataChunk.Data.Data...)
+				if len(body) > maxDownloadPayloadSize {
+					return ErrDownloadPayloadTooLarge
+				}
 			case nil:
 				break LOOP
 			}
@@ -12,6 +12,10 @@ import (
 	"fmt"
 )
 
+// NewClientController returns an empty controller set up through the chained
+// With* setters.
+//
+// Deprecated: use NewController with a context and clients instead.
 func NewClientController() Controller {
 	return &ctrl{}
 }
@@ -11,12 +11,15 @@ import (
 	"errors"
 
 	"github.com/cenkalti/backoff/v4"
+
+	"github.com/nginx/agent/sdk/v2"
 )
 
 var (
 	ErrDownloadHeaderUnexpectedNumber = &backoff.PermanentError{Err: errors.New("unexpected number of headers")}
 	ErrDownloadChecksumMismatch       = &backoff.PermanentError{Err: errors.New("download checksum mismatch")}
 	ErrDownloadDataChunkNoData        = &backoff.PermanentError{Err: errors.New("download DataChunk without data")}
+	ErrDownloadPayloadTooLarge        = &backoff.PermanentError{Err: sdk.ErrTooLarge}
 	ErrNotConnected                   = &backoff.PermanentError{Err: errors.New("not connected")}
 	ErrUnmarshallingData              = &backoff.PermanentError{Err: errors.New("unable to unmarshal data")}
 )
@@ -0,0 +1,28 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nginx/agent/sdk/v2"
+)
+
+func TestDownloadPayloadTooLargeError(t *testing.T) {
+	// the reporting layer branches on the sdk sentinel, the retry loop on the
+	// backoff permanent error wrapper
+	assert.True(t, errors.Is(ErrDownloadPayloadTooLarge, sdk.ErrTooLarge))
+
+	var permanent *backoff.PermanentError
+	assert.True(t, errors.As(ErrDownloadPayloadTooLarge, &permanent))
+	assert.ErrorIs(t, permanent.Err, sdk.ErrTooLarge)
+}
@@ -0,0 +1,192 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/nginx/agent/sdk/v2/interceptors"
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+// FakeCommander is a function-field fake of the Commander interface for
+// programs embedding the SDK. Every behaviour can be overridden by assigning
+// the matching *Func field; unset fields are no-ops returning zero values.
+type FakeCommander struct {
+	ConnectFunc  func(ctx context.Context) error
+	CloseFunc    func() error
+	SendFunc     func(ctx context.Context, message Message) error
+	DownloadFunc func(ctx context.Context, meta *proto.Metadata) (*proto.NginxConfig, error)
+	UploadFunc   func(ctx context.Context, cfg *proto.NginxConfig, messageID string) error
+	RecvChan     chan Message
+
+	server          string
+	dialOptions     []grpc.DialOption
+	chunkSize       int
+	backoffSettings BackoffSettings
+}
+
+var _ Commander = &FakeCommander{}
+
+func NewFakeCommander() *FakeCommander {
+	return &FakeCommander{
+		RecvChan:  make(chan Message, 1),
+		chunkSize: DefaultChunkSize,
+	}
+}
+
+func (f *FakeCommander) Connect(ctx context.Context) error {
+	if f.ConnectFunc != nil {
+		return f.ConnectFunc(ctx)
+	}
+	return nil
+}
+
+func (f *FakeCommander) Close() error {
+	if f.CloseFunc != nil {
+		return f.CloseFunc()
+	}
+	return nil
+}
+
+func (f *FakeCommander) Server() string {
+	return f.server
+}
+
+func (f *FakeCommander) WithServer(server string) Client {
+	f.server = server
+	return f
+}
+
+func (f *FakeCommander) DialOptions() []grpc.DialOption {
+	return f.dialOptions
+}
+
+func (f *FakeCommander) WithDialOptions(options ...grpc.DialOption) Client {
+	f.dialOptions = append(f.dialOptions, options...)
+	return f
+}
+
+func (f *FakeCommander) WithInterceptor(interceptor interceptors.Interceptor) Client {
+	return f
+}
+
+func (f *FakeCommander) WithClientInterceptor(interceptor interceptors.ClientInterceptor) Client {
+	return f
+}
+
+func (f *FakeCommander) WithBackoffSettings(backoffSettings BackoffSettings) Client {
+	f.backoffSettings = backoffSettings
+	return f
+}
+
+func (f *FakeCommander) ChunksSize() int {
+	return f.chunkSize
+}
+
+func (f *FakeCommander) WithChunkSize(size int) Client {
+	f.chunkSize = size
+	return f
+}
+
+func (f *FakeCommander) Send(ctx context.Context, message Message) error {
+	if f.SendFunc != nil {
+		return f.SendFunc(ctx, message)
+	}
+	return nil
+}
+
+func (f *FakeCommander) Download(ctx context.Context, meta *proto.Metadata) (*proto.NginxConfig, error) {
+	if f.DownloadFunc != nil {
+		return f.DownloadFunc(ctx, meta)
+	}
+	return nil, nil
+}
+
+func (f *FakeCommander) Upload(ctx context.Context, cfg *proto.NginxConfig, messageID string) error {
+	if f.UploadFunc != nil {
+		return f.UploadFunc(ctx, cfg, messageID)
+	}
+	return nil
+}
+
+func (f *FakeCommander) Recv() <-chan Message {
+	return f.RecvChan
+}
+
+// FakeMetricReporter is a function-field fake of the MetricReporter
+// interface, mirroring FakeCommander.
+type FakeMetricReporter struct {
+	ConnectFunc func(ctx context.Context) error
+	CloseFunc   func() error
+	SendFunc    func(ctx context.Context, message Message) error
+
+	server          string
+	dialOptions     []grpc.DialOption
+	backoffSettings BackoffSettings
+}
+
+var _ MetricReporter = &FakeMetricReporter{}
+
+func NewFakeMetricReporter() *FakeMetricReporter {
+	return &FakeMetricReporter{}
+}
+
+func (f *FakeMetricReporter) Connect(ctx context.Context) error {
+	if f.ConnectFunc != nil {
+		return f.ConnectFunc(ctx)
+	}
+	return nil
+}
+
+func (f *FakeMetricReporter) Close() error {
+	if f.CloseFunc != nil {
+		return f.CloseFunc()
+	}
+	return nil
+}
+
+func (f *FakeMetricReporter) Server() string {
+	return f.server
+}
+
+func (f *FakeMetricReporter) WithServer(server string) Client {
+	f.server = server
+	return f
+}
+
+func (f *FakeMetricReporter) DialOptions() []grpc.DialOption {
+	return f.dialOptions
+}
+
+func (f *FakeMetricReporter) WithDialOptions(options ...grpc.DialOption) Client {
+	f.dialOptions = append(f.dialOptions, options...)
+	return f
+}
+
+func (f *FakeMetricReporter) WithInterceptor(interceptor interceptors.Interceptor) Client {
+	return f
+}
+
+func (f *FakeMetricReporter) WithClientInterceptor(interceptor interceptors.ClientInterceptor) Client {
+	return f
+}
+
+func (f *FakeMetricReporter) WithBackoffSettings(backoffSettings BackoffSettings) Client {
+	f.backoffSettings = backoffSettings
+	return f
+}
+
+func (f *FakeMetricReporter) Send(ctx context.Context, message Message) error {
+	if f.SendFunc != nil {
+		return f.SendFunc(ctx, message)
+	}
+	return nil
+}
@@ -24,6 +24,10 @@ import (
 	events "github.com/nginx/agent/sdk/v2/proto/events"
 )
 
+// NewMetricReporterClient returns an unconfigured metric reporter client set
+// up through the chained With* setters.
+//
+// Deprecated: use NewMetricReporter with functional options instead.
 func NewMetricReporterClient() MetricReporter {
 	return &metricReporter{
 		connector:       newConnector(),
@@ -0,0 +1,126 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/nginx/agent/sdk/v2/interceptors"
+)
+
+// Option configures a client built by NewCommander or NewMetricReporter.
+// Options replace the chained With* setters of the Client interface, so a
+// fully configured client can be built in one call:
+//
+//	commander := client.NewCommander(
+//		client.WithServer("dataplane-manager:443"),
+//		client.WithTLS(tlsConfig),
+//		client.WithBackoff(client.DefaultBackoffSettings),
+//	)
+type Option func(Client)
+
+// WithServer sets the target (host:port) the client connects to.
+func WithServer(server string) Option {
+	return func(c Client) {
+		c.WithServer(server)
+	}
+}
+
+// WithDialOptions appends grpc dial options used when connecting.
+func WithDialOptions(options ...grpc.DialOption) Option {
+	return func(c Client) {
+		c.WithDialOptions(options...)
+	}
+}
+
+// WithTLS makes the client connect with transport credentials built from the
+// given TLS configuration. A nil configuration uses the defaults of the
+// crypto/tls package.
+func WithTLS(config *tls.Config) Option {
+	return func(c Client) {
+		c.WithDialOptions(grpc.WithTransportCredentials(credentials.NewTLS(config)))
+	}
+}
+
+// WithBackoff sets the backoff settings governing connection retries.
+func WithBackoff(settings BackoffSettings) Option {
+	return func(c Client) {
+		c.WithBackoffSettings(settings)
+	}
+}
+
+// WithInterceptor appends a server interceptor.
+func WithInterceptor(interceptor interceptors.Interceptor) Option {
+	return func(c Client) {
+		c.WithInterceptor(interceptor)
+	}
+}
+
+// WithClientInterceptor appends a client interceptor.
+func WithClientInterceptor(interceptor interceptors.ClientInterceptor) Option {
+	return func(c Client) {
+		c.WithClientInterceptor(interceptor)
+	}
+}
+
+// WithChunkSize sets the chunk size used when up- and downloading configs.
+// The option only applies to commander clients and is ignored by others.
+func WithChunkSize(size int) Option {
+	return func(c Client) {
+		if commander, ok := c.(Commander); ok {
+			commander.WithChunkSize(size)
+		}
+	}
+}
+
+// NewBackoffSettings assembles the backoff settings governing connection
+// retries: the first retry happens after initialInterval, further retries
+// back off exponentially up to maxInterval, and retrying stops after
+// maxTimeout (zero retries forever). sendMaxTimeout bounds a single send.
+func NewBackoffSettings(initialInterval, maxInterval, maxTimeout, sendMaxTimeout time.Duration) BackoffSettings {
+	return BackoffSettings{
+		initialInterval: initialInterval,
+		maxInterval:     maxInterval,
+		maxTimeout:      maxTimeout,
+		sendMaxTimeout:  sendMaxTimeout,
+	}
+}
+
+// NewCommander returns a commander client configured with the given options.
+func NewCommander(options ...Option) Commander {
+	commander := NewCommanderClient()
+	for _, option := range options {
+		option(commander)
+	}
+	return commander
+}
+
+// NewMetricReporter returns a metric reporter client configured with the
+// given options.
+func NewMetricReporter(options ...Option) MetricReporter {
+	reporter := NewMetricReporterClient()
+	for _, option := range options {
+		option(reporter)
+	}
+	return reporter
+}
+
+// NewController returns a controller driving the lifecycle of the given
+// clients with the given context.
+func NewController(ctx context.Context, clients ...Client) Controller {
+	controller := NewClientController().WithContext(ctx)
+	for _, grpcClient := range clients {
+		controller.WithClient(grpcClient)
+	}
+	return controller
+}
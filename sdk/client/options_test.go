@@ -0,0 +1,75 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCommanderWithOptions(t *testing.T) {
+	backoff := NewBackoffSettings(time.Second, time.Minute, 0, time.Minute)
+
+	commander := NewCommander(
+		WithServer("dataplane-manager:443"),
+		WithTLS(&tls.Config{MinVersion: tls.VersionTLS12}),
+		WithBackoff(backoff),
+		WithChunkSize(100),
+	)
+
+	assert.Equal(t, "dataplane-manager:443", commander.Server())
+	assert.Len(t, commander.DialOptions(), 1)
+	assert.Equal(t, 100, commander.ChunksSize())
+}
+
+func TestNewMetricReporterWithOptions(t *testing.T) {
+	reporter := NewMetricReporter(
+		WithServer("dataplane-manager:443"),
+		// chunk size only applies to commanders and is ignored here
+		WithChunkSize(100),
+	)
+
+	assert.Equal(t, "dataplane-manager:443", reporter.Server())
+	assert.Empty(t, reporter.DialOptions())
+}
+
+func TestNewControllerWithFakes(t *testing.T) {
+	connectErr := errors.New("connect failed")
+
+	commander := NewFakeCommander()
+	commander.ConnectFunc = func(ctx context.Context) error {
+		return connectErr
+	}
+	reporter := NewFakeMetricReporter()
+	reporter.WithServer("dataplane-manager:443")
+
+	controller := NewController(context.Background(), commander, reporter)
+
+	err := controller.Connect()
+	assert.ErrorIs(t, err, connectErr)
+	assert.NoError(t, controller.Close())
+	assert.NotNil(t, controller.Context())
+}
+
+func TestFakeCommanderDefaults(t *testing.T) {
+	commander := NewFakeCommander()
+
+	assert.NoError(t, commander.Connect(context.Background()))
+	assert.NoError(t, commander.Send(context.Background(), nil))
+	assert.NoError(t, commander.Upload(context.Background(), nil, "1"))
+	cfg, err := commander.Download(context.Background(), nil)
+	assert.Nil(t, cfg)
+	assert.NoError(t, err)
+	assert.NoError(t, commander.Close())
+	assert.Equal(t, DefaultChunkSize, commander.ChunksSize())
+}
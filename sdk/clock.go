@@ -0,0 +1,100 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts the time source, so timestamps and timers can be driven
+// deterministically in tests and replayed afterwards.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+}
+
+// RealClock is the wall clock the agent uses outside tests.
+type RealClock struct{}
+
+func NewRealClock() RealClock {
+	return RealClock{}
+}
+
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+func (RealClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (RealClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// FakeClock is a Clock a test advances by hand. Timers started with After fire
+// when Advance moves the clock past their deadline, never from real time
+// passing.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	deadline := c.now.Add(d)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{deadline: deadline, ch: ch})
+	return ch
+}
+
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// Advance moves the clock forward and fires every timer whose deadline has
+// passed.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	remaining := c.waiters[:0]
+	for _, waiter := range c.waiters {
+		if waiter.deadline.After(c.now) {
+			remaining = append(remaining, waiter)
+			continue
+		}
+		waiter.ch <- c.now
+	}
+	c.waiters = remaining
+}
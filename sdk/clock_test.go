@@ -0,0 +1,77 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClockAdvance(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	assert.Equal(t, start, clock.Now())
+
+	timer := clock.After(10 * time.Second)
+	select {
+	case <-timer:
+		t.Fatal("timer fired before the clock advanced")
+	default:
+	}
+
+	clock.Advance(5 * time.Second)
+	select {
+	case <-timer:
+		t.Fatal("timer fired before its deadline")
+	default:
+	}
+
+	clock.Advance(5 * time.Second)
+	select {
+	case fired := <-timer:
+		assert.Equal(t, start.Add(10*time.Second), fired)
+	default:
+		t.Fatal("timer did not fire at its deadline")
+	}
+
+	assert.Equal(t, start.Add(10*time.Second), clock.Now())
+}
+
+func TestWaitUntilWithClockRetries(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	attempts := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- WaitUntilWithClock(context.Background(), clock, 10*time.Millisecond, 10*time.Millisecond, time.Hour, func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("not ready")
+			}
+			return nil
+		})
+	}()
+
+	for {
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+			assert.Equal(t, 3, attempts)
+			return
+		default:
+			clock.Advance(10 * time.Millisecond)
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
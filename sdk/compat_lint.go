@@ -0,0 +1,207 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nginxinc/nginx-go-crossplane"
+)
+
+const (
+	// CompatSeverityError marks findings the instance cannot run, such as a directive
+	// of a module it was built without
+	CompatSeverityError = "error"
+	// CompatSeverityWarning marks findings the instance still accepts, such as
+	// deprecated directives
+	CompatSeverityWarning = "warning"
+)
+
+// CompatFinding describes one incompatibility between a directive of the config and
+// the nginx build it is applied to.
+type CompatFinding struct {
+	// Directive is the nginx directive the finding relates to
+	Directive string
+	// File and Line locate the directive in the config
+	File string
+	Line int
+	// Severity is one of CompatSeverityError or CompatSeverityWarning
+	Severity string
+	// Message is a human readable description of the finding
+	Message string
+}
+
+// compatRequirement states what an nginx build needs for a directive: the configure
+// flag of the module that provides it, the minimum version that knows it, or both.
+type compatRequirement struct {
+	module     string
+	minVersion string
+}
+
+// compatRequirements maps directives to the build they require. The module names
+// match the configure arguments as nginx -V reports them, so a module built as
+// dynamic (e.g. with-stream=dynamic) satisfies the requirement as well.
+var compatRequirements = map[string]compatRequirement{
+	"http3":            {module: "with-http_v3_module", minVersion: "1.25.0"},
+	"quic_retry":       {module: "with-http_v3_module", minVersion: "1.25.0"},
+	"http2":            {module: "with-http_v2_module", minVersion: "1.25.1"},
+	"gzip_static":      {module: "with-http_gzip_static_module"},
+	"gunzip":           {module: "with-http_gunzip_module"},
+	"stub_status":      {module: "with-http_stub_status_module"},
+	"stream":           {module: "with-stream"},
+	"mail":             {module: "with-mail"},
+	"auth_request":     {module: "with-http_auth_request_module"},
+	"sub_filter":       {module: "with-http_sub_module"},
+	"slice":            {module: "with-http_slice_module"},
+	"mp4":              {module: "with-http_mp4_module"},
+	"flv":              {module: "with-http_flv_module"},
+	"secure_link":      {module: "with-http_secure_link_module"},
+	"real_ip_header":   {module: "with-http_realip_module"},
+	"set_real_ip_from": {module: "with-http_realip_module"},
+	"grpc_pass":        {minVersion: "1.13.10"},
+}
+
+// LintConfigCompatibility cross references the directives of the config rooted at
+// confFile against the version and configure arguments of the nginx build that will
+// run it, and reports directives the build does not provide before nginx -t is ever
+// run. Module checks are skipped when the configure arguments are unknown.
+func LintConfigCompatibility(confFile, version string, configureArgs []string) ([]*CompatFinding, error) {
+	payload, err := crossplane.Parse(confFile,
+		&crossplane.ParseOptions{
+			SingleFile:         false,
+			StopParsingOnError: true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading config from %s: %v", ErrParse, confFile, err)
+	}
+
+	findings := []*CompatFinding{}
+	for _, conf := range payload.Config {
+		file := conf.File
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(confFile), file)
+		}
+		lintDirectives(conf.Parsed, file, version, configureArgs, &findings)
+	}
+	return findings, nil
+}
+
+func lintDirectives(directives crossplane.Directives, file, version string, configureArgs []string, findings *[]*CompatFinding) {
+	for _, directive := range directives {
+		if finding := lintDirective(directive, file, version, configureArgs); finding != nil {
+			*findings = append(*findings, finding)
+		}
+		if directive.IsBlock() {
+			lintDirectives(directive.Block, file, version, configureArgs, findings)
+		}
+	}
+}
+
+func lintDirective(directive *crossplane.Directive, file, version string, configureArgs []string) *CompatFinding {
+	finding := &CompatFinding{
+		Directive: directive.Directive,
+		File:      file,
+		Line:      directive.Line,
+	}
+
+	switch directive.Directive {
+	case "ssl":
+		// the standalone ssl directive was deprecated in 1.15.0 and removed in 1.25.1
+		if len(directive.Args) != 1 {
+			return nil
+		}
+		if versionAtLeast(version, "1.25.1") {
+			finding.Severity = CompatSeverityError
+			finding.Message = fmt.Sprintf("the ssl directive was removed in nginx 1.25.1, use the ssl parameter of listen (version %s)", version)
+		} else {
+			finding.Severity = CompatSeverityWarning
+			finding.Message = "the ssl directive is deprecated, use the ssl parameter of listen"
+		}
+		return finding
+	case "listen":
+		for _, arg := range directive.Args {
+			if arg == "spdy" {
+				finding.Severity = CompatSeverityError
+				finding.Message = "the spdy parameter of listen is not supported by any maintained nginx version"
+				return finding
+			}
+			if arg == "http2" && versionAtLeast(version, "1.25.1") {
+				finding.Severity = CompatSeverityWarning
+				finding.Message = "the http2 parameter of listen is deprecated since nginx 1.25.1, use the http2 directive"
+				return finding
+			}
+		}
+		return nil
+	}
+
+	requirement, known := compatRequirements[directive.Directive]
+	if !known {
+		return nil
+	}
+
+	if requirement.module != "" && len(configureArgs) > 0 && !hasConfigureArg(configureArgs, requirement.module) {
+		finding.Severity = CompatSeverityError
+		finding.Message = fmt.Sprintf("directive %s requires nginx built with --%s", directive.Directive, requirement.module)
+		return finding
+	}
+	if requirement.minVersion != "" && version != "" && !versionAtLeast(version, requirement.minVersion) {
+		finding.Severity = CompatSeverityError
+		finding.Message = fmt.Sprintf("directive %s requires nginx %s or later, the instance runs %s", directive.Directive, requirement.minVersion, version)
+		return finding
+	}
+	return nil
+}
+
+func hasConfigureArg(configureArgs []string, module string) bool {
+	for _, arg := range configureArgs {
+		if strings.HasPrefix(strings.TrimLeft(arg, "-"), module) {
+			return true
+		}
+	}
+	return false
+}
+
+// versionAtLeast reports whether version is min or later; versions that cannot be
+// parsed compare as recent enough, so an unknown build never produces findings.
+func versionAtLeast(version, min string) bool {
+	current, ok := parseVersionNumbers(version)
+	if !ok {
+		return true
+	}
+	required, ok := parseVersionNumbers(min)
+	if !ok {
+		return true
+	}
+	for i := 0; i < len(required); i++ {
+		c := 0
+		if i < len(current) {
+			c = current[i]
+		}
+		if c != required[i] {
+			return c > required[i]
+		}
+	}
+	return true
+}
+
+func parseVersionNumbers(version string) ([]int, bool) {
+	parts := strings.Split(strings.TrimSpace(version), ".")
+	numbers := make([]int, 0, len(parts))
+	for _, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, len(numbers) > 0
+}
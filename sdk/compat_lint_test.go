@@ -0,0 +1,124 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCompatTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	confFile := filepath.Join(t.TempDir(), "nginx.conf")
+	require.NoError(t, os.WriteFile(confFile, []byte(content), 0644))
+	return confFile
+}
+
+func TestLintConfigCompatibility(t *testing.T) {
+	confFile := writeCompatTestConfig(t, `
+events {}
+http {
+    server {
+        listen 443 ssl;
+        gzip_static on;
+        http3 on;
+    }
+}
+stream {
+    server {
+        listen 9000;
+    }
+}
+`)
+
+	configureArgs := []string{"with-http_gzip_static_module", "with-stream=dynamic"}
+	findings, err := LintConfigCompatibility(confFile, "1.23.2", configureArgs)
+	require.NoError(t, err)
+
+	// gzip_static and stream are satisfied (stream as a dynamic module), http3 is not
+	require.Len(t, findings, 1)
+	assert.Equal(t, "http3", findings[0].Directive)
+	assert.Equal(t, CompatSeverityError, findings[0].Severity)
+	assert.Contains(t, findings[0].Message, "with-http_v3_module")
+	assert.Equal(t, confFile, findings[0].File)
+	assert.Equal(t, 7, findings[0].Line)
+}
+
+func TestLintConfigCompatibilityVersion(t *testing.T) {
+	confFile := writeCompatTestConfig(t, `
+events {}
+http {
+    server {
+        listen 443 ssl;
+        http2 on;
+    }
+}
+`)
+
+	// the module is built in but the version predates the http2 directive
+	findings, err := LintConfigCompatibility(confFile, "1.23.2", []string{"with-http_v2_module"})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, CompatSeverityError, findings[0].Severity)
+	assert.Contains(t, findings[0].Message, "1.25.1 or later")
+
+	// a recent version accepts it
+	findings, err = LintConfigCompatibility(confFile, "1.25.3", []string{"with-http_v2_module"})
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestLintConfigCompatibilityDeprecations(t *testing.T) {
+	confFile := writeCompatTestConfig(t, `
+events {}
+http {
+    server {
+        listen 443 ssl http2;
+        ssl on;
+    }
+}
+`)
+
+	// on an old version the ssl directive and the http2 listen parameter still work
+	findings, err := LintConfigCompatibility(confFile, "1.23.2", []string{"with-http_v2_module"})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "ssl", findings[0].Directive)
+	assert.Equal(t, CompatSeverityWarning, findings[0].Severity)
+
+	// on 1.25.1 the ssl directive is gone and the listen parameter is deprecated
+	findings, err = LintConfigCompatibility(confFile, "1.25.1", []string{"with-http_v2_module"})
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	severities := map[string]string{}
+	for _, finding := range findings {
+		severities[finding.Directive] = finding.Severity
+	}
+	assert.Equal(t, CompatSeverityError, severities["ssl"])
+	assert.Equal(t, CompatSeverityWarning, severities["listen"])
+}
+
+func TestLintConfigCompatibilityUnknownBuild(t *testing.T) {
+	confFile := writeCompatTestConfig(t, `
+events {}
+http {
+    server {
+        gzip_static on;
+    }
+}
+`)
+
+	// without configure arguments the module checks cannot be made
+	findings, err := LintConfigCompatibility(confFile, "", nil)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
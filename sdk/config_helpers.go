@@ -8,8 +8,11 @@
 package sdk
 
 import (
+	"context"
 	"crypto/sha256"
+	"crypto/tls"
 	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -31,6 +34,15 @@ import (
 	"github.com/nginxinc/nginx-go-crossplane"
 )
 
+const (
+	// sslKeyTypeFile indicates an ssl_certificate_key referencing a key file on disk
+	sslKeyTypeFile = "file"
+	// sslKeyTypeEngine indicates an engine or PKCS#11 keystore backed key reference
+	sslKeyTypeEngine = "engine"
+	// sslKeyTypeData indicates an inline data: key reference
+	sslKeyTypeData = "data"
+)
+
 type DirectoryMap struct {
 	paths map[string]*proto.Directory
 }
@@ -86,6 +98,33 @@ func (dm DirectoryMap) appendFileWithProto(dir string, fileProto *proto.File) er
 	return nil
 }
 
+// ParsedConfig is the crossplane parse of the config rooted at one conf file.
+// It is produced once per collection or apply cycle and shared by the analyses
+// below, which used to each re-parse the full config independently.
+type ParsedConfig struct {
+	confFile string
+	payload  *crossplane.Payload
+}
+
+// ParseConfig parses the config rooted at confFile, following its includes
+func ParseConfig(confFile string) (*ParsedConfig, error) {
+	payload, err := crossplane.Parse(confFile,
+		&crossplane.ParseOptions{
+			SingleFile:         false,
+			StopParsingOnError: true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading config from %s: %v", ErrParse, confFile, err)
+	}
+	return &ParsedConfig{confFile: confFile, payload: payload}, nil
+}
+
+// ConfFile returns the conf file the parsed config is rooted at
+func (p *ParsedConfig) ConfFile() string {
+	return p.confFile
+}
+
 // GetNginxConfig parse the configFile into proto.NginxConfig payload, using the provided nginxID, and systemID for
 // ConfigDescriptor in the NginxConfig. The allowedDirectories is used to allowlist the directories we include
 // in the aux payload.
@@ -95,16 +134,65 @@ func GetNginxConfig(
 	systemId string,
 	allowedDirectories map[string]struct{},
 ) (*proto.NginxConfig, error) {
-	payload, err := crossplane.Parse(confFile,
-		&crossplane.ParseOptions{
-			SingleFile:         false,
-			StopParsingOnError: true,
-		},
-	)
+	return GetNginxConfigWithContext(context.Background(), confFile, nginxId, systemId, allowedDirectories)
+}
+
+// GetNginxConfigWithContext is GetNginxConfig honoring cancellation of ctx:
+// the directory walks collecting root and aux files stop once ctx is done
+func GetNginxConfigWithContext(
+	ctx context.Context,
+	confFile,
+	nginxId,
+	systemId string,
+	allowedDirectories map[string]struct{},
+) (*proto.NginxConfig, error) {
+	parsed, err := ParseConfig(confFile)
 	if err != nil {
-		return nil, fmt.Errorf("error reading config from %s, error: %s", confFile, err)
+		return nil, err
 	}
+	return parsed.NginxConfigWithContext(ctx, nginxId, systemId, allowedDirectories)
+}
 
+// NginxConfig assembles the proto.NginxConfig payload from the parsed config
+func (p *ParsedConfig) NginxConfig(
+	nginxId,
+	systemId string,
+	allowedDirectories map[string]struct{},
+) (*proto.NginxConfig, error) {
+	return p.NginxConfigWithContext(context.Background(), nginxId, systemId, allowedDirectories)
+}
+
+// CollectionWarnings records the files skipped while collecting a config,
+// keyed by path, with the reason each was skipped. An unreadable include no
+// longer fails the whole collection; callers decide whether the warnings are
+// fatal for their use case.
+type CollectionWarnings map[string]string
+
+// NginxConfigWithContext is NginxConfig honoring cancellation of ctx.
+// Collection warnings are logged and otherwise ignored.
+func (p *ParsedConfig) NginxConfigWithContext(
+	ctx context.Context,
+	nginxId,
+	systemId string,
+	allowedDirectories map[string]struct{},
+) (*proto.NginxConfig, error) {
+	nginxConfig, warnings, err := p.NginxConfigWithWarnings(ctx, nginxId, systemId, allowedDirectories)
+	for file, reason := range warnings {
+		log.Warnf("configs: skipping unreadable %s: %s", file, reason)
+	}
+	return nginxConfig, err
+}
+
+// NginxConfigWithWarnings assembles the proto.NginxConfig payload from the
+// parsed config, collecting what is readable. Files that cannot be read are
+// left out of the payload and reported in the returned warnings instead of
+// failing the collection.
+func (p *ParsedConfig) NginxConfigWithWarnings(
+	ctx context.Context,
+	nginxId,
+	systemId string,
+	allowedDirectories map[string]struct{},
+) (*proto.NginxConfig, CollectionWarnings, error) {
 	nginxConfig := &proto.NginxConfig{
 		Action: proto.NginxConfigAction_RETURN,
 		ConfigData: &proto.ConfigDescriptor{
@@ -119,20 +207,25 @@ func GetNginxConfig(
 		DirectoryMap: &proto.DirectoryMap{Directories: make([]*proto.Directory, 0)},
 	}
 
-	err = updateNginxConfigFromPayload(confFile, payload, nginxConfig, allowedDirectories)
+	warnings := CollectionWarnings{}
+	err := updateNginxConfigFromPayload(ctx, p.confFile, p.payload, nginxConfig, allowedDirectories, warnings)
 	if err != nil {
-		return nil, fmt.Errorf("error assemble payload from %s, error: %s", confFile, err)
+		return nil, warnings, fmt.Errorf("error assemble payload from %s, error: %s", p.confFile, err)
 	}
 
-	return nginxConfig, nil
+	nginxConfig.Topology = p.Topology()
+
+	return nginxConfig, warnings, nil
 }
 
 // updateNginxConfigFromPayload updates config files from payload.
 func updateNginxConfigFromPayload(
+	ctx context.Context,
 	confFile string,
 	payload *crossplane.Payload,
 	nginxConfig *proto.NginxConfig,
 	allowedDirectories map[string]struct{},
+	warnings CollectionWarnings,
 ) error {
 	conf, err := zip.NewWriter(filepath.Dir(confFile))
 	if err != nil {
@@ -148,9 +241,10 @@ func updateNginxConfigFromPayload(
 	formatMap := map[string]string{}  // map of accessLog/errorLog formats
 	seen := make(map[string]struct{}) // local cache of seen files
 
-	// Add files to the zipped config in a consistent order.
+	// Add files to the zipped config in a consistent order. An unreadable
+	// include is left out of the payload and recorded as a warning.
 	if err = conf.AddFile(payload.Config[0].File); err != nil {
-		return fmt.Errorf("configs: could not add conf(%s): %v", payload.Config[0].File, err)
+		warnings[payload.Config[0].File] = err.Error()
 	}
 
 	rest := make([]crossplane.Config, len(payload.Config[1:]))
@@ -160,7 +254,7 @@ func updateNginxConfigFromPayload(
 	})
 	for _, xpConf := range rest {
 		if err = conf.AddFile(xpConf.File); err != nil {
-			return fmt.Errorf("configs could not add conf file to archive: %s", err)
+			warnings[xpConf.File] = err.Error()
 		}
 	}
 
@@ -171,14 +265,15 @@ func updateNginxConfigFromPayload(
 
 		info, err = os.Stat(xpConf.File)
 		if err != nil {
-			return fmt.Errorf("configs: could not read file info(%s): %s", xpConf.File, err)
+			warnings[xpConf.File] = err.Error()
+			continue
 		}
 
 		if err := directoryMap.appendFile(base, info); err != nil {
 			return err
 		}
 
-		err = updateNginxConfigFileConfig(xpConf, nginxConfig, filepath.Dir(confFile), aux, formatMap, seen, allowedDirectories, directoryMap)
+		err = updateNginxConfigFileConfig(ctx, xpConf, nginxConfig, filepath.Dir(confFile), aux, formatMap, seen, allowedDirectories, directoryMap, warnings)
 		if err != nil {
 			return fmt.Errorf("configs: failed to update nginx config: %s", err)
 		}
@@ -201,6 +296,28 @@ func updateNginxConfigFromPayload(
 	return nil
 }
 
+// DefaultMaxDirectoryMapFiles is the per-directory file listing limit applied
+// when a DirectoryMap is summarized for reporting
+const DefaultMaxDirectoryMapFiles = 5000
+
+// SummarizeDirectoryMap caps the file listing of each directory at
+// maxFilesPerDirectory, so a huge static root does not push the report past
+// the message size limits. A truncated directory keeps its total file count,
+// and the control plane can still request the full config payload on demand.
+func SummarizeDirectoryMap(directoryMap *proto.DirectoryMap, maxFilesPerDirectory int) {
+	if directoryMap == nil || maxFilesPerDirectory <= 0 {
+		return
+	}
+	for _, directory := range directoryMap.GetDirectories() {
+		if len(directory.GetFiles()) <= maxFilesPerDirectory {
+			continue
+		}
+		directory.TotalFiles = int32(len(directory.GetFiles()))
+		directory.Truncated = true
+		directory.Files = directory.Files[:maxFilesPerDirectory]
+	}
+}
+
 func setDirectoryMap(directories *DirectoryMap, nginxConfig *proto.NginxConfig) {
 	// empty the DirectoryMap first
 	nginxConfig.DirectoryMap.Directories = nginxConfig.DirectoryMap.Directories[:0]
@@ -217,6 +334,7 @@ func setDirectoryMap(directories *DirectoryMap, nginxConfig *proto.NginxConfig)
 }
 
 func updateNginxConfigFileConfig(
+	ctx context.Context,
 	conf crossplane.Config,
 	nginxConfig *proto.NginxConfig,
 	hostDir string,
@@ -225,22 +343,56 @@ func updateNginxConfigFileConfig(
 	seen map[string]struct{},
 	allowedDirectories map[string]struct{},
 	directoryMap *DirectoryMap,
+	warnings CollectionWarnings,
 ) error {
+	certParents := make(map[*proto.SslCertificate][]*crossplane.Directive)
+	staplingOn := make(map[*crossplane.Directive]bool)
+	passwordFiles := make(map[*crossplane.Directive]bool)
+	serverNames := make(map[*crossplane.Directive][]string)
+	trustedCerts := []string{}
+
 	err := CrossplaneConfigTraverse(&conf,
 		func(parent *crossplane.Directive, directive *crossplane.Directive) (bool, error) {
+			if err := ctx.Err(); err != nil {
+				return false, err
+			}
 			switch directive.Directive {
 			case "log_format":
 				if len(directive.Args) >= 2 {
 					formatMap[directive.Args[0]] = strings.Join(directive.Args[1:], "")
 				}
 			case "root":
-				if err := updateNginxConfigFileWithRoot(aux, directive.Args[0], seen, allowedDirectories, directoryMap); err != nil {
+				if err := updateNginxConfigFileWithRoot(ctx, aux, directive.Args[0], seen, allowedDirectories, directoryMap, warnings); err != nil {
 					return true, err
 				}
-			case "ssl_certificate", "ssl_trusted_certificate":
-				if err := updateNginxConfigWithCert(directive.Directive, directive.Args[0], nginxConfig, aux, hostDir, directoryMap, allowedDirectories); err != nil {
+			case "alias":
+				if err := updateNginxConfigFileWithAlias(ctx, aux, directive.Args[0], seen, allowedDirectories, directoryMap, warnings); err != nil {
 					return true, err
 				}
+			case "ssl_certificate", "ssl_trusted_certificate":
+				cert, err := updateNginxConfigWithCert(directive.Directive, directive.Args[0], nginxConfig, aux, hostDir, directoryMap, allowedDirectories)
+				if err != nil {
+					warnings[resolveConfigFilePath(directive.Args[0], hostDir)] = err.Error()
+					return true, nil
+				}
+				if cert != nil {
+					certParents[cert] = append(certParents[cert], parent)
+				}
+				if directive.Directive == "ssl_trusted_certificate" {
+					trustedCerts = append(trustedCerts, resolveConfigFilePath(directive.Args[0], hostDir))
+				}
+			case "ssl_stapling":
+				if len(directive.Args) > 0 && directive.Args[0] == "on" {
+					staplingOn[parent] = true
+				}
+			case "server_name":
+				serverNames[parent] = append(serverNames[parent], directive.Args...)
+			case "ssl_password_file":
+				// only the presence of the password file is recorded, it is never
+				// collected into the config payload
+				passwordFiles[parent] = true
+			case "ssl_certificate_key":
+				updateNginxConfigWithCertKey(directive.Args[0], nginxConfig, hostDir)
 			case "access_log":
 				updateNginxConfigWithAccessLog(
 					directive.Args[0],
@@ -257,9 +409,51 @@ func updateNginxConfigFileConfig(
 	if err != nil {
 		return err
 	}
+
+	if err = collectTryFilesPaths(ctx, conf.Parsed, "", aux, seen, allowedDirectories, directoryMap, warnings); err != nil {
+		return err
+	}
+
+	// a certificate referenced from several server blocks is collected once and its
+	// block level attributes are aggregated over every referencing block
+	for _, cert := range nginxConfig.GetSsl().GetSslCerts() {
+		parents, ok := certParents[cert]
+		if !ok {
+			// collected from a different file of the payload
+			continue
+		}
+		cert.OcspStapling = false
+		cert.CoversServerNames = true
+		for _, parent := range parents {
+			if staplingOn[parent] {
+				cert.OcspStapling = true
+			}
+			if !certificateCoversNames(cert, serverNames[parent]) {
+				cert.CoversServerNames = false
+			}
+			if passwordFiles[parent] && cert.GetKey() != nil {
+				cert.Key.PasswordProtected = true
+			}
+			nginxConfig.Ssl.References = append(nginxConfig.Ssl.References, &proto.SslCertificateReference{
+				Fingerprint: cert.GetFingerprint(),
+				ServerNames: serverNames[parent],
+			})
+		}
+		UpdateCertificateValidationWithContext(ctx, cert, trustedCerts)
+	}
+
 	return nil
 }
 
+// resolveConfigFilePath resolves a file path from a config directive relative to the
+// directory of the main config file, mirroring how nginx resolves relative paths.
+func resolveConfigFilePath(file, rootDir string) string {
+	if !filepath.IsAbs(file) {
+		return filepath.Join(rootDir, file)
+	}
+	return file
+}
+
 func updateNginxConfigWithCert(
 	directive string,
 	file string,
@@ -268,10 +462,10 @@ func updateNginxConfigWithCert(
 	rootDir string,
 	directoryMap *DirectoryMap,
 	allowedDirectories map[string]struct{},
-) error {
+) (*proto.SslCertificate, error) {
 	if strings.HasPrefix("$", file) {
 		// variable loading, not actual cert file
-		return nil
+		return nil, nil
 	}
 
 	if !filepath.IsAbs(file) {
@@ -279,7 +473,7 @@ func updateNginxConfigWithCert(
 	}
 	info, err := os.Stat(file)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	isAllowed := false
@@ -290,14 +484,25 @@ func updateNginxConfigWithCert(
 		}
 	}
 
+	var certProto *proto.SslCertificate
 	if directive == "ssl_certificate" {
 		cert, err := LoadCertificate(file)
 		if err != nil {
-			return fmt.Errorf("configs: could not load cert(%s): %s", file, err)
+			return nil, fmt.Errorf("configs: could not load cert(%s): %s", file, err)
 		}
 
 		fingerprint := sha256.Sum256(cert.Raw)
-		certProto := &proto.SslCertificate{
+		fingerprintHex := convertToHexFormat(hex.EncodeToString(fingerprint[:]))
+
+		// the same certificate referenced from several server blocks or directories is
+		// only collected once, further references point at it by fingerprint
+		for _, existing := range nginxConfig.GetSsl().GetSslCerts() {
+			if existing.GetFingerprint() == fingerprintHex {
+				return existing, nil
+			}
+		}
+
+		certProto = &proto.SslCertificate{
 			FileName:           file,
 			PublicKeyAlgorithm: cert.PublicKeyAlgorithm.String(),
 			SignatureAlgorithm: cert.SignatureAlgorithm.String(),
@@ -320,11 +525,12 @@ func updateNginxConfigWithCert(
 				NotBefore: cert.NotBefore.Unix(),
 				NotAfter:  cert.NotAfter.Unix(),
 			},
-			SubjAltNames:           cert.DNSNames,
+			SubjAltNames:           subjectAltNames(cert),
+			ExtendedKeyUsage:       extendedKeyUsages(cert),
 			SerialNumber:           cert.SerialNumber.String(),
 			OcspUrl:                cert.IssuingCertificateURL,
 			SubjectKeyIdentifier:   convertToHexFormat(hex.EncodeToString(cert.SubjectKeyId)),
-			Fingerprint:            convertToHexFormat(hex.EncodeToString(fingerprint[:])),
+			Fingerprint:            fingerprintHex,
 			FingerprintAlgorithm:   cert.SignatureAlgorithm.String(),
 			Version:                int64(cert.Version),
 			AuthorityKeyIdentifier: convertToHexFormat(hex.EncodeToString(cert.AuthorityKeyId)),
@@ -338,17 +544,102 @@ func updateNginxConfigWithCert(
 	if !isAllowed {
 		log.Infof("certs: %s outside allowed directory, not including in aux payloads", file)
 		// we want the meta information, but skip putting the files into the aux contents
-		return nil
+		return certProto, nil
 	}
 	if err := directoryMap.appendFile(filepath.Dir(file), info); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := aux.AddFile(file); err != nil {
-		return fmt.Errorf("configs: could not add cert to aux file writer: %s", err)
+		return nil, fmt.Errorf("configs: could not add cert to aux file writer: %s", err)
 	}
 
-	return nil
+	return certProto, nil
+}
+
+// updateNginxConfigWithCertKey records metadata about the key file referenced by an
+// ssl_certificate_key directive: existence, permissions, ownership and whether the key
+// pairs with the certificate. Engine or PKCS#11 backed and inline keys are reported by
+// their key type instead of being treated as missing files. Only metadata is collected,
+// the key material itself is never read into the config payload.
+func updateNginxConfigWithCertKey(file string, nginxConfig *proto.NginxConfig, rootDir string) {
+	if strings.HasPrefix(file, "$") {
+		// variable loading, not an actual key file
+		return
+	}
+
+	// nginx pairs an ssl_certificate_key with the preceding ssl_certificate, which is
+	// the most recently collected certificate without a key
+	var cert *proto.SslCertificate
+	certs := nginxConfig.GetSsl().GetSslCerts()
+	for i := len(certs) - 1; i >= 0; i-- {
+		if certs[i].GetKey() == nil {
+			cert = certs[i]
+			break
+		}
+	}
+	if cert == nil {
+		return
+	}
+
+	// engine/keystore backed keys (engine:name:id, including PKCS#11 URIs) and inline
+	// data: keys have no file on disk to inspect
+	if strings.HasPrefix(file, "engine:") {
+		cert.Key = &proto.SslKey{FileName: file, KeyType: sslKeyTypeEngine, Exists: true}
+		return
+	}
+	if strings.HasPrefix(file, "data:") {
+		// the reference embeds the key material, which is never reported
+		cert.Key = &proto.SslKey{FileName: "data:", KeyType: sslKeyTypeData, Exists: true}
+		return
+	}
+
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(rootDir, file)
+	}
+
+	key := &proto.SslKey{FileName: file, KeyType: sslKeyTypeFile}
+	cert.Key = key
+
+	info, err := os.Stat(file)
+	if err != nil {
+		log.Infof("certs: could not read metadata for key file (%s): %s", file, err)
+		return
+	}
+
+	key.Exists = true
+	key.Permissions = filesSDK.GetPermissions(info.Mode())
+
+	if uid, _, ok := fileOwnerIDs(info); ok {
+		key.Owner = lookupUserName(uid)
+	}
+
+	if keyIsEncrypted(file) {
+		// an encrypted key cannot be paired without its passphrase, which the agent
+		// never has access to
+		key.PasswordProtected = true
+		return
+	}
+
+	if _, pairErr := tls.LoadX509KeyPair(cert.GetFileName(), file); pairErr == nil {
+		key.MatchesCertificate = true
+	}
+}
+
+// keyIsEncrypted reports whether the PEM encoded key at file is passphrase protected.
+func keyIsEncrypted(file string) bool {
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		return false
+	}
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return false
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		return true
+	}
+	return block.Headers["Proc-Type"] == "4,ENCRYPTED"
 }
 
 func getAccessLogDirectiveFormat(directive *crossplane.Directive) string {
@@ -447,11 +738,13 @@ func updateNginxConfigWithErrorLogPath(
 
 // root directive, so we slurp up all the files in the directory
 func updateNginxConfigFileWithRoot(
+	ctx context.Context,
 	aux *zip.Writer,
 	dir string,
 	seen map[string]struct{},
 	allowedDirectories map[string]struct{},
 	directoryMap *DirectoryMap,
+	warnings CollectionWarnings,
 ) error {
 	if _, ok := seen[dir]; ok {
 		return nil
@@ -462,9 +755,17 @@ func updateNginxConfigFileWithRoot(
 		return nil
 	}
 
-	return filepath.WalkDir(dir,
+	// gather the files in walk order first, then read them with bounded
+	// concurrency; these directories can hold thousands of small files
+	paths := []string{}
+	infos := []fs.FileInfo{}
+	err := filepath.WalkDir(dir,
 		func(path string, d fs.DirEntry, err error) error {
 			if err != nil {
+				warnings[path] = err.Error()
+				return nil
+			}
+			if err := ctx.Err(); err != nil {
 				return err
 			}
 
@@ -485,23 +786,101 @@ func updateNginxConfigFileWithRoot(
 			if err != nil {
 				return err
 			}
-			reader, err := os.Open(path)
-			if err != nil {
-				return fmt.Errorf("could read file(%s): %s", path, err)
-			}
-			defer reader.Close()
-
-			if err := directoryMap.appendFile(filepath.Dir(path), info); err != nil {
-				return err
-			}
-
-			if err = aux.Add(path, info.Mode(), reader); err != nil {
-				return fmt.Errorf("adding auxillary file error: %s", err)
-			}
+			paths = append(paths, path)
+			infos = append(infos, info)
 
 			return nil
 		},
 	)
+	if err != nil {
+		return err
+	}
+
+	return collectAuxFiles(paths, infos, aux, directoryMap, warnings)
+}
+
+// alias maps a location onto a path outside the root, so its target is collected
+// the same way a root directory is. The target can be a directory or a file name
+// prefix; a target that is not a directory collects its parent directory instead.
+// Aliases built from variables cannot be resolved statically and are skipped.
+func updateNginxConfigFileWithAlias(
+	ctx context.Context,
+	aux *zip.Writer,
+	path string,
+	seen map[string]struct{},
+	allowedDirectories map[string]struct{},
+	directoryMap *DirectoryMap,
+	warnings CollectionWarnings,
+) error {
+	if strings.Contains(path, "$") {
+		return nil
+	}
+	dir := strings.TrimRight(path, "/")
+	if dir == "" {
+		return nil
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	return updateNginxConfigFileWithRoot(ctx, aux, dir, seen, allowedDirectories, directoryMap, warnings)
+}
+
+// collectTryFilesPaths resolves the file arguments of try_files directives against
+// the root or alias in scope and collects the directories they point into, so the
+// fallback files of a location are part of the aux payload even when they live
+// outside the directories collected so far. Named locations, response codes and
+// arguments built from variables are skipped, as is a directive whose root cannot
+// be resolved statically. Like nginx itself, an alias takes precedence over the
+// root of the block it appears in but is not inherited by nested blocks.
+func collectTryFilesPaths(
+	ctx context.Context,
+	directives crossplane.Directives,
+	root string,
+	aux *zip.Writer,
+	seen map[string]struct{},
+	allowedDirectories map[string]struct{},
+	directoryMap *DirectoryMap,
+	warnings CollectionWarnings,
+) error {
+	alias := ""
+	for _, directive := range directives {
+		if len(directive.Args) == 0 {
+			continue
+		}
+		switch directive.Directive {
+		case "root":
+			root = directive.Args[0]
+		case "alias":
+			alias = directive.Args[0]
+		}
+	}
+	base := root
+	if alias != "" {
+		base = alias
+	}
+
+	for _, directive := range directives {
+		switch {
+		case directive.Directive == "try_files":
+			if base == "" || strings.Contains(base, "$") {
+				continue
+			}
+			for _, arg := range directive.Args {
+				if arg == "" || strings.HasPrefix(arg, "@") || strings.HasPrefix(arg, "=") || strings.Contains(arg, "$") {
+					continue
+				}
+				resolved := filepath.Join(base, strings.TrimSuffix(arg, "/"))
+				if err := updateNginxConfigFileWithRoot(ctx, aux, filepath.Dir(resolved), seen, allowedDirectories, directoryMap, warnings); err != nil {
+					return err
+				}
+			}
+		case directive.IsBlock():
+			if err := collectTryFilesPaths(ctx, directive.Block, root, aux, seen, allowedDirectories, directoryMap, warnings); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func updateNginxConfigFileWithAuxFile(
@@ -655,15 +1034,15 @@ func parseServerHost(parent *crossplane.Directive) string {
 		case "listen":
 			host, port, err := net.SplitHostPort(dir.Args[0])
 			if err == nil {
-				if host != "*" && host != "::" {
+				if !isWildcardHost(host) {
 					serverName = host
 				}
 				listenPort = port
 			} else {
 				if isPort(dir.Args[0]) {
 					listenPort = dir.Args[0]
-				} else {
-					serverName = dir.Args[0]
+				} else if host := strings.Trim(dir.Args[0], "[]"); !isWildcardHost(host) {
+					serverName = host
 				}
 			}
 		case "server_name":
@@ -674,7 +1053,22 @@ func parseServerHost(parent *crossplane.Directive) string {
 			serverName = dir.Args[0]
 		}
 	}
-	return fmt.Sprintf("%s:%s", serverName, listenPort)
+	return formatAddress(serverName, listenPort)
+}
+
+// isWildcardHost reports whether the listen host accepts connections on every
+// address, in which case the localhost default is kept for probing
+func isWildcardHost(host string) bool {
+	return host == "*" || host == "::" || host == "0.0.0.0"
+}
+
+// formatAddress joins the host and port, bracketing IPv6 literals and
+// percent-encoding any zone ID so the result is usable in a URL
+func formatAddress(host, port string) string {
+	if strings.Contains(host, ":") {
+		return net.JoinHostPort(strings.ReplaceAll(host, "%", "%25"), port)
+	}
+	return fmt.Sprintf("%s:%s", host, port)
 }
 
 func isPort(value string) bool {
@@ -690,60 +1084,122 @@ func parseLocationPath(location *crossplane.Directive) string {
 	return path
 }
 
-func statusAPICallback(parent *crossplane.Directive, current *crossplane.Directive) string {
-	plusUrls, ossUrls := parseStatusAPIEndpoints(parent, current)
-
-	for _, url := range plusUrls {
-		if pingStatusAPIEndpoint(url) {
-			log.Debugf("api at %q found", url)
-			return url
+// statusAPICallback returns a traversal callback probing the status API
+// endpoints of each api, status and stub_status directive until one answers or
+// ctx is cancelled
+func statusAPICallback(ctx context.Context) func(parent *crossplane.Directive, current *crossplane.Directive) string {
+	return func(parent *crossplane.Directive, current *crossplane.Directive) string {
+		plusUrls, ossUrls := parseStatusAPIEndpoints(parent, current)
+
+		for _, url := range plusUrls {
+			if pingStatusAPIEndpoint(ctx, url) {
+				log.Debugf("api at %q found", url)
+				return url
+			}
+			log.Debugf("api at %q is not reachable", url)
 		}
-		log.Debugf("api at %q is not reachable", url)
-	}
 
-	for _, url := range ossUrls {
-		if pingStatusAPIEndpoint(url) {
-			log.Debugf("stub_status at %q found", url)
-			return url
+		for _, url := range ossUrls {
+			if pingStatusAPIEndpoint(ctx, url) {
+				log.Debugf("stub_status at %q found", url)
+				return url
+			}
+			log.Debugf("stub_status at %q is not reachable", url)
 		}
-		log.Debugf("stub_status at %q is not reachable", url)
-	}
 
-	return ""
+		return ""
+	}
 }
 
 // pingStatusAPIEndpoint ensures the statusAPI is reachable from the agent
-func pingStatusAPIEndpoint(statusAPI string) bool {
+func pingStatusAPIEndpoint(ctx context.Context, statusAPI string) bool {
 	client := http.Client{Timeout: 50 * time.Millisecond}
 
-	if _, err := client.Head(statusAPI); err != nil {
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, statusAPI, nil)
+	if err != nil {
+		return false
+	}
+	if _, err := client.Do(request); err != nil {
 		return false
 	}
 	return true
 }
 
 func GetStatusApiInfo(confFile string) (statusApi string, err error) {
-	payload, err := crossplane.Parse(confFile,
-		&crossplane.ParseOptions{
-			SingleFile:         false,
-			StopParsingOnError: true,
-			CombineConfigs:     true,
-		},
-	)
+	return GetStatusApiInfoWithContext(context.Background(), confFile)
+}
+
+// GetStatusApiInfoWithContext is GetStatusApiInfo honoring cancellation of
+// ctx: no further endpoints are probed once ctx is done
+func GetStatusApiInfoWithContext(ctx context.Context, confFile string) (statusApi string, err error) {
+	parsed, err := ParseConfig(confFile)
 	if err != nil {
-		return "", fmt.Errorf("error reading config from %s, error: %s", confFile, err)
+		return "", err
 	}
+	return parsed.StatusApiWithContext(ctx)
+}
 
-	for _, xpConf := range payload.Config {
-		statusApi = CrossplaneConfigTraverseStr(&xpConf, statusAPICallback)
-		if statusApi != "" {
-			return statusApi, nil
+// StatusApi returns the first reachable status API (Plus API or stub status)
+// configured in the parsed config
+func (p *ParsedConfig) StatusApi() (statusApi string, err error) {
+	return p.StatusApiWithContext(context.Background())
+}
+
+// StatusApiWithContext is StatusApi honoring cancellation of ctx
+func (p *ParsedConfig) StatusApiWithContext(ctx context.Context) (statusApi string, err error) {
+	if len(p.payload.Config) == 0 {
+		return "", ErrUnreachableAPI
+	}
+	// walk the include-combined form of the config: visiting each included file
+	// as its own root would detach a location from the server block including
+	// it, so the probe would miss the server's listen address (or panic on the
+	// missing parent)
+	combined := &crossplane.Config{Parsed: p.combineDirectives(p.payload.Config[0].Parsed)}
+	statusApi = CrossplaneConfigTraverseStr(combined, statusAPICallback(ctx))
+	if statusApi != "" {
+		return statusApi, nil
+	}
+	if err = ctx.Err(); err != nil {
+		return "", err
+	}
+	return "", ErrUnreachableAPI
+}
+
+// combineDirectives returns the directives with every include directive
+// replaced by the directives of the configs it pulls in, the same shape
+// crossplane produces when parsing with CombineConfigs. Analyses that need the
+// parent chain intact across file boundaries run on the combined form.
+func (p *ParsedConfig) combineDirectives(directives crossplane.Directives) crossplane.Directives {
+	combined := make(crossplane.Directives, 0, len(directives))
+	for _, directive := range directives {
+		switch {
+		case directive.IsInclude():
+			for _, index := range directive.Includes {
+				combined = append(combined, p.combineDirectives(p.payload.Config[index].Parsed)...)
+			}
+		case directive.IsBlock():
+			block := *directive
+			block.Block = p.combineDirectives(directive.Block)
+			combined = append(combined, &block)
+		default:
+			combined = append(combined, directive)
 		}
 	}
-	return "", errors.New("no status api reachable from the agent found")
+	return combined
 }
 
 func GetErrorAndAccessLogs(confFile string) (*proto.ErrorLogs, *proto.AccessLogs, error) {
+	parsed, err := ParseConfig(confFile)
+	if err != nil {
+		return &proto.ErrorLogs{ErrorLog: make([]*proto.ErrorLog, 0)},
+			&proto.AccessLogs{AccessLog: make([]*proto.AccessLog, 0)}, err
+	}
+	return parsed.ErrorAndAccessLogs()
+}
+
+// ErrorAndAccessLogs returns the error and access logs configured in the
+// parsed config
+func (p *ParsedConfig) ErrorAndAccessLogs() (*proto.ErrorLogs, *proto.AccessLogs, error) {
 	nginxConfig := &proto.NginxConfig{
 		Action:       proto.NginxConfigAction_RETURN,
 		ConfigData:   nil,
@@ -755,19 +1211,9 @@ func GetErrorAndAccessLogs(confFile string) (*proto.ErrorLogs, *proto.AccessLogs
 		DirectoryMap: &proto.DirectoryMap{Directories: make([]*proto.Directory, 0)},
 	}
 
-	payload, err := crossplane.Parse(confFile,
-		&crossplane.ParseOptions{
-			SingleFile:         false,
-			StopParsingOnError: true,
-		},
-	)
-	if err != nil {
-		return nginxConfig.ErrorLogs, nginxConfig.AccessLogs, err
-	}
-
+	var err error
 	seen := make(map[string]struct{})
-	for _, xpConf := range payload.Config {
-		var err error
+	for _, xpConf := range p.payload.Config {
 		err = CrossplaneConfigTraverse(&xpConf,
 			func(parent *crossplane.Directive, current *crossplane.Directive) (bool, error) {
 				switch current.Directive {
@@ -783,6 +1229,50 @@ func GetErrorAndAccessLogs(confFile string) (*proto.ErrorLogs, *proto.AccessLogs
 	return nginxConfig.ErrorLogs, nginxConfig.AccessLogs, err
 }
 
+// GetServerNames returns the unique server names configured in the server blocks of
+// the config rooted at confFile. Default ("_"), variable and regular expression server
+// names are skipped as they do not name concrete hosts.
+func GetServerNames(confFile string) ([]string, error) {
+	parsed, err := ParseConfig(confFile)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.ServerNames()
+}
+
+// ServerNames returns the unique concrete server names configured in the
+// parsed config
+func (p *ParsedConfig) ServerNames() ([]string, error) {
+	var err error
+	seen := make(map[string]struct{})
+	names := []string{}
+	for _, xpConf := range p.payload.Config {
+		err = CrossplaneConfigTraverse(&xpConf,
+			func(parent *crossplane.Directive, current *crossplane.Directive) (bool, error) {
+				if current.Directive != "server_name" {
+					return true, nil
+				}
+				for _, name := range current.Args {
+					if name == "_" || strings.HasPrefix(name, "$") || strings.HasPrefix(name, "~") {
+						continue
+					}
+					if _, ok := seen[name]; ok {
+						continue
+					}
+					seen[name] = struct{}{}
+					names = append(names, name)
+				}
+				return true, nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
 func GetErrorLogs(errorLogs *proto.ErrorLogs) []string {
 	result := []string{}
 	for _, log := range errorLogs.ErrorLog {
@@ -803,7 +1293,7 @@ func GetAccessLogs(accessLogs *proto.AccessLogs) []string {
 // path could be a filepath or directory.
 func allowedPath(path string, allowedDirectories map[string]struct{}) bool {
 	for d := range allowedDirectories {
-		if strings.HasPrefix(path, d) {
+		if PathWithinDirectory(path, d) {
 			return true
 		}
 	}
@@ -822,49 +1312,62 @@ func convertToHexFormat(hexString string) string {
 	return formatted
 }
 
-func GetAppProtectPolicyAndSecurityLogFiles(cfg *proto.NginxConfig) ([]string, []string) {
+// GetAppProtectPolicyAndSecurityLogFiles returns the NGINX App Protect policy
+// and security log profile files referenced by the config. parsed is the parse
+// of the collection cycle the cfg payload was assembled from; it covers the
+// root config and everything it includes, so only aux config files outside the
+// include tree are parsed here. A nil parsed falls back to parsing every conf
+// file in the directory map.
+func GetAppProtectPolicyAndSecurityLogFiles(parsed *ParsedConfig, cfg *proto.NginxConfig) ([]string, []string) {
 	policyMap := make(map[string]bool)
 	profileMap := make(map[string]bool)
 
+	// parsing a conf file follows its includes, so files already covered by an
+	// earlier parse are skipped rather than parsed again as their own root
+	covered := make(map[string]struct{})
+
+	collect := func(p *ParsedConfig) {
+		for _, conf := range p.payload.Config {
+			covered[conf.File] = struct{}{}
+			err := CrossplaneConfigTraverse(&conf,
+				func(parent *crossplane.Directive, directive *crossplane.Directive) (bool, error) {
+					switch directive.Directive {
+					case "app_protect_policy_file":
+						if len(directive.Args) == 1 {
+							_, policy := path.Split(directive.Args[0])
+							policyMap[policy] = true
+						}
+					case "app_protect_security_log":
+						if len(directive.Args) == 2 {
+							_, profile := path.Split(directive.Args[0])
+							profileMap[profile] = true
+						}
+					}
+					return true, nil
+				})
+			if err != nil {
+				continue
+			}
+		}
+	}
+
+	if parsed != nil {
+		covered[parsed.confFile] = struct{}{}
+		collect(parsed)
+	}
+
 	for _, directory := range cfg.GetDirectoryMap().GetDirectories() {
 		for _, file := range directory.GetFiles() {
 			confFile := path.Join(directory.GetName(), file.GetName())
-
-			payload, err := crossplane.Parse(confFile,
-				&crossplane.ParseOptions{
-					SingleFile:         false,
-					StopParsingOnError: true,
-				},
-			)
-
-			if err != nil {
+			if _, ok := covered[confFile]; ok {
 				continue
 			}
 
-			for _, conf := range payload.Config {
-				err = CrossplaneConfigTraverse(&conf,
-					func(parent *crossplane.Directive, directive *crossplane.Directive) (bool, error) {
-						switch directive.Directive {
-						case "app_protect_policy_file":
-							if len(directive.Args) == 1 {
-								_, policy := path.Split(directive.Args[0])
-								policyMap[policy] = true
-							}
-						case "app_protect_security_log":
-							if len(directive.Args) == 2 {
-								_, profile := path.Split(directive.Args[0])
-								profileMap[profile] = true
-							}
-						}
-						return true, nil
-					})
-				if err != nil {
-					continue
-				}
-			}
+			p, err := ParseConfig(confFile)
 			if err != nil {
 				continue
 			}
+			collect(p)
 		}
 	}
 	policies := []string{}
@@ -0,0 +1,77 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nginxinc/nginx-go-crossplane"
+)
+
+// ConfigDirectiveMatch is one directive matched by a config query, located in the
+// file that holds it.
+type ConfigDirectiveMatch struct {
+	File      string   `json:"file"`
+	Line      int      `json:"line"`
+	Directive string   `json:"directive"`
+	Args      []string `json:"args"`
+}
+
+// ParseConfigPayload parses the nginx config rooted at confFile and returns the
+// crossplane payload as a single tree with includes resolved in place, which
+// marshals to JSON as is. Every directive carries the file it was read from.
+func ParseConfigPayload(confFile string) (*crossplane.Payload, error) {
+	payload, err := crossplane.Parse(confFile,
+		&crossplane.ParseOptions{
+			SingleFile:         false,
+			StopParsingOnError: true,
+			CombineConfigs:     true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading config from %s: %v", ErrParse, confFile, err)
+	}
+	return payload, nil
+}
+
+// QueryConfigDirectives filters the parsed payload down to the directives matched by
+// a dotted path of directive names, such as "http.server.listen" for every listen
+// directive of every http server block. Each segment matches a directive name at
+// that depth; the last segment names the directives that are returned.
+func QueryConfigDirectives(payload *crossplane.Payload, query string) []*ConfigDirectiveMatch {
+	segments := strings.Split(query, ".")
+	matches := []*ConfigDirectiveMatch{}
+	for _, conf := range payload.Config {
+		queryDirectives(conf.Parsed, segments, conf.File, &matches)
+	}
+	return matches
+}
+
+func queryDirectives(directives crossplane.Directives, segments []string, file string, matches *[]*ConfigDirectiveMatch) {
+	for _, directive := range directives {
+		if directive.Directive != segments[0] {
+			continue
+		}
+		if directive.File != "" {
+			file = directive.File
+		}
+		if len(segments) == 1 {
+			*matches = append(*matches, &ConfigDirectiveMatch{
+				File:      file,
+				Line:      directive.Line,
+				Directive: directive.Directive,
+				Args:      directive.Args,
+			})
+			continue
+		}
+		if directive.IsBlock() {
+			queryDirectives(directive.Block, segments[1:], file, matches)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfigPayload(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "site.conf"), []byte(`
+server {
+    listen 8080;
+}
+`), 0644))
+	confFile := filepath.Join(dir, "nginx.conf")
+	require.NoError(t, os.WriteFile(confFile, []byte(`
+events {}
+http {
+    include site.conf;
+}
+`), 0644))
+
+	payload, err := ParseConfigPayload(confFile)
+	require.NoError(t, err)
+
+	// includes are resolved into a single tree and the whole thing marshals to JSON
+	require.Len(t, payload.Config, 1)
+	contents, err := json.Marshal(payload)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), `"directive":"listen"`)
+
+	_, err = ParseConfigPayload(filepath.Join(dir, "missing.conf"))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrParse)
+}
+
+func TestQueryConfigDirectives(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "site.conf"), []byte(`
+server {
+    listen 443 ssl;
+    server_name two.example.com;
+}
+`), 0644))
+	confFile := filepath.Join(dir, "nginx.conf")
+	require.NoError(t, os.WriteFile(confFile, []byte(`
+events {}
+http {
+    server {
+        listen 80;
+        server_name one.example.com;
+    }
+    include site.conf;
+}
+stream {
+    server {
+        listen 9000;
+    }
+}
+`), 0644))
+
+	payload, err := ParseConfigPayload(confFile)
+	require.NoError(t, err)
+
+	// the path walks block directives by name, so the stream server is not matched
+	matches := QueryConfigDirectives(payload, "http.server.listen")
+	require.Len(t, matches, 2)
+	assert.Equal(t, confFile, matches[0].File)
+	assert.Equal(t, 5, matches[0].Line)
+	assert.Equal(t, []string{"80"}, matches[0].Args)
+	assert.Equal(t, filepath.Join(dir, "site.conf"), matches[1].File)
+	assert.Equal(t, []string{"443", "ssl"}, matches[1].Args)
+
+	matches = QueryConfigDirectives(payload, "stream.server.listen")
+	require.Len(t, matches, 1)
+	assert.Equal(t, []string{"9000"}, matches[0].Args)
+
+	assert.Empty(t, QueryConfigDirectives(payload, "http.server.proxy_pass"))
+}
@@ -0,0 +1,231 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/nginxinc/nginx-go-crossplane"
+)
+
+const (
+	// ConflictDuplicateDefaultServer marks a listen address with more than one
+	// server block claiming default_server
+	ConflictDuplicateDefaultServer = "duplicate_default_server"
+	// ConflictDuplicateUpstream marks an upstream name declared by more than one
+	// upstream block
+	ConflictDuplicateUpstream = "duplicate_upstream"
+	// ConflictDuplicateServerName marks a server name served by more than one
+	// server block on the same listen address
+	ConflictDuplicateServerName = "duplicate_server_name"
+)
+
+// ConfigConflict describes two directives of the include tree that contradict each
+// other. Conflicts typically creep in when server blocks are spread over included
+// files, so each conflict carries the locations of both directives involved.
+type ConfigConflict struct {
+	// Type is one of the Conflict* constants
+	Type string
+	// Subject is what the directives collide on: the listen address, the upstream
+	// name, or the listen address and server name pair
+	Subject string
+	// File and Line locate the directive the conflict was detected at
+	File string
+	Line int
+	// FirstFile and FirstLine locate the earlier directive it collides with
+	FirstFile string
+	FirstLine int
+	// Message is a human readable description of the conflict
+	Message string
+}
+
+// conflictLocation remembers where the first claimant of a listen address, upstream
+// name or server name pair was seen.
+type conflictLocation struct {
+	file string
+	line int
+}
+
+// DetectConfigConflicts parses the config rooted at confFile and reports directives
+// that conflict across the include tree: duplicate default_server flags on one listen
+// address, upstream blocks declared more than once, and server_name/listen pairs
+// served by more than one server block. nginx tolerates most of these at startup and
+// silently picks a winner, which is exactly what makes them hard to spot by hand.
+func DetectConfigConflicts(confFile string) ([]*ConfigConflict, error) {
+	payload, err := crossplane.Parse(confFile,
+		&crossplane.ParseOptions{
+			SingleFile:         false,
+			StopParsingOnError: true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading config from %s: %v", ErrParse, confFile, err)
+	}
+
+	collector := &conflictCollector{
+		defaultServers: map[string]conflictLocation{},
+		upstreams:      map[string]conflictLocation{},
+		serverNames:    map[string]conflictLocation{},
+	}
+	for _, conf := range payload.Config {
+		file := conf.File
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(confFile), file)
+		}
+		collector.walk(conf.Parsed, file)
+	}
+	return collector.conflicts, nil
+}
+
+type conflictCollector struct {
+	defaultServers map[string]conflictLocation
+	upstreams      map[string]conflictLocation
+	serverNames    map[string]conflictLocation
+	conflicts      []*ConfigConflict
+}
+
+func (c *conflictCollector) walk(directives crossplane.Directives, file string) {
+	for _, directive := range directives {
+		if !directive.IsBlock() {
+			continue
+		}
+		switch directive.Directive {
+		case "upstream":
+			if len(directive.Args) > 0 {
+				c.recordUpstream(directive, file)
+			}
+		case "server":
+			c.recordServer(directive, file)
+		default:
+			c.walk(directive.Block, file)
+		}
+	}
+}
+
+func (c *conflictCollector) recordUpstream(directive *crossplane.Directive, file string) {
+	name := directive.Args[0]
+	if first, seen := c.upstreams[name]; seen {
+		c.conflicts = append(c.conflicts, &ConfigConflict{
+			Type:      ConflictDuplicateUpstream,
+			Subject:   name,
+			File:      file,
+			Line:      directive.Line,
+			FirstFile: first.file,
+			FirstLine: first.line,
+			Message:   fmt.Sprintf("upstream %q is declared more than once, first declared at %s:%d", name, first.file, first.line),
+		})
+		return
+	}
+	c.upstreams[name] = conflictLocation{file: file, line: directive.Line}
+}
+
+// recordServer extracts the listen addresses and server names of one server block
+// and checks them against every server block seen before it. A server block without
+// an addressable listen (unix socket, variable address or no listen at all) is
+// skipped, matching CollectListenAddresses.
+func (c *conflictCollector) recordServer(directive *crossplane.Directive, file string) {
+	type serverListen struct {
+		address   string
+		isDefault bool
+		line      int
+	}
+	listens := []serverListen{}
+	type serverName struct {
+		name string
+		line int
+	}
+	names := []serverName{}
+
+	for _, child := range directive.Block {
+		switch child.Directive {
+		case "listen":
+			if len(child.Args) == 0 {
+				continue
+			}
+			address, ok := parseListenAddress(child.Args)
+			if !ok {
+				continue
+			}
+			listen := serverListen{address: address.String(), line: child.Line}
+			for _, arg := range child.Args[1:] {
+				if arg == "default_server" || arg == "default" {
+					listen.isDefault = true
+				}
+			}
+			listens = append(listens, listen)
+		case "server_name":
+			for _, arg := range child.Args {
+				names = append(names, serverName{name: strings.ToLower(arg), line: child.Line})
+			}
+		}
+	}
+
+	if len(listens) == 0 {
+		return
+	}
+	if len(names) == 0 {
+		names = append(names, serverName{name: "", line: directive.Line})
+	}
+
+	// a server block repeating its own listen address or server name is noise, not
+	// a conflict between servers, so claims are deduplicated per block first
+	claimedDefaults := map[string]struct{}{}
+	claimedNames := map[string]struct{}{}
+
+	for _, listen := range listens {
+		if listen.isDefault {
+			if _, claimed := claimedDefaults[listen.address]; !claimed {
+				claimedDefaults[listen.address] = struct{}{}
+				c.recordDefaultServer(listen.address, file, listen.line)
+			}
+		}
+		for _, name := range names {
+			key := listen.address + " " + name.name
+			if _, claimed := claimedNames[key]; claimed {
+				continue
+			}
+			claimedNames[key] = struct{}{}
+			c.recordServerName(listen.address, name.name, file, name.line)
+		}
+	}
+}
+
+func (c *conflictCollector) recordDefaultServer(address, file string, line int) {
+	if first, seen := c.defaultServers[address]; seen {
+		c.conflicts = append(c.conflicts, &ConfigConflict{
+			Type:      ConflictDuplicateDefaultServer,
+			Subject:   address,
+			File:      file,
+			Line:      line,
+			FirstFile: first.file,
+			FirstLine: first.line,
+			Message:   fmt.Sprintf("listen %s has more than one default server, first marked default at %s:%d", address, first.file, first.line),
+		})
+		return
+	}
+	c.defaultServers[address] = conflictLocation{file: file, line: line}
+}
+
+func (c *conflictCollector) recordServerName(address, name, file string, line int) {
+	key := address + " " + name
+	if first, seen := c.serverNames[key]; seen {
+		c.conflicts = append(c.conflicts, &ConfigConflict{
+			Type:      ConflictDuplicateServerName,
+			Subject:   strings.TrimSpace(key),
+			File:      file,
+			Line:      line,
+			FirstFile: first.file,
+			FirstLine: first.line,
+			Message:   fmt.Sprintf("server name %q on listen %s is served by more than one server block, first defined at %s:%d", name, address, first.file, first.line),
+		})
+		return
+	}
+	c.serverNames[key] = conflictLocation{file: file, line: line}
+}
@@ -0,0 +1,128 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectConfigConflicts(t *testing.T) {
+	tmpDir := t.TempDir()
+	confFile := filepath.Join(tmpDir, "nginx.conf")
+	siteFile := filepath.Join(tmpDir, "site.conf")
+
+	require.NoError(t, os.WriteFile(confFile, []byte(`
+events {}
+http {
+    upstream backend {
+        server 10.0.0.1:8080;
+    }
+    server {
+        listen 80 default_server;
+        server_name example.com;
+    }
+    include site.conf;
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(siteFile, []byte(`
+upstream backend {
+    server 10.0.0.2:8080;
+}
+server {
+    listen 80 default_server;
+    server_name example.com www.example.com;
+}
+`), 0644))
+
+	conflicts, err := DetectConfigConflicts(confFile)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 3)
+
+	byType := map[string]*ConfigConflict{}
+	for _, conflict := range conflicts {
+		byType[conflict.Type] = conflict
+	}
+
+	upstream := byType[ConflictDuplicateUpstream]
+	require.NotNil(t, upstream)
+	assert.Equal(t, "backend", upstream.Subject)
+	assert.Equal(t, siteFile, upstream.File)
+	assert.Equal(t, 2, upstream.Line)
+	assert.Equal(t, confFile, upstream.FirstFile)
+	assert.Equal(t, 4, upstream.FirstLine)
+
+	defaultServer := byType[ConflictDuplicateDefaultServer]
+	require.NotNil(t, defaultServer)
+	assert.Equal(t, "*:80", defaultServer.Subject)
+	assert.Equal(t, siteFile, defaultServer.File)
+	assert.Equal(t, confFile, defaultServer.FirstFile)
+
+	serverName := byType[ConflictDuplicateServerName]
+	require.NotNil(t, serverName)
+	assert.Equal(t, "*:80 example.com", serverName.Subject)
+	assert.Contains(t, serverName.Message, `server name "example.com"`)
+}
+
+func TestDetectConfigConflictsClean(t *testing.T) {
+	tmpDir := t.TempDir()
+	confFile := filepath.Join(tmpDir, "nginx.conf")
+
+	// two servers sharing a port with distinct names and a single default is the
+	// normal virtual hosting setup and must not be reported
+	require.NoError(t, os.WriteFile(confFile, []byte(`
+events {}
+http {
+    upstream backend {
+        server 10.0.0.1:8080;
+    }
+    server {
+        listen 80 default_server;
+        server_name example.com;
+    }
+    server {
+        listen 80;
+        server_name other.example.com;
+    }
+}
+`), 0644))
+
+	conflicts, err := DetectConfigConflicts(confFile)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
+
+func TestDetectConfigConflictsUnnamedServers(t *testing.T) {
+	tmpDir := t.TempDir()
+	confFile := filepath.Join(tmpDir, "nginx.conf")
+
+	// two servers on one listen with no server_name collide on the empty name,
+	// the same way nginx reports conflicting server name ""
+	require.NoError(t, os.WriteFile(confFile, []byte(`
+events {}
+http {
+    server {
+        listen 127.0.0.1:8080;
+    }
+    server {
+        listen 127.0.0.1:8080;
+    }
+}
+`), 0644))
+
+	conflicts, err := DetectConfigConflicts(confFile)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, ConflictDuplicateServerName, conflicts[0].Type)
+	assert.Equal(t, "127.0.0.1:8080", conflicts[0].Subject)
+	assert.Contains(t, conflicts[0].Message, `server name ""`)
+}
@@ -0,0 +1,161 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"encoding/json"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/sdk/v2/zip"
+)
+
+// the corpus runs from a fixed location so the absolute paths inside the
+// configs, and with them the golden files, are stable across machines
+const corpusRoot = "/tmp/testdata/corpus"
+
+var updateCorpus = flag.Bool("update-corpus", false, "rewrite the expected.json golden files of the config corpus")
+
+// corpusSnapshot is the comparable form of a GetNginxConfig result: the
+// unpacked payloads and the log and directory metadata, without the bits that
+// change between runs such as zip bytes and modification times
+type corpusSnapshot struct {
+	ConfigFiles map[string]string   `json:"config_files"`
+	AuxFiles    map[string]string   `json:"aux_files"`
+	AccessLogs  map[string]string   `json:"access_logs"`
+	ErrorLogs   map[string]string   `json:"error_logs"`
+	Directories map[string][]string `json:"directories"`
+}
+
+// TestNginxConfigCorpus runs GetNginxConfig against a corpus of anonymized
+// real-world configs (OSS, Plus, App Protect, stream-heavy, njs) and compares
+// the result against a golden file per config, so traversal regressions
+// surface as a readable diff. Regenerate the golden files after an intended
+// behavior change with: go test -run TestNginxConfigCorpus -update-corpus
+func TestNginxConfigCorpus(t *testing.T) {
+	entries, err := os.ReadDir("testdata/corpus")
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		t.Run(name, func(t *testing.T) {
+			source := filepath.Join("testdata/corpus", name)
+			dest := filepath.Join(corpusRoot, name)
+			require.NoError(t, os.RemoveAll(dest))
+			require.NoError(t, copyCorpusConfig(source, dest))
+
+			config, err := GetNginxConfig(
+				filepath.Join(dest, "nginx.conf"),
+				"corpus-nginx",
+				"corpus-system",
+				map[string]struct{}{dest: {}},
+			)
+			require.NoError(t, err)
+
+			snapshot := snapshotNginxConfig(t, config)
+			goldenFile := filepath.Join(source, "expected.json")
+
+			if *updateCorpus {
+				payload, err := json.MarshalIndent(snapshot, "", "    ")
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(goldenFile, append(payload, '\n'), 0644))
+				return
+			}
+
+			payload, err := os.ReadFile(goldenFile)
+			require.NoError(t, err, "missing golden file, regenerate with -update-corpus")
+			var expected corpusSnapshot
+			require.NoError(t, json.Unmarshal(payload, &expected))
+			assert.Equal(t, expected, snapshot)
+		})
+	}
+}
+
+// copyCorpusConfig copies a corpus config into place, leaving the golden file
+// behind
+func copyCorpusConfig(source, dest string) error {
+	return filepath.Walk(source, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dest, relative), 0755)
+		}
+		if relative == "expected.json" {
+			return nil
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dest, relative), contents, info.Mode().Perm())
+	})
+}
+
+func snapshotNginxConfig(t *testing.T, config *proto.NginxConfig) corpusSnapshot {
+	t.Helper()
+
+	snapshot := corpusSnapshot{
+		ConfigFiles: unpackToMap(t, config.GetZconfig()),
+		AuxFiles:    unpackToMap(t, config.GetZaux()),
+		AccessLogs:  make(map[string]string),
+		ErrorLogs:   make(map[string]string),
+		Directories: make(map[string][]string),
+	}
+
+	for _, accessLog := range config.GetAccessLogs().GetAccessLog() {
+		snapshot.AccessLogs[accessLog.GetName()] = accessLog.GetFormat()
+	}
+	for _, errorLog := range config.GetErrorLogs().GetErrorLog() {
+		snapshot.ErrorLogs[errorLog.GetName()] = errorLog.GetLogLevel()
+	}
+	for _, directory := range config.GetDirectoryMap().GetDirectories() {
+		files := make([]string, 0, len(directory.GetFiles()))
+		for _, file := range directory.GetFiles() {
+			files = append(files, file.GetName())
+		}
+		sort.Strings(files)
+		snapshot.Directories[directory.GetName()] = files
+	}
+
+	return snapshot
+}
+
+func unpackToMap(t *testing.T, payload *proto.ZippedFile) map[string]string {
+	t.Helper()
+
+	files := make(map[string]string)
+	if payload == nil {
+		return files
+	}
+	unpacked, err := zip.UnPack(payload)
+	require.NoError(t, err)
+	for _, file := range unpacked {
+		name := file.GetName()
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(payload.GetRootDirectory(), name)
+		}
+		files[name] = string(file.GetContents())
+	}
+	return files
+}
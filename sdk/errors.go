@@ -0,0 +1,24 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import "errors"
+
+// Sentinel errors callers and the control-plane reporting layer can branch on
+// with errors.Is, independent of the detail the returned error wraps around them.
+var (
+	// ErrNotAllowedDirectory indicates a path outside the configured allowed
+	// directory list
+	ErrNotAllowedDirectory = errors.New("path is outside the allowed directory list")
+	// ErrParse indicates the nginx config could not be parsed
+	ErrParse = errors.New("could not parse nginx config")
+	// ErrTooLarge indicates a payload over the size limit the agent accepts
+	ErrTooLarge = errors.New("payload exceeds the size limit")
+	// ErrUnreachableAPI indicates no configured status API answered a probe
+	ErrUnreachableAPI = errors.New("no status api reachable from the agent found")
+)
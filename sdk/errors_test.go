@@ -0,0 +1,39 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrParseIsWrapped(t *testing.T) {
+	_, err := ParseConfig(filepath.Join(t.TempDir(), "missing.conf"))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrParse))
+
+	_, err = AnalyzeTLSPosture(filepath.Join(t.TempDir(), "missing.conf"))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrParse))
+}
+
+func TestErrUnreachableAPIIsReturned(t *testing.T) {
+	confFile := filepath.Join(t.TempDir(), "nginx.conf")
+	require.NoError(t, os.WriteFile(confFile, []byte("events {}\n"), 0644))
+
+	parsed, err := ParseConfig(confFile)
+	require.NoError(t, err)
+
+	_, err = parsed.StatusApi()
+	assert.ErrorIs(t, err, ErrUnreachableAPI)
+}
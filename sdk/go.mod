@@ -9,6 +9,7 @@ require (
 	github.com/nginxinc/nginx-go-crossplane v0.4.1
 	github.com/sirupsen/logrus v1.9.0
 	github.com/stretchr/testify v1.8.1
+	golang.org/x/crypto v0.5.0
 	google.golang.org/grpc v1.52.0
 )
 
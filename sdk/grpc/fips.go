@@ -0,0 +1,46 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package grpc
+
+import (
+	"crypto/tls"
+)
+
+var fipsModeEnabled bool
+
+// FIPSApprovedCipherSuites are the TLS 1.2 cipher suites permitted when the agent
+// is running in FIPS mode. TLS 1.3 suites are not listed as they are not
+// configurable and the TLS 1.3 mandatory suites are all FIPS approved.
+var FIPSApprovedCipherSuites = []uint16{
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+}
+
+// EnableFIPSMode constrains all subsequently created transport credentials to
+// FIPS approved TLS versions and cipher suites.
+func EnableFIPSMode() {
+	fipsModeEnabled = true
+}
+
+// FIPSModeEnabled reports whether FIPS mode has been enabled for this process.
+func FIPSModeEnabled() bool {
+	return fipsModeEnabled
+}
+
+// applyFIPSMode restricts the provided tls Config to FIPS approved primitives.
+// TLS 1.2 is the minimum version permitted and the cipher suites are limited
+// to the approved AES-GCM suites.
+func applyFIPSMode(tlsConfig *tls.Config) {
+	if !fipsModeEnabled {
+		return
+	}
+	tlsConfig.MinVersion = tls.VersionTLS12
+	tlsConfig.CipherSuites = FIPSApprovedCipherSuites
+}
@@ -84,6 +84,22 @@ func SecureDialOptions(tlsEnabled bool, certPath string, keyPath string, caPath
 	return grpc.WithTransportCredentials(transCreds), nil
 }
 
+// SecureSPIFFEDialOptions returns dialOptions whose client identity is sourced from
+// the SPIFFE Workload API and rotated automatically, instead of static files.
+func SecureSPIFFEDialOptions(source *SPIFFESource, serverName string, skipVerify bool) (grpc.DialOption, error) {
+	tlsConfig := &tls.Config{
+		// note: ServerName is ignored if InsecureSkipVerify is true
+		ServerName:           serverName,
+		InsecureSkipVerify:   skipVerify,
+		GetClientCertificate: source.GetClientCertificate,
+		RootCAs:              source.RootCAs(),
+	}
+
+	applyFIPSMode(tlsConfig)
+
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
+}
+
 // DataplaneConnectionDialOptions returns dialOptions for connecting to a dataplane instance
 func DataplaneConnectionDialOptions(Token string, meta *proto.Metadata) []grpc.DialOption {
 	dataplaneDialOptions := []grpc.DialOption{}
@@ -140,6 +156,8 @@ func getTransportCredentials(certPath string, keyPath string, caPath string, ser
 		return nil, err
 	}
 
+	applyFIPSMode(tlsConfig)
+
 	return credentials.NewTLS(tlsConfig), nil
 }
 
@@ -0,0 +1,180 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/nginx/agent/sdk/v2/proto/workload"
+)
+
+const (
+	// spiffeSecurityHeader must accompany every Workload API call, per the SPIFFE spec
+	spiffeSecurityHeader = "workload.spiffe.io"
+
+	spiffeInitialFetchTimeout = 30 * time.Second
+	spiffeRetryInterval       = 5 * time.Second
+)
+
+// SPIFFESource sources the agent's client certificate from the SPIFFE Workload API of
+// a SPIRE agent. The identity is kept up to date in the background as the SPIRE agent
+// rotates it, so every new TLS handshake presents the current SVID.
+type SPIFFESource struct {
+	socketPath string
+	conn       *grpc.ClientConn
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+
+	mu     sync.RWMutex
+	cert   *tls.Certificate
+	bundle *x509.CertPool
+}
+
+// NewSPIFFESource connects to the Workload API on the provided unix domain socket and
+// blocks until the initial SVID was fetched, so callers never dial without an identity.
+func NewSPIFFESource(ctx context.Context, socketPath string) (*SPIFFESource, error) {
+	sourceCtx, cancel := context.WithCancel(ctx)
+
+	conn, err := grpc.DialContext(sourceCtx, "unix:"+socketPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("could not connect to spiffe workload api (%s): %w", socketPath, err)
+	}
+
+	source := &SPIFFESource{
+		socketPath: socketPath,
+		conn:       conn,
+		ctx:        sourceCtx,
+		cancel:     cancel,
+	}
+
+	initial := make(chan struct{})
+	source.wg.Add(1)
+	go source.watch(initial)
+
+	select {
+	case <-initial:
+	case <-time.After(spiffeInitialFetchTimeout):
+		source.Close()
+		return nil, fmt.Errorf("timed out waiting for initial svid from spiffe workload api (%s)", socketPath)
+	case <-sourceCtx.Done():
+		source.Close()
+		return nil, sourceCtx.Err()
+	}
+
+	return source, nil
+}
+
+// GetClientCertificate returns the current SVID, for use as tls.Config.GetClientCertificate.
+func (s *SPIFFESource) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cert == nil {
+		return nil, fmt.Errorf("no svid received from spiffe workload api (%s)", s.socketPath)
+	}
+	return s.cert, nil
+}
+
+// RootCAs returns the trust bundle of the workload's trust domain, or nil if none was
+// received, in which case the host OS's CA pool applies.
+func (s *SPIFFESource) RootCAs() *x509.CertPool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bundle
+}
+
+func (s *SPIFFESource) Close() {
+	s.cancel()
+	s.conn.Close()
+	s.wg.Wait()
+}
+
+// watch streams FetchX509SVID responses, updating the current identity on every
+// message. The stream is re-established after errors until the source is closed.
+func (s *SPIFFESource) watch(initial chan struct{}) {
+	defer s.wg.Done()
+	client := workload.NewSpiffeWorkloadAPIClient(s.conn)
+
+	for {
+		streamCtx := metadata.AppendToOutgoingContext(s.ctx, spiffeSecurityHeader, "true")
+		stream, err := client.FetchX509SVID(streamCtx, &workload.X509SVIDRequest{})
+		if err == nil {
+			for {
+				response, recvErr := stream.Recv()
+				if recvErr != nil {
+					err = recvErr
+					break
+				}
+				if updateErr := s.update(response); updateErr != nil {
+					log.Warnf("spiffe: could not process svid response: %v", updateErr)
+					continue
+				}
+				select {
+				case <-initial:
+				default:
+					close(initial)
+				}
+			}
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(spiffeRetryInterval):
+			log.Debugf("spiffe: reconnecting to workload api (%s) after: %v", s.socketPath, err)
+		}
+	}
+}
+
+func (s *SPIFFESource) update(response *workload.X509SVIDResponse) error {
+	if len(response.GetSvids()) == 0 {
+		return fmt.Errorf("svid response contained no svids")
+	}
+	svid := response.GetSvids()[0]
+
+	chain, err := x509.ParseCertificates(svid.GetX509Svid())
+	if err != nil {
+		return fmt.Errorf("could not parse svid certificate chain: %w", err)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(svid.GetX509SvidKey())
+	if err != nil {
+		return fmt.Errorf("could not parse svid private key: %w", err)
+	}
+
+	cert := &tls.Certificate{PrivateKey: key, Leaf: chain[0]}
+	for _, c := range chain {
+		cert.Certificate = append(cert.Certificate, c.Raw)
+	}
+
+	var bundle *x509.CertPool
+	if bundleCerts, bundleErr := x509.ParseCertificates(svid.GetBundle()); bundleErr == nil {
+		bundle = x509.NewCertPool()
+		for _, c := range bundleCerts {
+			bundle.AddCert(c)
+		}
+	}
+
+	s.mu.Lock()
+	s.cert = cert
+	s.bundle = bundle
+	s.mu.Unlock()
+
+	log.Debugf("spiffe: received svid %s, valid until %v", svid.GetSpiffeId(), chain[0].NotAfter)
+	return nil
+}
@@ -0,0 +1,73 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package grpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto/workload"
+)
+
+func TestSPIFFESourceUpdate(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	spiffeID, err := url.Parse("spiffe://example.org/nginx-agent")
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "nginx-agent"},
+		URIs:         []*url.URL{spiffeID},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	source := &SPIFFESource{socketPath: "/tmp/spire.sock"}
+
+	_, err = source.GetClientCertificate(nil)
+	assert.Error(t, err)
+
+	err = source.update(&workload.X509SVIDResponse{})
+	assert.Error(t, err)
+	assert.Nil(t, source.RootCAs())
+
+	err = source.update(&workload.X509SVIDResponse{
+		Svids: []*workload.X509SVID{
+			{
+				SpiffeId:    spiffeID.String(),
+				X509Svid:    certDER,
+				X509SvidKey: keyDER,
+				Bundle:      certDER,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	cert, err := source.GetClientCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{certDER}, cert.Certificate)
+	assert.Equal(t, key, cert.PrivateKey)
+	assert.NotNil(t, source.RootCAs())
+}
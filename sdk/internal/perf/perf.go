@@ -0,0 +1,21 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package perf holds test helpers for the sdk benchmark suites.
+package perf
+
+import "testing"
+
+// RequireAllocBudget runs fn repeatedly and fails the test when the average
+// number of heap allocations per run exceeds budget. The budgets are ceilings
+// chosen to catch regressions on hot paths, not targets to optimise towards.
+func RequireAllocBudget(t *testing.T, name string, budget float64, fn func()) {
+	t.Helper()
+	if allocs := testing.AllocsPerRun(100, fn); allocs > budget {
+		t.Errorf("%s allocated %.1f times per op, budget is %.1f", name, allocs, budget)
+	}
+}
@@ -0,0 +1,117 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nginxinc/nginx-go-crossplane"
+)
+
+// ListenAddress is one tcp or udp listen directive address from an nginx config.
+type ListenAddress struct {
+	// Host is the address the listen is bound to, empty for a wildcard listen
+	Host string
+	// Port is the port the listen is bound to
+	Port int
+	// UDP reports whether the listen carries the udp parameter
+	UDP bool
+}
+
+func (l ListenAddress) String() string {
+	host := l.Host
+	if host == "" {
+		host = "*"
+	}
+	return net.JoinHostPort(host, strconv.Itoa(l.Port))
+}
+
+// CollectListenAddresses parses the nginx config rooted at confFile and returns the
+// distinct listen directive addresses, skipping unix sockets and listens whose
+// address comes from a variable.
+func CollectListenAddresses(confFile string) ([]ListenAddress, error) {
+	payload, err := crossplane.Parse(confFile,
+		&crossplane.ParseOptions{
+			SingleFile:         false,
+			StopParsingOnError: true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading config from %s: %v", ErrParse, confFile, err)
+	}
+
+	seen := map[ListenAddress]struct{}{}
+	addresses := []ListenAddress{}
+
+	for _, xpConf := range payload.Config {
+		err = CrossplaneConfigTraverse(&xpConf,
+			func(parent *crossplane.Directive, current *crossplane.Directive) (bool, error) {
+				if current.Directive != "listen" || len(current.Args) == 0 {
+					return true, nil
+				}
+				address, ok := parseListenAddress(current.Args)
+				if !ok {
+					return true, nil
+				}
+				if _, found := seen[address]; !found {
+					seen[address] = struct{}{}
+					addresses = append(addresses, address)
+				}
+				return true, nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		if addresses[i].Port != addresses[j].Port {
+			return addresses[i].Port < addresses[j].Port
+		}
+		return addresses[i].Host < addresses[j].Host
+	})
+	return addresses, nil
+}
+
+// parseListenAddress interprets the arguments of one listen directive. The first
+// argument holds the address, the remaining arguments are parameters of which only
+// udp matters here.
+func parseListenAddress(args []string) (ListenAddress, bool) {
+	address := args[0]
+	if strings.HasPrefix(address, "unix:") || strings.Contains(address, "$") {
+		return ListenAddress{}, false
+	}
+
+	host := ""
+	portArg := address
+	if parsedHost, parsedPort, err := net.SplitHostPort(address); err == nil {
+		host = parsedHost
+		portArg = parsedPort
+	}
+	port, err := strconv.Atoi(portArg)
+	if err != nil || port < 1 || port > 65535 {
+		return ListenAddress{}, false
+	}
+
+	// the wildcard spellings all mean the listen binds every address
+	if host == "*" || host == "0.0.0.0" || host == "::" {
+		host = ""
+	}
+
+	listen := ListenAddress{Host: host, Port: port}
+	for _, arg := range args[1:] {
+		if arg == "udp" {
+			listen.UDP = true
+		}
+	}
+	return listen, true
+}
@@ -0,0 +1,74 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectListenAddresses(t *testing.T) {
+	tmpDir := t.TempDir()
+	confFile := tmpDir + "/nginx.conf"
+	conf := `
+http {
+    server {
+        listen 80;
+        listen [::]:80;
+        listen 127.0.0.1:8080 ssl;
+    }
+    server {
+        listen 80;
+        listen unix:/var/run/nginx.sock;
+        listen $listen_addr;
+    }
+}
+stream {
+    server {
+        listen 127.0.0.1:53 udp;
+    }
+}
+`
+	err := os.WriteFile(confFile, []byte(conf), 0644)
+	assert.NoError(t, err)
+
+	addresses, err := CollectListenAddresses(confFile)
+	assert.NoError(t, err)
+
+	// duplicates, the unix socket and the variable listen are dropped, wildcard
+	// spellings collapse to an empty host
+	assert.Equal(t, []ListenAddress{
+		{Host: "127.0.0.1", Port: 53, UDP: true},
+		{Host: "", Port: 80},
+		{Host: "127.0.0.1", Port: 8080},
+	}, addresses)
+}
+
+func TestParseListenAddress(t *testing.T) {
+	for _, tt := range []struct {
+		args     []string
+		expected ListenAddress
+		ok       bool
+	}{
+		{[]string{"80"}, ListenAddress{Port: 80}, true},
+		{[]string{"*:80"}, ListenAddress{Port: 80}, true},
+		{[]string{"0.0.0.0:80"}, ListenAddress{Port: 80}, true},
+		{[]string{"[::]:443", "ssl"}, ListenAddress{Port: 443}, true},
+		{[]string{"localhost:8080"}, ListenAddress{Host: "localhost", Port: 8080}, true},
+		{[]string{"127.0.0.1:53", "udp"}, ListenAddress{Host: "127.0.0.1", Port: 53, UDP: true}, true},
+		{[]string{"unix:/var/run/nginx.sock"}, ListenAddress{}, false},
+		{[]string{"$listen_addr"}, ListenAddress{}, false},
+		{[]string{"99999"}, ListenAddress{}, false},
+	} {
+		address, ok := parseListenAddress(tt.args)
+		assert.Equal(t, tt.ok, ok, "args %v", tt.args)
+		assert.Equal(t, tt.expected, address, "args %v", tt.args)
+	}
+}
@@ -0,0 +1,102 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+)
+
+// LogAccessDiagnostic describes why a log file could not be opened by the agent:
+// who owns it, its mode, whether ACLs or an SELinux label further restrict it,
+// the error the open attempt returned, and the exact command that would grant
+// the agent read access.
+type LogAccessDiagnostic struct {
+	Owner        string
+	Group        string
+	Mode         string
+	HasACLs      bool
+	SELinuxLabel string
+	OpenError    string
+	Remediation  string
+}
+
+// DiagnoseLogAccess inspects the log file at the given path and reports why it
+// is (or is not) readable by the user the agent is running as.
+func DiagnoseLogAccess(path string) *LogAccessDiagnostic {
+	diagnostic := &LogAccessDiagnostic{}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		diagnostic.OpenError = err.Error()
+		return diagnostic
+	}
+	diagnostic.Mode = info.Mode().String()
+
+	uid, gid, ownerKnown := fileOwnerIDs(info)
+	if ownerKnown {
+		diagnostic.Owner = lookupUserName(uid)
+		diagnostic.Group = lookupGroupName(gid)
+	}
+
+	diagnostic.HasACLs, diagnostic.SELinuxLabel = fileACLInfo(path)
+
+	if handle, openErr := os.Open(path); openErr == nil {
+		handle.Close()
+	} else {
+		diagnostic.OpenError = openErr.Error()
+		if agent, userErr := user.Current(); userErr == nil {
+			diagnostic.Remediation = remediationHint(path, uid, gid, agent)
+		}
+	}
+
+	return diagnostic
+}
+
+func (d *LogAccessDiagnostic) String() string {
+	var parts []string
+	if d.Owner != "" {
+		parts = append(parts, fmt.Sprintf("owner=%s:%s", d.Owner, d.Group))
+	}
+	if d.Mode != "" {
+		parts = append(parts, fmt.Sprintf("mode=%s", d.Mode))
+	}
+	if d.HasACLs {
+		parts = append(parts, "acls=present")
+	}
+	if d.SELinuxLabel != "" {
+		parts = append(parts, fmt.Sprintf("selinux=%s", d.SELinuxLabel))
+	}
+	if d.OpenError != "" {
+		parts = append(parts, fmt.Sprintf("open error=%q", d.OpenError))
+	}
+	summary := strings.Join(parts, " ")
+	if d.Remediation != "" {
+		summary = fmt.Sprintf("%s; to grant access run: %s", summary, d.Remediation)
+	}
+	return summary
+}
+
+func lookupUserName(uid uint32) string {
+	id := strconv.FormatUint(uint64(uid), 10)
+	if owner, err := user.LookupId(id); err == nil {
+		return owner.Username
+	}
+	return id
+}
+
+func lookupGroupName(gid uint32) string {
+	id := strconv.FormatUint(uint64(gid), 10)
+	if group, err := user.LookupGroupId(id); err == nil {
+		return group.Name
+	}
+	return id
+}
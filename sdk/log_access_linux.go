@@ -0,0 +1,33 @@
+//go:build linux
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"strings"
+	"syscall"
+)
+
+const (
+	selinuxXattr  = "security.selinux"
+	posixACLXattr = "system.posix_acl_access"
+)
+
+// fileACLInfo reports whether POSIX ACLs are set on the file and its SELinux
+// label, both read from extended attributes.
+func fileACLInfo(path string) (hasACLs bool, selinuxLabel string) {
+	if size, err := syscall.Getxattr(path, posixACLXattr, nil); err == nil && size > 0 {
+		hasACLs = true
+	}
+	label := make([]byte, 256)
+	if n, err := syscall.Getxattr(path, selinuxXattr, label); err == nil && n > 0 {
+		selinuxLabel = strings.TrimRight(string(label[:n]), "\x00")
+	}
+	return hasACLs, selinuxLabel
+}
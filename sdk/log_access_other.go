@@ -0,0 +1,17 @@
+//go:build !windows && !linux
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+// fileACLInfo reports whether POSIX ACLs are set on the file and its SELinux
+// label. Only Linux exposes these through extended attributes, so other unix
+// platforms report neither.
+func fileACLInfo(path string) (hasACLs bool, selinuxLabel string) {
+	return false, ""
+}
@@ -0,0 +1,64 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnoseLogAccessReadableFile(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "access.log")
+	require.NoError(t, os.WriteFile(logFile, []byte("log line\n"), 0640))
+
+	diagnostic := DiagnoseLogAccess(logFile)
+
+	assert.Empty(t, diagnostic.OpenError)
+	assert.Empty(t, diagnostic.Remediation)
+	assert.Equal(t, "-rw-r-----", diagnostic.Mode)
+	assert.NotEmpty(t, diagnostic.Owner)
+	assert.NotEmpty(t, diagnostic.Group)
+}
+
+func TestDiagnoseLogAccessMissingFile(t *testing.T) {
+	diagnostic := DiagnoseLogAccess(filepath.Join(t.TempDir(), "missing.log"))
+
+	assert.NotEmpty(t, diagnostic.OpenError)
+	assert.Contains(t, diagnostic.String(), "open error=")
+}
+
+func TestDiagnoseLogAccessUnreadableFile(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("file modes do not restrict root")
+	}
+
+	logFile := filepath.Join(t.TempDir(), "access.log")
+	require.NoError(t, os.WriteFile(logFile, []byte("log line\n"), 0000))
+
+	diagnostic := DiagnoseLogAccess(logFile)
+
+	assert.NotEmpty(t, diagnostic.OpenError)
+	assert.Contains(t, diagnostic.Remediation, "chmod")
+	assert.Contains(t, diagnostic.String(), "to grant access run:")
+}
+
+func TestRemediationHint(t *testing.T) {
+	agent := &user.User{Uid: "901", Gid: "901"}
+
+	assert.Equal(t, "chmod u+r /var/log/nginx/access.log", remediationHint("/var/log/nginx/access.log", 901, 33, agent))
+
+	hint := remediationHint("/var/log/nginx/access.log", 0, 33, agent)
+	assert.True(t, strings.HasPrefix(hint, "chgrp "), hint)
+	assert.Contains(t, hint, "chmod g+r /var/log/nginx/access.log")
+}
@@ -0,0 +1,53 @@
+//go:build !windows
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// fileOwnerIDs returns the numeric owner and group of the file, if the
+// platform exposes them.
+func fileOwnerIDs(info os.FileInfo) (uid, gid uint32, ok bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return stat.Uid, stat.Gid, true
+}
+
+// remediationHint returns the chmod/chown invocation that would give the agent
+// user read access to the file, picking the least invasive change: widen the
+// owner or group read bit if the agent already matches, otherwise hand the file
+// to the agent's group.
+func remediationHint(path string, uid, gid uint32, agent *user.User) string {
+	if agent.Uid == strconv.FormatUint(uint64(uid), 10) {
+		return fmt.Sprintf("chmod u+r %s", path)
+	}
+
+	agentGid := strconv.FormatUint(uint64(gid), 10)
+	if groups, err := agent.GroupIds(); err == nil {
+		for _, group := range groups {
+			if group == agentGid {
+				return fmt.Sprintf("chmod g+r %s", path)
+			}
+		}
+	}
+
+	agentGroup := agent.Gid
+	if agentGroupEntry, err := user.LookupGroupId(agent.Gid); err == nil {
+		agentGroup = agentGroupEntry.Name
+	}
+	return fmt.Sprintf("chgrp %s %s && chmod g+r %s", agentGroup, path, path)
+}
@@ -0,0 +1,36 @@
+//go:build windows
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+)
+
+// fileOwnerIDs returns the numeric owner and group of the file, if the
+// platform exposes them. Windows does not use numeric uids/gids, so ownership
+// is reported as unknown and callers fall back to mode and open error details.
+func fileOwnerIDs(info os.FileInfo) (uid, gid uint32, ok bool) {
+	return 0, 0, false
+}
+
+// fileACLInfo reports whether POSIX ACLs are set on the file and its SELinux
+// label. Neither concept exists on Windows, where access is governed by the
+// file's security descriptor instead.
+func fileACLInfo(path string) (hasACLs bool, selinuxLabel string) {
+	return false, ""
+}
+
+// remediationHint returns the icacls invocation that would grant the agent
+// user read access to the file.
+func remediationHint(path string, uid, gid uint32, agent *user.User) string {
+	return fmt.Sprintf("icacls %s /grant %s:R", path, agent.Username)
+}
@@ -0,0 +1,279 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/nginx/agent/sdk/v2/checksum"
+	sdkGRPC "github.com/nginx/agent/sdk/v2/grpc"
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+const downloadChunkSize = 4 * 1024
+
+// CommanderService is an in-memory Commander implementation. It captures every
+// command and upload a client sends and replays scripted commands, answering
+// each AgentConnectRequest with CONNECT_OK unless a test disables that.
+type CommanderService struct {
+	sync.Mutex
+	toClient        chan *proto.Command
+	received        chan *proto.Command
+	connectReceived chan *proto.AgentConnectRequest
+	downloads       chan []*proto.DataChunk
+
+	commands        []*proto.Command
+	connectRequests []*proto.AgentConnectRequest
+	uploadedChunks  []*proto.DataChunk
+	scripted        []*proto.Command
+
+	connectResponseDisabled bool
+}
+
+func newCommanderService() *CommanderService {
+	return &CommanderService{
+		toClient:        make(chan *proto.Command, 100),
+		received:        make(chan *proto.Command, 100),
+		connectReceived: make(chan *proto.AgentConnectRequest, 100),
+		downloads:       make(chan []*proto.DataChunk, 100),
+	}
+}
+
+// CommandChannel serves the bidirectional command stream. Commands scripted
+// before the stream opened are delivered first.
+func (c *CommanderService) CommandChannel(stream proto.Commander_CommandChannelServer) error {
+	c.Lock()
+	for _, cmd := range c.scripted {
+		c.toClient <- cmd
+	}
+	c.scripted = nil
+	c.Unlock()
+
+	go func() {
+		for {
+			cmd, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			c.handleCommand(cmd)
+		}
+	}()
+
+	for {
+		select {
+		case cmd := <-c.toClient:
+			if err := stream.Send(cmd); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// Download streams the next config ScriptDownload queued and closes the
+// stream, the way a management server answers one download request.
+func (c *CommanderService) Download(request *proto.DownloadRequest, server proto.Commander_DownloadServer) error {
+	select {
+	case chunks := <-c.downloads:
+		for _, chunk := range chunks {
+			if err := server.Send(chunk); err != nil {
+				return err
+			}
+		}
+		return nil
+	case <-server.Context().Done():
+		return nil
+	}
+}
+
+// Upload captures the uploaded chunks and acknowledges them.
+func (c *CommanderService) Upload(server proto.Commander_UploadServer) error {
+	for {
+		chunk, err := server.Recv()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if chunk != nil {
+			c.Lock()
+			c.uploadedChunks = append(c.uploadedChunks, chunk)
+			c.Unlock()
+		}
+		if err == io.EOF {
+			return server.SendAndClose(&proto.UploadStatus{Status: proto.UploadStatus_OK})
+		}
+	}
+}
+
+// Send delivers one command to the connected client.
+func (c *CommanderService) Send(cmd *proto.Command) {
+	c.toClient <- cmd
+}
+
+// Script queues commands for delivery as soon as the next command channel
+// opens, so a test can stage a sequence before the client connects.
+func (c *CommanderService) Script(cmds ...*proto.Command) {
+	c.Lock()
+	defer c.Unlock()
+	c.scripted = append(c.scripted, cmds...)
+}
+
+// ScriptDownload queues a config for the next download request, chunked the
+// way a management server would send it.
+func (c *CommanderService) ScriptDownload(messageID string, config *proto.NginxConfig) error {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	meta := sdkGRPC.NewMessageMeta(messageID)
+	chunks := checksum.Chunk(payload, downloadChunkSize)
+
+	sequence := []*proto.DataChunk{{
+		Chunk: &proto.DataChunk_Header{
+			Header: &proto.ChunkedResourceHeader{
+				Chunks:   int32(len(chunks)),
+				Checksum: checksum.Checksum(payload),
+				Meta:     meta,
+			},
+		},
+	}}
+	for id, chunk := range chunks {
+		sequence = append(sequence, &proto.DataChunk{
+			Chunk: &proto.DataChunk_Data{
+				Data: &proto.ChunkedResourceChunk{
+					ChunkId: int32(id),
+					Data:    chunk,
+					Meta:    meta,
+				},
+			},
+		})
+	}
+	c.downloads <- sequence
+	return nil
+}
+
+// DisableConnectResponse stops the service answering connect requests, so a
+// test can script its own AgentConnectResponse or none at all.
+func (c *CommanderService) DisableConnectResponse() {
+	c.Lock()
+	defer c.Unlock()
+	c.connectResponseDisabled = true
+}
+
+// Commands returns a copy of every command received so far.
+func (c *CommanderService) Commands() []*proto.Command {
+	c.Lock()
+	defer c.Unlock()
+	return append([]*proto.Command{}, c.commands...)
+}
+
+// ConnectRequests returns a copy of every AgentConnectRequest received so far.
+func (c *CommanderService) ConnectRequests() []*proto.AgentConnectRequest {
+	c.Lock()
+	defer c.Unlock()
+	return append([]*proto.AgentConnectRequest{}, c.connectRequests...)
+}
+
+// WaitForCommand returns the next command the client sends, or an error when
+// none arrives within the timeout.
+func (c *CommanderService) WaitForCommand(timeout time.Duration) (*proto.Command, error) {
+	select {
+	case cmd := <-c.received:
+		return cmd, nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("no command received within %s", timeout)
+	}
+}
+
+// WaitForConnectRequest returns the next AgentConnectRequest the client sends,
+// or an error when none arrives within the timeout.
+func (c *CommanderService) WaitForConnectRequest(timeout time.Duration) (*proto.AgentConnectRequest, error) {
+	select {
+	case request := <-c.connectReceived:
+		return request, nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("no connect request received within %s", timeout)
+	}
+}
+
+// UploadedConfigs reassembles every config upload captured so far.
+func (c *CommanderService) UploadedConfigs() []*proto.NginxConfig {
+	c.Lock()
+	chunks := append([]*proto.DataChunk{}, c.uploadedChunks...)
+	c.Unlock()
+
+	var configs []*proto.NginxConfig
+	var contents []byte
+	flush := func() {
+		if contents == nil {
+			return
+		}
+		var config *proto.NginxConfig
+		if err := json.Unmarshal(contents, &config); err == nil {
+			configs = append(configs, config)
+		}
+		contents = nil
+	}
+	for _, chunk := range chunks {
+		switch data := chunk.Chunk.(type) {
+		case *proto.DataChunk_Header:
+			flush()
+			contents = make([]byte, 0)
+		case *proto.DataChunk_Data:
+			contents = append(contents, data.Data.Data...)
+		}
+	}
+	flush()
+	return configs
+}
+
+func (c *CommanderService) handleCommand(cmd *proto.Command) {
+	c.Lock()
+	c.commands = append(c.commands, cmd)
+	respond := !c.connectResponseDisabled
+	c.Unlock()
+
+	if request, ok := cmd.Data.(*proto.Command_AgentConnectRequest); ok {
+		c.Lock()
+		c.connectRequests = append(c.connectRequests, request.AgentConnectRequest)
+		c.Unlock()
+		select {
+		case c.connectReceived <- request.AgentConnectRequest:
+		default:
+		}
+		if respond {
+			c.Send(connectResponse(cmd))
+		}
+	}
+
+	select {
+	case c.received <- cmd:
+	default:
+	}
+}
+
+// connectResponse builds the CONNECT_OK answer to one connect request.
+func connectResponse(cmd *proto.Command) *proto.Command {
+	return &proto.Command{
+		Meta: sdkGRPC.NewMessageMeta(cmd.GetMeta().GetMessageId()),
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_AgentConnectResponse{
+			AgentConnectResponse: &proto.AgentConnectResponse{
+				Status: &proto.AgentConnectStatus{
+					StatusCode: proto.AgentConnectStatus_CONNECT_OK,
+					Message:    "Connected",
+				},
+			},
+		},
+	}
+}
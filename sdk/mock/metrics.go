@@ -0,0 +1,105 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package mock
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	models "github.com/nginx/agent/sdk/v2/proto/events"
+)
+
+// MetricsService is an in-memory MetricsService implementation that captures
+// every metrics report and event report a client streams.
+type MetricsService struct {
+	sync.Mutex
+	reportReceived chan *proto.MetricsReport
+	eventReceived  chan *models.EventReport
+
+	reports      []*proto.MetricsReport
+	eventReports []*models.EventReport
+}
+
+func newMetricsService() *MetricsService {
+	return &MetricsService{
+		reportReceived: make(chan *proto.MetricsReport, 100),
+		eventReceived:  make(chan *models.EventReport, 100),
+	}
+}
+
+// Stream captures the metrics reports the client sends.
+func (m *MetricsService) Stream(stream proto.MetricsService_StreamServer) error {
+	for {
+		report, err := stream.Recv()
+		if err != nil {
+			return nil
+		}
+		m.Lock()
+		m.reports = append(m.reports, report)
+		m.Unlock()
+		select {
+		case m.reportReceived <- report:
+		default:
+		}
+	}
+}
+
+// StreamEvents captures the event reports the client sends.
+func (m *MetricsService) StreamEvents(stream proto.MetricsService_StreamEventsServer) error {
+	for {
+		report, err := stream.Recv()
+		if err != nil {
+			return nil
+		}
+		m.Lock()
+		m.eventReports = append(m.eventReports, report)
+		m.Unlock()
+		select {
+		case m.eventReceived <- report:
+		default:
+		}
+	}
+}
+
+// Reports returns a copy of every metrics report received so far.
+func (m *MetricsService) Reports() []*proto.MetricsReport {
+	m.Lock()
+	defer m.Unlock()
+	return append([]*proto.MetricsReport{}, m.reports...)
+}
+
+// EventReports returns a copy of every event report received so far.
+func (m *MetricsService) EventReports() []*models.EventReport {
+	m.Lock()
+	defer m.Unlock()
+	return append([]*models.EventReport{}, m.eventReports...)
+}
+
+// WaitForReport returns the next metrics report the client sends, or an error
+// when none arrives within the timeout.
+func (m *MetricsService) WaitForReport(timeout time.Duration) (*proto.MetricsReport, error) {
+	select {
+	case report := <-m.reportReceived:
+		return report, nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("no metrics report received within %s", timeout)
+	}
+}
+
+// WaitForEventReport returns the next event report the client sends, or an
+// error when none arrives within the timeout.
+func (m *MetricsService) WaitForEventReport(timeout time.Duration) (*models.EventReport, error) {
+	select {
+	case report := <-m.eventReceived:
+		return report, nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("no event report received within %s", timeout)
+	}
+}
@@ -0,0 +1,79 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package mock provides an in-memory control plane for tests. It serves the
+// Commander and MetricsService gRPC interfaces over a bufconn listener, so an
+// agent or an SDK client can register, receive scripted commands and upload
+// payloads without a running management server or any containers.
+package mock
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	sdkGRPC "github.com/nginx/agent/sdk/v2/grpc"
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+const listenerBufferSize = 1024 * 1024
+
+// Server is an in-memory control plane. The embedded services capture
+// everything a client sends and replay whatever a test scripts.
+type Server struct {
+	Commander *CommanderService
+	Metrics   *MetricsService
+
+	grpcServer *grpc.Server
+	listener   *bufconn.Listener
+}
+
+// NewServer starts an in-memory control plane serving the Commander and
+// MetricsService interfaces. Stop it with Close.
+func NewServer() *Server {
+	server := &Server{
+		Commander:  newCommanderService(),
+		Metrics:    newMetricsService(),
+		grpcServer: grpc.NewServer(sdkGRPC.DefaultServerDialOptions...),
+		listener:   bufconn.Listen(listenerBufferSize),
+	}
+
+	proto.RegisterCommanderServer(server.grpcServer, server.Commander)
+	proto.RegisterMetricsServiceServer(server.grpcServer, server.Metrics)
+
+	go func() {
+		// Serve returns when Close stops the server
+		_ = server.grpcServer.Serve(server.listener)
+	}()
+
+	return server
+}
+
+// Dialer returns a context dialer that connects to the in-memory listener,
+// for callers assembling their own dial options.
+func (s *Server) Dialer() func(context.Context, string) (net.Conn, error) {
+	return func(context.Context, string) (net.Conn, error) {
+		return s.listener.Dial()
+	}
+}
+
+// DialOptions returns the dial options a client needs to reach the server:
+// the in-memory dialer and insecure transport credentials.
+func (s *Server) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithContextDialer(s.Dialer()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+}
+
+// Close stops the server and disconnects every client.
+func (s *Server) Close() {
+	s.grpcServer.Stop()
+}
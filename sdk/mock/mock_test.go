@@ -0,0 +1,131 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package mock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/client"
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+func TestMockServerCommandCapture(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Commander.Script(&proto.Command{Meta: &proto.Metadata{MessageId: "scripted-1"}})
+
+	commander := client.NewCommanderClient()
+	commander.WithServer("bufnet")
+	commander.WithDialOptions(server.DialOptions()...)
+	require.NoError(t, commander.Connect(context.Background()))
+	defer commander.Close()
+
+	select {
+	case scripted := <-commander.Recv():
+		assert.Equal(t, "scripted-1", scripted.Meta().GetMessageId())
+	case <-time.After(2 * time.Second):
+		t.Fatal("scripted command was not delivered")
+	}
+
+	connect := &proto.Command{
+		Meta: &proto.Metadata{MessageId: "connect-1"},
+		Data: &proto.Command_AgentConnectRequest{
+			AgentConnectRequest: &proto.AgentConnectRequest{
+				Meta: &proto.AgentMeta{SystemUid: "system-1"},
+			},
+		},
+	}
+	require.NoError(t, commander.Send(context.Background(), client.MessageFromCommand(connect)))
+
+	request, err := server.Commander.WaitForConnectRequest(2 * time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "system-1", request.GetMeta().GetSystemUid())
+	assert.Len(t, server.Commander.ConnectRequests(), 1)
+
+	select {
+	case response := <-commander.Recv():
+		cmd, ok := response.Raw().(*proto.Command)
+		require.True(t, ok)
+		assert.Equal(t, proto.AgentConnectStatus_CONNECT_OK, cmd.GetAgentConnectResponse().GetStatus().GetStatusCode())
+	case <-time.After(2 * time.Second):
+		t.Fatal("connect response was not delivered")
+	}
+}
+
+func TestMockServerDownload(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	expected := &proto.NginxConfig{
+		Action: proto.NginxConfigAction_APPLY,
+		ConfigData: &proto.ConfigDescriptor{
+			SystemId: "system-1",
+			NginxId:  "nginx-1",
+		},
+	}
+	require.NoError(t, server.Commander.ScriptDownload("download-1", expected))
+
+	commander := client.NewCommanderClient()
+	commander.WithServer("bufnet")
+	commander.WithDialOptions(server.DialOptions()...)
+	require.NoError(t, commander.Connect(context.Background()))
+	defer commander.Close()
+
+	config, err := commander.Download(context.Background(), &proto.Metadata{MessageId: "download-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "nginx-1", config.GetConfigData().GetNginxId())
+}
+
+func TestMockServerUploadCapture(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	commander := client.NewCommanderClient()
+	commander.WithServer("bufnet")
+	commander.WithDialOptions(server.DialOptions()...)
+	require.NoError(t, commander.Connect(context.Background()))
+	defer commander.Close()
+
+	uploaded := &proto.NginxConfig{
+		Action: proto.NginxConfigAction_RETURN,
+		ConfigData: &proto.ConfigDescriptor{
+			SystemId: "system-1",
+			NginxId:  "nginx-1",
+		},
+	}
+	require.NoError(t, commander.Upload(context.Background(), uploaded, "upload-1"))
+
+	configs := server.Commander.UploadedConfigs()
+	require.Len(t, configs, 1)
+	assert.Equal(t, "nginx-1", configs[0].GetConfigData().GetNginxId())
+}
+
+func TestMockServerMetricsCapture(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	reporter := client.NewMetricReporterClient()
+	reporter.WithServer("bufnet")
+	reporter.WithDialOptions(server.DialOptions()...)
+	require.NoError(t, reporter.Connect(context.Background()))
+	defer reporter.Close()
+
+	report := &proto.MetricsReport{Meta: &proto.Metadata{MessageId: "report-1"}}
+	require.NoError(t, reporter.Send(context.Background(), client.MessageFromMetrics(report)))
+
+	received, err := server.Metrics.WaitForReport(2 * time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "report-1", received.GetMeta().GetMessageId())
+	assert.Len(t, server.Metrics.Reports(), 1)
+}
@@ -0,0 +1,87 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+// moduleSignatureRegex matches the binary compatibility signature nginx embeds in
+// every dynamically loadable module: the pointer sizes followed by the feature bits
+// the module was built against.
+var moduleSignatureRegex = regexp.MustCompile(`[0-9]+,[0-9]+,[0-9]+,[01]{16,}`)
+
+// moduleVersionRegex matches version strings embedded in a module binary, such as
+// the nginx version the module was built for.
+var moduleVersionRegex = regexp.MustCompile(`nginx/[0-9]+\.[0-9]+\.[0-9]+`)
+
+// CollectLoadedModules parses the nginx config rooted at confFile and inventories
+// every module referenced by a load_module directive: whether the binary is present
+// on disk, its size, and the compatibility signature and version information
+// embedded in it where they could be extracted. Relative module paths are resolved
+// against the directory of the config file.
+func CollectLoadedModules(confFile string) ([]*proto.NginxLoadedModule, error) {
+	parsed, err := ParseConfig(confFile)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.LoadedModules(), nil
+}
+
+// LoadedModules inventories the modules referenced by load_module directives in
+// the parsed config, see CollectLoadedModules
+func (p *ParsedConfig) LoadedModules() []*proto.NginxLoadedModule {
+	modules := []*proto.NginxLoadedModule{}
+	seen := map[string]struct{}{}
+	for _, conf := range p.payload.Config {
+		for _, directive := range conf.Parsed {
+			if directive.Directive != "load_module" || len(directive.Args) == 0 {
+				continue
+			}
+			path := directive.Args[0]
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(filepath.Dir(p.confFile), path)
+			}
+			if _, ok := seen[path]; ok {
+				continue
+			}
+			seen[path] = struct{}{}
+			modules = append(modules, inspectModule(path))
+		}
+	}
+
+	return modules
+}
+
+func inspectModule(path string) *proto.NginxLoadedModule {
+	module := &proto.NginxLoadedModule{
+		Name: strings.TrimSuffix(filepath.Base(path), ".so"),
+		Path: path,
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return module
+	}
+	module.Exists = true
+	module.Size_ = stat.Size()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return module
+	}
+	module.Signature = string(moduleSignatureRegex.Find(content))
+	module.Version = string(moduleVersionRegex.Find(content))
+
+	return module
+}
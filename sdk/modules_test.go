@@ -0,0 +1,60 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectLoadedModules(t *testing.T) {
+	dir := t.TempDir()
+
+	// a fake module binary with an embedded signature and version string, the way
+	// a real .so carries them between other binary content
+	signature := "8,4,8,0011111010011111101110"
+	moduleFile := filepath.Join(dir, "modules", "ngx_http_geoip_module.so")
+	require.NoError(t, os.MkdirAll(filepath.Dir(moduleFile), 0755))
+	content := append([]byte{0x7f, 'E', 'L', 'F', 0x00}, []byte(signature)...)
+	content = append(content, 0x00)
+	content = append(content, []byte("nginx/1.23.2")...)
+	require.NoError(t, os.WriteFile(moduleFile, content, 0644))
+
+	confFile := filepath.Join(dir, "nginx.conf")
+	require.NoError(t, os.WriteFile(confFile, []byte(fmt.Sprintf(`
+load_module %s;
+load_module modules/ngx_http_missing_module.so;
+events {}
+http {}
+`, moduleFile)), 0644))
+
+	modules, err := CollectLoadedModules(confFile)
+	require.NoError(t, err)
+	require.Len(t, modules, 2)
+
+	geoip := modules[0]
+	assert.Equal(t, "ngx_http_geoip_module", geoip.GetName())
+	assert.Equal(t, moduleFile, geoip.GetPath())
+	assert.True(t, geoip.GetExists())
+	assert.Equal(t, int64(len(content)), geoip.GetSize_())
+	assert.Equal(t, signature, geoip.GetSignature())
+	assert.Equal(t, "nginx/1.23.2", geoip.GetVersion())
+
+	// a relative path resolves against the config directory; the missing binary is
+	// still inventoried so callers can warn about it
+	missing := modules[1]
+	assert.Equal(t, "ngx_http_missing_module", missing.GetName())
+	assert.Equal(t, filepath.Join(dir, "modules", "ngx_http_missing_module.so"), missing.GetPath())
+	assert.False(t, missing.GetExists())
+	assert.Empty(t, missing.GetSignature())
+}
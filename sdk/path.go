@@ -0,0 +1,46 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// caseInsensitiveFilesystem reports whether paths on the host filesystem are
+// compared without regard to case, as on Windows and macOS
+var caseInsensitiveFilesystem = runtime.GOOS == "windows" || runtime.GOOS == "darwin"
+
+// NormalizePath returns the canonical form of path used for allowed directory
+// comparisons: cleaned of redundant separators, "." and ".." elements and
+// trailing slashes, and case-folded on case-insensitive filesystems. The
+// result is a purely lexical normalization, no symlinks are resolved.
+func NormalizePath(path string) string {
+	cleaned := filepath.Clean(path)
+	if caseInsensitiveFilesystem {
+		cleaned = strings.ToLower(cleaned)
+	}
+	return cleaned
+}
+
+// PathWithinDirectory reports whether path is dir itself or lies inside it.
+// Both arguments are normalized first, so the answer does not depend on
+// trailing slashes or casing, and a sibling sharing the directory name as a
+// prefix (e.g. /etc/nginx-other for /etc/nginx) does not match.
+func PathWithinDirectory(path, dir string) bool {
+	path = NormalizePath(path)
+	dir = NormalizePath(dir)
+	if path == dir {
+		return true
+	}
+	if dir == string(filepath.Separator) {
+		return strings.HasPrefix(path, dir)
+	}
+	return strings.HasPrefix(path, dir+string(filepath.Separator))
+}
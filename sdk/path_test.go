@@ -0,0 +1,67 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePath(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{"already clean", "/etc/nginx/nginx.conf", "/etc/nginx/nginx.conf"},
+		{"trailing slash", "/etc/nginx/", "/etc/nginx"},
+		{"redundant separators", "/etc//nginx///conf.d", "/etc/nginx/conf.d"},
+		{"dot elements", "/etc/nginx/./conf.d/../nginx.conf", "/etc/nginx/nginx.conf"},
+		{"root", "/", "/"},
+		{"relative", "conf.d/../nginx.conf", "nginx.conf"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizePath(tt.path))
+		})
+	}
+}
+
+func TestPathWithinDirectory(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		path     string
+		dir      string
+		expected bool
+	}{
+		{"file in directory", "/etc/nginx/nginx.conf", "/etc/nginx", true},
+		{"directory itself", "/etc/nginx", "/etc/nginx", true},
+		{"trailing slash on directory", "/etc/nginx/nginx.conf", "/etc/nginx/", true},
+		{"trailing slash on path", "/etc/nginx/conf.d/", "/etc/nginx", true},
+		{"nested file", "/etc/nginx/conf.d/ssl/site.conf", "/etc/nginx", true},
+		{"sibling sharing prefix", "/etc/nginx-other/nginx.conf", "/etc/nginx", false},
+		{"outside directory", "/etc/passwd", "/etc/nginx", false},
+		{"escape through dot dot", "/etc/nginx/../passwd", "/etc/nginx", false},
+		{"root directory", "/etc/nginx/nginx.conf", "/", true},
+		{"unclean directory", "/etc/nginx/nginx.conf", "/etc//nginx/", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PathWithinDirectory(tt.path, tt.dir))
+		})
+	}
+}
+
+func TestPathWithinDirectoryCaseFolding(t *testing.T) {
+	defer func(insensitive bool) { caseInsensitiveFilesystem = insensitive }(caseInsensitiveFilesystem)
+
+	caseInsensitiveFilesystem = false
+	assert.False(t, PathWithinDirectory("/Etc/Nginx/nginx.conf", "/etc/nginx"))
+
+	caseInsensitiveFilesystem = true
+	assert.True(t, PathWithinDirectory("/Etc/Nginx/nginx.conf", "/etc/nginx"))
+}
@@ -24,6 +24,38 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// Maturity levels of agent functionality
+type AgentMaturity_Level int32
+
+const (
+	// Functionality is stable
+	AgentMaturity_STABLE AgentMaturity_Level = 0
+	// Functionality may change or be removed without notice
+	AgentMaturity_EXPERIMENTAL AgentMaturity_Level = 1
+	// Functionality will be removed in a future release
+	AgentMaturity_DEPRECATED AgentMaturity_Level = 2
+)
+
+var AgentMaturity_Level_name = map[int32]string{
+	0: "STABLE",
+	1: "EXPERIMENTAL",
+	2: "DEPRECATED",
+}
+
+var AgentMaturity_Level_value = map[string]int32{
+	"STABLE":       0,
+	"EXPERIMENTAL": 1,
+	"DEPRECATED":   2,
+}
+
+func (x AgentMaturity_Level) String() string {
+	return proto.EnumName(AgentMaturity_Level_name, int32(x))
+}
+
+func (AgentMaturity_Level) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{1, 0}
+}
+
 // Different status codes for agent connect response
 type AgentConnectStatus_StatusCode int32
 
@@ -57,7 +89,7 @@ func (x AgentConnectStatus_StatusCode) String() string {
 }
 
 func (AgentConnectStatus_StatusCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{1, 0}
+	return fileDescriptor_56ede974c0020f77, []int{2, 0}
 }
 
 // Log level enum
@@ -97,7 +129,7 @@ func (x AgentLogging_Level) String() string {
 }
 
 func (AgentLogging_Level) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{6, 0}
+	return fileDescriptor_56ede974c0020f77, []int{7, 0}
 }
 
 // Represents an agent connect request that is sent from the agent to the management server
@@ -111,9 +143,11 @@ type AgentConnectRequest struct {
 	Host *HostInfo `protobuf:"bytes,4,opt,name=host,proto3" json:"host"`
 	// Provides information about software installed in the system (e.g. App Protect WAF, NGINX, etc.)
 	DataplaneSoftwareDetails []*DataplaneSoftwareDetails `protobuf:"bytes,5,rep,name=dataplane_software_details,json=dataplaneSoftwareDetails,proto3" json:"dataplane_software_details"`
-	XXX_NoUnkeyedLiteral     struct{}                    `json:"-"`
-	XXX_unrecognized         []byte                      `json:"-"`
-	XXX_sizecache            int32                       `json:"-"`
+	// Reports experimental or deprecated functionality the agent is configured to use
+	Maturity             []*AgentMaturity `protobuf:"bytes,6,rep,name=maturity,proto3" json:"maturity"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *AgentConnectRequest) Reset()         { *m = AgentConnectRequest{} }
@@ -177,6 +211,80 @@ func (m *AgentConnectRequest) GetDataplaneSoftwareDetails() []*DataplaneSoftware
 	return nil
 }
 
+func (m *AgentConnectRequest) GetMaturity() []*AgentMaturity {
+	if m != nil {
+		return m.Maturity
+	}
+	return nil
+}
+
+// Describes the maturity of one plugin or configuration option the agent is using
+type AgentMaturity struct {
+	// Name of the plugin or configuration option
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name"`
+	// Maturity level of the functionality
+	Level AgentMaturity_Level `protobuf:"varint,2,opt,name=level,proto3,enum=f5.nginx.agent.sdk.AgentMaturity_Level" json:"level"`
+	// Describes the consequences of the level, such as the replacement for a deprecated option
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AgentMaturity) Reset()         { *m = AgentMaturity{} }
+func (m *AgentMaturity) String() string { return proto.CompactTextString(m) }
+func (*AgentMaturity) ProtoMessage()    {}
+func (*AgentMaturity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{1}
+}
+func (m *AgentMaturity) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentMaturity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentMaturity.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentMaturity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentMaturity.Merge(m, src)
+}
+func (m *AgentMaturity) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentMaturity) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentMaturity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentMaturity proto.InternalMessageInfo
+
+func (m *AgentMaturity) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AgentMaturity) GetLevel() AgentMaturity_Level {
+	if m != nil {
+		return m.Level
+	}
+	return AgentMaturity_STABLE
+}
+
+func (m *AgentMaturity) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 // Represents an agent connect status
 type AgentConnectStatus struct {
 	// Provides a status of the agent connect response
@@ -194,7 +302,7 @@ func (m *AgentConnectStatus) Reset()         { *m = AgentConnectStatus{} }
 func (m *AgentConnectStatus) String() string { return proto.CompactTextString(m) }
 func (*AgentConnectStatus) ProtoMessage()    {}
 func (*AgentConnectStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{1}
+	return fileDescriptor_56ede974c0020f77, []int{2}
 }
 func (m *AgentConnectStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -259,7 +367,7 @@ func (m *AgentConnectResponse) Reset()         { *m = AgentConnectResponse{} }
 func (m *AgentConnectResponse) String() string { return proto.CompactTextString(m) }
 func (*AgentConnectResponse) ProtoMessage()    {}
 func (*AgentConnectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{2}
+	return fileDescriptor_56ede974c0020f77, []int{3}
 }
 func (m *AgentConnectResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -314,7 +422,7 @@ func (m *AgentConfigRequest) Reset()         { *m = AgentConfigRequest{} }
 func (m *AgentConfigRequest) String() string { return proto.CompactTextString(m) }
 func (*AgentConfigRequest) ProtoMessage()    {}
 func (*AgentConfigRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{3}
+	return fileDescriptor_56ede974c0020f77, []int{4}
 }
 func (m *AgentConfigRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -361,7 +469,7 @@ func (m *AgentConfig) Reset()         { *m = AgentConfig{} }
 func (m *AgentConfig) String() string { return proto.CompactTextString(m) }
 func (*AgentConfig) ProtoMessage()    {}
 func (*AgentConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{4}
+	return fileDescriptor_56ede974c0020f77, []int{5}
 }
 func (m *AgentConfig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -430,7 +538,7 @@ func (m *AgentDetails) Reset()         { *m = AgentDetails{} }
 func (m *AgentDetails) String() string { return proto.CompactTextString(m) }
 func (*AgentDetails) ProtoMessage()    {}
 func (*AgentDetails) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{5}
+	return fileDescriptor_56ede974c0020f77, []int{6}
 }
 func (m *AgentDetails) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -512,7 +620,7 @@ func (m *AgentLogging) Reset()         { *m = AgentLogging{} }
 func (m *AgentLogging) String() string { return proto.CompactTextString(m) }
 func (*AgentLogging) ProtoMessage()    {}
 func (*AgentLogging) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{6}
+	return fileDescriptor_56ede974c0020f77, []int{7}
 }
 func (m *AgentLogging) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -605,17 +713,19 @@ type AgentMeta struct {
 	// ID of the system where the agent is installed
 	SystemUid string `protobuf:"bytes,7,opt,name=system_uid,json=systemUid,proto3" json:"system_uid"`
 	// Provides other agent information
-	AgentDetails         *AgentDetails `protobuf:"bytes,8,opt,name=agent_details,json=agentDetails,proto3" json:"agent_details"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	AgentDetails *AgentDetails `protobuf:"bytes,8,opt,name=agent_details,json=agentDetails,proto3" json:"agent_details"`
+	// Static labels for fleet partitioning (e.g. team, environment, datacenter)
+	Labels               map[string]string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *AgentMeta) Reset()         { *m = AgentMeta{} }
 func (m *AgentMeta) String() string { return proto.CompactTextString(m) }
 func (*AgentMeta) ProtoMessage()    {}
 func (*AgentMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{7}
+	return fileDescriptor_56ede974c0020f77, []int{8}
 }
 func (m *AgentMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -693,10 +803,19 @@ func (m *AgentMeta) GetAgentDetails() *AgentDetails {
 	return nil
 }
 
+func (m *AgentMeta) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("f5.nginx.agent.sdk.AgentMaturity_Level", AgentMaturity_Level_name, AgentMaturity_Level_value)
 	proto.RegisterEnum("f5.nginx.agent.sdk.AgentConnectStatus_StatusCode", AgentConnectStatus_StatusCode_name, AgentConnectStatus_StatusCode_value)
 	proto.RegisterEnum("f5.nginx.agent.sdk.AgentLogging_Level", AgentLogging_Level_name, AgentLogging_Level_value)
 	proto.RegisterType((*AgentConnectRequest)(nil), "f5.nginx.agent.sdk.AgentConnectRequest")
+	proto.RegisterType((*AgentMaturity)(nil), "f5.nginx.agent.sdk.AgentMaturity")
 	proto.RegisterType((*AgentConnectStatus)(nil), "f5.nginx.agent.sdk.AgentConnectStatus")
 	proto.RegisterType((*AgentConnectResponse)(nil), "f5.nginx.agent.sdk.AgentConnectResponse")
 	proto.RegisterType((*AgentConfigRequest)(nil), "f5.nginx.agent.sdk.AgentConfigRequest")
@@ -704,80 +823,91 @@ func init() {
 	proto.RegisterType((*AgentDetails)(nil), "f5.nginx.agent.sdk.AgentDetails")
 	proto.RegisterType((*AgentLogging)(nil), "f5.nginx.agent.sdk.AgentLogging")
 	proto.RegisterType((*AgentMeta)(nil), "f5.nginx.agent.sdk.AgentMeta")
+	proto.RegisterMapType((map[string]string)(nil), "f5.nginx.agent.sdk.AgentMeta.LabelsEntry")
 }
 
 func init() { proto.RegisterFile("agent.proto", fileDescriptor_56ede974c0020f77) }
 
 var fileDescriptor_56ede974c0020f77 = []byte{
-	// 1081 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xcd, 0x72, 0x1b, 0x45,
-	0x10, 0xce, 0xea, 0xc7, 0x92, 0x5a, 0xb6, 0xb3, 0x4c, 0x52, 0xa0, 0x88, 0xe0, 0x55, 0xa9, 0x20,
-	0x88, 0x2a, 0x58, 0x81, 0x52, 0x14, 0x05, 0xe1, 0x22, 0x59, 0x8a, 0x63, 0xc7, 0x48, 0xd4, 0x58,
-	0xae, 0x50, 0x5c, 0xb6, 0xc6, 0xda, 0xd1, 0x66, 0xb1, 0x76, 0x57, 0xec, 0x8c, 0x8c, 0x9c, 0x47,
-	0xe0, 0x21, 0xb8, 0x72, 0xe1, 0x01, 0x78, 0x04, 0x8e, 0x3c, 0xc1, 0x16, 0xe5, 0x13, 0xb5, 0x67,
-	0x2e, 0xdc, 0xa8, 0xf9, 0x59, 0x5b, 0xc6, 0x3f, 0xc9, 0x65, 0xa6, 0xfb, 0xdb, 0xee, 0x9e, 0x9e,
-	0x6f, 0xa6, 0x7b, 0x16, 0xaa, 0xc4, 0xa3, 0x21, 0xb7, 0xe7, 0x71, 0xc4, 0x23, 0x84, 0xa6, 0x9f,
-	0xdb, 0xa1, 0xe7, 0x87, 0x4b, 0x5b, 0xa1, 0xcc, 0x3d, 0xae, 0xaf, 0x4f, 0xa2, 0x70, 0xea, 0x7b,
-	0xca, 0xa2, 0xfe, 0xc0, 0x9d, 0x3b, 0x2c, 0x9a, 0xf2, 0x9f, 0x48, 0x4c, 0x1d, 0x97, 0x72, 0xe2,
-	0xcf, 0x98, 0xfe, 0x04, 0x5e, 0xe4, 0x45, 0x5a, 0xb6, 0xbc, 0x28, 0xf2, 0x66, 0xb4, 0x2d, 0xb5,
-	0xa3, 0xc5, 0xb4, 0xcd, 0xfd, 0x80, 0x32, 0x4e, 0x82, 0x79, 0x66, 0xfc, 0x32, 0x62, 0x7a, 0xd5,
-	0x7a, 0x55, 0x2d, 0x29, 0x95, 0xe6, 0x3f, 0x39, 0xb8, 0xd7, 0x15, 0x8b, 0x6f, 0x47, 0x61, 0x48,
-	0x27, 0x1c, 0xd3, 0x1f, 0x17, 0x94, 0x71, 0xf4, 0x04, 0x0a, 0x01, 0xe5, 0xa4, 0x96, 0x6b, 0x18,
-	0xad, 0x6a, 0xe7, 0x3d, 0xfb, 0x6a, 0xa6, 0xb6, 0x74, 0xfb, 0x86, 0x72, 0xd2, 0x2b, 0xa7, 0x89,
-	0x25, 0xcd, 0xb1, 0x1c, 0xd1, 0x0e, 0x94, 0x74, 0xae, 0xb5, 0x7c, 0x23, 0xdf, 0xaa, 0x76, 0x1a,
-	0xd7, 0xf9, 0x0f, 0x85, 0xde, 0x57, 0x76, 0xbd, 0x6a, 0x9a, 0x58, 0x99, 0x13, 0xce, 0x04, 0xf4,
-	0x15, 0x14, 0x44, 0xe2, 0xb5, 0x82, 0xcc, 0xe2, 0xe1, 0x75, 0x51, 0x9e, 0x45, 0x8c, 0xef, 0x86,
-	0xd3, 0x48, 0x25, 0x21, 0xac, 0xb1, 0x1c, 0xd1, 0xcf, 0x06, 0xd4, 0x5d, 0xc2, 0xc9, 0x7c, 0x46,
-	0x42, 0x7a, 0x85, 0xc4, 0x5a, 0x51, 0x26, 0xf6, 0xf1, 0x75, 0x21, 0xfb, 0x99, 0xd7, 0x81, 0x76,
-	0xca, 0x92, 0xdc, 0x4a, 0x13, 0xeb, 0x96, 0x98, 0xb8, 0xe6, 0xde, 0xe0, 0xb9, 0x57, 0x28, 0x1b,
-	0x66, 0x0e, 0x97, 0x7d, 0x97, 0x86, 0xdc, 0xe7, 0xa7, 0xcd, 0xdf, 0x72, 0x80, 0x56, 0x69, 0x3f,
-	0xe0, 0x84, 0x2f, 0x18, 0x3a, 0x02, 0x60, 0x52, 0xda, 0x8e, 0x5c, 0x5a, 0x33, 0x1a, 0x46, 0x6b,
-	0xb3, 0xf3, 0xd9, 0x8d, 0xdc, 0x5f, 0xf2, 0xb5, 0x0f, 0xce, 0x1d, 0x7b, 0x77, 0xd3, 0xc4, 0xaa,
-	0xaa, 0x40, 0xce, 0x24, 0x72, 0x29, 0x5e, 0x89, 0x8a, 0x3e, 0x80, 0x52, 0x40, 0x19, 0x23, 0x1e,
-	0x95, 0x87, 0x5b, 0x51, 0xd4, 0x6b, 0x08, 0x67, 0x02, 0xb2, 0xa0, 0x48, 0xe3, 0x38, 0x8a, 0x6b,
-	0x79, 0x69, 0x54, 0x49, 0x13, 0x4b, 0x01, 0x58, 0x4d, 0xcd, 0x1f, 0x00, 0x2e, 0x96, 0x44, 0xf7,
-	0xe0, 0xee, 0xf6, 0x68, 0x38, 0x1c, 0x6c, 0x8f, 0x9d, 0xc3, 0xe1, 0xf3, 0xe1, 0xe8, 0xc5, 0xd0,
-	0xbc, 0x83, 0x36, 0x01, 0x32, 0x70, 0xf4, 0xdc, 0x34, 0x50, 0x1d, 0xde, 0xce, 0x74, 0x3c, 0xd8,
-	0x1b, 0x6c, 0x8f, 0x07, 0x7d, 0x67, 0x34, 0x7e, 0x36, 0xc0, 0x66, 0x0e, 0xbd, 0x0b, 0xef, 0x5c,
-	0xf9, 0xd6, 0x3f, 0xfc, 0xd6, 0xd9, 0xed, 0x9b, 0xf9, 0xe6, 0xef, 0x06, 0xdc, 0xbf, 0x7c, 0x4b,
-	0xd9, 0x3c, 0x0a, 0x19, 0x45, 0x63, 0x58, 0x97, 0xa4, 0x38, 0xaa, 0x6a, 0x24, 0x65, 0xd5, 0x8e,
-	0x75, 0x1b, 0x65, 0x53, 0xdf, 0xeb, 0x99, 0x69, 0x62, 0x5d, 0x72, 0xc4, 0xaa, 0x2e, 0xd5, 0x67,
-	0xb4, 0x07, 0x6b, 0x8a, 0x30, 0x7d, 0xfd, 0x1f, 0xbd, 0xd9, 0x11, 0xf4, 0x20, 0x4d, 0x2c, 0xed,
-	0x89, 0xf5, 0xdc, 0xbc, 0x7f, 0x71, 0xd0, 0x62, 0x1d, 0x55, 0x5e, 0xcd, 0xbf, 0x0d, 0xa8, 0xae,
-	0xc0, 0xab, 0x15, 0xa3, 0xb6, 0xd0, 0xb8, 0x71, 0xc9, 0xdb, 0x2b, 0x66, 0x07, 0x4a, 0xb3, 0xc8,
-	0xf3, 0x68, 0x9c, 0xe5, 0x7e, 0x73, 0xa0, 0xfd, 0xc8, 0xf3, 0xfc, 0xd0, 0x53, 0x81, 0xb4, 0x13,
-	0xce, 0x04, 0x11, 0x48, 0x51, 0xc3, 0xe4, 0x0d, 0xb8, 0x21, 0x50, 0xb6, 0xab, 0x79, 0x14, 0x73,
-	0x15, 0x48, 0x3b, 0xe1, 0x4c, 0x68, 0xfe, 0x6a, 0xc0, 0xfa, 0x6a, 0xe2, 0xa8, 0x05, 0xe5, 0x29,
-	0x25, 0x7c, 0x11, 0x53, 0xb1, 0xd9, 0x7c, 0xab, 0xd2, 0x5b, 0x4f, 0x13, 0xeb, 0x1c, 0xc3, 0xe7,
-	0x12, 0xb2, 0x01, 0xe8, 0x92, 0xd3, 0x90, 0xf9, 0x51, 0x28, 0xf6, 0x23, 0x6c, 0x37, 0xd3, 0xc4,
-	0x5a, 0x41, 0xf1, 0x8a, 0x8c, 0x1e, 0x42, 0x81, 0x13, 0x4f, 0x35, 0x9d, 0x8a, 0x6a, 0x08, 0x42,
-	0xc7, 0x72, 0x14, 0x37, 0x9a, 0xcc, 0x7c, 0xc2, 0x64, 0x37, 0xd1, 0x37, 0x5a, 0x02, 0x58, 0x4d,
-	0xcd, 0x7f, 0x73, 0x3a, 0x53, 0xcd, 0x0c, 0xda, 0x81, 0xe2, 0x8c, 0x9e, 0xd0, 0x99, 0xae, 0xc4,
-	0x47, 0xaf, 0xa3, 0xd2, 0xde, 0x17, 0xd6, 0x2a, 0xb2, 0x74, 0xc4, 0x6a, 0x42, 0x0f, 0x20, 0xef,
-	0xfa, 0xb1, 0xae, 0xb7, 0x52, 0x9a, 0x58, 0x42, 0xc5, 0x62, 0x10, 0x39, 0x4f, 0xfd, 0x19, 0xd5,
-	0x65, 0x26, 0x73, 0x16, 0x3a, 0x96, 0x23, 0xfa, 0x10, 0xca, 0x01, 0x59, 0x3a, 0xcc, 0x7f, 0x45,
-	0x65, 0xda, 0x1b, 0x8a, 0xab, 0x0c, 0xc3, 0xa5, 0x80, 0x2c, 0x0f, 0xfc, 0x57, 0x14, 0x7d, 0x0a,
-	0x55, 0x01, 0x1e, 0x91, 0xc9, 0xf1, 0x62, 0x2e, 0xba, 0x9b, 0xb0, 0x95, 0x7d, 0x60, 0x05, 0xc6,
-	0x10, 0x90, 0x65, 0x4f, 0xc9, 0xe8, 0x7d, 0x10, 0xce, 0x8e, 0xe8, 0x03, 0x6b, 0xd2, 0x5a, 0xf5,
-	0x01, 0x05, 0xe1, 0xb5, 0x80, 0x2c, 0xbb, 0x1e, 0x15, 0x87, 0x35, 0x89, 0x82, 0x79, 0x4c, 0x19,
-	0xab, 0x95, 0x1a, 0x46, 0xab, 0xac, 0x12, 0xc8, 0x30, 0x7c, 0x2e, 0x35, 0xbf, 0x86, 0xa2, 0xdc,
-	0x3e, 0x2a, 0x43, 0x61, 0x77, 0xf8, 0x74, 0x64, 0xde, 0x41, 0x15, 0x28, 0xf6, 0x07, 0xbd, 0xc3,
-	0x1d, 0xd3, 0x10, 0xe0, 0x8b, 0x2e, 0x1e, 0x9a, 0x39, 0x01, 0x0e, 0x30, 0x1e, 0x61, 0x33, 0x2f,
-	0xc4, 0xa7, 0xdd, 0x71, 0x77, 0xdf, 0x2c, 0x34, 0x7f, 0xc9, 0x43, 0xe5, 0xfc, 0x41, 0x11, 0x3d,
-	0xea, 0x84, 0xc6, 0xe2, 0x50, 0x25, 0xf5, 0xba, 0x47, 0x69, 0x08, 0x67, 0x02, 0x7a, 0x0c, 0xeb,
-	0xae, 0xcf, 0xe6, 0x33, 0x72, 0xea, 0x84, 0x24, 0xc8, 0xfa, 0x99, 0x2c, 0xee, 0x55, 0x1c, 0x57,
-	0xb5, 0x36, 0x24, 0x01, 0x15, 0x67, 0xc1, 0x89, 0xa7, 0xef, 0x88, 0x3c, 0x0b, 0x4e, 0x3c, 0x2c,
-	0x06, 0xf4, 0x25, 0x6c, 0xfa, 0x21, 0xe3, 0x24, 0x9c, 0x50, 0xc7, 0x8b, 0xa3, 0xc5, 0x5c, 0xf2,
-	0x58, 0xe9, 0xa1, 0x34, 0xb1, 0xfe, 0xf7, 0x05, 0x6f, 0x64, 0xfa, 0x8e, 0x50, 0x51, 0x17, 0x4a,
-	0x8b, 0xb9, 0x4b, 0x38, 0x75, 0x25, 0x9b, 0xd5, 0x4e, 0xdd, 0x56, 0x6f, 0xb2, 0x9d, 0xbd, 0xc9,
-	0xf6, 0x38, 0x7b, 0x93, 0xd5, 0x6e, 0xb4, 0x39, 0xce, 0x04, 0xf4, 0x09, 0x00, 0x3b, 0x65, 0x9c,
-	0x06, 0xce, 0xc2, 0x77, 0x25, 0xd9, 0xfa, 0xb6, 0x5f, 0xa0, 0xb8, 0xa2, 0xe4, 0x43, 0xdf, 0x45,
-	0xdf, 0xc1, 0x86, 0xea, 0x60, 0x59, 0xe3, 0x28, 0xbf, 0x61, 0xe3, 0x78, 0x2b, 0x4d, 0xac, 0xcb,
-	0xae, 0x58, 0xf5, 0xc2, 0x8b, 0xc7, 0xaa, 0x60, 0x16, 0x2f, 0x36, 0x28, 0x39, 0xec, 0x7d, 0xf1,
-	0xc7, 0xd9, 0x96, 0xf1, 0xe7, 0xd9, 0x96, 0xf1, 0xd7, 0xd9, 0x96, 0xf1, 0xfd, 0x47, 0x9e, 0xcf,
-	0x5f, 0x2e, 0x8e, 0xec, 0x49, 0x14, 0xb4, 0xe5, 0x62, 0x6d, 0x19, 0xa1, 0xcd, 0xdc, 0xe3, 0xf6,
-	0x49, 0x47, 0xfd, 0x87, 0x3c, 0x51, 0x3b, 0x5f, 0x93, 0xd3, 0xe3, 0xff, 0x02, 0x00, 0x00, 0xff,
-	0xff, 0x01, 0x6e, 0xed, 0x83, 0xfa, 0x08, 0x00, 0x00,
+	// 1244 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xcd, 0x72, 0x1b, 0xc5,
+	0x13, 0xcf, 0xea, 0x5b, 0x2d, 0xdb, 0xd9, 0xff, 0x24, 0xf5, 0x47, 0x11, 0xc1, 0x6b, 0x54, 0x90,
+	0x38, 0x55, 0xb0, 0x06, 0xa5, 0x52, 0x90, 0x84, 0x8b, 0x64, 0x6d, 0x1c, 0x3b, 0x8e, 0x9c, 0x1a,
+	0xcb, 0x95, 0x14, 0x17, 0xd5, 0x58, 0x3b, 0xda, 0x2c, 0x96, 0x76, 0xc5, 0xce, 0xc8, 0xd8, 0x79,
+	0x04, 0x5e, 0x04, 0x0e, 0x3c, 0x00, 0x57, 0x6e, 0x1c, 0xb9, 0x70, 0xdd, 0xa2, 0x72, 0xa2, 0xf6,
+	0x0d, 0xb8, 0x51, 0xf3, 0x65, 0xcb, 0x24, 0x72, 0x72, 0x99, 0xe9, 0xee, 0xe9, 0xee, 0xe9, 0x8f,
+	0x99, 0xdf, 0x0c, 0xd4, 0x48, 0x40, 0x23, 0xee, 0x4e, 0x93, 0x98, 0xc7, 0x08, 0x8d, 0xee, 0xb9,
+	0x51, 0x10, 0x46, 0x27, 0xae, 0x92, 0x32, 0xff, 0xa8, 0xb1, 0x34, 0x8c, 0xa3, 0x51, 0x18, 0x28,
+	0x8d, 0xc6, 0x0d, 0x7f, 0x3a, 0x60, 0xf1, 0x88, 0xff, 0x40, 0x12, 0x3a, 0xf0, 0x29, 0x27, 0xe1,
+	0x98, 0xe9, 0x25, 0x08, 0xe2, 0x20, 0xd6, 0xb4, 0x13, 0xc4, 0x71, 0x30, 0xa6, 0x1b, 0x92, 0x3b,
+	0x9c, 0x8d, 0x36, 0x78, 0x38, 0xa1, 0x8c, 0x93, 0xc9, 0xd4, 0x28, 0xbf, 0x8c, 0x99, 0xde, 0xb5,
+	0x51, 0x53, 0x5b, 0x4a, 0xa6, 0xf9, 0x5b, 0x1e, 0xae, 0xb5, 0xc5, 0xe6, 0x9b, 0x71, 0x14, 0xd1,
+	0x21, 0xc7, 0xf4, 0xfb, 0x19, 0x65, 0x1c, 0x3d, 0x84, 0xc2, 0x84, 0x72, 0x52, 0xcf, 0xad, 0x59,
+	0xeb, 0xb5, 0xd6, 0x47, 0xee, 0x9b, 0x91, 0xba, 0xd2, 0xec, 0x29, 0xe5, 0xa4, 0x53, 0xc9, 0x52,
+	0x47, 0xaa, 0x63, 0x39, 0xa2, 0x2d, 0x28, 0xeb, 0x58, 0xeb, 0xf9, 0xb5, 0xfc, 0x7a, 0xad, 0xb5,
+	0xf6, 0x36, 0xfb, 0x9e, 0xe0, 0xbb, 0x4a, 0xaf, 0x53, 0xcb, 0x52, 0xc7, 0x18, 0x61, 0x43, 0xa0,
+	0x07, 0x50, 0x10, 0x81, 0xd7, 0x0b, 0x32, 0x8a, 0x9b, 0x6f, 0xf3, 0xf2, 0x38, 0x66, 0x7c, 0x3b,
+	0x1a, 0xc5, 0x2a, 0x08, 0xa1, 0x8d, 0xe5, 0x88, 0x7e, 0xb4, 0xa0, 0xe1, 0x13, 0x4e, 0xa6, 0x63,
+	0x12, 0xd1, 0x37, 0x8a, 0x58, 0x2f, 0xca, 0xc0, 0x3e, 0x7b, 0x9b, 0xcb, 0xae, 0xb1, 0xda, 0xd7,
+	0x46, 0x26, 0xc8, 0xd5, 0x2c, 0x75, 0x2e, 0xf1, 0x89, 0xeb, 0xfe, 0x02, 0x4b, 0xf4, 0x04, 0x2a,
+	0x13, 0xc2, 0x67, 0x49, 0xc8, 0x4f, 0xeb, 0x25, 0xb9, 0xf3, 0xc7, 0x8b, 0x4b, 0xaa, 0x15, 0x3b,
+	0x4b, 0x59, 0xea, 0x9c, 0x99, 0xe1, 0x33, 0x6a, 0xa7, 0x50, 0xb1, 0xec, 0x1c, 0xae, 0x84, 0x3e,
+	0x8d, 0x78, 0xc8, 0x4f, 0x9b, 0x7f, 0x5a, 0xb0, 0x7c, 0xc1, 0x12, 0xdd, 0x84, 0x42, 0x44, 0x26,
+	0xb4, 0x6e, 0xad, 0x59, 0xeb, 0x55, 0x55, 0x19, 0xc1, 0x63, 0x39, 0xa2, 0xc7, 0x50, 0x1c, 0xd3,
+	0x63, 0x3a, 0x96, 0xcd, 0x5d, 0x69, 0xdd, 0x7e, 0x67, 0x24, 0xee, 0xae, 0x50, 0xef, 0x54, 0xb3,
+	0xd4, 0x51, 0x96, 0x58, 0x4d, 0xe8, 0x53, 0x28, 0x4f, 0x28, 0x63, 0x24, 0xa0, 0xf5, 0xbc, 0xdc,
+	0x4a, 0xb6, 0x51, 0x8b, 0xb0, 0x21, 0x9a, 0xf7, 0xa0, 0x28, 0x3d, 0x20, 0x80, 0xd2, 0x7e, 0xbf,
+	0xdd, 0xd9, 0xf5, 0xec, 0x2b, 0xc8, 0x86, 0x25, 0xef, 0xc5, 0x33, 0x0f, 0x6f, 0x3f, 0xf5, 0x7a,
+	0xfd, 0xf6, 0xae, 0x6d, 0xa1, 0x15, 0x80, 0xae, 0xf7, 0x0c, 0x7b, 0x9b, 0xed, 0xbe, 0xd7, 0xb5,
+	0x73, 0xcd, 0x5f, 0x72, 0x80, 0xe6, 0xcf, 0xe6, 0x3e, 0x27, 0x7c, 0xc6, 0xd0, 0x21, 0x00, 0x93,
+	0xd4, 0x66, 0xec, 0xab, 0x14, 0x57, 0x5a, 0x5f, 0x2e, 0xcc, 0xe1, 0x82, 0xad, 0xbb, 0x7f, 0x66,
+	0xd8, 0xb9, 0x9a, 0xa5, 0x4e, 0x4d, 0x39, 0x1a, 0x0c, 0x63, 0x9f, 0xe2, 0x39, 0xaf, 0xf3, 0x89,
+	0xe5, 0x16, 0x27, 0x86, 0x1c, 0x28, 0xd2, 0x24, 0x89, 0x13, 0x9d, 0xbd, 0x2c, 0x90, 0x14, 0x60,
+	0x35, 0x35, 0xbf, 0x03, 0x38, 0xdf, 0x12, 0x5d, 0x83, 0xab, 0x9b, 0x7b, 0xbd, 0x9e, 0xb7, 0xd9,
+	0x1f, 0x1c, 0xf4, 0x9e, 0xf4, 0xf6, 0x9e, 0xf7, 0xec, 0x2b, 0x22, 0x6b, 0x23, 0xdc, 0x7b, 0x62,
+	0x5b, 0xa8, 0x01, 0xff, 0x37, 0x3c, 0xf6, 0x76, 0xbc, 0xcd, 0xbe, 0xd7, 0x1d, 0xec, 0xf5, 0x1f,
+	0x7b, 0xd8, 0xce, 0xa1, 0x0f, 0xe1, 0x83, 0x37, 0xd6, 0xba, 0x07, 0xcf, 0x06, 0xdb, 0x5d, 0x3b,
+	0xdf, 0xfc, 0xd5, 0x82, 0xeb, 0x17, 0xaf, 0x32, 0x9b, 0xc6, 0x11, 0xa3, 0xa8, 0x0f, 0x4b, 0xb2,
+	0x28, 0x03, 0x05, 0x2d, 0xb2, 0x64, 0xb5, 0x96, 0x73, 0x59, 0xc9, 0x46, 0x61, 0xd0, 0xb1, 0xb3,
+	0xd4, 0xb9, 0x60, 0x88, 0x15, 0x78, 0xa9, 0x65, 0xb4, 0x03, 0x25, 0x55, 0x30, 0x8d, 0x11, 0xb7,
+	0xde, 0xaf, 0x05, 0x1d, 0xc8, 0x52, 0x47, 0x5b, 0x62, 0x3d, 0x37, 0xaf, 0x9f, 0x37, 0x5a, 0xec,
+	0xa3, 0x30, 0xa8, 0xf9, 0xb7, 0x05, 0xb5, 0x39, 0xf1, 0x3c, 0xac, 0xa8, 0x14, 0xd6, 0x16, 0x6e,
+	0x79, 0x39, 0xac, 0x6c, 0x41, 0x79, 0x1c, 0x07, 0x01, 0x4d, 0x4c, 0xec, 0x8b, 0x1d, 0xed, 0xc6,
+	0x41, 0x10, 0x46, 0x81, 0x72, 0xa4, 0x8d, 0xb0, 0x21, 0x84, 0x23, 0x55, 0x1a, 0x26, 0x4f, 0xc0,
+	0x02, 0x47, 0x26, 0xab, 0x69, 0x9c, 0x70, 0xe5, 0x48, 0x1b, 0x61, 0x43, 0x34, 0x7f, 0xb2, 0x60,
+	0x69, 0x3e, 0x70, 0xb4, 0x0e, 0x95, 0x11, 0x15, 0xd7, 0x8f, 0x8a, 0x64, 0xf3, 0xeb, 0x55, 0x85,
+	0x06, 0x46, 0x86, 0xcf, 0x28, 0xe4, 0x02, 0xd0, 0x13, 0x4e, 0x23, 0x16, 0xc6, 0x91, 0xc8, 0x47,
+	0xe8, 0xae, 0x64, 0xa9, 0x33, 0x27, 0xc5, 0x73, 0xb4, 0xc0, 0x06, 0x4e, 0x02, 0x85, 0xcc, 0x1a,
+	0x1b, 0x04, 0x8f, 0xe5, 0x28, 0x4e, 0x34, 0x19, 0x87, 0x84, 0x49, 0xc8, 0xd5, 0x27, 0x5a, 0x0a,
+	0xb0, 0x9a, 0x9a, 0xff, 0xe4, 0x74, 0xa4, 0xba, 0x32, 0x68, 0xcb, 0xa0, 0x89, 0xba, 0x89, 0xb7,
+	0xde, 0x55, 0xca, 0x85, 0x60, 0x72, 0x03, 0xf2, 0x7e, 0x98, 0xe8, 0xfb, 0x56, 0xce, 0x52, 0x47,
+	0xb0, 0x58, 0x0c, 0x22, 0xe6, 0x51, 0x38, 0x36, 0x20, 0x23, 0x63, 0x16, 0x3c, 0x96, 0x23, 0xba,
+	0x2d, 0xc0, 0xf5, 0x64, 0xc0, 0xc2, 0x57, 0x54, 0x86, 0xbd, 0x6c, 0x90, 0x53, 0xc9, 0x70, 0x79,
+	0x42, 0x4e, 0xf6, 0xc3, 0x57, 0x14, 0x7d, 0x01, 0x35, 0x21, 0x3c, 0x24, 0xc3, 0xa3, 0xd9, 0x54,
+	0x3c, 0x01, 0x42, 0x57, 0xe2, 0xc0, 0x9c, 0x18, 0xc3, 0x84, 0x9c, 0x74, 0x14, 0x8d, 0x3e, 0x01,
+	0x61, 0x3c, 0x10, 0x38, 0x50, 0x92, 0xda, 0x0a, 0x07, 0x94, 0x08, 0x97, 0x26, 0xe4, 0xa4, 0x1d,
+	0x50, 0xd1, 0xac, 0x61, 0x3c, 0x99, 0x26, 0x94, 0xb1, 0x7a, 0x79, 0xcd, 0x5a, 0xaf, 0xa8, 0x00,
+	0x8c, 0x0c, 0x9f, 0x51, 0xcd, 0x6f, 0x0c, 0x12, 0x56, 0xa0, 0xb0, 0xdd, 0x7b, 0xb4, 0x67, 0x5f,
+	0x41, 0x55, 0x28, 0x76, 0xbd, 0xce, 0xc1, 0x96, 0x6d, 0x09, 0xe1, 0xf3, 0x36, 0xee, 0xd9, 0x39,
+	0x21, 0xf4, 0x30, 0xde, 0xc3, 0x76, 0x5e, 0x90, 0x8f, 0xda, 0x02, 0x20, 0x0b, 0xcd, 0x9f, 0x0b,
+	0x50, 0x3d, 0x7b, 0x75, 0x05, 0x46, 0x1d, 0xd3, 0x44, 0x34, 0x55, 0xe3, 0xbc, 0x8c, 0x4d, 0x8b,
+	0xb0, 0x21, 0xd0, 0x5d, 0x58, 0xf2, 0x43, 0x36, 0x1d, 0x93, 0xd3, 0x81, 0x7c, 0x13, 0x54, 0x7d,
+	0xe5, 0xe5, 0x9e, 0x97, 0xe3, 0x9a, 0xe6, 0x7a, 0xe2, 0x89, 0xb8, 0x01, 0x79, 0x4e, 0x02, 0x7d,
+	0x46, 0x64, 0x2f, 0x38, 0x09, 0xb0, 0x18, 0xd0, 0x7d, 0x58, 0x09, 0x23, 0xc6, 0x49, 0x34, 0xa4,
+	0x83, 0x20, 0x89, 0x67, 0x53, 0x59, 0xc7, 0x6a, 0x07, 0x65, 0xa9, 0xf3, 0x9f, 0x15, 0xbc, 0x6c,
+	0xf8, 0x2d, 0xc1, 0xa2, 0x36, 0x94, 0x67, 0x53, 0x9f, 0x70, 0xea, 0xcb, 0x6a, 0xd6, 0x5a, 0x0d,
+	0x57, 0x7d, 0x5c, 0x5c, 0xf3, 0x71, 0x71, 0xfb, 0xe6, 0xe3, 0xa2, 0xb2, 0xd1, 0xea, 0xd8, 0x10,
+	0xe8, 0x73, 0x00, 0x76, 0xca, 0x38, 0x9d, 0x0c, 0x66, 0xa1, 0x2f, 0x8b, 0xad, 0x4f, 0xfb, 0xb9,
+	0x14, 0x57, 0x15, 0x7d, 0x10, 0xfa, 0xe8, 0x05, 0x2c, 0x2b, 0x04, 0x33, 0xc0, 0x51, 0x79, 0x4f,
+	0xe0, 0xf8, 0x5f, 0x96, 0x3a, 0x17, 0x4d, 0xb1, 0xc2, 0x42, 0x73, 0x41, 0x9f, 0x42, 0x69, 0x4c,
+	0x0e, 0xe9, 0x98, 0xd5, 0xab, 0xf2, 0x3d, 0xbf, 0x73, 0xe9, 0x17, 0xc9, 0xdd, 0x95, 0xba, 0x5e,
+	0xc4, 0x93, 0x53, 0x85, 0x80, 0xca, 0x18, 0xeb, 0xb9, 0x71, 0x1f, 0x6a, 0x73, 0x2a, 0xc8, 0x86,
+	0xfc, 0x11, 0x3d, 0x55, 0x7d, 0xc5, 0x82, 0x44, 0xd7, 0xa1, 0x78, 0x4c, 0xc6, 0x33, 0xdd, 0x3f,
+	0xac, 0x98, 0x07, 0xb9, 0xaf, 0xad, 0x9d, 0x42, 0xa5, 0x60, 0x17, 0xcf, 0x4b, 0x2d, 0xbb, 0xd9,
+	0xf9, 0xea, 0xf7, 0xd7, 0xab, 0xd6, 0x1f, 0xaf, 0x57, 0xad, 0xbf, 0x5e, 0xaf, 0x5a, 0xdf, 0xde,
+	0x09, 0x42, 0xfe, 0x72, 0x76, 0xe8, 0x0e, 0xe3, 0xc9, 0x86, 0x8c, 0x71, 0x43, 0xc6, 0xb8, 0xc1,
+	0xfc, 0xa3, 0x8d, 0xe3, 0x96, 0xfa, 0x36, 0x3e, 0x54, 0x3d, 0x28, 0xc9, 0xe9, 0xee, 0xbf, 0x01,
+	0x00, 0x00, 0xff, 0xff, 0x89, 0x72, 0xa3, 0xc0, 0xa9, 0x0a, 0x00, 0x00,
 }
 
 func (m *AgentConnectRequest) Marshal() (dAtA []byte, err error) {
@@ -804,6 +934,20 @@ func (m *AgentConnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Maturity) > 0 {
+		for iNdEx := len(m.Maturity) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Maturity[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.DataplaneSoftwareDetails) > 0 {
 		for iNdEx := len(m.DataplaneSoftwareDetails) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -859,6 +1003,52 @@ func (m *AgentConnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *AgentMaturity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentMaturity) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentMaturity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Level != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Level))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *AgentConnectStatus) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1202,6 +1392,25 @@ func (m *AgentMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Labels) > 0 {
+		for k := range m.Labels {
+			v := m.Labels[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintAgent(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintAgent(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintAgent(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
 	if m.AgentDetails != nil {
 		{
 			size, err := m.AgentDetails.MarshalToSizedBuffer(dAtA[:i])
@@ -1303,6 +1512,35 @@ func (m *AgentConnectRequest) Size() (n int) {
 			n += 1 + l + sovAgent(uint64(l))
 		}
 	}
+	if len(m.Maturity) > 0 {
+		for _, e := range m.Maturity {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AgentMaturity) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Level != 0 {
+		n += 1 + sovAgent(uint64(m.Level))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1493,6 +1731,14 @@ func (m *AgentMeta) Size() (n int) {
 		l = m.AgentDetails.Size()
 		n += 1 + l + sovAgent(uint64(l))
 	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovAgent(uint64(len(k))) + 1 + len(v) + sovAgent(uint64(len(v)))
+			n += mapEntrySize + 1 + sovAgent(uint64(mapEntrySize))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1674,6 +1920,174 @@ func (m *AgentConnectRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Maturity", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Maturity = append(m.Maturity, &AgentMaturity{})
+			if err := m.Maturity[len(m.Maturity)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AgentMaturity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AgentMaturity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AgentMaturity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
+			}
+			m.Level = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Level |= AgentMaturity_Level(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -2814,6 +3228,133 @@ func (m *AgentMeta) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAgent
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAgent
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthAgent
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthAgent
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAgent
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthAgent
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthAgent
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipAgent(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthAgent
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Labels[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -59,6 +59,66 @@ func (Command_CommandType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_213c0bb044472049, []int{0, 0}
 }
 
+// Administrative state the upstream server is put into
+type UpstreamDrainRequest_TargetState int32
+
+const (
+	// The server receives traffic as usual
+	UpstreamDrainRequest_UP UpstreamDrainRequest_TargetState = 0
+	// The server finishes active sessions but receives no new ones
+	UpstreamDrainRequest_DRAIN UpstreamDrainRequest_TargetState = 1
+	// The server receives no traffic at all
+	UpstreamDrainRequest_DOWN UpstreamDrainRequest_TargetState = 2
+)
+
+var UpstreamDrainRequest_TargetState_name = map[int32]string{
+	0: "UP",
+	1: "DRAIN",
+	2: "DOWN",
+}
+
+var UpstreamDrainRequest_TargetState_value = map[string]int32{
+	"UP":    0,
+	"DRAIN": 1,
+	"DOWN":  2,
+}
+
+func (x UpstreamDrainRequest_TargetState) String() string {
+	return proto.EnumName(UpstreamDrainRequest_TargetState_name, int32(x))
+}
+
+func (UpstreamDrainRequest_TargetState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{5, 0}
+}
+
+// What is done with the mirror configuration of the server block
+type TrafficMirrorRequest_Action int32
+
+const (
+	// Generate the mirror include and wire it into the server block
+	TrafficMirrorRequest_SET TrafficMirrorRequest_Action = 0
+	// Remove the mirror include from the server block and delete it
+	TrafficMirrorRequest_REMOVE TrafficMirrorRequest_Action = 1
+)
+
+var TrafficMirrorRequest_Action_name = map[int32]string{
+	0: "SET",
+	1: "REMOVE",
+}
+
+var TrafficMirrorRequest_Action_value = map[string]int32{
+	"SET":    0,
+	"REMOVE": 1,
+}
+
+func (x TrafficMirrorRequest_Action) String() string {
+	return proto.EnumName(TrafficMirrorRequest_Action_name, int32(x))
+}
+
+func (TrafficMirrorRequest_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{6, 0}
+}
+
 // Command status enum
 type CommandStatusResponse_CommandStatus int32
 
@@ -88,7 +148,7 @@ func (x CommandStatusResponse_CommandStatus) String() string {
 }
 
 func (CommandStatusResponse_CommandStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_213c0bb044472049, []int{1, 0}
+	return fileDescriptor_213c0bb044472049, []int{8, 0}
 }
 
 // Command error code enum
@@ -116,7 +176,7 @@ func (x CommandStatusResponse_CommandErrorCode) String() string {
 }
 
 func (CommandStatusResponse_CommandErrorCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_213c0bb044472049, []int{1, 1}
+	return fileDescriptor_213c0bb044472049, []int{8, 1}
 }
 
 // NGINX configuration status enum
@@ -148,7 +208,7 @@ func (x NginxConfigStatus_Status) String() string {
 }
 
 func (NginxConfigStatus_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_213c0bb044472049, []int{4, 0}
+	return fileDescriptor_213c0bb044472049, []int{11, 0}
 }
 
 // Transfer status enum
@@ -180,7 +240,7 @@ func (x UploadStatus_TransferStatus) String() string {
 }
 
 func (UploadStatus_TransferStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_213c0bb044472049, []int{9, 0}
+	return fileDescriptor_213c0bb044472049, []int{16, 0}
 }
 
 // Represents a command message, which is used for communication between the management server and the agent.
@@ -201,6 +261,13 @@ type Command struct {
 	//	*Command_EventReport
 	//	*Command_DataplaneSoftwareDetails
 	//	*Command_DataplaneUpdate
+	//	*Command_CertificateDeployRequest
+	//	*Command_DebugCaptureRequest
+	//	*Command_KeyvalSyncRequest
+	//	*Command_AgentUpdateRequest
+	//	*Command_UpstreamDrainRequest
+	//	*Command_TrafficMirrorRequest
+	//	*Command_UpstreamSwitchRequest
 	Data                 isCommand_Data `protobuf_oneof:"data"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
@@ -279,6 +346,27 @@ type Command_DataplaneSoftwareDetails struct {
 type Command_DataplaneUpdate struct {
 	DataplaneUpdate *DataplaneUpdate `protobuf:"bytes,14,opt,name=dataplane_update,json=dataplaneUpdate,proto3,oneof" json:"dataplane_update"`
 }
+type Command_CertificateDeployRequest struct {
+	CertificateDeployRequest *CertificateDeployRequest `protobuf:"bytes,15,opt,name=certificate_deploy_request,json=certificateDeployRequest,proto3,oneof" json:"certificate_deploy_request"`
+}
+type Command_DebugCaptureRequest struct {
+	DebugCaptureRequest *DebugCaptureRequest `protobuf:"bytes,16,opt,name=debug_capture_request,json=debugCaptureRequest,proto3,oneof" json:"debug_capture_request"`
+}
+type Command_KeyvalSyncRequest struct {
+	KeyvalSyncRequest *KeyvalSyncRequest `protobuf:"bytes,17,opt,name=keyval_sync_request,json=keyvalSyncRequest,proto3,oneof" json:"keyval_sync_request"`
+}
+type Command_AgentUpdateRequest struct {
+	AgentUpdateRequest *AgentUpdateRequest `protobuf:"bytes,18,opt,name=agent_update_request,json=agentUpdateRequest,proto3,oneof" json:"agent_update_request"`
+}
+type Command_UpstreamDrainRequest struct {
+	UpstreamDrainRequest *UpstreamDrainRequest `protobuf:"bytes,19,opt,name=upstream_drain_request,json=upstreamDrainRequest,proto3,oneof" json:"upstream_drain_request"`
+}
+type Command_TrafficMirrorRequest struct {
+	TrafficMirrorRequest *TrafficMirrorRequest `protobuf:"bytes,20,opt,name=traffic_mirror_request,json=trafficMirrorRequest,proto3,oneof" json:"traffic_mirror_request"`
+}
+type Command_UpstreamSwitchRequest struct {
+	UpstreamSwitchRequest *UpstreamSwitchRequest `protobuf:"bytes,21,opt,name=upstream_switch_request,json=upstreamSwitchRequest,proto3,oneof" json:"upstream_switch_request"`
+}
 
 func (*Command_CmdStatus) isCommand_Data()                {}
 func (*Command_NginxConfig) isCommand_Data()              {}
@@ -291,6 +379,13 @@ func (*Command_DataplaneStatus) isCommand_Data()          {}
 func (*Command_EventReport) isCommand_Data()              {}
 func (*Command_DataplaneSoftwareDetails) isCommand_Data() {}
 func (*Command_DataplaneUpdate) isCommand_Data()          {}
+func (*Command_CertificateDeployRequest) isCommand_Data() {}
+func (*Command_DebugCaptureRequest) isCommand_Data()      {}
+func (*Command_KeyvalSyncRequest) isCommand_Data()        {}
+func (*Command_AgentUpdateRequest) isCommand_Data()       {}
+func (*Command_UpstreamDrainRequest) isCommand_Data()     {}
+func (*Command_TrafficMirrorRequest) isCommand_Data()     {}
+func (*Command_UpstreamSwitchRequest) isCommand_Data()    {}
 
 func (m *Command) GetData() isCommand_Data {
 	if m != nil {
@@ -390,6 +485,55 @@ func (m *Command) GetDataplaneUpdate() *DataplaneUpdate {
 	return nil
 }
 
+func (m *Command) GetCertificateDeployRequest() *CertificateDeployRequest {
+	if x, ok := m.GetData().(*Command_CertificateDeployRequest); ok {
+		return x.CertificateDeployRequest
+	}
+	return nil
+}
+
+func (m *Command) GetDebugCaptureRequest() *DebugCaptureRequest {
+	if x, ok := m.GetData().(*Command_DebugCaptureRequest); ok {
+		return x.DebugCaptureRequest
+	}
+	return nil
+}
+
+func (m *Command) GetKeyvalSyncRequest() *KeyvalSyncRequest {
+	if x, ok := m.GetData().(*Command_KeyvalSyncRequest); ok {
+		return x.KeyvalSyncRequest
+	}
+	return nil
+}
+
+func (m *Command) GetAgentUpdateRequest() *AgentUpdateRequest {
+	if x, ok := m.GetData().(*Command_AgentUpdateRequest); ok {
+		return x.AgentUpdateRequest
+	}
+	return nil
+}
+
+func (m *Command) GetUpstreamDrainRequest() *UpstreamDrainRequest {
+	if x, ok := m.GetData().(*Command_UpstreamDrainRequest); ok {
+		return x.UpstreamDrainRequest
+	}
+	return nil
+}
+
+func (m *Command) GetTrafficMirrorRequest() *TrafficMirrorRequest {
+	if x, ok := m.GetData().(*Command_TrafficMirrorRequest); ok {
+		return x.TrafficMirrorRequest
+	}
+	return nil
+}
+
+func (m *Command) GetUpstreamSwitchRequest() *UpstreamSwitchRequest {
+	if x, ok := m.GetData().(*Command_UpstreamSwitchRequest); ok {
+		return x.UpstreamSwitchRequest
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Command) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -404,36 +548,52 @@ func (*Command) XXX_OneofWrappers() []interface{} {
 		(*Command_EventReport)(nil),
 		(*Command_DataplaneSoftwareDetails)(nil),
 		(*Command_DataplaneUpdate)(nil),
+		(*Command_CertificateDeployRequest)(nil),
+		(*Command_DebugCaptureRequest)(nil),
+		(*Command_KeyvalSyncRequest)(nil),
+		(*Command_AgentUpdateRequest)(nil),
+		(*Command_UpstreamDrainRequest)(nil),
+		(*Command_TrafficMirrorRequest)(nil),
+		(*Command_UpstreamSwitchRequest)(nil),
 	}
 }
 
-// Represents a command status response
-type CommandStatusResponse struct {
-	// Command status
-	Status CommandStatusResponse_CommandStatus `protobuf:"varint,1,opt,name=status,proto3,enum=f5.nginx.agent.sdk.CommandStatusResponse_CommandStatus" json:"status"`
-	// Error code
-	ErrorCode CommandStatusResponse_CommandErrorCode `protobuf:"varint,2,opt,name=error_code,json=errorCode,proto3,enum=f5.nginx.agent.sdk.CommandStatusResponse_CommandErrorCode" json:"error_code"`
-	// Provides a user friendly message to describe the response
-	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message"`
-	// Provides an error message of why the command failed
-	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error"`
+// Represents a request to install a certificate bundle on the dataplane. The agent
+// verifies that the key pairs with the certificate and that the chain is complete
+// before installing the files and reloading NGINX, rolling the previous files back
+// if the reload fails.
+type CertificateDeployRequest struct {
+	// System ID
+	SystemId string `protobuf:"bytes,1,opt,name=system_id,json=systemId,proto3" json:"system_id"`
+	// NGINX ID of the instance that is reloaded after the bundle is installed
+	NginxId string `protobuf:"bytes,2,opt,name=nginx_id,json=nginxId,proto3" json:"nginx_id"`
+	// Directory the bundle is installed into (must be within the allowed directories)
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path"`
+	// Base name of the installed files (<name>.crt and <name>.key)
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name"`
+	// PEM encoded leaf certificate
+	Certificate []byte `protobuf:"bytes,5,opt,name=certificate,proto3" json:"certificate"`
+	// PEM encoded private key
+	PrivateKey []byte `protobuf:"bytes,6,opt,name=private_key,json=privateKey,proto3" json:"private_key"`
+	// PEM encoded intermediate chain, appended to the certificate file
+	Chain                []byte   `protobuf:"bytes,7,opt,name=chain,proto3" json:"chain"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CommandStatusResponse) Reset()         { *m = CommandStatusResponse{} }
-func (m *CommandStatusResponse) String() string { return proto.CompactTextString(m) }
-func (*CommandStatusResponse) ProtoMessage()    {}
-func (*CommandStatusResponse) Descriptor() ([]byte, []int) {
+func (m *CertificateDeployRequest) Reset()         { *m = CertificateDeployRequest{} }
+func (m *CertificateDeployRequest) String() string { return proto.CompactTextString(m) }
+func (*CertificateDeployRequest) ProtoMessage()    {}
+func (*CertificateDeployRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_213c0bb044472049, []int{1}
 }
-func (m *CommandStatusResponse) XXX_Unmarshal(b []byte) error {
+func (m *CertificateDeployRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CommandStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CertificateDeployRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CommandStatusResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CertificateDeployRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -443,79 +603,102 @@ func (m *CommandStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *CommandStatusResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandStatusResponse.Merge(m, src)
+func (m *CertificateDeployRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificateDeployRequest.Merge(m, src)
 }
-func (m *CommandStatusResponse) XXX_Size() int {
+func (m *CertificateDeployRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *CommandStatusResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommandStatusResponse.DiscardUnknown(m)
+func (m *CertificateDeployRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificateDeployRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CommandStatusResponse proto.InternalMessageInfo
+var xxx_messageInfo_CertificateDeployRequest proto.InternalMessageInfo
 
-func (m *CommandStatusResponse) GetStatus() CommandStatusResponse_CommandStatus {
+func (m *CertificateDeployRequest) GetSystemId() string {
 	if m != nil {
-		return m.Status
+		return m.SystemId
 	}
-	return CommandStatusResponse_CMD_UNKNOWN
+	return ""
 }
 
-func (m *CommandStatusResponse) GetErrorCode() CommandStatusResponse_CommandErrorCode {
+func (m *CertificateDeployRequest) GetNginxId() string {
 	if m != nil {
-		return m.ErrorCode
+		return m.NginxId
 	}
-	return CommandStatusResponse_ERR_OK
+	return ""
 }
 
-func (m *CommandStatusResponse) GetMessage() string {
+func (m *CertificateDeployRequest) GetPath() string {
 	if m != nil {
-		return m.Message
+		return m.Path
 	}
 	return ""
 }
 
-func (m *CommandStatusResponse) GetError() string {
+func (m *CertificateDeployRequest) GetName() string {
 	if m != nil {
-		return m.Error
+		return m.Name
 	}
 	return ""
 }
 
-// Represents a dataplane status, which is used by the agent to periodically report the status of NGINX, agent activities and other dataplane software activities.
-type DataplaneStatus struct {
+func (m *CertificateDeployRequest) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *CertificateDeployRequest) GetPrivateKey() []byte {
+	if m != nil {
+		return m.PrivateKey
+	}
+	return nil
+}
+
+func (m *CertificateDeployRequest) GetChain() []byte {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
+// Represents a request to synchronise an NGINX Plus key-value zone through the Plus
+// API, so dynamic data such as blocklists can be driven from the management server
+// without custom scripts on the dataplane. The agent adds and modifies the pushed
+// entries, optionally removes entries that are not in the payload, or exports the
+// current contents of the zone instead.
+type KeyvalSyncRequest struct {
 	// System ID
 	SystemId string `protobuf:"bytes,1,opt,name=system_id,json=systemId,proto3" json:"system_id"`
-	// List of NGINX details. This field will be moving to DataplaneSoftwareDetails in a future release.
-	Details []*NginxDetails `protobuf:"bytes,2,rep,name=details,proto3" json:"details"`
-	// Host information
-	Host *HostInfo `protobuf:"bytes,3,opt,name=host,proto3" json:"host"`
-	// List of NGINX health information. This field will be moving to DataplaneSoftwareHealth in a future release.
-	Healths []*NginxHealth `protobuf:"bytes,5,rep,name=healths,proto3" json:"healths"`
-	// List of software details. This includes details about NGINX and any other software installed in the system that the agent is interested in.
-	DataplaneSoftwareDetails []*DataplaneSoftwareDetails `protobuf:"bytes,6,rep,name=dataplane_software_details,json=dataplaneSoftwareDetails,proto3" json:"dataplane_software_details"`
-	// List of software health statues. This includes the health of NGINX and any other software installed in the system that the agent is interested in.
-	DataplaneSoftwareHealths []*DataplaneSoftwareHealth `protobuf:"bytes,7,rep,name=dataplane_software_healths,json=dataplaneSoftwareHealths,proto3" json:"dataplane_software_healths"`
-	// List of activity statuses. Reports on the status of activities that the agent is currently executing.
-	AgentActivityStatus  []*AgentActivityStatus `protobuf:"bytes,8,rep,name=agent_activity_status,json=agentActivityStatus,proto3" json:"agent_activity_status"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	// NGINX ID of the Plus instance whose key-value zone is synchronised
+	NginxId string `protobuf:"bytes,2,opt,name=nginx_id,json=nginxId,proto3" json:"nginx_id"`
+	// Name of the key-value zone
+	Zone string `protobuf:"bytes,3,opt,name=zone,proto3" json:"zone"`
+	// JSON object of the key-value pairs to load into the zone
+	Entries []byte `protobuf:"bytes,4,opt,name=entries,proto3" json:"entries"`
+	// Remove entries from the zone that are not in the payload
+	Truncate bool `protobuf:"varint,5,opt,name=truncate,proto3" json:"truncate"`
+	// Respond with the current contents of the zone instead of loading the payload
+	Export               bool     `protobuf:"varint,6,opt,name=export,proto3" json:"export"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DataplaneStatus) Reset()         { *m = DataplaneStatus{} }
-func (m *DataplaneStatus) String() string { return proto.CompactTextString(m) }
-func (*DataplaneStatus) ProtoMessage()    {}
-func (*DataplaneStatus) Descriptor() ([]byte, []int) {
+func (m *KeyvalSyncRequest) Reset()         { *m = KeyvalSyncRequest{} }
+func (m *KeyvalSyncRequest) String() string { return proto.CompactTextString(m) }
+func (*KeyvalSyncRequest) ProtoMessage()    {}
+func (*KeyvalSyncRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_213c0bb044472049, []int{2}
 }
-func (m *DataplaneStatus) XXX_Unmarshal(b []byte) error {
+func (m *KeyvalSyncRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DataplaneStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *KeyvalSyncRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DataplaneStatus.Marshal(b, m, deterministic)
+		return xxx_messageInfo_KeyvalSyncRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -525,89 +708,83 @@ func (m *DataplaneStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *DataplaneStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DataplaneStatus.Merge(m, src)
+func (m *KeyvalSyncRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyvalSyncRequest.Merge(m, src)
 }
-func (m *DataplaneStatus) XXX_Size() int {
+func (m *KeyvalSyncRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DataplaneStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_DataplaneStatus.DiscardUnknown(m)
+func (m *KeyvalSyncRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyvalSyncRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DataplaneStatus proto.InternalMessageInfo
+var xxx_messageInfo_KeyvalSyncRequest proto.InternalMessageInfo
 
-func (m *DataplaneStatus) GetSystemId() string {
+func (m *KeyvalSyncRequest) GetSystemId() string {
 	if m != nil {
 		return m.SystemId
 	}
 	return ""
 }
 
-func (m *DataplaneStatus) GetDetails() []*NginxDetails {
-	if m != nil {
-		return m.Details
-	}
-	return nil
-}
-
-func (m *DataplaneStatus) GetHost() *HostInfo {
+func (m *KeyvalSyncRequest) GetNginxId() string {
 	if m != nil {
-		return m.Host
+		return m.NginxId
 	}
-	return nil
+	return ""
 }
 
-func (m *DataplaneStatus) GetHealths() []*NginxHealth {
+func (m *KeyvalSyncRequest) GetZone() string {
 	if m != nil {
-		return m.Healths
+		return m.Zone
 	}
-	return nil
+	return ""
 }
 
-func (m *DataplaneStatus) GetDataplaneSoftwareDetails() []*DataplaneSoftwareDetails {
+func (m *KeyvalSyncRequest) GetEntries() []byte {
 	if m != nil {
-		return m.DataplaneSoftwareDetails
+		return m.Entries
 	}
 	return nil
 }
 
-func (m *DataplaneStatus) GetDataplaneSoftwareHealths() []*DataplaneSoftwareHealth {
+func (m *KeyvalSyncRequest) GetTruncate() bool {
 	if m != nil {
-		return m.DataplaneSoftwareHealths
+		return m.Truncate
 	}
-	return nil
+	return false
 }
 
-func (m *DataplaneStatus) GetAgentActivityStatus() []*AgentActivityStatus {
+func (m *KeyvalSyncRequest) GetExport() bool {
 	if m != nil {
-		return m.AgentActivityStatus
+		return m.Export
 	}
-	return nil
+	return false
 }
 
-// Represent an agent activity status
-type AgentActivityStatus struct {
-	// Types that are valid to be assigned to Status:
-	//	*AgentActivityStatus_NginxConfigStatus
-	Status               isAgentActivityStatus_Status `protobuf_oneof:"Status"`
-	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
-	XXX_unrecognized     []byte                       `json:"-"`
-	XXX_sizecache        int32                        `json:"-"`
+// Represents a request for a diagnostic bundle. The agent collects pprof profiles, a
+// goroutine dump, the agent configuration with secrets redacted, recent agent log
+// lines and the full NGINX configuration into a single archive for support cases.
+type DebugCaptureRequest struct {
+	// Number of trailing agent log lines to include in the bundle (0 uses the default)
+	LogLines             int32    `protobuf:"varint,1,opt,name=log_lines,json=logLines,proto3" json:"log_lines"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AgentActivityStatus) Reset()         { *m = AgentActivityStatus{} }
-func (m *AgentActivityStatus) String() string { return proto.CompactTextString(m) }
-func (*AgentActivityStatus) ProtoMessage()    {}
-func (*AgentActivityStatus) Descriptor() ([]byte, []int) {
+func (m *DebugCaptureRequest) Reset()         { *m = DebugCaptureRequest{} }
+func (m *DebugCaptureRequest) String() string { return proto.CompactTextString(m) }
+func (*DebugCaptureRequest) ProtoMessage()    {}
+func (*DebugCaptureRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_213c0bb044472049, []int{3}
 }
-func (m *AgentActivityStatus) XXX_Unmarshal(b []byte) error {
+func (m *DebugCaptureRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AgentActivityStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DebugCaptureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AgentActivityStatus.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DebugCaptureRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -617,78 +794,59 @@ func (m *AgentActivityStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *AgentActivityStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AgentActivityStatus.Merge(m, src)
+func (m *DebugCaptureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DebugCaptureRequest.Merge(m, src)
 }
-func (m *AgentActivityStatus) XXX_Size() int {
+func (m *DebugCaptureRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *AgentActivityStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_AgentActivityStatus.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_AgentActivityStatus proto.InternalMessageInfo
-
-type isAgentActivityStatus_Status interface {
-	isAgentActivityStatus_Status()
-	MarshalTo([]byte) (int, error)
-	Size() int
-}
-
-type AgentActivityStatus_NginxConfigStatus struct {
-	NginxConfigStatus *NginxConfigStatus `protobuf:"bytes,1,opt,name=nginx_config_status,json=nginxConfigStatus,proto3,oneof" json:"nginx_config_status"`
+func (m *DebugCaptureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DebugCaptureRequest.DiscardUnknown(m)
 }
 
-func (*AgentActivityStatus_NginxConfigStatus) isAgentActivityStatus_Status() {}
+var xxx_messageInfo_DebugCaptureRequest proto.InternalMessageInfo
 
-func (m *AgentActivityStatus) GetStatus() isAgentActivityStatus_Status {
+func (m *DebugCaptureRequest) GetLogLines() int32 {
 	if m != nil {
-		return m.Status
-	}
-	return nil
-}
-
-func (m *AgentActivityStatus) GetNginxConfigStatus() *NginxConfigStatus {
-	if x, ok := m.GetStatus().(*AgentActivityStatus_NginxConfigStatus); ok {
-		return x.NginxConfigStatus
-	}
-	return nil
-}
-
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*AgentActivityStatus) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*AgentActivityStatus_NginxConfigStatus)(nil),
+		return m.LogLines
 	}
+	return 0
 }
 
-// Represents a NGINX configuration status
-type NginxConfigStatus struct {
-	// CorrelationID is an ID used by the producer of the message to track the flow of events
-	CorrelationId string `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id"`
-	// Provides a status for the NGINX configuration
-	Status NginxConfigStatus_Status `protobuf:"varint,2,opt,name=status,proto3,enum=f5.nginx.agent.sdk.NginxConfigStatus_Status" json:"status"`
-	// Provides a user friendly message to describe the current state of the NGINX configuration.
-	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message"`
-	// NGINX ID
-	NginxId              string   `protobuf:"bytes,4,opt,name=nginx_id,json=nginxId,proto3" json:"nginx_id"`
+// Represents an offer of a new agent version. The agent downloads the package (or
+// takes it inline), verifies the checksum and the signature against its configured
+// public key, stages the binary next to the running one, health checks it and then
+// execs into the new version, rolling the binary back if any step fails.
+type AgentUpdateRequest struct {
+	// System ID
+	SystemId string `protobuf:"bytes,1,opt,name=system_id,json=systemId,proto3" json:"system_id"`
+	// Version of the offered agent package
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version"`
+	// URL the agent binary is downloaded from
+	PackageUrl string `protobuf:"bytes,3,opt,name=package_url,json=packageUrl,proto3" json:"package_url"`
+	// Agent binary, as an inline alternative to package_url
+	Package []byte `protobuf:"bytes,4,opt,name=package,proto3" json:"package"`
+	// Hex encoded SHA-256 checksum of the agent binary
+	Checksum string `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum"`
+	// Signature over the agent binary, verified with the configured public key
+	Signature            []byte   `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NginxConfigStatus) Reset()         { *m = NginxConfigStatus{} }
-func (m *NginxConfigStatus) String() string { return proto.CompactTextString(m) }
-func (*NginxConfigStatus) ProtoMessage()    {}
-func (*NginxConfigStatus) Descriptor() ([]byte, []int) {
+func (m *AgentUpdateRequest) Reset()         { *m = AgentUpdateRequest{} }
+func (m *AgentUpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*AgentUpdateRequest) ProtoMessage()    {}
+func (*AgentUpdateRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_213c0bb044472049, []int{4}
 }
-func (m *NginxConfigStatus) XXX_Unmarshal(b []byte) error {
+func (m *AgentUpdateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *NginxConfigStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AgentUpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_NginxConfigStatus.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AgentUpdateRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -698,69 +856,94 @@ func (m *NginxConfigStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *NginxConfigStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NginxConfigStatus.Merge(m, src)
+func (m *AgentUpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentUpdateRequest.Merge(m, src)
 }
-func (m *NginxConfigStatus) XXX_Size() int {
+func (m *AgentUpdateRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *NginxConfigStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_NginxConfigStatus.DiscardUnknown(m)
+func (m *AgentUpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentUpdateRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NginxConfigStatus proto.InternalMessageInfo
+var xxx_messageInfo_AgentUpdateRequest proto.InternalMessageInfo
 
-func (m *NginxConfigStatus) GetCorrelationId() string {
+func (m *AgentUpdateRequest) GetSystemId() string {
 	if m != nil {
-		return m.CorrelationId
+		return m.SystemId
 	}
 	return ""
 }
 
-func (m *NginxConfigStatus) GetStatus() NginxConfigStatus_Status {
+func (m *AgentUpdateRequest) GetVersion() string {
 	if m != nil {
-		return m.Status
+		return m.Version
 	}
-	return NginxConfigStatus_PENDING
+	return ""
 }
 
-func (m *NginxConfigStatus) GetMessage() string {
+func (m *AgentUpdateRequest) GetPackageUrl() string {
 	if m != nil {
-		return m.Message
+		return m.PackageUrl
 	}
 	return ""
 }
 
-func (m *NginxConfigStatus) GetNginxId() string {
+func (m *AgentUpdateRequest) GetPackage() []byte {
 	if m != nil {
-		return m.NginxId
+		return m.Package
+	}
+	return nil
+}
+
+func (m *AgentUpdateRequest) GetChecksum() string {
+	if m != nil {
+		return m.Checksum
 	}
 	return ""
 }
 
-// Represents a dataplane software health
-type DataplaneSoftwareHealth struct {
-	// Types that are valid to be assigned to Health:
-	//	*DataplaneSoftwareHealth_NginxHealth
-	//	*DataplaneSoftwareHealth_AppProtectWafHealth
-	Health               isDataplaneSoftwareHealth_Health `protobuf_oneof:"health"`
-	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
-	XXX_unrecognized     []byte                           `json:"-"`
-	XXX_sizecache        int32                            `json:"-"`
+func (m *AgentUpdateRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
 }
 
-func (m *DataplaneSoftwareHealth) Reset()         { *m = DataplaneSoftwareHealth{} }
-func (m *DataplaneSoftwareHealth) String() string { return proto.CompactTextString(m) }
-func (*DataplaneSoftwareHealth) ProtoMessage()    {}
-func (*DataplaneSoftwareHealth) Descriptor() ([]byte, []int) {
+// Represents a request to change the administrative state of a named member of an
+// NGINX Plus upstream through the Plus API, so nodes can be drained out of rotation
+// from the management server before maintenance and brought back afterwards. The
+// agent reports the resulting state of the member in the command status.
+type UpstreamDrainRequest struct {
+	// System ID
+	SystemId string `protobuf:"bytes,1,opt,name=system_id,json=systemId,proto3" json:"system_id"`
+	// NGINX ID of the Plus instance whose upstream is modified
+	NginxId string `protobuf:"bytes,2,opt,name=nginx_id,json=nginxId,proto3" json:"nginx_id"`
+	// Name of the upstream group
+	Upstream string `protobuf:"bytes,3,opt,name=upstream,proto3" json:"upstream"`
+	// Address of the upstream server as it appears in the configuration
+	Server string `protobuf:"bytes,4,opt,name=server,proto3" json:"server"`
+	// State the server is put into
+	State UpstreamDrainRequest_TargetState `protobuf:"varint,5,opt,name=state,proto3,enum=f5.nginx.agent.sdk.UpstreamDrainRequest_TargetState" json:"state"`
+	// The upstream is a stream upstream rather than an HTTP one
+	Stream               bool     `protobuf:"varint,6,opt,name=stream,proto3" json:"stream"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpstreamDrainRequest) Reset()         { *m = UpstreamDrainRequest{} }
+func (m *UpstreamDrainRequest) String() string { return proto.CompactTextString(m) }
+func (*UpstreamDrainRequest) ProtoMessage()    {}
+func (*UpstreamDrainRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_213c0bb044472049, []int{5}
 }
-func (m *DataplaneSoftwareHealth) XXX_Unmarshal(b []byte) error {
+func (m *UpstreamDrainRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DataplaneSoftwareHealth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpstreamDrainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DataplaneSoftwareHealth.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpstreamDrainRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -770,86 +953,93 @@ func (m *DataplaneSoftwareHealth) XXX_Marshal(b []byte, deterministic bool) ([]b
 		return b[:n], nil
 	}
 }
-func (m *DataplaneSoftwareHealth) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DataplaneSoftwareHealth.Merge(m, src)
+func (m *UpstreamDrainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpstreamDrainRequest.Merge(m, src)
 }
-func (m *DataplaneSoftwareHealth) XXX_Size() int {
+func (m *UpstreamDrainRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DataplaneSoftwareHealth) XXX_DiscardUnknown() {
-	xxx_messageInfo_DataplaneSoftwareHealth.DiscardUnknown(m)
+func (m *UpstreamDrainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpstreamDrainRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DataplaneSoftwareHealth proto.InternalMessageInfo
-
-type isDataplaneSoftwareHealth_Health interface {
-	isDataplaneSoftwareHealth_Health()
-	MarshalTo([]byte) (int, error)
-	Size() int
-}
+var xxx_messageInfo_UpstreamDrainRequest proto.InternalMessageInfo
 
-type DataplaneSoftwareHealth_NginxHealth struct {
-	NginxHealth *NginxHealth `protobuf:"bytes,1,opt,name=nginx_health,json=nginxHealth,proto3,oneof" json:"nginx_health"`
-}
-type DataplaneSoftwareHealth_AppProtectWafHealth struct {
-	AppProtectWafHealth *AppProtectWAFHealth `protobuf:"bytes,2,opt,name=app_protect_waf_health,json=appProtectWafHealth,proto3,oneof" json:"app_protect_waf_health"`
+func (m *UpstreamDrainRequest) GetSystemId() string {
+	if m != nil {
+		return m.SystemId
+	}
+	return ""
 }
 
-func (*DataplaneSoftwareHealth_NginxHealth) isDataplaneSoftwareHealth_Health()         {}
-func (*DataplaneSoftwareHealth_AppProtectWafHealth) isDataplaneSoftwareHealth_Health() {}
-
-func (m *DataplaneSoftwareHealth) GetHealth() isDataplaneSoftwareHealth_Health {
+func (m *UpstreamDrainRequest) GetNginxId() string {
 	if m != nil {
-		return m.Health
+		return m.NginxId
 	}
-	return nil
+	return ""
 }
 
-func (m *DataplaneSoftwareHealth) GetNginxHealth() *NginxHealth {
-	if x, ok := m.GetHealth().(*DataplaneSoftwareHealth_NginxHealth); ok {
-		return x.NginxHealth
+func (m *UpstreamDrainRequest) GetUpstream() string {
+	if m != nil {
+		return m.Upstream
 	}
-	return nil
+	return ""
 }
 
-func (m *DataplaneSoftwareHealth) GetAppProtectWafHealth() *AppProtectWAFHealth {
-	if x, ok := m.GetHealth().(*DataplaneSoftwareHealth_AppProtectWafHealth); ok {
-		return x.AppProtectWafHealth
+func (m *UpstreamDrainRequest) GetServer() string {
+	if m != nil {
+		return m.Server
 	}
-	return nil
+	return ""
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*DataplaneSoftwareHealth) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*DataplaneSoftwareHealth_NginxHealth)(nil),
-		(*DataplaneSoftwareHealth_AppProtectWafHealth)(nil),
+func (m *UpstreamDrainRequest) GetState() UpstreamDrainRequest_TargetState {
+	if m != nil {
+		return m.State
 	}
+	return UpstreamDrainRequest_UP
 }
 
-// Represents a dataplane update
-type DataplaneUpdate struct {
-	// Host information
-	Host *HostInfo `protobuf:"bytes,1,opt,name=host,proto3" json:"host"`
-	// List of software details. This includes details about NGINX and any other software installed in the system that the agent is interested in.
-	DataplaneSoftwareDetails []*DataplaneSoftwareDetails `protobuf:"bytes,2,rep,name=dataplane_software_details,json=dataplaneSoftwareDetails,proto3" json:"dataplane_software_details"`
-	XXX_NoUnkeyedLiteral     struct{}                    `json:"-"`
-	XXX_unrecognized         []byte                      `json:"-"`
-	XXX_sizecache            int32                       `json:"-"`
+func (m *UpstreamDrainRequest) GetStream() bool {
+	if m != nil {
+		return m.Stream
+	}
+	return false
 }
 
-func (m *DataplaneUpdate) Reset()         { *m = DataplaneUpdate{} }
-func (m *DataplaneUpdate) String() string { return proto.CompactTextString(m) }
-func (*DataplaneUpdate) ProtoMessage()    {}
-func (*DataplaneUpdate) Descriptor() ([]byte, []int) {
+// Represents a request to shadow the traffic of a managed server block to another
+// backend, so new builds can be tested against production traffic without serving
+// it. The agent generates a dedicated include file with the mirror location, wires
+// it into the server block, and validates the result before reloading; the change
+// is rolled back when validation or the reload fails.
+type TrafficMirrorRequest struct {
+	// System ID
+	SystemId string `protobuf:"bytes,1,opt,name=system_id,json=systemId,proto3" json:"system_id"`
+	// NGINX ID of the instance whose configuration is modified
+	NginxId string `protobuf:"bytes,2,opt,name=nginx_id,json=nginxId,proto3" json:"nginx_id"`
+	// server_name of the server block whose traffic is mirrored
+	ServerName string `protobuf:"bytes,3,opt,name=server_name,json=serverName,proto3" json:"server_name"`
+	// URL of the backend the mirrored requests are sent to
+	Backend string `protobuf:"bytes,4,opt,name=backend,proto3" json:"backend"`
+	// Action performed with the mirror configuration
+	Action               TrafficMirrorRequest_Action `protobuf:"varint,5,opt,name=action,proto3,enum=f5.nginx.agent.sdk.TrafficMirrorRequest_Action" json:"action"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *TrafficMirrorRequest) Reset()         { *m = TrafficMirrorRequest{} }
+func (m *TrafficMirrorRequest) String() string { return proto.CompactTextString(m) }
+func (*TrafficMirrorRequest) ProtoMessage()    {}
+func (*TrafficMirrorRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_213c0bb044472049, []int{6}
 }
-func (m *DataplaneUpdate) XXX_Unmarshal(b []byte) error {
+func (m *TrafficMirrorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DataplaneUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TrafficMirrorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DataplaneUpdate.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TrafficMirrorRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -859,106 +1049,87 @@ func (m *DataplaneUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *DataplaneUpdate) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DataplaneUpdate.Merge(m, src)
+func (m *TrafficMirrorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrafficMirrorRequest.Merge(m, src)
 }
-func (m *DataplaneUpdate) XXX_Size() int {
+func (m *TrafficMirrorRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DataplaneUpdate) XXX_DiscardUnknown() {
-	xxx_messageInfo_DataplaneUpdate.DiscardUnknown(m)
+func (m *TrafficMirrorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrafficMirrorRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DataplaneUpdate proto.InternalMessageInfo
+var xxx_messageInfo_TrafficMirrorRequest proto.InternalMessageInfo
 
-func (m *DataplaneUpdate) GetHost() *HostInfo {
+func (m *TrafficMirrorRequest) GetSystemId() string {
 	if m != nil {
-		return m.Host
+		return m.SystemId
 	}
-	return nil
+	return ""
 }
 
-func (m *DataplaneUpdate) GetDataplaneSoftwareDetails() []*DataplaneSoftwareDetails {
+func (m *TrafficMirrorRequest) GetNginxId() string {
 	if m != nil {
-		return m.DataplaneSoftwareDetails
+		return m.NginxId
 	}
-	return nil
+	return ""
 }
 
-// Represents a download request
-type DownloadRequest struct {
-	// Metadata information
-	Meta                 *Metadata `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *TrafficMirrorRequest) GetServerName() string {
+	if m != nil {
+		return m.ServerName
+	}
+	return ""
 }
 
-func (m *DownloadRequest) Reset()         { *m = DownloadRequest{} }
-func (m *DownloadRequest) String() string { return proto.CompactTextString(m) }
-func (*DownloadRequest) ProtoMessage()    {}
-func (*DownloadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_213c0bb044472049, []int{7}
-}
-func (m *DownloadRequest) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *DownloadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_DownloadRequest.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *TrafficMirrorRequest) GetBackend() string {
+	if m != nil {
+		return m.Backend
 	}
-}
-func (m *DownloadRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DownloadRequest.Merge(m, src)
-}
-func (m *DownloadRequest) XXX_Size() int {
-	return m.Size()
-}
-func (m *DownloadRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DownloadRequest.DiscardUnknown(m)
+	return ""
 }
 
-var xxx_messageInfo_DownloadRequest proto.InternalMessageInfo
-
-func (m *DownloadRequest) GetMeta() *Metadata {
+func (m *TrafficMirrorRequest) GetAction() TrafficMirrorRequest_Action {
 	if m != nil {
-		return m.Meta
+		return m.Action
 	}
-	return nil
+	return TrafficMirrorRequest_SET
 }
 
-// Represents a NGINX config response
-type NginxConfigResponse struct {
-	// Command status
-	Status *CommandStatusResponse `protobuf:"bytes,1,opt,name=status,proto3" json:"status"`
-	// NGINX config action
-	Action NginxConfigAction `protobuf:"varint,2,opt,name=action,proto3,enum=f5.nginx.agent.sdk.NginxConfigAction" json:"action"`
-	// NGINX config description
-	ConfigData           *ConfigDescriptor `protobuf:"bytes,3,opt,name=config_data,json=configData,proto3" json:"config_data"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+// Represents a request to switch a designated upstream to a new set of servers in a
+// single step, supporting blue/green deployments driven from the management server.
+// The agent rewrites the server list into a dedicated include file wired into the
+// upstream block, validates the result before reloading, and rolls the switch back
+// when the new servers fail the post-switch health probes.
+type UpstreamSwitchRequest struct {
+	// System ID
+	SystemId string `protobuf:"bytes,1,opt,name=system_id,json=systemId,proto3" json:"system_id"`
+	// NGINX ID of the instance whose upstream is switched
+	NginxId string `protobuf:"bytes,2,opt,name=nginx_id,json=nginxId,proto3" json:"nginx_id"`
+	// Name of the upstream whose server list is replaced
+	Upstream string `protobuf:"bytes,3,opt,name=upstream,proto3" json:"upstream"`
+	// The new server list
+	Servers []*UpstreamSwitchRequest_Server `protobuf:"bytes,4,rep,name=servers,proto3" json:"servers"`
+	// Path probed over HTTP on every new server after the reload; when empty only the
+	// TCP connection is probed
+	ProbePath            string   `protobuf:"bytes,5,opt,name=probe_path,json=probePath,proto3" json:"probe_path"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NginxConfigResponse) Reset()         { *m = NginxConfigResponse{} }
-func (m *NginxConfigResponse) String() string { return proto.CompactTextString(m) }
-func (*NginxConfigResponse) ProtoMessage()    {}
-func (*NginxConfigResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_213c0bb044472049, []int{8}
+func (m *UpstreamSwitchRequest) Reset()         { *m = UpstreamSwitchRequest{} }
+func (m *UpstreamSwitchRequest) String() string { return proto.CompactTextString(m) }
+func (*UpstreamSwitchRequest) ProtoMessage()    {}
+func (*UpstreamSwitchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{7}
 }
-func (m *NginxConfigResponse) XXX_Unmarshal(b []byte) error {
+func (m *UpstreamSwitchRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *NginxConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpstreamSwitchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_NginxConfigResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpstreamSwitchRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -968,64 +1139,78 @@ func (m *NginxConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *NginxConfigResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NginxConfigResponse.Merge(m, src)
+func (m *UpstreamSwitchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpstreamSwitchRequest.Merge(m, src)
 }
-func (m *NginxConfigResponse) XXX_Size() int {
+func (m *UpstreamSwitchRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *NginxConfigResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_NginxConfigResponse.DiscardUnknown(m)
+func (m *UpstreamSwitchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpstreamSwitchRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NginxConfigResponse proto.InternalMessageInfo
+var xxx_messageInfo_UpstreamSwitchRequest proto.InternalMessageInfo
 
-func (m *NginxConfigResponse) GetStatus() *CommandStatusResponse {
+func (m *UpstreamSwitchRequest) GetSystemId() string {
 	if m != nil {
-		return m.Status
+		return m.SystemId
 	}
-	return nil
+	return ""
 }
 
-func (m *NginxConfigResponse) GetAction() NginxConfigAction {
+func (m *UpstreamSwitchRequest) GetNginxId() string {
 	if m != nil {
-		return m.Action
+		return m.NginxId
 	}
-	return NginxConfigAction_UNKNOWN
+	return ""
 }
 
-func (m *NginxConfigResponse) GetConfigData() *ConfigDescriptor {
+func (m *UpstreamSwitchRequest) GetUpstream() string {
 	if m != nil {
-		return m.ConfigData
+		return m.Upstream
+	}
+	return ""
+}
+
+func (m *UpstreamSwitchRequest) GetServers() []*UpstreamSwitchRequest_Server {
+	if m != nil {
+		return m.Servers
 	}
 	return nil
 }
 
-// Represents an upload status
-type UploadStatus struct {
-	// Metadata information
-	Meta *Metadata `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta"`
-	// Transfer status
-	Status UploadStatus_TransferStatus `protobuf:"varint,2,opt,name=status,proto3,enum=f5.nginx.agent.sdk.UploadStatus_TransferStatus" json:"status"`
-	// Provides an error message of why the upload failed
-	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason"`
+func (m *UpstreamSwitchRequest) GetProbePath() string {
+	if m != nil {
+		return m.ProbePath
+	}
+	return ""
+}
+
+// A member of the new server list
+type UpstreamSwitchRequest_Server struct {
+	// Address of the server as it would appear in a server directive
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address"`
+	// Relative weight of the server; zero leaves the nginx default in place
+	Weight int32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight"`
+	// The server only receives traffic when the primary servers are unavailable
+	Backup               bool     `protobuf:"varint,3,opt,name=backup,proto3" json:"backup"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UploadStatus) Reset()         { *m = UploadStatus{} }
-func (m *UploadStatus) String() string { return proto.CompactTextString(m) }
-func (*UploadStatus) ProtoMessage()    {}
-func (*UploadStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_213c0bb044472049, []int{9}
+func (m *UpstreamSwitchRequest_Server) Reset()         { *m = UpstreamSwitchRequest_Server{} }
+func (m *UpstreamSwitchRequest_Server) String() string { return proto.CompactTextString(m) }
+func (*UpstreamSwitchRequest_Server) ProtoMessage()    {}
+func (*UpstreamSwitchRequest_Server) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{7, 0}
 }
-func (m *UploadStatus) XXX_Unmarshal(b []byte) error {
+func (m *UpstreamSwitchRequest_Server) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UploadStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpstreamSwitchRequest_Server) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UploadStatus.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpstreamSwitchRequest_Server.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1035,62 +1220,66 @@ func (m *UploadStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *UploadStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UploadStatus.Merge(m, src)
+func (m *UpstreamSwitchRequest_Server) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpstreamSwitchRequest_Server.Merge(m, src)
 }
-func (m *UploadStatus) XXX_Size() int {
+func (m *UpstreamSwitchRequest_Server) XXX_Size() int {
 	return m.Size()
 }
-func (m *UploadStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_UploadStatus.DiscardUnknown(m)
+func (m *UpstreamSwitchRequest_Server) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpstreamSwitchRequest_Server.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UploadStatus proto.InternalMessageInfo
+var xxx_messageInfo_UpstreamSwitchRequest_Server proto.InternalMessageInfo
 
-func (m *UploadStatus) GetMeta() *Metadata {
+func (m *UpstreamSwitchRequest_Server) GetAddress() string {
 	if m != nil {
-		return m.Meta
+		return m.Address
 	}
-	return nil
+	return ""
 }
 
-func (m *UploadStatus) GetStatus() UploadStatus_TransferStatus {
+func (m *UpstreamSwitchRequest_Server) GetWeight() int32 {
 	if m != nil {
-		return m.Status
+		return m.Weight
 	}
-	return UploadStatus_UNKNOWN
+	return 0
 }
 
-func (m *UploadStatus) GetReason() string {
+func (m *UpstreamSwitchRequest_Server) GetBackup() bool {
 	if m != nil {
-		return m.Reason
+		return m.Backup
 	}
-	return ""
+	return false
 }
 
-// Represents a data chunck
-type DataChunk struct {
-	// Types that are valid to be assigned to Chunk:
-	//	*DataChunk_Header
-	//	*DataChunk_Data
-	Chunk                isDataChunk_Chunk `protobuf_oneof:"chunk"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+// Represents a command status response
+type CommandStatusResponse struct {
+	// Command status
+	Status CommandStatusResponse_CommandStatus `protobuf:"varint,1,opt,name=status,proto3,enum=f5.nginx.agent.sdk.CommandStatusResponse_CommandStatus" json:"status"`
+	// Error code
+	ErrorCode CommandStatusResponse_CommandErrorCode `protobuf:"varint,2,opt,name=error_code,json=errorCode,proto3,enum=f5.nginx.agent.sdk.CommandStatusResponse_CommandErrorCode" json:"error_code"`
+	// Provides a user friendly message to describe the response
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message"`
+	// Provides an error message of why the command failed
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DataChunk) Reset()         { *m = DataChunk{} }
-func (m *DataChunk) String() string { return proto.CompactTextString(m) }
-func (*DataChunk) ProtoMessage()    {}
-func (*DataChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_213c0bb044472049, []int{10}
+func (m *CommandStatusResponse) Reset()         { *m = CommandStatusResponse{} }
+func (m *CommandStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandStatusResponse) ProtoMessage()    {}
+func (*CommandStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{8}
 }
-func (m *DataChunk) XXX_Unmarshal(b []byte) error {
+func (m *CommandStatusResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DataChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CommandStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DataChunk.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CommandStatusResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1100,90 +1289,79 @@ func (m *DataChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *DataChunk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DataChunk.Merge(m, src)
+func (m *CommandStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandStatusResponse.Merge(m, src)
 }
-func (m *DataChunk) XXX_Size() int {
+func (m *CommandStatusResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *DataChunk) XXX_DiscardUnknown() {
-	xxx_messageInfo_DataChunk.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_DataChunk proto.InternalMessageInfo
-
-type isDataChunk_Chunk interface {
-	isDataChunk_Chunk()
-	MarshalTo([]byte) (int, error)
-	Size() int
-}
-
-type DataChunk_Header struct {
-	Header *ChunkedResourceHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof" json:"header"`
-}
-type DataChunk_Data struct {
-	Data *ChunkedResourceChunk `protobuf:"bytes,2,opt,name=data,proto3,oneof" json:"data"`
+func (m *CommandStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandStatusResponse.DiscardUnknown(m)
 }
 
-func (*DataChunk_Header) isDataChunk_Chunk() {}
-func (*DataChunk_Data) isDataChunk_Chunk()   {}
+var xxx_messageInfo_CommandStatusResponse proto.InternalMessageInfo
 
-func (m *DataChunk) GetChunk() isDataChunk_Chunk {
+func (m *CommandStatusResponse) GetStatus() CommandStatusResponse_CommandStatus {
 	if m != nil {
-		return m.Chunk
+		return m.Status
 	}
-	return nil
+	return CommandStatusResponse_CMD_UNKNOWN
 }
 
-func (m *DataChunk) GetHeader() *ChunkedResourceHeader {
-	if x, ok := m.GetChunk().(*DataChunk_Header); ok {
-		return x.Header
+func (m *CommandStatusResponse) GetErrorCode() CommandStatusResponse_CommandErrorCode {
+	if m != nil {
+		return m.ErrorCode
 	}
-	return nil
+	return CommandStatusResponse_ERR_OK
 }
 
-func (m *DataChunk) GetData() *ChunkedResourceChunk {
-	if x, ok := m.GetChunk().(*DataChunk_Data); ok {
-		return x.Data
+func (m *CommandStatusResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	return nil
+	return ""
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*DataChunk) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*DataChunk_Header)(nil),
-		(*DataChunk_Data)(nil),
+func (m *CommandStatusResponse) GetError() string {
+	if m != nil {
+		return m.Error
 	}
+	return ""
 }
 
-// Represents a chunked resource Header
-type ChunkedResourceHeader struct {
-	// Metadata information
-	Meta *Metadata `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta"`
-	// Number of chunks expected in the transfer
-	Chunks int32 `protobuf:"varint,2,opt,name=chunks,proto3" json:"chunks"`
-	// Chunk checksum
-	Checksum string `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum"`
-	// Chunk size
-	ChunkSize            int32    `protobuf:"varint,4,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// Represents a dataplane status, which is used by the agent to periodically report the status of NGINX, agent activities and other dataplane software activities.
+type DataplaneStatus struct {
+	// System ID
+	SystemId string `protobuf:"bytes,1,opt,name=system_id,json=systemId,proto3" json:"system_id"`
+	// List of NGINX details. This field will be moving to DataplaneSoftwareDetails in a future release.
+	Details []*NginxDetails `protobuf:"bytes,2,rep,name=details,proto3" json:"details"`
+	// Host information
+	Host *HostInfo `protobuf:"bytes,3,opt,name=host,proto3" json:"host"`
+	// List of NGINX health information. This field will be moving to DataplaneSoftwareHealth in a future release.
+	Healths []*NginxHealth `protobuf:"bytes,5,rep,name=healths,proto3" json:"healths"`
+	// List of software details. This includes details about NGINX and any other software installed in the system that the agent is interested in.
+	DataplaneSoftwareDetails []*DataplaneSoftwareDetails `protobuf:"bytes,6,rep,name=dataplane_software_details,json=dataplaneSoftwareDetails,proto3" json:"dataplane_software_details"`
+	// List of software health statues. This includes the health of NGINX and any other software installed in the system that the agent is interested in.
+	DataplaneSoftwareHealths []*DataplaneSoftwareHealth `protobuf:"bytes,7,rep,name=dataplane_software_healths,json=dataplaneSoftwareHealths,proto3" json:"dataplane_software_healths"`
+	// List of activity statuses. Reports on the status of activities that the agent is currently executing.
+	AgentActivityStatus  []*AgentActivityStatus `protobuf:"bytes,8,rep,name=agent_activity_status,json=agentActivityStatus,proto3" json:"agent_activity_status"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func (m *ChunkedResourceHeader) Reset()         { *m = ChunkedResourceHeader{} }
-func (m *ChunkedResourceHeader) String() string { return proto.CompactTextString(m) }
-func (*ChunkedResourceHeader) ProtoMessage()    {}
-func (*ChunkedResourceHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_213c0bb044472049, []int{11}
+func (m *DataplaneStatus) Reset()         { *m = DataplaneStatus{} }
+func (m *DataplaneStatus) String() string { return proto.CompactTextString(m) }
+func (*DataplaneStatus) ProtoMessage()    {}
+func (*DataplaneStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{9}
 }
-func (m *ChunkedResourceHeader) XXX_Unmarshal(b []byte) error {
+func (m *DataplaneStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ChunkedResourceHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DataplaneStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ChunkedResourceHeader.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DataplaneStatus.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1193,71 +1371,90 @@ func (m *ChunkedResourceHeader) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *ChunkedResourceHeader) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChunkedResourceHeader.Merge(m, src)
+func (m *DataplaneStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataplaneStatus.Merge(m, src)
 }
-func (m *ChunkedResourceHeader) XXX_Size() int {
+func (m *DataplaneStatus) XXX_Size() int {
 	return m.Size()
 }
-func (m *ChunkedResourceHeader) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChunkedResourceHeader.DiscardUnknown(m)
+func (m *DataplaneStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataplaneStatus.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ChunkedResourceHeader proto.InternalMessageInfo
+var xxx_messageInfo_DataplaneStatus proto.InternalMessageInfo
 
-func (m *ChunkedResourceHeader) GetMeta() *Metadata {
+func (m *DataplaneStatus) GetSystemId() string {
 	if m != nil {
-		return m.Meta
+		return m.SystemId
+	}
+	return ""
+}
+
+func (m *DataplaneStatus) GetDetails() []*NginxDetails {
+	if m != nil {
+		return m.Details
 	}
 	return nil
 }
 
-func (m *ChunkedResourceHeader) GetChunks() int32 {
+func (m *DataplaneStatus) GetHost() *HostInfo {
 	if m != nil {
-		return m.Chunks
+		return m.Host
 	}
-	return 0
+	return nil
 }
 
-func (m *ChunkedResourceHeader) GetChecksum() string {
+func (m *DataplaneStatus) GetHealths() []*NginxHealth {
 	if m != nil {
-		return m.Checksum
+		return m.Healths
 	}
-	return ""
+	return nil
 }
 
-func (m *ChunkedResourceHeader) GetChunkSize() int32 {
+func (m *DataplaneStatus) GetDataplaneSoftwareDetails() []*DataplaneSoftwareDetails {
 	if m != nil {
-		return m.ChunkSize
+		return m.DataplaneSoftwareDetails
 	}
-	return 0
+	return nil
 }
 
-// Represents a chunked resource chunk
-type ChunkedResourceChunk struct {
-	// Metadata information
-	Meta *Metadata `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta"`
-	// Chunk ID
-	ChunkId int32 `protobuf:"varint,2,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id"`
-	// Chunk data
-	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *DataplaneStatus) GetDataplaneSoftwareHealths() []*DataplaneSoftwareHealth {
+	if m != nil {
+		return m.DataplaneSoftwareHealths
+	}
+	return nil
 }
 
-func (m *ChunkedResourceChunk) Reset()         { *m = ChunkedResourceChunk{} }
-func (m *ChunkedResourceChunk) String() string { return proto.CompactTextString(m) }
-func (*ChunkedResourceChunk) ProtoMessage()    {}
-func (*ChunkedResourceChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_213c0bb044472049, []int{12}
+func (m *DataplaneStatus) GetAgentActivityStatus() []*AgentActivityStatus {
+	if m != nil {
+		return m.AgentActivityStatus
+	}
+	return nil
 }
-func (m *ChunkedResourceChunk) XXX_Unmarshal(b []byte) error {
+
+// Represent an agent activity status
+type AgentActivityStatus struct {
+	// Types that are valid to be assigned to Status:
+	//
+	//	*AgentActivityStatus_NginxConfigStatus
+	Status               isAgentActivityStatus_Status `protobuf_oneof:"Status"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *AgentActivityStatus) Reset()         { *m = AgentActivityStatus{} }
+func (m *AgentActivityStatus) String() string { return proto.CompactTextString(m) }
+func (*AgentActivityStatus) ProtoMessage()    {}
+func (*AgentActivityStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{10}
+}
+func (m *AgentActivityStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ChunkedResourceChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AgentActivityStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ChunkedResourceChunk.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AgentActivityStatus.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1267,894 +1464,875 @@ func (m *ChunkedResourceChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *ChunkedResourceChunk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChunkedResourceChunk.Merge(m, src)
+func (m *AgentActivityStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentActivityStatus.Merge(m, src)
 }
-func (m *ChunkedResourceChunk) XXX_Size() int {
+func (m *AgentActivityStatus) XXX_Size() int {
 	return m.Size()
 }
-func (m *ChunkedResourceChunk) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChunkedResourceChunk.DiscardUnknown(m)
+func (m *AgentActivityStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentActivityStatus.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ChunkedResourceChunk proto.InternalMessageInfo
+var xxx_messageInfo_AgentActivityStatus proto.InternalMessageInfo
 
-func (m *ChunkedResourceChunk) GetMeta() *Metadata {
-	if m != nil {
-		return m.Meta
-	}
-	return nil
+type isAgentActivityStatus_Status interface {
+	isAgentActivityStatus_Status()
+	MarshalTo([]byte) (int, error)
+	Size() int
 }
 
-func (m *ChunkedResourceChunk) GetChunkId() int32 {
-	if m != nil {
-		return m.ChunkId
-	}
-	return 0
+type AgentActivityStatus_NginxConfigStatus struct {
+	NginxConfigStatus *NginxConfigStatus `protobuf:"bytes,1,opt,name=nginx_config_status,json=nginxConfigStatus,proto3,oneof" json:"nginx_config_status"`
 }
 
-func (m *ChunkedResourceChunk) GetData() []byte {
+func (*AgentActivityStatus_NginxConfigStatus) isAgentActivityStatus_Status() {}
+
+func (m *AgentActivityStatus) GetStatus() isAgentActivityStatus_Status {
 	if m != nil {
-		return m.Data
+		return m.Status
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("f5.nginx.agent.sdk.Command_CommandType", Command_CommandType_name, Command_CommandType_value)
-	proto.RegisterEnum("f5.nginx.agent.sdk.CommandStatusResponse_CommandStatus", CommandStatusResponse_CommandStatus_name, CommandStatusResponse_CommandStatus_value)
-	proto.RegisterEnum("f5.nginx.agent.sdk.CommandStatusResponse_CommandErrorCode", CommandStatusResponse_CommandErrorCode_name, CommandStatusResponse_CommandErrorCode_value)
-	proto.RegisterEnum("f5.nginx.agent.sdk.NginxConfigStatus_Status", NginxConfigStatus_Status_name, NginxConfigStatus_Status_value)
-	proto.RegisterEnum("f5.nginx.agent.sdk.UploadStatus_TransferStatus", UploadStatus_TransferStatus_name, UploadStatus_TransferStatus_value)
-	proto.RegisterType((*Command)(nil), "f5.nginx.agent.sdk.Command")
-	proto.RegisterType((*CommandStatusResponse)(nil), "f5.nginx.agent.sdk.CommandStatusResponse")
-	proto.RegisterType((*DataplaneStatus)(nil), "f5.nginx.agent.sdk.DataplaneStatus")
-	proto.RegisterType((*AgentActivityStatus)(nil), "f5.nginx.agent.sdk.AgentActivityStatus")
-	proto.RegisterType((*NginxConfigStatus)(nil), "f5.nginx.agent.sdk.NginxConfigStatus")
-	proto.RegisterType((*DataplaneSoftwareHealth)(nil), "f5.nginx.agent.sdk.DataplaneSoftwareHealth")
-	proto.RegisterType((*DataplaneUpdate)(nil), "f5.nginx.agent.sdk.DataplaneUpdate")
-	proto.RegisterType((*DownloadRequest)(nil), "f5.nginx.agent.sdk.DownloadRequest")
-	proto.RegisterType((*NginxConfigResponse)(nil), "f5.nginx.agent.sdk.NginxConfigResponse")
-	proto.RegisterType((*UploadStatus)(nil), "f5.nginx.agent.sdk.UploadStatus")
-	proto.RegisterType((*DataChunk)(nil), "f5.nginx.agent.sdk.DataChunk")
-	proto.RegisterType((*ChunkedResourceHeader)(nil), "f5.nginx.agent.sdk.ChunkedResourceHeader")
-	proto.RegisterType((*ChunkedResourceChunk)(nil), "f5.nginx.agent.sdk.ChunkedResourceChunk")
-}
-
-func init() { proto.RegisterFile("command.proto", fileDescriptor_213c0bb044472049) }
-
-var fileDescriptor_213c0bb044472049 = []byte{
-	// 1534 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcb, 0x6e, 0xdb, 0x46,
-	0x17, 0x26, 0x15, 0xeb, 0x76, 0x24, 0xdb, 0xca, 0xd8, 0x49, 0x14, 0x23, 0x30, 0x0d, 0xfe, 0x7f,
-	0x6a, 0xa7, 0x4d, 0x25, 0xd4, 0x41, 0x11, 0x34, 0x59, 0x59, 0x96, 0x13, 0x0a, 0x89, 0x65, 0x63,
-	0x12, 0x27, 0x40, 0x8a, 0x42, 0x60, 0xc4, 0x91, 0x4c, 0xd8, 0x22, 0x59, 0x92, 0x72, 0xe2, 0xa0,
-	0xfb, 0xa2, 0x45, 0x37, 0x5d, 0x74, 0xd1, 0xf6, 0x21, 0xfa, 0x1a, 0x5d, 0x66, 0xdd, 0x05, 0x51,
-	0x64, 0xc9, 0x07, 0x68, 0x17, 0xdd, 0x14, 0x73, 0xa1, 0x44, 0x49, 0x94, 0xec, 0xd4, 0x45, 0x37,
-	0x9a, 0xe1, 0xf0, 0x3b, 0xdf, 0xb9, 0xcc, 0xcc, 0x37, 0x43, 0xc1, 0x7c, 0xdb, 0xee, 0xf5, 0x74,
-	0xcb, 0xa8, 0x38, 0xae, 0xed, 0xdb, 0x08, 0x75, 0x3e, 0xad, 0x58, 0x5d, 0xd3, 0x7a, 0x5d, 0xd1,
-	0xbb, 0xc4, 0xf2, 0x2b, 0x9e, 0x71, 0xb4, 0x52, 0xe0, 0x5d, 0x06, 0x58, 0x29, 0x52, 0xbc, 0x6d,
-	0x0d, 0x9f, 0xac, 0x8e, 0xd9, 0x15, 0x4f, 0xd7, 0x0d, 0xa7, 0xe5, 0xd9, 0x1d, 0xff, 0x95, 0xee,
-	0x92, 0x96, 0x41, 0x7c, 0xdd, 0x3c, 0xf6, 0xc4, 0x2b, 0x44, 0x4e, 0x88, 0xe5, 0x7b, 0x55, 0xd6,
-	0x88, 0x31, 0xe8, 0xda, 0x5d, 0x3b, 0xea, 0x1f, 0xda, 0x5e, 0x34, 0x9e, 0xb7, 0x74, 0x47, 0x74,
-	0x0b, 0x3c, 0x16, 0xf6, 0xa0, 0xfe, 0x05, 0x90, 0xdd, 0xe6, 0xd1, 0xa2, 0x7b, 0x30, 0xd7, 0x23,
-	0xbe, 0x5e, 0x96, 0xd7, 0xe4, 0x8d, 0xc2, 0xe6, 0x8d, 0xca, 0x64, 0xd8, 0x95, 0x5d, 0xe2, 0xeb,
-	0x86, 0xee, 0xeb, 0xb5, 0x5c, 0x18, 0x28, 0x0c, 0x8d, 0xd9, 0x2f, 0xda, 0x81, 0x39, 0xff, 0xd4,
-	0x21, 0xe5, 0xd4, 0x9a, 0xbc, 0xb1, 0xb0, 0xb9, 0x9e, 0x64, 0x2b, 0xdc, 0x44, 0xed, 0xd3, 0x53,
-	0x87, 0x70, 0x1a, 0x6a, 0x88, 0xd9, 0x2f, 0x7a, 0x01, 0xd0, 0xee, 0x19, 0x2d, 0xcf, 0xd7, 0xfd,
-	0xbe, 0x57, 0xbe, 0xc4, 0x02, 0xb9, 0x35, 0x83, 0xec, 0x09, 0x03, 0x62, 0xe2, 0x39, 0xb6, 0xe5,
-	0x91, 0xda, 0x42, 0x18, 0x28, 0x31, 0x02, 0x4d, 0xc2, 0xf9, 0x76, 0x4f, 0x80, 0xd0, 0x33, 0x28,
-	0x32, 0x96, 0x16, 0xaf, 0x6f, 0x79, 0x8e, 0xb1, 0x2b, 0x49, 0xec, 0x4d, 0xfa, 0xbc, 0xcd, 0x60,
-	0xb5, 0x52, 0x18, 0x28, 0x23, 0x86, 0x9a, 0x84, 0x79, 0x09, 0x39, 0x00, 0xbd, 0x86, 0x2b, 0xf1,
-	0xd7, 0x2d, 0x57, 0x44, 0x53, 0x4e, 0x33, 0x07, 0xeb, 0x67, 0x38, 0x18, 0x04, 0x7f, 0x3d, 0x0c,
-	0x94, 0x64, 0x26, 0x4d, 0xc2, 0x4b, 0xd6, 0xa4, 0x05, 0xf5, 0xcc, 0x28, 0x29, 0xde, 0x22, 0x6d,
-	0xbf, 0xe5, 0x92, 0x2f, 0xfb, 0xc4, 0xf3, 0xcb, 0x99, 0xe9, 0x9e, 0xb7, 0x68, 0x6f, 0x9b, 0xe3,
-	0x31, 0x87, 0x73, 0xcf, 0x89, 0x4c, 0xd4, 0xb3, 0x3e, 0x69, 0x81, 0xbe, 0x82, 0xab, 0xe3, 0x78,
-	0x91, 0x74, 0x96, 0xb9, 0xde, 0x38, 0xdb, 0xb5, 0xc8, 0x7a, 0x25, 0x0c, 0x94, 0x29, 0x5c, 0x9a,
-	0x84, 0x97, 0xf5, 0x04, 0x1b, 0xe4, 0xc3, 0xf2, 0xc0, 0x82, 0xd7, 0x89, 0xa7, 0x9d, 0x63, 0xbe,
-	0x3f, 0x98, 0xe5, 0x9b, 0x95, 0x8f, 0x67, 0x5d, 0x0e, 0x03, 0x25, 0x91, 0x47, 0x93, 0x30, 0xd2,
-	0x27, 0xf0, 0x74, 0xfd, 0xc4, 0xd1, 0xe5, 0xfc, 0xf4, 0xf5, 0x13, 0xf3, 0xc6, 0xd7, 0x4f, 0xdc,
-	0x90, 0xae, 0x9f, 0x18, 0x3d, 0xea, 0x40, 0x89, 0x6e, 0x29, 0xe7, 0x58, 0xb7, 0x48, 0xb4, 0xf2,
-	0x0b, 0x8c, 0xfb, 0x7f, 0x49, 0xdc, 0xf5, 0x08, 0xcb, 0x97, 0x75, 0x6d, 0x39, 0x0c, 0x94, 0x09,
-	0x02, 0x4d, 0xc2, 0x8b, 0xc6, 0x28, 0x10, 0x7d, 0x01, 0x45, 0xa6, 0x14, 0x2d, 0x97, 0x38, 0xb6,
-	0xeb, 0x97, 0x8b, 0xd3, 0xab, 0xc5, 0x85, 0xa5, 0xb2, 0x43, 0x1b, 0xcc, 0xd0, 0x3c, 0x8d, 0xb8,
-	0x3d, 0x4d, 0x83, 0x0c, 0x01, 0xe8, 0x3b, 0x19, 0x56, 0x62, 0x61, 0x8c, 0x49, 0x56, 0x79, 0x9e,
-	0x79, 0xbb, 0x3d, 0x3b, 0x23, 0x61, 0x54, 0xe7, 0x36, 0xb5, 0xd5, 0x30, 0x50, 0x66, 0x70, 0x6a,
-	0x12, 0x2e, 0x1b, 0x53, 0x6c, 0x47, 0xab, 0xda, 0x77, 0x0c, 0xdd, 0x27, 0xe5, 0x85, 0x73, 0x54,
-	0xf5, 0x80, 0x41, 0xc7, 0xab, 0xca, 0x09, 0x46, 0xaa, 0xca, 0x81, 0xea, 0x1d, 0x28, 0xc4, 0x04,
-	0x0d, 0x01, 0x64, 0x9a, 0x7b, 0x78, 0x77, 0xeb, 0x71, 0x49, 0x42, 0x45, 0xc8, 0xd5, 0xf7, 0x9e,
-	0x37, 0x1f, 0xef, 0x6d, 0xd5, 0x4b, 0x32, 0x7d, 0x73, 0xb0, 0xcf, 0xfa, 0xa9, 0x5a, 0x06, 0xe6,
-	0x28, 0x8f, 0xfa, 0xc3, 0x25, 0xb8, 0x92, 0xa8, 0x64, 0xe8, 0x73, 0xc8, 0x88, 0xa5, 0x20, 0x33,
-	0x45, 0xbd, 0x7b, 0x6e, 0x11, 0x1c, 0x1d, 0xad, 0x41, 0x18, 0x28, 0x82, 0x0a, 0x8b, 0x16, 0x99,
-	0x00, 0xc4, 0x75, 0x6d, 0xb7, 0xd5, 0xb6, 0x8d, 0x48, 0xb2, 0xef, 0xbd, 0xb7, 0x83, 0x1d, 0x4a,
-	0xb1, 0x6d, 0x1b, 0x42, 0x76, 0x87, 0x8c, 0x38, 0x4f, 0xa2, 0x57, 0xe8, 0x26, 0x64, 0x7b, 0xc4,
-	0xf3, 0xf4, 0x2e, 0x61, 0x6a, 0x9e, 0xaf, 0x15, 0xc2, 0x40, 0x89, 0x86, 0x70, 0xd4, 0x41, 0x0a,
-	0xa4, 0x99, 0x0d, 0x13, 0xe5, 0x7c, 0x2d, 0x1f, 0x06, 0x0a, 0x1f, 0xc0, 0xbc, 0x51, 0xef, 0xc3,
-	0xfc, 0x48, 0x30, 0x68, 0x11, 0x0a, 0xdb, 0xbb, 0xf5, 0xd6, 0x41, 0xf3, 0x51, 0x73, 0xef, 0x79,
-	0xb3, 0x24, 0xd1, 0xfa, 0xd2, 0x81, 0xbd, 0x47, 0x25, 0x19, 0xcd, 0x43, 0x9e, 0xf6, 0x77, 0x30,
-	0xde, 0xc3, 0xa5, 0x94, 0x5a, 0x85, 0xd2, 0x78, 0xcc, 0x14, 0xbe, 0x83, 0x31, 0x85, 0x4b, 0x94,
-	0x8b, 0xf6, 0x23, 0x2e, 0x59, 0xfd, 0x39, 0x0d, 0x8b, 0x63, 0xfb, 0x0c, 0x7d, 0x08, 0x79, 0xef,
-	0xd4, 0xf3, 0x49, 0xaf, 0x65, 0x1a, 0x6c, 0x52, 0xf2, 0xb5, 0xf9, 0x30, 0x50, 0x86, 0x83, 0x38,
-	0xc7, 0xbb, 0x0d, 0x03, 0x3d, 0x84, 0x6c, 0xb4, 0xee, 0x53, 0x6b, 0x97, 0x36, 0x0a, 0x9b, 0x6b,
-	0x53, 0x0f, 0x81, 0x68, 0xad, 0xb3, 0xba, 0x08, 0x23, 0x1c, 0x75, 0xe8, 0x91, 0x4c, 0x0f, 0x71,
-	0x71, 0x12, 0x26, 0x1e, 0xc9, 0x9a, 0xed, 0xf9, 0x0d, 0xab, 0x63, 0xf3, 0xb3, 0x94, 0xa2, 0x31,
-	0xfb, 0x45, 0x0f, 0x20, 0x7b, 0x48, 0xf4, 0x63, 0xff, 0xd0, 0x2b, 0xa7, 0x59, 0x10, 0xd3, 0x8f,
-	0x3a, 0x8d, 0xe1, 0x78, 0x0c, 0xc2, 0x06, 0x47, 0x1d, 0xf4, 0xed, 0xec, 0x8d, 0x9d, 0x61, 0xdc,
-	0xff, 0xea, 0xc6, 0x9e, 0xb1, 0xad, 0xbf, 0x49, 0x0e, 0x26, 0x4a, 0x34, 0xcb, 0x82, 0xf9, 0xe8,
-	0x5c, 0xc1, 0x88, 0xa4, 0xa7, 0xc5, 0x12, 0xd5, 0x61, 0x32, 0x16, 0x4d, 0x14, 0xe6, 0x24, 0x3a,
-	0x7e, 0xf5, 0xb6, 0x6f, 0x9e, 0x98, 0xfe, 0x69, 0xa4, 0xde, 0x39, 0x16, 0xc5, 0xf4, 0xe3, 0x77,
-	0x4b, 0xe0, 0xc5, 0x16, 0x8d, 0x1d, 0xbf, 0x63, 0x4c, 0xe2, 0xf0, 0x1d, 0xc5, 0xab, 0xdf, 0xcb,
-	0xb0, 0x94, 0xc0, 0x83, 0x1c, 0x58, 0x1a, 0xb9, 0x3e, 0xc4, 0x04, 0xa4, 0xb0, 0x79, 0xf3, 0x8c,
-	0x6b, 0x88, 0x88, 0xe5, 0x5a, 0x18, 0x28, 0x49, 0x2c, 0x9a, 0x84, 0x2f, 0x5b, 0x13, 0xe8, 0x1c,
-	0x64, 0x44, 0x4c, 0x3f, 0xa5, 0xe0, 0xf2, 0x04, 0x1b, 0xfa, 0x0c, 0x16, 0xda, 0xb6, 0xeb, 0x92,
-	0x63, 0xdd, 0x37, 0x6d, 0x6b, 0xb8, 0x71, 0x50, 0x18, 0x28, 0x63, 0x6f, 0xf0, 0x7c, 0xec, 0xb9,
-	0x61, 0xa0, 0xfd, 0x81, 0x00, 0x72, 0x7d, 0xba, 0x7d, 0xae, 0xf8, 0x2b, 0x33, 0x54, 0xef, 0x9c,
-	0x52, 0xb4, 0x0e, 0x39, 0x9e, 0xbf, 0x69, 0x08, 0x35, 0x2a, 0x86, 0x81, 0x32, 0x18, 0xc3, 0x59,
-	0xd6, 0x6b, 0x18, 0xea, 0x46, 0x94, 0x3c, 0x2a, 0x40, 0x76, 0x7f, 0xa7, 0x59, 0x6f, 0x34, 0x1f,
-	0x96, 0x24, 0x94, 0x81, 0x14, 0xd3, 0xa0, 0x3c, 0xa4, 0x23, 0xfd, 0xf9, 0x53, 0x86, 0x6b, 0x53,
-	0x96, 0xdf, 0xf0, 0x56, 0xca, 0xd7, 0x9b, 0x98, 0xad, 0x33, 0xb7, 0x6a, 0xec, 0x56, 0xca, 0x0d,
-	0x07, 0xb7, 0x52, 0xc1, 0xfb, 0x06, 0xae, 0xea, 0x8e, 0xd3, 0xa2, 0xb7, 0x7c, 0x7a, 0xa7, 0x7a,
-	0xa5, 0x77, 0x22, 0x0f, 0xa9, 0x19, 0x97, 0x43, 0xc7, 0xd9, 0xe7, 0x06, 0xcf, 0xb7, 0x1e, 0x08,
-	0x4f, 0xfc, 0x82, 0x96, 0x48, 0xc5, 0x6e, 0x87, 0x43, 0x13, 0xbd, 0x23, 0x4c, 0x72, 0x90, 0xe1,
-	0x00, 0xf5, 0x37, 0x39, 0x26, 0xa4, 0xfc, 0xc4, 0x1c, 0x68, 0x9a, 0xfc, 0x0f, 0x34, 0xed, 0x0c,
-	0x2d, 0x4a, 0xfd, 0x97, 0x5a, 0xa4, 0xee, 0xc2, 0x62, 0xdd, 0x7e, 0x65, 0x1d, 0xdb, 0xba, 0x11,
-	0xdd, 0x11, 0x2f, 0xf0, 0x09, 0xa5, 0x7e, 0x9d, 0x82, 0xa5, 0x84, 0xef, 0x02, 0xb4, 0x3b, 0x72,
-	0x15, 0x78, 0xaf, 0xef, 0xa1, 0xa4, 0x6d, 0xd0, 0x80, 0x0c, 0x55, 0x19, 0xdb, 0x12, 0x1b, 0xeb,
-	0x2c, 0x61, 0xd8, 0x62, 0x60, 0x4e, 0xc5, 0x0d, 0xb1, 0x68, 0xd1, 0x33, 0x28, 0x08, 0x91, 0xa0,
-	0x09, 0x89, 0x43, 0xea, 0xff, 0xc9, 0xe1, 0x51, 0x58, 0x9d, 0x78, 0x6d, 0xd7, 0x74, 0x7c, 0xdb,
-	0xad, 0x2d, 0x86, 0x81, 0x12, 0x37, 0xc6, 0xc0, 0x1f, 0xe8, 0x34, 0xa9, 0x7f, 0xc8, 0x50, 0x3c,
-	0x70, 0x68, 0x5d, 0xc5, 0x06, 0xbb, 0xc8, 0x97, 0xe9, 0x93, 0x31, 0x21, 0xa9, 0x26, 0x59, 0xc7,
-	0xbd, 0x55, 0x9e, 0xba, 0xba, 0xe5, 0x75, 0x88, 0x3b, 0x43, 0x4b, 0x54, 0xc8, 0xb8, 0x44, 0xf7,
-	0x6c, 0x4b, 0x48, 0x09, 0xc3, 0xf0, 0x11, 0x2c, 0x5a, 0xf5, 0x13, 0x58, 0x18, 0x65, 0xa2, 0x3a,
-	0x31, 0xbc, 0xaf, 0x44, 0x3a, 0x01, 0x90, 0x79, 0xb0, 0xd5, 0x78, 0xbc, 0x53, 0x2f, 0xa5, 0xd4,
-	0x5f, 0x64, 0xc8, 0xd3, 0x0a, 0x6c, 0x1f, 0xf6, 0xad, 0x23, 0xb4, 0xc7, 0xb6, 0x91, 0x41, 0xdc,
-	0x99, 0x13, 0x4f, 0xa1, 0xc4, 0xc0, 0xc4, 0xb3, 0xfb, 0x6e, 0x9b, 0xaa, 0x8a, 0x41, 0x5c, 0x1e,
-	0x0f, 0x37, 0xd6, 0x24, 0x2c, 0x7a, 0x48, 0xe3, 0xd7, 0x4e, 0xa1, 0x00, 0x1b, 0xe7, 0xa0, 0x63,
-	0x8f, 0xbc, 0xa4, 0xd4, 0x52, 0x93, 0x30, 0x6b, 0x6b, 0x59, 0x48, 0xb7, 0xe9, 0x2b, 0xf5, 0xad,
-	0x0c, 0x57, 0x12, 0x43, 0xb8, 0xd0, 0x9c, 0xa9, 0x90, 0x61, 0xf4, 0x7c, 0xce, 0xd2, 0x3c, 0x1d,
-	0x3e, 0x82, 0x45, 0x8b, 0x36, 0x20, 0xd7, 0x3e, 0x24, 0xed, 0x23, 0xaf, 0xdf, 0x13, 0x93, 0xc0,
-	0x74, 0x3a, 0x1a, 0xc3, 0x83, 0x1e, 0xfa, 0x18, 0x80, 0xd9, 0xb4, 0x3c, 0xf3, 0x0d, 0x61, 0x9a,
-	0x9e, 0x16, 0xff, 0x14, 0x0c, 0x46, 0x71, 0x9e, 0xf5, 0x9f, 0x98, 0x6f, 0x88, 0xfa, 0xa3, 0x0c,
-	0xcb, 0x49, 0x65, 0xb8, 0x50, 0x46, 0xeb, 0x34, 0x5a, 0xea, 0xcd, 0x34, 0x44, 0x4e, 0x22, 0x5a,
-	0x3e, 0x86, 0xb3, 0xac, 0xd7, 0x30, 0xd0, 0x0d, 0x31, 0x47, 0x34, 0xa5, 0xe2, 0xb0, 0xf2, 0xa2,
-	0xee, 0x77, 0x7f, 0x7d, 0xb7, 0x2a, 0xbf, 0x7d, 0xb7, 0x2a, 0xff, 0xfe, 0x6e, 0x55, 0x7e, 0x71,
-	0xab, 0x6b, 0xfa, 0x87, 0xfd, 0x97, 0x95, 0xb6, 0xdd, 0xab, 0xb2, 0x48, 0xaa, 0x2c, 0x92, 0xaa,
-	0x67, 0x1c, 0x55, 0x4f, 0x36, 0xab, 0xec, 0x1f, 0x9e, 0xfb, 0xec, 0xf7, 0x65, 0x86, 0x35, 0x77,
-	0xfe, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x6a, 0x53, 0x4d, 0x33, 0x9b, 0x12, 0x00, 0x00,
+func (m *AgentActivityStatus) GetNginxConfigStatus() *NginxConfigStatus {
+	if x, ok := m.GetStatus().(*AgentActivityStatus_NginxConfigStatus); ok {
+		return x.NginxConfigStatus
+	}
+	return nil
 }
 
-func (m *Command) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*AgentActivityStatus) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*AgentActivityStatus_NginxConfigStatus)(nil),
 	}
-	return dAtA[:n], nil
 }
 
-func (m *Command) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Represents a NGINX configuration status
+type NginxConfigStatus struct {
+	// CorrelationID is an ID used by the producer of the message to track the flow of events
+	CorrelationId string `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id"`
+	// Provides a status for the NGINX configuration
+	Status NginxConfigStatus_Status `protobuf:"varint,2,opt,name=status,proto3,enum=f5.nginx.agent.sdk.NginxConfigStatus_Status" json:"status"`
+	// Provides a user friendly message to describe the current state of the NGINX configuration.
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message"`
+	// NGINX ID
+	NginxId              string   `protobuf:"bytes,4,opt,name=nginx_id,json=nginxId,proto3" json:"nginx_id"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Command) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Data != nil {
-		{
-			size := m.Data.Size()
-			i -= size
-			if _, err := m.Data.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-		}
-	}
-	if m.Type != 0 {
-		i = encodeVarintCommand(dAtA, i, uint64(m.Type))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Meta != nil {
-		{
-			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *NginxConfigStatus) Reset()         { *m = NginxConfigStatus{} }
+func (m *NginxConfigStatus) String() string { return proto.CompactTextString(m) }
+func (*NginxConfigStatus) ProtoMessage()    {}
+func (*NginxConfigStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{11}
 }
-
-func (m *Command_CmdStatus) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *NginxConfigStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *Command_CmdStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.CmdStatus != nil {
-		{
-			size, err := m.CmdStatus.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
+func (m *NginxConfigStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NginxConfigStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x1a
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *Command_NginxConfig) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *NginxConfigStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NginxConfigStatus.Merge(m, src)
 }
-
-func (m *Command_NginxConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.NginxConfig != nil {
-		{
-			size, err := m.NginxConfig.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	return len(dAtA) - i, nil
+func (m *NginxConfigStatus) XXX_Size() int {
+	return m.Size()
 }
-func (m *Command_NginxConfigResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *NginxConfigStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_NginxConfigStatus.DiscardUnknown(m)
 }
 
-func (m *Command_NginxConfigResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.NginxConfigResponse != nil {
-		{
-			size, err := m.NginxConfigResponse.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x2a
+var xxx_messageInfo_NginxConfigStatus proto.InternalMessageInfo
+
+func (m *NginxConfigStatus) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
 	}
-	return len(dAtA) - i, nil
-}
-func (m *Command_AgentConnectRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return ""
 }
 
-func (m *Command_AgentConnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.AgentConnectRequest != nil {
-		{
-			size, err := m.AgentConnectRequest.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x32
+func (m *NginxConfigStatus) GetStatus() NginxConfigStatus_Status {
+	if m != nil {
+		return m.Status
 	}
-	return len(dAtA) - i, nil
-}
-func (m *Command_AgentConnectResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return NginxConfigStatus_PENDING
 }
 
-func (m *Command_AgentConnectResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.AgentConnectResponse != nil {
-		{
-			size, err := m.AgentConnectResponse.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x3a
+func (m *NginxConfigStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	return len(dAtA) - i, nil
-}
-func (m *Command_AgentConfigRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return ""
 }
 
-func (m *Command_AgentConfigRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.AgentConfigRequest != nil {
-		{
-			size, err := m.AgentConfigRequest.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x42
+func (m *NginxConfigStatus) GetNginxId() string {
+	if m != nil {
+		return m.NginxId
 	}
-	return len(dAtA) - i, nil
-}
-func (m *Command_AgentConfig) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return ""
 }
 
-func (m *Command_AgentConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.AgentConfig != nil {
-		{
-			size, err := m.AgentConfig.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x4a
-	}
-	return len(dAtA) - i, nil
-}
-func (m *Command_DataplaneStatus) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Represents a dataplane software health
+type DataplaneSoftwareHealth struct {
+	// Types that are valid to be assigned to Health:
+	//
+	//	*DataplaneSoftwareHealth_NginxHealth
+	//	*DataplaneSoftwareHealth_AppProtectWafHealth
+	Health               isDataplaneSoftwareHealth_Health `protobuf_oneof:"health"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
 }
 
-func (m *Command_DataplaneStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.DataplaneStatus != nil {
-		{
-			size, err := m.DataplaneStatus.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
+func (m *DataplaneSoftwareHealth) Reset()         { *m = DataplaneSoftwareHealth{} }
+func (m *DataplaneSoftwareHealth) String() string { return proto.CompactTextString(m) }
+func (*DataplaneSoftwareHealth) ProtoMessage()    {}
+func (*DataplaneSoftwareHealth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{12}
+}
+func (m *DataplaneSoftwareHealth) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DataplaneSoftwareHealth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DataplaneSoftwareHealth.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x5a
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *Command_EventReport) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DataplaneSoftwareHealth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataplaneSoftwareHealth.Merge(m, src)
+}
+func (m *DataplaneSoftwareHealth) XXX_Size() int {
+	return m.Size()
+}
+func (m *DataplaneSoftwareHealth) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataplaneSoftwareHealth.DiscardUnknown(m)
 }
 
-func (m *Command_EventReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.EventReport != nil {
-		{
-			size, err := m.EventReport.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x62
-	}
-	return len(dAtA) - i, nil
+var xxx_messageInfo_DataplaneSoftwareHealth proto.InternalMessageInfo
+
+type isDataplaneSoftwareHealth_Health interface {
+	isDataplaneSoftwareHealth_Health()
+	MarshalTo([]byte) (int, error)
+	Size() int
 }
-func (m *Command_DataplaneSoftwareDetails) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+type DataplaneSoftwareHealth_NginxHealth struct {
+	NginxHealth *NginxHealth `protobuf:"bytes,1,opt,name=nginx_health,json=nginxHealth,proto3,oneof" json:"nginx_health"`
+}
+type DataplaneSoftwareHealth_AppProtectWafHealth struct {
+	AppProtectWafHealth *AppProtectWAFHealth `protobuf:"bytes,2,opt,name=app_protect_waf_health,json=appProtectWafHealth,proto3,oneof" json:"app_protect_waf_health"`
 }
 
-func (m *Command_DataplaneSoftwareDetails) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.DataplaneSoftwareDetails != nil {
-		{
-			size, err := m.DataplaneSoftwareDetails.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x6a
+func (*DataplaneSoftwareHealth_NginxHealth) isDataplaneSoftwareHealth_Health()         {}
+func (*DataplaneSoftwareHealth_AppProtectWafHealth) isDataplaneSoftwareHealth_Health() {}
+
+func (m *DataplaneSoftwareHealth) GetHealth() isDataplaneSoftwareHealth_Health {
+	if m != nil {
+		return m.Health
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
-func (m *Command_DataplaneUpdate) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+func (m *DataplaneSoftwareHealth) GetNginxHealth() *NginxHealth {
+	if x, ok := m.GetHealth().(*DataplaneSoftwareHealth_NginxHealth); ok {
+		return x.NginxHealth
+	}
+	return nil
 }
 
-func (m *Command_DataplaneUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.DataplaneUpdate != nil {
-		{
-			size, err := m.DataplaneUpdate.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x72
+func (m *DataplaneSoftwareHealth) GetAppProtectWafHealth() *AppProtectWAFHealth {
+	if x, ok := m.GetHealth().(*DataplaneSoftwareHealth_AppProtectWafHealth); ok {
+		return x.AppProtectWafHealth
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
-func (m *CommandStatusResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*DataplaneSoftwareHealth) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*DataplaneSoftwareHealth_NginxHealth)(nil),
+		(*DataplaneSoftwareHealth_AppProtectWafHealth)(nil),
 	}
-	return dAtA[:n], nil
 }
 
-func (m *CommandStatusResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Represents a dataplane update
+type DataplaneUpdate struct {
+	// Host information
+	Host *HostInfo `protobuf:"bytes,1,opt,name=host,proto3" json:"host"`
+	// List of software details. This includes details about NGINX and any other software installed in the system that the agent is interested in.
+	DataplaneSoftwareDetails []*DataplaneSoftwareDetails `protobuf:"bytes,2,rep,name=dataplane_software_details,json=dataplaneSoftwareDetails,proto3" json:"dataplane_software_details"`
+	XXX_NoUnkeyedLiteral     struct{}                    `json:"-"`
+	XXX_unrecognized         []byte                      `json:"-"`
+	XXX_sizecache            int32                       `json:"-"`
 }
 
-func (m *CommandStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Error) > 0 {
-		i -= len(m.Error)
-		copy(dAtA[i:], m.Error)
-		i = encodeVarintCommand(dAtA, i, uint64(len(m.Error)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintCommand(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.ErrorCode != 0 {
-		i = encodeVarintCommand(dAtA, i, uint64(m.ErrorCode))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Status != 0 {
-		i = encodeVarintCommand(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+func (m *DataplaneUpdate) Reset()         { *m = DataplaneUpdate{} }
+func (m *DataplaneUpdate) String() string { return proto.CompactTextString(m) }
+func (*DataplaneUpdate) ProtoMessage()    {}
+func (*DataplaneUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{13}
+}
+func (m *DataplaneUpdate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DataplaneUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DataplaneUpdate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *DataplaneUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataplaneUpdate.Merge(m, src)
+}
+func (m *DataplaneUpdate) XXX_Size() int {
+	return m.Size()
+}
+func (m *DataplaneUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataplaneUpdate.DiscardUnknown(m)
 }
 
-func (m *DataplaneStatus) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DataplaneUpdate proto.InternalMessageInfo
+
+func (m *DataplaneUpdate) GetHost() *HostInfo {
+	if m != nil {
+		return m.Host
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *DataplaneStatus) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DataplaneUpdate) GetDataplaneSoftwareDetails() []*DataplaneSoftwareDetails {
+	if m != nil {
+		return m.DataplaneSoftwareDetails
+	}
+	return nil
 }
 
-func (m *DataplaneStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.AgentActivityStatus) > 0 {
-		for iNdEx := len(m.AgentActivityStatus) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.AgentActivityStatus[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintCommand(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x42
-		}
-	}
-	if len(m.DataplaneSoftwareHealths) > 0 {
-		for iNdEx := len(m.DataplaneSoftwareHealths) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.DataplaneSoftwareHealths[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintCommand(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x3a
-		}
-	}
-	if len(m.DataplaneSoftwareDetails) > 0 {
-		for iNdEx := len(m.DataplaneSoftwareDetails) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.DataplaneSoftwareDetails[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintCommand(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x32
-		}
-	}
-	if len(m.Healths) > 0 {
-		for iNdEx := len(m.Healths) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Healths[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintCommand(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x2a
-		}
-	}
-	if m.Host != nil {
-		{
-			size, err := m.Host.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Details) > 0 {
-		for iNdEx := len(m.Details) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Details[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintCommand(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
+// Represents a download request
+type DownloadRequest struct {
+	// Metadata information
+	Meta                 *Metadata `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *DownloadRequest) Reset()         { *m = DownloadRequest{} }
+func (m *DownloadRequest) String() string { return proto.CompactTextString(m) }
+func (*DownloadRequest) ProtoMessage()    {}
+func (*DownloadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{14}
+}
+func (m *DownloadRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DownloadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DownloadRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.SystemId) > 0 {
-		i -= len(m.SystemId)
-		copy(dAtA[i:], m.SystemId)
-		i = encodeVarintCommand(dAtA, i, uint64(len(m.SystemId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+}
+func (m *DownloadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownloadRequest.Merge(m, src)
+}
+func (m *DownloadRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DownloadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownloadRequest.DiscardUnknown(m)
 }
 
-func (m *AgentActivityStatus) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DownloadRequest proto.InternalMessageInfo
+
+func (m *DownloadRequest) GetMeta() *Metadata {
+	if m != nil {
+		return m.Meta
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AgentActivityStatus) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Represents a NGINX config response
+type NginxConfigResponse struct {
+	// Command status
+	Status *CommandStatusResponse `protobuf:"bytes,1,opt,name=status,proto3" json:"status"`
+	// NGINX config action
+	Action NginxConfigAction `protobuf:"varint,2,opt,name=action,proto3,enum=f5.nginx.agent.sdk.NginxConfigAction" json:"action"`
+	// NGINX config description
+	ConfigData           *ConfigDescriptor `protobuf:"bytes,3,opt,name=config_data,json=configData,proto3" json:"config_data"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *AgentActivityStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Status != nil {
-		{
-			size := m.Status.Size()
-			i -= size
-			if _, err := m.Status.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
+func (m *NginxConfigResponse) Reset()         { *m = NginxConfigResponse{} }
+func (m *NginxConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*NginxConfigResponse) ProtoMessage()    {}
+func (*NginxConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{15}
+}
+func (m *NginxConfigResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NginxConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NginxConfigResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *NginxConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NginxConfigResponse.Merge(m, src)
+}
+func (m *NginxConfigResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *NginxConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NginxConfigResponse.DiscardUnknown(m)
 }
 
-func (m *AgentActivityStatus_NginxConfigStatus) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_NginxConfigResponse proto.InternalMessageInfo
+
+func (m *NginxConfigResponse) GetStatus() *CommandStatusResponse {
+	if m != nil {
+		return m.Status
+	}
+	return nil
 }
 
-func (m *AgentActivityStatus_NginxConfigStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.NginxConfigStatus != nil {
-		{
-			size, err := m.NginxConfigStatus.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (m *NginxConfigResponse) GetAction() NginxConfigAction {
+	if m != nil {
+		return m.Action
 	}
-	return len(dAtA) - i, nil
+	return NginxConfigAction_UNKNOWN
 }
-func (m *NginxConfigStatus) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+
+func (m *NginxConfigResponse) GetConfigData() *ConfigDescriptor {
+	if m != nil {
+		return m.ConfigData
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *NginxConfigStatus) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Represents an upload status
+type UploadStatus struct {
+	// Metadata information
+	Meta *Metadata `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta"`
+	// Transfer status
+	Status UploadStatus_TransferStatus `protobuf:"varint,2,opt,name=status,proto3,enum=f5.nginx.agent.sdk.UploadStatus_TransferStatus" json:"status"`
+	// Provides an error message of why the upload failed
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NginxConfigStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.NginxId) > 0 {
-		i -= len(m.NginxId)
-		copy(dAtA[i:], m.NginxId)
-		i = encodeVarintCommand(dAtA, i, uint64(len(m.NginxId)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintCommand(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Status != 0 {
-		i = encodeVarintCommand(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.CorrelationId) > 0 {
-		i -= len(m.CorrelationId)
-		copy(dAtA[i:], m.CorrelationId)
-		i = encodeVarintCommand(dAtA, i, uint64(len(m.CorrelationId)))
-		i--
-		dAtA[i] = 0xa
+func (m *UploadStatus) Reset()         { *m = UploadStatus{} }
+func (m *UploadStatus) String() string { return proto.CompactTextString(m) }
+func (*UploadStatus) ProtoMessage()    {}
+func (*UploadStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{16}
+}
+func (m *UploadStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UploadStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UploadStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *UploadStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadStatus.Merge(m, src)
+}
+func (m *UploadStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *UploadStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadStatus.DiscardUnknown(m)
 }
 
-func (m *DataplaneSoftwareHealth) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_UploadStatus proto.InternalMessageInfo
+
+func (m *UploadStatus) GetMeta() *Metadata {
+	if m != nil {
+		return m.Meta
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *DataplaneSoftwareHealth) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *UploadStatus) GetStatus() UploadStatus_TransferStatus {
+	if m != nil {
+		return m.Status
+	}
+	return UploadStatus_UNKNOWN
 }
 
-func (m *DataplaneSoftwareHealth) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Health != nil {
-		{
-			size := m.Health.Size()
-			i -= size
-			if _, err := m.Health.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-		}
+func (m *UploadStatus) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *DataplaneSoftwareHealth_NginxHealth) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Represents a data chunck
+type DataChunk struct {
+	// Types that are valid to be assigned to Chunk:
+	//
+	//	*DataChunk_Header
+	//	*DataChunk_Data
+	Chunk                isDataChunk_Chunk `protobuf_oneof:"chunk"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *DataplaneSoftwareHealth_NginxHealth) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.NginxHealth != nil {
-		{
-			size, err := m.NginxHealth.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *DataChunk) Reset()         { *m = DataChunk{} }
+func (m *DataChunk) String() string { return proto.CompactTextString(m) }
+func (*DataChunk) ProtoMessage()    {}
+func (*DataChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{17}
 }
-func (m *DataplaneSoftwareHealth_AppProtectWafHealth) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DataChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *DataplaneSoftwareHealth_AppProtectWafHealth) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.AppProtectWafHealth != nil {
-		{
-			size, err := m.AppProtectWafHealth.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
+func (m *DataChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DataChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *DataplaneUpdate) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *DataChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataChunk.Merge(m, src)
+}
+func (m *DataChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *DataChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataChunk.DiscardUnknown(m)
 }
 
-func (m *DataplaneUpdate) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_DataChunk proto.InternalMessageInfo
+
+type isDataChunk_Chunk interface {
+	isDataChunk_Chunk()
+	MarshalTo([]byte) (int, error)
+	Size() int
 }
 
-func (m *DataplaneUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.DataplaneSoftwareDetails) > 0 {
-		for iNdEx := len(m.DataplaneSoftwareDetails) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.DataplaneSoftwareDetails[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintCommand(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if m.Host != nil {
-		{
-			size, err := m.Host.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+type DataChunk_Header struct {
+	Header *ChunkedResourceHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof" json:"header"`
+}
+type DataChunk_Data struct {
+	Data *ChunkedResourceChunk `protobuf:"bytes,2,opt,name=data,proto3,oneof" json:"data"`
+}
+
+func (*DataChunk_Header) isDataChunk_Chunk() {}
+func (*DataChunk_Data) isDataChunk_Chunk()   {}
+
+func (m *DataChunk) GetChunk() isDataChunk_Chunk {
+	if m != nil {
+		return m.Chunk
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *DownloadRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *DataChunk) GetHeader() *ChunkedResourceHeader {
+	if x, ok := m.GetChunk().(*DataChunk_Header); ok {
+		return x.Header
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *DownloadRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DataChunk) GetData() *ChunkedResourceChunk {
+	if x, ok := m.GetChunk().(*DataChunk_Data); ok {
+		return x.Data
+	}
+	return nil
 }
 
-func (m *DownloadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*DataChunk) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*DataChunk_Header)(nil),
+		(*DataChunk_Data)(nil),
 	}
-	if m.Meta != nil {
-		{
-			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
+}
+
+// Represents a chunked resource Header
+type ChunkedResourceHeader struct {
+	// Metadata information
+	Meta *Metadata `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta"`
+	// Number of chunks expected in the transfer
+	Chunks int32 `protobuf:"varint,2,opt,name=chunks,proto3" json:"chunks"`
+	// Chunk checksum
+	Checksum string `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum"`
+	// Chunk size
+	ChunkSize            int32    `protobuf:"varint,4,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChunkedResourceHeader) Reset()         { *m = ChunkedResourceHeader{} }
+func (m *ChunkedResourceHeader) String() string { return proto.CompactTextString(m) }
+func (*ChunkedResourceHeader) ProtoMessage()    {}
+func (*ChunkedResourceHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{18}
+}
+func (m *ChunkedResourceHeader) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChunkedResourceHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChunkedResourceHeader.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ChunkedResourceHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChunkedResourceHeader.Merge(m, src)
+}
+func (m *ChunkedResourceHeader) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChunkedResourceHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChunkedResourceHeader.DiscardUnknown(m)
 }
 
-func (m *NginxConfigResponse) Marshal() (dAtA []byte, err error) {
+var xxx_messageInfo_ChunkedResourceHeader proto.InternalMessageInfo
+
+func (m *ChunkedResourceHeader) GetMeta() *Metadata {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *ChunkedResourceHeader) GetChunks() int32 {
+	if m != nil {
+		return m.Chunks
+	}
+	return 0
+}
+
+func (m *ChunkedResourceHeader) GetChecksum() string {
+	if m != nil {
+		return m.Checksum
+	}
+	return ""
+}
+
+func (m *ChunkedResourceHeader) GetChunkSize() int32 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+// Represents a chunked resource chunk
+type ChunkedResourceChunk struct {
+	// Metadata information
+	Meta *Metadata `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta"`
+	// Chunk ID
+	ChunkId int32 `protobuf:"varint,2,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id"`
+	// Chunk data
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChunkedResourceChunk) Reset()         { *m = ChunkedResourceChunk{} }
+func (m *ChunkedResourceChunk) String() string { return proto.CompactTextString(m) }
+func (*ChunkedResourceChunk) ProtoMessage()    {}
+func (*ChunkedResourceChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213c0bb044472049, []int{19}
+}
+func (m *ChunkedResourceChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChunkedResourceChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChunkedResourceChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChunkedResourceChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChunkedResourceChunk.Merge(m, src)
+}
+func (m *ChunkedResourceChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChunkedResourceChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChunkedResourceChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChunkedResourceChunk proto.InternalMessageInfo
+
+func (m *ChunkedResourceChunk) GetMeta() *Metadata {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *ChunkedResourceChunk) GetChunkId() int32 {
+	if m != nil {
+		return m.ChunkId
+	}
+	return 0
+}
+
+func (m *ChunkedResourceChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("f5.nginx.agent.sdk.Command_CommandType", Command_CommandType_name, Command_CommandType_value)
+	proto.RegisterEnum("f5.nginx.agent.sdk.UpstreamDrainRequest_TargetState", UpstreamDrainRequest_TargetState_name, UpstreamDrainRequest_TargetState_value)
+	proto.RegisterEnum("f5.nginx.agent.sdk.TrafficMirrorRequest_Action", TrafficMirrorRequest_Action_name, TrafficMirrorRequest_Action_value)
+	proto.RegisterEnum("f5.nginx.agent.sdk.CommandStatusResponse_CommandStatus", CommandStatusResponse_CommandStatus_name, CommandStatusResponse_CommandStatus_value)
+	proto.RegisterEnum("f5.nginx.agent.sdk.CommandStatusResponse_CommandErrorCode", CommandStatusResponse_CommandErrorCode_name, CommandStatusResponse_CommandErrorCode_value)
+	proto.RegisterEnum("f5.nginx.agent.sdk.NginxConfigStatus_Status", NginxConfigStatus_Status_name, NginxConfigStatus_Status_value)
+	proto.RegisterEnum("f5.nginx.agent.sdk.UploadStatus_TransferStatus", UploadStatus_TransferStatus_name, UploadStatus_TransferStatus_value)
+	proto.RegisterType((*Command)(nil), "f5.nginx.agent.sdk.Command")
+	proto.RegisterType((*CertificateDeployRequest)(nil), "f5.nginx.agent.sdk.CertificateDeployRequest")
+	proto.RegisterType((*KeyvalSyncRequest)(nil), "f5.nginx.agent.sdk.KeyvalSyncRequest")
+	proto.RegisterType((*DebugCaptureRequest)(nil), "f5.nginx.agent.sdk.DebugCaptureRequest")
+	proto.RegisterType((*AgentUpdateRequest)(nil), "f5.nginx.agent.sdk.AgentUpdateRequest")
+	proto.RegisterType((*UpstreamDrainRequest)(nil), "f5.nginx.agent.sdk.UpstreamDrainRequest")
+	proto.RegisterType((*TrafficMirrorRequest)(nil), "f5.nginx.agent.sdk.TrafficMirrorRequest")
+	proto.RegisterType((*UpstreamSwitchRequest)(nil), "f5.nginx.agent.sdk.UpstreamSwitchRequest")
+	proto.RegisterType((*UpstreamSwitchRequest_Server)(nil), "f5.nginx.agent.sdk.UpstreamSwitchRequest.Server")
+	proto.RegisterType((*CommandStatusResponse)(nil), "f5.nginx.agent.sdk.CommandStatusResponse")
+	proto.RegisterType((*DataplaneStatus)(nil), "f5.nginx.agent.sdk.DataplaneStatus")
+	proto.RegisterType((*AgentActivityStatus)(nil), "f5.nginx.agent.sdk.AgentActivityStatus")
+	proto.RegisterType((*NginxConfigStatus)(nil), "f5.nginx.agent.sdk.NginxConfigStatus")
+	proto.RegisterType((*DataplaneSoftwareHealth)(nil), "f5.nginx.agent.sdk.DataplaneSoftwareHealth")
+	proto.RegisterType((*DataplaneUpdate)(nil), "f5.nginx.agent.sdk.DataplaneUpdate")
+	proto.RegisterType((*DownloadRequest)(nil), "f5.nginx.agent.sdk.DownloadRequest")
+	proto.RegisterType((*NginxConfigResponse)(nil), "f5.nginx.agent.sdk.NginxConfigResponse")
+	proto.RegisterType((*UploadStatus)(nil), "f5.nginx.agent.sdk.UploadStatus")
+	proto.RegisterType((*DataChunk)(nil), "f5.nginx.agent.sdk.DataChunk")
+	proto.RegisterType((*ChunkedResourceHeader)(nil), "f5.nginx.agent.sdk.ChunkedResourceHeader")
+	proto.RegisterType((*ChunkedResourceChunk)(nil), "f5.nginx.agent.sdk.ChunkedResourceChunk")
+}
+
+func init() { proto.RegisterFile("command.proto", fileDescriptor_213c0bb044472049) }
+
+var fileDescriptor_213c0bb044472049 = []byte{
+	// 2333 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0xcd, 0x6f, 0xdb, 0xc8,
+	0x15, 0x17, 0x65, 0xeb, 0xeb, 0xc9, 0x1f, 0xca, 0xd8, 0xde, 0xd5, 0xa6, 0xdb, 0x28, 0x60, 0x9b,
+	0xc6, 0xfb, 0x25, 0x67, 0xb3, 0x2d, 0x16, 0xcd, 0x9e, 0x2c, 0xcb, 0x59, 0x19, 0x89, 0x3f, 0x30,
+	0x8e, 0x13, 0x60, 0x8b, 0x42, 0x60, 0xc8, 0x91, 0x44, 0x58, 0x22, 0x59, 0x92, 0xb2, 0xe3, 0xa0,
+	0x87, 0xde, 0x8a, 0x16, 0xbd, 0xf4, 0x50, 0x2c, 0xda, 0xfe, 0x11, 0x45, 0x6f, 0xfd, 0x13, 0x7a,
+	0xdc, 0x73, 0x0f, 0xc2, 0x22, 0x47, 0xf5, 0xde, 0x1e, 0x7a, 0x29, 0xe6, 0xcd, 0x0c, 0x45, 0x59,
+	0xa4, 0xec, 0x34, 0x4d, 0x7b, 0xd1, 0xbc, 0x79, 0x7c, 0x5f, 0xf3, 0x66, 0xe6, 0x37, 0x33, 0x4f,
+	0xb0, 0x6c, 0xba, 0x83, 0x81, 0xe1, 0x58, 0x75, 0xcf, 0x77, 0x43, 0x97, 0x90, 0xce, 0x8f, 0xea,
+	0x4e, 0xd7, 0x76, 0x5e, 0xd4, 0x8d, 0x2e, 0x73, 0xc2, 0x7a, 0x60, 0x9d, 0xde, 0x2c, 0x0b, 0x12,
+	0x05, 0x6e, 0x2e, 0x71, 0x79, 0xd7, 0x99, 0xf4, 0x9c, 0x8e, 0xdd, 0x95, 0xbd, 0xf7, 0x2c, 0xaf,
+	0x1d, 0xb8, 0x9d, 0xf0, 0xdc, 0xf0, 0x59, 0xdb, 0x62, 0xa1, 0x61, 0xf7, 0x03, 0xf9, 0x89, 0xb0,
+	0x33, 0xe6, 0x84, 0xc1, 0x16, 0x36, 0x92, 0x07, 0x5d, 0xb7, 0xeb, 0x2a, 0xba, 0xe7, 0x06, 0x8a,
+	0x5f, 0x72, 0x0c, 0x4f, 0x92, 0x65, 0x11, 0x0b, 0x76, 0xf4, 0xbf, 0xdf, 0x80, 0xc2, 0x8e, 0x88,
+	0x96, 0x3c, 0x80, 0xc5, 0x01, 0x0b, 0x8d, 0xaa, 0x76, 0x5b, 0xdb, 0x2c, 0xdf, 0x7f, 0xbf, 0x3e,
+	0x1b, 0x76, 0x7d, 0x9f, 0x85, 0x86, 0x65, 0x84, 0x46, 0xa3, 0x38, 0x1e, 0xd5, 0x50, 0x9a, 0xe2,
+	0x2f, 0xd9, 0x85, 0xc5, 0xf0, 0xc2, 0x63, 0xd5, 0xec, 0x6d, 0x6d, 0x73, 0xe5, 0xfe, 0xdd, 0x24,
+	0x5d, 0xe9, 0x46, 0xb5, 0x4f, 0x2e, 0x3c, 0x26, 0xcc, 0x70, 0x45, 0x8a, 0xbf, 0xe4, 0x2b, 0x00,
+	0x73, 0x60, 0xb5, 0x83, 0xd0, 0x08, 0x87, 0x41, 0x75, 0x01, 0x03, 0xf9, 0x60, 0x8e, 0xb1, 0x63,
+	0x14, 0xa4, 0x2c, 0xf0, 0x5c, 0x27, 0x60, 0x8d, 0x95, 0xf1, 0xa8, 0x16, 0x33, 0xd0, 0xca, 0xd0,
+	0x92, 0x39, 0x90, 0x42, 0xe4, 0x29, 0x2c, 0xa1, 0x95, 0xb6, 0xc8, 0x6f, 0x75, 0x11, 0xad, 0xd7,
+	0x92, 0xac, 0x1f, 0xf0, 0xfe, 0x0e, 0x8a, 0x35, 0x2a, 0xe3, 0x51, 0x6d, 0x4a, 0xb1, 0x95, 0xa1,
+	0x22, 0x85, 0x42, 0x80, 0xbc, 0x80, 0x8d, 0xf8, 0xe7, 0xb6, 0x2f, 0xa3, 0xa9, 0xe6, 0xd0, 0xc1,
+	0xdd, 0x2b, 0x1c, 0x44, 0xc1, 0xbf, 0x37, 0x1e, 0xd5, 0x92, 0x2d, 0xb5, 0x32, 0x74, 0xcd, 0x99,
+	0xd5, 0xe0, 0x9e, 0xd1, 0x24, 0x97, 0x77, 0x98, 0x19, 0xb6, 0x7d, 0xf6, 0xb3, 0x21, 0x0b, 0xc2,
+	0x6a, 0x3e, 0xdd, 0xf3, 0x36, 0xa7, 0x76, 0x84, 0x3c, 0x15, 0xe2, 0xc2, 0x73, 0xa2, 0x25, 0xee,
+	0xd9, 0x98, 0xd5, 0x20, 0x3f, 0x87, 0x77, 0x2e, 0xcb, 0xcb, 0x41, 0x17, 0xd0, 0xf5, 0xe6, 0xd5,
+	0xae, 0xe5, 0xa8, 0x6f, 0x8e, 0x47, 0xb5, 0x14, 0x5b, 0xad, 0x0c, 0x5d, 0x37, 0x12, 0x74, 0x48,
+	0x08, 0xeb, 0x91, 0x86, 0xc8, 0x93, 0x18, 0x76, 0x11, 0x7d, 0xff, 0x60, 0x9e, 0x6f, 0x4c, 0x9f,
+	0x18, 0x75, 0x75, 0x3c, 0xaa, 0x25, 0xda, 0x69, 0x65, 0x28, 0x31, 0x66, 0xe4, 0xf9, 0xfa, 0x89,
+	0x4b, 0x57, 0x4b, 0xe9, 0xeb, 0x27, 0xe6, 0x4d, 0xac, 0x9f, 0xb8, 0x22, 0x5f, 0x3f, 0x31, 0xf3,
+	0xa4, 0x03, 0x15, 0xbe, 0xa5, 0xbc, 0xbe, 0xe1, 0x30, 0xb5, 0xf2, 0xcb, 0x68, 0xfb, 0x7b, 0x49,
+	0xb6, 0x9b, 0x4a, 0x56, 0x2c, 0xeb, 0xc6, 0xfa, 0x78, 0x54, 0x9b, 0x31, 0xd0, 0xca, 0xd0, 0x55,
+	0x6b, 0x5a, 0x90, 0xfc, 0x14, 0x96, 0x10, 0x29, 0xda, 0x3e, 0xf3, 0x5c, 0x3f, 0xac, 0x2e, 0xa5,
+	0x67, 0x4b, 0x00, 0x4b, 0x7d, 0x97, 0x37, 0x14, 0xa5, 0xc5, 0x30, 0xe2, 0xfa, 0x7c, 0x18, 0x6c,
+	0x22, 0x40, 0x7e, 0xa3, 0xc1, 0xcd, 0x58, 0x18, 0x97, 0x20, 0xab, 0xba, 0x8c, 0xde, 0x3e, 0x9e,
+	0x3f, 0x22, 0xa9, 0xd4, 0x14, 0x3a, 0x8d, 0x5b, 0xe3, 0x51, 0x6d, 0x8e, 0xcd, 0x56, 0x86, 0x56,
+	0xad, 0x14, 0xdd, 0xe9, 0xac, 0x0e, 0x3d, 0xcb, 0x08, 0x59, 0x75, 0xe5, 0x1a, 0x59, 0x3d, 0x41,
+	0xd1, 0xcb, 0x59, 0x15, 0x06, 0xa6, 0xb2, 0x2a, 0x04, 0x71, 0xd8, 0x26, 0xf3, 0x43, 0xbb, 0x63,
+	0x9b, 0x46, 0xc8, 0x63, 0xf3, 0xfa, 0xee, 0x45, 0xb4, 0x24, 0x57, 0xd3, 0x87, 0xbd, 0x33, 0xd1,
+	0x6a, 0xa2, 0x92, 0x5a, 0x98, 0x38, 0xec, 0x74, 0x9b, 0x7c, 0xd8, 0x66, 0x8a, 0x2e, 0x87, 0x04,
+	0x8b, 0x3d, 0x1f, 0x76, 0xdb, 0xa6, 0xe1, 0x85, 0x43, 0x9f, 0x45, 0x81, 0x54, 0xd2, 0x21, 0xa1,
+	0xc9, 0x15, 0x76, 0x84, 0xfc, 0x14, 0x24, 0x24, 0x5a, 0xe2, 0x90, 0x60, 0xcd, 0x6a, 0x10, 0x0f,
+	0xd6, 0x4e, 0xd9, 0xc5, 0x99, 0xd1, 0x6f, 0x07, 0x17, 0x8e, 0x19, 0xf9, 0xbd, 0x81, 0x7e, 0xef,
+	0x24, 0xf9, 0x7d, 0x84, 0xe2, 0xc7, 0x17, 0x8e, 0xa9, 0xbc, 0xbe, 0x3b, 0x1e, 0xd5, 0x92, 0xac,
+	0xb4, 0x32, 0xf4, 0xc6, 0xe9, 0x65, 0xe9, 0x09, 0x0c, 0x88, 0xd9, 0x89, 0x5c, 0x92, 0x2b, 0x60,
+	0x40, 0xcc, 0x5c, 0x02, 0x0c, 0x4c, 0xdb, 0x89, 0x60, 0x60, 0x4a, 0x9e, 0x43, 0xdf, 0xd0, 0x0b,
+	0x42, 0x9f, 0x19, 0x83, 0xb6, 0xe5, 0x1b, 0xb6, 0x13, 0xf9, 0x5d, 0x4b, 0x87, 0xbe, 0x13, 0xa9,
+	0xd1, 0xe4, 0x0a, 0xca, 0x33, 0x42, 0x5f, 0xb2, 0x2d, 0x0e, 0x7d, 0xc3, 0x04, 0x1d, 0xee, 0x3d,
+	0xf4, 0x8d, 0x4e, 0xc7, 0x36, 0xdb, 0x03, 0xdb, 0xf7, 0x5d, 0x3f, 0xf2, 0xbe, 0x9e, 0xee, 0xfd,
+	0x89, 0xd0, 0xd8, 0x47, 0x85, 0x29, 0xef, 0xc9, 0xb6, 0xb8, 0xf7, 0x30, 0x41, 0x87, 0xfc, 0x42,
+	0x83, 0x77, 0xa3, 0x80, 0x83, 0x73, 0x3b, 0x34, 0x7b, 0x91, 0xff, 0x8d, 0xf4, 0xc3, 0x5a, 0x8d,
+	0xfe, 0x18, 0x35, 0x54, 0x00, 0xdf, 0x19, 0x8f, 0x6a, 0x69, 0xd6, 0x5a, 0x19, 0xba, 0x31, 0x4c,
+	0xd2, 0xd2, 0x3f, 0x83, 0x72, 0xec, 0x02, 0x41, 0x00, 0xf2, 0x07, 0x87, 0x74, 0x7f, 0xfb, 0x71,
+	0x25, 0x43, 0x96, 0xa0, 0xd8, 0x3c, 0x7c, 0x76, 0xf0, 0xf8, 0x70, 0xbb, 0x59, 0xd1, 0xf8, 0x97,
+	0x93, 0x23, 0xa4, 0xb3, 0x8d, 0x3c, 0x2c, 0xf2, 0x7d, 0xab, 0xff, 0x39, 0x0b, 0xd5, 0xb4, 0x6d,
+	0x47, 0x3e, 0x84, 0x52, 0x70, 0x11, 0x84, 0x6c, 0xd0, 0xb6, 0x2d, 0xbc, 0x03, 0x95, 0x1a, 0xcb,
+	0xe3, 0x51, 0x6d, 0xc2, 0xa4, 0x45, 0x41, 0xee, 0x59, 0xe4, 0x2e, 0x14, 0xc5, 0x49, 0x6d, 0x5b,
+	0x78, 0xe5, 0x29, 0x35, 0x96, 0xc6, 0xa3, 0x5a, 0xc4, 0xa3, 0x05, 0xa4, 0xf6, 0x2c, 0xf2, 0x3e,
+	0x2c, 0x7a, 0x46, 0xd8, 0xc3, 0xab, 0x4c, 0x49, 0x5c, 0x77, 0x78, 0x9f, 0xe2, 0x2f, 0xff, 0xea,
+	0x18, 0x03, 0x86, 0x57, 0x11, 0xf9, 0x95, 0xf7, 0x29, 0xfe, 0x92, 0x4f, 0xa1, 0x1c, 0xdb, 0xe7,
+	0x78, 0x9d, 0x58, 0x6a, 0xac, 0x8e, 0x47, 0xb5, 0x38, 0x9b, 0xc6, 0x3b, 0xe4, 0x1e, 0x94, 0x3d,
+	0xdf, 0x3e, 0xe3, 0xab, 0xf8, 0x94, 0x5d, 0xe0, 0x3d, 0x40, 0xaa, 0xc4, 0xd8, 0x14, 0x64, 0xe7,
+	0x11, 0xbb, 0x20, 0x35, 0xc8, 0x99, 0x3d, 0xc3, 0x76, 0xf0, 0xe0, 0x5e, 0x6a, 0x94, 0xc6, 0xa3,
+	0x9a, 0x60, 0x50, 0xd1, 0xe8, 0xff, 0xd2, 0xe0, 0xc6, 0xcc, 0x4e, 0x7d, 0x6b, 0xc9, 0x7a, 0xe9,
+	0x3a, 0x2c, 0x9e, 0x2c, 0xde, 0xa7, 0xf8, 0x4b, 0xee, 0x40, 0x81, 0x39, 0xa1, 0x6f, 0xb3, 0x00,
+	0xf3, 0xb5, 0xd4, 0x28, 0x8f, 0x47, 0x35, 0xc5, 0xa2, 0x8a, 0x20, 0x9b, 0x50, 0x0c, 0xfd, 0xa1,
+	0x13, 0xa5, 0xac, 0x28, 0xbc, 0x29, 0x1e, 0x8d, 0x28, 0xa2, 0x43, 0x9e, 0xbd, 0xc0, 0xa3, 0x30,
+	0x8f, 0x72, 0x30, 0x1e, 0xd5, 0x24, 0x87, 0xca, 0x56, 0xdf, 0x86, 0xb5, 0x04, 0x78, 0xe4, 0xc3,
+	0xef, 0xbb, 0xdd, 0x76, 0xdf, 0x76, 0x58, 0x80, 0xc3, 0xcf, 0x89, 0xe1, 0x47, 0x4c, 0x5a, 0xec,
+	0xbb, 0xdd, 0xc7, 0x9c, 0xd2, 0xbf, 0xce, 0x02, 0x99, 0xc5, 0x9d, 0xd7, 0xca, 0xe0, 0x1d, 0x28,
+	0x9c, 0x31, 0x3f, 0xb0, 0x5d, 0x47, 0x26, 0x10, 0x87, 0x2e, 0x59, 0x54, 0x11, 0x38, 0xfb, 0x86,
+	0x79, 0x6a, 0x74, 0x59, 0x7b, 0xe8, 0xf7, 0x65, 0x1a, 0xc5, 0xec, 0x4f, 0xd8, 0x14, 0x64, 0xe7,
+	0xc4, 0xef, 0x73, 0xc3, 0xb2, 0x17, 0xcf, 0xa9, 0x64, 0x51, 0x45, 0xf0, 0x9c, 0x9a, 0x3d, 0x66,
+	0x9e, 0x06, 0xc3, 0x01, 0xe6, 0x54, 0xce, 0xa0, 0xe2, 0xd1, 0x88, 0x22, 0x1f, 0x41, 0x29, 0xb0,
+	0xbb, 0x8e, 0xc1, 0x93, 0x25, 0x97, 0x9f, 0x18, 0x95, 0x62, 0xd2, 0x09, 0xa9, 0x7f, 0x9b, 0x85,
+	0xf5, 0x24, 0x64, 0x7c, 0x3b, 0xab, 0x6b, 0x13, 0x8a, 0x0a, 0x52, 0x64, 0x6a, 0x50, 0x50, 0xf1,
+	0x68, 0x44, 0xf1, 0x85, 0x11, 0x30, 0xff, 0x8c, 0xf9, 0x72, 0x63, 0xe2, 0xc2, 0x10, 0x1c, 0x2a,
+	0x5b, 0x72, 0x02, 0x39, 0x7e, 0xd5, 0x12, 0x6b, 0x6c, 0xe5, 0xfe, 0x0f, 0xaf, 0x8b, 0xfa, 0xf5,
+	0x27, 0x86, 0xdf, 0x65, 0x21, 0xbf, 0x93, 0x31, 0xb1, 0xdb, 0xd0, 0x0c, 0x15, 0x0d, 0xba, 0x16,
+	0x21, 0xc6, 0xd6, 0xa4, 0x0c, 0x50, 0xb6, 0xfa, 0x87, 0x50, 0x8e, 0x19, 0x21, 0x79, 0xc8, 0x9e,
+	0x1c, 0x55, 0x32, 0xa4, 0x04, 0xb9, 0x26, 0xdd, 0xde, 0x3b, 0xa8, 0x68, 0xa4, 0x08, 0x8b, 0x1c,
+	0x09, 0x2b, 0x59, 0xfd, 0x2f, 0x59, 0x58, 0x4f, 0x82, 0xff, 0xb7, 0x93, 0xe2, 0x7b, 0x50, 0x16,
+	0xe9, 0x69, 0x23, 0xac, 0xc5, 0x16, 0x60, 0x8c, 0x4d, 0x41, 0x74, 0x0e, 0x38, 0xc6, 0xdd, 0x81,
+	0xc2, 0x73, 0xc3, 0x3c, 0x65, 0x8e, 0x25, 0x73, 0x8d, 0x0b, 0x50, 0xb2, 0xa8, 0x22, 0xc8, 0x31,
+	0xe4, 0x0d, 0x33, 0xe4, 0xeb, 0x5f, 0xa4, 0x7b, 0xeb, 0xba, 0xc7, 0x5c, 0x7d, 0x1b, 0xd5, 0x44,
+	0x1e, 0x85, 0x09, 0x2a, 0x5b, 0xfd, 0xbb, 0x90, 0x17, 0x5f, 0x49, 0x01, 0x16, 0x8e, 0x77, 0x9f,
+	0x54, 0x32, 0xfc, 0xd0, 0xa0, 0xbb, 0xfb, 0x87, 0x4f, 0x77, 0x2b, 0x9a, 0xfe, 0xf5, 0x02, 0x6c,
+	0x24, 0x9e, 0x5c, 0xff, 0xef, 0xe5, 0xf9, 0x0c, 0x0a, 0x22, 0x83, 0x1c, 0x08, 0x17, 0x36, 0xcb,
+	0xf7, 0xef, 0x5d, 0xfb, 0xd0, 0xad, 0x1f, 0xa3, 0xa2, 0xc8, 0xb2, 0x34, 0x42, 0x15, 0x41, 0x3e,
+	0x01, 0xf0, 0x7c, 0xf7, 0x39, 0x6b, 0xe3, 0x91, 0x25, 0x36, 0x3a, 0x3e, 0xa9, 0x27, 0x5c, 0x5a,
+	0x42, 0xfa, 0xc8, 0x08, 0x7b, 0x37, 0xcf, 0x21, 0x2f, 0xcc, 0xf1, 0x59, 0x34, 0x2c, 0xcb, 0x67,
+	0x41, 0x20, 0xd3, 0x81, 0xf6, 0x25, 0x8b, 0x2a, 0x82, 0x2f, 0xee, 0x73, 0x66, 0x77, 0x7b, 0x21,
+	0x66, 0x22, 0x27, 0x26, 0x45, 0x70, 0xa8, 0x6c, 0xb9, 0x0c, 0x9f, 0xf4, 0xa1, 0x87, 0x49, 0x90,
+	0x1b, 0x40, 0x70, 0xa8, 0x6c, 0xf5, 0xdf, 0x2d, 0xc0, 0x46, 0x62, 0x01, 0x80, 0xfc, 0x84, 0x6f,
+	0x1f, 0x7c, 0x41, 0x69, 0xb8, 0x4e, 0x3e, 0xbf, 0x76, 0xed, 0x60, 0x9a, 0xab, 0xf6, 0x1d, 0x4a,
+	0xc8, 0x96, 0xd8, 0x00, 0x0c, 0xaf, 0x49, 0xa6, 0x6b, 0xa9, 0x4a, 0xc7, 0x83, 0xd7, 0x76, 0xb0,
+	0xcb, 0x4d, 0xec, 0xb8, 0x96, 0xac, 0x56, 0x4c, 0x2c, 0xd2, 0x12, 0x53, 0x9f, 0x78, 0x42, 0x07,
+	0x2c, 0x08, 0x38, 0x2e, 0x2f, 0x4c, 0x12, 0x2a, 0x59, 0x54, 0x11, 0xfc, 0xf0, 0x46, 0x1d, 0xb9,
+	0x77, 0x10, 0x4e, 0x90, 0x41, 0x45, 0xa3, 0x7f, 0x01, 0xcb, 0x53, 0xc1, 0x90, 0x55, 0x28, 0xef,
+	0xec, 0x37, 0xdb, 0x27, 0x07, 0x8f, 0x0e, 0x38, 0x40, 0xe0, 0x8a, 0xe7, 0x8c, 0xc3, 0x47, 0x15,
+	0x8d, 0x2c, 0x43, 0x89, 0xd3, 0xbb, 0x94, 0x1e, 0xd2, 0x4a, 0x56, 0xdf, 0x82, 0xca, 0xe5, 0x98,
+	0xb9, 0xf8, 0x2e, 0xa5, 0x5c, 0x3c, 0xc3, 0x6d, 0x71, 0x5a, 0xd9, 0xd2, 0xf4, 0x3f, 0xe6, 0x60,
+	0xf5, 0xd2, 0xf3, 0xf4, 0xb5, 0xf6, 0xca, 0x97, 0x50, 0x50, 0xcf, 0xc5, 0x2c, 0x2e, 0xec, 0xdb,
+	0xa9, 0xb5, 0x13, 0xf5, 0x44, 0xc4, 0xbc, 0x48, 0x25, 0xaa, 0x08, 0xf2, 0x00, 0x16, 0x7b, 0x6e,
+	0x10, 0xca, 0x02, 0x52, 0x62, 0x25, 0xab, 0xe5, 0x06, 0xe1, 0x9e, 0xd3, 0x71, 0xc5, 0x35, 0x83,
+	0x4b, 0x53, 0xfc, 0x25, 0x0f, 0xa1, 0xd0, 0x63, 0x46, 0x3f, 0xec, 0x05, 0xd5, 0x1c, 0x06, 0x91,
+	0x5e, 0x21, 0x6a, 0xa1, 0x9c, 0x88, 0x41, 0xea, 0x50, 0x45, 0x90, 0x5f, 0xcf, 0x7f, 0x0f, 0xe7,
+	0xd1, 0xf6, 0x7f, 0xf5, 0x3d, 0x3c, 0xe7, 0x35, 0xfc, 0xab, 0xe4, 0x60, 0xd4, 0x40, 0x0b, 0x18,
+	0xcc, 0x47, 0xd7, 0x0a, 0x46, 0x0e, 0x3a, 0x2d, 0x16, 0x95, 0x87, 0xd9, 0x58, 0x5a, 0x32, 0x31,
+	0x67, 0xaa, 0x6a, 0xc5, 0x61, 0xf8, 0xcc, 0x0e, 0x2f, 0x54, 0xd1, 0xa3, 0x88, 0x51, 0xa4, 0x57,
+	0xad, 0xb6, 0xa5, 0xbc, 0xdc, 0xa2, 0xb1, 0xaa, 0xd5, 0x25, 0x4b, 0xb2, 0x66, 0x35, 0x2d, 0xaf,
+	0xff, 0x56, 0x83, 0xb5, 0x04, 0x3b, 0xfc, 0xe1, 0x3a, 0x55, 0x75, 0x8b, 0x01, 0x48, 0xca, 0xc3,
+	0x35, 0x56, 0xbd, 0x93, 0xb1, 0xe0, 0xc3, 0x35, 0xc1, 0x0a, 0x7f, 0xb8, 0x3a, 0x33, 0xd2, 0x45,
+	0xc8, 0xcb, 0x98, 0xfe, 0x90, 0x85, 0x1b, 0x33, 0xd6, 0xc8, 0x8f, 0x61, 0xc5, 0x74, 0x7d, 0x9f,
+	0xf5, 0x0d, 0x7e, 0x3a, 0x4d, 0x36, 0x0e, 0x19, 0x8f, 0x6a, 0x97, 0xbe, 0xd0, 0xe5, 0x58, 0x7f,
+	0xcf, 0x22, 0x47, 0x11, 0x00, 0x0a, 0x7c, 0xfa, 0xf8, 0x5a, 0xf1, 0xd7, 0xe7, 0xa0, 0xde, 0x35,
+	0xa1, 0x28, 0x7e, 0xce, 0x2d, 0xce, 0x39, 0xe7, 0xf4, 0x4d, 0x35, 0x78, 0x52, 0x86, 0xc2, 0xd1,
+	0xee, 0x41, 0x73, 0xef, 0xe0, 0xcb, 0x4a, 0x86, 0xdf, 0x62, 0x10, 0x83, 0x4a, 0x90, 0x53, 0xf8,
+	0xf3, 0x4f, 0x0d, 0xde, 0x4d, 0x59, 0x7e, 0x93, 0x62, 0xae, 0x58, 0x6f, 0x72, 0xb6, 0xae, 0xdc,
+	0xaa, 0xb1, 0x62, 0xae, 0x50, 0x8c, 0x8a, 0xb9, 0xd2, 0xee, 0x4b, 0x78, 0xc7, 0xf0, 0xbc, 0xb6,
+	0xe7, 0xbb, 0x21, 0x33, 0xc3, 0xf6, 0xb9, 0xd1, 0x51, 0x1e, 0xb2, 0x73, 0x6a, 0xaa, 0x9e, 0x77,
+	0x24, 0x14, 0x9e, 0x6d, 0x3f, 0x94, 0x9e, 0x44, 0x5d, 0x33, 0xd1, 0x14, 0x16, 0x55, 0x27, 0x2a,
+	0x46, 0x47, 0xaa, 0x14, 0x21, 0x2f, 0x04, 0xf4, 0xbf, 0x69, 0x31, 0x20, 0x95, 0x85, 0x26, 0x85,
+	0x69, 0xda, 0x7f, 0x80, 0x69, 0x57, 0x60, 0x51, 0xf6, 0x7f, 0x89, 0x45, 0xfa, 0x3e, 0xac, 0x36,
+	0xdd, 0x73, 0xa7, 0xef, 0x1a, 0x96, 0xba, 0x50, 0xbd, 0xc1, 0x3f, 0x0f, 0xfa, 0x2f, 0xb3, 0xb0,
+	0x96, 0x50, 0x4e, 0x27, 0xfb, 0x53, 0x57, 0x81, 0xd7, 0xfa, 0x1b, 0x21, 0x69, 0x1b, 0xec, 0x45,
+	0x37, 0x50, 0xb1, 0xb1, 0xae, 0x02, 0x86, 0xf4, 0x7b, 0x27, 0x79, 0x0a, 0x65, 0x09, 0x12, 0x7c,
+	0x40, 0xf2, 0x90, 0xfa, 0x7e, 0x72, 0x78, 0x5c, 0xac, 0xc9, 0x02, 0xd3, 0xb7, 0xbd, 0xd0, 0xf5,
+	0xe5, 0xeb, 0x7f, 0xa2, 0x4c, 0x41, 0x74, 0xf8, 0x34, 0xe9, 0xff, 0xd0, 0x60, 0xe9, 0xc4, 0xe3,
+	0x79, 0x95, 0x1b, 0xec, 0x4d, 0xfe, 0xd0, 0x39, 0xbe, 0x04, 0x24, 0x5b, 0xc9, 0x77, 0xcc, 0x89,
+	0x37, 0x7e, 0xfd, 0x76, 0x82, 0x0e, 0xf3, 0xe7, 0x60, 0x89, 0x0e, 0x79, 0x9f, 0x19, 0x81, 0xeb,
+	0x48, 0x28, 0x41, 0x19, 0xc1, 0xa1, 0xb2, 0xd5, 0x3f, 0x85, 0x95, 0x69, 0x4b, 0x1c, 0x27, 0x26,
+	0xf7, 0x15, 0x85, 0x13, 0x00, 0xf9, 0x87, 0xdb, 0x7b, 0x8f, 0x77, 0x9b, 0x95, 0xac, 0xfe, 0x27,
+	0x0d, 0x4a, 0x3c, 0x03, 0x3b, 0xbd, 0xa1, 0x73, 0x4a, 0x0e, 0x71, 0x1b, 0x59, 0xcc, 0x9f, 0x3b,
+	0xf1, 0x5c, 0x94, 0x59, 0x94, 0x05, 0xee, 0xd0, 0x37, 0x39, 0xaa, 0x58, 0xcc, 0x17, 0xf1, 0x08,
+	0xe5, 0x56, 0x86, 0x4a, 0x8a, 0xb4, 0x44, 0xf5, 0x48, 0x22, 0xc0, 0xe6, 0x35, 0xcc, 0x61, 0x57,
+	0xa4, 0x94, 0x6b, 0xb6, 0x32, 0x14, 0xdb, 0x46, 0x01, 0x72, 0x26, 0xff, 0xa4, 0x7f, 0xa3, 0xc1,
+	0x46, 0x62, 0x08, 0x6f, 0x34, 0x67, 0x3a, 0xe4, 0xd1, 0x7c, 0x10, 0xbf, 0x5f, 0x0b, 0x0e, 0x95,
+	0xed, 0xd4, 0x53, 0x7e, 0x61, 0xee, 0x53, 0xfe, 0x13, 0x00, 0xd4, 0x69, 0x07, 0xf6, 0x4b, 0x51,
+	0x1e, 0xc8, 0xc9, 0x3f, 0xd8, 0x22, 0x2e, 0x2d, 0x21, 0x7d, 0x6c, 0xbf, 0x64, 0xfa, 0xef, 0x35,
+	0x58, 0x4f, 0x4a, 0xc3, 0x1b, 0x8d, 0xe8, 0x2e, 0x8f, 0x96, 0x7b, 0x93, 0xaf, 0xa7, 0x9c, 0x8a,
+	0x56, 0xf0, 0x68, 0x01, 0x29, 0x51, 0x3a, 0x8a, 0x36, 0xd3, 0xd2, 0x24, 0xf3, 0x32, 0xef, 0x9f,
+	0xff, 0xf5, 0xd5, 0x2d, 0xed, 0x9b, 0x57, 0xb7, 0xb4, 0x6f, 0x5f, 0xdd, 0xd2, 0xbe, 0xfa, 0xa0,
+	0x6b, 0x87, 0xbd, 0xe1, 0xf3, 0xba, 0xe9, 0x0e, 0xb6, 0x30, 0x92, 0x2d, 0x8c, 0x64, 0x2b, 0xb0,
+	0x4e, 0xb7, 0xce, 0xee, 0x6f, 0xe1, 0x1f, 0xa3, 0x5f, 0xe0, 0xef, 0xf3, 0x3c, 0x36, 0x9f, 0xfd,
+	0x3b, 0x00, 0x00, 0xff, 0xff, 0xdc, 0xd8, 0x6e, 0xac, 0xd2, 0x1d, 0x00, 0x00,
+}
+
+func (m *Command) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2164,12 +2342,12 @@ func (m *NginxConfigResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NginxConfigResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *Command) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NginxConfigResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Command) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -2178,26 +2356,23 @@ func (m *NginxConfigResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.ConfigData != nil {
+	if m.Data != nil {
 		{
-			size, err := m.ConfigData.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
+			size := m.Data.Size()
+			i -= size
+			if _, err := m.Data.MarshalTo(dAtA[i:]); err != nil {
 				return 0, err
 			}
-			i -= size
-			i = encodeVarintCommand(dAtA, i, uint64(size))
 		}
-		i--
-		dAtA[i] = 0x1a
 	}
-	if m.Action != 0 {
-		i = encodeVarintCommand(dAtA, i, uint64(m.Action))
+	if m.Type != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.Type))
 		i--
 		dAtA[i] = 0x10
 	}
-	if m.Status != nil {
+	if m.Meta != nil {
 		{
-			size, err := m.Status.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -2210,45 +2385,16 @@ func (m *NginxConfigResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *UploadStatus) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *UploadStatus) MarshalTo(dAtA []byte) (int, error) {
+func (m *Command_CmdStatus) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *UploadStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Command_CmdStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Reason) > 0 {
-		i -= len(m.Reason)
-		copy(dAtA[i:], m.Reason)
-		i = encodeVarintCommand(dAtA, i, uint64(len(m.Reason)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Status != 0 {
-		i = encodeVarintCommand(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Meta != nil {
+	if m.CmdStatus != nil {
 		{
-			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.CmdStatus.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -2256,57 +2402,62 @@ func (m *UploadStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintCommand(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x1a
 	}
 	return len(dAtA) - i, nil
 }
-
-func (m *DataChunk) Marshal() (dAtA []byte, err error) {
+func (m *Command_NginxConfig) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DataChunk) MarshalTo(dAtA []byte) (int, error) {
+func (m *Command_NginxConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.NginxConfig != nil {
+		{
+			size, err := m.NginxConfig.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Command_NginxConfigResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DataChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Command_NginxConfigResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Chunk != nil {
+	if m.NginxConfigResponse != nil {
 		{
-			size := m.Chunk.Size()
-			i -= size
-			if _, err := m.Chunk.MarshalTo(dAtA[i:]); err != nil {
+			size, err := m.NginxConfigResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
 				return 0, err
 			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x2a
 	}
 	return len(dAtA) - i, nil
 }
-
-func (m *DataChunk_Header) MarshalTo(dAtA []byte) (int, error) {
+func (m *Command_AgentConnectRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DataChunk_Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Command_AgentConnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.Header != nil {
+	if m.AgentConnectRequest != nil {
 		{
-			size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.AgentConnectRequest.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -2314,20 +2465,20 @@ func (m *DataChunk_Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintCommand(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x32
 	}
 	return len(dAtA) - i, nil
 }
-func (m *DataChunk_Data) MarshalTo(dAtA []byte) (int, error) {
+func (m *Command_AgentConnectResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DataChunk_Data) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Command_AgentConnectResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.Data != nil {
+	if m.AgentConnectResponse != nil {
 		{
-			size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.AgentConnectResponse.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -2335,54 +2486,104 @@ func (m *DataChunk_Data) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintCommand(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x3a
 	}
 	return len(dAtA) - i, nil
 }
-func (m *ChunkedResourceHeader) Marshal() (dAtA []byte, err error) {
+func (m *Command_AgentConfigRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ChunkedResourceHeader) MarshalTo(dAtA []byte) (int, error) {
+func (m *Command_AgentConfigRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.AgentConfigRequest != nil {
+		{
+			size, err := m.AgentConfigRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Command_AgentConfig) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ChunkedResourceHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Command_AgentConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.ChunkSize != 0 {
-		i = encodeVarintCommand(dAtA, i, uint64(m.ChunkSize))
+	if m.AgentConfig != nil {
+		{
+			size, err := m.AgentConfig.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
 		i--
-		dAtA[i] = 0x20
+		dAtA[i] = 0x4a
 	}
-	if len(m.Checksum) > 0 {
-		i -= len(m.Checksum)
-		copy(dAtA[i:], m.Checksum)
-		i = encodeVarintCommand(dAtA, i, uint64(len(m.Checksum)))
+	return len(dAtA) - i, nil
+}
+func (m *Command_DataplaneStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Command_DataplaneStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DataplaneStatus != nil {
+		{
+			size, err := m.DataplaneStatus.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x5a
 	}
-	if m.Chunks != 0 {
-		i = encodeVarintCommand(dAtA, i, uint64(m.Chunks))
+	return len(dAtA) - i, nil
+}
+func (m *Command_EventReport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Command_EventReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.EventReport != nil {
+		{
+			size, err := m.EventReport.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0x62
 	}
-	if m.Meta != nil {
+	return len(dAtA) - i, nil
+}
+func (m *Command_DataplaneSoftwareDetails) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Command_DataplaneSoftwareDetails) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DataplaneSoftwareDetails != nil {
 		{
-			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.DataplaneSoftwareDetails.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -2390,50 +2591,85 @@ func (m *ChunkedResourceHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintCommand(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x6a
 	}
 	return len(dAtA) - i, nil
 }
-
-func (m *ChunkedResourceChunk) Marshal() (dAtA []byte, err error) {
+func (m *Command_DataplaneUpdate) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ChunkedResourceChunk) MarshalTo(dAtA []byte) (int, error) {
+func (m *Command_DataplaneUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DataplaneUpdate != nil {
+		{
+			size, err := m.DataplaneUpdate.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Command_CertificateDeployRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ChunkedResourceChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Command_CertificateDeployRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintCommand(dAtA, i, uint64(len(m.Data)))
+	if m.CertificateDeployRequest != nil {
+		{
+			size, err := m.CertificateDeployRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x7a
 	}
-	if m.ChunkId != 0 {
-		i = encodeVarintCommand(dAtA, i, uint64(m.ChunkId))
+	return len(dAtA) - i, nil
+}
+func (m *Command_DebugCaptureRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Command_DebugCaptureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DebugCaptureRequest != nil {
+		{
+			size, err := m.DebugCaptureRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
 	}
-	if m.Meta != nil {
+	return len(dAtA) - i, nil
+}
+func (m *Command_KeyvalSyncRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Command_KeyvalSyncRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.KeyvalSyncRequest != nil {
 		{
-			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.KeyvalSyncRequest.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -2441,524 +2677,3741 @@ func (m *ChunkedResourceChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintCommand(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Command_AgentUpdateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
 
-func encodeVarintCommand(dAtA []byte, offset int, v uint64) int {
-	offset -= sovCommand(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *Command_AgentUpdateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.AgentUpdateRequest != nil {
+		{
+			size, err := m.AgentUpdateRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return len(dAtA) - i, nil
 }
-func (m *Command) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Meta != nil {
-		l = m.Meta.Size()
-		n += 1 + l + sovCommand(uint64(l))
+func (m *Command_UpstreamDrainRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Command_UpstreamDrainRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.UpstreamDrainRequest != nil {
+		{
+			size, err := m.UpstreamDrainRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
 	}
-	if m.Type != 0 {
-		n += 1 + sovCommand(uint64(m.Type))
+	return len(dAtA) - i, nil
+}
+func (m *Command_TrafficMirrorRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Command_TrafficMirrorRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.TrafficMirrorRequest != nil {
+		{
+			size, err := m.TrafficMirrorRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
 	}
-	if m.Data != nil {
-		n += m.Data.Size()
+	return len(dAtA) - i, nil
+}
+func (m *Command_UpstreamSwitchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Command_UpstreamSwitchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.UpstreamSwitchRequest != nil {
+		{
+			size, err := m.UpstreamSwitchRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xaa
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return len(dAtA) - i, nil
+}
+func (m *CertificateDeployRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Command_CmdStatus) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *CertificateDeployRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CertificateDeployRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.CmdStatus != nil {
-		l = m.CmdStatus.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-func (m *Command_NginxConfig) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Chain) > 0 {
+		i -= len(m.Chain)
+		copy(dAtA[i:], m.Chain)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Chain)))
+		i--
+		dAtA[i] = 0x3a
 	}
-	var l int
-	_ = l
-	if m.NginxConfig != nil {
-		l = m.NginxConfig.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.PrivateKey) > 0 {
+		i -= len(m.PrivateKey)
+		copy(dAtA[i:], m.PrivateKey)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.PrivateKey)))
+		i--
+		dAtA[i] = 0x32
 	}
-	return n
-}
-func (m *Command_NginxConfigResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Certificate) > 0 {
+		i -= len(m.Certificate)
+		copy(dAtA[i:], m.Certificate)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Certificate)))
+		i--
+		dAtA[i] = 0x2a
 	}
-	var l int
-	_ = l
-	if m.NginxConfigResponse != nil {
-		l = m.NginxConfigResponse.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x22
 	}
-	return n
-}
-func (m *Command_AgentConnectRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	var l int
-	_ = l
-	if m.AgentConnectRequest != nil {
-		l = m.AgentConnectRequest.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.NginxId) > 0 {
+		i -= len(m.NginxId)
+		copy(dAtA[i:], m.NginxId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.NginxId)))
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if len(m.SystemId) > 0 {
+		i -= len(m.SystemId)
+		copy(dAtA[i:], m.SystemId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.SystemId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
-func (m *Command_AgentConnectResponse) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *KeyvalSyncRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KeyvalSyncRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeyvalSyncRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.AgentConnectResponse != nil {
-		l = m.AgentConnectResponse.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-func (m *Command_AgentConfigRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Export {
+		i--
+		if m.Export {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
 	}
-	var l int
-	_ = l
-	if m.AgentConfigRequest != nil {
-		l = m.AgentConfigRequest.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if m.Truncate {
+		i--
+		if m.Truncate {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
 	}
-	return n
-}
-func (m *Command_AgentConfig) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Entries) > 0 {
+		i -= len(m.Entries)
+		copy(dAtA[i:], m.Entries)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Entries)))
+		i--
+		dAtA[i] = 0x22
 	}
-	var l int
-	_ = l
-	if m.AgentConfig != nil {
-		l = m.AgentConfig.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.Zone) > 0 {
+		i -= len(m.Zone)
+		copy(dAtA[i:], m.Zone)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Zone)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	return n
-}
-func (m *Command_DataplaneStatus) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.NginxId) > 0 {
+		i -= len(m.NginxId)
+		copy(dAtA[i:], m.NginxId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.NginxId)))
+		i--
+		dAtA[i] = 0x12
 	}
-	var l int
-	_ = l
-	if m.DataplaneStatus != nil {
-		l = m.DataplaneStatus.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.SystemId) > 0 {
+		i -= len(m.SystemId)
+		copy(dAtA[i:], m.SystemId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.SystemId)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
-func (m *Command_EventReport) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *DebugCaptureRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DebugCaptureRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DebugCaptureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.EventReport != nil {
-		l = m.EventReport.Size()
-		n += 1 + l + sovCommand(uint64(l))
-	}
-	return n
-}
-func (m *Command_DataplaneSoftwareDetails) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	var l int
-	_ = l
-	if m.DataplaneSoftwareDetails != nil {
-		l = m.DataplaneSoftwareDetails.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if m.LogLines != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.LogLines))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
-func (m *Command_DataplaneUpdate) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *AgentUpdateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentUpdateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentUpdateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.DataplaneUpdate != nil {
-		l = m.DataplaneUpdate.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-func (m *CommandStatusResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x32
 	}
-	var l int
-	_ = l
-	if m.Status != 0 {
-		n += 1 + sovCommand(uint64(m.Status))
+	if len(m.Checksum) > 0 {
+		i -= len(m.Checksum)
+		copy(dAtA[i:], m.Checksum)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Checksum)))
+		i--
+		dAtA[i] = 0x2a
 	}
-	if m.ErrorCode != 0 {
-		n += 1 + sovCommand(uint64(m.ErrorCode))
+	if len(m.Package) > 0 {
+		i -= len(m.Package)
+		copy(dAtA[i:], m.Package)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Package)))
+		i--
+		dAtA[i] = 0x22
 	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.PackageUrl) > 0 {
+		i -= len(m.PackageUrl)
+		copy(dAtA[i:], m.PackageUrl)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.PackageUrl)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	l = len(m.Error)
-	if l > 0 {
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.SystemId) > 0 {
+		i -= len(m.SystemId)
+		copy(dAtA[i:], m.SystemId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.SystemId)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *DataplaneStatus) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *UpstreamDrainRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *UpstreamDrainRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpstreamDrainRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.SystemId)
-	if l > 0 {
-		n += 1 + l + sovCommand(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Details) > 0 {
-		for _, e := range m.Details {
-			l = e.Size()
-			n += 1 + l + sovCommand(uint64(l))
+	if m.Stream {
+		i--
+		if m.Stream {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x30
 	}
-	if m.Host != nil {
-		l = m.Host.Size()
-		n += 1 + l + sovCommand(uint64(l))
-	}
-	if len(m.Healths) > 0 {
-		for _, e := range m.Healths {
-			l = e.Size()
-			n += 1 + l + sovCommand(uint64(l))
-		}
+	if m.State != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.State))
+		i--
+		dAtA[i] = 0x28
 	}
-	if len(m.DataplaneSoftwareDetails) > 0 {
-		for _, e := range m.DataplaneSoftwareDetails {
-			l = e.Size()
-			n += 1 + l + sovCommand(uint64(l))
-		}
+	if len(m.Server) > 0 {
+		i -= len(m.Server)
+		copy(dAtA[i:], m.Server)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Server)))
+		i--
+		dAtA[i] = 0x22
 	}
-	if len(m.DataplaneSoftwareHealths) > 0 {
-		for _, e := range m.DataplaneSoftwareHealths {
-			l = e.Size()
-			n += 1 + l + sovCommand(uint64(l))
-		}
+	if len(m.Upstream) > 0 {
+		i -= len(m.Upstream)
+		copy(dAtA[i:], m.Upstream)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Upstream)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if len(m.AgentActivityStatus) > 0 {
-		for _, e := range m.AgentActivityStatus {
-			l = e.Size()
-			n += 1 + l + sovCommand(uint64(l))
-		}
+	if len(m.NginxId) > 0 {
+		i -= len(m.NginxId)
+		copy(dAtA[i:], m.NginxId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.NginxId)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.SystemId) > 0 {
+		i -= len(m.SystemId)
+		copy(dAtA[i:], m.SystemId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.SystemId)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *AgentActivityStatus) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Status != nil {
-		n += m.Status.Size()
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *TrafficMirrorRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *AgentActivityStatus_NginxConfigStatus) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.NginxConfigStatus != nil {
-		l = m.NginxConfigStatus.Size()
-		n += 1 + l + sovCommand(uint64(l))
-	}
-	return n
+func (m *TrafficMirrorRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (m *NginxConfigStatus) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *TrafficMirrorRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.CorrelationId)
-	if l > 0 {
-		n += 1 + l + sovCommand(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Status != 0 {
-		n += 1 + sovCommand(uint64(m.Status))
+	if m.Action != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.Action))
+		i--
+		dAtA[i] = 0x28
 	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.Backend) > 0 {
+		i -= len(m.Backend)
+		copy(dAtA[i:], m.Backend)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Backend)))
+		i--
+		dAtA[i] = 0x22
 	}
-	l = len(m.NginxId)
-	if l > 0 {
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.ServerName) > 0 {
+		i -= len(m.ServerName)
+		copy(dAtA[i:], m.ServerName)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.ServerName)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.NginxId) > 0 {
+		i -= len(m.NginxId)
+		copy(dAtA[i:], m.NginxId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.NginxId)))
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if len(m.SystemId) > 0 {
+		i -= len(m.SystemId)
+		copy(dAtA[i:], m.SystemId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.SystemId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *DataplaneSoftwareHealth) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *UpstreamSwitchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *UpstreamSwitchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpstreamSwitchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Health != nil {
-		n += m.Health.Size()
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-
-func (m *DataplaneSoftwareHealth_NginxHealth) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.NginxHealth != nil {
-		l = m.NginxHealth.Size()
-		n += 1 + l + sovCommand(uint64(l))
-	}
-	return n
-}
-func (m *DataplaneSoftwareHealth_AppProtectWafHealth) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.AppProtectWafHealth != nil {
-		l = m.AppProtectWafHealth.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.ProbePath) > 0 {
+		i -= len(m.ProbePath)
+		copy(dAtA[i:], m.ProbePath)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.ProbePath)))
+		i--
+		dAtA[i] = 0x2a
 	}
-	return n
-}
-func (m *DataplaneUpdate) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Servers) > 0 {
+		for iNdEx := len(m.Servers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Servers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCommand(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
 	}
-	var l int
-	_ = l
-	if m.Host != nil {
-		l = m.Host.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.Upstream) > 0 {
+		i -= len(m.Upstream)
+		copy(dAtA[i:], m.Upstream)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Upstream)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if len(m.DataplaneSoftwareDetails) > 0 {
-		for _, e := range m.DataplaneSoftwareDetails {
-			l = e.Size()
-			n += 1 + l + sovCommand(uint64(l))
-		}
+	if len(m.NginxId) > 0 {
+		i -= len(m.NginxId)
+		copy(dAtA[i:], m.NginxId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.NginxId)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.SystemId) > 0 {
+		i -= len(m.SystemId)
+		copy(dAtA[i:], m.SystemId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.SystemId)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *DownloadRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Meta != nil {
-		l = m.Meta.Size()
-		n += 1 + l + sovCommand(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *UpstreamSwitchRequest_Server) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *NginxConfigResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Status != nil {
-		l = m.Status.Size()
-		n += 1 + l + sovCommand(uint64(l))
-	}
-	if m.Action != 0 {
-		n += 1 + sovCommand(uint64(m.Action))
-	}
-	if m.ConfigData != nil {
-		l = m.ConfigData.Size()
-		n += 1 + l + sovCommand(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *UpstreamSwitchRequest_Server) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *UploadStatus) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *UpstreamSwitchRequest_Server) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Meta != nil {
-		l = m.Meta.Size()
-		n += 1 + l + sovCommand(uint64(l))
-	}
-	if m.Status != 0 {
-		n += 1 + sovCommand(uint64(m.Status))
-	}
-	l = len(m.Reason)
-	if l > 0 {
-		n += 1 + l + sovCommand(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-
-func (m *DataChunk) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Backup {
+		i--
+		if m.Backup {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
 	}
-	var l int
-	_ = l
-	if m.Chunk != nil {
-		n += m.Chunk.Size()
+	if m.Weight != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.Weight))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *DataChunk_Header) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovCommand(uint64(l))
+func (m *CommandStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *DataChunk_Data) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Data != nil {
-		l = m.Data.Size()
-		n += 1 + l + sovCommand(uint64(l))
-	}
-	return n
+
+func (m *CommandStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (m *ChunkedResourceHeader) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *CommandStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Meta != nil {
-		l = m.Meta.Size()
-		n += 1 + l + sovCommand(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Chunks != 0 {
-		n += 1 + sovCommand(uint64(m.Chunks))
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x22
 	}
-	l = len(m.Checksum)
-	if l > 0 {
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.ChunkSize != 0 {
-		n += 1 + sovCommand(uint64(m.ChunkSize))
+	if m.ErrorCode != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.ErrorCode))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.Status != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ChunkedResourceChunk) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DataplaneStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DataplaneStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataplaneStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Meta != nil {
-		l = m.Meta.Size()
-		n += 1 + l + sovCommand(uint64(l))
-	}
-	if m.ChunkId != 0 {
-		n += 1 + sovCommand(uint64(m.ChunkId))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovCommand(uint64(l))
+	if len(m.AgentActivityStatus) > 0 {
+		for iNdEx := len(m.AgentActivityStatus) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AgentActivityStatus[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCommand(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.DataplaneSoftwareHealths) > 0 {
+		for iNdEx := len(m.DataplaneSoftwareHealths) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DataplaneSoftwareHealths[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCommand(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.DataplaneSoftwareDetails) > 0 {
+		for iNdEx := len(m.DataplaneSoftwareDetails) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DataplaneSoftwareDetails[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCommand(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Healths) > 0 {
+		for iNdEx := len(m.Healths) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Healths[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCommand(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Host != nil {
+		{
+			size, err := m.Host.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Details) > 0 {
+		for iNdEx := len(m.Details) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Details[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCommand(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.SystemId) > 0 {
+		i -= len(m.SystemId)
+		copy(dAtA[i:], m.SystemId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.SystemId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentActivityStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentActivityStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentActivityStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Status != nil {
+		{
+			size := m.Status.Size()
+			i -= size
+			if _, err := m.Status.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentActivityStatus_NginxConfigStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentActivityStatus_NginxConfigStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.NginxConfigStatus != nil {
+		{
+			size, err := m.NginxConfigStatus.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *NginxConfigStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NginxConfigStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NginxConfigStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.NginxId) > 0 {
+		i -= len(m.NginxId)
+		copy(dAtA[i:], m.NginxId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.NginxId)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Status != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.CorrelationId) > 0 {
+		i -= len(m.CorrelationId)
+		copy(dAtA[i:], m.CorrelationId)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.CorrelationId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DataplaneSoftwareHealth) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DataplaneSoftwareHealth) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataplaneSoftwareHealth) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Health != nil {
+		{
+			size := m.Health.Size()
+			i -= size
+			if _, err := m.Health.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DataplaneSoftwareHealth_NginxHealth) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataplaneSoftwareHealth_NginxHealth) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.NginxHealth != nil {
+		{
+			size, err := m.NginxHealth.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *DataplaneSoftwareHealth_AppProtectWafHealth) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataplaneSoftwareHealth_AppProtectWafHealth) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.AppProtectWafHealth != nil {
+		{
+			size, err := m.AppProtectWafHealth.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *DataplaneUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DataplaneUpdate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataplaneUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.DataplaneSoftwareDetails) > 0 {
+		for iNdEx := len(m.DataplaneSoftwareDetails) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DataplaneSoftwareDetails[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCommand(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Host != nil {
+		{
+			size, err := m.Host.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DownloadRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DownloadRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DownloadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Meta != nil {
+		{
+			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NginxConfigResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NginxConfigResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NginxConfigResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ConfigData != nil {
+		{
+			size, err := m.ConfigData.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Action != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.Action))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status != nil {
+		{
+			size, err := m.Status.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UploadStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UploadStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UploadStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Status != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Meta != nil {
+		{
+			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DataChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DataChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Chunk != nil {
+		{
+			size := m.Chunk.Size()
+			i -= size
+			if _, err := m.Chunk.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DataChunk_Header) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataChunk_Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Header != nil {
+		{
+			size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *DataChunk_Data) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataChunk_Data) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Data != nil {
+		{
+			size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ChunkedResourceHeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChunkedResourceHeader) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChunkedResourceHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ChunkSize != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.ChunkSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Checksum) > 0 {
+		i -= len(m.Checksum)
+		copy(dAtA[i:], m.Checksum)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Checksum)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Chunks != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.Chunks))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Meta != nil {
+		{
+			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ChunkedResourceChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChunkedResourceChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChunkedResourceChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintCommand(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ChunkId != 0 {
+		i = encodeVarintCommand(dAtA, i, uint64(m.ChunkId))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Meta != nil {
+		{
+			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommand(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintCommand(dAtA []byte, offset int, v uint64) int {
+	offset -= sovCommand(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Command) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovCommand(uint64(m.Type))
+	}
+	if m.Data != nil {
+		n += m.Data.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Command_CmdStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CmdStatus != nil {
+		l = m.CmdStatus.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_NginxConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NginxConfig != nil {
+		l = m.NginxConfig.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_NginxConfigResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NginxConfigResponse != nil {
+		l = m.NginxConfigResponse.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_AgentConnectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AgentConnectRequest != nil {
+		l = m.AgentConnectRequest.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_AgentConnectResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AgentConnectResponse != nil {
+		l = m.AgentConnectResponse.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_AgentConfigRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AgentConfigRequest != nil {
+		l = m.AgentConfigRequest.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_AgentConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AgentConfig != nil {
+		l = m.AgentConfig.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_DataplaneStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DataplaneStatus != nil {
+		l = m.DataplaneStatus.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_EventReport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EventReport != nil {
+		l = m.EventReport.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_DataplaneSoftwareDetails) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DataplaneSoftwareDetails != nil {
+		l = m.DataplaneSoftwareDetails.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_DataplaneUpdate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DataplaneUpdate != nil {
+		l = m.DataplaneUpdate.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_CertificateDeployRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CertificateDeployRequest != nil {
+		l = m.CertificateDeployRequest.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_DebugCaptureRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DebugCaptureRequest != nil {
+		l = m.DebugCaptureRequest.Size()
+		n += 2 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_KeyvalSyncRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.KeyvalSyncRequest != nil {
+		l = m.KeyvalSyncRequest.Size()
+		n += 2 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_AgentUpdateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AgentUpdateRequest != nil {
+		l = m.AgentUpdateRequest.Size()
+		n += 2 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_UpstreamDrainRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.UpstreamDrainRequest != nil {
+		l = m.UpstreamDrainRequest.Size()
+		n += 2 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_TrafficMirrorRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TrafficMirrorRequest != nil {
+		l = m.TrafficMirrorRequest.Size()
+		n += 2 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *Command_UpstreamSwitchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.UpstreamSwitchRequest != nil {
+		l = m.UpstreamSwitchRequest.Size()
+		n += 2 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *CertificateDeployRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SystemId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.NginxId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Certificate)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.PrivateKey)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Chain)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KeyvalSyncRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SystemId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.NginxId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Zone)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Entries)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.Truncate {
+		n += 2
+	}
+	if m.Export {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DebugCaptureRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogLines != 0 {
+		n += 1 + sovCommand(uint64(m.LogLines))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AgentUpdateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SystemId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.PackageUrl)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Package)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Checksum)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpstreamDrainRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SystemId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.NginxId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Upstream)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Server)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.State != 0 {
+		n += 1 + sovCommand(uint64(m.State))
+	}
+	if m.Stream {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TrafficMirrorRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SystemId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.NginxId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.ServerName)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Backend)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.Action != 0 {
+		n += 1 + sovCommand(uint64(m.Action))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpstreamSwitchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SystemId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.NginxId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Upstream)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if len(m.Servers) > 0 {
+		for _, e := range m.Servers {
+			l = e.Size()
+			n += 1 + l + sovCommand(uint64(l))
+		}
+	}
+	l = len(m.ProbePath)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpstreamSwitchRequest_Server) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.Weight != 0 {
+		n += 1 + sovCommand(uint64(m.Weight))
+	}
+	if m.Backup {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CommandStatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovCommand(uint64(m.Status))
+	}
+	if m.ErrorCode != 0 {
+		n += 1 + sovCommand(uint64(m.ErrorCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DataplaneStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SystemId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if len(m.Details) > 0 {
+		for _, e := range m.Details {
+			l = e.Size()
+			n += 1 + l + sovCommand(uint64(l))
+		}
+	}
+	if m.Host != nil {
+		l = m.Host.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if len(m.Healths) > 0 {
+		for _, e := range m.Healths {
+			l = e.Size()
+			n += 1 + l + sovCommand(uint64(l))
+		}
+	}
+	if len(m.DataplaneSoftwareDetails) > 0 {
+		for _, e := range m.DataplaneSoftwareDetails {
+			l = e.Size()
+			n += 1 + l + sovCommand(uint64(l))
+		}
+	}
+	if len(m.DataplaneSoftwareHealths) > 0 {
+		for _, e := range m.DataplaneSoftwareHealths {
+			l = e.Size()
+			n += 1 + l + sovCommand(uint64(l))
+		}
+	}
+	if len(m.AgentActivityStatus) > 0 {
+		for _, e := range m.AgentActivityStatus {
+			l = e.Size()
+			n += 1 + l + sovCommand(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AgentActivityStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != nil {
+		n += m.Status.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AgentActivityStatus_NginxConfigStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NginxConfigStatus != nil {
+		l = m.NginxConfigStatus.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *NginxConfigStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.CorrelationId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.Status != 0 {
+		n += 1 + sovCommand(uint64(m.Status))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	l = len(m.NginxId)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DataplaneSoftwareHealth) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Health != nil {
+		n += m.Health.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DataplaneSoftwareHealth_NginxHealth) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NginxHealth != nil {
+		l = m.NginxHealth.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *DataplaneSoftwareHealth_AppProtectWafHealth) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AppProtectWafHealth != nil {
+		l = m.AppProtectWafHealth.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *DataplaneUpdate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Host != nil {
+		l = m.Host.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if len(m.DataplaneSoftwareDetails) > 0 {
+		for _, e := range m.DataplaneSoftwareDetails {
+			l = e.Size()
+			n += 1 + l + sovCommand(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DownloadRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NginxConfigResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != nil {
+		l = m.Status.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.Action != 0 {
+		n += 1 + sovCommand(uint64(m.Action))
+	}
+	if m.ConfigData != nil {
+		l = m.ConfigData.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UploadStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.Status != 0 {
+		n += 1 + sovCommand(uint64(m.Status))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DataChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Chunk != nil {
+		n += m.Chunk.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DataChunk_Header) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *DataChunk_Data) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Data != nil {
+		l = m.Data.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	return n
+}
+func (m *ChunkedResourceHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.Chunks != 0 {
+		n += 1 + sovCommand(uint64(m.Chunks))
+	}
+	l = len(m.Checksum)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.ChunkSize != 0 {
+		n += 1 + sovCommand(uint64(m.ChunkSize))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ChunkedResourceChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.ChunkId != 0 {
+		n += 1 + sovCommand(uint64(m.ChunkId))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovCommand(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovCommand(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozCommand(x uint64) (n int) {
+	return sovCommand(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Command) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommand
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Command: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Command: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Meta == nil {
+				m.Meta = &Metadata{}
+			}
+			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= Command_CommandType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CmdStatus", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CommandStatusResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_CmdStatus{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NginxConfig", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &NginxConfig{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_NginxConfig{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NginxConfigResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &NginxConfigResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_NginxConfigResponse{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentConnectRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AgentConnectRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_AgentConnectRequest{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentConnectResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AgentConnectResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_AgentConnectResponse{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentConfigRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AgentConfigRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_AgentConfigRequest{v}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentConfig", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AgentConfig{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_AgentConfig{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataplaneStatus", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DataplaneStatus{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_DataplaneStatus{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventReport", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &events.EventReport{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_EventReport{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataplaneSoftwareDetails", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DataplaneSoftwareDetails{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_DataplaneSoftwareDetails{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataplaneUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DataplaneUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_DataplaneUpdate{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CertificateDeployRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CertificateDeployRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_CertificateDeployRequest{v}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DebugCaptureRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DebugCaptureRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_DebugCaptureRequest{v}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyvalSyncRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &KeyvalSyncRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_KeyvalSyncRequest{v}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentUpdateRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AgentUpdateRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_AgentUpdateRequest{v}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpstreamDrainRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &UpstreamDrainRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_UpstreamDrainRequest{v}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrafficMirrorRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &TrafficMirrorRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_TrafficMirrorRequest{v}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpstreamSwitchRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &UpstreamSwitchRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Command_UpstreamSwitchRequest{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommand(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertificateDeployRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommand
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CertificateDeployRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CertificateDeployRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SystemId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SystemId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NginxId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NginxId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Certificate = append(m.Certificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.Certificate == nil {
+				m.Certificate = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrivateKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrivateKey = append(m.PrivateKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PrivateKey == nil {
+				m.PrivateKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chain", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chain = append(m.Chain[:0], dAtA[iNdEx:postIndex]...)
+			if m.Chain == nil {
+				m.Chain = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommand(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KeyvalSyncRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommand
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeyvalSyncRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeyvalSyncRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SystemId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SystemId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NginxId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NginxId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Zone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Zone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries[:0], dAtA[iNdEx:postIndex]...)
+			if m.Entries == nil {
+				m.Entries = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Truncate", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Truncate = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Export", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Export = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommand(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DebugCaptureRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommand
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DebugCaptureRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DebugCaptureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogLines", wireType)
+			}
+			m.LogLines = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LogLines |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommand(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AgentUpdateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommand
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AgentUpdateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AgentUpdateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SystemId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SystemId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PackageUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PackageUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Package", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Package = append(m.Package[:0], dAtA[iNdEx:postIndex]...)
+			if m.Package == nil {
+				m.Package = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Checksum = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommand(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovCommand(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozCommand(x uint64) (n int) {
-	return sovCommand(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Command) Unmarshal(dAtA []byte) error {
+func (m *UpstreamDrainRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2981,17 +6434,17 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Command: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpstreamDrainRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Command: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpstreamDrainRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SystemId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommand
@@ -3001,33 +6454,29 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCommand
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCommand
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Meta == nil {
-				m.Meta = &Metadata{}
-			}
-			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.SystemId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NginxId", wireType)
 			}
-			m.Type = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommand
@@ -3037,16 +6486,29 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= Command_CommandType(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NginxId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CmdStatus", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Upstream", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommand
@@ -3056,32 +6518,29 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCommand
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCommand
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &CommandStatusResponse{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Data = &Command_CmdStatus{v}
+			m.Upstream = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NginxConfig", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Server", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommand
@@ -3091,32 +6550,215 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCommand
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCommand
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &NginxConfig{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Server = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= UpstreamDrainRequest_TargetState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stream", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Stream = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommand(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Data = &Command_NginxConfig{v}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TrafficMirrorRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommand
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TrafficMirrorRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TrafficMirrorRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SystemId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SystemId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NginxId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NginxId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommand
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServerName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NginxConfigResponse", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Backend", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommand
@@ -3126,32 +6768,29 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCommand
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCommand
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &NginxConfigResponse{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Data = &Command_NginxConfigResponse{v}
+			m.Backend = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AgentConnectRequest", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
 			}
-			var msglen int
+			m.Action = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommand
@@ -3161,32 +6800,67 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Action |= TrafficMirrorRequest_Action(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthCommand
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommand(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthCommand
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &AgentConnectRequest{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpstreamSwitchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommand
 			}
-			m.Data = &Command_AgentConnectRequest{v}
-			iNdEx = postIndex
-		case 7:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpstreamSwitchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpstreamSwitchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AgentConnectResponse", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SystemId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommand
@@ -3196,32 +6870,29 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCommand
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCommand
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &AgentConnectResponse{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Data = &Command_AgentConnectResponse{v}
+			m.SystemId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AgentConfigRequest", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NginxId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommand
@@ -3231,32 +6902,29 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCommand
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCommand
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &AgentConfigRequest{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Data = &Command_AgentConfigRequest{v}
+			m.NginxId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 9:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AgentConfig", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Upstream", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommand
@@ -3266,30 +6934,27 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCommand
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCommand
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &AgentConfig{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Data = &Command_AgentConfig{v}
+			m.Upstream = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 11:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DataplaneStatus", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Servers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3316,17 +6981,16 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &DataplaneStatus{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Servers = append(m.Servers, &UpstreamSwitchRequest_Server{})
+			if err := m.Servers[len(m.Servers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Data = &Command_DataplaneStatus{v}
 			iNdEx = postIndex
-		case 12:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EventReport", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ProbePath", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommand
@@ -3336,32 +7000,80 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCommand
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCommand
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &events.EventReport{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.ProbePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommand(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Data = &Command_EventReport{v}
-			iNdEx = postIndex
-		case 13:
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCommand
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpstreamSwitchRequest_Server) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommand
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Server: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Server: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DataplaneSoftwareDetails", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommand
@@ -3371,32 +7083,29 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCommand
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCommand
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &DataplaneSoftwareDetails{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Data = &Command_DataplaneSoftwareDetails{v}
+			m.Address = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 14:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DataplaneUpdate", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
 			}
-			var msglen int
+			m.Weight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommand
@@ -3406,27 +7115,31 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Weight |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthCommand
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthCommand
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Backup", wireType)
 			}
-			v := &DataplaneUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommand
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Data = &Command_DataplaneUpdate{v}
-			iNdEx = postIndex
+			m.Backup = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommand(dAtA[iNdEx:])
@@ -73,7 +73,11 @@ type Metadata struct {
 	// Message ID
 	MessageId string `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id"`
 	// Cloud Account ID (e.g. AWS/Azure/GCP account ID)
-	CloudAccountId       string   `protobuf:"bytes,4,opt,name=cloud_account_id,json=cloudAccountId,proto3" json:"cloud_account_id"`
+	CloudAccountId string `protobuf:"bytes,4,opt,name=cloud_account_id,json=cloudAccountId,proto3" json:"cloud_account_id"`
+	// Idempotency key identifying the operation a command is part of. Retried
+	// deliveries of the same operation carry the same key, so the agent can
+	// deduplicate them and replay the original result
+	IdempotencyKey       string   `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -140,6 +144,13 @@ func (m *Metadata) GetCloudAccountId() string {
 	return ""
 }
 
+func (m *Metadata) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 // Represents a map of directories & files on the system
 type DirectoryMap struct {
 	// List of directories
@@ -302,8 +313,14 @@ type Directory struct {
 	Permissions string `protobuf:"bytes,3,opt,name=permissions,proto3" json:"permissions,omitempty"`
 	// Size of the directory in bytes
 	Size_ int64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	// List of files in the directory
-	Files                []*File  `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`
+	// List of files in the directory. May be truncated to a per-directory limit
+	// for reporting, in which case truncated is set and total_files holds the
+	// real count
+	Files []*File `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`
+	// Total number of files in the directory, set when the file list is truncated
+	TotalFiles int32 `protobuf:"varint,6,opt,name=total_files,json=totalFiles,proto3" json:"total_files,omitempty"`
+	// Whether the file list was truncated for reporting
+	Truncated            bool     `protobuf:"varint,7,opt,name=truncated,proto3" json:"truncated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -377,13 +394,29 @@ func (m *Directory) GetFiles() []*File {
 	return nil
 }
 
+func (m *Directory) GetTotalFiles() int32 {
+	if m != nil {
+		return m.TotalFiles
+	}
+	return 0
+}
+
+func (m *Directory) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
 // Represents a list of SSL certificates files
 type SslCertificates struct {
-	// List of SSL certificates
-	SslCerts             []*SslCertificate `protobuf:"bytes,1,rep,name=ssl_certs,json=sslCerts,proto3" json:"ssl_certs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	// List of SSL certificates, deduplicated by fingerprint
+	SslCerts []*SslCertificate `protobuf:"bytes,1,rep,name=ssl_certs,json=sslCerts,proto3" json:"ssl_certs,omitempty"`
+	// References from server blocks to certificates in ssl_certs, by fingerprint
+	References           []*SslCertificateReference `protobuf:"bytes,2,rep,name=references,proto3" json:"references,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
 }
 
 func (m *SslCertificates) Reset()         { *m = SslCertificates{} }
@@ -426,6 +459,71 @@ func (m *SslCertificates) GetSslCerts() []*SslCertificate {
 	return nil
 }
 
+func (m *SslCertificates) GetReferences() []*SslCertificateReference {
+	if m != nil {
+		return m.References
+	}
+	return nil
+}
+
+// Represents a server block referencing a SSL certificate
+type SslCertificateReference struct {
+	// Fingerprint of the referenced certificate
+	Fingerprint string `protobuf:"bytes,1,opt,name=fingerprint,proto3" json:"fingerprint"`
+	// server_name values of the referencing server block
+	ServerNames          []string `protobuf:"bytes,2,rep,name=server_names,json=serverNames,proto3" json:"serverNames"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SslCertificateReference) Reset()         { *m = SslCertificateReference{} }
+func (m *SslCertificateReference) String() string { return proto.CompactTextString(m) }
+func (*SslCertificateReference) ProtoMessage()    {}
+func (*SslCertificateReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{5}
+}
+func (m *SslCertificateReference) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SslCertificateReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SslCertificateReference.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SslCertificateReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SslCertificateReference.Merge(m, src)
+}
+func (m *SslCertificateReference) XXX_Size() int {
+	return m.Size()
+}
+func (m *SslCertificateReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_SslCertificateReference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SslCertificateReference proto.InternalMessageInfo
+
+func (m *SslCertificateReference) GetFingerprint() string {
+	if m != nil {
+		return m.Fingerprint
+	}
+	return ""
+}
+
+func (m *SslCertificateReference) GetServerNames() []string {
+	if m != nil {
+		return m.ServerNames
+	}
+	return nil
+}
+
 // Represents a SSL certificate file
 type SslCertificate struct {
 	// Name of the file
@@ -459,17 +557,29 @@ type SslCertificate struct {
 	// There are three versions of certificates: 1, 2 and 3, numbered as 0, 1 and 2. Version 1 supports only the basic fields; Version 2 adds unique identifiers, which represent two additional fields; Version 3 adds extensions.
 	Version int64 `protobuf:"varint,15,opt,name=version,proto3" json:"version"`
 	// The authority key identifier extension provides a means of identifying the Public Key corresponding to the Private Key used to sign a certificate
-	AuthorityKeyIdentifier string   `protobuf:"bytes,16,opt,name=authority_key_identifier,json=authorityKeyIdentifier,proto3" json:"authorityKeyIdentifier"`
-	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
-	XXX_unrecognized       []byte   `json:"-"`
-	XXX_sizecache          int32    `json:"-"`
+	AuthorityKeyIdentifier string `protobuf:"bytes,16,opt,name=authority_key_identifier,json=authorityKeyIdentifier,proto3" json:"authorityKeyIdentifier"`
+	// Metadata about the private key file paired with the certificate
+	Key *SslKey `protobuf:"bytes,17,opt,name=key,proto3" json:"key"`
+	// Result of validating the certificate chain against the trusted roots, either "valid" or the validation error
+	ChainValidationStatus string `protobuf:"bytes,18,opt,name=chain_validation_status,json=chainValidationStatus,proto3" json:"chainValidationStatus"`
+	// Revocation status of the certificate determined via OCSP or CRL when reachable ("good", "revoked" or "unknown")
+	RevocationStatus string `protobuf:"bytes,19,opt,name=revocation_status,json=revocationStatus,proto3" json:"revocationStatus"`
+	// Whether OCSP stapling is enabled in the server block referencing this certificate
+	OcspStapling bool `protobuf:"varint,20,opt,name=ocsp_stapling,json=ocspStapling,proto3" json:"ocspStapling"`
+	// Extended key usages of the certificate (e.g. ServerAuth)
+	ExtendedKeyUsage []string `protobuf:"bytes,21,rep,name=extended_key_usage,json=extendedKeyUsage,proto3" json:"extendedKeyUsage"`
+	// Whether the certificate covers all the server names of the server block referencing it
+	CoversServerNames    bool     `protobuf:"varint,22,opt,name=covers_server_names,json=coversServerNames,proto3" json:"coversServerNames"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SslCertificate) Reset()         { *m = SslCertificate{} }
 func (m *SslCertificate) String() string { return proto.CompactTextString(m) }
 func (*SslCertificate) ProtoMessage()    {}
 func (*SslCertificate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{5}
+	return fileDescriptor_555bd8c177793206, []int{6}
 }
 func (m *SslCertificate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -610,6 +720,152 @@ func (m *SslCertificate) GetAuthorityKeyIdentifier() string {
 	return ""
 }
 
+func (m *SslCertificate) GetKey() *SslKey {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *SslCertificate) GetChainValidationStatus() string {
+	if m != nil {
+		return m.ChainValidationStatus
+	}
+	return ""
+}
+
+func (m *SslCertificate) GetRevocationStatus() string {
+	if m != nil {
+		return m.RevocationStatus
+	}
+	return ""
+}
+
+func (m *SslCertificate) GetOcspStapling() bool {
+	if m != nil {
+		return m.OcspStapling
+	}
+	return false
+}
+
+func (m *SslCertificate) GetExtendedKeyUsage() []string {
+	if m != nil {
+		return m.ExtendedKeyUsage
+	}
+	return nil
+}
+
+func (m *SslCertificate) GetCoversServerNames() bool {
+	if m != nil {
+		return m.CoversServerNames
+	}
+	return false
+}
+
+// Represents metadata about a SSL private key file. Only metadata about the key
+// file is collected, never the key material itself
+type SslKey struct {
+	// Name of the key file
+	FileName string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"fileName"`
+	// Whether the key file exists on disk
+	Exists bool `protobuf:"varint,2,opt,name=exists,proto3" json:"exists"`
+	// Key file permissions (e.g. 0600)
+	Permissions string `protobuf:"bytes,3,opt,name=permissions,proto3" json:"permissions"`
+	// Name of the user that owns the key file
+	Owner string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner"`
+	// Whether the key file matches the public key of the certificate
+	MatchesCertificate bool `protobuf:"varint,5,opt,name=matches_certificate,json=matchesCertificate,proto3" json:"matchesCertificate"`
+	// Kind of key reference: "file" for on disk keys, "engine" for engine or PKCS#11 backed keys, "data" for inline keys
+	KeyType string `protobuf:"bytes,6,opt,name=key_type,json=keyType,proto3" json:"keyType"`
+	// Whether the key is encrypted or an ssl_password_file is configured for the server block
+	PasswordProtected    bool     `protobuf:"varint,7,opt,name=password_protected,json=passwordProtected,proto3" json:"passwordProtected"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SslKey) Reset()         { *m = SslKey{} }
+func (m *SslKey) String() string { return proto.CompactTextString(m) }
+func (*SslKey) ProtoMessage()    {}
+func (*SslKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{7}
+}
+func (m *SslKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SslKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SslKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SslKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SslKey.Merge(m, src)
+}
+func (m *SslKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *SslKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_SslKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SslKey proto.InternalMessageInfo
+
+func (m *SslKey) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *SslKey) GetExists() bool {
+	if m != nil {
+		return m.Exists
+	}
+	return false
+}
+
+func (m *SslKey) GetPermissions() string {
+	if m != nil {
+		return m.Permissions
+	}
+	return ""
+}
+
+func (m *SslKey) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *SslKey) GetMatchesCertificate() bool {
+	if m != nil {
+		return m.MatchesCertificate
+	}
+	return false
+}
+
+func (m *SslKey) GetKeyType() string {
+	if m != nil {
+		return m.KeyType
+	}
+	return ""
+}
+
+func (m *SslKey) GetPasswordProtected() bool {
+	if m != nil {
+		return m.PasswordProtected
+	}
+	return false
+}
+
 // Represents the dates for which a certificate is valid
 type CertificateDates struct {
 	// The start date that for when the certificate is valid
@@ -625,7 +881,7 @@ func (m *CertificateDates) Reset()         { *m = CertificateDates{} }
 func (m *CertificateDates) String() string { return proto.CompactTextString(m) }
 func (*CertificateDates) ProtoMessage()    {}
 func (*CertificateDates) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{6}
+	return fileDescriptor_555bd8c177793206, []int{8}
 }
 func (m *CertificateDates) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -691,7 +947,7 @@ func (m *CertificateName) Reset()         { *m = CertificateName{} }
 func (m *CertificateName) String() string { return proto.CompactTextString(m) }
 func (*CertificateName) ProtoMessage()    {}
 func (*CertificateName) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{7}
+	return fileDescriptor_555bd8c177793206, []int{9}
 }
 func (m *CertificateName) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -779,7 +1035,7 @@ func (m *ZippedFile) Reset()         { *m = ZippedFile{} }
 func (m *ZippedFile) String() string { return proto.CompactTextString(m) }
 func (*ZippedFile) ProtoMessage()    {}
 func (*ZippedFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{8}
+	return fileDescriptor_555bd8c177793206, []int{10}
 }
 func (m *ZippedFile) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -836,7 +1092,9 @@ func init() {
 	proto.RegisterType((*File)(nil), "f5.nginx.agent.sdk.File")
 	proto.RegisterType((*Directory)(nil), "f5.nginx.agent.sdk.Directory")
 	proto.RegisterType((*SslCertificates)(nil), "f5.nginx.agent.sdk.SslCertificates")
+	proto.RegisterType((*SslCertificateReference)(nil), "f5.nginx.agent.sdk.SslCertificateReference")
 	proto.RegisterType((*SslCertificate)(nil), "f5.nginx.agent.sdk.SslCertificate")
+	proto.RegisterType((*SslKey)(nil), "f5.nginx.agent.sdk.SslKey")
 	proto.RegisterType((*CertificateDates)(nil), "f5.nginx.agent.sdk.CertificateDates")
 	proto.RegisterType((*CertificateName)(nil), "f5.nginx.agent.sdk.CertificateName")
 	proto.RegisterType((*ZippedFile)(nil), "f5.nginx.agent.sdk.ZippedFile")
@@ -845,81 +1103,105 @@ func init() {
 func init() { proto.RegisterFile("common.proto", fileDescriptor_555bd8c177793206) }
 
 var fileDescriptor_555bd8c177793206 = []byte{
-	// 1178 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcf, 0x8e, 0xdb, 0x36,
-	0x13, 0xff, 0x64, 0xaf, 0x77, 0xad, 0xb1, 0xd7, 0x6b, 0xf0, 0x4b, 0x03, 0x61, 0xd1, 0x46, 0x86,
-	0xfb, 0x07, 0x4e, 0xd1, 0xda, 0xed, 0xb6, 0x41, 0x5a, 0x04, 0x68, 0xb0, 0x4e, 0xd0, 0x74, 0x9b,
-	0x66, 0x0b, 0xb0, 0xc9, 0x25, 0x17, 0x83, 0x96, 0x68, 0x2d, 0xbb, 0x32, 0x69, 0x88, 0x54, 0x50,
-	0xe7, 0x55, 0xfa, 0x18, 0x3d, 0xf4, 0x15, 0x7a, 0xec, 0x13, 0x08, 0x45, 0x8e, 0x3a, 0xf4, 0xd8,
-	0x4b, 0x2f, 0x05, 0x49, 0x49, 0x96, 0xb3, 0x4e, 0x52, 0xa0, 0x17, 0x71, 0xe6, 0x37, 0x33, 0x14,
-	0xf9, 0xe3, 0x70, 0x86, 0xd0, 0x0d, 0xc4, 0x72, 0x29, 0xf8, 0x78, 0x95, 0x08, 0x25, 0x10, 0x5a,
-	0xdc, 0x1a, 0xf3, 0x88, 0xf1, 0x9f, 0xc6, 0x24, 0xa2, 0x5c, 0x8d, 0x65, 0x78, 0x79, 0x0c, 0x91,
-	0x88, 0x84, 0xb5, 0x1f, 0xfb, 0x91, 0x10, 0x51, 0x4c, 0x27, 0x46, 0x9b, 0xa7, 0x8b, 0x89, 0x62,
-	0x4b, 0x2a, 0x15, 0x59, 0xae, 0xac, 0xc3, 0xf0, 0x4f, 0x07, 0xda, 0x8f, 0xa8, 0x22, 0x21, 0x51,
-	0x04, 0x3d, 0x00, 0xb7, 0xb2, 0x7b, 0xce, 0xc0, 0x19, 0x75, 0x4e, 0x8e, 0xc7, 0x76, 0x86, 0x71,
-	0x39, 0xc3, 0xf8, 0x71, 0xe9, 0x31, 0x3d, 0xcc, 0x33, 0x7f, 0x13, 0x80, 0x37, 0x22, 0xfa, 0x10,
-	0xdc, 0x20, 0x66, 0x94, 0xab, 0x19, 0x0b, 0xbd, 0xc6, 0xc0, 0x19, 0xb9, 0xd6, 0xb9, 0x02, 0x71,
-	0xdb, 0x8a, 0x67, 0x21, 0xfa, 0x18, 0x60, 0x49, 0xa5, 0x24, 0x11, 0xd5, 0xce, 0x4d, 0xe3, 0xdc,
-	0xcb, 0x33, 0xbf, 0x86, 0x62, 0xb7, 0x90, 0xcf, 0x42, 0xf4, 0x15, 0xf4, 0x83, 0x58, 0xa4, 0xe1,
-	0x8c, 0x04, 0x81, 0x48, 0xed, 0x1f, 0xf6, 0x4c, 0xd0, 0xb5, 0x3c, 0xf3, 0xaf, 0xd8, 0x70, 0xcf,
-	0x20, 0xa7, 0x16, 0x38, 0x0b, 0x87, 0xdf, 0x43, 0xf7, 0x3e, 0x4b, 0x68, 0xa0, 0x44, 0xb2, 0x7e,
-	0x44, 0x56, 0xe8, 0x2e, 0x74, 0xc2, 0x42, 0x67, 0x54, 0x7a, 0xce, 0xa0, 0x39, 0xea, 0x9c, 0xbc,
-	0x33, 0xbe, 0xca, 0xeb, 0xb8, 0x0a, 0xc3, 0xf5, 0x88, 0xe1, 0xdf, 0x0d, 0xd8, 0xfb, 0x9a, 0xc5,
-	0x14, 0xbd, 0x0d, 0x7b, 0x9c, 0x2c, 0xa9, 0x21, 0xce, 0x9d, 0xb6, 0xf3, 0xcc, 0x37, 0x3a, 0x36,
-	0x5f, 0xe4, 0x43, 0x2b, 0x66, 0x9c, 0x4a, 0x43, 0x47, 0x6b, 0xea, 0xe6, 0x99, 0x6f, 0x01, 0x6c,
-	0x07, 0x74, 0x07, 0x5a, 0x4b, 0xcd, 0xa0, 0xa1, 0xe0, 0xf5, 0xc4, 0x9b, 0x60, 0xe3, 0x8c, 0xed,
-	0x80, 0x3e, 0x85, 0xce, 0x8a, 0x26, 0x4b, 0x26, 0x25, 0x13, 0x5c, 0x16, 0x84, 0x1c, 0xe5, 0x99,
-	0x5f, 0x87, 0x71, 0x5d, 0xd1, 0xcb, 0x95, 0xec, 0x39, 0xf5, 0x5a, 0x03, 0x67, 0xd4, 0xb4, 0xcb,
-	0xd5, 0x3a, 0x36, 0x5f, 0x34, 0x82, 0x76, 0x20, 0xb8, 0xa2, 0x5c, 0x49, 0x6f, 0x7f, 0xe0, 0x8c,
-	0xba, 0xd3, 0x6e, 0x9e, 0xf9, 0x15, 0x86, 0x2b, 0x09, 0xdd, 0x86, 0x7d, 0x12, 0x28, 0x26, 0xb8,
-	0x77, 0x30, 0x70, 0x46, 0xbd, 0x13, 0x7f, 0x17, 0x77, 0x9a, 0xa0, 0xf1, 0xa9, 0x71, 0xc3, 0x85,
-	0xfb, 0xf0, 0x1e, 0xec, 0x5b, 0x04, 0xb9, 0xd0, 0x4a, 0xb9, 0xa4, 0xaa, 0xff, 0x3f, 0x74, 0x08,
-	0x6e, 0xca, 0x83, 0x0b, 0xc2, 0x23, 0x1a, 0xf6, 0x1d, 0x74, 0x00, 0x4d, 0x12, 0x86, 0xfd, 0x06,
-	0x02, 0xd8, 0x4f, 0x57, 0x21, 0x51, 0xb4, 0xdf, 0xd4, 0x72, 0x48, 0x63, 0xaa, 0x68, 0x7f, 0x6f,
-	0xf8, 0xab, 0x03, 0x6e, 0x75, 0x30, 0x08, 0xd5, 0x8f, 0xa0, 0x20, 0xfe, 0x93, 0x92, 0xd7, 0xc6,
-	0x9b, 0x78, 0x2d, 0xc9, 0x1c, 0x6c, 0x93, 0x69, 0x52, 0x72, 0x9b, 0x3b, 0x54, 0x70, 0xa7, 0x79,
-	0x6e, 0x16, 0x8c, 0x8d, 0xa1, 0xb5, 0x60, 0x31, 0x95, 0x5e, 0xcb, 0xa4, 0x90, 0xf7, 0x2a, 0x1a,
-	0xb0, 0x75, 0x1b, 0x62, 0x38, 0xfa, 0x41, 0xc6, 0xf7, 0x68, 0xa2, 0xd8, 0x82, 0x05, 0x44, 0x51,
-	0x89, 0xee, 0x82, 0x2b, 0x65, 0x3c, 0x0b, 0x68, 0xa2, 0xca, 0x4c, 0x1c, 0xee, 0x9a, 0x66, 0x3b,
-	0x0e, 0xb7, 0xa5, 0xd5, 0xe5, 0xf0, 0xaf, 0x03, 0xe8, 0x6d, 0x1b, 0xd1, 0x4d, 0x70, 0xf5, 0xff,
-	0x66, 0xb5, 0xd4, 0x34, 0x27, 0xa9, 0xc1, 0x73, 0x9d, 0x9e, 0x95, 0x54, 0x65, 0x44, 0x63, 0x67,
-	0x46, 0xfc, 0xa7, 0xfc, 0x3c, 0x87, 0xf6, 0x33, 0x12, 0xb3, 0x90, 0xa9, 0xb5, 0x21, 0xad, 0x73,
-	0xf2, 0xde, 0xae, 0x8d, 0xd5, 0x16, 0x7e, 0x5f, 0x33, 0x62, 0x97, 0x5a, 0x46, 0xe2, 0x4a, 0x42,
-	0x0f, 0x60, 0x9f, 0x49, 0x99, 0xd2, 0xc4, 0xa4, 0x6f, 0xe7, 0xe4, 0xdd, 0x37, 0xcc, 0xa6, 0xf7,
-	0x37, 0x85, 0x3c, 0xf3, 0x8b, 0x30, 0x5c, 0x8c, 0xe8, 0x5b, 0x38, 0x90, 0xe9, 0xfc, 0x47, 0x1a,
-	0x28, 0x93, 0xe6, 0xff, 0x72, 0xa6, 0x4e, 0x9e, 0xf9, 0x65, 0x1c, 0x2e, 0x05, 0xf4, 0x05, 0xf4,
-	0xb4, 0x38, 0x23, 0xb1, 0x32, 0x74, 0x4b, 0xef, 0x60, 0xd0, 0x1c, 0xb9, 0x53, 0x94, 0x67, 0x7e,
-	0xaf, 0x70, 0x3a, 0x8d, 0x95, 0x61, 0xbd, 0xab, 0xf5, 0x42, 0x91, 0xe8, 0x03, 0x68, 0x8b, 0x40,
-	0xae, 0x66, 0x69, 0x12, 0x7b, 0x6d, 0x13, 0x63, 0xfe, 0xa0, 0xb1, 0x27, 0xf8, 0x3b, 0x6c, 0x85,
-	0x24, 0x46, 0xdf, 0xc0, 0xb5, 0x55, 0x3a, 0x8f, 0x59, 0x30, 0xbb, 0xa4, 0xeb, 0x19, 0x89, 0x23,
-	0x91, 0x30, 0x75, 0xb1, 0xf4, 0x5c, 0x73, 0xae, 0xd7, 0xf3, 0xcc, 0x47, 0xd6, 0xfe, 0x90, 0xae,
-	0x4f, 0x4b, 0x2b, 0xde, 0x81, 0xa1, 0x07, 0xf0, 0x7f, 0xc9, 0x22, 0x4e, 0x54, 0x9a, 0xd0, 0xda,
-	0x44, 0xb0, 0x99, 0xa8, 0x32, 0xd7, 0x26, 0xba, 0x8a, 0xa1, 0x5b, 0x70, 0x28, 0x69, 0xc2, 0x48,
-	0x3c, 0xe3, 0xe9, 0x72, 0x4e, 0x13, 0xaf, 0x63, 0xa6, 0xe8, 0xe7, 0x99, 0xdf, 0xb5, 0x86, 0x73,
-	0x83, 0xe3, 0x2d, 0x0d, 0x9d, 0xc3, 0xf5, 0x82, 0x11, 0xb3, 0x15, 0x16, 0x52, 0xae, 0x19, 0xa6,
-	0x89, 0xd7, 0x35, 0xf1, 0x5e, 0x9e, 0xf9, 0xd7, 0x0a, 0x8f, 0x87, 0x74, 0x7d, 0x56, 0xd9, 0xf1,
-	0x4e, 0x54, 0x17, 0xc0, 0x05, 0xe3, 0x11, 0x4d, 0x56, 0x09, 0xe3, 0xca, 0x3b, 0xdc, 0x14, 0xc0,
-	0x1a, 0x8c, 0xeb, 0x0a, 0x7a, 0x04, 0x6f, 0xd5, 0xd4, 0x1a, 0x09, 0xbd, 0xcd, 0x0a, 0x6a, 0x0e,
-	0x1b, 0x1a, 0x76, 0xa2, 0xe8, 0x7d, 0x38, 0x78, 0x46, 0x13, 0x5d, 0x1f, 0xbc, 0x23, 0x73, 0x81,
-	0xcc, 0x11, 0x16, 0x10, 0x2e, 0x05, 0xf4, 0x18, 0x3c, 0x92, 0xaa, 0x0b, 0x1d, 0xb4, 0x7e, 0x79,
-	0xeb, 0x7d, 0xf3, 0xe3, 0xe3, 0x3c, 0xf3, 0xaf, 0x57, 0x3e, 0xdb, 0x9b, 0x7f, 0x05, 0x3e, 0xbc,
-	0x84, 0xfe, 0xcb, 0x77, 0x07, 0x7d, 0x04, 0xc0, 0x85, 0x9a, 0xcd, 0xe9, 0x42, 0x24, 0xf6, 0xea,
-	0x37, 0x6d, 0x17, 0xe6, 0x42, 0x4d, 0x0d, 0x88, 0x37, 0xa2, 0xae, 0x13, 0xda, 0x9b, 0x2c, 0x14,
-	0x4d, 0x8a, 0x0a, 0x60, 0x2e, 0x1f, 0x17, 0xea, 0x54, 0x63, 0xb8, 0x92, 0x86, 0xbf, 0x34, 0xe0,
-	0xe8, 0xa5, 0x1b, 0x81, 0x26, 0xd0, 0xb1, 0x0f, 0x93, 0x7a, 0xa1, 0x31, 0x6d, 0xdc, 0xc2, 0x26,
-	0xe9, 0x6b, 0xb2, 0xa6, 0xcb, 0xb4, 0xe4, 0x64, 0xed, 0x35, 0x36, 0x19, 0x5f, 0x40, 0xb8, 0x14,
-	0x74, 0xdb, 0x94, 0x8a, 0x28, 0x5d, 0x75, 0xb4, 0x93, 0xa9, 0x2c, 0x06, 0xc0, 0x76, 0xd0, 0x8d,
-	0x2a, 0x16, 0x01, 0x89, 0x6d, 0x65, 0x69, 0x96, 0xe5, 0xad, 0xc4, 0x70, 0x25, 0xa1, 0xcf, 0xa1,
-	0x2b, 0x92, 0x88, 0x70, 0xf6, 0x9c, 0x98, 0x76, 0xd5, 0x32, 0xde, 0x26, 0x51, 0xeb, 0x38, 0xde,
-	0xd2, 0xf4, 0x45, 0xa9, 0xeb, 0x24, 0x9e, 0xa5, 0x9c, 0xe9, 0x62, 0xd1, 0x2c, 0x2f, 0xca, 0xb6,
-	0xf9, 0x09, 0x67, 0x0a, 0xef, 0xc0, 0x86, 0x3f, 0x3b, 0x00, 0x4f, 0xd9, 0x6a, 0x45, 0x43, 0xf3,
-	0x5a, 0xa8, 0x37, 0x58, 0xe7, 0xb5, 0x0d, 0x56, 0x7b, 0x5e, 0xd0, 0xe0, 0x52, 0xa6, 0xcb, 0xe2,
-	0x2d, 0x65, 0x3d, 0x0b, 0x0c, 0x57, 0x12, 0xfa, 0x12, 0x7a, 0x89, 0x10, 0x6a, 0x56, 0x3e, 0x4f,
-	0xd6, 0xc5, 0x73, 0xca, 0x14, 0xa0, 0x6d, 0x0b, 0x3e, 0xd4, 0x7a, 0xd5, 0x39, 0xa7, 0xb7, 0x7f,
-	0x7b, 0x71, 0xc3, 0xf9, 0xfd, 0xc5, 0x0d, 0xe7, 0x8f, 0x17, 0x37, 0x9c, 0xa7, 0x37, 0x23, 0xa6,
-	0x2e, 0xd2, 0xf9, 0x38, 0x10, 0xcb, 0x89, 0xa9, 0x85, 0x13, 0x53, 0x0b, 0x27, 0x32, 0xbc, 0x9c,
-	0x3c, 0x3b, 0xb1, 0xaf, 0xc9, 0x3b, 0xb6, 0xf0, 0xef, 0x9b, 0xe1, 0xb3, 0x7f, 0x02, 0x00, 0x00,
-	0xff, 0xff, 0x95, 0x41, 0xf8, 0x87, 0x98, 0x0a, 0x00, 0x00,
+	// 1561 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xcd, 0x72, 0x23, 0x49,
+	0x11, 0x46, 0x92, 0x25, 0x4b, 0x29, 0x59, 0xd6, 0x94, 0x7f, 0xb6, 0x71, 0x2c, 0xd3, 0x0e, 0xf1,
+	0x13, 0x5e, 0x7e, 0x64, 0x30, 0x4c, 0x2c, 0xc4, 0x12, 0x6c, 0x58, 0x3b, 0xec, 0x60, 0xcc, 0x18,
+	0x28, 0xcf, 0x70, 0xd8, 0x4b, 0x47, 0xbb, 0xbb, 0x24, 0x17, 0x6e, 0x55, 0x2b, 0xba, 0xaa, 0xbd,
+	0xa3, 0x3d, 0x71, 0xe1, 0x29, 0x38, 0xf0, 0x02, 0xdc, 0x78, 0x09, 0x8e, 0x3c, 0x41, 0x07, 0xe1,
+	0x63, 0x3f, 0x00, 0x17, 0x2e, 0x44, 0x65, 0x55, 0xb7, 0x5a, 0xb6, 0x66, 0xc6, 0x04, 0x17, 0x75,
+	0xe6, 0x97, 0x99, 0xa5, 0xea, 0x2f, 0x2b, 0xb3, 0xb2, 0xa1, 0x17, 0xc4, 0xb3, 0x59, 0x2c, 0x46,
+	0xf3, 0x24, 0x56, 0x31, 0x21, 0x93, 0x67, 0x23, 0x31, 0xe5, 0xe2, 0xcd, 0xc8, 0x9f, 0x32, 0xa1,
+	0x46, 0x32, 0xbc, 0x39, 0x80, 0x69, 0x3c, 0x8d, 0x8d, 0xfd, 0xc0, 0x9d, 0xc6, 0xf1, 0x34, 0x62,
+	0xc7, 0xa8, 0x5d, 0xa5, 0x93, 0x63, 0xc5, 0x67, 0x4c, 0x2a, 0x7f, 0x36, 0x37, 0x0e, 0xc3, 0xbf,
+	0xd5, 0xa1, 0xfd, 0x92, 0x29, 0x3f, 0xf4, 0x95, 0x4f, 0x5e, 0x40, 0xa7, 0xb4, 0x3b, 0xb5, 0xc3,
+	0xda, 0x51, 0xf7, 0xe4, 0x60, 0x64, 0x56, 0x18, 0x15, 0x2b, 0x8c, 0x5e, 0x15, 0x1e, 0xe3, 0xad,
+	0x3c, 0x73, 0x97, 0x01, 0x74, 0x29, 0x92, 0xef, 0x42, 0x27, 0x88, 0x38, 0x13, 0xca, 0xe3, 0xa1,
+	0x53, 0x3f, 0xac, 0x1d, 0x75, 0x8c, 0x73, 0x09, 0xd2, 0xb6, 0x11, 0xcf, 0x42, 0xf2, 0x03, 0x80,
+	0x19, 0x93, 0xd2, 0x9f, 0x32, 0xed, 0xdc, 0x40, 0xe7, 0x7e, 0x9e, 0xb9, 0x15, 0x94, 0x76, 0xac,
+	0x7c, 0x16, 0x92, 0x5f, 0xc0, 0x20, 0x88, 0xe2, 0x34, 0xf4, 0xfc, 0x20, 0x88, 0x53, 0xf3, 0x0f,
+	0x1b, 0x18, 0xb4, 0x9b, 0x67, 0xee, 0x03, 0x1b, 0xed, 0x23, 0x72, 0x6a, 0x80, 0xb3, 0x90, 0xfc,
+	0x1c, 0xb6, 0x79, 0xc8, 0x66, 0xf3, 0x58, 0x31, 0x11, 0x2c, 0xbc, 0x1b, 0xb6, 0x70, 0x9a, 0x18,
+	0xbe, 0x93, 0x67, 0xee, 0x7d, 0x13, 0xed, 0x57, 0x80, 0x73, 0xb6, 0x18, 0xfe, 0x16, 0x7a, 0xcf,
+	0x79, 0xc2, 0x02, 0x15, 0x27, 0x8b, 0x97, 0xfe, 0x9c, 0x7c, 0x0a, 0xdd, 0xd0, 0xea, 0x9c, 0x49,
+	0xa7, 0x76, 0xd8, 0x38, 0xea, 0x9e, 0x7c, 0x63, 0xf4, 0x30, 0x2b, 0xa3, 0x32, 0x8c, 0x56, 0x23,
+	0x86, 0xff, 0xa9, 0xc3, 0xc6, 0xe7, 0x3c, 0x62, 0xe4, 0x43, 0xd8, 0x10, 0xfe, 0x8c, 0x21, 0xed,
+	0x9d, 0x71, 0x3b, 0xcf, 0x5c, 0xd4, 0x29, 0xfe, 0x12, 0x17, 0x9a, 0x11, 0x17, 0x4c, 0x22, 0x99,
+	0xcd, 0x71, 0x27, 0xcf, 0x5c, 0x03, 0x50, 0xf3, 0x20, 0x9f, 0x40, 0x73, 0xa6, 0xf9, 0x47, 0x02,
+	0xdf, 0x9d, 0x36, 0x0c, 0x46, 0x67, 0x6a, 0x1e, 0xe4, 0x47, 0xd0, 0x9d, 0xb3, 0x64, 0xc6, 0xa5,
+	0xe4, 0xb1, 0x90, 0x96, 0xce, 0xed, 0x3c, 0x73, 0xab, 0x30, 0xad, 0x2a, 0x7a, 0xbb, 0x92, 0x7f,
+	0xc5, 0x90, 0xbb, 0x86, 0xd9, 0xae, 0xd6, 0x29, 0xfe, 0x92, 0x23, 0x68, 0x07, 0xb1, 0x50, 0x4c,
+	0x28, 0xe9, 0xb4, 0x0e, 0x6b, 0x47, 0xbd, 0x71, 0x2f, 0xcf, 0xdc, 0x12, 0xa3, 0xa5, 0x44, 0x3e,
+	0x86, 0x96, 0x1f, 0x28, 0x1e, 0x0b, 0x67, 0xf3, 0xb0, 0x76, 0xd4, 0x3f, 0x71, 0xd7, 0x71, 0xa7,
+	0x09, 0x1a, 0x9d, 0xa2, 0x1b, 0xb5, 0xee, 0xc3, 0xcf, 0xa0, 0x65, 0x10, 0xd2, 0x81, 0x66, 0x2a,
+	0x24, 0x53, 0x83, 0xaf, 0x91, 0x2d, 0xe8, 0xa4, 0x22, 0xb8, 0xf6, 0xc5, 0x94, 0x85, 0x83, 0x1a,
+	0xd9, 0x84, 0x86, 0x1f, 0x86, 0x83, 0x3a, 0x01, 0x68, 0xa5, 0xf3, 0xd0, 0x57, 0x6c, 0xd0, 0xd0,
+	0x72, 0xc8, 0x22, 0xa6, 0xd8, 0x60, 0x63, 0xf8, 0xef, 0x1a, 0x74, 0xca, 0xc4, 0x10, 0x52, 0x4d,
+	0x81, 0x25, 0xfe, 0x87, 0x05, 0xaf, 0xf5, 0xf7, 0xf1, 0x5a, 0x90, 0x79, 0xb8, 0x4a, 0x26, 0x1e,
+	0xe8, 0x55, 0xee, 0x88, 0xe5, 0x4e, 0xf3, 0xdc, 0xb0, 0x8c, 0x8d, 0xa0, 0x39, 0xe1, 0x11, 0x93,
+	0x4e, 0x13, 0x8f, 0x90, 0xf3, 0x36, 0x1a, 0xa8, 0x71, 0x23, 0x2e, 0x74, 0x55, 0xac, 0xfc, 0xc8,
+	0x33, 0x51, 0x9a, 0xe4, 0x26, 0x05, 0x84, 0x3e, 0x47, 0x87, 0x0f, 0xa1, 0xa3, 0x92, 0x54, 0x04,
+	0xbe, 0x62, 0x21, 0x72, 0xdb, 0xa6, 0x4b, 0x60, 0xf8, 0xd7, 0x1a, 0x6c, 0x5f, 0xca, 0xe8, 0x33,
+	0x96, 0x28, 0x3e, 0xe1, 0x1a, 0x93, 0xe4, 0x53, 0xe8, 0x48, 0x19, 0x79, 0x01, 0x4b, 0x54, 0x71,
+	0x92, 0x87, 0xeb, 0xb6, 0xb1, 0x1a, 0x47, 0xdb, 0xd2, 0xe8, 0x92, 0x9c, 0x03, 0x24, 0x6c, 0xc2,
+	0x12, 0x26, 0x02, 0x3c, 0xa9, 0x7a, 0x85, 0xef, 0x3d, 0x62, 0x85, 0x22, 0x86, 0x56, 0xc2, 0x87,
+	0x7f, 0xaa, 0xc1, 0x07, 0x6f, 0xf1, 0xd3, 0xe7, 0x75, 0xc2, 0xc5, 0x94, 0x25, 0xf3, 0x84, 0x0b,
+	0x65, 0x4b, 0x06, 0xcf, 0x6b, 0x05, 0xa6, 0x55, 0x85, 0x9c, 0x40, 0x4f, 0xb2, 0xe4, 0x96, 0x25,
+	0x9e, 0x4e, 0xab, 0xd9, 0x9d, 0x8d, 0x31, 0xf8, 0x85, 0x86, 0x69, 0x55, 0x19, 0xfe, 0xb9, 0x0b,
+	0xfd, 0xd5, 0x2d, 0x90, 0x8f, 0xa0, 0xa3, 0x09, 0xf7, 0x2a, 0xa5, 0x8a, 0x27, 0x5b, 0x83, 0x3a,
+	0x88, 0x96, 0x52, 0x59, 0x21, 0xf5, 0xb5, 0x15, 0xf2, 0x7f, 0xd5, 0xeb, 0x05, 0xb4, 0x6f, 0xfd,
+	0x88, 0x87, 0x5c, 0x2d, 0xf0, 0x10, 0x75, 0x4f, 0xbe, 0xb5, 0x8e, 0xe6, 0xca, 0xc6, 0x9f, 0xeb,
+	0x0c, 0x9b, 0xad, 0x16, 0x91, 0xb4, 0x94, 0xc8, 0x0b, 0x68, 0x71, 0x29, 0x53, 0x96, 0x60, 0x39,
+	0x77, 0x4f, 0xbe, 0xf9, 0x9e, 0xd5, 0xf4, 0xfb, 0x8d, 0x21, 0xcf, 0x5c, 0x1b, 0x46, 0xed, 0x93,
+	0xfc, 0x1a, 0x36, 0x65, 0x7a, 0xf5, 0x47, 0x16, 0x28, 0x3c, 0x91, 0x8f, 0x5c, 0xa9, 0x9b, 0x67,
+	0x6e, 0x11, 0x47, 0x0b, 0x81, 0xfc, 0x14, 0xfa, 0x5a, 0xf4, 0xfc, 0x48, 0xd9, 0x9c, 0x6d, 0x62,
+	0xce, 0x48, 0x9e, 0xb9, 0x7d, 0xeb, 0x74, 0x1a, 0x29, 0x64, 0xbd, 0xa7, 0x75, 0xab, 0x48, 0xf2,
+	0x1d, 0x68, 0xc7, 0x81, 0x9c, 0x7b, 0x69, 0x12, 0x39, 0x6d, 0x8c, 0xc1, 0x7f, 0xd0, 0xd8, 0x6b,
+	0xfa, 0x1b, 0x6a, 0x84, 0x24, 0x22, 0xbf, 0x82, 0xdd, 0x79, 0x7a, 0x15, 0xf1, 0x40, 0xb7, 0x7a,
+	0xcf, 0x8f, 0xa6, 0x71, 0xc2, 0xd5, 0xf5, 0xcc, 0xe9, 0x60, 0x5e, 0xf7, 0xf3, 0xcc, 0x25, 0xc6,
+	0x7e, 0xce, 0x16, 0xa7, 0x85, 0x95, 0xae, 0xc1, 0xc8, 0x0b, 0xd8, 0x91, 0x7c, 0x2a, 0x7c, 0x95,
+	0x26, 0xac, 0xb2, 0x10, 0x2c, 0x17, 0x2a, 0xcd, 0x95, 0x85, 0x1e, 0x62, 0xe4, 0x19, 0x6c, 0x49,
+	0x96, 0x70, 0x3f, 0xf2, 0x44, 0x3a, 0xbb, 0x62, 0x89, 0xd3, 0xc5, 0x25, 0x06, 0x79, 0xe6, 0xf6,
+	0x8c, 0xe1, 0x02, 0x71, 0xba, 0xa2, 0x91, 0x0b, 0xd8, 0xb7, 0x8c, 0xe0, 0xab, 0xf0, 0x90, 0x09,
+	0xcd, 0x30, 0x4b, 0x9c, 0x1e, 0xc6, 0x3b, 0x79, 0xe6, 0xee, 0x5a, 0x8f, 0x73, 0xb6, 0x38, 0x2b,
+	0xed, 0x74, 0x2d, 0x7a, 0xbf, 0xc0, 0xb6, 0x1e, 0x51, 0x60, 0x2f, 0x61, 0xaf, 0xa2, 0x56, 0x48,
+	0xe8, 0x2f, 0x77, 0x50, 0x71, 0x58, 0xd2, 0xb0, 0x16, 0x25, 0xdf, 0x86, 0xcd, 0x5b, 0x96, 0xe8,
+	0x7e, 0xe9, 0x6c, 0x63, 0x01, 0x61, 0x0a, 0x2d, 0x44, 0x0b, 0x81, 0xbc, 0x02, 0xc7, 0x4f, 0xd5,
+	0xb5, 0x0e, 0x5a, 0xdc, 0x7f, 0xf5, 0x01, 0xfe, 0xf1, 0x41, 0x9e, 0xb9, 0xfb, 0xa5, 0xcf, 0xea,
+	0xcb, 0xbf, 0x05, 0x27, 0xcf, 0xa0, 0xa1, 0xe7, 0x82, 0x27, 0xb6, 0x34, 0xd7, 0x77, 0xb0, 0x73,
+	0xb6, 0x18, 0x6f, 0xe6, 0x99, 0xab, 0x5d, 0xa9, 0xfe, 0x21, 0xbf, 0x87, 0x0f, 0x82, 0x6b, 0x9f,
+	0x0b, 0x0f, 0x0b, 0xcb, 0xd7, 0x97, 0x93, 0x27, 0x95, 0xaf, 0x52, 0xe9, 0x10, 0xdc, 0xcb, 0xd7,
+	0xf3, 0xcc, 0xdd, 0x43, 0x97, 0x3f, 0x94, 0x1e, 0x97, 0xe8, 0x40, 0xd7, 0xc3, 0xe4, 0x14, 0x9e,
+	0x24, 0xec, 0x36, 0x0e, 0x56, 0x16, 0xdb, 0x59, 0x8e, 0x3b, 0x4b, 0xa3, 0x5d, 0xe7, 0x01, 0xa2,
+	0x8f, 0x14, 0x56, 0x83, 0x54, 0xfe, 0x3c, 0xe2, 0x62, 0xea, 0xec, 0xea, 0xcb, 0xc0, 0x1c, 0x29,
+	0x6d, 0xb8, 0xb4, 0x38, 0x5d, 0xd1, 0xc8, 0x18, 0x08, 0x7b, 0xa3, 0x98, 0x08, 0x59, 0x88, 0xc4,
+	0xa6, 0x7a, 0xfe, 0x72, 0xf6, 0xb0, 0x9c, 0xf0, 0xaf, 0x0b, 0xeb, 0x39, 0x5b, 0xbc, 0xd6, 0x36,
+	0xfa, 0x00, 0x21, 0xbf, 0x84, 0x9d, 0x20, 0xd6, 0xa9, 0xf2, 0x56, 0x7a, 0xef, 0x3e, 0x6e, 0x60,
+	0x2f, 0xcf, 0xdc, 0x27, 0xc6, 0x7c, 0x59, 0xe9, 0xc0, 0x0f, 0xa1, 0xe1, 0x5d, 0x1d, 0x5a, 0x86,
+	0xf0, 0xff, 0xa5, 0xff, 0x0e, 0xa1, 0xc5, 0xde, 0x70, 0xa9, 0xcc, 0xcc, 0xd4, 0x36, 0xfd, 0xca,
+	0x20, 0xd4, 0x3e, 0xef, 0x0f, 0x3e, 0x8d, 0x47, 0x0c, 0x3e, 0x2e, 0x34, 0xe3, 0x2f, 0x05, 0x4b,
+	0xec, 0x94, 0x84, 0xcd, 0x19, 0x01, 0x6a, 0x1e, 0xba, 0x17, 0xcc, 0x7c, 0x15, 0x5c, 0x33, 0x89,
+	0x57, 0xa9, 0x6d, 0x74, 0xd8, 0x59, 0xdb, 0xa6, 0x17, 0x58, 0x73, 0xf5, 0x6a, 0x5b, 0x83, 0xe9,
+	0x36, 0xa6, 0x89, 0x57, 0x8b, 0x39, 0xc3, 0x6e, 0x6a, 0xdb, 0xd8, 0x0d, 0x5b, 0xbc, 0x5a, 0xcc,
+	0x19, 0x2d, 0x04, 0xf2, 0x1c, 0xc8, 0xdc, 0x97, 0xf2, 0xcb, 0x38, 0x09, 0x3d, 0x7d, 0x7d, 0xb0,
+	0xa0, 0xbc, 0xf2, 0x0d, 0xc9, 0x85, 0xf5, 0x77, 0x85, 0x91, 0x3e, 0x84, 0x86, 0x37, 0x30, 0xb8,
+	0x7f, 0x5f, 0x90, 0xef, 0x03, 0x88, 0x58, 0x79, 0x57, 0x6c, 0x12, 0x27, 0x86, 0xee, 0x86, 0x99,
+	0xe3, 0x45, 0xac, 0xc6, 0x08, 0xd2, 0xa5, 0xa8, 0x73, 0xa3, 0xbd, 0xfd, 0x89, 0x62, 0x89, 0xbd,
+	0xf5, 0x30, 0x37, 0x22, 0x56, 0xa7, 0x1a, 0xa3, 0xa5, 0x34, 0xfc, 0x7b, 0x1d, 0xb6, 0xef, 0xdd,
+	0x02, 0xe4, 0x18, 0xba, 0xe6, 0xd3, 0xa6, 0x9a, 0x5c, 0xfc, 0x10, 0x30, 0x30, 0xa6, 0xb7, 0x22,
+	0xeb, 0x16, 0x81, 0x43, 0x7d, 0xb2, 0xb0, 0xb7, 0x39, 0xd2, 0x63, 0x21, 0x5a, 0x08, 0x3a, 0x61,
+	0xba, 0x6e, 0xf4, 0x4d, 0xdb, 0x28, 0x12, 0x86, 0x00, 0x35, 0x0f, 0x3d, 0xac, 0x46, 0x71, 0xe0,
+	0x47, 0xe6, 0x36, 0x6d, 0x14, 0x47, 0xaa, 0xc0, 0x68, 0x29, 0x91, 0x9f, 0x40, 0x2f, 0x4e, 0xa6,
+	0xbe, 0xe0, 0x5f, 0x61, 0x81, 0xe1, 0xac, 0x66, 0x9b, 0x73, 0x15, 0xa7, 0x2b, 0x9a, 0x3e, 0x10,
+	0x55, 0xdd, 0x8f, 0xbc, 0x54, 0x70, 0x7d, 0x41, 0x36, 0x8a, 0xcb, 0x61, 0xd5, 0xfc, 0x5a, 0x70,
+	0x45, 0xd7, 0x60, 0xc3, 0xbf, 0xd4, 0x00, 0xbe, 0xe0, 0xf3, 0x39, 0x0b, 0xf1, 0x8b, 0xa1, 0x3a,
+	0x64, 0xd7, 0xde, 0x39, 0x64, 0x6b, 0xcf, 0x6b, 0x16, 0xdc, 0xc8, 0x74, 0x66, 0xbf, 0xc6, 0x8c,
+	0xa7, 0xc5, 0x68, 0x29, 0x91, 0x9f, 0x41, 0x3f, 0x89, 0x63, 0xe5, 0x15, 0x9f, 0x28, 0x0b, 0x5b,
+	0x13, 0x78, 0xe9, 0xae, 0x5a, 0xe8, 0x96, 0xd6, 0xcb, 0xe9, 0x79, 0xfc, 0xf1, 0x3f, 0xee, 0x9e,
+	0xd6, 0xfe, 0x79, 0xf7, 0xb4, 0xf6, 0xaf, 0xbb, 0xa7, 0xb5, 0x2f, 0x3e, 0x9a, 0x72, 0x75, 0x9d,
+	0x5e, 0x8d, 0x82, 0x78, 0x76, 0x8c, 0xcd, 0xf3, 0x18, 0x9b, 0xe7, 0xb1, 0x0c, 0x6f, 0x8e, 0x6f,
+	0x4f, 0xcc, 0xf7, 0xe8, 0x27, 0x66, 0xd8, 0x69, 0xe1, 0xe3, 0xc7, 0xff, 0x0d, 0x00, 0x00, 0xff,
+	0xff, 0x11, 0xa6, 0x8f, 0x46, 0xda, 0x0e, 0x00, 0x00,
 }
 
 func (m *Metadata) Marshal() (dAtA []byte, err error) {
@@ -946,6 +1228,13 @@ func (m *Metadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.IdempotencyKey) > 0 {
+		i -= len(m.IdempotencyKey)
+		copy(dAtA[i:], m.IdempotencyKey)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.IdempotencyKey)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.CloudAccountId) > 0 {
 		i -= len(m.CloudAccountId)
 		copy(dAtA[i:], m.CloudAccountId)
@@ -1122,6 +1411,21 @@ func (m *Directory) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Truncated {
+		i--
+		if m.Truncated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.TotalFiles != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.TotalFiles))
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.Files) > 0 {
 		for iNdEx := len(m.Files) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1194,6 +1498,20 @@ func (m *SslCertificates) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.References) > 0 {
+		for iNdEx := len(m.References) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.References[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCommon(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
 	if len(m.SslCerts) > 0 {
 		for iNdEx := len(m.SslCerts) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1211,6 +1529,49 @@ func (m *SslCertificates) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SslCertificateReference) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SslCertificateReference) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SslCertificateReference) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ServerNames) > 0 {
+		for iNdEx := len(m.ServerNames) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ServerNames[iNdEx])
+			copy(dAtA[i:], m.ServerNames[iNdEx])
+			i = encodeVarintCommon(dAtA, i, uint64(len(m.ServerNames[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Fingerprint) > 0 {
+		i -= len(m.Fingerprint)
+		copy(dAtA[i:], m.Fingerprint)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Fingerprint)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SslCertificate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1235,6 +1596,73 @@ func (m *SslCertificate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.CoversServerNames {
+		i--
+		if m.CoversServerNames {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb0
+	}
+	if len(m.ExtendedKeyUsage) > 0 {
+		for iNdEx := len(m.ExtendedKeyUsage) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ExtendedKeyUsage[iNdEx])
+			copy(dAtA[i:], m.ExtendedKeyUsage[iNdEx])
+			i = encodeVarintCommon(dAtA, i, uint64(len(m.ExtendedKeyUsage[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xaa
+		}
+	}
+	if m.OcspStapling {
+		i--
+		if m.OcspStapling {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
+	if len(m.RevocationStatus) > 0 {
+		i -= len(m.RevocationStatus)
+		copy(dAtA[i:], m.RevocationStatus)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.RevocationStatus)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	if len(m.ChainValidationStatus) > 0 {
+		i -= len(m.ChainValidationStatus)
+		copy(dAtA[i:], m.ChainValidationStatus)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.ChainValidationStatus)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	if m.Key != nil {
+		{
+			size, err := m.Key.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCommon(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
 	if len(m.AuthorityKeyIdentifier) > 0 {
 		i -= len(m.AuthorityKeyIdentifier)
 		copy(dAtA[i:], m.AuthorityKeyIdentifier)
@@ -1372,7 +1800,7 @@ func (m *SslCertificate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *CertificateDates) Marshal() (dAtA []byte, err error) {
+func (m *SslKey) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1382,12 +1810,12 @@ func (m *CertificateDates) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CertificateDates) MarshalTo(dAtA []byte) (int, error) {
+func (m *SslKey) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CertificateDates) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SslKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -1396,15 +1824,100 @@ func (m *CertificateDates) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.NotAfter != 0 {
-		i = encodeVarintCommon(dAtA, i, uint64(m.NotAfter))
+	if m.PasswordProtected {
 		i--
-		dAtA[i] = 0x10
+		if m.PasswordProtected {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
 	}
-	if m.NotBefore != 0 {
-		i = encodeVarintCommon(dAtA, i, uint64(m.NotBefore))
+	if len(m.KeyType) > 0 {
+		i -= len(m.KeyType)
+		copy(dAtA[i:], m.KeyType)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.KeyType)))
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0x32
+	}
+	if m.MatchesCertificate {
+		i--
+		if m.MatchesCertificate {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Permissions) > 0 {
+		i -= len(m.Permissions)
+		copy(dAtA[i:], m.Permissions)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Permissions)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Exists {
+		i--
+		if m.Exists {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.FileName) > 0 {
+		i -= len(m.FileName)
+		copy(dAtA[i:], m.FileName)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.FileName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CertificateDates) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CertificateDates) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CertificateDates) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.NotAfter != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.NotAfter))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.NotBefore != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.NotBefore))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
@@ -1569,6 +2082,10 @@ func (m *Metadata) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCommon(uint64(l))
 	}
+	l = len(m.IdempotencyKey)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1657,6 +2174,12 @@ func (m *Directory) Size() (n int) {
 			n += 1 + l + sovCommon(uint64(l))
 		}
 	}
+	if m.TotalFiles != 0 {
+		n += 1 + sovCommon(uint64(m.TotalFiles))
+	}
+	if m.Truncated {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1675,6 +2198,34 @@ func (m *SslCertificates) Size() (n int) {
 			n += 1 + l + sovCommon(uint64(l))
 		}
 	}
+	if len(m.References) > 0 {
+		for _, e := range m.References {
+			l = e.Size()
+			n += 1 + l + sovCommon(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SslCertificateReference) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Fingerprint)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	if len(m.ServerNames) > 0 {
+		for _, s := range m.ServerNames {
+			l = len(s)
+			n += 1 + l + sovCommon(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1753,6 +2304,67 @@ func (m *SslCertificate) Size() (n int) {
 	if l > 0 {
 		n += 2 + l + sovCommon(uint64(l))
 	}
+	if m.Key != nil {
+		l = m.Key.Size()
+		n += 2 + l + sovCommon(uint64(l))
+	}
+	l = len(m.ChainValidationStatus)
+	if l > 0 {
+		n += 2 + l + sovCommon(uint64(l))
+	}
+	l = len(m.RevocationStatus)
+	if l > 0 {
+		n += 2 + l + sovCommon(uint64(l))
+	}
+	if m.OcspStapling {
+		n += 3
+	}
+	if len(m.ExtendedKeyUsage) > 0 {
+		for _, s := range m.ExtendedKeyUsage {
+			l = len(s)
+			n += 2 + l + sovCommon(uint64(l))
+		}
+	}
+	if m.CoversServerNames {
+		n += 3
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SslKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.FileName)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	if m.Exists {
+		n += 2
+	}
+	l = len(m.Permissions)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	if m.MatchesCertificate {
+		n += 2
+	}
+	l = len(m.KeyType)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	if m.PasswordProtected {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2014,6 +2626,38 @@ func (m *Metadata) Unmarshal(dAtA []byte) error {
 			}
 			m.CloudAccountId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdempotencyKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IdempotencyKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommon(dAtA[iNdEx:])
@@ -2545,6 +3189,45 @@ func (m *Directory) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalFiles", wireType)
+			}
+			m.TotalFiles = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalFiles |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Truncated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Truncated = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommon(dAtA[iNdEx:])
@@ -2630,6 +3313,40 @@ func (m *SslCertificates) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field References", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.References = append(m.References, &SslCertificateReference{})
+			if err := m.References[len(m.References)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommon(dAtA[iNdEx:])
@@ -2652,7 +3369,7 @@ func (m *SslCertificates) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SslCertificate) Unmarshal(dAtA []byte) error {
+func (m *SslCertificateReference) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2675,15 +3392,15 @@ func (m *SslCertificate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SslCertificate: wiretype end group for non-group")
+			return fmt.Errorf("proto: SslCertificateReference: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SslCertificate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SslCertificateReference: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FileName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Fingerprint", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2711,13 +3428,13 @@ func (m *SslCertificate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.FileName = string(dAtA[iNdEx:postIndex])
+			m.Fingerprint = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerNames", wireType)
 			}
-			m.Size_ = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCommon
@@ -2727,25 +3444,140 @@ func (m *SslCertificate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Size_ |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Mtime", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCommon
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServerNames = append(m.ServerNames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SslCertificate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SslCertificate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SslCertificate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FileName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mtime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
 				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
@@ -3183,6 +4015,417 @@ func (m *SslCertificate) Unmarshal(dAtA []byte) error {
 			}
 			m.AuthorityKeyIdentifier = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Key == nil {
+				m.Key = &SslKey{}
+			}
+			if err := m.Key.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainValidationStatus", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainValidationStatus = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevocationStatus", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RevocationStatus = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OcspStapling", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.OcspStapling = bool(v != 0)
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtendedKeyUsage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExtendedKeyUsage = append(m.ExtendedKeyUsage, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CoversServerNames", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CoversServerNames = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SslKey) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SslKey: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SslKey: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FileName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exists", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Exists = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permissions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Permissions = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MatchesCertificate", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MatchesCertificate = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PasswordProtected", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PasswordProtected = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommon(dAtA[iNdEx:])
@@ -28,6 +28,7 @@ type DataplaneSoftwareDetails struct {
 	// Types that are valid to be assigned to Data:
 	//	*DataplaneSoftwareDetails_AppProtectWafDetails
 	//	*DataplaneSoftwareDetails_NginxDetails
+	//	*DataplaneSoftwareDetails_UnitDetails
 	Data                 isDataplaneSoftwareDetails_Data `protobuf_oneof:"data"`
 	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
 	XXX_unrecognized     []byte                          `json:"-"`
@@ -79,9 +80,13 @@ type DataplaneSoftwareDetails_AppProtectWafDetails struct {
 type DataplaneSoftwareDetails_NginxDetails struct {
 	NginxDetails *NginxDetails `protobuf:"bytes,2,opt,name=nginx_details,json=nginxDetails,proto3,oneof" json:"nginx_details"`
 }
+type DataplaneSoftwareDetails_UnitDetails struct {
+	UnitDetails *UnitDetails `protobuf:"bytes,3,opt,name=unit_details,json=unitDetails,proto3,oneof" json:"unit_details"`
+}
 
 func (*DataplaneSoftwareDetails_AppProtectWafDetails) isDataplaneSoftwareDetails_Data() {}
 func (*DataplaneSoftwareDetails_NginxDetails) isDataplaneSoftwareDetails_Data()         {}
+func (*DataplaneSoftwareDetails_UnitDetails) isDataplaneSoftwareDetails_Data()          {}
 
 func (m *DataplaneSoftwareDetails) GetData() isDataplaneSoftwareDetails_Data {
 	if m != nil {
@@ -104,11 +109,19 @@ func (m *DataplaneSoftwareDetails) GetNginxDetails() *NginxDetails {
 	return nil
 }
 
+func (m *DataplaneSoftwareDetails) GetUnitDetails() *UnitDetails {
+	if x, ok := m.GetData().(*DataplaneSoftwareDetails_UnitDetails); ok {
+		return x.UnitDetails
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*DataplaneSoftwareDetails) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*DataplaneSoftwareDetails_AppProtectWafDetails)(nil),
 		(*DataplaneSoftwareDetails_NginxDetails)(nil),
+		(*DataplaneSoftwareDetails_UnitDetails)(nil),
 	}
 }
 
@@ -119,24 +132,26 @@ func init() {
 func init() { proto.RegisterFile("dp_software_details.proto", fileDescriptor_c38a59b96dc90da7) }
 
 var fileDescriptor_c38a59b96dc90da7 = []byte{
-	// 262 bytes of a gzipped FileDescriptorProto
+	// 303 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x4c, 0x29, 0x88, 0x2f,
 	0xce, 0x4f, 0x2b, 0x29, 0x4f, 0x2c, 0x4a, 0x8d, 0x4f, 0x49, 0x2d, 0x49, 0xcc, 0xcc, 0x29, 0xd6,
 	0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x4a, 0x33, 0xd5, 0xcb, 0x4b, 0xcf, 0xcc, 0xab, 0xd0,
 	0x4b, 0x4c, 0x4f, 0xcd, 0x2b, 0xd1, 0x2b, 0x4e, 0xc9, 0x96, 0xe2, 0x4a, 0xcf, 0x4f, 0xcf, 0x87,
-	0xc8, 0x4b, 0x71, 0xe6, 0x25, 0x16, 0x40, 0x99, 0xdc, 0x10, 0x75, 0x60, 0x8e, 0xd2, 0x3f, 0x46,
-	0x2e, 0x09, 0x97, 0xc4, 0x92, 0xc4, 0x82, 0x9c, 0xc4, 0xbc, 0xd4, 0x60, 0xa8, 0xd9, 0x2e, 0x10,
-	0xa3, 0x85, 0xea, 0xb8, 0xc4, 0x13, 0x0b, 0x0a, 0xe2, 0x41, 0x2a, 0x53, 0x93, 0x4b, 0xe2, 0xcb,
-	0x13, 0xd3, 0x60, 0xb6, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x1b, 0x69, 0xe8, 0x61, 0x5a, 0xab,
-	0xe7, 0x58, 0x50, 0x10, 0x00, 0xd1, 0x11, 0xee, 0xe8, 0x06, 0x35, 0xca, 0x49, 0xfa, 0xd5, 0x3d,
-	0x79, 0x5c, 0x86, 0x79, 0x30, 0x04, 0x89, 0x24, 0x22, 0x34, 0x25, 0xa6, 0xc1, 0xec, 0x8f, 0xe2,
-	0xe2, 0x05, 0x1b, 0x0e, 0xb7, 0x95, 0x09, 0x6c, 0xab, 0x02, 0x36, 0x5b, 0xfd, 0x40, 0x7c, 0x98,
-	0x6d, 0x82, 0xaf, 0xee, 0xc9, 0xa3, 0x6a, 0xf5, 0x60, 0x08, 0xe2, 0xc9, 0x43, 0x56, 0xc2, 0xc6,
-	0xc5, 0x92, 0x92, 0x58, 0x92, 0xe8, 0x64, 0x7e, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72, 0x8c,
-	0x0f, 0x1e, 0xc9, 0x31, 0x46, 0x69, 0xa6, 0x67, 0x96, 0x64, 0x94, 0x26, 0xe9, 0x25, 0xe7, 0xe7,
-	0xea, 0x83, 0x95, 0xeb, 0x83, 0x6d, 0xd0, 0x2f, 0x4e, 0xc9, 0xd6, 0x2f, 0x33, 0xd2, 0x07, 0x87,
-	0x99, 0x35, 0x98, 0x4c, 0x62, 0x03, 0x53, 0xc6, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff, 0xe0, 0xcc,
-	0xb1, 0x3e, 0x95, 0x01, 0x00, 0x00,
+	0xc8, 0x4b, 0x71, 0xe6, 0x25, 0x16, 0x40, 0x99, 0xdc, 0x10, 0x75, 0x10, 0x0e, 0x57, 0x69, 0x5e,
+	0x66, 0x09, 0x84, 0xad, 0x74, 0x8d, 0x89, 0x4b, 0xc2, 0x25, 0xb1, 0x24, 0xb1, 0x20, 0x27, 0x31,
+	0x2f, 0x35, 0x18, 0x6a, 0x8f, 0x0b, 0xc4, 0x1a, 0xa1, 0x3a, 0x2e, 0xf1, 0xc4, 0x82, 0x82, 0x78,
+	0x90, 0xca, 0xd4, 0xe4, 0x92, 0xf8, 0xf2, 0xc4, 0x34, 0x98, 0x0b, 0x24, 0x18, 0x15, 0x18, 0x35,
+	0xb8, 0x8d, 0x34, 0xf4, 0x30, 0x9d, 0xa0, 0xe7, 0x58, 0x50, 0x10, 0x00, 0xd1, 0x11, 0xee, 0xe8,
+	0x06, 0x35, 0xca, 0x49, 0xfa, 0xd5, 0x3d, 0x79, 0x5c, 0x86, 0x79, 0x30, 0x04, 0x89, 0x24, 0x22,
+	0x34, 0x25, 0xa6, 0xc1, 0xec, 0x8f, 0xe2, 0xe2, 0x05, 0x1b, 0x0e, 0xb7, 0x95, 0x09, 0x6c, 0xab,
+	0x02, 0x36, 0x5b, 0xfd, 0x40, 0x7c, 0x98, 0x6d, 0x82, 0xaf, 0xee, 0xc9, 0xa3, 0x6a, 0xf5, 0x60,
+	0x08, 0xe2, 0xc9, 0x43, 0x52, 0x22, 0x14, 0xc6, 0xc5, 0x03, 0x0a, 0x06, 0xb8, 0xd1, 0xcc, 0x60,
+	0xa3, 0xe5, 0xb1, 0x19, 0x1d, 0x9a, 0x97, 0x59, 0x02, 0x33, 0x59, 0xe0, 0xd5, 0x3d, 0x79, 0x14,
+	0x8d, 0x1e, 0x0c, 0x41, 0xdc, 0xa5, 0x48, 0x0a, 0xd8, 0xb8, 0x58, 0x52, 0x12, 0x4b, 0x12, 0x9d,
+	0xcc, 0x4f, 0x3c, 0x92, 0x63, 0xbc, 0xf0, 0x48, 0x8e, 0xf1, 0xc1, 0x23, 0x39, 0xc6, 0x28, 0xcd,
+	0xf4, 0xcc, 0x92, 0x8c, 0xd2, 0x24, 0xbd, 0xe4, 0xfc, 0x5c, 0x7d, 0xb0, 0xf1, 0xfa, 0x60, 0xe3,
+	0xf5, 0x8b, 0x53, 0xb2, 0xf5, 0xcb, 0x8c, 0xf4, 0xc1, 0x71, 0x61, 0x0d, 0x26, 0x93, 0xd8, 0xc0,
+	0x94, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0xab, 0x7f, 0xea, 0x68, 0xf9, 0x01, 0x00, 0x00,
 }
 
 func (m *DataplaneSoftwareDetails) Marshal() (dAtA []byte, err error) {
@@ -217,6 +232,27 @@ func (m *DataplaneSoftwareDetails_NginxDetails) MarshalToSizedBuffer(dAtA []byte
 	}
 	return len(dAtA) - i, nil
 }
+func (m *DataplaneSoftwareDetails_UnitDetails) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataplaneSoftwareDetails_UnitDetails) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.UnitDetails != nil {
+		{
+			size, err := m.UnitDetails.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDpSoftwareDetails(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintDpSoftwareDetails(dAtA []byte, offset int, v uint64) int {
 	offset -= sovDpSoftwareDetails(v)
 	base := offset
@@ -267,6 +303,18 @@ func (m *DataplaneSoftwareDetails_NginxDetails) Size() (n int) {
 	}
 	return n
 }
+func (m *DataplaneSoftwareDetails_UnitDetails) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.UnitDetails != nil {
+		l = m.UnitDetails.Size()
+		n += 1 + l + sovDpSoftwareDetails(uint64(l))
+	}
+	return n
+}
 
 func sovDpSoftwareDetails(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -373,6 +421,41 @@ func (m *DataplaneSoftwareDetails) Unmarshal(dAtA []byte) error {
 			}
 			m.Data = &DataplaneSoftwareDetails_NginxDetails{v}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnitDetails", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpSoftwareDetails
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDpSoftwareDetails
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDpSoftwareDetails
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &UnitDetails{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &DataplaneSoftwareDetails_UnitDetails{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDpSoftwareDetails(dAtA[iNdEx:])
@@ -51,10 +51,18 @@ type HostInfo struct {
 	// List of tags
 	Tags []string `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags"`
 	// List of directories that the NGINX Agent is allowed to access on the host
-	AgentAccessibleDirs  string   `protobuf:"bytes,13,opt,name=agent_accessible_dirs,json=agentAccessibleDirs,proto3" json:"agent_accessible_dirs"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	AgentAccessibleDirs string `protobuf:"bytes,13,opt,name=agent_accessible_dirs,json=agentAccessibleDirs,proto3" json:"agent_accessible_dirs"`
+	// Kubernetes pod metadata, set when the host is a pod
+	Kubernetes *KubernetesMetadata `protobuf:"bytes,14,opt,name=kubernetes,proto3" json:"kubernetes"`
+	// Cloud instance metadata, set when the host runs on a known cloud provider
+	Cloud *CloudMetadata `protobuf:"bytes,15,opt,name=cloud,proto3" json:"cloud"`
+	// Virtualization the host runs under (e.g. kvm, container), empty on bare metal
+	Virtualization string `protobuf:"bytes,16,opt,name=virtualization,proto3" json:"virtualization"`
+	// Kernel tunables relevant to nginx capacity (e.g. net.core.somaxconn)
+	Sysctls              map[string]string `protobuf:"bytes,17,rep,name=sysctls,proto3" json:"sysctls" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *HostInfo) Reset()         { *m = HostInfo{} }
@@ -181,6 +189,187 @@ func (m *HostInfo) GetAgentAccessibleDirs() string {
 	return ""
 }
 
+func (m *HostInfo) GetKubernetes() *KubernetesMetadata {
+	if m != nil {
+		return m.Kubernetes
+	}
+	return nil
+}
+
+func (m *HostInfo) GetCloud() *CloudMetadata {
+	if m != nil {
+		return m.Cloud
+	}
+	return nil
+}
+
+func (m *HostInfo) GetVirtualization() string {
+	if m != nil {
+		return m.Virtualization
+	}
+	return ""
+}
+
+func (m *HostInfo) GetSysctls() map[string]string {
+	if m != nil {
+		return m.Sysctls
+	}
+	return nil
+}
+
+// Represents the cloud instance the host runs on, read from the provider's
+// instance metadata service
+type CloudMetadata struct {
+	// Cloud provider name (aws, gcp or azure)
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider"`
+	// Instance type or machine size
+	InstanceType string `protobuf:"bytes,2,opt,name=instance_type,json=instanceType,proto3" json:"instance_type"`
+	// Provider assigned instance ID
+	InstanceId string `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id"`
+	// Region or location the instance runs in
+	Region               string   `protobuf:"bytes,4,opt,name=region,proto3" json:"region"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloudMetadata) Reset()         { *m = CloudMetadata{} }
+func (m *CloudMetadata) String() string { return proto.CompactTextString(m) }
+func (*CloudMetadata) ProtoMessage()    {}
+func (*CloudMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_85e40b83b4d50a8d, []int{1}
+}
+func (m *CloudMetadata) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CloudMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CloudMetadata.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CloudMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloudMetadata.Merge(m, src)
+}
+func (m *CloudMetadata) XXX_Size() int {
+	return m.Size()
+}
+func (m *CloudMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloudMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloudMetadata proto.InternalMessageInfo
+
+func (m *CloudMetadata) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
+func (m *CloudMetadata) GetInstanceType() string {
+	if m != nil {
+		return m.InstanceType
+	}
+	return ""
+}
+
+func (m *CloudMetadata) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+func (m *CloudMetadata) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+// Represents the Kubernetes pod the NGINX Agent runs in, read from the downward API
+type KubernetesMetadata struct {
+	// Name of the pod
+	PodName string `protobuf:"bytes,1,opt,name=pod_name,json=podName,proto3" json:"pod_name"`
+	// Namespace of the pod
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace"`
+	// Name of the node running the pod
+	NodeName string `protobuf:"bytes,3,opt,name=node_name,json=nodeName,proto3" json:"node_name"`
+	// Pod labels exposed through a downward API volume
+	Labels               map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *KubernetesMetadata) Reset()         { *m = KubernetesMetadata{} }
+func (m *KubernetesMetadata) String() string { return proto.CompactTextString(m) }
+func (*KubernetesMetadata) ProtoMessage()    {}
+func (*KubernetesMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_85e40b83b4d50a8d, []int{2}
+}
+func (m *KubernetesMetadata) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KubernetesMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KubernetesMetadata.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KubernetesMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KubernetesMetadata.Merge(m, src)
+}
+func (m *KubernetesMetadata) XXX_Size() int {
+	return m.Size()
+}
+func (m *KubernetesMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_KubernetesMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KubernetesMetadata proto.InternalMessageInfo
+
+func (m *KubernetesMetadata) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
+func (m *KubernetesMetadata) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *KubernetesMetadata) GetNodeName() string {
+	if m != nil {
+		return m.NodeName
+	}
+	return ""
+}
+
+func (m *KubernetesMetadata) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 // Represents a disk partition
 type DiskPartition struct {
 	// Mount point location
@@ -198,7 +387,7 @@ func (m *DiskPartition) Reset()         { *m = DiskPartition{} }
 func (m *DiskPartition) String() string { return proto.CompactTextString(m) }
 func (*DiskPartition) ProtoMessage()    {}
 func (*DiskPartition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_85e40b83b4d50a8d, []int{1}
+	return fileDescriptor_85e40b83b4d50a8d, []int{3}
 }
 func (m *DiskPartition) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -263,7 +452,7 @@ func (m *Network) Reset()         { *m = Network{} }
 func (m *Network) String() string { return proto.CompactTextString(m) }
 func (*Network) ProtoMessage()    {}
 func (*Network) Descriptor() ([]byte, []int) {
-	return fileDescriptor_85e40b83b4d50a8d, []int{2}
+	return fileDescriptor_85e40b83b4d50a8d, []int{4}
 }
 func (m *Network) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -325,7 +514,7 @@ func (m *NetworkInterface) Reset()         { *m = NetworkInterface{} }
 func (m *NetworkInterface) String() string { return proto.CompactTextString(m) }
 func (*NetworkInterface) ProtoMessage()    {}
 func (*NetworkInterface) Descriptor() ([]byte, []int) {
-	return fileDescriptor_85e40b83b4d50a8d, []int{3}
+	return fileDescriptor_85e40b83b4d50a8d, []int{5}
 }
 func (m *NetworkInterface) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -399,7 +588,7 @@ func (m *Address) Reset()         { *m = Address{} }
 func (m *Address) String() string { return proto.CompactTextString(m) }
 func (*Address) ProtoMessage()    {}
 func (*Address) Descriptor() ([]byte, []int) {
-	return fileDescriptor_85e40b83b4d50a8d, []int{4}
+	return fileDescriptor_85e40b83b4d50a8d, []int{6}
 }
 func (m *Address) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -476,7 +665,7 @@ func (m *CpuInfo) Reset()         { *m = CpuInfo{} }
 func (m *CpuInfo) String() string { return proto.CompactTextString(m) }
 func (*CpuInfo) ProtoMessage()    {}
 func (*CpuInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_85e40b83b4d50a8d, []int{5}
+	return fileDescriptor_85e40b83b4d50a8d, []int{7}
 }
 func (m *CpuInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -582,7 +771,7 @@ func (m *ReleaseInfo) Reset()         { *m = ReleaseInfo{} }
 func (m *ReleaseInfo) String() string { return proto.CompactTextString(m) }
 func (*ReleaseInfo) ProtoMessage()    {}
 func (*ReleaseInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_85e40b83b4d50a8d, []int{6}
+	return fileDescriptor_85e40b83b4d50a8d, []int{8}
 }
 func (m *ReleaseInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -648,6 +837,10 @@ func (m *ReleaseInfo) GetVersion() string {
 
 func init() {
 	proto.RegisterType((*HostInfo)(nil), "f5.nginx.agent.sdk.HostInfo")
+	proto.RegisterMapType((map[string]string)(nil), "f5.nginx.agent.sdk.HostInfo.SysctlsEntry")
+	proto.RegisterType((*CloudMetadata)(nil), "f5.nginx.agent.sdk.CloudMetadata")
+	proto.RegisterType((*KubernetesMetadata)(nil), "f5.nginx.agent.sdk.KubernetesMetadata")
+	proto.RegisterMapType((map[string]string)(nil), "f5.nginx.agent.sdk.KubernetesMetadata.LabelsEntry")
 	proto.RegisterType((*DiskPartition)(nil), "f5.nginx.agent.sdk.DiskPartition")
 	proto.RegisterType((*Network)(nil), "f5.nginx.agent.sdk.Network")
 	proto.RegisterType((*NetworkInterface)(nil), "f5.nginx.agent.sdk.NetworkInterface")
@@ -660,67 +853,84 @@ func init() {
 func init() { proto.RegisterFile("host.proto", fileDescriptor_85e40b83b4d50a8d) }
 
 var fileDescriptor_85e40b83b4d50a8d = []byte{
-	// 950 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x41, 0x8f, 0xdb, 0x44,
-	0x14, 0x96, 0xe3, 0xcd, 0x7a, 0xf3, 0xb2, 0x5b, 0x56, 0x53, 0x40, 0x6e, 0x41, 0xeb, 0x10, 0x0a,
-	0x0a, 0x42, 0x75, 0xa4, 0xed, 0x02, 0xa5, 0x9c, 0x9a, 0x16, 0xe8, 0x1e, 0xa8, 0xaa, 0xd1, 0x9e,
-	0xb8, 0x44, 0x5e, 0x7b, 0x92, 0x8c, 0x92, 0x78, 0x2c, 0xcf, 0x38, 0x34, 0x7b, 0x46, 0x48, 0xfc,
-	0x11, 0x24, 0x7e, 0x06, 0xe2, 0xc2, 0x91, 0x5f, 0x60, 0xa1, 0x3d, 0xfa, 0x57, 0xa0, 0x79, 0x33,
-	0x8e, 0xb3, 0x4b, 0xa8, 0x7a, 0x79, 0xf3, 0xde, 0xe7, 0x6f, 0x9e, 0xc7, 0xef, 0x7d, 0xf3, 0x0c,
-	0x30, 0x13, 0x52, 0x85, 0x59, 0x2e, 0x94, 0x20, 0x64, 0xf2, 0x45, 0x98, 0x4e, 0x79, 0xfa, 0x3a,
-	0x8c, 0xa6, 0x2c, 0x55, 0xa1, 0x4c, 0xe6, 0xf7, 0x61, 0x2a, 0xa6, 0xc2, 0x3c, 0xef, 0xff, 0xd6,
-	0x86, 0x83, 0x17, 0x42, 0xaa, 0xf3, 0x74, 0x22, 0x48, 0x00, 0x6d, 0x64, 0xf9, 0x4e, 0xcf, 0x19,
-	0x74, 0x46, 0x9d, 0xaa, 0x0c, 0x0c, 0x40, 0xcd, 0x42, 0x3e, 0x84, 0xbd, 0x4b, 0x21, 0x94, 0xdf,
-	0xea, 0x39, 0x83, 0xbd, 0xd1, 0x41, 0x55, 0x06, 0x18, 0x53, 0xb4, 0x64, 0x00, 0x07, 0xfa, 0xcd,
-	0x69, 0xb4, 0x64, 0xbe, 0x8b, 0x19, 0x0e, 0xab, 0x32, 0xd8, 0x60, 0x74, 0xe3, 0x91, 0x47, 0x70,
-	0x98, 0x70, 0x99, 0x2d, 0xa2, 0xf5, 0x18, 0xd9, 0x7b, 0xc8, 0x3e, 0xae, 0xca, 0xe0, 0x06, 0x4e,
-	0xbb, 0x36, 0x7a, 0xa9, 0x37, 0x3d, 0x00, 0x4f, 0xc8, 0xb1, 0x5a, 0x67, 0xcc, 0x6f, 0x23, 0xbf,
-	0x5b, 0x95, 0x81, 0x27, 0xe4, 0x43, 0x0d, 0xd1, 0x7d, 0x21, 0x2f, 0xd6, 0x19, 0xd3, 0x47, 0x2c,
-	0x0a, 0x9e, 0xf8, 0xfb, 0x48, 0xc1, 0x23, 0xea, 0x98, 0xa2, 0xd5, 0x5f, 0x58, 0xe0, 0x1b, 0xbd,
-	0xe6, 0x0b, 0x11, 0xa0, 0x66, 0x21, 0x17, 0xd0, 0xc9, 0xa2, 0x5c, 0x71, 0x25, 0x52, 0xe9, 0x1f,
-	0xf4, 0xdc, 0x41, 0xf7, 0xf4, 0xa3, 0xf0, 0xbf, 0x35, 0x0c, 0x9f, 0x73, 0x39, 0x7f, 0x85, 0x44,
-	0x2e, 0xd2, 0xd1, 0xdd, 0xaa, 0x0c, 0xde, 0x49, 0xb8, 0x9c, 0x8f, 0xb3, 0x1a, 0x93, 0xb4, 0x49,
-	0x44, 0x46, 0xe0, 0xa5, 0x4c, 0xfd, 0x24, 0xf2, 0xb9, 0xdf, 0xe9, 0x39, 0x83, 0xee, 0xe9, 0x07,
-	0xbb, 0x72, 0xbe, 0x34, 0x14, 0xf3, 0x5d, 0x96, 0x4f, 0x6b, 0x87, 0xbc, 0x80, 0x4e, 0x96, 0x8b,
-	0x98, 0x49, 0x29, 0x72, 0x1f, 0xf0, 0x64, 0x3b, 0xb3, 0x3c, 0xcb, 0x0a, 0xdd, 0xcc, 0xd1, 0x51,
-	0x55, 0x06, 0xcd, 0x0e, 0xda, 0xb8, 0xe4, 0x3b, 0xf0, 0x72, 0xb6, 0x60, 0x91, 0x64, 0x7e, 0x17,
-	0x4f, 0x13, 0xec, 0xca, 0x43, 0x0d, 0x05, 0x73, 0xe1, 0x89, 0xec, 0x1e, 0x5a, 0x3b, 0xba, 0xd4,
-	0x2a, 0x9a, 0x4a, 0xff, 0xb0, 0xe7, 0xd6, 0xa5, 0xd6, 0x31, 0x45, 0x4b, 0x7e, 0x80, 0xf7, 0x30,
-	0xd9, 0x38, 0x8a, 0xf5, 0x7b, 0xf9, 0xe5, 0x82, 0x8d, 0x13, 0x9e, 0x4b, 0xff, 0x08, 0x4b, 0x7f,
-	0xaf, 0x2a, 0x83, 0xdd, 0x04, 0x7a, 0x17, 0xe1, 0xa7, 0x1b, 0xf4, 0x39, 0xcf, 0x65, 0xff, 0x57,
-	0x07, 0x8e, 0x6e, 0x14, 0x9d, 0x0c, 0xa1, 0xbb, 0x14, 0x45, 0xaa, 0xc6, 0x99, 0xe0, 0x1b, 0xcd,
-	0xde, 0xa9, 0xca, 0x00, 0x10, 0x46, 0x94, 0x1a, 0xff, 0x95, 0xf6, 0x49, 0x1f, 0xf6, 0x13, 0xb6,
-	0xe2, 0x31, 0x43, 0xfd, 0x76, 0x46, 0x50, 0x95, 0x81, 0x45, 0xa8, 0x5d, 0xc9, 0xc7, 0xe0, 0x4d,
-	0xac, 0xc8, 0xdc, 0x86, 0x34, 0x91, 0x46, 0x63, 0x13, 0xd4, 0x58, 0xff, 0x17, 0x07, 0x3c, 0xdb,
-	0x2c, 0x72, 0x01, 0xc0, 0x53, 0xc5, 0xf2, 0x49, 0x14, 0x33, 0xe9, 0x3b, 0xd8, 0x97, 0x07, 0x6f,
-	0xe8, 0xee, 0x79, 0x4d, 0x36, 0x47, 0x6d, 0xf6, 0xd2, 0x2d, 0x9f, 0x7c, 0x02, 0x5e, 0xc2, 0x26,
-	0x51, 0xb1, 0x50, 0xf6, 0xac, 0xd8, 0x01, 0x0b, 0xd1, 0xda, 0xe9, 0xff, 0xe1, 0xc0, 0xf1, 0xed,
-	0xbc, 0xe4, 0x1e, 0xb8, 0xcb, 0x28, 0xb6, 0xf5, 0xf0, 0xaa, 0x32, 0xd0, 0x21, 0xd5, 0x86, 0x7c,
-	0x0d, 0x7b, 0x3c, 0x5b, 0x7d, 0xe9, 0xb7, 0xfe, 0x5f, 0x3e, 0x4f, 0x93, 0x24, 0x67, 0x52, 0x9a,
-	0x76, 0x6a, 0x32, 0x45, 0x6b, 0xb7, 0x9e, 0xf9, 0xee, 0xdb, 0x6f, 0x3d, 0xc3, 0xad, 0x67, 0x5a,
-	0x27, 0x5b, 0xb7, 0x1c, 0x9f, 0xe2, 0x95, 0x43, 0xdb, 0xff, 0xd9, 0x01, 0xcf, 0xee, 0x24, 0x9f,
-	0x6b, 0x8d, 0xb3, 0x09, 0x7f, 0xbd, 0x60, 0x29, 0x7e, 0x80, 0x5b, 0xcb, 0xd8, 0x82, 0xb4, 0x71,
-	0x75, 0x8d, 0x52, 0xa6, 0x96, 0x91, 0x9c, 0x6f, 0xd7, 0xc8, 0x42, 0xb4, 0x76, 0x34, 0x2d, 0x32,
-	0xe9, 0x6d, 0x47, 0x91, 0x66, 0x21, 0x5a, 0x3b, 0xfd, 0xdf, 0x5d, 0xf0, 0xec, 0xd5, 0xd1, 0x53,
-	0x62, 0x29, 0x12, 0xb6, 0xd8, 0x9e, 0x83, 0x08, 0x50, 0xb3, 0x68, 0x42, 0x2c, 0x72, 0x26, 0xf1,
-	0xc5, 0x6d, 0x43, 0x40, 0x80, 0x9a, 0x85, 0x9c, 0xc1, 0x61, 0x94, 0xc7, 0x33, 0xae, 0x58, 0xac,
-	0x8a, 0xbc, 0xd6, 0x12, 0x0e, 0xb8, 0x6d, 0x9c, 0xde, 0x88, 0xb0, 0x73, 0xb3, 0x2b, 0xac, 0x93,
-	0x63, 0x3b, 0x37, 0xbb, 0xa2, 0xda, 0x90, 0x10, 0x60, 0xb6, 0xce, 0x58, 0xbe, 0xe2, 0xfa, 0xfa,
-	0xb7, 0x1b, 0xad, 0x37, 0x28, 0xdd, 0xf2, 0x75, 0xcd, 0xe3, 0xac, 0x90, 0x38, 0x06, 0xdb, 0xa6,
-	0xe6, 0x3a, 0xa6, 0x68, 0xc9, 0x13, 0xb8, 0xb3, 0xe2, 0xb9, 0x2a, 0xa2, 0x05, 0xbf, 0x8a, 0xf4,
-	0x65, 0xb2, 0xf3, 0x90, 0x54, 0x65, 0x70, 0xeb, 0x09, 0xbd, 0x15, 0x93, 0xef, 0xa1, 0x1d, 0x47,
-	0xf1, 0x8c, 0xd9, 0xe9, 0xf8, 0xe9, 0x1b, 0x66, 0x50, 0xf8, 0x4c, 0x13, 0xbf, 0x4d, 0x55, 0xbe,
-	0xb6, 0x35, 0xd2, 0x31, 0x35, 0xcb, 0xfd, 0xc7, 0x00, 0xcd, 0x73, 0x72, 0x0c, 0xee, 0x9c, 0xad,
-	0x4d, 0xc5, 0xa9, 0x76, 0xc9, 0xbb, 0xd0, 0x5e, 0x45, 0x8b, 0xc2, 0xde, 0x56, 0x6a, 0x82, 0x27,
-	0xad, 0xc7, 0x4e, 0xff, 0x4f, 0x07, 0xba, 0x5b, 0xe3, 0x49, 0xff, 0x78, 0x62, 0x91, 0x30, 0x14,
-	0x99, 0xd3, 0xfc, 0x78, 0x6a, 0x8c, 0x6e, 0x3c, 0xf2, 0x3e, 0xb4, 0x78, 0x62, 0xe5, 0xb2, 0x5f,
-	0x95, 0x41, 0x8b, 0x27, 0xb4, 0xc5, 0x93, 0x8d, 0x44, 0xdd, 0x5d, 0x12, 0x25, 0x0f, 0x01, 0x56,
-	0x2c, 0x97, 0x5c, 0xa4, 0x63, 0x9e, 0x58, 0x19, 0x63, 0xf1, 0x1b, 0x94, 0x76, 0xac, 0x7f, 0x9e,
-	0x68, 0xc5, 0xd9, 0x60, 0xfb, 0x47, 0x65, 0x21, 0x5a, 0x3b, 0xa3, 0xaf, 0xfe, 0xba, 0x3e, 0x71,
-	0xfe, 0xbe, 0x3e, 0x71, 0xfe, 0xb9, 0x3e, 0x71, 0x7e, 0xfc, 0x6c, 0xca, 0xd5, 0xac, 0xb8, 0x0c,
-	0x63, 0xb1, 0x1c, 0x62, 0x39, 0x87, 0x58, 0xce, 0xa1, 0x4c, 0xe6, 0xc3, 0xd5, 0xe9, 0x10, 0xff,
-	0xd6, 0xdf, 0xa0, 0xbd, 0xdc, 0xc7, 0xe5, 0xd1, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x4a, 0x49,
-	0xf4, 0x5f, 0xe8, 0x07, 0x00, 0x00,
+	// 1226 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x51, 0x8f, 0xdb, 0xc4,
+	0x13, 0x97, 0x93, 0xcb, 0x39, 0x99, 0xdc, 0xb5, 0xd7, 0xed, 0xff, 0x8f, 0xdc, 0x03, 0xd5, 0x47,
+	0x28, 0xe5, 0x0a, 0x6a, 0x0e, 0x5d, 0x8f, 0xd2, 0x96, 0xa7, 0xa6, 0x05, 0x7a, 0x82, 0x56, 0xd5,
+	0x52, 0xf5, 0x81, 0x97, 0xc8, 0x67, 0x6f, 0x92, 0x55, 0x12, 0xaf, 0xe5, 0x5d, 0x87, 0xa6, 0xcf,
+	0x08, 0x09, 0x89, 0x2f, 0xd2, 0x8f, 0x81, 0x78, 0x80, 0x47, 0x3e, 0x81, 0x85, 0xfa, 0xe8, 0x4f,
+	0x81, 0x76, 0x76, 0x1d, 0xfb, 0xae, 0xa1, 0xba, 0xbe, 0xec, 0xce, 0xfc, 0xfc, 0x9b, 0xf1, 0xee,
+	0xcc, 0xec, 0xec, 0x02, 0x4c, 0x84, 0x54, 0xfd, 0x24, 0x15, 0x4a, 0x10, 0x32, 0xfa, 0xa2, 0x1f,
+	0x8f, 0x79, 0xfc, 0xa2, 0x1f, 0x8c, 0x59, 0xac, 0xfa, 0x32, 0x9a, 0xee, 0xc2, 0x58, 0x8c, 0x85,
+	0xf9, 0xde, 0xfb, 0xad, 0x0d, 0xed, 0x47, 0x42, 0xaa, 0xe3, 0x78, 0x24, 0x88, 0x0f, 0x2d, 0x64,
+	0x79, 0xce, 0x9e, 0xb3, 0xdf, 0x19, 0x74, 0x8a, 0xdc, 0x37, 0x00, 0x35, 0x13, 0xf9, 0x00, 0x36,
+	0x4e, 0x84, 0x50, 0x5e, 0x63, 0xcf, 0xd9, 0xdf, 0x18, 0xb4, 0x8b, 0xdc, 0x47, 0x9d, 0xe2, 0x48,
+	0xf6, 0xa1, 0xad, 0xff, 0x1c, 0x07, 0x73, 0xe6, 0x35, 0xd1, 0xc3, 0x56, 0x91, 0xfb, 0x2b, 0x8c,
+	0xae, 0x24, 0x72, 0x0b, 0xb6, 0x22, 0x2e, 0x93, 0x59, 0xb0, 0x1c, 0x22, 0x7b, 0x03, 0xd9, 0x3b,
+	0x45, 0xee, 0x9f, 0xc2, 0x69, 0xd7, 0x6a, 0x4f, 0xb4, 0xd1, 0x35, 0x70, 0x85, 0x1c, 0xaa, 0x65,
+	0xc2, 0xbc, 0x16, 0xf2, 0xbb, 0x45, 0xee, 0xbb, 0x42, 0xde, 0xd4, 0x10, 0xdd, 0x14, 0xf2, 0xd9,
+	0x32, 0x61, 0x7a, 0x89, 0x59, 0xc6, 0x23, 0x6f, 0x13, 0x29, 0xb8, 0x44, 0xad, 0x53, 0x1c, 0xf5,
+	0x0e, 0x33, 0xfc, 0xa3, 0x5b, 0xed, 0x10, 0x01, 0x6a, 0x26, 0xf2, 0x0c, 0x3a, 0x49, 0x90, 0x2a,
+	0xae, 0x44, 0x2c, 0xbd, 0xf6, 0x5e, 0x73, 0xbf, 0x7b, 0xf8, 0x61, 0xff, 0xcd, 0x18, 0xf6, 0x1f,
+	0x72, 0x39, 0x7d, 0x8a, 0x44, 0x2e, 0xe2, 0xc1, 0xe5, 0x22, 0xf7, 0x2f, 0x46, 0x5c, 0x4e, 0x87,
+	0x49, 0x89, 0x49, 0x5a, 0x39, 0x22, 0x03, 0x70, 0x63, 0xa6, 0x7e, 0x12, 0xe9, 0xd4, 0xeb, 0xec,
+	0x39, 0xfb, 0xdd, 0xc3, 0xf7, 0xd7, 0xf9, 0x7c, 0x62, 0x28, 0x66, 0x5f, 0x96, 0x4f, 0x4b, 0x81,
+	0x3c, 0x82, 0x4e, 0x92, 0x8a, 0x90, 0x49, 0x29, 0x52, 0x0f, 0x70, 0x65, 0x6b, 0xbd, 0x3c, 0x48,
+	0x32, 0x9d, 0xcc, 0xc1, 0x76, 0x91, 0xfb, 0x95, 0x05, 0xad, 0x44, 0xf2, 0x0d, 0xb8, 0x29, 0x9b,
+	0xb1, 0x40, 0x32, 0xaf, 0x8b, 0xab, 0xf1, 0xd7, 0xf9, 0xa1, 0x86, 0x82, 0xbe, 0x70, 0x45, 0xd6,
+	0x86, 0x96, 0x82, 0x0e, 0xb5, 0x0a, 0xc6, 0xd2, 0xdb, 0xda, 0x6b, 0x96, 0xa1, 0xd6, 0x3a, 0xc5,
+	0x91, 0x3c, 0x86, 0xff, 0xa3, 0xb3, 0x61, 0x10, 0xea, 0xff, 0xf2, 0x93, 0x19, 0x1b, 0x46, 0x3c,
+	0x95, 0xde, 0x36, 0x86, 0xfe, 0x4a, 0x91, 0xfb, 0xeb, 0x09, 0xf4, 0x32, 0xc2, 0xf7, 0x57, 0xe8,
+	0x43, 0x9e, 0x4a, 0xf2, 0x1c, 0x60, 0x9a, 0x9d, 0xb0, 0x34, 0x66, 0x8a, 0x49, 0xef, 0x02, 0xae,
+	0xfb, 0xfa, 0xba, 0x75, 0x7f, 0xb7, 0x62, 0x3d, 0x66, 0x2a, 0x88, 0x02, 0x15, 0x0c, 0x2e, 0x14,
+	0xb9, 0x5f, 0xb3, 0xa6, 0x35, 0x99, 0x0c, 0xa0, 0x15, 0xce, 0x44, 0x16, 0x79, 0x17, 0xd1, 0xe5,
+	0xda, 0x64, 0x3f, 0xd0, 0x84, 0x95, 0x37, 0x2c, 0x1a, 0xb4, 0xa1, 0x66, 0x22, 0xf7, 0xe0, 0xc2,
+	0x82, 0xa7, 0x2a, 0x0b, 0x66, 0xfc, 0x65, 0xa0, 0x93, 0xef, 0xed, 0xe0, 0x1e, 0x49, 0x91, 0xfb,
+	0x67, 0xbe, 0xd0, 0x33, 0x3a, 0x79, 0x0a, 0xae, 0x5c, 0xca, 0x50, 0xcd, 0xa4, 0x77, 0x09, 0x93,
+	0x7a, 0x63, 0xdd, 0x0a, 0xca, 0x23, 0xda, 0xff, 0xc1, 0x70, 0xbf, 0x8e, 0x55, 0xba, 0x34, 0x69,
+	0xb1, 0xd6, 0xb4, 0x14, 0x76, 0xef, 0xc1, 0x56, 0x9d, 0x45, 0x76, 0xa0, 0x39, 0x65, 0x4b, 0x73,
+	0xa6, 0xa9, 0x16, 0xc9, 0xff, 0xa0, 0xb5, 0x08, 0x66, 0x19, 0xc3, 0x73, 0xdc, 0xa1, 0x46, 0xb9,
+	0xd7, 0xb8, 0xe3, 0xf4, 0xfe, 0x74, 0x60, 0xfb, 0xd4, 0x6e, 0xf5, 0xa1, 0x4e, 0x52, 0xb1, 0xe0,
+	0x11, 0x4b, 0x6d, 0x5b, 0xc0, 0x43, 0x5d, 0x62, 0x74, 0x25, 0x91, 0xdb, 0xb0, 0xcd, 0x63, 0xa9,
+	0x82, 0x38, 0x64, 0xe6, 0x94, 0xa2, 0xf7, 0xc1, 0xa5, 0x22, 0xf7, 0x4f, 0x7f, 0xa0, 0x5b, 0xa5,
+	0x8a, 0x27, 0xf6, 0x73, 0xe8, 0xae, 0x3e, 0xf3, 0xc8, 0x76, 0x8e, 0x8b, 0x45, 0xee, 0xd7, 0x61,
+	0x0a, 0xa5, 0x72, 0x1c, 0x91, 0x1e, 0x6c, 0xa6, 0x6c, 0xac, 0xe3, 0x6c, 0x1a, 0x07, 0x14, 0xb9,
+	0x6f, 0x11, 0x6a, 0xe7, 0xde, 0xab, 0x06, 0x90, 0x37, 0x4b, 0x81, 0x7c, 0x02, 0xed, 0x44, 0x44,
+	0xa6, 0xeb, 0xd4, 0xb7, 0x63, 0x31, 0xea, 0x26, 0x22, 0xc2, 0x6e, 0xf3, 0x19, 0x74, 0x34, 0x20,
+	0x93, 0x20, 0x2c, 0x77, 0x82, 0x27, 0x6a, 0x05, 0xd2, 0x4a, 0x24, 0x9f, 0x42, 0x27, 0x16, 0x11,
+	0x1b, 0xd6, 0x5a, 0x9f, 0x21, 0x97, 0x20, 0x6d, 0x6b, 0x11, 0x1d, 0x3f, 0x87, 0xcd, 0x59, 0x70,
+	0xc2, 0x66, 0xd2, 0xdb, 0xc0, 0x7c, 0x1f, 0x9e, 0xaf, 0x88, 0xfb, 0xdf, 0xa3, 0x91, 0x49, 0x3c,
+	0x6e, 0xd8, 0x78, 0xa1, 0x76, 0xde, 0xbd, 0x0b, 0xdd, 0x1a, 0xe5, 0x9d, 0xb2, 0xfe, 0xab, 0x03,
+	0xdb, 0xa7, 0x1a, 0x1a, 0x39, 0x80, 0xee, 0x5c, 0x64, 0xb1, 0x1a, 0x26, 0x82, 0xaf, 0xee, 0x03,
+	0x3c, 0x46, 0x08, 0x23, 0x4a, 0x8d, 0xfc, 0x54, 0xcb, 0x3a, 0x25, 0x11, 0x5b, 0xf0, 0x55, 0xac,
+	0x70, 0x85, 0x06, 0xa1, 0x76, 0x26, 0x1f, 0x81, 0x3b, 0xb2, 0x0d, 0xbc, 0x59, 0x91, 0x46, 0xd2,
+	0xf4, 0xef, 0x11, 0xf6, 0xef, 0xde, 0x2f, 0x0e, 0xb8, 0xb6, 0x11, 0x92, 0x67, 0x00, 0x3c, 0x56,
+	0x2c, 0x1d, 0x05, 0x21, 0x93, 0x9e, 0x83, 0xe1, 0xba, 0xf6, 0x96, 0xce, 0x79, 0x5c, 0x92, 0xcd,
+	0x52, 0x2b, 0x5b, 0x5a, 0x93, 0xc9, 0xc7, 0xe0, 0x46, 0x6c, 0x14, 0x64, 0x33, 0x65, 0xd7, 0x8a,
+	0xc7, 0xc8, 0x42, 0xb4, 0x14, 0x7a, 0xbf, 0x3b, 0xb0, 0x73, 0xd6, 0x2f, 0xb9, 0x02, 0xcd, 0x79,
+	0x10, 0xda, 0x78, 0xb8, 0x45, 0xee, 0x6b, 0x95, 0xea, 0x81, 0xdc, 0x85, 0x0d, 0x9e, 0x2c, 0x6e,
+	0x7b, 0x8d, 0xff, 0x6e, 0xcd, 0xf7, 0xa3, 0x28, 0x65, 0x52, 0x9a, 0x56, 0xa9, 0xc9, 0x14, 0x47,
+	0x6b, 0x7a, 0xe4, 0x35, 0xcf, 0x6f, 0x7a, 0x84, 0xa6, 0x47, 0xba, 0x07, 0xd7, 0x6e, 0x50, 0xfc,
+	0x8a, 0xf5, 0x86, 0x63, 0xef, 0x67, 0x07, 0x5c, 0x6b, 0xa9, 0x0b, 0x3a, 0x49, 0xd9, 0x88, 0xbf,
+	0x98, 0xb1, 0x18, 0x37, 0xd0, 0x2c, 0xaf, 0x08, 0x0b, 0xd2, 0x4a, 0xd4, 0x31, 0x8a, 0x99, 0x9a,
+	0x07, 0x72, 0x5a, 0x8f, 0x91, 0x85, 0x68, 0x29, 0x68, 0x5a, 0x60, 0xdc, 0xdb, 0x8c, 0x22, 0xcd,
+	0x42, 0xb4, 0x14, 0x7a, 0xaf, 0x9a, 0xe0, 0xda, 0x6b, 0x49, 0xdf, 0xc0, 0x73, 0x11, 0xb1, 0x59,
+	0xfd, 0x8d, 0x81, 0x00, 0x35, 0x93, 0x26, 0x84, 0x22, 0x65, 0x12, 0x7f, 0xdc, 0xb2, 0xdd, 0x56,
+	0x03, 0xd4, 0x4c, 0xe4, 0x08, 0xb6, 0x82, 0x34, 0x9c, 0x70, 0xc5, 0x42, 0x95, 0xa5, 0x65, 0x2d,
+	0xe1, 0xe3, 0xa1, 0x8e, 0xd3, 0x53, 0x1a, 0x66, 0x6e, 0xf2, 0x12, 0xe3, 0xe4, 0xd8, 0xcc, 0x4d,
+	0x5e, 0x52, 0x3d, 0x90, 0x3e, 0xc0, 0x64, 0x99, 0xb0, 0x74, 0xc1, 0xf5, 0xd5, 0xda, 0xaa, 0x6a,
+	0xbd, 0x42, 0x69, 0x4d, 0xd6, 0x31, 0x0f, 0x93, 0x4c, 0xe2, 0x13, 0xa3, 0x65, 0x62, 0xae, 0x75,
+	0x8a, 0xe3, 0x9a, 0xcb, 0xc0, 0x3d, 0xf7, 0x65, 0xf0, 0x2d, 0xb4, 0xc2, 0x20, 0x9c, 0x30, 0xfb,
+	0xf2, 0xb8, 0xfe, 0x96, 0xfb, 0xbd, 0xff, 0x40, 0x13, 0x4d, 0x3b, 0x30, 0x31, 0xd2, 0x3a, 0x35,
+	0xd3, 0xee, 0x1d, 0x80, 0xea, 0xfb, 0x3b, 0xf5, 0x82, 0x3f, 0x1c, 0xe8, 0xd6, 0xae, 0x7e, 0xdd,
+	0xff, 0x43, 0x11, 0xb1, 0xb3, 0x0d, 0xb3, 0xc4, 0xe8, 0x4a, 0x22, 0xef, 0x41, 0x83, 0x47, 0xb6,
+	0x5c, 0x36, 0x8b, 0xdc, 0x6f, 0xf0, 0x88, 0x36, 0x78, 0xb4, 0x2a, 0xd1, 0xe6, 0xba, 0x12, 0x25,
+	0x37, 0x01, 0x16, 0x2c, 0x95, 0x5c, 0xc4, 0xba, 0xf9, 0x6f, 0x54, 0xc1, 0xaf, 0x50, 0xda, 0xb1,
+	0xf2, 0x71, 0xa4, 0x2b, 0xce, 0x2a, 0xf5, 0x47, 0xa0, 0x85, 0x68, 0x29, 0x0c, 0xbe, 0xfc, 0xeb,
+	0xf5, 0x55, 0xe7, 0xef, 0xd7, 0x57, 0x9d, 0x7f, 0x5e, 0x5f, 0x75, 0x7e, 0xbc, 0x31, 0xe6, 0x6a,
+	0x92, 0x9d, 0xf4, 0x43, 0x31, 0x3f, 0xc0, 0x70, 0x1e, 0x60, 0x38, 0x0f, 0x64, 0x34, 0x3d, 0x58,
+	0x1c, 0x1e, 0xe0, 0x4b, 0xf8, 0x2b, 0x1c, 0x4f, 0x36, 0x71, 0xba, 0xf5, 0x6f, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0x18, 0xdc, 0x53, 0xf9, 0x44, 0x0b, 0x00, 0x00,
 }
 
 func (m *HostInfo) Marshal() (dAtA []byte, err error) {
@@ -747,6 +957,60 @@ func (m *HostInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Sysctls) > 0 {
+		for k := range m.Sysctls {
+			v := m.Sysctls[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintHost(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintHost(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintHost(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x8a
+		}
+	}
+	if len(m.Virtualization) > 0 {
+		i -= len(m.Virtualization)
+		copy(dAtA[i:], m.Virtualization)
+		i = encodeVarintHost(dAtA, i, uint64(len(m.Virtualization)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.Cloud != nil {
+		{
+			size, err := m.Cloud.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHost(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.Kubernetes != nil {
+		{
+			size, err := m.Kubernetes.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHost(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
 	if len(m.AgentAccessibleDirs) > 0 {
 		i -= len(m.AgentAccessibleDirs)
 		copy(dAtA[i:], m.AgentAccessibleDirs)
@@ -865,6 +1129,128 @@ func (m *HostInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *CloudMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CloudMetadata) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CloudMetadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Region) > 0 {
+		i -= len(m.Region)
+		copy(dAtA[i:], m.Region)
+		i = encodeVarintHost(dAtA, i, uint64(len(m.Region)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.InstanceId) > 0 {
+		i -= len(m.InstanceId)
+		copy(dAtA[i:], m.InstanceId)
+		i = encodeVarintHost(dAtA, i, uint64(len(m.InstanceId)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.InstanceType) > 0 {
+		i -= len(m.InstanceType)
+		copy(dAtA[i:], m.InstanceType)
+		i = encodeVarintHost(dAtA, i, uint64(len(m.InstanceType)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Provider) > 0 {
+		i -= len(m.Provider)
+		copy(dAtA[i:], m.Provider)
+		i = encodeVarintHost(dAtA, i, uint64(len(m.Provider)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *KubernetesMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KubernetesMetadata) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KubernetesMetadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Labels) > 0 {
+		for k := range m.Labels {
+			v := m.Labels[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintHost(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintHost(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintHost(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.NodeName) > 0 {
+		i -= len(m.NodeName)
+		copy(dAtA[i:], m.NodeName)
+		i = encodeVarintHost(dAtA, i, uint64(len(m.NodeName)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintHost(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.PodName) > 0 {
+		i -= len(m.PodName)
+		copy(dAtA[i:], m.PodName)
+		i = encodeVarintHost(dAtA, i, uint64(len(m.PodName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *DiskPartition) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1302,10 +1688,90 @@ func (m *HostInfo) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovHost(uint64(l))
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.Kubernetes != nil {
+		l = m.Kubernetes.Size()
+		n += 1 + l + sovHost(uint64(l))
 	}
-	return n
+	if m.Cloud != nil {
+		l = m.Cloud.Size()
+		n += 1 + l + sovHost(uint64(l))
+	}
+	l = len(m.Virtualization)
+	if l > 0 {
+		n += 2 + l + sovHost(uint64(l))
+	}
+	if len(m.Sysctls) > 0 {
+		for k, v := range m.Sysctls {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovHost(uint64(len(k))) + 1 + len(v) + sovHost(uint64(len(v)))
+			n += mapEntrySize + 2 + sovHost(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CloudMetadata) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Provider)
+	if l > 0 {
+		n += 1 + l + sovHost(uint64(l))
+	}
+	l = len(m.InstanceType)
+	if l > 0 {
+		n += 1 + l + sovHost(uint64(l))
+	}
+	l = len(m.InstanceId)
+	if l > 0 {
+		n += 1 + l + sovHost(uint64(l))
+	}
+	l = len(m.Region)
+	if l > 0 {
+		n += 1 + l + sovHost(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KubernetesMetadata) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PodName)
+	if l > 0 {
+		n += 1 + l + sovHost(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovHost(uint64(l))
+	}
+	l = len(m.NodeName)
+	if l > 0 {
+		n += 1 + l + sovHost(uint64(l))
+	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovHost(uint64(len(k))) + 1 + len(v) + sovHost(uint64(len(v)))
+			n += mapEntrySize + 1 + sovHost(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
 
 func (m *DiskPartition) Size() (n int) {
@@ -1936,6 +2402,690 @@ func (m *HostInfo) Unmarshal(dAtA []byte) error {
 			}
 			m.AgentAccessibleDirs = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kubernetes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHost
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHost
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Kubernetes == nil {
+				m.Kubernetes = &KubernetesMetadata{}
+			}
+			if err := m.Kubernetes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cloud", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHost
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHost
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Cloud == nil {
+				m.Cloud = &CloudMetadata{}
+			}
+			if err := m.Cloud.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Virtualization", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHost
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHost
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Virtualization = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sysctls", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHost
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHost
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Sysctls == nil {
+				m.Sysctls = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowHost
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowHost
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthHost
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthHost
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowHost
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthHost
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthHost
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipHost(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthHost
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Sysctls[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHost(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHost
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CloudMetadata) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHost
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CloudMetadata: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CloudMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provider", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHost
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHost
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provider = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHost
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHost
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstanceType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHost
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHost
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstanceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHost
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHost
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Region = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHost(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHost
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KubernetesMetadata) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHost
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KubernetesMetadata: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KubernetesMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHost
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHost
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PodName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHost
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHost
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHost
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHost
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHost
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHost
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowHost
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowHost
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthHost
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthHost
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowHost
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthHost
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthHost
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipHost(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthHost
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Labels[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHost(dAtA[iNdEx:])
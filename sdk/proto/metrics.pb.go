@@ -64,10 +64,18 @@ type MetricsReport struct {
 	// Type of metrics
 	Type MetricsReport_Type `protobuf:"varint,2,opt,name=type,proto3,enum=f5.nginx.agent.sdk.MetricsReport_Type" json:"type"`
 	// List of stats entities
-	Data                 []*StatsEntity `protobuf:"bytes,3,rep,name=data,proto3" json:"data"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Data []*StatsEntity `protobuf:"bytes,3,rep,name=data,proto3" json:"data"`
+	// Sequence number of this message within a split report, starting at 1.
+	// Zero when the report was not split
+	SplitSequence uint32 `protobuf:"varint,4,opt,name=split_sequence,json=splitSequence,proto3" json:"split_sequence"`
+	// Total number of messages the report was split across. All parts share
+	// the message_id of their meta, so a receiver can reassemble a report by
+	// collecting split_total messages with the same message_id and ordering
+	// them by split_sequence. Zero when the report was not split
+	SplitTotal           uint32   `protobuf:"varint,5,opt,name=split_total,json=splitTotal,proto3" json:"split_total"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *MetricsReport) Reset()         { *m = MetricsReport{} }
@@ -124,6 +132,20 @@ func (m *MetricsReport) GetData() []*StatsEntity {
 	return nil
 }
 
+func (m *MetricsReport) GetSplitSequence() uint32 {
+	if m != nil {
+		return m.SplitSequence
+	}
+	return 0
+}
+
+func (m *MetricsReport) GetSplitTotal() uint32 {
+	if m != nil {
+		return m.SplitTotal
+	}
+	return 0
+}
+
 // Represents a simple metric
 type SimpleMetric struct {
 	// Metric name
@@ -318,36 +340,40 @@ func init() {
 func init() { proto.RegisterFile("metrics.proto", fileDescriptor_6039342a2ba47b72) }
 
 var fileDescriptor_6039342a2ba47b72 = []byte{
-	// 460 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc6, 0x6d, 0x36, 0x9a, 0xd7, 0x76, 0x2a, 0x3e, 0x55, 0xd5, 0x68, 0xaa, 0x1e, 0x50, 0x11,
-	0x92, 0x23, 0x15, 0x21, 0x10, 0x88, 0xc3, 0xc2, 0x2a, 0xc4, 0xa4, 0xf4, 0xe0, 0xe4, 0x32, 0x6e,
-	0x5e, 0xeb, 0x85, 0x68, 0x75, 0x1c, 0xd5, 0xee, 0x44, 0x6f, 0xfc, 0x3c, 0x8e, 0xfc, 0x82, 0x0a,
-	0xf5, 0xd8, 0xff, 0x80, 0x84, 0x62, 0xa7, 0x5b, 0x27, 0xc2, 0x61, 0x97, 0x67, 0x3f, 0xeb, 0x7d,
-	0xdf, 0x7b, 0xef, 0xfb, 0x0c, 0x6d, 0xc1, 0xf5, 0x32, 0x9d, 0x29, 0x92, 0x2f, 0xa5, 0x96, 0x18,
-	0x5f, 0xbf, 0x21, 0x59, 0x92, 0x66, 0xdf, 0x09, 0x4b, 0x78, 0xa6, 0x89, 0x9a, 0xdf, 0xf4, 0x5a,
-	0x33, 0x29, 0x84, 0xcc, 0x6c, 0x45, 0x0f, 0x12, 0x99, 0xc8, 0xf2, 0xee, 0x25, 0x52, 0x26, 0x0b,
-	0xee, 0x9b, 0xec, 0x6a, 0x75, 0xed, 0xeb, 0x54, 0x70, 0xa5, 0x99, 0xc8, 0x6d, 0xc1, 0xf0, 0x0f,
-	0x82, 0x76, 0x68, 0x1b, 0x50, 0x9e, 0xcb, 0xa5, 0xc6, 0xef, 0xc1, 0x11, 0x5c, 0xb3, 0x2e, 0x1a,
-	0xa0, 0x51, 0x73, 0x7c, 0x4a, 0xfe, 0xed, 0x47, 0x42, 0xae, 0xd9, 0x9c, 0x69, 0x16, 0x34, 0x76,
-	0x1b, 0xcf, 0x54, 0x53, 0x13, 0xf1, 0x39, 0x38, 0x7a, 0x9d, 0xf3, 0x6e, 0x6d, 0x80, 0x46, 0x27,
-	0xe3, 0x17, 0xff, 0xc1, 0xde, 0x37, 0x23, 0xf1, 0x3a, 0xe7, 0x96, 0xa5, 0xc0, 0x51, 0x13, 0xf1,
-	0x47, 0x70, 0x0a, 0xf6, 0x6e, 0x7d, 0x50, 0x1f, 0x35, 0xc7, 0x5e, 0x15, 0x4b, 0xa4, 0x99, 0x56,
-	0x93, 0x4c, 0xa7, 0x7a, 0x6d, 0xe1, 0x05, 0x80, 0x9a, 0x38, 0x7c, 0x05, 0x4e, 0x41, 0x8b, 0x01,
-	0x8e, 0xa3, 0xcb, 0x28, 0x9e, 0x84, 0x9d, 0x27, 0xb8, 0x05, 0x8d, 0x2f, 0xd3, 0x28, 0x3e, 0x9b,
-	0x7e, 0x9a, 0x74, 0x10, 0x76, 0xe1, 0xe8, 0xec, 0xf3, 0x64, 0x1a, 0x77, 0x6a, 0xc3, 0x10, 0x5a,
-	0x51, 0x2a, 0xf2, 0x05, 0xb7, 0x73, 0xe1, 0x53, 0x70, 0x32, 0x26, 0xb8, 0xd9, 0xde, 0xb5, 0xd4,
-	0x45, 0x4e, 0x4d, 0xc4, 0x1e, 0x1c, 0xdd, 0xb2, 0xc5, 0xca, 0x2e, 0x88, 0x02, 0x77, 0xb7, 0xf1,
-	0xec, 0x03, 0xb5, 0xc7, 0xf0, 0x02, 0xdc, 0xf3, 0x54, 0xf0, 0x4c, 0xa5, 0x32, 0x7b, 0x0c, 0x97,
-	0x5b, 0xc1, 0xf5, 0xa3, 0x06, 0xcd, 0x83, 0x3d, 0xf1, 0x3b, 0x70, 0xef, 0xdc, 0x2b, 0xdd, 0xe9,
-	0x11, 0xeb, 0x2f, 0xd9, 0xfb, 0x4b, 0xe2, 0x7d, 0x05, 0xbd, 0x2f, 0xc6, 0x21, 0xc0, 0x7c, 0x3f,
-	0x95, 0x2a, 0x65, 0x7d, 0x5e, 0x25, 0xeb, 0xdd, 0xec, 0xc1, 0xc9, 0x6e, 0xe3, 0x1d, 0x80, 0xe8,
-	0xc1, 0x1d, 0x5f, 0x42, 0x5b, 0x19, 0xcd, 0xca, 0x9f, 0xd9, 0x75, 0x0c, 0xe3, 0xa0, 0xd2, 0xa8,
-	0x03, 0x71, 0x83, 0x67, 0xbb, 0x8d, 0xf7, 0x10, 0x4a, 0x1f, 0xa6, 0x17, 0x4e, 0xa3, 0xd6, 0xa9,
-	0xd3, 0xa7, 0x65, 0x1a, 0xbc, 0xfd, 0xb9, 0xed, 0xa3, 0x5f, 0xdb, 0x3e, 0xfa, 0xbd, 0xed, 0xa3,
-	0xaf, 0x2f, 0x93, 0x54, 0x7f, 0x5b, 0x5d, 0x91, 0x99, 0x14, 0xbe, 0xe9, 0xe3, 0x9b, 0x3e, 0xbe,
-	0x9a, 0xdf, 0xf8, 0xb7, 0x63, 0xfb, 0xc7, 0x3f, 0x58, 0x25, 0x8e, 0xcd, 0xf1, 0xfa, 0x6f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xe6, 0xcd, 0x1e, 0xb5, 0x3d, 0x03, 0x00, 0x00,
+	// 513 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x4d, 0x8f, 0xd3, 0x30,
+	0x10, 0x25, 0x6d, 0xba, 0x34, 0xd3, 0x0f, 0x8a, 0x4f, 0x51, 0xb5, 0x34, 0x55, 0x0f, 0xa8, 0x08,
+	0xc9, 0x41, 0x45, 0x88, 0x2f, 0x71, 0xd8, 0xb0, 0x15, 0x62, 0xa5, 0xf6, 0xe0, 0xe4, 0xb2, 0x5c,
+	0x50, 0xb6, 0xf5, 0x86, 0x68, 0xeb, 0x38, 0xd4, 0xee, 0x8a, 0xde, 0xf8, 0x79, 0x1c, 0xf9, 0x05,
+	0x11, 0xea, 0x31, 0xbf, 0x02, 0xc5, 0x4e, 0x77, 0xb3, 0x50, 0x0e, 0x5c, 0x26, 0xf3, 0x9c, 0x79,
+	0x6f, 0xec, 0xe7, 0x31, 0x74, 0x18, 0x95, 0xeb, 0x78, 0x21, 0x70, 0xba, 0xe6, 0x92, 0x23, 0x74,
+	0xf9, 0x02, 0x27, 0x51, 0x9c, 0x7c, 0xc3, 0x61, 0x44, 0x13, 0x89, 0xc5, 0xf2, 0xaa, 0xdf, 0x5e,
+	0x70, 0xc6, 0x78, 0xa2, 0x2b, 0xfa, 0x10, 0xf1, 0x88, 0x97, 0xb9, 0x13, 0x71, 0x1e, 0xad, 0xa8,
+	0xab, 0xd0, 0xc5, 0xe6, 0xd2, 0x95, 0x31, 0xa3, 0x42, 0x86, 0x2c, 0xd5, 0x05, 0xa3, 0xbc, 0x06,
+	0x9d, 0x99, 0x6e, 0x40, 0x68, 0xca, 0xd7, 0x12, 0xbd, 0x01, 0x93, 0x51, 0x19, 0xda, 0xc6, 0xd0,
+	0x18, 0xb7, 0x26, 0xc7, 0xf8, 0xef, 0x7e, 0x78, 0x46, 0x65, 0xb8, 0x0c, 0x65, 0xe8, 0x35, 0xf3,
+	0xcc, 0x51, 0xd5, 0x44, 0x45, 0x74, 0x0a, 0xa6, 0xdc, 0xa6, 0xd4, 0xae, 0x0d, 0x8d, 0x71, 0x77,
+	0xf2, 0xf8, 0x1f, 0xdc, 0xdb, 0x66, 0x38, 0xd8, 0xa6, 0x54, 0xab, 0x14, 0x3c, 0xa2, 0x22, 0x7a,
+	0x07, 0x66, 0xa1, 0x6e, 0xd7, 0x87, 0xf5, 0x71, 0x6b, 0xe2, 0x1c, 0x52, 0xf1, 0x65, 0x28, 0xc5,
+	0x34, 0x91, 0xb1, 0xdc, 0x6a, 0x7a, 0x41, 0x20, 0x2a, 0xa2, 0xd7, 0xd0, 0x15, 0xe9, 0x2a, 0x96,
+	0x9f, 0x05, 0xfd, 0xba, 0xa1, 0xc9, 0x82, 0xda, 0xe6, 0xd0, 0x18, 0x77, 0x3c, 0x94, 0x67, 0xce,
+	0x1f, 0x7f, 0x48, 0x47, 0x61, 0xbf, 0x84, 0xe8, 0x19, 0xb4, 0x74, 0x81, 0xe4, 0x32, 0x5c, 0xd9,
+	0x0d, 0xc5, 0x7b, 0x90, 0x67, 0x4e, 0x75, 0x99, 0x80, 0x02, 0x41, 0x91, 0x8f, 0x9e, 0x82, 0x59,
+	0x9c, 0x01, 0x01, 0x1c, 0xf9, 0xe7, 0x7e, 0x30, 0x9d, 0xf5, 0xee, 0xa1, 0x36, 0x34, 0x3f, 0xce,
+	0xfd, 0xe0, 0x64, 0xfe, 0x7e, 0xda, 0x33, 0x90, 0x05, 0x8d, 0x93, 0x0f, 0xd3, 0x79, 0xd0, 0xab,
+	0x8d, 0x66, 0xd0, 0xf6, 0x63, 0x96, 0xae, 0xa8, 0x36, 0x01, 0x1d, 0x83, 0x99, 0x84, 0x8c, 0x2a,
+	0xab, 0x2d, 0x7d, 0x8e, 0x02, 0x13, 0x15, 0x91, 0x03, 0x8d, 0xeb, 0x70, 0xb5, 0xd1, 0x6e, 0x1a,
+	0x9e, 0x95, 0x67, 0x8e, 0x5e, 0x20, 0xfa, 0x33, 0x3a, 0x03, 0xeb, 0x34, 0x66, 0x34, 0x11, 0x31,
+	0x4f, 0xfe, 0x47, 0xcb, 0x3a, 0xa0, 0xf5, 0xbd, 0x06, 0xad, 0x8a, 0xa9, 0xe8, 0x15, 0x58, 0x37,
+	0xa3, 0x52, 0x8e, 0x42, 0x1f, 0xeb, 0x61, 0xc2, 0xfb, 0x61, 0xc2, 0xc1, 0xbe, 0x82, 0xdc, 0x16,
+	0xa3, 0x19, 0xc0, 0x72, 0xbf, 0x2b, 0x51, 0xde, 0xe1, 0xa3, 0x43, 0x77, 0x78, 0xb3, 0x77, 0xaf,
+	0x9b, 0x67, 0x4e, 0x85, 0x44, 0x2a, 0x39, 0x3a, 0x87, 0x8e, 0x50, 0x9e, 0x95, 0xcf, 0xc0, 0x36,
+	0x95, 0xe2, 0xf0, 0xe0, 0x54, 0x54, 0xcc, 0xf5, 0x1e, 0xe6, 0x99, 0x73, 0x97, 0x4a, 0xee, 0xc2,
+	0x33, 0xb3, 0x59, 0xeb, 0xd5, 0xc9, 0xfd, 0x12, 0x7a, 0x2f, 0x7f, 0xec, 0x06, 0xc6, 0xcf, 0xdd,
+	0xc0, 0xf8, 0xb5, 0x1b, 0x18, 0x9f, 0x9e, 0x44, 0xb1, 0xfc, 0xb2, 0xb9, 0xc0, 0x0b, 0xce, 0x5c,
+	0xd5, 0xc7, 0x55, 0x7d, 0x5c, 0xb1, 0xbc, 0x72, 0xaf, 0x27, 0xfa, 0x41, 0xbd, 0xd5, 0x4e, 0x1c,
+	0xa9, 0xcf, 0xf3, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xbd, 0x41, 0xe0, 0xc5, 0xaa, 0x03, 0x00,
+	0x00,
 }
 
 func (m *MetricsReport) Marshal() (dAtA []byte, err error) {
@@ -374,6 +400,16 @@ func (m *MetricsReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.SplitTotal != 0 {
+		i = encodeVarintMetrics(dAtA, i, uint64(m.SplitTotal))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.SplitSequence != 0 {
+		i = encodeVarintMetrics(dAtA, i, uint64(m.SplitSequence))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.Data) > 0 {
 		for iNdEx := len(m.Data) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -586,6 +622,12 @@ func (m *MetricsReport) Size() (n int) {
 			n += 1 + l + sovMetrics(uint64(l))
 		}
 	}
+	if m.SplitSequence != 0 {
+		n += 1 + sovMetrics(uint64(m.SplitSequence))
+	}
+	if m.SplitTotal != 0 {
+		n += 1 + sovMetrics(uint64(m.SplitTotal))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -783,6 +825,44 @@ func (m *MetricsReport) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitSequence", wireType)
+			}
+			m.SplitSequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SplitSequence |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitTotal", wireType)
+			}
+			m.SplitTotal = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SplitTotal |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetrics(dAtA[iNdEx:])
@@ -92,7 +92,7 @@ func (x NginxSslMetaData_NginxSslType) String() string {
 }
 
 func (NginxSslMetaData_NginxSslType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_917f1a70b1fd7255, []int{2, 0}
+	return fileDescriptor_917f1a70b1fd7255, []int{4, 0}
 }
 
 // NGINX status enum
@@ -124,7 +124,7 @@ func (x NginxHealth_NginxStatus) String() string {
 }
 
 func (NginxHealth_NginxStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_917f1a70b1fd7255, []int{3, 0}
+	return fileDescriptor_917f1a70b1fd7255, []int{5, 0}
 }
 
 // swagger:model NginxDetails
@@ -166,10 +166,16 @@ type NginxDetails struct {
 	StatusUrl string `protobuf:"bytes,12,opt,name=status_url,json=statusUrl,proto3" json:"status_url"`
 	// Command line arguments that were used when the NGINX instance was started.
 	// Example: [ "", "with-http_stub_status_module" ]
-	ConfigureArgs        []string `protobuf:"bytes,13,rep,name=configure_args,json=configureArgs,proto3" json:"configure_args"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ConfigureArgs []string `protobuf:"bytes,13,rep,name=configure_args,json=configureArgs,proto3" json:"configure_args"`
+	// List of worker processes belonging to this NGINX instance.
+	// Example: null
+	Workers []*NginxWorkerProcess `protobuf:"bytes,14,rep,name=workers,proto3" json:"workers"`
+	// List of modules referenced by load_module directives in the configuration.
+	// Example: null
+	LoadedModules        []*NginxLoadedModule `protobuf:"bytes,15,rep,name=loaded_modules,json=loadedModules,proto3" json:"loaded_modules"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *NginxDetails) Reset()         { *m = NginxDetails{} }
@@ -296,6 +302,180 @@ func (m *NginxDetails) GetConfigureArgs() []string {
 	return nil
 }
 
+func (m *NginxDetails) GetWorkers() []*NginxWorkerProcess {
+	if m != nil {
+		return m.Workers
+	}
+	return nil
+}
+
+func (m *NginxDetails) GetLoadedModules() []*NginxLoadedModule {
+	if m != nil {
+		return m.LoadedModules
+	}
+	return nil
+}
+
+// Represents a dynamically loaded module referenced by a load_module directive
+type NginxLoadedModule struct {
+	// Name of the module, derived from the file name.
+	// Example: ngx_http_geoip_module
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name"`
+	// Path of the module binary as resolved from the load_module directive.
+	// Example: /usr/lib/nginx/modules/ngx_http_geoip_module.so
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path"`
+	// Whether the module binary is present on disk.
+	// Example: true
+	Exists bool `protobuf:"varint,3,opt,name=exists,proto3" json:"exists"`
+	// Size of the module binary in bytes.
+	// Example: 24576
+	Size_ int64 `protobuf:"varint,4,opt,name=size,proto3" json:"size"`
+	// Binary compatibility signature embedded in the module, when one was found.
+	// Example: 8,4,8,0011111010011111101110
+	Signature string `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature"`
+	// Version information embedded in the module binary, when one was found.
+	// Example: nginx/1.23.2
+	Version              string   `protobuf:"bytes,6,opt,name=version,proto3" json:"version"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NginxLoadedModule) Reset()         { *m = NginxLoadedModule{} }
+func (m *NginxLoadedModule) String() string { return proto.CompactTextString(m) }
+func (*NginxLoadedModule) ProtoMessage()    {}
+func (*NginxLoadedModule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_917f1a70b1fd7255, []int{1}
+}
+func (m *NginxLoadedModule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NginxLoadedModule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NginxLoadedModule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NginxLoadedModule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NginxLoadedModule.Merge(m, src)
+}
+func (m *NginxLoadedModule) XXX_Size() int {
+	return m.Size()
+}
+func (m *NginxLoadedModule) XXX_DiscardUnknown() {
+	xxx_messageInfo_NginxLoadedModule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NginxLoadedModule proto.InternalMessageInfo
+
+func (m *NginxLoadedModule) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NginxLoadedModule) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *NginxLoadedModule) GetExists() bool {
+	if m != nil {
+		return m.Exists
+	}
+	return false
+}
+
+func (m *NginxLoadedModule) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *NginxLoadedModule) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+func (m *NginxLoadedModule) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+// Represents a worker process of a NGINX instance
+type NginxWorkerProcess struct {
+	// Process ID of the worker.
+	// Example: 9
+	ProcessId string `protobuf:"bytes,1,opt,name=process_id,json=processId,proto3" json:"process_id"`
+	// The start time of the worker process.
+	// Example: 1670429190000
+	StartTime            int64    `protobuf:"varint,2,opt,name=start_time,json=startTime,proto3" json:"start_time"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NginxWorkerProcess) Reset()         { *m = NginxWorkerProcess{} }
+func (m *NginxWorkerProcess) String() string { return proto.CompactTextString(m) }
+func (*NginxWorkerProcess) ProtoMessage()    {}
+func (*NginxWorkerProcess) Descriptor() ([]byte, []int) {
+	return fileDescriptor_917f1a70b1fd7255, []int{2}
+}
+func (m *NginxWorkerProcess) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NginxWorkerProcess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NginxWorkerProcess.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NginxWorkerProcess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NginxWorkerProcess.Merge(m, src)
+}
+func (m *NginxWorkerProcess) XXX_Size() int {
+	return m.Size()
+}
+func (m *NginxWorkerProcess) XXX_DiscardUnknown() {
+	xxx_messageInfo_NginxWorkerProcess.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NginxWorkerProcess proto.InternalMessageInfo
+
+func (m *NginxWorkerProcess) GetProcessId() string {
+	if m != nil {
+		return m.ProcessId
+	}
+	return ""
+}
+
+func (m *NginxWorkerProcess) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
 // swagger:model NginxPlusMetaData
 // Represents NGINX Plus metadata
 type NginxPlusMetaData struct {
@@ -314,7 +494,7 @@ func (m *NginxPlusMetaData) Reset()         { *m = NginxPlusMetaData{} }
 func (m *NginxPlusMetaData) String() string { return proto.CompactTextString(m) }
 func (*NginxPlusMetaData) ProtoMessage()    {}
 func (*NginxPlusMetaData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_917f1a70b1fd7255, []int{1}
+	return fileDescriptor_917f1a70b1fd7255, []int{3}
 }
 func (m *NginxPlusMetaData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -375,7 +555,7 @@ func (m *NginxSslMetaData) Reset()         { *m = NginxSslMetaData{} }
 func (m *NginxSslMetaData) String() string { return proto.CompactTextString(m) }
 func (*NginxSslMetaData) ProtoMessage()    {}
 func (*NginxSslMetaData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_917f1a70b1fd7255, []int{2}
+	return fileDescriptor_917f1a70b1fd7255, []int{4}
 }
 func (m *NginxSslMetaData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -435,7 +615,7 @@ func (m *NginxHealth) Reset()         { *m = NginxHealth{} }
 func (m *NginxHealth) String() string { return proto.CompactTextString(m) }
 func (*NginxHealth) ProtoMessage()    {}
 func (*NginxHealth) Descriptor() ([]byte, []int) {
-	return fileDescriptor_917f1a70b1fd7255, []int{3}
+	return fileDescriptor_917f1a70b1fd7255, []int{5}
 }
 func (m *NginxHealth) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -502,17 +682,20 @@ type NginxConfig struct {
 	// Information about all SSL certificates files
 	Ssl *SslCertificates `protobuf:"bytes,7,opt,name=ssl,proto3" json:"ssl"`
 	// Directory map of all config and aux files
-	DirectoryMap         *DirectoryMap `protobuf:"bytes,8,opt,name=directory_map,json=directoryMap,proto3" json:"directory_map"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	DirectoryMap *DirectoryMap `protobuf:"bytes,8,opt,name=directory_map,json=directoryMap,proto3" json:"directory_map"`
+	// Server and location structure of the parsed config with the include-tree
+	// provenance of every block
+	Topology             *ConfigTopology `protobuf:"bytes,9,opt,name=topology,proto3" json:"topology"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *NginxConfig) Reset()         { *m = NginxConfig{} }
 func (m *NginxConfig) String() string { return proto.CompactTextString(m) }
 func (*NginxConfig) ProtoMessage()    {}
 func (*NginxConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_917f1a70b1fd7255, []int{4}
+	return fileDescriptor_917f1a70b1fd7255, []int{6}
 }
 func (m *NginxConfig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -597,6 +780,234 @@ func (m *NginxConfig) GetDirectoryMap() *DirectoryMap {
 	return nil
 }
 
+func (m *NginxConfig) GetTopology() *ConfigTopology {
+	if m != nil {
+		return m.Topology
+	}
+	return nil
+}
+
+// Represents the server and location structure of the parsed config. Each block
+// carries the file and line it was read from, resolved through the include tree,
+// so consumers can tell which file defines a given virtual server or location
+type ConfigTopology struct {
+	// Server blocks of the config, in configuration order
+	Servers              []*ServerBlock `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ConfigTopology) Reset()         { *m = ConfigTopology{} }
+func (m *ConfigTopology) String() string { return proto.CompactTextString(m) }
+func (*ConfigTopology) ProtoMessage()    {}
+func (*ConfigTopology) Descriptor() ([]byte, []int) {
+	return fileDescriptor_917f1a70b1fd7255, []int{7}
+}
+func (m *ConfigTopology) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConfigTopology) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConfigTopology.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConfigTopology) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigTopology.Merge(m, src)
+}
+func (m *ConfigTopology) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConfigTopology) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigTopology.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigTopology proto.InternalMessageInfo
+
+func (m *ConfigTopology) GetServers() []*ServerBlock {
+	if m != nil {
+		return m.Servers
+	}
+	return nil
+}
+
+// Represents one server block of the parsed config
+type ServerBlock struct {
+	// server_name values of the server block
+	ServerNames []string `protobuf:"bytes,1,rep,name=server_names,json=serverNames,proto3" json:"server_names"`
+	// Addresses of the listen directives of the server block
+	Listen []string `protobuf:"bytes,2,rep,name=listen,proto3" json:"listen"`
+	// File the server block was read from
+	File string `protobuf:"bytes,3,opt,name=file,proto3" json:"file"`
+	// Line of the server directive within the file
+	Line int32 `protobuf:"varint,4,opt,name=line,proto3" json:"line"`
+	// Location blocks of the server block, in configuration order
+	Locations            []*LocationBlock `protobuf:"bytes,5,rep,name=locations,proto3" json:"locations"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ServerBlock) Reset()         { *m = ServerBlock{} }
+func (m *ServerBlock) String() string { return proto.CompactTextString(m) }
+func (*ServerBlock) ProtoMessage()    {}
+func (*ServerBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_917f1a70b1fd7255, []int{8}
+}
+func (m *ServerBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ServerBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ServerBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ServerBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerBlock.Merge(m, src)
+}
+func (m *ServerBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *ServerBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerBlock proto.InternalMessageInfo
+
+func (m *ServerBlock) GetServerNames() []string {
+	if m != nil {
+		return m.ServerNames
+	}
+	return nil
+}
+
+func (m *ServerBlock) GetListen() []string {
+	if m != nil {
+		return m.Listen
+	}
+	return nil
+}
+
+func (m *ServerBlock) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *ServerBlock) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *ServerBlock) GetLocations() []*LocationBlock {
+	if m != nil {
+		return m.Locations
+	}
+	return nil
+}
+
+// Represents one location block of a server block
+type LocationBlock struct {
+	// Modifier of the location, such as = or ~, empty for a prefix location
+	Modifier string `protobuf:"bytes,1,opt,name=modifier,proto3" json:"modifier"`
+	// Path or pattern the location matches
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path"`
+	// File the location block was read from
+	File string `protobuf:"bytes,3,opt,name=file,proto3" json:"file"`
+	// Line of the location directive within the file
+	Line int32 `protobuf:"varint,4,opt,name=line,proto3" json:"line"`
+	// Nested location blocks
+	Locations            []*LocationBlock `protobuf:"bytes,5,rep,name=locations,proto3" json:"locations"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *LocationBlock) Reset()         { *m = LocationBlock{} }
+func (m *LocationBlock) String() string { return proto.CompactTextString(m) }
+func (*LocationBlock) ProtoMessage()    {}
+func (*LocationBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_917f1a70b1fd7255, []int{9}
+}
+func (m *LocationBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LocationBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LocationBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LocationBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocationBlock.Merge(m, src)
+}
+func (m *LocationBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *LocationBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocationBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocationBlock proto.InternalMessageInfo
+
+func (m *LocationBlock) GetModifier() string {
+	if m != nil {
+		return m.Modifier
+	}
+	return ""
+}
+
+func (m *LocationBlock) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *LocationBlock) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *LocationBlock) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *LocationBlock) GetLocations() []*LocationBlock {
+	if m != nil {
+		return m.Locations
+	}
+	return nil
+}
+
 // Represents access log files
 type AccessLogs struct {
 	// List of access log files
@@ -610,7 +1021,7 @@ func (m *AccessLogs) Reset()         { *m = AccessLogs{} }
 func (m *AccessLogs) String() string { return proto.CompactTextString(m) }
 func (*AccessLogs) ProtoMessage()    {}
 func (*AccessLogs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_917f1a70b1fd7255, []int{5}
+	return fileDescriptor_917f1a70b1fd7255, []int{10}
 }
 func (m *AccessLogs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -665,7 +1076,7 @@ func (m *AccessLog) Reset()         { *m = AccessLog{} }
 func (m *AccessLog) String() string { return proto.CompactTextString(m) }
 func (*AccessLog) ProtoMessage()    {}
 func (*AccessLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_917f1a70b1fd7255, []int{6}
+	return fileDescriptor_917f1a70b1fd7255, []int{11}
 }
 func (m *AccessLog) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -735,7 +1146,7 @@ func (m *ErrorLogs) Reset()         { *m = ErrorLogs{} }
 func (m *ErrorLogs) String() string { return proto.CompactTextString(m) }
 func (*ErrorLogs) ProtoMessage()    {}
 func (*ErrorLogs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_917f1a70b1fd7255, []int{7}
+	return fileDescriptor_917f1a70b1fd7255, []int{12}
 }
 func (m *ErrorLogs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -790,7 +1201,7 @@ func (m *ErrorLog) Reset()         { *m = ErrorLog{} }
 func (m *ErrorLog) String() string { return proto.CompactTextString(m) }
 func (*ErrorLog) ProtoMessage()    {}
 func (*ErrorLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_917f1a70b1fd7255, []int{8}
+	return fileDescriptor_917f1a70b1fd7255, []int{13}
 }
 func (m *ErrorLog) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -852,10 +1263,15 @@ func init() {
 	proto.RegisterEnum("f5.nginx.agent.sdk.NginxSslMetaData_NginxSslType", NginxSslMetaData_NginxSslType_name, NginxSslMetaData_NginxSslType_value)
 	proto.RegisterEnum("f5.nginx.agent.sdk.NginxHealth_NginxStatus", NginxHealth_NginxStatus_name, NginxHealth_NginxStatus_value)
 	proto.RegisterType((*NginxDetails)(nil), "f5.nginx.agent.sdk.NginxDetails")
+	proto.RegisterType((*NginxLoadedModule)(nil), "f5.nginx.agent.sdk.NginxLoadedModule")
+	proto.RegisterType((*NginxWorkerProcess)(nil), "f5.nginx.agent.sdk.NginxWorkerProcess")
 	proto.RegisterType((*NginxPlusMetaData)(nil), "f5.nginx.agent.sdk.NginxPlusMetaData")
 	proto.RegisterType((*NginxSslMetaData)(nil), "f5.nginx.agent.sdk.NginxSslMetaData")
 	proto.RegisterType((*NginxHealth)(nil), "f5.nginx.agent.sdk.NginxHealth")
 	proto.RegisterType((*NginxConfig)(nil), "f5.nginx.agent.sdk.NginxConfig")
+	proto.RegisterType((*ConfigTopology)(nil), "f5.nginx.agent.sdk.ConfigTopology")
+	proto.RegisterType((*ServerBlock)(nil), "f5.nginx.agent.sdk.ServerBlock")
+	proto.RegisterType((*LocationBlock)(nil), "f5.nginx.agent.sdk.LocationBlock")
 	proto.RegisterType((*AccessLogs)(nil), "f5.nginx.agent.sdk.AccessLogs")
 	proto.RegisterType((*AccessLog)(nil), "f5.nginx.agent.sdk.AccessLog")
 	proto.RegisterType((*ErrorLogs)(nil), "f5.nginx.agent.sdk.ErrorLogs")
@@ -865,80 +1281,101 @@ func init() {
 func init() { proto.RegisterFile("nginx.proto", fileDescriptor_917f1a70b1fd7255) }
 
 var fileDescriptor_917f1a70b1fd7255 = []byte{
-	// 1166 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
-	0x10, 0x0e, 0x2d, 0xd9, 0x22, 0x47, 0xb2, 0xcd, 0x6c, 0x5b, 0x80, 0x28, 0x52, 0x53, 0x60, 0x1b,
-	0xd4, 0x4d, 0x51, 0x1b, 0x71, 0x5a, 0x14, 0x45, 0x83, 0x06, 0x92, 0xa5, 0x34, 0x46, 0x14, 0xc7,
-	0x58, 0xcb, 0x69, 0xeb, 0x0b, 0xbb, 0x16, 0xd7, 0x34, 0x11, 0x4a, 0x14, 0x76, 0xa9, 0x20, 0xc9,
-	0x33, 0xf4, 0x49, 0x7a, 0xeb, 0xb1, 0x6f, 0xd0, 0x5b, 0xfb, 0x04, 0x44, 0x91, 0x23, 0x2f, 0x7d,
-	0x85, 0x82, 0xb3, 0x4b, 0x4a, 0xfe, 0x6b, 0x72, 0xe9, 0x85, 0x9a, 0xf9, 0x76, 0xbe, 0x6f, 0x39,
-	0xb3, 0xb3, 0x43, 0x41, 0x73, 0x12, 0x46, 0x93, 0x97, 0x5b, 0x53, 0x91, 0xa4, 0x09, 0x21, 0xa7,
-	0x5f, 0x6d, 0x29, 0x9f, 0x85, 0x7c, 0x92, 0x6e, 0xc9, 0xe0, 0xf9, 0x87, 0xad, 0x51, 0x32, 0x1e,
-	0x27, 0x13, 0x15, 0x51, 0x78, 0x93, 0xd3, 0x28, 0xd4, 0x1e, 0x84, 0x49, 0x98, 0x28, 0xdb, 0xfb,
-	0x73, 0x19, 0x5a, 0xfb, 0x05, 0xb7, 0xc7, 0x53, 0x16, 0xc5, 0x92, 0x7c, 0x0a, 0x26, 0x6a, 0xf9,
-	0x51, 0xe0, 0x18, 0x6d, 0x63, 0xd3, 0xea, 0xb6, 0xf2, 0xcc, 0xad, 0x30, 0xda, 0x40, 0x6b, 0x2f,
-	0x20, 0xb7, 0xa1, 0xf1, 0x82, 0x0b, 0x19, 0x25, 0x13, 0x67, 0x09, 0xe3, 0x9a, 0x79, 0xe6, 0x96,
-	0x10, 0x2d, 0x0d, 0x72, 0x07, 0xac, 0x62, 0x73, 0x7f, 0xca, 0xd2, 0x33, 0xa7, 0x86, 0x81, 0xab,
-	0x79, 0xe6, 0xce, 0x41, 0x6a, 0x16, 0xe6, 0x01, 0x4b, 0xcf, 0xc8, 0x17, 0x00, 0x53, 0x91, 0x8c,
-	0xb8, 0x94, 0xc5, 0xee, 0x75, 0x0c, 0x5e, 0xcb, 0x33, 0x77, 0x01, 0xa5, 0x96, 0xb6, 0xf7, 0x02,
-	0x72, 0x0f, 0x5a, 0xe5, 0x02, 0xaa, 0x2f, 0x23, 0xc1, 0xce, 0x33, 0xf7, 0x1c, 0x4e, 0x9b, 0xda,
-	0x2b, 0xf7, 0x90, 0x29, 0x13, 0xa9, 0x9f, 0x46, 0x63, 0xee, 0xac, 0xb4, 0x8d, 0xcd, 0x9a, 0xda,
-	0x63, 0x8e, 0x52, 0x0b, 0xed, 0x61, 0x34, 0xe6, 0xa4, 0x03, 0x37, 0x4f, 0x66, 0x51, 0x9c, 0xfa,
-	0xa7, 0x22, 0x19, 0xfb, 0x32, 0x99, 0x89, 0x11, 0x77, 0x1a, 0x6d, 0x63, 0xd3, 0xec, 0x7e, 0x90,
-	0x67, 0xee, 0xe5, 0x45, 0xba, 0x8e, 0xd0, 0x43, 0x91, 0x8c, 0x0f, 0x11, 0x20, 0x0f, 0xc0, 0x8e,
-	0x13, 0x16, 0xb0, 0x93, 0x98, 0xfb, 0xe3, 0x24, 0x98, 0xc5, 0x5c, 0x3a, 0x66, 0xbb, 0xb6, 0x69,
-	0x75, 0xdf, 0xcf, 0x33, 0xf7, 0xd2, 0x1a, 0x5d, 0x2f, 0x91, 0x27, 0x0a, 0x20, 0xf7, 0x61, 0x5d,
-	0xcc, 0x26, 0xc5, 0x9b, 0x55, 0x7c, 0x0b, 0xf9, 0xef, 0xe5, 0x99, 0x7b, 0x71, 0x89, 0xae, 0x69,
-	0xa0, 0x64, 0xef, 0x42, 0x7d, 0x1a, 0xcf, 0xa4, 0x03, 0x6d, 0x63, 0xb3, 0xb9, 0x73, 0x7b, 0xeb,
-	0x72, 0xb3, 0x6c, 0x61, 0x03, 0x1c, 0xc4, 0x33, 0xf9, 0x84, 0xa7, 0xac, 0xc7, 0x52, 0xd6, 0x35,
-	0xf3, 0xcc, 0x45, 0x1a, 0xc5, 0x27, 0x79, 0x00, 0x35, 0x29, 0x63, 0xa7, 0x89, 0x1a, 0x9f, 0x5c,
-	0xab, 0x71, 0x28, 0xe3, 0x4a, 0xa2, 0x91, 0x67, 0x6e, 0x41, 0xa2, 0xc5, 0x43, 0x97, 0x3d, 0x9d,
-	0x49, 0x7f, 0x26, 0x62, 0xa7, 0x35, 0x3f, 0xda, 0x39, 0x8a, 0x65, 0x4f, 0x67, 0xf2, 0x48, 0xc4,
-	0xe4, 0x1b, 0x58, 0x53, 0x2d, 0x3b, 0x13, 0xdc, 0x67, 0x22, 0x94, 0xce, 0x2a, 0x66, 0x4c, 0xf2,
-	0xcc, 0xbd, 0xb0, 0x42, 0x57, 0x2b, 0xbf, 0x23, 0x42, 0xe9, 0x31, 0xb8, 0x79, 0x29, 0x9f, 0xa2,
-	0x59, 0xf9, 0xa4, 0xa8, 0xa9, 0x6a, 0x6a, 0x53, 0x35, 0xab, 0x86, 0x68, 0x69, 0x14, 0x61, 0x82,
-	0xc7, 0x9c, 0x49, 0xbe, 0xd8, 0xd3, 0x1a, 0xa2, 0xa5, 0xe1, 0xfd, 0x6e, 0x80, 0x7d, 0x31, 0x5f,
-	0x72, 0x0c, 0xa6, 0x94, 0xb1, 0x9f, 0xbe, 0x9a, 0x72, 0xdc, 0x63, 0x6d, 0xe7, 0xee, 0xbb, 0xd4,
-	0xa9, 0x02, 0x86, 0xaf, 0xa6, 0x5c, 0xdd, 0xb5, 0x52, 0x86, 0x36, 0xa4, 0x82, 0x8b, 0xf7, 0x0a,
-	0xd4, 0xfd, 0x74, 0x96, 0xb0, 0x0e, 0xf8, 0x5e, 0x1a, 0xa2, 0xa5, 0xe1, 0x79, 0xfa, 0x2e, 0x6b,
-	0x35, 0x62, 0xc1, 0x72, 0xf7, 0x68, 0x6f, 0x30, 0xb4, 0x6f, 0x90, 0x06, 0xd4, 0xe8, 0xd1, 0xbe,
-	0x6d, 0x78, 0xbf, 0x2c, 0x41, 0x13, 0x83, 0x1e, 0x71, 0x16, 0xa7, 0x67, 0xef, 0x7e, 0xdf, 0x7f,
-	0x86, 0x96, 0x02, 0xd5, 0x29, 0x61, 0x81, 0xd6, 0x76, 0x3e, 0xbf, 0x36, 0x47, 0xa5, 0xaf, 0xd3,
-	0x43, 0x8a, 0xba, 0x9a, 0x8b, 0x22, 0x54, 0xcd, 0x31, 0xb5, 0x5c, 0xf4, 0x79, 0xc0, 0x43, 0xc1,
-	0x02, 0x1e, 0xf8, 0x82, 0x33, 0x99, 0x4c, 0xf4, 0xc0, 0xc0, 0x3e, 0xbf, 0xb0, 0x44, 0xd7, 0x4a,
-	0x80, 0xa2, 0xef, 0x7d, 0xa9, 0xf3, 0xd2, 0x62, 0x4d, 0x68, 0x1c, 0xed, 0x3f, 0xde, 0x7f, 0xfa,
-	0xc3, 0xbe, 0x7d, 0x83, 0x00, 0xac, 0x74, 0x76, 0x87, 0x7b, 0xcf, 0xfa, 0xb6, 0x41, 0x5a, 0x60,
-	0xf6, 0xfa, 0xdf, 0xd3, 0x4e, 0xaf, 0xdf, 0xb3, 0x97, 0xbc, 0x7f, 0xea, 0x9a, 0xb6, 0x8b, 0x4d,
-	0x44, 0xf6, 0x60, 0x85, 0x8d, 0xd2, 0x62, 0xa8, 0xa9, 0x33, 0xbc, 0xfe, 0xbe, 0x28, 0x42, 0x07,
-	0x83, 0xbb, 0x90, 0x67, 0xae, 0x26, 0x52, 0xfd, 0x4b, 0x9e, 0x41, 0x53, 0x75, 0xa6, 0x1f, 0xb0,
-	0x94, 0x61, 0xbd, 0xae, 0xb9, 0x3b, 0x4a, 0xaa, 0xc7, 0xe5, 0x48, 0x44, 0xd3, 0x34, 0x11, 0xdd,
-	0xf5, 0x3c, 0x73, 0x17, 0xc9, 0x14, 0x94, 0x83, 0x8d, 0xd6, 0x87, 0xc6, 0x6b, 0xe5, 0x62, 0x79,
-	0x9a, 0x3b, 0x1b, 0x57, 0x69, 0x1e, 0x47, 0xd3, 0x29, 0x0f, 0x1e, 0x46, 0x31, 0x57, 0xcd, 0xa2,
-	0x29, 0xb4, 0x34, 0xc8, 0x7d, 0xa8, 0xbf, 0x66, 0xb3, 0x97, 0x38, 0x66, 0xdf, 0xae, 0x81, 0x03,
-	0xa1, 0x88, 0xa7, 0xf8, 0x24, 0x07, 0xd0, 0x64, 0x23, 0x1c, 0xb1, 0x71, 0x12, 0x4a, 0x1c, 0xbd,
-	0xd7, 0x88, 0x74, 0x30, 0x6c, 0x90, 0x84, 0x52, 0xa5, 0xb5, 0x40, 0xa3, 0xc0, 0xaa, 0x45, 0x32,
-	0x00, 0xe0, 0x42, 0x24, 0x42, 0x09, 0xae, 0xa0, 0xe0, 0x47, 0x57, 0x09, 0xf6, 0x8b, 0x28, 0xd4,
-	0xc3, 0x01, 0x32, 0x27, 0x51, 0x8b, 0x97, 0x4b, 0xe4, 0x3b, 0x35, 0xb0, 0x1a, 0x28, 0xf3, 0xf1,
-	0x55, 0x32, 0x87, 0x32, 0xde, 0xe5, 0x22, 0x8d, 0x4e, 0xa3, 0x11, 0x4b, 0xb9, 0xbc, 0x30, 0xaf,
-	0x7e, 0x84, 0xd5, 0x20, 0x12, 0x7c, 0x94, 0x26, 0xe2, 0x95, 0x3f, 0x66, 0x53, 0xc7, 0x44, 0xa5,
-	0xf6, 0x55, 0x4a, 0xbd, 0x32, 0xf0, 0x09, 0x9b, 0x76, 0x6f, 0xe6, 0x99, 0x7b, 0x9e, 0x4a, 0x5b,
-	0xc1, 0x42, 0x80, 0x77, 0x0c, 0xd0, 0x39, 0x97, 0xf5, 0xbc, 0x20, 0x8e, 0xd1, 0xae, 0x5d, 0x97,
-	0x75, 0xc5, 0x51, 0x59, 0xcf, 0x49, 0xd4, 0xaa, 0x8a, 0xe8, 0xfd, 0x6a, 0x80, 0x55, 0x05, 0x92,
-	0x5b, 0x50, 0x9f, 0xb0, 0x31, 0xd7, 0xd7, 0x1a, 0x4f, 0xb0, 0xf0, 0x29, 0x3e, 0x89, 0x07, 0x2b,
-	0xa7, 0x89, 0x18, 0xb3, 0x54, 0x8f, 0x3a, 0x6c, 0x61, 0x85, 0x50, 0xfd, 0x4b, 0xee, 0x42, 0x73,
-	0xca, 0xc5, 0x38, 0x92, 0xc5, 0xa7, 0x5c, 0xea, 0xdb, 0x88, 0xa7, 0xb8, 0x00, 0xd3, 0x45, 0x87,
-	0x6c, 0x82, 0x29, 0xb8, 0xfa, 0x7e, 0x61, 0x6b, 0x99, 0x6a, 0x9e, 0x94, 0x18, 0xad, 0x2c, 0xef,
-	0x08, 0xac, 0xea, 0x28, 0xc9, 0x23, 0xb0, 0xaa, 0x83, 0xd4, 0x65, 0xb8, 0xf5, 0x5f, 0x87, 0xaf,
-	0xfe, 0x44, 0x54, 0x14, 0x6a, 0x96, 0x47, 0xef, 0xfd, 0x66, 0x80, 0x59, 0x46, 0xbd, 0xa5, 0x04,
-	0x77, 0xc0, 0x8a, 0x93, 0xd0, 0x8f, 0xf9, 0x0b, 0x1e, 0xeb, 0x2a, 0xa0, 0x6c, 0x05, 0x52, 0x33,
-	0x4e, 0xc2, 0x41, 0x61, 0xfd, 0xaf, 0xa5, 0xb8, 0x73, 0xac, 0xbf, 0x59, 0x8b, 0x33, 0xe5, 0xfc,
-	0x04, 0xb3, 0x60, 0xb9, 0x73, 0x70, 0x30, 0xf8, 0xc9, 0x36, 0x88, 0x09, 0xf5, 0x61, 0xff, 0x70,
-	0x68, 0x2f, 0x15, 0xa3, 0x8c, 0x3e, 0x1d, 0x0c, 0xba, 0x9d, 0xdd, 0xc7, 0x76, 0xad, 0x18, 0x72,
-	0xb4, 0x3f, 0x3c, 0xa2, 0xfb, 0x76, 0xbd, 0x08, 0x7f, 0xf8, 0x94, 0xee, 0xf6, 0xed, 0xe5, 0xee,
-	0xd7, 0x7f, 0xbc, 0xd9, 0x30, 0xfe, 0x7a, 0xb3, 0x61, 0xfc, 0xfd, 0x66, 0xc3, 0x38, 0xfe, 0x2c,
-	0x8c, 0xd2, 0xb3, 0xd9, 0xc9, 0xd6, 0x28, 0x19, 0x6f, 0x63, 0x6d, 0xb7, 0xb1, 0xb6, 0xdb, 0x32,
-	0x78, 0xbe, 0xfd, 0x62, 0x67, 0x1b, 0xff, 0x14, 0x7e, 0x8b, 0xcf, 0x93, 0x15, 0xfc, 0xb9, 0xf7,
-	0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4d, 0x80, 0x5c, 0xfb, 0x6c, 0x0a, 0x00, 0x00,
+	// 1495 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0x4f, 0x6f, 0xdb, 0x46,
+	0x16, 0x0f, 0x2d, 0x5b, 0x22, 0x9f, 0x64, 0x59, 0x99, 0xdd, 0x05, 0x88, 0x45, 0x62, 0x7a, 0xb9,
+	0x9b, 0x5d, 0x6f, 0x82, 0xb5, 0x11, 0x67, 0x17, 0x8b, 0xa2, 0x41, 0x03, 0xc9, 0x96, 0x1b, 0x23,
+	0xb2, 0x62, 0x8c, 0xe5, 0x24, 0xf5, 0x45, 0xa1, 0xc5, 0x31, 0x4d, 0x98, 0x12, 0x85, 0x19, 0x2a,
+	0x8d, 0x73, 0xea, 0x07, 0xe8, 0x27, 0xe9, 0xad, 0xc7, 0x1e, 0x7a, 0xef, 0xb1, 0x9f, 0x40, 0x28,
+	0x52, 0xf4, 0xa2, 0x63, 0x3f, 0x41, 0x31, 0x6f, 0x86, 0x14, 0x65, 0x5b, 0x71, 0x2e, 0x05, 0x7a,
+	0x19, 0xbd, 0xf7, 0x9b, 0xf7, 0x7e, 0xe4, 0xbc, 0x79, 0x7f, 0x28, 0x28, 0x0f, 0x82, 0x70, 0xf0,
+	0x76, 0x63, 0xc8, 0xe3, 0x24, 0x26, 0xe4, 0xf4, 0x7f, 0x1b, 0x4a, 0xf7, 0x02, 0x36, 0x48, 0x36,
+	0x84, 0x7f, 0xfe, 0xd7, 0x4a, 0x2f, 0xee, 0xf7, 0xe3, 0x81, 0xb2, 0x90, 0xda, 0xe0, 0x34, 0x0c,
+	0xb4, 0x06, 0x41, 0x1c, 0xc4, 0x4a, 0x76, 0xbf, 0x2a, 0x41, 0xa5, 0x2d, 0x7d, 0x77, 0x58, 0xe2,
+	0x85, 0x91, 0x20, 0xff, 0x02, 0x13, 0xb9, 0xba, 0xa1, 0x6f, 0x1b, 0x6b, 0xc6, 0xba, 0xd5, 0xa8,
+	0x4c, 0xc6, 0x4e, 0x86, 0xd1, 0x12, 0x4a, 0x7b, 0x3e, 0xb9, 0x07, 0xa5, 0x37, 0x8c, 0x8b, 0x30,
+	0x1e, 0xd8, 0x0b, 0x68, 0x57, 0x9e, 0x8c, 0x9d, 0x14, 0xa2, 0xa9, 0x40, 0xee, 0x83, 0x25, 0x1f,
+	0xde, 0x1d, 0x7a, 0xc9, 0x99, 0x5d, 0x40, 0xc3, 0xe5, 0xc9, 0xd8, 0x99, 0x82, 0xd4, 0x94, 0xe2,
+	0x81, 0x97, 0x9c, 0x91, 0xff, 0x00, 0x0c, 0x79, 0xdc, 0x63, 0x42, 0xc8, 0xa7, 0x2f, 0xa2, 0x71,
+	0x75, 0x32, 0x76, 0x72, 0x28, 0xb5, 0xb4, 0xbc, 0xe7, 0x93, 0x47, 0x50, 0x49, 0x37, 0x90, 0x7d,
+	0x09, 0x1d, 0x6a, 0x93, 0xb1, 0x33, 0x83, 0xd3, 0xb2, 0xd6, 0xd2, 0x67, 0x88, 0xc4, 0xe3, 0x49,
+	0x37, 0x09, 0xfb, 0xcc, 0x2e, 0xae, 0x19, 0xeb, 0x05, 0xf5, 0x8c, 0x29, 0x4a, 0x2d, 0x94, 0x3b,
+	0x61, 0x9f, 0x91, 0x3a, 0xdc, 0x3e, 0x19, 0x85, 0x51, 0xd2, 0x3d, 0xe5, 0x71, 0xbf, 0x2b, 0xe2,
+	0x11, 0xef, 0x31, 0xbb, 0xb4, 0x66, 0xac, 0x9b, 0x8d, 0xbf, 0x4c, 0xc6, 0xce, 0xd5, 0x4d, 0xba,
+	0x82, 0xd0, 0x2e, 0x8f, 0xfb, 0x87, 0x08, 0x90, 0x27, 0x50, 0x8b, 0x62, 0xcf, 0xf7, 0x4e, 0x22,
+	0xd6, 0xed, 0xc7, 0xfe, 0x28, 0x62, 0xc2, 0x36, 0xd7, 0x0a, 0xeb, 0x56, 0xe3, 0xcf, 0x93, 0xb1,
+	0x73, 0x65, 0x8f, 0xae, 0xa4, 0xc8, 0xbe, 0x02, 0xc8, 0x63, 0x58, 0xe1, 0xa3, 0x81, 0x7c, 0xb3,
+	0xcc, 0xdf, 0x42, 0xff, 0x3f, 0x4d, 0xc6, 0xce, 0xe5, 0x2d, 0x5a, 0xd5, 0x40, 0xea, 0xbd, 0x0d,
+	0x8b, 0xc3, 0x68, 0x24, 0x6c, 0x58, 0x33, 0xd6, 0xcb, 0x5b, 0xf7, 0x36, 0xae, 0x26, 0xcb, 0x06,
+	0x26, 0xc0, 0x41, 0x34, 0x12, 0xfb, 0x2c, 0xf1, 0x76, 0xbc, 0xc4, 0x6b, 0x98, 0x93, 0xb1, 0x83,
+	0x6e, 0x14, 0x57, 0xf2, 0x04, 0x0a, 0x42, 0x44, 0x76, 0x19, 0x39, 0xfe, 0x31, 0x97, 0xe3, 0x50,
+	0x44, 0x19, 0x45, 0x69, 0x32, 0x76, 0xa4, 0x13, 0x95, 0x8b, 0x0e, 0x7b, 0x32, 0x12, 0xdd, 0x11,
+	0x8f, 0xec, 0xca, 0xf4, 0x6a, 0xa7, 0x28, 0x86, 0x3d, 0x19, 0x89, 0x23, 0x1e, 0x91, 0x4f, 0xa0,
+	0xaa, 0x52, 0x76, 0xc4, 0x59, 0xd7, 0xe3, 0x81, 0xb0, 0x97, 0xf1, 0xc4, 0x64, 0x32, 0x76, 0x2e,
+	0xed, 0xd0, 0xe5, 0x4c, 0xaf, 0xf3, 0x40, 0x90, 0x7d, 0x28, 0x7d, 0x19, 0xf3, 0x73, 0xc6, 0x85,
+	0x5d, 0x5d, 0x2b, 0xac, 0x97, 0xb7, 0xfe, 0x39, 0xf7, 0x75, 0x5f, 0xa2, 0xdd, 0x81, 0xca, 0x0e,
+	0x95, 0xbf, 0xda, 0x95, 0xa6, 0x02, 0x79, 0x0d, 0x55, 0x79, 0x1f, 0xcc, 0xcf, 0x62, 0xbf, 0x82,
+	0xac, 0xf3, 0x03, 0xd9, 0x42, 0x73, 0x15, 0x7e, 0xf5, 0xc2, 0xb3, 0x04, 0x74, 0x39, 0xca, 0x59,
+	0x08, 0xf7, 0x17, 0x03, 0x6e, 0x5f, 0x71, 0x24, 0x77, 0x60, 0x71, 0xe0, 0xf5, 0x99, 0xae, 0x41,
+	0xbc, 0x0f, 0xa9, 0x53, 0x5c, 0xe5, 0x2e, 0xa6, 0xfc, 0xc2, 0x74, 0x17, 0x53, 0x1d, 0x57, 0xe2,
+	0x42, 0x91, 0xbd, 0x0d, 0x45, 0x22, 0xb0, 0xe0, 0xcc, 0x06, 0x4c, 0xc6, 0x8e, 0x46, 0xa8, 0xfe,
+	0x95, 0x0c, 0x22, 0x7c, 0xc7, 0xb0, 0xca, 0x0a, 0x8a, 0x41, 0xea, 0x14, 0x57, 0xf2, 0x00, 0x2c,
+	0x11, 0x06, 0x03, 0x2f, 0x19, 0x71, 0xa6, 0xeb, 0x0a, 0xab, 0x36, 0x03, 0xe9, 0x54, 0xcc, 0x77,
+	0x82, 0xe2, 0xfc, 0x4e, 0xe0, 0x72, 0x20, 0x57, 0xa3, 0x7e, 0xa9, 0xe6, 0x8d, 0x9b, 0x6a, 0x7e,
+	0xb6, 0x7c, 0x17, 0x6e, 0x28, 0x5f, 0xd7, 0xd3, 0xa1, 0xcd, 0x27, 0xb7, 0x7c, 0x5f, 0x36, 0x90,
+	0x05, 0xa6, 0x9e, 0x67, 0xaa, 0xf7, 0xd5, 0x10, 0x4d, 0x05, 0x69, 0xc6, 0x59, 0xc4, 0x3c, 0xc1,
+	0xf2, 0x0d, 0x4e, 0x43, 0x34, 0x15, 0xdc, 0xef, 0x0c, 0xa8, 0x5d, 0x4e, 0x7e, 0x72, 0x0c, 0xa6,
+	0x10, 0x51, 0x37, 0xb9, 0x18, 0xaa, 0x1b, 0xac, 0x6e, 0x3d, 0xfc, 0x98, 0xa2, 0xc9, 0x80, 0xce,
+	0xc5, 0x90, 0xa9, 0xc6, 0x9b, 0xd2, 0xd0, 0x92, 0x50, 0xb0, 0x7c, 0x2f, 0x5f, 0x35, 0x6b, 0x7b,
+	0x01, 0x8b, 0x02, 0xdf, 0x4b, 0x43, 0x34, 0x15, 0x5c, 0x57, 0x37, 0x76, 0xcd, 0x46, 0x2c, 0x58,
+	0x6a, 0x1c, 0xed, 0xb5, 0x3a, 0xb5, 0x5b, 0xa4, 0x04, 0x05, 0x7a, 0xd4, 0xae, 0x19, 0xee, 0xd7,
+	0x0b, 0x50, 0x46, 0xa3, 0xa7, 0xcc, 0x8b, 0x92, 0xb3, 0x8f, 0x6f, 0xfe, 0xaf, 0xa1, 0xa2, 0x40,
+	0x55, 0xb2, 0x18, 0xa0, 0xea, 0xd6, 0x83, 0xb9, 0x67, 0x54, 0xfc, 0xfa, 0x78, 0xe8, 0xa2, 0xfa,
+	0x74, 0x9e, 0x84, 0xaa, 0xa1, 0xa6, 0xb6, 0x65, 0xd3, 0xf3, 0x59, 0xc0, 0xb1, 0x70, 0x38, 0xf3,
+	0x44, 0x3c, 0xd0, 0xd3, 0x03, 0x9b, 0xde, 0xa5, 0x2d, 0x5a, 0x4d, 0x01, 0x8a, 0xba, 0xfb, 0x5f,
+	0x7d, 0x2e, 0x4d, 0x56, 0x86, 0xd2, 0x51, 0xfb, 0x59, 0xfb, 0xf9, 0xcb, 0x76, 0xed, 0x16, 0x01,
+	0x28, 0xd6, 0xb7, 0x3b, 0x7b, 0x2f, 0x9a, 0x35, 0x83, 0x54, 0xc0, 0xdc, 0x69, 0x7e, 0x4e, 0xeb,
+	0x3b, 0xcd, 0x9d, 0xda, 0x82, 0xfb, 0xfd, 0x92, 0x76, 0xdb, 0xc6, 0x8e, 0x42, 0xf6, 0xa0, 0xe8,
+	0xf5, 0x12, 0x99, 0xd7, 0xea, 0x0e, 0xe7, 0xd7, 0xbc, 0x72, 0xa8, 0xa3, 0xb1, 0x2a, 0x37, 0xe5,
+	0x48, 0xf5, 0x2f, 0x79, 0x01, 0x65, 0xd5, 0xa6, 0xba, 0xbe, 0x97, 0x78, 0x18, 0xaf, 0x39, 0x8d,
+	0x54, 0x51, 0xed, 0x30, 0xd1, 0xe3, 0xe1, 0x30, 0x89, 0x79, 0x63, 0x65, 0x32, 0x76, 0xf2, 0xce,
+	0x14, 0x94, 0x82, 0x89, 0xd6, 0x84, 0xd2, 0x3b, 0xa5, 0x62, 0x78, 0xca, 0x5b, 0xab, 0xd7, 0x71,
+	0x1e, 0x87, 0xc3, 0x21, 0xf3, 0x77, 0xc3, 0x88, 0xa9, 0x64, 0xd1, 0x2e, 0x34, 0x15, 0xc8, 0x63,
+	0x58, 0x7c, 0xe7, 0x8d, 0xde, 0x62, 0x37, 0xb8, 0x99, 0x03, 0xbb, 0x85, 0xb4, 0xa7, 0xb8, 0x92,
+	0x03, 0x28, 0x7b, 0x3d, 0x2c, 0xd6, 0x28, 0x0e, 0x04, 0xf6, 0x8b, 0x39, 0x24, 0x75, 0x34, 0x6b,
+	0xc5, 0x81, 0x50, 0xc7, 0xca, 0xb9, 0x51, 0xf0, 0xb2, 0x4d, 0xd2, 0x02, 0x60, 0x9c, 0xc7, 0x5c,
+	0x11, 0x16, 0x91, 0xf0, 0xee, 0x75, 0x84, 0x4d, 0x69, 0x85, 0x7c, 0xd8, 0x05, 0xa6, 0x4e, 0xd4,
+	0x62, 0xe9, 0x16, 0xf9, 0x4c, 0x4d, 0xaf, 0x12, 0xd2, 0xfc, 0xfd, 0x3a, 0x9a, 0x43, 0x11, 0x6d,
+	0x33, 0x9e, 0x84, 0xa7, 0x61, 0xcf, 0x4b, 0x98, 0xb8, 0x34, 0xbc, 0x5e, 0xc1, 0xb2, 0x1f, 0x72,
+	0xd6, 0x4b, 0x62, 0x7e, 0xd1, 0xed, 0x7b, 0x43, 0xdb, 0x44, 0xa6, 0xb5, 0xeb, 0x98, 0x76, 0x52,
+	0xc3, 0x7d, 0x6f, 0xd8, 0xb8, 0x3d, 0x19, 0x3b, 0xb3, 0xae, 0xb4, 0xe2, 0xe7, 0x0c, 0x48, 0x0b,
+	0xcc, 0x24, 0x1e, 0xc6, 0x51, 0x1c, 0x5c, 0xd8, 0x16, 0x92, 0xba, 0xf3, 0x73, 0xa2, 0xa3, 0x2d,
+	0x55, 0x51, 0xa6, 0x7e, 0x34, 0x93, 0xdc, 0x57, 0x50, 0x9d, 0xb5, 0x24, 0xbb, 0x50, 0x12, 0x8c,
+	0xcb, 0x0e, 0x6c, 0x1b, 0x38, 0xb6, 0x9c, 0x6b, 0x4f, 0x8f, 0x26, 0x8d, 0x28, 0xee, 0x9d, 0xab,
+	0xfc, 0xd0, 0x3e, 0x34, 0x15, 0xdc, 0x5f, 0x0d, 0x28, 0xe7, 0xac, 0xe4, 0xa7, 0x97, 0xda, 0xea,
+	0xca, 0x71, 0xa4, 0xc8, 0xf5, 0xa7, 0x57, 0x1e, 0xa7, 0x65, 0xa5, 0xb5, 0xa5, 0x22, 0xc7, 0x52,
+	0x14, 0x8a, 0x84, 0x0d, 0x74, 0xdf, 0xc2, 0x3a, 0x51, 0x08, 0xd5, 0xbf, 0x72, 0x2c, 0x9d, 0x86,
+	0x11, 0xd3, 0xb5, 0x8e, 0x89, 0x26, 0x75, 0x8a, 0xab, 0xdc, 0x8d, 0xc2, 0x81, 0x1a, 0x5a, 0x4b,
+	0x6a, 0x57, 0xea, 0x14, 0x57, 0xd2, 0x06, 0x2b, 0x8a, 0x7b, 0x9e, 0xac, 0x37, 0x99, 0x84, 0xf2,
+	0xb8, 0x7f, 0xbb, 0xee, 0xb8, 0x2d, 0x6d, 0xa4, 0x0e, 0x8c, 0x73, 0x2d, 0xf3, 0xa3, 0x53, 0xd1,
+	0xfd, 0xd9, 0x80, 0xe5, 0x19, 0x5b, 0xb2, 0x0e, 0x66, 0x3f, 0xf6, 0xc3, 0xd3, 0x90, 0xf1, 0x7c,
+	0x7f, 0x4c, 0x31, 0x9a, 0x49, 0x37, 0x0c, 0xe8, 0x3f, 0xd2, 0x29, 0x8f, 0x01, 0xea, 0x33, 0x85,
+	0x37, 0xad, 0x49, 0x9d, 0x33, 0x77, 0x3f, 0x58, 0xc9, 0xaa, 0xf0, 0xa6, 0x4e, 0xd4, 0xca, 0xea,
+	0xd8, 0xfd, 0xc6, 0x00, 0x2b, 0x33, 0xbc, 0xe1, 0x93, 0xc6, 0x85, 0xe2, 0x69, 0xcc, 0xfb, 0x5e,
+	0xa2, 0x63, 0x86, 0xd9, 0xa1, 0x10, 0xaa, 0x7f, 0xc9, 0x43, 0x28, 0x0f, 0x19, 0xef, 0x87, 0x42,
+	0xe0, 0xe9, 0x55, 0xf8, 0xb0, 0x91, 0xe4, 0x60, 0x9a, 0x57, 0xe4, 0x95, 0x71, 0xa6, 0xbe, 0xa7,
+	0x31, 0xa0, 0xa6, 0xba, 0xb2, 0x14, 0xa3, 0x99, 0xe4, 0x1e, 0x81, 0x95, 0x75, 0x13, 0xf2, 0x14,
+	0xac, 0xac, 0x97, 0xe8, 0x30, 0xdc, 0xf9, 0x50, 0xff, 0x51, 0x01, 0xce, 0x5c, 0xa8, 0x99, 0x76,
+	0x1f, 0xf7, 0x5b, 0x03, 0xcc, 0xd4, 0xea, 0x86, 0x10, 0xdc, 0x97, 0x57, 0x1b, 0x74, 0x23, 0xf6,
+	0x86, 0x45, 0x3a, 0x0a, 0xfa, 0xde, 0x34, 0x48, 0xcd, 0x28, 0x0e, 0x5a, 0x52, 0xfa, 0x5d, 0x43,
+	0x71, 0xff, 0x58, 0x7f, 0x36, 0xe5, 0xc7, 0xda, 0xec, 0x10, 0xb5, 0x60, 0xa9, 0x7e, 0x70, 0xd0,
+	0xfa, 0xa2, 0x66, 0x10, 0x13, 0x16, 0x3b, 0xcd, 0xc3, 0x4e, 0x6d, 0x41, 0x4e, 0x53, 0xfa, 0xbc,
+	0xd5, 0x6a, 0xd4, 0xb7, 0x9f, 0xd5, 0x0a, 0x72, 0xce, 0xd2, 0x66, 0xe7, 0x88, 0xb6, 0x6b, 0x8b,
+	0xd2, 0x7c, 0xf7, 0x39, 0xdd, 0x6e, 0xd6, 0x96, 0x1a, 0xff, 0xff, 0xe1, 0xfd, 0xaa, 0xf1, 0xe3,
+	0xfb, 0x55, 0xe3, 0xa7, 0xf7, 0xab, 0xc6, 0xf1, 0xbf, 0x83, 0x30, 0x39, 0x1b, 0x9d, 0x6c, 0xf4,
+	0xe2, 0xfe, 0x26, 0xc6, 0x76, 0x13, 0x63, 0xbb, 0x29, 0xfc, 0xf3, 0xcd, 0x37, 0x5b, 0x9b, 0xf8,
+	0x27, 0xf5, 0x53, 0x5c, 0x4f, 0x8a, 0xf8, 0xf3, 0xe8, 0xb7, 0x00, 0x00, 0x00, 0xff, 0xff, 0x61,
+	0x3b, 0x7a, 0xa2, 0xfc, 0x0e, 0x00, 0x00,
 }
 
 func (m *NginxDetails) Marshal() (dAtA []byte, err error) {
@@ -965,11 +1402,39 @@ func (m *NginxDetails) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.ConfigureArgs) > 0 {
-		for iNdEx := len(m.ConfigureArgs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.ConfigureArgs[iNdEx])
-			copy(dAtA[i:], m.ConfigureArgs[iNdEx])
-			i = encodeVarintNginx(dAtA, i, uint64(len(m.ConfigureArgs[iNdEx])))
+	if len(m.LoadedModules) > 0 {
+		for iNdEx := len(m.LoadedModules) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.LoadedModules[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNginx(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x7a
+		}
+	}
+	if len(m.Workers) > 0 {
+		for iNdEx := len(m.Workers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Workers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNginx(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x72
+		}
+	}
+	if len(m.ConfigureArgs) > 0 {
+		for iNdEx := len(m.ConfigureArgs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ConfigureArgs[iNdEx])
+			copy(dAtA[i:], m.ConfigureArgs[iNdEx])
+			i = encodeVarintNginx(dAtA, i, uint64(len(m.ConfigureArgs[iNdEx])))
 			i--
 			dAtA[i] = 0x6a
 		}
@@ -1076,6 +1541,115 @@ func (m *NginxDetails) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *NginxLoadedModule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NginxLoadedModule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NginxLoadedModule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintNginx(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintNginx(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Size_ != 0 {
+		i = encodeVarintNginx(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Exists {
+		i--
+		if m.Exists {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintNginx(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintNginx(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NginxWorkerProcess) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NginxWorkerProcess) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NginxWorkerProcess) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.StartTime != 0 {
+		i = encodeVarintNginx(dAtA, i, uint64(m.StartTime))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ProcessId) > 0 {
+		i -= len(m.ProcessId)
+		copy(dAtA[i:], m.ProcessId)
+		i = encodeVarintNginx(dAtA, i, uint64(len(m.ProcessId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *NginxPlusMetaData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1231,6 +1805,18 @@ func (m *NginxConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Topology != nil {
+		{
+			size, err := m.Topology.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNginx(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
 	if m.DirectoryMap != nil {
 		{
 			size, err := m.DirectoryMap.MarshalToSizedBuffer(dAtA[:i])
@@ -1323,6 +1909,185 @@ func (m *NginxConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ConfigTopology) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigTopology) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfigTopology) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Servers) > 0 {
+		for iNdEx := len(m.Servers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Servers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNginx(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ServerBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServerBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ServerBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Locations) > 0 {
+		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNginx(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Line != 0 {
+		i = encodeVarintNginx(dAtA, i, uint64(m.Line))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.File) > 0 {
+		i -= len(m.File)
+		copy(dAtA[i:], m.File)
+		i = encodeVarintNginx(dAtA, i, uint64(len(m.File)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Listen) > 0 {
+		for iNdEx := len(m.Listen) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Listen[iNdEx])
+			copy(dAtA[i:], m.Listen[iNdEx])
+			i = encodeVarintNginx(dAtA, i, uint64(len(m.Listen[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ServerNames) > 0 {
+		for iNdEx := len(m.ServerNames) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ServerNames[iNdEx])
+			copy(dAtA[i:], m.ServerNames[iNdEx])
+			i = encodeVarintNginx(dAtA, i, uint64(len(m.ServerNames[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LocationBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LocationBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LocationBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Locations) > 0 {
+		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNginx(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Line != 0 {
+		i = encodeVarintNginx(dAtA, i, uint64(m.Line))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.File) > 0 {
+		i -= len(m.File)
+		copy(dAtA[i:], m.File)
+		i = encodeVarintNginx(dAtA, i, uint64(len(m.File)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintNginx(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Modifier) > 0 {
+		i -= len(m.Modifier)
+		copy(dAtA[i:], m.Modifier)
+		i = encodeVarintNginx(dAtA, i, uint64(len(m.Modifier)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *AccessLogs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1594,19 +2359,84 @@ func (m *NginxDetails) Size() (n int) {
 			n += 1 + l + sovNginx(uint64(l))
 		}
 	}
+	if len(m.Workers) > 0 {
+		for _, e := range m.Workers {
+			l = e.Size()
+			n += 1 + l + sovNginx(uint64(l))
+		}
+	}
+	if len(m.LoadedModules) > 0 {
+		for _, e := range m.LoadedModules {
+			l = e.Size()
+			n += 1 + l + sovNginx(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *NginxPlusMetaData) Size() (n int) {
+func (m *NginxLoadedModule) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Enabled {
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovNginx(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovNginx(uint64(l))
+	}
+	if m.Exists {
+		n += 2
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovNginx(uint64(m.Size_))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovNginx(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovNginx(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NginxWorkerProcess) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ProcessId)
+	if l > 0 {
+		n += 1 + l + sovNginx(uint64(l))
+	}
+	if m.StartTime != 0 {
+		n += 1 + sovNginx(uint64(m.StartTime))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NginxPlusMetaData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Enabled {
 		n += 2
 	}
 	l = len(m.Release)
@@ -1700,6 +2530,98 @@ func (m *NginxConfig) Size() (n int) {
 		l = m.DirectoryMap.Size()
 		n += 1 + l + sovNginx(uint64(l))
 	}
+	if m.Topology != nil {
+		l = m.Topology.Size()
+		n += 1 + l + sovNginx(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConfigTopology) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Servers) > 0 {
+		for _, e := range m.Servers {
+			l = e.Size()
+			n += 1 + l + sovNginx(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ServerBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ServerNames) > 0 {
+		for _, s := range m.ServerNames {
+			l = len(s)
+			n += 1 + l + sovNginx(uint64(l))
+		}
+	}
+	if len(m.Listen) > 0 {
+		for _, s := range m.Listen {
+			l = len(s)
+			n += 1 + l + sovNginx(uint64(l))
+		}
+	}
+	l = len(m.File)
+	if l > 0 {
+		n += 1 + l + sovNginx(uint64(l))
+	}
+	if m.Line != 0 {
+		n += 1 + sovNginx(uint64(m.Line))
+	}
+	if len(m.Locations) > 0 {
+		for _, e := range m.Locations {
+			l = e.Size()
+			n += 1 + l + sovNginx(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LocationBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Modifier)
+	if l > 0 {
+		n += 1 + l + sovNginx(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovNginx(uint64(l))
+	}
+	l = len(m.File)
+	if l > 0 {
+		n += 1 + l + sovNginx(uint64(l))
+	}
+	if m.Line != 0 {
+		n += 1 + sovNginx(uint64(m.Line))
+	}
+	if len(m.Locations) > 0 {
+		for _, e := range m.Locations {
+			l = e.Size()
+			n += 1 + l + sovNginx(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2230,62 +3152,11 @@ func (m *NginxDetails) Unmarshal(dAtA []byte) error {
 			}
 			m.ConfigureArgs = append(m.ConfigureArgs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipNginx(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthNginx
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *NginxPlusMetaData) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowNginx
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: NginxPlusMetaData: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NginxPlusMetaData: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Workers", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNginx
@@ -2295,17 +3166,31 @@ func (m *NginxPlusMetaData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Enabled = bool(v != 0)
-		case 2:
+			if msglen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Workers = append(m.Workers, &NginxWorkerProcess{})
+			if err := m.Workers[len(m.Workers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Release", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadedModules", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNginx
@@ -2315,23 +3200,25 @@ func (m *NginxPlusMetaData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthNginx
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthNginx
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Release = string(dAtA[iNdEx:postIndex])
+			m.LoadedModules = append(m.LoadedModules, &NginxLoadedModule{})
+			if err := m.LoadedModules[len(m.LoadedModules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2355,7 +3242,7 @@ func (m *NginxPlusMetaData) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NginxSslMetaData) Unmarshal(dAtA []byte) error {
+func (m *NginxLoadedModule) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2378,17 +3265,17 @@ func (m *NginxSslMetaData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NginxSslMetaData: wiretype end group for non-group")
+			return fmt.Errorf("proto: NginxLoadedModule: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NginxSslMetaData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NginxLoadedModule: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SslType", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			m.SslType = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNginx
@@ -2398,14 +3285,27 @@ func (m *NginxSslMetaData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SslType |= NginxSslMetaData_NginxSslType(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Details", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2433,19 +3333,1006 @@ func (m *NginxSslMetaData) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Details = append(m.Details, string(dAtA[iNdEx:postIndex]))
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipNginx(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthNginx
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exists", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Exists = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNginx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NginxWorkerProcess) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNginx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NginxWorkerProcess: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NginxWorkerProcess: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProcessId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			m.StartTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNginx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NginxPlusMetaData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNginx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NginxPlusMetaData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NginxPlusMetaData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Release", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Release = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNginx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NginxSslMetaData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNginx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NginxSslMetaData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NginxSslMetaData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SslType", wireType)
+			}
+			m.SslType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SslType |= NginxSslMetaData_NginxSslType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Details", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Details = append(m.Details, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNginx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NginxHealth) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNginx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NginxHealth: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NginxHealth: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NginxId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NginxId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NginxStatus", wireType)
+			}
+			m.NginxStatus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NginxStatus |= NginxHealth_NginxStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DegradedReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DegradedReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNginx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NginxConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNginx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NginxConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NginxConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			m.Action = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Action |= NginxConfigAction(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigData == nil {
+				m.ConfigData = &ConfigDescriptor{}
+			}
+			if err := m.ConfigData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Zconfig", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Zconfig == nil {
+				m.Zconfig = &ZippedFile{}
+			}
+			if err := m.Zconfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Zaux", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Zaux == nil {
+				m.Zaux = &ZippedFile{}
+			}
+			if err := m.Zaux.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessLogs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AccessLogs == nil {
+				m.AccessLogs = &AccessLogs{}
+			}
+			if err := m.AccessLogs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorLogs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ErrorLogs == nil {
+				m.ErrorLogs = &ErrorLogs{}
+			}
+			if err := m.ErrorLogs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ssl", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ssl == nil {
+				m.Ssl = &SslCertificates{}
+			}
+			if err := m.Ssl.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DirectoryMap", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DirectoryMap == nil {
+				m.DirectoryMap = &DirectoryMap{}
+			}
+			if err := m.DirectoryMap.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topology", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Topology == nil {
+				m.Topology = &ConfigTopology{}
+			}
+			if err := m.Topology.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNginx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigTopology) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNginx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigTopology: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigTopology: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Servers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNginx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Servers = append(m.Servers, &ServerBlock{})
+			if err := m.Servers[len(m.Servers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNginx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
 			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
 			iNdEx += skippy
@@ -2457,7 +4344,7 @@ func (m *NginxSslMetaData) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NginxHealth) Unmarshal(dAtA []byte) error {
+func (m *ServerBlock) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2480,15 +4367,15 @@ func (m *NginxHealth) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NginxHealth: wiretype end group for non-group")
+			return fmt.Errorf("proto: ServerBlock: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NginxHealth: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ServerBlock: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NginxId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerNames", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2516,13 +4403,13 @@ func (m *NginxHealth) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NginxId = string(dAtA[iNdEx:postIndex])
+			m.ServerNames = append(m.ServerNames, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NginxStatus", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Listen", wireType)
 			}
-			m.NginxStatus = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNginx
@@ -2532,14 +4419,27 @@ func (m *NginxHealth) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NginxStatus |= NginxHealth_NginxStatus(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNginx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNginx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Listen = append(m.Listen, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DegradedReason", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2567,64 +4467,13 @@ func (m *NginxHealth) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DegradedReason = string(dAtA[iNdEx:postIndex])
+			m.File = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipNginx(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthNginx
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *NginxConfig) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowNginx
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: NginxConfig: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NginxConfig: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Line", wireType)
 			}
-			m.Action = 0
+			m.Line = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNginx
@@ -2634,14 +4483,14 @@ func (m *NginxConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Action |= NginxConfigAction(b&0x7F) << shift
+				m.Line |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConfigData", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2668,54 +4517,67 @@ func (m *NginxConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConfigData == nil {
-				m.ConfigData = &ConfigDescriptor{}
-			}
-			if err := m.ConfigData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Locations = append(m.Locations, &LocationBlock{})
+			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Zconfig", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowNginx
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthNginx
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNginx(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthNginx
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Zconfig == nil {
-				m.Zconfig = &ZippedFile{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LocationBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNginx
 			}
-			if err := m.Zconfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 4:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LocationBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LocationBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Zaux", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Modifier", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNginx
@@ -2725,33 +4587,29 @@ func (m *NginxConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthNginx
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthNginx
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Zaux == nil {
-				m.Zaux = &ZippedFile{}
-			}
-			if err := m.Zaux.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Modifier = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccessLogs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNginx
@@ -2761,33 +4619,29 @@ func (m *NginxConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthNginx
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthNginx
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AccessLogs == nil {
-				m.AccessLogs = &AccessLogs{}
-			}
-			if err := m.AccessLogs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ErrorLogs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNginx
@@ -2797,33 +4651,29 @@ func (m *NginxConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthNginx
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthNginx
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ErrorLogs == nil {
-				m.ErrorLogs = &ErrorLogs{}
-			}
-			if err := m.ErrorLogs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.File = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ssl", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Line", wireType)
 			}
-			var msglen int
+			m.Line = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNginx
@@ -2833,31 +4683,14 @@ func (m *NginxConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Line |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthNginx
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthNginx
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Ssl == nil {
-				m.Ssl = &SslCertificates{}
-			}
-			if err := m.Ssl.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 8:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DirectoryMap", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2884,10 +4717,8 @@ func (m *NginxConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DirectoryMap == nil {
-				m.DirectoryMap = &DirectoryMap{}
-			}
-			if err := m.DirectoryMap.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Locations = append(m.Locations, &LocationBlock{})
+			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -0,0 +1,490 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: unit.proto
+
+package proto
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Represents NGINX Unit instance details
+type UnitDetails struct {
+	// Unit ID
+	UnitId string `protobuf:"bytes,1,opt,name=unit_id,json=unitId,proto3" json:"unit_id"`
+	// Unit version
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version"`
+	// Path of the control socket the instance is administered through
+	ControlSocket string `protobuf:"bytes,3,opt,name=control_socket,json=controlSocket,proto3" json:"control_socket"`
+	// Checksum of the current configuration
+	ConfigChecksum       string   `protobuf:"bytes,4,opt,name=config_checksum,json=configChecksum,proto3" json:"config_checksum"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnitDetails) Reset()         { *m = UnitDetails{} }
+func (m *UnitDetails) String() string { return proto.CompactTextString(m) }
+func (*UnitDetails) ProtoMessage()    {}
+func (*UnitDetails) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e8d688cf2cb325c8, []int{0}
+}
+func (m *UnitDetails) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UnitDetails) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UnitDetails.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UnitDetails) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnitDetails.Merge(m, src)
+}
+func (m *UnitDetails) XXX_Size() int {
+	return m.Size()
+}
+func (m *UnitDetails) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnitDetails.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnitDetails proto.InternalMessageInfo
+
+func (m *UnitDetails) GetUnitId() string {
+	if m != nil {
+		return m.UnitId
+	}
+	return ""
+}
+
+func (m *UnitDetails) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *UnitDetails) GetControlSocket() string {
+	if m != nil {
+		return m.ControlSocket
+	}
+	return ""
+}
+
+func (m *UnitDetails) GetConfigChecksum() string {
+	if m != nil {
+		return m.ConfigChecksum
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*UnitDetails)(nil), "f5.nginx.agent.sdk.UnitDetails")
+}
+
+func init() { proto.RegisterFile("unit.proto", fileDescriptor_e8d688cf2cb325c8) }
+
+var fileDescriptor_e8d688cf2cb325c8 = []byte{
+	// 249 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2a, 0xcd, 0xcb, 0x2c,
+	0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x4a, 0x33, 0xd5, 0xcb, 0x4b, 0xcf, 0xcc, 0xab,
+	0xd0, 0x4b, 0x4c, 0x4f, 0xcd, 0x2b, 0xd1, 0x2b, 0x4e, 0xc9, 0x96, 0xe2, 0x4a, 0xcf, 0x4f, 0xcf,
+	0x87, 0xc8, 0x2b, 0x5d, 0x66, 0xe4, 0xe2, 0x0e, 0xcd, 0xcb, 0x2c, 0x71, 0x49, 0x2d, 0x49, 0xcc,
+	0xcc, 0x29, 0x16, 0x52, 0xe1, 0x62, 0x07, 0xe9, 0x8e, 0xcf, 0x4c, 0x91, 0x60, 0x54, 0x60, 0xd4,
+	0xe0, 0x74, 0xe2, 0x7e, 0x75, 0x4f, 0x1e, 0x26, 0x14, 0xc4, 0x06, 0x62, 0x78, 0xa6, 0x08, 0xa9,
+	0x72, 0xb1, 0x97, 0xa5, 0x16, 0x15, 0x67, 0xe6, 0xe7, 0x49, 0x30, 0x21, 0x54, 0x41, 0x85, 0x82,
+	0x60, 0x0c, 0x21, 0x4b, 0x2e, 0xbe, 0xe4, 0xfc, 0xbc, 0x92, 0xa2, 0xfc, 0x9c, 0xf8, 0xe2, 0xfc,
+	0xe4, 0xec, 0xd4, 0x12, 0x09, 0x66, 0xb0, 0x6a, 0xa1, 0x57, 0xf7, 0xe4, 0xd1, 0x64, 0x82, 0x78,
+	0xa1, 0xfc, 0x60, 0x30, 0x57, 0xc8, 0x86, 0x8b, 0x3f, 0x39, 0x3f, 0x2f, 0x2d, 0x33, 0x3d, 0x3e,
+	0x39, 0x23, 0x35, 0x39, 0xbb, 0xb8, 0x34, 0x57, 0x82, 0x05, 0xac, 0x57, 0xf8, 0xd5, 0x3d, 0x79,
+	0x74, 0xa9, 0x20, 0x3e, 0x88, 0x80, 0x33, 0x94, 0xef, 0x64, 0x7e, 0xe2, 0x91, 0x1c, 0xe3, 0x85,
+	0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0x46, 0x69, 0xa6, 0x67, 0x96, 0x64, 0x94, 0x26, 0xe9,
+	0x25, 0xe7, 0xe7, 0xea, 0x83, 0xc3, 0x42, 0x1f, 0x1c, 0x16, 0xfa, 0xc5, 0x29, 0xd9, 0xfa, 0x65,
+	0x46, 0xfa, 0xe0, 0x80, 0xb0, 0x06, 0x93, 0x49, 0x6c, 0x60, 0xca, 0x18, 0x10, 0x00, 0x00, 0xff,
+	0xff, 0xee, 0x00, 0x3b, 0xdc, 0x43, 0x01, 0x00, 0x00,
+}
+
+func (m *UnitDetails) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnitDetails) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UnitDetails) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ConfigChecksum) > 0 {
+		i -= len(m.ConfigChecksum)
+		copy(dAtA[i:], m.ConfigChecksum)
+		i = encodeVarintUnit(dAtA, i, uint64(len(m.ConfigChecksum)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ControlSocket) > 0 {
+		i -= len(m.ControlSocket)
+		copy(dAtA[i:], m.ControlSocket)
+		i = encodeVarintUnit(dAtA, i, uint64(len(m.ControlSocket)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintUnit(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UnitId) > 0 {
+		i -= len(m.UnitId)
+		copy(dAtA[i:], m.UnitId)
+		i = encodeVarintUnit(dAtA, i, uint64(len(m.UnitId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintUnit(dAtA []byte, offset int, v uint64) int {
+	offset -= sovUnit(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *UnitDetails) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UnitId)
+	if l > 0 {
+		n += 1 + l + sovUnit(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovUnit(uint64(l))
+	}
+	l = len(m.ControlSocket)
+	if l > 0 {
+		n += 1 + l + sovUnit(uint64(l))
+	}
+	l = len(m.ConfigChecksum)
+	if l > 0 {
+		n += 1 + l + sovUnit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovUnit(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozUnit(x uint64) (n int) {
+	return sovUnit(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *UnitDetails) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUnit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UnitDetails: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UnitDetails: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnitId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUnit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUnit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUnit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UnitId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUnit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUnit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUnit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ControlSocket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUnit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUnit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUnit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ControlSocket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigChecksum", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUnit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUnit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUnit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConfigChecksum = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUnit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUnit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipUnit(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowUnit
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowUnit
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowUnit
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthUnit
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupUnit
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthUnit
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthUnit        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowUnit          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupUnit = fmt.Errorf("proto: unexpected end of group")
+)
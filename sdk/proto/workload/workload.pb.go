@@ -0,0 +1,1112 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: workload.proto
+
+package workload
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Represents a request for X.509 SVIDs
+type X509SVIDRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509SVIDRequest) Reset()         { *m = X509SVIDRequest{} }
+func (m *X509SVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*X509SVIDRequest) ProtoMessage()    {}
+func (*X509SVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_611edb31abe0f206, []int{0}
+}
+func (m *X509SVIDRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *X509SVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_X509SVIDRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *X509SVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509SVIDRequest.Merge(m, src)
+}
+func (m *X509SVIDRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *X509SVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509SVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509SVIDRequest proto.InternalMessageInfo
+
+// Represents the workload's identities and associated trust bundles. A new response is
+// streamed whenever an identity is rotated
+type X509SVIDResponse struct {
+	// List of X.509 SVIDs the workload is entitled to
+	Svids []*X509SVID `protobuf:"bytes,1,rep,name=svids,proto3" json:"svids,omitempty"`
+	// Trust bundles of federated trust domains, keyed by trust domain name
+	FederatedBundles     map[string][]byte `protobuf:"bytes,2,rep,name=federated_bundles,json=federatedBundles,proto3" json:"federated_bundles,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *X509SVIDResponse) Reset()         { *m = X509SVIDResponse{} }
+func (m *X509SVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*X509SVIDResponse) ProtoMessage()    {}
+func (*X509SVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_611edb31abe0f206, []int{1}
+}
+func (m *X509SVIDResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *X509SVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_X509SVIDResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *X509SVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509SVIDResponse.Merge(m, src)
+}
+func (m *X509SVIDResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *X509SVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509SVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509SVIDResponse proto.InternalMessageInfo
+
+func (m *X509SVIDResponse) GetSvids() []*X509SVID {
+	if m != nil {
+		return m.Svids
+	}
+	return nil
+}
+
+func (m *X509SVIDResponse) GetFederatedBundles() map[string][]byte {
+	if m != nil {
+		return m.FederatedBundles
+	}
+	return nil
+}
+
+// Represents a single X.509 SVID with its private key and trust bundle
+type X509SVID struct {
+	// SPIFFE ID of the SVID
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// ASN.1 DER encoded certificate chain, leaf first
+	X509Svid []byte `protobuf:"bytes,2,opt,name=x509_svid,json=x509Svid,proto3" json:"x509_svid,omitempty"`
+	// ASN.1 DER encoded PKCS#8 private key
+	X509SvidKey []byte `protobuf:"bytes,3,opt,name=x509_svid_key,json=x509SvidKey,proto3" json:"x509_svid_key,omitempty"`
+	// ASN.1 DER encoded trust bundle certificates
+	Bundle               []byte   `protobuf:"bytes,4,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509SVID) Reset()         { *m = X509SVID{} }
+func (m *X509SVID) String() string { return proto.CompactTextString(m) }
+func (*X509SVID) ProtoMessage()    {}
+func (*X509SVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_611edb31abe0f206, []int{2}
+}
+func (m *X509SVID) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *X509SVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_X509SVID.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *X509SVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509SVID.Merge(m, src)
+}
+func (m *X509SVID) XXX_Size() int {
+	return m.Size()
+}
+func (m *X509SVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509SVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509SVID proto.InternalMessageInfo
+
+func (m *X509SVID) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *X509SVID) GetX509Svid() []byte {
+	if m != nil {
+		return m.X509Svid
+	}
+	return nil
+}
+
+func (m *X509SVID) GetX509SvidKey() []byte {
+	if m != nil {
+		return m.X509SvidKey
+	}
+	return nil
+}
+
+func (m *X509SVID) GetBundle() []byte {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*X509SVIDRequest)(nil), "spiffe.workload.X509SVIDRequest")
+	proto.RegisterType((*X509SVIDResponse)(nil), "spiffe.workload.X509SVIDResponse")
+	proto.RegisterMapType((map[string][]byte)(nil), "spiffe.workload.X509SVIDResponse.FederatedBundlesEntry")
+	proto.RegisterType((*X509SVID)(nil), "spiffe.workload.X509SVID")
+}
+
+func init() { proto.RegisterFile("workload.proto", fileDescriptor_611edb31abe0f206) }
+
+var fileDescriptor_611edb31abe0f206 = []byte{
+	// 366 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x52, 0xcd, 0x4e, 0xea, 0x40,
+	0x14, 0xce, 0xc0, 0x85, 0xc0, 0xe1, 0x72, 0x81, 0xc9, 0xd5, 0x54, 0x4c, 0x48, 0xed, 0x8a, 0x55,
+	0x4b, 0x30, 0x44, 0xd1, 0x95, 0xa0, 0x24, 0xc4, 0x8d, 0x29, 0x09, 0x1a, 0x37, 0x4d, 0x61, 0xa6,
+	0xd0, 0x14, 0x5b, 0xec, 0x4c, 0x2b, 0xec, 0x7c, 0x3c, 0x97, 0x3e, 0x82, 0xe1, 0x01, 0x7c, 0x06,
+	0xd3, 0x69, 0x8b, 0x09, 0xf1, 0x67, 0x77, 0xce, 0xf7, 0x73, 0xce, 0xe9, 0xd7, 0x81, 0x7f, 0x4f,
+	0x9e, 0xef, 0x2c, 0x3c, 0x93, 0xa8, 0x4b, 0xdf, 0xe3, 0x1e, 0xae, 0xb0, 0xa5, 0x6d, 0x59, 0x54,
+	0x4d, 0x61, 0xa5, 0x06, 0x95, 0xbb, 0x4e, 0xab, 0x3b, 0x1a, 0x0f, 0x2f, 0x75, 0xfa, 0x18, 0x50,
+	0xc6, 0x95, 0x77, 0x04, 0xd5, 0x4f, 0x8c, 0x2d, 0x3d, 0x97, 0x51, 0xac, 0x41, 0x8e, 0x85, 0x36,
+	0x61, 0x12, 0x92, 0xb3, 0xcd, 0x52, 0xfb, 0x40, 0xdd, 0x19, 0xa4, 0x6e, 0x1d, 0xb1, 0x0e, 0x13,
+	0xa8, 0x59, 0x94, 0x50, 0xdf, 0xe4, 0x94, 0x18, 0x93, 0xc0, 0x25, 0x0b, 0xca, 0xa4, 0x8c, 0x30,
+	0x9f, 0x7c, 0x6f, 0x4e, 0xd6, 0xa9, 0x83, 0xd4, 0xda, 0x8b, 0x9d, 0x57, 0x2e, 0xf7, 0xd7, 0x7a,
+	0xd5, 0xda, 0x81, 0xeb, 0x7d, 0xd8, 0xfb, 0x52, 0x8a, 0xab, 0x90, 0x75, 0xe8, 0x5a, 0x42, 0x32,
+	0x6a, 0x16, 0xf5, 0xa8, 0xc4, 0xff, 0x21, 0x17, 0x9a, 0x8b, 0x80, 0x4a, 0x19, 0x19, 0x35, 0xff,
+	0xea, 0x71, 0x73, 0x96, 0x39, 0x45, 0xca, 0x33, 0x82, 0x42, 0x7a, 0x01, 0x3e, 0x84, 0x62, 0x7c,
+	0x9d, 0x61, 0x93, 0xc4, 0x5e, 0x88, 0x81, 0x21, 0x89, 0xc8, 0x55, 0xa7, 0xd5, 0x35, 0xa2, 0x4f,
+	0x4c, 0xe6, 0x14, 0x22, 0x60, 0x14, 0xda, 0x04, 0x2b, 0x50, 0xde, 0x92, 0x46, 0xb4, 0x3c, 0x2b,
+	0x04, 0xa5, 0x54, 0x70, 0x4d, 0xd7, 0x78, 0x1f, 0xf2, 0x71, 0x16, 0xd2, 0x1f, 0x41, 0x26, 0x5d,
+	0xdb, 0x81, 0xda, 0x48, 0x2c, 0xb9, 0x4d, 0x22, 0xb9, 0xb8, 0x19, 0xe2, 0x31, 0x94, 0x07, 0x94,
+	0x4f, 0xe7, 0xdb, 0xdb, 0xe4, 0x1f, 0x82, 0x13, 0xff, 0xae, 0x7e, 0xf4, 0x6b, 0xb4, 0x2d, 0xd4,
+	0xeb, 0xbf, 0x6c, 0x1a, 0xe8, 0x75, 0xd3, 0x40, 0x6f, 0x9b, 0x06, 0xba, 0xef, 0xcc, 0x6c, 0x3e,
+	0x0f, 0x26, 0xea, 0xd4, 0x7b, 0xd0, 0xdc, 0x99, 0xed, 0xae, 0x34, 0x73, 0x46, 0x5d, 0xae, 0x31,
+	0xe2, 0x68, 0x61, 0x5b, 0x13, 0xef, 0x46, 0x4b, 0xc7, 0x9d, 0xa7, 0xc5, 0x24, 0x2f, 0x88, 0xe3,
+	0x8f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xe9, 0xa7, 0x7b, 0x45, 0x62, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SpiffeWorkloadAPIClient is the client API for SpiffeWorkloadAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SpiffeWorkloadAPIClient interface {
+	// Fetches the X.509 SVIDs of the workload, streaming a new response on rotation
+	FetchX509SVID(ctx context.Context, in *X509SVIDRequest, opts ...grpc.CallOption) (SpiffeWorkloadAPI_FetchX509SVIDClient, error)
+}
+
+type spiffeWorkloadAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSpiffeWorkloadAPIClient(cc *grpc.ClientConn) SpiffeWorkloadAPIClient {
+	return &spiffeWorkloadAPIClient{cc}
+}
+
+func (c *spiffeWorkloadAPIClient) FetchX509SVID(ctx context.Context, in *X509SVIDRequest, opts ...grpc.CallOption) (SpiffeWorkloadAPI_FetchX509SVIDClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SpiffeWorkloadAPI_serviceDesc.Streams[0], "/spiffe.workload.SpiffeWorkloadAPI/FetchX509SVID", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &spiffeWorkloadAPIFetchX509SVIDClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SpiffeWorkloadAPI_FetchX509SVIDClient interface {
+	Recv() (*X509SVIDResponse, error)
+	grpc.ClientStream
+}
+
+type spiffeWorkloadAPIFetchX509SVIDClient struct {
+	grpc.ClientStream
+}
+
+func (x *spiffeWorkloadAPIFetchX509SVIDClient) Recv() (*X509SVIDResponse, error) {
+	m := new(X509SVIDResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SpiffeWorkloadAPIServer is the server API for SpiffeWorkloadAPI service.
+type SpiffeWorkloadAPIServer interface {
+	// Fetches the X.509 SVIDs of the workload, streaming a new response on rotation
+	FetchX509SVID(*X509SVIDRequest, SpiffeWorkloadAPI_FetchX509SVIDServer) error
+}
+
+// UnimplementedSpiffeWorkloadAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedSpiffeWorkloadAPIServer struct {
+}
+
+func (*UnimplementedSpiffeWorkloadAPIServer) FetchX509SVID(req *X509SVIDRequest, srv SpiffeWorkloadAPI_FetchX509SVIDServer) error {
+	return status.Errorf(codes.Unimplemented, "method FetchX509SVID not implemented")
+}
+
+func RegisterSpiffeWorkloadAPIServer(s *grpc.Server, srv SpiffeWorkloadAPIServer) {
+	s.RegisterService(&_SpiffeWorkloadAPI_serviceDesc, srv)
+}
+
+func _SpiffeWorkloadAPI_FetchX509SVID_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(X509SVIDRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SpiffeWorkloadAPIServer).FetchX509SVID(m, &spiffeWorkloadAPIFetchX509SVIDServer{stream})
+}
+
+type SpiffeWorkloadAPI_FetchX509SVIDServer interface {
+	Send(*X509SVIDResponse) error
+	grpc.ServerStream
+}
+
+type spiffeWorkloadAPIFetchX509SVIDServer struct {
+	grpc.ServerStream
+}
+
+func (x *spiffeWorkloadAPIFetchX509SVIDServer) Send(m *X509SVIDResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _SpiffeWorkloadAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spiffe.workload.SpiffeWorkloadAPI",
+	HandlerType: (*SpiffeWorkloadAPIServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FetchX509SVID",
+			Handler:       _SpiffeWorkloadAPI_FetchX509SVID_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "workload.proto",
+}
+
+func (m *X509SVIDRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *X509SVIDRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *X509SVIDRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *X509SVIDResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *X509SVIDResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *X509SVIDResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.FederatedBundles) > 0 {
+		for k := range m.FederatedBundles {
+			v := m.FederatedBundles[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintWorkload(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintWorkload(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintWorkload(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Svids) > 0 {
+		for iNdEx := len(m.Svids) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Svids[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWorkload(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *X509SVID) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *X509SVID) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *X509SVID) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Bundle) > 0 {
+		i -= len(m.Bundle)
+		copy(dAtA[i:], m.Bundle)
+		i = encodeVarintWorkload(dAtA, i, uint64(len(m.Bundle)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.X509SvidKey) > 0 {
+		i -= len(m.X509SvidKey)
+		copy(dAtA[i:], m.X509SvidKey)
+		i = encodeVarintWorkload(dAtA, i, uint64(len(m.X509SvidKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.X509Svid) > 0 {
+		i -= len(m.X509Svid)
+		copy(dAtA[i:], m.X509Svid)
+		i = encodeVarintWorkload(dAtA, i, uint64(len(m.X509Svid)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SpiffeId) > 0 {
+		i -= len(m.SpiffeId)
+		copy(dAtA[i:], m.SpiffeId)
+		i = encodeVarintWorkload(dAtA, i, uint64(len(m.SpiffeId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintWorkload(dAtA []byte, offset int, v uint64) int {
+	offset -= sovWorkload(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *X509SVIDRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *X509SVIDResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Svids) > 0 {
+		for _, e := range m.Svids {
+			l = e.Size()
+			n += 1 + l + sovWorkload(uint64(l))
+		}
+	}
+	if len(m.FederatedBundles) > 0 {
+		for k, v := range m.FederatedBundles {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovWorkload(uint64(len(v)))
+			}
+			mapEntrySize := 1 + len(k) + sovWorkload(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovWorkload(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *X509SVID) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SpiffeId)
+	if l > 0 {
+		n += 1 + l + sovWorkload(uint64(l))
+	}
+	l = len(m.X509Svid)
+	if l > 0 {
+		n += 1 + l + sovWorkload(uint64(l))
+	}
+	l = len(m.X509SvidKey)
+	if l > 0 {
+		n += 1 + l + sovWorkload(uint64(l))
+	}
+	l = len(m.Bundle)
+	if l > 0 {
+		n += 1 + l + sovWorkload(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovWorkload(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozWorkload(x uint64) (n int) {
+	return sovWorkload(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *X509SVIDRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkload
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: X509SVIDRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: X509SVIDRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkload(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *X509SVIDResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkload
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: X509SVIDResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: X509SVIDResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Svids", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkload
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Svids = append(m.Svids, &X509SVID{})
+			if err := m.Svids[len(m.Svids)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FederatedBundles", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkload
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FederatedBundles == nil {
+				m.FederatedBundles = make(map[string][]byte)
+			}
+			var mapkey string
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWorkload
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWorkload
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthWorkload
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthWorkload
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWorkload
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthWorkload
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthWorkload
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipWorkload(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthWorkload
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.FederatedBundles[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkload(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *X509SVID) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkload
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: X509SVID: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: X509SVID: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpiffeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkload
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SpiffeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field X509Svid", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkload
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.X509Svid = append(m.X509Svid[:0], dAtA[iNdEx:postIndex]...)
+			if m.X509Svid == nil {
+				m.X509Svid = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field X509SvidKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkload
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.X509SvidKey = append(m.X509SvidKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.X509SvidKey == nil {
+				m.X509SvidKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bundle", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkload
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bundle = append(m.Bundle[:0], dAtA[iNdEx:postIndex]...)
+			if m.Bundle == nil {
+				m.Bundle = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkload(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkload
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipWorkload(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowWorkload
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWorkload
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWorkload
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthWorkload
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupWorkload
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthWorkload
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthWorkload        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowWorkload          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupWorkload = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,133 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/nginxinc/nginx-go-crossplane"
+)
+
+// ResolutionTargets are the DNS dependencies of an nginx config: the addresses of
+// the configured resolver directives and the hostnames nginx has to resolve to reach
+// its upstreams.
+type ResolutionTargets struct {
+	// Resolvers are the addresses given to resolver directives, without parameters
+	Resolvers []string
+	// Hostnames are the upstream hostnames found in the config, excluding literal
+	// IP addresses and unix sockets
+	Hostnames []string
+}
+
+// CollectResolutionTargets parses the nginx config rooted at confFile and returns the
+// configured resolver addresses together with the hostnames used in upstream server
+// directives and in proxy_pass destinations that do not refer to a named upstream.
+func CollectResolutionTargets(confFile string) (*ResolutionTargets, error) {
+	payload, err := crossplane.Parse(confFile,
+		&crossplane.ParseOptions{
+			SingleFile:         false,
+			StopParsingOnError: true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading config from %s: %v", ErrParse, confFile, err)
+	}
+
+	resolvers := map[string]struct{}{}
+	hostnames := map[string]struct{}{}
+	upstreamNames := map[string]struct{}{}
+	proxyPassHosts := map[string]struct{}{}
+
+	for _, xpConf := range payload.Config {
+		err = CrossplaneConfigTraverse(&xpConf,
+			func(parent *crossplane.Directive, current *crossplane.Directive) (bool, error) {
+				switch current.Directive {
+				case "resolver":
+					for _, arg := range current.Args {
+						// trailing parameters such as valid=30s or ipv6=off
+						if strings.Contains(arg, "=") {
+							continue
+						}
+						resolvers[arg] = struct{}{}
+					}
+				case "upstream":
+					if len(current.Args) > 0 {
+						upstreamNames[current.Args[0]] = struct{}{}
+					}
+				case "server":
+					if parent != nil && parent.Directive == "upstream" && len(current.Args) > 0 {
+						if host := hostFromAddress(current.Args[0]); host != "" {
+							hostnames[host] = struct{}{}
+						}
+					}
+				case "proxy_pass":
+					if len(current.Args) > 0 {
+						if host := hostFromAddress(current.Args[0]); host != "" {
+							proxyPassHosts[host] = struct{}{}
+						}
+					}
+				}
+				return true, nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// a proxy_pass destination that names an upstream block is resolved through the
+	// servers of that block, not through DNS
+	for host := range proxyPassHosts {
+		if _, found := upstreamNames[host]; !found {
+			hostnames[host] = struct{}{}
+		}
+	}
+
+	targets := &ResolutionTargets{
+		Resolvers: sortedKeys(resolvers),
+		Hostnames: sortedKeys(hostnames),
+	}
+	return targets, nil
+}
+
+// hostFromAddress extracts the hostname from an upstream address or proxy_pass
+// destination, returning an empty string for unix sockets, literal IP addresses and
+// nginx variables, none of which go through a resolver.
+func hostFromAddress(address string) string {
+	if schemeEnd := strings.Index(address, "://"); schemeEnd != -1 {
+		address = address[schemeEnd+3:]
+	}
+	if strings.HasPrefix(address, "unix:") {
+		return ""
+	}
+	if pathStart := strings.IndexByte(address, '/'); pathStart != -1 {
+		address = address[:pathStart]
+	}
+
+	host := address
+	if parsedHost, _, err := net.SplitHostPort(address); err == nil {
+		host = parsedHost
+	}
+	host = strings.Trim(host, "[]")
+
+	if host == "" || strings.Contains(host, "$") || net.ParseIP(host) != nil {
+		return ""
+	}
+	return host
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
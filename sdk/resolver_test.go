@@ -0,0 +1,73 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectResolutionTargets(t *testing.T) {
+	tmpDir := t.TempDir()
+	confFile := tmpDir + "/nginx.conf"
+	conf := `
+http {
+    resolver 127.0.0.53 [2001:db8::1]:5353 valid=30s ipv6=off;
+    upstream backend {
+        server app-1.internal:8080;
+        server app-2.internal:8080 backup;
+        server 10.0.0.5:8080;
+        server unix:/var/run/app.sock;
+    }
+    server {
+        listen 80;
+        location / {
+            proxy_pass http://backend;
+        }
+        location /static {
+            proxy_pass https://static.example.com/assets/;
+        }
+        location /ip {
+            proxy_pass http://192.168.0.1:9000;
+        }
+        location /dynamic {
+            proxy_pass http://$upstream_host;
+        }
+    }
+}
+`
+	err := os.WriteFile(confFile, []byte(conf), 0644)
+	assert.NoError(t, err)
+
+	targets, err := CollectResolutionTargets(confFile)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"127.0.0.53", "[2001:db8::1]:5353"}, targets.Resolvers)
+	// the named upstream, the IP addresses, the unix socket and the variable
+	// destination are not resolved through DNS
+	assert.Equal(t, []string{"app-1.internal", "app-2.internal", "static.example.com"}, targets.Hostnames)
+}
+
+func TestHostFromAddress(t *testing.T) {
+	for _, tt := range []struct {
+		address  string
+		expected string
+	}{
+		{"app.internal:8080", "app.internal"},
+		{"app.internal", "app.internal"},
+		{"http://app.internal:8080/path", "app.internal"},
+		{"10.0.0.5:8080", ""},
+		{"[2001:db8::1]:8080", ""},
+		{"unix:/var/run/app.sock", ""},
+		{"http://$upstream_host", ""},
+	} {
+		assert.Equal(t, tt.expected, hostFromAddress(tt.address), "address %s", tt.address)
+	}
+}
@@ -0,0 +1,118 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/nginxinc/nginx-go-crossplane"
+)
+
+// ServerBlockLocation identifies where a server block lives in the parsed config,
+// pointing at the file that holds the block and the line of its server directive.
+type ServerBlockLocation struct {
+	File string
+	Line int
+}
+
+// FindServerBlock parses the nginx config rooted at confFile and locates the http
+// server block whose server_name lists the given name. Server names are compared
+// case insensitively, as nginx matches them.
+func FindServerBlock(confFile, serverName string) (*ServerBlockLocation, error) {
+	payload, err := crossplane.Parse(confFile,
+		&crossplane.ParseOptions{
+			SingleFile:         false,
+			StopParsingOnError: true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading config from %s: %v", ErrParse, confFile, err)
+	}
+
+	for _, conf := range payload.Config {
+		if line, found := findServerDirective(conf.Parsed, serverName); found {
+			file := conf.File
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(filepath.Dir(confFile), file)
+			}
+			return &ServerBlockLocation{File: file, Line: line}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no server block with server_name %s found in %s", serverName, confFile)
+}
+
+// FindUpstreamBlock parses the nginx config rooted at confFile and locates the
+// upstream block with the given name, in the http as well as the stream context.
+func FindUpstreamBlock(confFile, upstream string) (*ServerBlockLocation, error) {
+	payload, err := crossplane.Parse(confFile,
+		&crossplane.ParseOptions{
+			SingleFile:         false,
+			StopParsingOnError: true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading config from %s: %v", ErrParse, confFile, err)
+	}
+
+	for _, conf := range payload.Config {
+		if line, found := findUpstreamDirective(conf.Parsed, upstream); found {
+			file := conf.File
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(filepath.Dir(confFile), file)
+			}
+			return &ServerBlockLocation{File: file, Line: line}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no upstream block named %s found in %s", upstream, confFile)
+}
+
+func findServerDirective(directives crossplane.Directives, serverName string) (int, bool) {
+	for _, directive := range directives {
+		if directive.Directive == "server" && serverBlockHasName(directive.Block, serverName) {
+			return directive.Line, true
+		}
+		if directive.IsBlock() {
+			if line, found := findServerDirective(directive.Block, serverName); found {
+				return line, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func findUpstreamDirective(directives crossplane.Directives, upstream string) (int, bool) {
+	for _, directive := range directives {
+		if directive.Directive == "upstream" && len(directive.Args) > 0 && directive.Args[0] == upstream {
+			return directive.Line, true
+		}
+		if directive.IsBlock() {
+			if line, found := findUpstreamDirective(directive.Block, upstream); found {
+				return line, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func serverBlockHasName(block crossplane.Directives, serverName string) bool {
+	for _, directive := range block {
+		if directive.Directive != "server_name" {
+			continue
+		}
+		for _, arg := range directive.Args {
+			if strings.EqualFold(arg, serverName) {
+				return true
+			}
+		}
+	}
+	return false
+}
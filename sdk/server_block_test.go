@@ -0,0 +1,93 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindServerBlock(t *testing.T) {
+	dir := t.TempDir()
+	siteConf := filepath.Join(dir, "site.conf")
+	require.NoError(t, os.WriteFile(siteConf, []byte(`
+server {
+    listen 8080;
+    server_name other.example.com;
+}
+`), 0644))
+
+	confFile := filepath.Join(dir, "nginx.conf")
+	require.NoError(t, os.WriteFile(confFile, []byte(`
+events {}
+http {
+    server {
+        listen 80;
+        server_name app.example.com www.example.com;
+    }
+    include site.conf;
+}
+`), 0644))
+
+	location, err := FindServerBlock(confFile, "app.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, confFile, location.File)
+	assert.Equal(t, 4, location.Line)
+
+	// server names match case insensitively and secondary names are found too
+	location, err = FindServerBlock(confFile, "WWW.Example.Com")
+	require.NoError(t, err)
+	assert.Equal(t, confFile, location.File)
+
+	// a server block in an included file reports the file that holds it
+	location, err = FindServerBlock(confFile, "other.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, siteConf, location.File)
+	assert.Equal(t, 2, location.Line)
+
+	_, err = FindServerBlock(confFile, "missing.example.com")
+	assert.Error(t, err)
+}
+
+func TestFindUpstreamBlock(t *testing.T) {
+	dir := t.TempDir()
+	confFile := filepath.Join(dir, "nginx.conf")
+	require.NoError(t, os.WriteFile(confFile, []byte(`
+events {}
+http {
+    upstream backend {
+        server 10.0.0.1:8080;
+    }
+    server {
+        listen 80;
+    }
+}
+stream {
+    upstream tcp_backend {
+        server 10.0.0.2:5432;
+    }
+}
+`), 0644))
+
+	location, err := FindUpstreamBlock(confFile, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, confFile, location.File)
+	assert.Equal(t, 4, location.Line)
+
+	// stream upstreams are found as well
+	location, err = FindUpstreamBlock(confFile, "tcp_backend")
+	require.NoError(t, err)
+	assert.Equal(t, 12, location.Line)
+
+	_, err = FindUpstreamBlock(confFile, "missing")
+	assert.Error(t, err)
+}
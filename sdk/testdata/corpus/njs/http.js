@@ -0,0 +1,5 @@
+function hello(r) {
+    r.return(200, "Hello from njs\n");
+}
+
+export default { hello };
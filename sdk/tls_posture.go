@@ -0,0 +1,241 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nginxinc/nginx-go-crossplane"
+)
+
+const (
+	// TLSPostureSeverityWarning marks findings that weaken the TLS configuration
+	TLSPostureSeverityWarning = "warning"
+	// TLSPostureSeverityInfo marks hardening opportunities rather than active weaknesses
+	TLSPostureSeverityInfo = "info"
+
+	hstsHeaderName = "Strict-Transport-Security"
+)
+
+// weakSslProtocols are the protocol versions with known weaknesses that should no
+// longer be offered by a server
+var weakSslProtocols = map[string]struct{}{
+	"SSLv2":   {},
+	"SSLv3":   {},
+	"TLSv1":   {},
+	"TLSv1.1": {},
+}
+
+// weakCipherMarkers are OpenSSL cipher name fragments that enable weak or broken
+// cipher suites when present in ssl_ciphers. The catch-all "ALL" keyword is handled
+// separately since it must match exactly.
+var weakCipherMarkers = []string{"RC4", "DES", "MD5", "NULL", "EXP", "ADH"}
+
+// TLSPostureFinding describes a single weakness or hardening opportunity found in the
+// TLS settings of one server block.
+type TLSPostureFinding struct {
+	// ServerNames are the server_name arguments of the affected server block
+	ServerNames []string
+	// Directive is the nginx directive the finding relates to
+	Directive string
+	// Severity is one of TLSPostureSeverityWarning or TLSPostureSeverityInfo
+	Severity string
+	// Message is a human readable description of the finding
+	Message string
+}
+
+// tlsServerSettings collects the TLS relevant directives of one server block, or of
+// the enclosing http block when used as the inherited defaults.
+type tlsServerSettings struct {
+	ssl            bool
+	serverNames    []string
+	protocols      []string
+	ciphers        string
+	sessionTickets string
+	stapling       string
+	hstsHeader     bool
+}
+
+// AnalyzeTLSPosture parses the nginx config rooted at confFile and scores the TLS
+// posture of every TLS enabled server block, reporting weak protocol versions, weak
+// cipher suites, disabled OCSP stapling, missing HSTS headers and enabled session
+// tickets. Directives set at http level are treated as inherited defaults for server
+// blocks that do not override them.
+func AnalyzeTLSPosture(confFile string) ([]*TLSPostureFinding, error) {
+	payload, err := crossplane.Parse(confFile,
+		&crossplane.ParseOptions{
+			SingleFile:         false,
+			StopParsingOnError: true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading config from %s: %v", ErrParse, confFile, err)
+	}
+
+	defaults := &tlsServerSettings{}
+	servers := make(map[*crossplane.Directive]*tlsServerSettings)
+	serverOrder := []*crossplane.Directive{}
+
+	settingsFor := func(parent *crossplane.Directive) *tlsServerSettings {
+		if parent == nil || parent.Directive != "server" {
+			return defaults
+		}
+		settings, ok := servers[parent]
+		if !ok {
+			settings = &tlsServerSettings{}
+			servers[parent] = settings
+			serverOrder = append(serverOrder, parent)
+		}
+		return settings
+	}
+
+	for _, xpConf := range payload.Config {
+		err = CrossplaneConfigTraverse(&xpConf,
+			func(parent *crossplane.Directive, current *crossplane.Directive) (bool, error) {
+				settings := settingsFor(parent)
+				switch current.Directive {
+				case "listen":
+					for _, arg := range current.Args {
+						if arg == "ssl" {
+							settings.ssl = true
+						}
+					}
+				case "server_name":
+					settings.serverNames = append(settings.serverNames, current.Args...)
+				case "ssl_certificate":
+					settings.ssl = true
+				case "ssl_protocols":
+					settings.protocols = current.Args
+				case "ssl_ciphers":
+					if len(current.Args) > 0 {
+						settings.ciphers = current.Args[0]
+					}
+				case "ssl_session_tickets":
+					if len(current.Args) > 0 {
+						settings.sessionTickets = current.Args[0]
+					}
+				case "ssl_stapling":
+					if len(current.Args) > 0 {
+						settings.stapling = current.Args[0]
+					}
+				case "add_header":
+					if len(current.Args) > 0 && strings.EqualFold(current.Args[0], hstsHeaderName) {
+						settings.hstsHeader = true
+					}
+				}
+				return true, nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	findings := []*TLSPostureFinding{}
+	for _, server := range serverOrder {
+		settings := servers[server].inherit(defaults)
+		if !settings.ssl {
+			continue
+		}
+		findings = append(findings, settings.findings()...)
+	}
+	return findings, nil
+}
+
+// inherit returns a copy of the server settings with unset directives filled in from
+// the http level defaults, mirroring nginx's directive inheritance.
+func (s *tlsServerSettings) inherit(defaults *tlsServerSettings) *tlsServerSettings {
+	merged := *s
+	if len(merged.protocols) == 0 {
+		merged.protocols = defaults.protocols
+	}
+	if merged.ciphers == "" {
+		merged.ciphers = defaults.ciphers
+	}
+	if merged.sessionTickets == "" {
+		merged.sessionTickets = defaults.sessionTickets
+	}
+	if merged.stapling == "" {
+		merged.stapling = defaults.stapling
+	}
+	if defaults.hstsHeader {
+		merged.hstsHeader = true
+	}
+	return &merged
+}
+
+func (s *tlsServerSettings) findings() []*TLSPostureFinding {
+	findings := []*TLSPostureFinding{}
+
+	weak := []string{}
+	for _, protocol := range s.protocols {
+		if _, ok := weakSslProtocols[protocol]; ok {
+			weak = append(weak, protocol)
+		}
+	}
+	if len(weak) > 0 {
+		findings = append(findings, s.finding("ssl_protocols", TLSPostureSeverityWarning,
+			fmt.Sprintf("weak TLS protocol versions enabled: %s", strings.Join(weak, ", "))))
+	}
+
+	if markers := weakCiphers(s.ciphers); len(markers) > 0 {
+		findings = append(findings, s.finding("ssl_ciphers", TLSPostureSeverityWarning,
+			fmt.Sprintf("weak cipher suites enabled: %s", strings.Join(markers, ", "))))
+	}
+
+	if s.stapling != "on" {
+		findings = append(findings, s.finding("ssl_stapling", TLSPostureSeverityInfo,
+			"OCSP stapling is not enabled"))
+	}
+
+	if !s.hstsHeader {
+		findings = append(findings, s.finding("add_header", TLSPostureSeverityInfo,
+			"Strict-Transport-Security header is not set"))
+	}
+
+	if s.sessionTickets != "off" {
+		findings = append(findings, s.finding("ssl_session_tickets", TLSPostureSeverityInfo,
+			"TLS session tickets are enabled; without external key rotation this weakens forward secrecy"))
+	}
+
+	return findings
+}
+
+func (s *tlsServerSettings) finding(directive, severity, message string) *TLSPostureFinding {
+	return &TLSPostureFinding{
+		ServerNames: s.serverNames,
+		Directive:   directive,
+		Severity:    severity,
+		Message:     message,
+	}
+}
+
+// weakCiphers returns the weak cipher list fragments present in the provided
+// ssl_ciphers value. Fragments excluded with a ! prefix are not reported.
+func weakCiphers(ciphers string) []string {
+	if ciphers == "" {
+		return nil
+	}
+	markers := []string{}
+	for _, element := range strings.FieldsFunc(ciphers, func(r rune) bool { return r == ':' || r == '+' }) {
+		if strings.HasPrefix(element, "!") || strings.HasPrefix(element, "-") {
+			continue
+		}
+		if element == "ALL" {
+			markers = append(markers, element)
+			continue
+		}
+		for _, marker := range weakCipherMarkers {
+			if strings.Contains(element, marker) {
+				markers = append(markers, element)
+				break
+			}
+		}
+	}
+	return markers
+}
@@ -0,0 +1,83 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeTLSPosture(t *testing.T) {
+	tmpDir := t.TempDir()
+	confFile := tmpDir + "/nginx.conf"
+	conf := `
+http {
+    ssl_protocols TLSv1 TLSv1.2;
+    server {
+        listen 443 ssl;
+        server_name weak.example.com;
+        ssl_ciphers RC4-SHA:AES128-GCM-SHA256;
+    }
+    server {
+        listen 443 ssl;
+        server_name strong.example.com;
+        ssl_protocols TLSv1.2 TLSv1.3;
+        ssl_ciphers HIGH:!aNULL:!MD5;
+        ssl_stapling on;
+        ssl_session_tickets off;
+        add_header Strict-Transport-Security "max-age=31536000";
+    }
+    server {
+        listen 80;
+        server_name plain.example.com;
+    }
+}
+`
+	err := os.WriteFile(confFile, []byte(conf), 0644)
+	assert.NoError(t, err)
+
+	findings, err := AnalyzeTLSPosture(confFile)
+	assert.NoError(t, err)
+
+	byDirective := make(map[string][]*TLSPostureFinding)
+	for _, finding := range findings {
+		assert.NotEqual(t, []string{"plain.example.com"}, finding.ServerNames)
+		byDirective[finding.Directive] = append(byDirective[finding.Directive], finding)
+	}
+
+	// the weak server inherits TLSv1 from http level and enables RC4, the strong
+	// server overrides every inherited weakness
+	assert.Len(t, byDirective["ssl_protocols"], 1)
+	assert.Equal(t, []string{"weak.example.com"}, byDirective["ssl_protocols"][0].ServerNames)
+	assert.Equal(t, TLSPostureSeverityWarning, byDirective["ssl_protocols"][0].Severity)
+	assert.Contains(t, byDirective["ssl_protocols"][0].Message, "TLSv1")
+
+	assert.Len(t, byDirective["ssl_ciphers"], 1)
+	assert.Equal(t, []string{"weak.example.com"}, byDirective["ssl_ciphers"][0].ServerNames)
+	assert.Contains(t, byDirective["ssl_ciphers"][0].Message, "RC4-SHA")
+
+	assert.Len(t, byDirective["ssl_stapling"], 1)
+	assert.Len(t, byDirective["add_header"], 1)
+	assert.Len(t, byDirective["ssl_session_tickets"], 1)
+	for _, directive := range []string{"ssl_stapling", "add_header", "ssl_session_tickets"} {
+		assert.Equal(t, []string{"weak.example.com"}, byDirective[directive][0].ServerNames)
+		assert.Equal(t, TLSPostureSeverityInfo, byDirective[directive][0].Severity)
+	}
+
+	_, err = AnalyzeTLSPosture(tmpDir + "/missing.conf")
+	assert.Error(t, err)
+}
+
+func TestWeakCiphers(t *testing.T) {
+	assert.Empty(t, weakCiphers(""))
+	assert.Empty(t, weakCiphers("HIGH:!aNULL:!MD5:!RC4"))
+	assert.Equal(t, []string{"ALL"}, weakCiphers("ALL"))
+	assert.Equal(t, []string{"RC4-SHA", "DES-CBC3-SHA"}, weakCiphers("RC4-SHA:DES-CBC3-SHA:AES256-GCM-SHA384"))
+}
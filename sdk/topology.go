@@ -0,0 +1,133 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"path/filepath"
+
+	"github.com/nginxinc/nginx-go-crossplane"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+// Topology returns the server and location structure of the parsed config. Each
+// block records the file and line it was read from, resolved through the include
+// tree, so a server defined in an included file is attributed to that file rather
+// than to the conf file including it.
+func (p *ParsedConfig) Topology() *proto.ConfigTopology {
+	builder := &topologyBuilder{
+		confFile: p.confFile,
+		payload:  p.payload,
+		topology: &proto.ConfigTopology{Servers: []*proto.ServerBlock{}},
+	}
+	if len(p.payload.Config) > 0 {
+		builder.walk(p.payload.Config[0].Parsed, builder.configFile(0))
+	}
+	return builder.topology
+}
+
+type topologyBuilder struct {
+	confFile string
+	payload  *crossplane.Payload
+	topology *proto.ConfigTopology
+}
+
+// configFile resolves the file of the index-th config of the payload the same way
+// the other analyses do: crossplane reports included files relative to the conf
+// file that was parsed.
+func (t *topologyBuilder) configFile(index int) string {
+	file := t.payload.Config[index].File
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(filepath.Dir(t.confFile), file)
+	}
+	return file
+}
+
+// walk descends from the root config into every block, following include
+// directives into the configs they pull in, so server blocks keep the locations
+// their included files contribute.
+func (t *topologyBuilder) walk(directives crossplane.Directives, file string) {
+	for _, directive := range directives {
+		switch {
+		case directive.IsInclude():
+			for _, index := range directive.Includes {
+				t.walk(t.payload.Config[index].Parsed, t.configFile(index))
+			}
+		case directive.Directive == "server" && directive.IsBlock():
+			t.topology.Servers = append(t.topology.Servers, t.buildServer(directive, file))
+		case directive.IsBlock():
+			t.walk(directive.Block, file)
+		}
+	}
+}
+
+func (t *topologyBuilder) buildServer(directive *crossplane.Directive, file string) *proto.ServerBlock {
+	server := &proto.ServerBlock{
+		ServerNames: []string{},
+		Listen:      []string{},
+		File:        file,
+		Line:        int32(directive.Line),
+		Locations:   []*proto.LocationBlock{},
+	}
+	t.fillServer(directive.Block, file, server)
+	return server
+}
+
+// fillServer collects the listen addresses, server names and locations of one
+// server block, following includes within the block into their files.
+func (t *topologyBuilder) fillServer(directives crossplane.Directives, file string, server *proto.ServerBlock) {
+	for _, directive := range directives {
+		switch {
+		case directive.IsInclude():
+			for _, index := range directive.Includes {
+				t.fillServer(t.payload.Config[index].Parsed, t.configFile(index), server)
+			}
+		case directive.Directive == "listen" && len(directive.Args) > 0:
+			server.Listen = append(server.Listen, directive.Args[0])
+		case directive.Directive == "server_name":
+			server.ServerNames = append(server.ServerNames, directive.Args...)
+		case directive.Directive == "location" && directive.IsBlock():
+			server.Locations = append(server.Locations, t.buildLocation(directive, file))
+		}
+	}
+}
+
+func (t *topologyBuilder) buildLocation(directive *crossplane.Directive, file string) *proto.LocationBlock {
+	location := &proto.LocationBlock{
+		File: file,
+		Line: int32(directive.Line),
+	}
+	// a location has an optional modifier argument before the path, such as
+	// "location = /exact" or "location ~ \.php$"
+	switch len(directive.Args) {
+	case 1:
+		location.Path = directive.Args[0]
+	default:
+		if len(directive.Args) > 1 {
+			location.Modifier = directive.Args[0]
+			location.Path = directive.Args[1]
+		}
+	}
+	t.fillLocation(directive.Block, file, location)
+	return location
+}
+
+// fillLocation collects the nested locations of one location block, following
+// includes within the block into their files.
+func (t *topologyBuilder) fillLocation(directives crossplane.Directives, file string, location *proto.LocationBlock) {
+	for _, directive := range directives {
+		switch {
+		case directive.IsInclude():
+			for _, index := range directive.Includes {
+				t.fillLocation(t.payload.Config[index].Parsed, t.configFile(index), location)
+			}
+		case directive.Directive == "location" && directive.IsBlock():
+			location.Locations = append(location.Locations, t.buildLocation(directive, file))
+		}
+	}
+}
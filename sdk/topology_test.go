@@ -0,0 +1,106 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopology(t *testing.T) {
+	tmpDir := t.TempDir()
+	confFile := filepath.Join(tmpDir, "nginx.conf")
+	siteFile := filepath.Join(tmpDir, "site.conf")
+	locationsFile := filepath.Join(tmpDir, "locations.conf")
+
+	require.NoError(t, os.WriteFile(confFile, []byte(`
+events {}
+http {
+    server {
+        listen 80 default_server;
+        server_name example.com www.example.com;
+        location / {
+        }
+        location = /health {
+        }
+    }
+    include site.conf;
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(siteFile, []byte(`
+server {
+    listen 443;
+    server_name other.example.com;
+    include locations.conf;
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(locationsFile, []byte(`
+location /api {
+    location ~ \.php$ {
+    }
+}
+`), 0644))
+
+	parsed, err := ParseConfig(confFile)
+	require.NoError(t, err)
+
+	topology := parsed.Topology()
+	require.Len(t, topology.GetServers(), 2)
+
+	first := topology.GetServers()[0]
+	assert.Equal(t, []string{"example.com", "www.example.com"}, first.GetServerNames())
+	assert.Equal(t, []string{"80"}, first.GetListen())
+	assert.Equal(t, confFile, first.GetFile())
+	assert.Equal(t, int32(4), first.GetLine())
+	require.Len(t, first.GetLocations(), 2)
+	assert.Equal(t, "/", first.GetLocations()[0].GetPath())
+	assert.Empty(t, first.GetLocations()[0].GetModifier())
+	assert.Equal(t, "=", first.GetLocations()[1].GetModifier())
+	assert.Equal(t, "/health", first.GetLocations()[1].GetPath())
+	assert.Equal(t, confFile, first.GetLocations()[1].GetFile())
+
+	// the second server and its locations come from included files and must be
+	// attributed to those files
+	second := topology.GetServers()[1]
+	assert.Equal(t, []string{"other.example.com"}, second.GetServerNames())
+	assert.Equal(t, siteFile, second.GetFile())
+	assert.Equal(t, int32(2), second.GetLine())
+	require.Len(t, second.GetLocations(), 1)
+	api := second.GetLocations()[0]
+	assert.Equal(t, "/api", api.GetPath())
+	assert.Equal(t, locationsFile, api.GetFile())
+	assert.Equal(t, int32(2), api.GetLine())
+	require.Len(t, api.GetLocations(), 1)
+	assert.Equal(t, "~", api.GetLocations()[0].GetModifier())
+	assert.Equal(t, `\.php$`, api.GetLocations()[0].GetPath())
+	assert.Equal(t, locationsFile, api.GetLocations()[0].GetFile())
+}
+
+func TestTopologyInNginxConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	confFile := filepath.Join(tmpDir, "nginx.conf")
+	require.NoError(t, os.WriteFile(confFile, []byte(`
+events {}
+http {
+    server {
+        listen 8080;
+        server_name example.com;
+    }
+}
+`), 0644))
+
+	cfg, err := GetNginxConfig(confFile, "nginx-id", "system-id", map[string]struct{}{tmpDir: {}})
+	require.NoError(t, err)
+	require.NotNil(t, cfg.GetTopology())
+	require.Len(t, cfg.GetTopology().GetServers(), 1)
+	assert.Equal(t, []string{"example.com"}, cfg.GetTopology().GetServers()[0].GetServerNames())
+}
@@ -0,0 +1,52 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nginxinc/nginx-go-crossplane"
+)
+
+// FuzzCrossplaneConfigTraverse hardens config parsing and traversal against
+// malformed input: configs arrive from the management plane, so neither
+// parsing nor walking the parse tree may panic on garbage.
+func FuzzCrossplaneConfigTraverse(f *testing.F) {
+	f.Add([]byte("events {}\nhttp {\n  server {\n    listen 8080;\n    location / {\n      return 200;\n    }\n  }\n}\n"))
+	f.Add([]byte("http { upstream backend { server 127.0.0.1:8081; } }"))
+	f.Add([]byte("}{ include ;;; \"unterminated"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		confFile := filepath.Join(t.TempDir(), "nginx.conf")
+		if err := os.WriteFile(confFile, data, 0644); err != nil {
+			t.Skip()
+		}
+
+		payload, err := crossplane.Parse(confFile,
+			&crossplane.ParseOptions{
+				SingleFile:         true,
+				StopParsingOnError: false,
+			},
+		)
+		if err != nil {
+			return
+		}
+
+		for index := range payload.Config {
+			conf := &payload.Config[index]
+			_ = CrossplaneConfigTraverse(conf, func(parent, current *crossplane.Directive) (bool, error) {
+				return true, nil
+			})
+			_ = CrossplaneConfigTraverseStr(conf, func(parent, current *crossplane.Directive) string {
+				return ""
+			})
+		}
+	})
+}
@@ -30,10 +30,30 @@ var (
 
 const (
 	DefaultFileMode = 0644
+
+	copyBufferSize = 32 * 1024
 )
 
+// copyBufferPool holds the scratch buffers used to move file contents in and
+// out of archives, so repeated config collections do not reallocate them.
+var copyBufferPool = sync.Pool{
+	New: func() interface{} {
+		buffer := make([]byte, copyBufferSize)
+		return &buffer
+	},
+}
+
+// stagingBufferPool holds the buffers used to learn the size of readers that
+// cannot report it themselves before their contents enter the archive.
+var stagingBufferPool = sync.Pool{
+	New: func() interface{} {
+		return &bytes.Buffer{}
+	},
+}
+
 // Writer is a helper for building the proto ZippedFile for sending with multiple file contents.
-// Uses in memory bytes.Buffer, should be extended if larger file content is expected.
+// File contents are streamed into the compressed in-memory payload, so only the compressed
+// bytes are held while a config is collected.
 type Writer struct {
 	sync.Mutex
 	flushed bool
@@ -102,26 +122,61 @@ func (z *Writer) Add(name string, mode os.FileMode, r io.Reader) error {
 	if z.flushed {
 		return ErrFlushed
 	}
-	b := bytes.NewBuffer([]byte{})
+	if size, ok := readerSize(r); ok {
+		return z.add(name, mode, size, r)
+	}
+
+	// the reader cannot report its size, which the tar header needs up front,
+	// so stage the contents through a pooled buffer to learn it
+	b := stagingBufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		b.Reset()
+		stagingBufferPool.Put(b)
+	}()
 	n, err := io.Copy(b, r)
 	if err != nil {
 		return fmt.Errorf("zipped file: copy error %s", err)
 	}
+	return z.add(name, mode, n, b)
+}
+
+func (z *Writer) add(name string, mode os.FileMode, size int64, r io.Reader) error {
 	z.wrote++
 	h := &tar.Header{
 		Name: name,
 		Mode: int64(mode),
-		Size: n,
+		Size: size,
 	}
-	err = z.writer.WriteHeader(h)
-	if err != nil {
+	if err := z.writer.WriteHeader(h); err != nil {
 		return fmt.Errorf("zipped file: write header error %s", err)
 	}
-	_, err = z.writer.Write(b.Bytes())
-	if err != nil {
+	buffer := copyBufferPool.Get().(*[]byte)
+	defer copyBufferPool.Put(buffer)
+	if _, err := io.CopyBuffer(z.writer, r, *buffer); err != nil {
 		return fmt.Errorf("zipped file: write error %s", err)
 	}
-	return err
+	return nil
+}
+
+// readerSize reports how many bytes r will yield when that can be determined
+// without reading it, so file contents stream straight into the archive instead
+// of being copied into memory first. A file that changes size while it is being
+// added surfaces as a write error.
+func readerSize(r io.Reader) (int64, bool) {
+	switch v := r.(type) {
+	case *os.File:
+		info, err := v.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0, false
+		}
+		if offset, err := v.Seek(0, io.SeekCurrent); err == nil {
+			return info.Size() - offset, true
+		}
+		return info.Size(), true
+	case interface{ Len() int }:
+		return int64(v.Len()), true
+	}
+	return 0, false
 }
 
 func (z *Writer) AddFile(fullPath string) error {
@@ -203,24 +258,28 @@ func UnPack(zipFile *proto.ZippedFile) ([]*proto.File, error) {
 	}()
 
 	rawFiles := make([]*proto.File, 0)
-	zipContentsReader.RangeFileReaders(func(err error, path string, mode os.FileMode, rc io.Reader) bool {
+	for {
+		header, err := zipContentsReader.reader.Next()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
 			log.Print(err)
+			return rawFiles, err
 		}
 
-		b := bytes.NewBuffer([]byte{})
-		_, err = io.Copy(b, rc)
-
-		if err != nil {
-			return false
+		// the tar header carries the exact size, so each file is read into a
+		// right-sized slice instead of growing through a buffer
+		contents := make([]byte, header.Size)
+		if _, err := io.ReadFull(zipContentsReader.reader, contents); err != nil {
+			return rawFiles, err
 		}
 
 		rawFiles = append(rawFiles, &proto.File{
-			Name:        path,
-			Permissions: files.GetPermissions(mode),
-			Contents:    b.Bytes(),
+			Name:        header.Name,
+			Permissions: files.GetPermissions(os.FileMode(header.Mode)),
+			Contents:    contents,
 		})
-		return true
-	})
-	return rawFiles, err
+	}
+	return rawFiles, nil
 }
@@ -9,6 +9,7 @@ package zip
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"os"
 	"testing"
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/nginx/agent/sdk/v2/internal/perf"
 	"github.com/nginx/agent/sdk/v2/proto"
 )
 
@@ -235,3 +237,153 @@ func TestUnPack(t *testing.T) {
 		assert.NotEmpty(t, confFiles)
 	}
 }
+
+func TestAddStreamsKnownSizes(t *testing.T) {
+	content := []byte("streamed contents")
+
+	file, err := os.CreateTemp(t.TempDir(), "zip")
+	require.NoError(t, err)
+	_, err = file.Write(content)
+	require.NoError(t, err)
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	defer file.Close()
+
+	w, err := NewWriter("/root")
+	require.NoError(t, err)
+	// a file and a sized reader stream straight into the archive, an opaque
+	// reader is staged through a buffer first; all of them must round-trip
+	require.NoError(t, w.Add("from-file", 0600, file))
+	require.NoError(t, w.Add("from-sized-reader", 0600, bytes.NewReader(content)))
+	require.NoError(t, w.Add("from-opaque-reader", 0600, struct{ io.Reader }{bytes.NewReader(content)}))
+
+	p, err := w.Proto()
+	require.NoError(t, err)
+
+	confFiles, err := UnPack(p)
+	require.NoError(t, err)
+	require.Len(t, confFiles, 3)
+	for _, confFile := range confFiles {
+		assert.Equal(t, content, confFile.Contents, confFile.Name)
+	}
+}
+
+func TestReaderSize(t *testing.T) {
+	size, ok := readerSize(bytes.NewReader([]byte("12345")))
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), size)
+
+	_, ok = readerSize(struct{ io.Reader }{bytes.NewReader([]byte("12345"))})
+	assert.False(t, ok)
+
+	// a partially read file only has its remainder left to add
+	file, err := os.CreateTemp(t.TempDir(), "zip")
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.Write([]byte("12345"))
+	require.NoError(t, err)
+	_, err = file.Seek(2, io.SeekStart)
+	require.NoError(t, err)
+	size, ok = readerSize(file)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), size)
+}
+
+func benchmarkPayload(b *testing.B, fileCount, fileSize int) *proto.ZippedFile {
+	b.Helper()
+	writer, err := NewWriter("/tmp")
+	if err != nil {
+		b.Fatal(err)
+	}
+	content := bytes.Repeat([]byte("x"), fileSize)
+	for i := 0; i < fileCount; i++ {
+		if err := writer.Add(fmt.Sprintf("/tmp/file-%04d", i), DefaultFileMode, bytes.NewReader(content)); err != nil {
+			b.Fatal(err)
+		}
+	}
+	payload, err := writer.Proto()
+	if err != nil {
+		b.Fatal(err)
+	}
+	return payload
+}
+
+func BenchmarkWriterAdd(b *testing.B) {
+	content := bytes.Repeat([]byte("x"), 1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		writer, err := NewWriter("/tmp")
+		if err != nil {
+			b.Fatal(err)
+		}
+		for i := 0; i < 100; i++ {
+			if err := writer.Add(fmt.Sprintf("/tmp/file-%04d", i), DefaultFileMode, bytes.NewReader(content)); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if _, err := writer.Proto(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnPack(b *testing.B) {
+	payload := benchmarkPayload(b, 100, 1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := UnPack(payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestWriterAddAllocBudget(t *testing.T) {
+	writer, err := NewWriter("/tmp")
+	require.NoError(t, err)
+	content := bytes.Repeat([]byte("x"), 1024)
+
+	i := 0
+	perf.RequireAllocBudget(t, "Writer.Add", 16, func() {
+		i++
+		if err := writer.Add(fmt.Sprintf("/tmp/file-%04d", i), DefaultFileMode, bytes.NewReader(content)); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// FuzzUnPack hardens UnPack against arbitrary payloads: zipped files arrive
+// from the management plane, so a corrupt archive must surface as an error,
+// never a panic.
+func FuzzUnPack(f *testing.F) {
+	writer, err := NewWriter("/tmp")
+	if err != nil {
+		f.Fatal(err)
+	}
+	if err := writer.Add("nginx.conf", DefaultFileMode, bytes.NewReader([]byte("events {}\n"))); err != nil {
+		f.Fatal(err)
+	}
+	seed, err := writer.Proto()
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(seed.GetContents(), seed.GetRootDirectory(), seed.GetChecksum())
+	f.Add([]byte("not a zip"), "/tmp", "bogus")
+
+	f.Fuzz(func(t *testing.T, contents []byte, rootDirectory, checksum string) {
+		files, err := UnPack(&proto.ZippedFile{
+			Contents:      contents,
+			RootDirectory: rootDirectory,
+			Checksum:      checksum,
+		})
+		if err == nil {
+			for _, file := range files {
+				_ = file.GetName()
+				_ = file.GetContents()
+			}
+		}
+	})
+}
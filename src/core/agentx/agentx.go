@@ -0,0 +1,353 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package agentx implements a minimal AgentX (RFC 2741) sub-agent, covering
+// just enough of the protocol to register a subtree of read-only scalars with
+// a master SNMP agent such as net-snmp and answer Get and GetNext requests
+// for them.
+package agentx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	pduOpen     = 1
+	pduClose    = 2
+	pduRegister = 3
+	pduGet      = 5
+	pduGetNext  = 6
+	pduPing     = 13
+	pduResponse = 18
+
+	// TypeGauge32 exposes the current value of a metric
+	TypeGauge32 = 66
+	// TypeCounter64 exposes a monotonically increasing value
+	TypeCounter64 = 70
+
+	typeNoSuchObject = 128
+	typeEndOfMibView = 130
+
+	headerLength       = 20
+	sessionOpenTimeout = 5 * time.Second
+	reconnectDelay     = 10 * time.Second
+)
+
+// OID is an object identifier as a sequence of sub-identifiers
+type OID []uint32
+
+// ParseOID parses a dotted OID string such as "1.3.6.1.4.1.8072.1"
+func ParseOID(s string) (OID, error) {
+	var oid OID
+	for _, part := range strings.Split(s, ".") {
+		subID, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OID %q: %v", s, err)
+		}
+		oid = append(oid, uint32(subID))
+	}
+	return oid, nil
+}
+
+func (o OID) String() string {
+	parts := make([]string, len(o))
+	for i, subID := range o {
+		parts[i] = strconv.FormatUint(uint64(subID), 10)
+	}
+	return strings.Join(parts, ".")
+}
+
+// Append returns a new OID with the sub-identifiers added to the end
+func (o OID) Append(subIDs ...uint32) OID {
+	return append(append(OID{}, o...), subIDs...)
+}
+
+// Compare orders OIDs lexicographically, the order GetNext walks them in
+func (o OID) Compare(other OID) int {
+	for i := 0; i < len(o) && i < len(other); i++ {
+		switch {
+		case o[i] < other[i]:
+			return -1
+		case o[i] > other[i]:
+			return 1
+		}
+	}
+	switch {
+	case len(o) < len(other):
+		return -1
+	case len(o) > len(other):
+		return 1
+	}
+	return 0
+}
+
+// Scalar is a read-only value the sub-agent serves at a fixed OID. The Get
+// callback is invoked for every SNMP request, so it should be cheap.
+type Scalar struct {
+	OID  OID
+	Type uint16
+	Get  func() uint64
+}
+
+// SubAgent connects to a master SNMP agent over the AgentX protocol,
+// registers a subtree and serves the configured scalars from it
+type SubAgent struct {
+	network     string
+	address     string
+	description string
+	subtree     OID
+	scalars     []Scalar
+	sessionID   uint32
+	packetID    uint32
+}
+
+// NewSubAgent builds a sub-agent serving the given scalars, which must all
+// live under the subtree the sub-agent registers
+func NewSubAgent(network, address, description string, subtree OID, scalars []Scalar) *SubAgent {
+	sorted := append([]Scalar{}, scalars...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].OID.Compare(sorted[j].OID) < 0
+	})
+	return &SubAgent{
+		network:     network,
+		address:     address,
+		description: description,
+		subtree:     subtree,
+		scalars:     sorted,
+	}
+}
+
+// Run connects to the master agent and serves requests until the context is
+// done, reconnecting with a fixed delay when the session drops. The returned
+// error is the last connection error, or nil when the context ended the run.
+func (s *SubAgent) Run(ctx context.Context) error {
+	var lastErr error
+	for {
+		if err := s.serve(ctx); err != nil {
+			lastErr = err
+		}
+		select {
+		case <-time.After(reconnectDelay):
+		case <-ctx.Done():
+			return lastErr
+		}
+	}
+}
+
+func (s *SubAgent) serve(ctx context.Context) error {
+	conn, err := net.DialTimeout(s.network, s.address, sessionOpenTimeout)
+	if err != nil {
+		return fmt.Errorf("unable to connect to the master agent at %s: %v", s.address, err)
+	}
+	defer conn.Close()
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	if err := s.openSession(conn); err != nil {
+		return err
+	}
+	if err := s.register(conn); err != nil {
+		return err
+	}
+
+	for {
+		header, payload, err := readPDU(conn)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		switch header.pduType {
+		case pduGet, pduGetNext:
+			varbinds := s.handleSearch(header.pduType, newDecoder(payload, header.bigEndian))
+			if err := s.respond(conn, header, varbinds); err != nil {
+				return err
+			}
+		case pduPing:
+			if err := s.respond(conn, header, nil); err != nil {
+				return err
+			}
+		case pduClose:
+			_ = s.respond(conn, header, nil)
+			return fmt.Errorf("session closed by the master agent")
+		default:
+			// silently ignore the PDU types a read-only sub-agent never sees
+		}
+	}
+}
+
+// openSession performs the agentx-Open handshake and records the session ID
+// the master assigned
+func (s *SubAgent) openSession(conn net.Conn) error {
+	payload := newEncoder()
+	payload.byte(byte(sessionOpenTimeout / time.Second))
+	payload.pad(3)
+	payload.oid(nil)
+	payload.octetString([]byte(s.description))
+
+	header, _, err := s.transact(conn, pduOpen, payload.bytes())
+	if err != nil {
+		return fmt.Errorf("unable to open an AgentX session: %v", err)
+	}
+	s.sessionID = header.sessionID
+	return nil
+}
+
+func (s *SubAgent) register(conn net.Conn) error {
+	payload := newEncoder()
+	payload.byte(0)   // timeout: use the session default
+	payload.byte(127) // priority: the register default
+	payload.byte(0)   // range_subid: register a plain subtree
+	payload.pad(1)
+	payload.oid(s.subtree)
+
+	if _, _, err := s.transact(conn, pduRegister, payload.bytes()); err != nil {
+		return fmt.Errorf("unable to register %s: %v", s.subtree, err)
+	}
+	return nil
+}
+
+// transact sends a PDU and waits for the matching response
+func (s *SubAgent) transact(conn net.Conn, pduType byte, payload []byte) (header, []byte, error) {
+	s.packetID++
+	if err := writePDU(conn, pduType, s.sessionID, 0, s.packetID, payload); err != nil {
+		return header{}, nil, err
+	}
+
+	h, responsePayload, err := readPDU(conn)
+	if err != nil {
+		return header{}, nil, err
+	}
+	if h.pduType != pduResponse {
+		return header{}, nil, fmt.Errorf("expected a response PDU, got type %d", h.pduType)
+	}
+	response := newDecoder(responsePayload, h.bigEndian)
+	response.u32() // sysUpTime
+	if errCode := response.u16(); errCode != 0 {
+		return header{}, nil, fmt.Errorf("master agent returned error %d", errCode)
+	}
+	return h, responsePayload, nil
+}
+
+func (s *SubAgent) respond(conn net.Conn, request header, varbinds []byte) error {
+	payload := newEncoder()
+	payload.u32(0) // sysUpTime: not maintained by this sub-agent
+	payload.u16(0) // error
+	payload.u16(0) // index
+	payload.raw(varbinds)
+	return writePDU(conn, pduResponse, request.sessionID, request.transactionID, request.packetID, payload.bytes())
+}
+
+// handleSearch answers the SearchRangeList of a Get or GetNext PDU with one
+// varbind per range
+func (s *SubAgent) handleSearch(pduType byte, request *decoder) []byte {
+	varbinds := newEncoder()
+	for !request.empty() {
+		start, include := request.oid()
+		end, _ := request.oid()
+		if request.failed() {
+			break
+		}
+
+		if pduType == pduGet {
+			s.appendGet(varbinds, start)
+		} else {
+			s.appendGetNext(varbinds, start, include, end)
+		}
+	}
+	return varbinds.bytes()
+}
+
+func (s *SubAgent) appendGet(varbinds *encoder, name OID) {
+	for _, scalar := range s.scalars {
+		if scalar.OID.Compare(name) == 0 {
+			varbinds.varbind(scalar.Type, scalar.OID, scalar.Get())
+			return
+		}
+	}
+	varbinds.emptyVarbind(typeNoSuchObject, name)
+}
+
+func (s *SubAgent) appendGetNext(varbinds *encoder, start OID, include bool, end OID) {
+	for _, scalar := range s.scalars {
+		order := scalar.OID.Compare(start)
+		if order < 0 || (order == 0 && !include) {
+			continue
+		}
+		if len(end) > 0 && scalar.OID.Compare(end) >= 0 {
+			break
+		}
+		varbinds.varbind(scalar.Type, scalar.OID, scalar.Get())
+		return
+	}
+	varbinds.emptyVarbind(typeEndOfMibView, start)
+}
+
+type header struct {
+	pduType       byte
+	bigEndian     bool
+	sessionID     uint32
+	transactionID uint32
+	packetID      uint32
+	payloadLength uint32
+}
+
+func readPDU(conn io.Reader) (header, []byte, error) {
+	raw := make([]byte, headerLength)
+	if _, err := io.ReadFull(conn, raw); err != nil {
+		return header{}, nil, err
+	}
+	if raw[0] != 1 {
+		return header{}, nil, fmt.Errorf("unsupported AgentX version %d", raw[0])
+	}
+
+	h := header{
+		pduType:   raw[1],
+		bigEndian: raw[2]&0x10 != 0,
+	}
+	fields := newDecoder(raw[4:], h.bigEndian)
+	h.sessionID = fields.u32()
+	h.transactionID = fields.u32()
+	h.packetID = fields.u32()
+	h.payloadLength = fields.u32()
+
+	payload := make([]byte, h.payloadLength)
+	if _, err := io.ReadFull(conn, payload); err != nil {
+		return header{}, nil, err
+	}
+	return h, payload, nil
+}
+
+// writePDU frames and sends a PDU. The sub-agent always sends network byte
+// order, signalled by the NETWORK_BYTE_ORDER header flag.
+func writePDU(conn io.Writer, pduType byte, sessionID, transactionID, packetID uint32, payload []byte) error {
+	frame := newEncoder()
+	frame.byte(1) // version
+	frame.byte(pduType)
+	frame.byte(0x10) // flags: NETWORK_BYTE_ORDER
+	frame.pad(1)
+	frame.u32(sessionID)
+	frame.u32(transactionID)
+	frame.u32(packetID)
+	frame.u32(uint32(len(payload)))
+	frame.raw(payload)
+
+	_, err := conn.Write(frame.bytes())
+	return err
+}
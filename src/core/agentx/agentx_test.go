@@ -0,0 +1,133 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package agentx
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOID(t *testing.T) {
+	oid, err := ParseOID("1.3.6.1.4.1.8072.1")
+	require.NoError(t, err)
+	assert.Equal(t, OID{1, 3, 6, 1, 4, 1, 8072, 1}, oid)
+	assert.Equal(t, "1.3.6.1.4.1.8072.1", oid.String())
+
+	_, err = ParseOID("1.3.not-an-oid")
+	assert.Error(t, err)
+
+	assert.Equal(t, 0, OID{1, 3, 6}.Compare(OID{1, 3, 6}))
+	assert.Equal(t, -1, OID{1, 3, 6}.Compare(OID{1, 3, 6, 1}))
+	assert.Equal(t, 1, OID{1, 4}.Compare(OID{1, 3, 6}))
+}
+
+// fakeMaster drives the master agent's side of an AgentX session using the
+// package's own codec
+type fakeMaster struct {
+	t    *testing.T
+	conn net.Conn
+}
+
+func (m *fakeMaster) respondOK(request header, sessionID uint32) {
+	payload := newEncoder()
+	payload.u32(0)
+	payload.u16(0)
+	payload.u16(0)
+	require.NoError(m.t, writePDU(m.conn, pduResponse, sessionID, request.transactionID, request.packetID, payload.bytes()))
+}
+
+// search sends a Get or GetNext PDU and returns the varbind of the response
+func (m *fakeMaster) search(pduType byte, sessionID uint32, start, end OID) (uint16, OID, *decoder) {
+	payload := newEncoder()
+	payload.oid(start)
+	payload.oid(end)
+	require.NoError(m.t, writePDU(m.conn, pduType, sessionID, 7, 99, payload.bytes()))
+
+	response, responsePayload, err := readPDU(m.conn)
+	require.NoError(m.t, err)
+	require.Equal(m.t, byte(pduResponse), response.pduType)
+	assert.Equal(m.t, uint32(7), response.transactionID)
+	assert.Equal(m.t, uint32(99), response.packetID)
+
+	varbinds := newDecoder(responsePayload, response.bigEndian)
+	varbinds.u32() // sysUpTime
+	require.Equal(m.t, uint16(0), varbinds.u16())
+	varbinds.u16() // index
+
+	valueType := varbinds.u16()
+	varbinds.u16() // reserved
+	name, _ := varbinds.oid()
+	return valueType, name, varbinds
+}
+
+func TestSubAgentSession(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	base := OID{1, 3, 6, 1, 4, 1, 8072, 9999, 1}
+	subAgent := NewSubAgent("tcp", listener.Addr().String(), "nginx-agent", base, []Scalar{
+		{OID: base.Append(2, 0), Type: TypeCounter64, Get: func() uint64 { return 12345 }},
+		{OID: base.Append(1, 0), Type: TypeGauge32, Get: func() uint64 { return 42 }},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go subAgent.Run(ctx)
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	master := &fakeMaster{t: t, conn: conn}
+
+	// the sub-agent opens a session and registers its subtree
+	open, _, err := readPDU(conn)
+	require.NoError(t, err)
+	require.Equal(t, byte(pduOpen), open.pduType)
+	master.respondOK(open, 1000)
+
+	register, registerPayload, err := readPDU(conn)
+	require.NoError(t, err)
+	require.Equal(t, byte(pduRegister), register.pduType)
+	assert.Equal(t, uint32(1000), register.sessionID)
+	registered := newDecoder(registerPayload, register.bigEndian)
+	registered.take(4)
+	subtree, _ := registered.oid()
+	assert.Equal(t, base, subtree)
+	master.respondOK(register, 1000)
+
+	// Get on an existing scalar returns its value
+	valueType, name, values := master.search(pduGet, 1000, base.Append(1, 0), nil)
+	assert.Equal(t, uint16(TypeGauge32), valueType)
+	assert.Equal(t, base.Append(1, 0), name)
+	assert.Equal(t, uint32(42), values.u32())
+
+	// Get on an unknown OID returns noSuchObject
+	valueType, name, _ = master.search(pduGet, 1000, base.Append(9, 0), nil)
+	assert.Equal(t, uint16(typeNoSuchObject), valueType)
+	assert.Equal(t, base.Append(9, 0), name)
+
+	// GetNext walks from the subtree root to the first scalar in OID order
+	valueType, name, values = master.search(pduGetNext, 1000, base, nil)
+	assert.Equal(t, uint16(TypeGauge32), valueType)
+	assert.Equal(t, base.Append(1, 0), name)
+	assert.Equal(t, uint32(42), values.u32())
+
+	// GetNext past the last scalar returns endOfMibView
+	valueType, _, _ = master.search(pduGetNext, 1000, base.Append(2, 0), nil)
+	assert.Equal(t, uint16(typeEndOfMibView), valueType)
+
+	// a Counter64 scalar is encoded as eight bytes
+	valueType, _, values = master.search(pduGet, 1000, base.Append(2, 0), nil)
+	assert.Equal(t, uint16(TypeCounter64), valueType)
+	assert.Equal(t, uint64(12345), uint64(values.u32())<<32|uint64(values.u32()))
+}
@@ -0,0 +1,154 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package agentx
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// encoder builds AgentX PDU payloads. Everything the sub-agent sends uses
+// network byte order.
+type encoder struct {
+	buf bytes.Buffer
+}
+
+func newEncoder() *encoder {
+	return &encoder{}
+}
+
+func (e *encoder) bytes() []byte {
+	return e.buf.Bytes()
+}
+
+func (e *encoder) byte(v byte) {
+	e.buf.WriteByte(v)
+}
+
+func (e *encoder) pad(n int) {
+	e.buf.Write(make([]byte, n))
+}
+
+func (e *encoder) raw(data []byte) {
+	e.buf.Write(data)
+}
+
+func (e *encoder) u16(v uint16) {
+	var raw [2]byte
+	binary.BigEndian.PutUint16(raw[:], v)
+	e.buf.Write(raw[:])
+}
+
+func (e *encoder) u32(v uint32) {
+	var raw [4]byte
+	binary.BigEndian.PutUint32(raw[:], v)
+	e.buf.Write(raw[:])
+}
+
+func (e *encoder) u64(v uint64) {
+	var raw [8]byte
+	binary.BigEndian.PutUint64(raw[:], v)
+	e.buf.Write(raw[:])
+}
+
+func (e *encoder) oid(oid OID) {
+	e.byte(byte(len(oid)))
+	e.byte(0) // prefix: OIDs are written out in full
+	e.byte(0) // include
+	e.pad(1)
+	for _, subID := range oid {
+		e.u32(subID)
+	}
+}
+
+func (e *encoder) octetString(data []byte) {
+	e.u32(uint32(len(data)))
+	e.raw(data)
+	if padding := len(data) % 4; padding != 0 {
+		e.pad(4 - padding)
+	}
+}
+
+// varbind writes a varbind carrying a scalar value
+func (e *encoder) varbind(valueType uint16, name OID, value uint64) {
+	e.u16(valueType)
+	e.pad(2)
+	e.oid(name)
+	if valueType == TypeCounter64 {
+		e.u64(value)
+	} else {
+		e.u32(uint32(value))
+	}
+}
+
+// emptyVarbind writes a valueless varbind, used for the noSuchObject and
+// endOfMibView exceptions
+func (e *encoder) emptyVarbind(valueType uint16, name OID) {
+	e.u16(valueType)
+	e.pad(2)
+	e.oid(name)
+}
+
+// decoder reads AgentX PDU payloads in the byte order the PDU header
+// announced. A short read marks the decoder failed and zero values are
+// returned from then on.
+type decoder struct {
+	data  []byte
+	order binary.ByteOrder
+	err   bool
+}
+
+func newDecoder(data []byte, bigEndian bool) *decoder {
+	order := binary.ByteOrder(binary.LittleEndian)
+	if bigEndian {
+		order = binary.BigEndian
+	}
+	return &decoder{data: data, order: order}
+}
+
+func (d *decoder) empty() bool {
+	return len(d.data) == 0
+}
+
+func (d *decoder) failed() bool {
+	return d.err
+}
+
+func (d *decoder) take(n int) []byte {
+	if d.err || len(d.data) < n {
+		d.err = true
+		return make([]byte, n)
+	}
+	taken := d.data[:n]
+	d.data = d.data[n:]
+	return taken
+}
+
+func (d *decoder) u16() uint16 {
+	return d.order.Uint16(d.take(2))
+}
+
+func (d *decoder) u32() uint32 {
+	return d.order.Uint32(d.take(4))
+}
+
+// oid reads an object identifier, expanding the compact internet prefix form,
+// and reports whether the include flag was set on it
+func (d *decoder) oid() (OID, bool) {
+	head := d.take(4)
+	nSubIDs, prefix, include := int(head[0]), head[1], head[2] != 0
+
+	var oid OID
+	if prefix != 0 {
+		oid = OID{1, 3, 6, 1, uint32(prefix)}
+	}
+	for i := 0; i < nSubIDs; i++ {
+		oid = append(oid, d.u32())
+	}
+	return oid, include
+}
@@ -0,0 +1,80 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// Priorities of outbound traffic against the bandwidth budget, most important
+// first.
+const (
+	// BandwidthPriorityControl covers command traffic such as registration,
+	// config uploads and command responses
+	BandwidthPriorityControl = iota
+	// BandwidthPriorityMetrics covers metric reports
+	BandwidthPriorityMetrics
+	// BandwidthPriorityEvents covers event and security violation reports
+	BandwidthPriorityEvents
+)
+
+// BandwidthBudget bounds how many bytes the agent sends per interval, for
+// edge boxes on metered or narrow links. Control traffic always goes out and
+// lower priorities are deferred once their share of the budget is spent.
+type BandwidthBudget struct {
+	mu        sync.Mutex
+	limit     int64
+	interval  time.Duration
+	remaining int64
+	resetAt   time.Time
+}
+
+// NewBandwidthBudget returns a budget of limit bytes per interval. A limit of
+// zero disables budgeting.
+func NewBandwidthBudget(limit int64, interval time.Duration) *BandwidthBudget {
+	return &BandwidthBudget{
+		limit:    limit,
+		interval: interval,
+	}
+}
+
+// Allow reports whether a payload of size bytes may be sent now, debiting the
+// budget when it may. Control traffic is always allowed and can drive the
+// budget negative, so lower priorities defer until it recovers. The last
+// quarter of the budget is reserved for control and metric traffic, so event
+// reports cannot starve them.
+func (b *BandwidthBudget) Allow(priority int, size int64) bool {
+	if b == nil || b.limit <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if now.After(b.resetAt) {
+		b.remaining = b.limit
+		b.resetAt = now.Add(b.interval)
+	}
+
+	var floor int64
+	switch priority {
+	case BandwidthPriorityControl:
+		b.remaining -= size
+		return true
+	case BandwidthPriorityEvents:
+		floor = b.limit / 4
+	}
+
+	if b.remaining-size < floor {
+		return false
+	}
+	b.remaining -= size
+	return true
+}
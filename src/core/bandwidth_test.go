@@ -0,0 +1,51 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandwidthBudgetDisabled(t *testing.T) {
+	var budget *BandwidthBudget
+	assert.True(t, budget.Allow(BandwidthPriorityEvents, 1<<30))
+
+	budget = NewBandwidthBudget(0, time.Minute)
+	assert.True(t, budget.Allow(BandwidthPriorityEvents, 1<<30))
+}
+
+func TestBandwidthBudgetPriorities(t *testing.T) {
+	budget := NewBandwidthBudget(1000, time.Minute)
+
+	// events may only spend down to the last quarter of the budget
+	assert.True(t, budget.Allow(BandwidthPriorityEvents, 700))
+	assert.False(t, budget.Allow(BandwidthPriorityEvents, 100))
+
+	// metrics may spend the reserve events cannot touch
+	assert.True(t, budget.Allow(BandwidthPriorityMetrics, 300))
+	assert.False(t, budget.Allow(BandwidthPriorityMetrics, 100))
+
+	// control traffic is never denied, even over budget
+	assert.True(t, budget.Allow(BandwidthPriorityControl, 100))
+
+	// the overdraft defers lower priorities until the budget recovers
+	assert.False(t, budget.Allow(BandwidthPriorityMetrics, 1))
+}
+
+func TestBandwidthBudgetRefill(t *testing.T) {
+	budget := NewBandwidthBudget(100, 10*time.Millisecond)
+
+	assert.True(t, budget.Allow(BandwidthPriorityMetrics, 100))
+	assert.False(t, budget.Allow(BandwidthPriorityMetrics, 100))
+
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, budget.Allow(BandwidthPriorityMetrics, 100))
+}
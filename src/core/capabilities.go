@@ -0,0 +1,65 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Capabilities the agent may have to run without when it lacks privileges.
+const (
+	CapabilityAccessLogs = "access-logs"
+	CapabilityErrorLogs  = "error-logs"
+)
+
+// CapabilityGap names a capability the agent cannot use with the privileges
+// it is running under, and why. Gaps are detected up front so the affected
+// collectors are disabled once instead of erroring on every collection cycle.
+type CapabilityGap struct {
+	Capability string `json:"capability"`
+	Reason     string `json:"reason"`
+}
+
+// FilterReadableLogs splits logs (path to format) into the ones the agent can
+// open with its current privileges and the paths it cannot.
+func FilterReadableLogs(logs map[string]string) (readable map[string]string, unreadable []string) {
+	readable = make(map[string]string, len(logs))
+	for logFile, format := range logs {
+		f, err := os.Open(logFile)
+		if err != nil {
+			unreadable = append(unreadable, logFile)
+			continue
+		}
+		f.Close()
+		readable[logFile] = format
+	}
+	sort.Strings(unreadable)
+	return readable, unreadable
+}
+
+// DetectCapabilityGaps probes the discovered NGINX log files and returns the
+// capability set the agent has to run without.
+func DetectCapabilityGaps(accessLogs, errorLogs map[string]string) []CapabilityGap {
+	gaps := []CapabilityGap{}
+	if _, unreadable := FilterReadableLogs(accessLogs); len(unreadable) > 0 {
+		gaps = append(gaps, CapabilityGap{
+			Capability: CapabilityAccessLogs,
+			Reason:     fmt.Sprintf("cannot read %s", strings.Join(unreadable, ", ")),
+		})
+	}
+	if _, unreadable := FilterReadableLogs(errorLogs); len(unreadable) > 0 {
+		gaps = append(gaps, CapabilityGap{
+			Capability: CapabilityErrorLogs,
+			Reason:     fmt.Sprintf("cannot read %s", strings.Join(unreadable, ", ")),
+		})
+	}
+	return gaps
+}
@@ -0,0 +1,47 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterReadableLogs(t *testing.T) {
+	accessLog := filepath.Join(t.TempDir(), "access.log")
+	require.NoError(t, os.WriteFile(accessLog, []byte(""), 0644))
+
+	readable, unreadable := FilterReadableLogs(map[string]string{
+		accessLog:               "$remote_addr",
+		"/does/not/exist/a.log": "$remote_addr",
+		"/does/not/exist/b.log": "$remote_addr",
+	})
+
+	assert.Equal(t, map[string]string{accessLog: "$remote_addr"}, readable)
+	assert.Equal(t, []string{"/does/not/exist/a.log", "/does/not/exist/b.log"}, unreadable)
+}
+
+func TestDetectCapabilityGaps(t *testing.T) {
+	errorLog := filepath.Join(t.TempDir(), "error.log")
+	require.NoError(t, os.WriteFile(errorLog, []byte(""), 0644))
+
+	gaps := DetectCapabilityGaps(
+		map[string]string{"/does/not/exist/access.log": ""},
+		map[string]string{errorLog: ""},
+	)
+
+	require.Len(t, gaps, 1)
+	assert.Equal(t, CapabilityAccessLogs, gaps[0].Capability)
+	assert.Contains(t, gaps[0].Reason, "/does/not/exist/access.log")
+
+	assert.Empty(t, DetectCapabilityGaps(map[string]string{errorLog: ""}, nil))
+}
@@ -10,10 +10,12 @@ package config
 import (
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -79,6 +81,8 @@ func SetDefaults() {
 
 	// NGINX DEFAULTS
 	Viper.SetDefault(NginxClientVersion, Defaults.Nginx.NginxClientVersion)
+	Viper.SetDefault(NginxConfigCollectWorkers, Defaults.Nginx.ConfigCollectWorkers)
+	Viper.SetDefault(NginxConfigApplyFsyncDirs, Defaults.Nginx.ConfigApplyFsyncDirs)
 }
 
 func SetNginxAppProtectDefaults() {
@@ -127,18 +131,26 @@ func RegisterFlags() {
 	Viper.AutomaticEnv()
 
 	fs := ROOT_COMMAND.Flags()
+	// normalizing before registration lets the generated flags detect the
+	// hand-written ones regardless of dash or underscore spelling
+	fs.SetNormalizeFunc(wordSepNormalizeFunc)
 	for _, f := range append(agentFlags, deprecatedFlags...) {
 		f.register(fs)
 	}
+	registerConfigStructFlags(fs)
 
-	fs.SetNormalizeFunc(wordSepNormalizeFunc)
 	deprecateFlags()
 
+	// flags take precedence over environment variables, which take precedence
+	// over the configuration file and the built-in defaults
 	fs.VisitAll(func(flag *flag.Flag) {
-		if err := Viper.BindPFlag(strings.ReplaceAll(flag.Name, "-", "_"), fs.Lookup(flag.Name)); err != nil {
+		key := strings.ReplaceAll(flag.Name, "-", "_")
+		if err := Viper.BindPFlag(key, fs.Lookup(flag.Name)); err != nil {
 			return
 		}
-		err := Viper.BindEnv(flag.Name)
+		err := Viper.BindEnv(key,
+			strings.ToUpper(EnvPrefix+agent_config.KeyDelimiter+key),
+			strings.ToUpper(AgentEnvPrefix+agent_config.KeyDelimiter+key))
 		if err != nil {
 			log.Warnf("error occurred binding env %s: %v", flag.Name, err)
 		}
@@ -156,6 +168,13 @@ func RegisterConfigFile(dynamicConfFilePath string, confFileName string, confPat
 		log.Fatalf("Unable to load properties from config files (%s, %s) - %v", cfg, dynamicConfFilePath, err)
 	}
 
+	// typos in the config file are not fatal, but they should not be silent
+	if findings, err := CheckConfigFile(cfg); err == nil {
+		for _, finding := range findings {
+			log.Warnf("Problem in %s, %s", cfg, finding)
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -174,16 +193,40 @@ func GetConfig(clientId string) (*Config, error) {
 		ConfigDirs:            Viper.GetString(ConfigDirsKey),
 		Log:                   getLog(),
 		TLS:                   getTLS(),
+		FIPSMode:              Viper.GetBool(FIPSModeKey),
 		Nginx:                 getNginx(),
 		Dataplane:             getDataplane(),
 		AgentMetrics:          getMetrics(),
 		Features:              Viper.GetStringSlice(agent_config.FeaturesKey),
 		Tags:                  Viper.GetStringSlice(TagsKey),
+		Labels:                Viper.GetStringMapString(LabelsKey),
 		Updated:               filePathUTime(Viper.GetString(DynamicConfigPathKey)),
 		AllowedDirectoriesMap: map[string]struct{}{},
 		DisplayName:           Viper.GetString(DisplayNameKey),
 		InstanceGroup:         Viper.GetString(InstanceGroupKey),
+		ShutdownGracePeriod:   Viper.GetDuration(ShutdownGracePeriodKey),
 		NginxAppProtect:       getNginxAppProtect(),
+		CertExpiry:            getCertExpiry(),
+		ResolverHealth:        getResolverHealth(),
+		SyntheticChecks:       getSyntheticChecks(),
+		Acme:                  getAcme(),
+		ErrorLogEvents:        getErrorLogEvents(),
+		LogForwarding:         getLogForwarding(),
+		Docker:                getDocker(),
+		Consul:                getConsul(),
+		Vault:                 getVault(),
+		ObjectStore:           getObjectStore(),
+		SNMP:                  getSNMP(),
+		Webhook:               getWebhook(),
+		Tracing:               getTracing(),
+		Unit:                  getUnit(),
+		AgentUpdate:           getAgentUpdate(),
+		DiskBudget:            getDiskBudget(),
+		BandwidthBudget:       getBandwidthBudget(),
+		Supervisor:            getSupervisor(),
+		Failover:              getFailover(),
+		Export:                getExport(),
+		InstancePolicies:      getInstancePolicies(),
 		NAPMonitoring:         getNAPMonitoring(),
 		AdvancedMetrics:       getAdvancedMetrics(),
 	}
@@ -193,7 +236,8 @@ func GetConfig(clientId string) (*Config, error) {
 			config.AllowedDirectoriesMap[dir] = struct{}{}
 		}
 	}
-	config.Server.Target = fmt.Sprintf("%s:%d", config.Server.Host, config.Server.GrpcPort)
+	// JoinHostPort brackets IPv6 literals, so the target is dialable either way
+	config.Server.Target = net.JoinHostPort(config.Server.Host, strconv.Itoa(config.Server.GrpcPort))
 
 	log.Tracef("%v", config)
 	return config, nil
@@ -273,6 +317,9 @@ func getMetrics() AgentMetrics {
 		ReportInterval:     Viper.GetDuration(MetricsReportInterval),
 		CollectionInterval: Viper.GetDuration(MetricsCollectionInterval),
 		Mode:               Viper.GetString(MetricsMode),
+		Naming:             Viper.GetString(MetricsNaming),
+		CorrectClockSkew:   Viper.GetBool(MetricsCorrectClockSkew),
+		MaxMessageSize:     Viper.GetInt(MetricsMaxMessageSize),
 	}
 }
 
@@ -292,8 +339,13 @@ func getAdvancedMetrics() AdvancedMetrics {
 
 func getLog() LogConfig {
 	return LogConfig{
-		Level: Viper.GetString(LogLevel),
-		Path:  Viper.GetString(LogPath),
+		Level:      Viper.GetString(LogLevel),
+		Path:       Viper.GetString(LogPath),
+		Format:     Viper.GetString(LogFormat),
+		Journal:    Viper.GetBool(LogJournal),
+		MaxSize:    Viper.GetInt(LogMaxSize),
+		MaxBackups: Viper.GetInt(LogMaxBackups),
+		MaxAge:     Viper.GetInt(LogMaxAge),
 	}
 }
 
@@ -306,6 +358,217 @@ func getDataplane() Dataplane {
 	}
 }
 
+func getCertExpiry() CertExpiry {
+	return CertExpiry{
+		WarningDays:  Viper.GetInt(CertExpiryWarningDays),
+		CriticalDays: Viper.GetInt(CertExpiryCriticalDays),
+		Interval:     Viper.GetDuration(CertExpiryInterval),
+	}
+}
+
+func getResolverHealth() ResolverHealth {
+	return ResolverHealth{
+		Interval: Viper.GetDuration(ResolverHealthInterval),
+	}
+}
+
+func getSyntheticChecks() SyntheticChecks {
+	checks := []SyntheticCheck{}
+	if err := Viper.UnmarshalKey(SyntheticChecksChecks, &checks); err != nil {
+		log.Warnf("Unable to decode synthetic checks from config: %v", err)
+	}
+	return SyntheticChecks{
+		Interval: Viper.GetDuration(SyntheticChecksInterval),
+		Checks:   checks,
+	}
+}
+
+func getAcme() Acme {
+	return Acme{
+		Enabled:      Viper.GetBool(AcmeEnabled),
+		DirectoryURL: Viper.GetString(AcmeDirectoryURL),
+		Email:        Viper.GetString(AcmeEmail),
+		Challenge:    Viper.GetString(AcmeChallenge),
+		DNSProvider:  Viper.GetString(AcmeDNSProvider),
+		WebrootPath:  Viper.GetString(AcmeWebrootPath),
+		CertDir:      Viper.GetString(AcmeCertDir),
+		RenewBefore:  Viper.GetDuration(AcmeRenewBefore),
+		Interval:     Viper.GetDuration(AcmeInterval),
+	}
+}
+
+func getErrorLogEvents() ErrorLogEvents {
+	return ErrorLogEvents{
+		Enable:            Viper.GetBool(ErrorLogEventsEnable),
+		MinLevel:          Viper.GetString(ErrorLogEventsMinLevel),
+		Patterns:          Viper.GetStringSlice(ErrorLogEventsPatterns),
+		ExcerptLength:     Viper.GetInt(ErrorLogEventsExcerptLength),
+		MultilinePatterns: Viper.GetStringSlice(ErrorLogEventsMultilinePatterns),
+		MultilineTimeout:  Viper.GetDuration(ErrorLogEventsMultilineTimeout),
+	}
+}
+
+func getLogForwarding() LogForwarding {
+	return LogForwarding{
+		Enable:          Viper.GetBool(LogForwardingEnable),
+		Sink:            Viper.GetString(LogForwardingSink),
+		Address:         Viper.GetString(LogForwardingAddress),
+		KafkaTopic:      Viper.GetString(LogForwardingKafkaTopic),
+		BatchSize:       Viper.GetInt(LogForwardingBatchSize),
+		BatchInterval:   Viper.GetDuration(LogForwardingBatchInterval),
+		QueueSize:       Viper.GetInt(LogForwardingQueueSize),
+		CheckpointDir:   Viper.GetString(LogForwardingCheckpointDir),
+		RotationCatchUp: Viper.GetBool(LogForwardingRotationCatchUp),
+	}
+}
+
+func getDocker() Docker {
+	return Docker{
+		Enable:       Viper.GetBool(DockerEnable),
+		Socket:       Viper.GetString(DockerSocket),
+		PollInterval: Viper.GetDuration(DockerPollInterval),
+	}
+}
+
+func getConsul() Consul {
+	return Consul{
+		Enable:          Viper.GetBool(ConsulEnable),
+		Address:         Viper.GetString(ConsulAddress),
+		Token:           Viper.GetString(ConsulToken),
+		CheckTTL:        Viper.GetDuration(ConsulCheckTTL),
+		DeregisterAfter: Viper.GetDuration(ConsulDeregisterAfter),
+	}
+}
+
+func getVault() Vault {
+	return Vault{
+		Enable:       Viper.GetBool(VaultEnable),
+		Address:      Viper.GetString(VaultAddress),
+		Namespace:    Viper.GetString(VaultNamespace),
+		Auth:         Viper.GetString(VaultAuth),
+		RoleID:       Viper.GetString(VaultRoleID),
+		SecretIDFile: Viper.GetString(VaultSecretIDFile),
+		Role:         Viper.GetString(VaultRole),
+		Token:        Viper.GetString(VaultToken),
+	}
+}
+
+func getTracing() Tracing {
+	return Tracing{
+		Enable:      Viper.GetBool(TracingEnable),
+		Endpoint:    Viper.GetString(TracingEndpoint),
+		ServiceName: Viper.GetString(TracingServiceName),
+	}
+}
+
+func getWebhook() Webhook {
+	return Webhook{
+		Enable:       Viper.GetBool(WebhookEnable),
+		URL:          Viper.GetString(WebhookURL),
+		Type:         Viper.GetString(WebhookType),
+		SecretEnv:    Viper.GetString(WebhookSecretEnv),
+		Levels:       Viper.GetStringSlice(WebhookLevels),
+		Timeout:      Viper.GetDuration(WebhookTimeout),
+		Retries:      Viper.GetInt(WebhookRetries),
+		RetryBackoff: Viper.GetDuration(WebhookRetryBackoff),
+	}
+}
+
+func getSNMP() SNMP {
+	return SNMP{
+		Enable:        Viper.GetBool(SNMPEnable),
+		AgentXAddress: Viper.GetString(SNMPAgentXAddress),
+		BaseOID:       Viper.GetString(SNMPBaseOID),
+	}
+}
+
+func getObjectStore() ObjectStore {
+	return ObjectStore{
+		Enable:          Viper.GetBool(ObjectStoreEnable),
+		Endpoint:        Viper.GetString(ObjectStoreEndpoint),
+		Region:          Viper.GetString(ObjectStoreRegion),
+		Bucket:          Viper.GetString(ObjectStoreBucket),
+		Prefix:          Viper.GetString(ObjectStorePrefix),
+		AccessKeyID:     Viper.GetString(ObjectStoreAccessKeyID),
+		SecretAccessKey: Viper.GetString(ObjectStoreSecretAccessKey),
+		SSE:             Viper.GetString(ObjectStoreSSE),
+		SSEKMSKeyID:     Viper.GetString(ObjectStoreSSEKMSKeyID),
+		PollInterval:    Viper.GetDuration(ObjectStorePollInterval),
+		Backup:          Viper.GetBool(ObjectStoreBackup),
+	}
+}
+
+func getUnit() Unit {
+	return Unit{
+		Enable:         Viper.GetBool(UnitEnable),
+		ControlSocket:  Viper.GetString(UnitControlSocket),
+		ReportInterval: Viper.GetDuration(UnitReportInterval),
+	}
+}
+
+func getAgentUpdate() AgentUpdate {
+	return AgentUpdate{
+		Enable:    Viper.GetBool(AgentUpdateEnable),
+		PublicKey: Viper.GetString(AgentUpdatePublicKey),
+	}
+}
+
+func getDiskBudget() DiskBudget {
+	return DiskBudget{
+		Enable:            Viper.GetBool(DiskBudgetEnable),
+		BudgetMB:          Viper.GetInt(DiskBudgetBudgetMB),
+		WarningPercent:    Viper.GetInt(DiskBudgetWarningPercent),
+		Interval:          Viper.GetDuration(DiskBudgetInterval),
+		LogQuotaMB:        Viper.GetInt(DiskBudgetLogQuotaMB),
+		CheckpointQuotaMB: Viper.GetInt(DiskBudgetCheckpointQuotaMB),
+		BundleQuotaMB:     Viper.GetInt(DiskBudgetBundleQuotaMB),
+	}
+}
+
+func getExport() Export {
+	return Export{
+		Enable:     Viper.GetBool(ExportEnable),
+		File:       Viper.GetString(ExportFile),
+		MaxSize:    Viper.GetInt(ExportMaxSize),
+		MaxBackups: Viper.GetInt(ExportMaxBackups),
+	}
+}
+
+func getInstancePolicies() []InstancePolicy {
+	policies := []InstancePolicy{}
+	if err := Viper.UnmarshalKey(InstancePoliciesKey, &policies); err != nil {
+		log.Warnf("Unable to decode instance policies from config: %v", err)
+	}
+	return policies
+}
+
+func getFailover() Failover {
+	return Failover{
+		Enable:           Viper.GetBool(FailoverEnable),
+		Servers:          Viper.GetStringSlice(FailoverServers),
+		ProbeInterval:    Viper.GetDuration(FailoverProbeInterval),
+		FailureThreshold: Viper.GetInt(FailoverFailureThreshold),
+	}
+}
+
+func getSupervisor() Supervisor {
+	return Supervisor{
+		Enable:       Viper.GetBool(SupervisorEnable),
+		BinPath:      Viper.GetString(SupervisorBinPath),
+		BackoffMin:   Viper.GetDuration(SupervisorBackoffMin),
+		BackoffMax:   Viper.GetDuration(SupervisorBackoffMax),
+		StableUptime: Viper.GetDuration(SupervisorStableUptime),
+	}
+}
+
+func getBandwidthBudget() BandwidthBudget {
+	return BandwidthBudget{
+		Enable:           Viper.GetBool(BandwidthBudgetEnable),
+		BytesPerInterval: Viper.GetInt(BandwidthBudgetBytesPerInterval),
+		Interval:         Viper.GetDuration(BandwidthBudgetInterval),
+	}
+}
+
 func getNginxAppProtect() NginxAppProtect {
 	return NginxAppProtect{
 		ReportInterval:         Viper.GetDuration(NginxAppProtectReportInterval),
@@ -326,10 +589,14 @@ func getNAPMonitoring() NAPMonitoring {
 
 func getNginx() Nginx {
 	return Nginx{
-		ExcludeLogs:         Viper.GetString(NginxExcludeLogs),
-		Debug:               Viper.GetBool(NginxDebug),
-		NginxCountingSocket: Viper.GetString(NginxCountingSocket),
-		NginxClientVersion:  Viper.GetInt(NginxClientVersion),
+		ExcludeLogs:          Viper.GetString(NginxExcludeLogs),
+		Debug:                Viper.GetBool(NginxDebug),
+		LogAccessEvents:      Viper.GetBool(NginxLogAccessEvents),
+		BinarySearchPaths:    Viper.GetStringSlice(NginxBinarySearchPaths),
+		NginxCountingSocket:  Viper.GetString(NginxCountingSocket),
+		NginxClientVersion:   Viper.GetInt(NginxClientVersion),
+		ConfigCollectWorkers: Viper.GetInt(NginxConfigCollectWorkers),
+		ConfigApplyFsyncDirs: Viper.GetBool(NginxConfigApplyFsyncDirs),
 	}
 }
 
@@ -345,19 +612,21 @@ func getServer() Server {
 
 func getAgentAPI() AgentAPI {
 	return AgentAPI{
-		Port: Viper.GetInt(AgentAPIPort),
-		Cert: Viper.GetString(AgentAPICert),
-		Key:  Viper.GetString(AgentAPIKey),
+		Port:  Viper.GetInt(AgentAPIPort),
+		Cert:  Viper.GetString(AgentAPICert),
+		Key:   Viper.GetString(AgentAPIKey),
+		Token: Viper.GetString(AgentAPIToken),
 	}
 }
 
 func getTLS() TLSConfig {
 	return TLSConfig{
-		Enable:     Viper.GetBool(TlsEnable),
-		Cert:       Viper.GetString(TlsCert),
-		Key:        Viper.GetString(TlsPrivateKey),
-		Ca:         Viper.GetString(TlsCa),
-		SkipVerify: Viper.GetBool(TlsSkipVerify),
+		Enable:       Viper.GetBool(TlsEnable),
+		Cert:         Viper.GetString(TlsCert),
+		Key:          Viper.GetString(TlsPrivateKey),
+		Ca:           Viper.GetString(TlsCa),
+		SkipVerify:   Viper.GetBool(TlsSkipVerify),
+		SpiffeSocket: Viper.GetString(TlsSpiffeSocket),
 	}
 }
 
@@ -468,6 +468,57 @@ func TestUpdateAgentConfig(t *testing.T) {
 	}
 }
 
+func TestInstancePolicyFor(t *testing.T) {
+	conf := &Config{
+		InstancePolicies: []InstancePolicy{
+			{
+				ProcessID:          "1234",
+				DisableConfigApply: true,
+			},
+			{
+				ConfPathPrefix: "/etc/nginx/tenant-a/",
+				ConfigDirs:     "/etc/nginx/tenant-a",
+			},
+			{
+				BinPathPrefix:        "/opt/tenant-b/",
+				DisableAuxCollection: true,
+			},
+			{
+				// a policy without selectors must not match anything
+				ConfigDirs: "/",
+			},
+		},
+	}
+
+	policy := conf.InstancePolicyFor("/etc/nginx/tenant-a/nginx.conf", "/usr/sbin/nginx", "5678")
+	require.NotNil(t, policy)
+	assert.Equal(t, "/etc/nginx/tenant-a", policy.ConfigDirs)
+
+	policy = conf.InstancePolicyFor("/etc/nginx/nginx.conf", "/opt/tenant-b/sbin/nginx", "5678")
+	require.NotNil(t, policy)
+	assert.True(t, policy.DisableAuxCollection)
+
+	// the first matching policy wins
+	policy = conf.InstancePolicyFor("/etc/nginx/tenant-a/nginx.conf", "/usr/sbin/nginx", "1234")
+	require.NotNil(t, policy)
+	assert.True(t, policy.DisableConfigApply)
+
+	assert.Nil(t, conf.InstancePolicyFor("/etc/nginx/nginx.conf", "/usr/sbin/nginx", "5678"))
+}
+
+func TestAllowedDirectoriesFor(t *testing.T) {
+	conf := &Config{
+		AllowedDirectoriesMap: map[string]struct{}{"/etc/nginx": {}},
+	}
+
+	assert.Equal(t, conf.AllowedDirectoriesMap, conf.AllowedDirectoriesFor(nil))
+	assert.Equal(t, conf.AllowedDirectoriesMap, conf.AllowedDirectoriesFor(&InstancePolicy{}))
+	assert.Equal(t,
+		map[string]struct{}{"/etc/nginx/tenant-a": {}, "/var/www/tenant-a": {}},
+		conf.AllowedDirectoriesFor(&InstancePolicy{ConfigDirs: "/etc/nginx/tenant-a:/var/www/tenant-a"}),
+	)
+}
+
 func setEnvVariable(t *testing.T, name string, value string) {
 	key := strings.ToUpper(EnvPrefix + agent_config.KeyDelimiter + name)
 	err := os.Setenv(key, value)
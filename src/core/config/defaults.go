@@ -38,10 +38,16 @@ func ConfigFilePaths() []string {
 
 var (
 	Defaults = &Config{
-		CloudAccountID: uuid.New().String(),
+		CloudAccountID:      uuid.New().String(),
+		ShutdownGracePeriod: 5 * time.Second,
 		Log: LogConfig{
-			Level: "info",
-			Path:  "/var/log/nginx-agent",
+			Level:      "info",
+			Path:       "/var/log/nginx-agent",
+			Format:     "plain",
+			Journal:    false,
+			MaxSize:    0,
+			MaxBackups: 4,
+			MaxAge:     30,
 		},
 		Server: Server{
 			Command: "",
@@ -51,9 +57,11 @@ var (
 			Token: uuid.New().String(),
 		},
 		Nginx: Nginx{
-			Debug:               false,
-			NginxCountingSocket: "unix:/var/run/nginx-agent/nginx.sock",
-			NginxClientVersion:  6,
+			Debug:                false,
+			NginxCountingSocket:  "unix:/var/run/nginx-agent/nginx.sock",
+			NginxClientVersion:   6,
+			ConfigCollectWorkers: 4,
+			ConfigApplyFsyncDirs: false,
 		},
 		ConfigDirs:            "/etc/nginx:/usr/local/etc/nginx:/usr/share/nginx/modules:/etc/nms",
 		AllowedDirectoriesMap: map[string]struct{}{},
@@ -61,6 +69,7 @@ var (
 			Enable:     false,
 			SkipVerify: false,
 		},
+		FIPSMode: false,
 		Dataplane: Dataplane{
 			Status: Status{
 				PollInterval:   30 * time.Second,
@@ -72,6 +81,9 @@ var (
 			ReportInterval:     1 * time.Minute,
 			CollectionInterval: 15 * time.Second,
 			Mode:               "aggregation",
+			Naming:             "agent",
+			// the default grpc server receive limit
+			MaxMessageSize: 4194304,
 		},
 		Features: agent_config.GetDefaultFeatures(),
 		NAPMonitoring: NAPMonitoring{
@@ -85,6 +97,123 @@ var (
 		NginxAppProtect: NginxAppProtect{
 			PrecompiledPublication: false,
 		},
+		CertExpiry: CertExpiry{
+			WarningDays:  30,
+			CriticalDays: 7,
+			Interval:     24 * time.Hour,
+		},
+		ResolverHealth: ResolverHealth{
+			Interval: 5 * time.Minute,
+		},
+		SyntheticChecks: SyntheticChecks{
+			Interval: time.Minute,
+		},
+		Acme: Acme{
+			Enabled:      false,
+			DirectoryURL: "https://acme-v02.api.letsencrypt.org/directory",
+			Challenge:    "http-01",
+			WebrootPath:  "/var/www/nginx-agent-acme",
+			CertDir:      "/etc/nginx/ssl/acme",
+			RenewBefore:  30 * 24 * time.Hour,
+			Interval:     24 * time.Hour,
+		},
+		ErrorLogEvents: ErrorLogEvents{
+			Enable:           false,
+			MinLevel:         "crit",
+			ExcerptLength:    200,
+			MultilineTimeout: 1 * time.Second,
+		},
+		Docker: Docker{
+			Enable:       false,
+			PollInterval: 30 * time.Second,
+		},
+		Consul: Consul{
+			Enable:          false,
+			Address:         "http://127.0.0.1:8500",
+			CheckTTL:        30 * time.Second,
+			DeregisterAfter: 10 * time.Minute,
+		},
+		Vault: Vault{
+			Enable:  false,
+			Address: "http://127.0.0.1:8200",
+			Auth:    "approle",
+		},
+		Tracing: Tracing{
+			Enable:      false,
+			Endpoint:    "http://127.0.0.1:4318",
+			ServiceName: "nginx-agent",
+		},
+		Webhook: Webhook{
+			Enable:       false,
+			Type:         "generic",
+			Levels:       []string{"WARN", "ERROR", "CRITICAL"},
+			Timeout:      10 * time.Second,
+			Retries:      3,
+			RetryBackoff: 5 * time.Second,
+		},
+		SNMP: SNMP{
+			Enable:        false,
+			AgentXAddress: "unix:/var/agentx/master",
+			// the net-snmp playpen arc; deployments with their own
+			// enterprise number should point this at a subtree of it
+			BaseOID: "1.3.6.1.4.1.8072.9999.9999.123",
+		},
+		ObjectStore: ObjectStore{
+			Enable:       false,
+			Region:       "us-east-1",
+			SSE:          "AES256",
+			PollInterval: 30 * time.Second,
+			Backup:       true,
+		},
+		Unit: Unit{
+			Enable:         false,
+			ControlSocket:  "/var/run/unit/control.sock",
+			ReportInterval: 30 * time.Second,
+		},
+		AgentUpdate: AgentUpdate{
+			Enable: false,
+		},
+		DiskBudget: DiskBudget{
+			Enable:            false,
+			BudgetMB:          200,
+			WarningPercent:    80,
+			Interval:          5 * time.Minute,
+			LogQuotaMB:        100,
+			CheckpointQuotaMB: 50,
+			BundleQuotaMB:     50,
+		},
+		BandwidthBudget: BandwidthBudget{
+			Enable:           false,
+			BytesPerInterval: 1048576,
+			Interval:         time.Minute,
+		},
+		Export: Export{
+			Enable:     false,
+			File:       "/var/lib/nginx-agent/export.ndjson",
+			MaxSize:    50,
+			MaxBackups: 4,
+		},
+		Failover: Failover{
+			Enable:           false,
+			ProbeInterval:    15 * time.Second,
+			FailureThreshold: 3,
+		},
+		Supervisor: Supervisor{
+			Enable:       false,
+			BinPath:      "/usr/sbin/nginx",
+			BackoffMin:   time.Second,
+			BackoffMax:   time.Minute,
+			StableUptime: time.Minute,
+		},
+		LogForwarding: LogForwarding{
+			Enable:          false,
+			Sink:            "syslog",
+			BatchSize:       100,
+			BatchInterval:   5 * time.Second,
+			QueueSize:       10000,
+			CheckpointDir:   "/var/lib/nginx-agent",
+			RotationCatchUp: true,
+		},
 	}
 	AllowedDirectoriesMap map[string]struct{}
 )
@@ -95,8 +224,11 @@ const (
 	ConfigFileName           = "nginx-agent.conf"
 	ConfigFileType           = "yaml"
 	EnvPrefix                = "nms"
-	ConfigPathKey            = "path"
-	DynamicConfigPathKey     = "dynamic-config-path"
+	// AgentEnvPrefix is the documented prefix for environment variable
+	// overrides; the legacy EnvPrefix keeps working
+	AgentEnvPrefix       = "nginx_agent"
+	ConfigPathKey        = "path"
+	DynamicConfigPathKey = "dynamic-config-path"
 
 	CloudAccountIdKey = "cloudaccountid"
 	LocationKey       = "location"
@@ -104,12 +236,20 @@ const (
 	InstanceGroupKey  = "instance_group"
 	ConfigDirsKey     = "config_dirs"
 	TagsKey           = "tags"
+	LabelsKey         = "labels"
+
+	ShutdownGracePeriodKey = "shutdown_grace_period"
 
 	// viper keys used in config
 	LogKey = "log"
 
-	LogLevel = LogKey + agent_config.KeyDelimiter + "level"
-	LogPath  = LogKey + agent_config.KeyDelimiter + "path"
+	LogLevel      = LogKey + agent_config.KeyDelimiter + "level"
+	LogPath       = LogKey + agent_config.KeyDelimiter + "path"
+	LogFormat     = LogKey + agent_config.KeyDelimiter + "format"
+	LogJournal    = LogKey + agent_config.KeyDelimiter + "journal"
+	LogMaxSize    = LogKey + agent_config.KeyDelimiter + "max_size"
+	LogMaxBackups = LogKey + agent_config.KeyDelimiter + "max_backups"
+	LogMaxAge     = LogKey + agent_config.KeyDelimiter + "max_age"
 
 	// viper keys used in config
 	ServerKey = "server"
@@ -127,22 +267,34 @@ const (
 	AgentAPICert = APIKey + agent_config.KeyDelimiter + "cert"
 	AgentAPIKey  = APIKey + agent_config.KeyDelimiter + "key"
 
+	AgentAPIToken = APIKey + agent_config.KeyDelimiter + "auth_token"
+
+	AgentAPIMetricsNaming = APIKey + agent_config.KeyDelimiter + "metrics_naming"
+
+	FIPSModeKey = "fips_mode"
+
 	// viper keys used in config
 	TlsKey = "tls"
 
-	TlsEnable     = TlsKey + agent_config.KeyDelimiter + "enable"
-	TlsCert       = TlsKey + agent_config.KeyDelimiter + "cert"
-	TlsPrivateKey = TlsKey + agent_config.KeyDelimiter + "key"
-	TlsCa         = TlsKey + agent_config.KeyDelimiter + "ca"
-	TlsSkipVerify = TlsKey + agent_config.KeyDelimiter + "skip_verify"
+	TlsEnable       = TlsKey + agent_config.KeyDelimiter + "enable"
+	TlsCert         = TlsKey + agent_config.KeyDelimiter + "cert"
+	TlsPrivateKey   = TlsKey + agent_config.KeyDelimiter + "key"
+	TlsCa           = TlsKey + agent_config.KeyDelimiter + "ca"
+	TlsSkipVerify   = TlsKey + agent_config.KeyDelimiter + "skip_verify"
+	TlsSpiffeSocket = TlsKey + agent_config.KeyDelimiter + "spiffe_workload_api_socket"
 
 	// viper keys used in config
 	NginxKey = "nginx"
 
-	NginxExcludeLogs    = NginxKey + agent_config.KeyDelimiter + "exclude_logs"
-	NginxDebug          = NginxKey + agent_config.KeyDelimiter + "debug"
-	NginxCountingSocket = NginxKey + agent_config.KeyDelimiter + "socket"
-	NginxClientVersion  = NginxKey + agent_config.KeyDelimiter + "client_version"
+	NginxExcludeLogs       = NginxKey + agent_config.KeyDelimiter + "exclude_logs"
+	NginxDebug             = NginxKey + agent_config.KeyDelimiter + "debug"
+	NginxLogAccessEvents   = NginxKey + agent_config.KeyDelimiter + "log_access_events"
+	NginxBinarySearchPaths = NginxKey + agent_config.KeyDelimiter + "binary_search_paths"
+	NginxCountingSocket    = NginxKey + agent_config.KeyDelimiter + "socket"
+	NginxClientVersion     = NginxKey + agent_config.KeyDelimiter + "client_version"
+
+	NginxConfigCollectWorkers = NginxKey + agent_config.KeyDelimiter + "config_collect_workers"
+	NginxConfigApplyFsyncDirs = NginxKey + agent_config.KeyDelimiter + "config_apply_fsync_dirs"
 
 	// viper keys used in config
 	DataplaneKey = "dataplane"
@@ -159,6 +311,9 @@ const (
 	MetricsReportInterval     = MetricsKey + agent_config.KeyDelimiter + "report_interval"
 	MetricsCollectionInterval = MetricsKey + agent_config.KeyDelimiter + "collection_interval"
 	MetricsMode               = MetricsKey + agent_config.KeyDelimiter + "mode"
+	MetricsNaming             = MetricsKey + agent_config.KeyDelimiter + "naming"
+	MetricsCorrectClockSkew   = MetricsKey + agent_config.KeyDelimiter + "correct_clock_skew"
+	MetricsMaxMessageSize     = MetricsKey + agent_config.KeyDelimiter + "max_message_size"
 
 	// viper keys used in config
 	AdvancedMetricsKey = "advanced_metrics"
@@ -172,6 +327,188 @@ const (
 	AdvancedMetricsTableSizesLimitsPTMS = AdvancedMetricsTableSizesLimits + agent_config.KeyDelimiter + "priority_table_max_size"
 	AdvancedMetricsTableSizesLimitsPTT  = AdvancedMetricsTableSizesLimits + agent_config.KeyDelimiter + "priority_table_threshold"
 
+	// viper keys used in config
+	CertExpiryKey = "cert_expiry"
+
+	CertExpiryWarningDays  = CertExpiryKey + agent_config.KeyDelimiter + "warning_days"
+	CertExpiryCriticalDays = CertExpiryKey + agent_config.KeyDelimiter + "critical_days"
+	CertExpiryInterval     = CertExpiryKey + agent_config.KeyDelimiter + "interval"
+
+	// viper keys used in config
+	ResolverHealthKey = "resolver_health"
+
+	ResolverHealthInterval = ResolverHealthKey + agent_config.KeyDelimiter + "interval"
+
+	// viper keys used in config
+	SyntheticChecksKey = "synthetic_checks"
+
+	SyntheticChecksInterval = SyntheticChecksKey + agent_config.KeyDelimiter + "interval"
+	SyntheticChecksChecks   = SyntheticChecksKey + agent_config.KeyDelimiter + "checks"
+
+	// viper keys used in config
+	AcmeKey = "acme"
+
+	AcmeEnabled      = AcmeKey + agent_config.KeyDelimiter + "enabled"
+	AcmeDirectoryURL = AcmeKey + agent_config.KeyDelimiter + "directory_url"
+	AcmeEmail        = AcmeKey + agent_config.KeyDelimiter + "email"
+	AcmeChallenge    = AcmeKey + agent_config.KeyDelimiter + "challenge"
+	AcmeDNSProvider  = AcmeKey + agent_config.KeyDelimiter + "dns_provider"
+	AcmeWebrootPath  = AcmeKey + agent_config.KeyDelimiter + "webroot_path"
+	AcmeCertDir      = AcmeKey + agent_config.KeyDelimiter + "cert_dir"
+	AcmeRenewBefore  = AcmeKey + agent_config.KeyDelimiter + "renew_before"
+	AcmeInterval     = AcmeKey + agent_config.KeyDelimiter + "interval"
+
+	// viper keys used in config
+	ErrorLogEventsKey = "error_log_events"
+
+	ErrorLogEventsEnable            = ErrorLogEventsKey + agent_config.KeyDelimiter + "enable"
+	ErrorLogEventsMinLevel          = ErrorLogEventsKey + agent_config.KeyDelimiter + "min_level"
+	ErrorLogEventsPatterns          = ErrorLogEventsKey + agent_config.KeyDelimiter + "patterns"
+	ErrorLogEventsExcerptLength     = ErrorLogEventsKey + agent_config.KeyDelimiter + "excerpt_length"
+	ErrorLogEventsMultilinePatterns = ErrorLogEventsKey + agent_config.KeyDelimiter + "multiline_patterns"
+	ErrorLogEventsMultilineTimeout  = ErrorLogEventsKey + agent_config.KeyDelimiter + "multiline_timeout"
+
+	// viper keys used in config
+	LogForwardingKey = "log_forwarding"
+
+	LogForwardingEnable          = LogForwardingKey + agent_config.KeyDelimiter + "enable"
+	LogForwardingSink            = LogForwardingKey + agent_config.KeyDelimiter + "sink"
+	LogForwardingAddress         = LogForwardingKey + agent_config.KeyDelimiter + "address"
+	LogForwardingKafkaTopic      = LogForwardingKey + agent_config.KeyDelimiter + "kafka_topic"
+	LogForwardingBatchSize       = LogForwardingKey + agent_config.KeyDelimiter + "batch_size"
+	LogForwardingBatchInterval   = LogForwardingKey + agent_config.KeyDelimiter + "batch_interval"
+	LogForwardingQueueSize       = LogForwardingKey + agent_config.KeyDelimiter + "queue_size"
+	LogForwardingCheckpointDir   = LogForwardingKey + agent_config.KeyDelimiter + "checkpoint_dir"
+	LogForwardingRotationCatchUp = LogForwardingKey + agent_config.KeyDelimiter + "rotation_catch_up"
+
+	// viper keys used in config
+	DockerKey = "docker"
+
+	DockerEnable       = DockerKey + agent_config.KeyDelimiter + "enable"
+	DockerSocket       = DockerKey + agent_config.KeyDelimiter + "socket"
+	DockerPollInterval = DockerKey + agent_config.KeyDelimiter + "poll_interval"
+
+	// viper keys used in config
+	ConsulKey = "consul"
+
+	ConsulEnable          = ConsulKey + agent_config.KeyDelimiter + "enable"
+	ConsulAddress         = ConsulKey + agent_config.KeyDelimiter + "address"
+	ConsulToken           = ConsulKey + agent_config.KeyDelimiter + "token"
+	ConsulCheckTTL        = ConsulKey + agent_config.KeyDelimiter + "check_ttl"
+	ConsulDeregisterAfter = ConsulKey + agent_config.KeyDelimiter + "deregister_after"
+
+	// viper keys used in config
+	VaultKey = "vault"
+
+	VaultEnable       = VaultKey + agent_config.KeyDelimiter + "enable"
+	VaultAddress      = VaultKey + agent_config.KeyDelimiter + "address"
+	VaultNamespace    = VaultKey + agent_config.KeyDelimiter + "namespace"
+	VaultAuth         = VaultKey + agent_config.KeyDelimiter + "auth"
+	VaultRoleID       = VaultKey + agent_config.KeyDelimiter + "role_id"
+	VaultSecretIDFile = VaultKey + agent_config.KeyDelimiter + "secret_id_file"
+	VaultRole         = VaultKey + agent_config.KeyDelimiter + "role"
+	VaultToken        = VaultKey + agent_config.KeyDelimiter + "token"
+
+	// viper keys used in config
+	TracingKey = "tracing"
+
+	TracingEnable      = TracingKey + agent_config.KeyDelimiter + "enable"
+	TracingEndpoint    = TracingKey + agent_config.KeyDelimiter + "endpoint"
+	TracingServiceName = TracingKey + agent_config.KeyDelimiter + "service_name"
+
+	// viper keys used in config
+	WebhookKey = "webhook"
+
+	WebhookEnable       = WebhookKey + agent_config.KeyDelimiter + "enable"
+	WebhookURL          = WebhookKey + agent_config.KeyDelimiter + "url"
+	WebhookType         = WebhookKey + agent_config.KeyDelimiter + "type"
+	WebhookSecretEnv    = WebhookKey + agent_config.KeyDelimiter + "secret_env"
+	WebhookLevels       = WebhookKey + agent_config.KeyDelimiter + "levels"
+	WebhookTimeout      = WebhookKey + agent_config.KeyDelimiter + "timeout"
+	WebhookRetries      = WebhookKey + agent_config.KeyDelimiter + "retries"
+	WebhookRetryBackoff = WebhookKey + agent_config.KeyDelimiter + "retry_backoff"
+
+	// viper keys used in config
+	SNMPKey = "snmp"
+
+	SNMPEnable        = SNMPKey + agent_config.KeyDelimiter + "enable"
+	SNMPAgentXAddress = SNMPKey + agent_config.KeyDelimiter + "agentx_address"
+	SNMPBaseOID       = SNMPKey + agent_config.KeyDelimiter + "base_oid"
+
+	// viper keys used in config
+	ObjectStoreKey = "object_store"
+
+	ObjectStoreEnable          = ObjectStoreKey + agent_config.KeyDelimiter + "enable"
+	ObjectStoreEndpoint        = ObjectStoreKey + agent_config.KeyDelimiter + "endpoint"
+	ObjectStoreRegion          = ObjectStoreKey + agent_config.KeyDelimiter + "region"
+	ObjectStoreBucket          = ObjectStoreKey + agent_config.KeyDelimiter + "bucket"
+	ObjectStorePrefix          = ObjectStoreKey + agent_config.KeyDelimiter + "prefix"
+	ObjectStoreAccessKeyID     = ObjectStoreKey + agent_config.KeyDelimiter + "access_key_id"
+	ObjectStoreSecretAccessKey = ObjectStoreKey + agent_config.KeyDelimiter + "secret_access_key"
+	ObjectStoreSSE             = ObjectStoreKey + agent_config.KeyDelimiter + "sse"
+	ObjectStoreSSEKMSKeyID     = ObjectStoreKey + agent_config.KeyDelimiter + "sse_kms_key_id"
+	ObjectStorePollInterval    = ObjectStoreKey + agent_config.KeyDelimiter + "poll_interval"
+	ObjectStoreBackup          = ObjectStoreKey + agent_config.KeyDelimiter + "backup"
+
+	// viper keys used in config
+	UnitKey = "unit"
+
+	UnitEnable         = UnitKey + agent_config.KeyDelimiter + "enable"
+	UnitControlSocket  = UnitKey + agent_config.KeyDelimiter + "control_socket"
+	UnitReportInterval = UnitKey + agent_config.KeyDelimiter + "report_interval"
+
+	// viper keys used in config
+	AgentUpdateKey = "agent_update"
+
+	AgentUpdateEnable    = AgentUpdateKey + agent_config.KeyDelimiter + "enable"
+	AgentUpdatePublicKey = AgentUpdateKey + agent_config.KeyDelimiter + "public_key"
+
+	// viper keys used in config
+	DiskBudgetKey = "disk_budget"
+
+	DiskBudgetEnable            = DiskBudgetKey + agent_config.KeyDelimiter + "enable"
+	DiskBudgetBudgetMB          = DiskBudgetKey + agent_config.KeyDelimiter + "budget_mb"
+	DiskBudgetWarningPercent    = DiskBudgetKey + agent_config.KeyDelimiter + "warning_percent"
+	DiskBudgetInterval          = DiskBudgetKey + agent_config.KeyDelimiter + "interval"
+	DiskBudgetLogQuotaMB        = DiskBudgetKey + agent_config.KeyDelimiter + "log_quota_mb"
+	DiskBudgetCheckpointQuotaMB = DiskBudgetKey + agent_config.KeyDelimiter + "checkpoint_quota_mb"
+	DiskBudgetBundleQuotaMB     = DiskBudgetKey + agent_config.KeyDelimiter + "bundle_quota_mb"
+
+	// viper keys used in config
+	InstancePoliciesKey = "instance_policies"
+
+	// viper keys used in config
+	ExportKey = "export"
+
+	ExportEnable     = ExportKey + agent_config.KeyDelimiter + "enable"
+	ExportFile       = ExportKey + agent_config.KeyDelimiter + "file"
+	ExportMaxSize    = ExportKey + agent_config.KeyDelimiter + "max_size"
+	ExportMaxBackups = ExportKey + agent_config.KeyDelimiter + "max_backups"
+
+	// viper keys used in config
+	FailoverKey = "failover"
+
+	FailoverEnable           = FailoverKey + agent_config.KeyDelimiter + "enable"
+	FailoverServers          = FailoverKey + agent_config.KeyDelimiter + "servers"
+	FailoverProbeInterval    = FailoverKey + agent_config.KeyDelimiter + "probe_interval"
+	FailoverFailureThreshold = FailoverKey + agent_config.KeyDelimiter + "failure_threshold"
+
+	// viper keys used in config
+	SupervisorKey = "supervisor"
+
+	SupervisorEnable       = SupervisorKey + agent_config.KeyDelimiter + "enable"
+	SupervisorBinPath      = SupervisorKey + agent_config.KeyDelimiter + "bin_path"
+	SupervisorBackoffMin   = SupervisorKey + agent_config.KeyDelimiter + "backoff_min"
+	SupervisorBackoffMax   = SupervisorKey + agent_config.KeyDelimiter + "backoff_max"
+	SupervisorStableUptime = SupervisorKey + agent_config.KeyDelimiter + "stable_uptime"
+
+	// viper keys used in config
+	BandwidthBudgetKey = "bandwidth_budget"
+
+	BandwidthBudgetEnable           = BandwidthBudgetKey + agent_config.KeyDelimiter + "enable"
+	BandwidthBudgetBytesPerInterval = BandwidthBudgetKey + agent_config.KeyDelimiter + "bytes_per_interval"
+	BandwidthBudgetInterval         = BandwidthBudgetKey + agent_config.KeyDelimiter + "interval"
+
 	// viper keys used in config
 	NginxAppProtectKey = "nginx_app_protect"
 
@@ -213,6 +550,31 @@ var (
 			Usage:        "The path to output log messages to. If the default path doesn't exist, log messages are output to stdout/stderr.",
 			DefaultValue: Defaults.Log.Path,
 		},
+		&StringFlag{
+			Name:         LogFormat,
+			Usage:        "The format to output log messages in. Available options are: plain and json.",
+			DefaultValue: Defaults.Log.Format,
+		},
+		&BoolFlag{
+			Name:         LogJournal,
+			Usage:        "Enables logging to the systemd journal with structured fields, in addition to the configured outputs.",
+			DefaultValue: Defaults.Log.Journal,
+		},
+		&IntFlag{
+			Name:         LogMaxSize,
+			Usage:        "The maximum size, in megabytes, a log file can reach before it is rotated. A value of 0 disables log rotation.",
+			DefaultValue: Defaults.Log.MaxSize,
+		},
+		&IntFlag{
+			Name:         LogMaxBackups,
+			Usage:        "The maximum number of rotated log files to keep. Older rotated log files are deleted.",
+			DefaultValue: Defaults.Log.MaxBackups,
+		},
+		&IntFlag{
+			Name:         LogMaxAge,
+			Usage:        "The maximum number of days to keep rotated log files. Older rotated log files are deleted.",
+			DefaultValue: Defaults.Log.MaxAge,
+		},
 		&StringFlag{
 			Name:  ServerHost,
 			Usage: "The IP address of the server host. IPv4 addresses and hostnames are supported.",
@@ -251,6 +613,16 @@ var (
 			Usage:        "The key used by the Agent API.",
 			DefaultValue: "",
 		},
+		&StringFlag{
+			Name:         AgentAPIToken,
+			Usage:        "The bearer token required by the read-only /api/v1 endpoints of the Agent API.",
+			DefaultValue: "",
+		},
+		&StringFlag{
+			Name:         AgentAPIMetricsNaming,
+			Usage:        "Sets the metric naming scheme used by the Agent API metrics endpoint: agent or amplify.",
+			DefaultValue: "",
+		},
 		&StringFlag{
 			Name:         ConfigDirsKey,
 			Usage:        "Defines the paths that you want to grant nginx-agent read/write access to. This key is formatted as a string and follows Unix PATH format.",
@@ -275,6 +647,24 @@ var (
 			Usage:        "The NGINX Plus counting unix socket location.",
 			DefaultValue: Defaults.Nginx.NginxCountingSocket,
 		},
+		&BoolFlag{
+			Name:  NginxLogAccessEvents,
+			Usage: "Enables events that report NGINX log files the agent cannot read, including the remediation needed to grant access.",
+		},
+		&StringSliceFlag{
+			Name:  NginxBinarySearchPaths,
+			Usage: "A comma-separated list of additional directories searched for NGINX binaries, for instances built from source under custom prefixes.",
+		},
+		&IntFlag{
+			Name:         NginxConfigCollectWorkers,
+			Usage:        "Sets how many auxiliary files are read concurrently when an NGINX config payload is collected.",
+			DefaultValue: Defaults.Nginx.ConfigCollectWorkers,
+		},
+		&BoolFlag{
+			Name:         NginxConfigApplyFsyncDirs,
+			Usage:        "Enables syncing each directory an NGINX config apply wrote into once all of its files are written.",
+			DefaultValue: Defaults.Nginx.ConfigApplyFsyncDirs,
+		},
 		// Metrics
 		&DurationFlag{
 			Name:         MetricsCollectionInterval,
@@ -286,6 +676,21 @@ var (
 			Usage:        "Sets the desired metrics collection mode: streaming or aggregation.",
 			DefaultValue: Defaults.AgentMetrics.Mode,
 		},
+		&StringFlag{
+			Name:         MetricsNaming,
+			Usage:        "Sets the metric naming scheme used when reporting to the management server: agent or amplify.",
+			DefaultValue: Defaults.AgentMetrics.Naming,
+		},
+		&BoolFlag{
+			Name:         MetricsCorrectClockSkew,
+			Usage:        "Shifts metric timestamps onto the control plane clock when the host clock is detected to be skewed.",
+			DefaultValue: Defaults.AgentMetrics.CorrectClockSkew,
+		},
+		&IntFlag{
+			Name:         MetricsMaxMessageSize,
+			Usage:        "The largest metrics report message, in bytes, sent in one gRPC message. Reports over the limit are split across messages.",
+			DefaultValue: Defaults.AgentMetrics.MaxMessageSize,
+		},
 		&IntFlag{
 			Name:         MetricsBulkSize,
 			Usage:        "The amount of metrics reports collected before sending the data back to the server.",
@@ -350,6 +755,15 @@ var (
 			Usage:        "Only intended for demonstration, sets InsecureSkipVerify for gRPC TLS credentials",
 			DefaultValue: Defaults.TLS.SkipVerify,
 		},
+		&StringFlag{
+			Name:  TlsSpiffeSocket,
+			Usage: "The path to the SPIFFE Workload API unix socket used to source the agent's client certificate with automatic rotation, instead of static cert and key files.",
+		},
+		&BoolFlag{
+			Name:         FIPSModeKey,
+			Usage:        "Restricts the agent to FIPS approved TLS versions, cipher suites and hash algorithms. The agent will refuse to start if an incompatible option is configured.",
+			DefaultValue: Defaults.FIPSMode,
+		},
 		// Dataplane
 		&DurationFlag{
 			Name:         DataplaneStatusPoll,
@@ -361,6 +775,494 @@ var (
 			Usage:        "The amount of time the agent will report on the dataplane. After this period of time it will send a snapshot of the dataplane information.",
 			DefaultValue: Defaults.Dataplane.Status.ReportInterval,
 		},
+		// Cert Expiry
+		&IntFlag{
+			Name:         CertExpiryWarningDays,
+			Usage:        "The number of days before a certificate expires at which the agent will emit a warning event.",
+			DefaultValue: Defaults.CertExpiry.WarningDays,
+		},
+		&IntFlag{
+			Name:         CertExpiryCriticalDays,
+			Usage:        "The number of days before a certificate expires at which the agent will emit a critical event.",
+			DefaultValue: Defaults.CertExpiry.CriticalDays,
+		},
+		&DurationFlag{
+			Name:         CertExpiryInterval,
+			Usage:        "The period of time the agent will check certificates for upcoming expiry.",
+			DefaultValue: Defaults.CertExpiry.Interval,
+		},
+		// Resolver Health
+		&DurationFlag{
+			Name:         ResolverHealthInterval,
+			Usage:        "The period of time the agent will check that the upstream hostnames in the NGINX configuration still resolve.",
+			DefaultValue: Defaults.ResolverHealth.Interval,
+		},
+		// Synthetic Checks
+		&DurationFlag{
+			Name:         SyntheticChecksInterval,
+			Usage:        "The period of time the agent will run the configured synthetic HTTP checks.",
+			DefaultValue: Defaults.SyntheticChecks.Interval,
+		},
+		// ACME
+		&BoolFlag{
+			Name:         AcmeEnabled,
+			Usage:        "Enables the ACME subsystem for certificate issuance and renewal.",
+			DefaultValue: Defaults.Acme.Enabled,
+		},
+		&StringFlag{
+			Name:         AcmeDirectoryURL,
+			Usage:        "The URL of the ACME directory used for certificate issuance and renewal.",
+			DefaultValue: Defaults.Acme.DirectoryURL,
+		},
+		&StringFlag{
+			Name:  AcmeEmail,
+			Usage: "The contact email address registered with the ACME account.",
+		},
+		&StringFlag{
+			Name:         AcmeChallenge,
+			Usage:        "The ACME challenge type used to prove control of a domain (http-01 or dns-01).",
+			DefaultValue: Defaults.Acme.Challenge,
+		},
+		&StringFlag{
+			Name:  AcmeDNSProvider,
+			Usage: "The name of the registered DNS provider used to solve dns-01 challenges.",
+		},
+		&StringFlag{
+			Name:         AcmeWebrootPath,
+			Usage:        "The directory served by NGINX for /.well-known/acme-challenge/ requests when solving http-01 challenges.",
+			DefaultValue: Defaults.Acme.WebrootPath,
+		},
+		&StringFlag{
+			Name:         AcmeCertDir,
+			Usage:        "The directory the agent installs issued certificates and keys into.",
+			DefaultValue: Defaults.Acme.CertDir,
+		},
+		&DurationFlag{
+			Name:         AcmeRenewBefore,
+			Usage:        "The amount of time before expiry at which a certificate will be renewed.",
+			DefaultValue: Defaults.Acme.RenewBefore,
+		},
+		&DurationFlag{
+			Name:         AcmeInterval,
+			Usage:        "The period of time the agent will check certificates for upcoming renewal.",
+			DefaultValue: Defaults.Acme.Interval,
+		},
+		// Error Log Events
+		&BoolFlag{
+			Name:         ErrorLogEventsEnable,
+			Usage:        "Enables escalation of matching nginx error log lines into events.",
+			DefaultValue: Defaults.ErrorLogEvents.Enable,
+		},
+		&StringFlag{
+			Name:         ErrorLogEventsMinLevel,
+			Usage:        "The minimum nginx error log severity reported as an event. Available options are: emerg, alert, crit, error and warn.",
+			DefaultValue: Defaults.ErrorLogEvents.MinLevel,
+		},
+		&StringSliceFlag{
+			Name:  ErrorLogEventsPatterns,
+			Usage: "A comma-separated list of regular expressions reported as events regardless of the log severity.",
+		},
+		&IntFlag{
+			Name:         ErrorLogEventsExcerptLength,
+			Usage:        "The maximum number of characters of a matching error log line included in an event.",
+			DefaultValue: Defaults.ErrorLogEvents.ExcerptLength,
+		},
+		&StringSliceFlag{
+			Name:  ErrorLogEventsMultilinePatterns,
+			Usage: "A comma-separated list of regular expressions marking a line as the continuation of a multi-line diagnostic, in addition to lines without an nginx timestamp.",
+		},
+		&DurationFlag{
+			Name:         ErrorLogEventsMultilineTimeout,
+			Usage:        "How long to wait for further continuation lines before a grouped multi-line diagnostic is reported.",
+			DefaultValue: Defaults.ErrorLogEvents.MultilineTimeout,
+		},
+		// Log Forwarding
+		&BoolFlag{
+			Name:         LogForwardingEnable,
+			Usage:        "Enables forwarding of the discovered access and error logs to an external sink.",
+			DefaultValue: Defaults.LogForwarding.Enable,
+		},
+		&StringFlag{
+			Name:         LogForwardingSink,
+			Usage:        "The sink log lines are forwarded to. Available options are: syslog, loki and kafka.",
+			DefaultValue: Defaults.LogForwarding.Sink,
+		},
+		&StringFlag{
+			Name:  LogForwardingAddress,
+			Usage: "The address of the sink: a host:port for syslog, a base URL for loki or a comma-separated broker list for kafka.",
+		},
+		&StringFlag{
+			Name:  LogForwardingKafkaTopic,
+			Usage: "The kafka topic log lines are published to when the kafka sink is used.",
+		},
+		&IntFlag{
+			Name:         LogForwardingBatchSize,
+			Usage:        "The maximum number of log lines shipped to the sink in one batch.",
+			DefaultValue: Defaults.LogForwarding.BatchSize,
+		},
+		&DurationFlag{
+			Name:         LogForwardingBatchInterval,
+			Usage:        "The maximum amount of time a batch of log lines is held before it is shipped.",
+			DefaultValue: Defaults.LogForwarding.BatchInterval,
+		},
+		&IntFlag{
+			Name:         LogForwardingQueueSize,
+			Usage:        "The number of log lines buffered while the sink is slow or unavailable. Further lines are dropped until the sink catches up.",
+			DefaultValue: Defaults.LogForwarding.QueueSize,
+		},
+		&StringFlag{
+			Name:         LogForwardingCheckpointDir,
+			Usage:        "The directory the log forwarder persists its file positions into.",
+			DefaultValue: Defaults.LogForwarding.CheckpointDir,
+		},
+		&BoolFlag{
+			Name:         LogForwardingRotationCatchUp,
+			Usage:        "Enables forwarding the unread remainder of a rotated log file, including compressed archives, before tailing its replacement.",
+			DefaultValue: Defaults.LogForwarding.RotationCatchUp,
+		},
+		// Docker
+		&BoolFlag{
+			Name:         DockerEnable,
+			Usage:        "Enables discovery of NGINX instances running in Docker containers on the dataplane host.",
+			DefaultValue: Defaults.Docker.Enable,
+		},
+		&StringFlag{
+			Name:  DockerSocket,
+			Usage: "The Docker daemon socket to connect to. When empty the standard Docker environment variables and socket location are used.",
+		},
+		&DurationFlag{
+			Name:         DockerPollInterval,
+			Usage:        "The interval at which the running containers are listed to discover NGINX instances.",
+			DefaultValue: Defaults.Docker.PollInterval,
+		},
+		// Consul
+		&BoolFlag{
+			Name:         ConsulEnable,
+			Usage:        "Enables registration of the agent and its NGINX instances in a Consul catalog.",
+			DefaultValue: Defaults.Consul.Enable,
+		},
+		&StringFlag{
+			Name:         ConsulAddress,
+			Usage:        "The address of the local Consul agent HTTP API.",
+			DefaultValue: Defaults.Consul.Address,
+		},
+		&StringFlag{
+			Name:  ConsulToken,
+			Usage: "The ACL token presented to the Consul agent.",
+		},
+		&DurationFlag{
+			Name:         ConsulCheckTTL,
+			Usage:        "The TTL of the Consul health checks. The agent refreshes them at half this interval.",
+			DefaultValue: Defaults.Consul.CheckTTL,
+		},
+		&DurationFlag{
+			Name:         ConsulDeregisterAfter,
+			Usage:        "How long a service stays in the Consul catalog after its health check turns critical before it is deregistered.",
+			DefaultValue: Defaults.Consul.DeregisterAfter,
+		},
+		// Vault
+		&BoolFlag{
+			Name:         VaultEnable,
+			Usage:        "Enables resolving config values of the form vault:<path>#<field> from a Vault server.",
+			DefaultValue: Defaults.Vault.Enable,
+		},
+		&StringFlag{
+			Name:         VaultAddress,
+			Usage:        "The address of the Vault server HTTP API.",
+			DefaultValue: Defaults.Vault.Address,
+		},
+		&StringFlag{
+			Name:  VaultNamespace,
+			Usage: "The Vault namespace the agent operates in.",
+		},
+		&StringFlag{
+			Name:         VaultAuth,
+			Usage:        "The Vault auth method to use. Available options are: approle, kubernetes and token.",
+			DefaultValue: Defaults.Vault.Auth,
+		},
+		&StringFlag{
+			Name:  VaultRoleID,
+			Usage: "The AppRole role ID used to log in to Vault.",
+		},
+		&StringFlag{
+			Name:  VaultSecretIDFile,
+			Usage: "The file holding the AppRole secret ID used to log in to Vault.",
+		},
+		&StringFlag{
+			Name:  VaultRole,
+			Usage: "The Vault role used with the kubernetes auth method.",
+		},
+		&StringFlag{
+			Name:  VaultToken,
+			Usage: "A pre-issued Vault token, used with the token auth method.",
+		},
+		// Tracing
+		&BoolFlag{
+			Name:         TracingEnable,
+			Usage:        "Enables exporting OpenTelemetry spans of config operations.",
+			DefaultValue: Defaults.Tracing.Enable,
+		},
+		&StringFlag{
+			Name:         TracingEndpoint,
+			Usage:        "The OTLP/HTTP endpoint the spans are exported to.",
+			DefaultValue: Defaults.Tracing.Endpoint,
+		},
+		&StringFlag{
+			Name:         TracingServiceName,
+			Usage:        "The service name the exported spans are attributed to.",
+			DefaultValue: Defaults.Tracing.ServiceName,
+		},
+		// Webhook
+		&BoolFlag{
+			Name:         WebhookEnable,
+			Usage:        "Enables pushing critical events to a webhook.",
+			DefaultValue: Defaults.Webhook.Enable,
+		},
+		&StringFlag{
+			Name:  WebhookURL,
+			Usage: "The URL the events are posted to.",
+		},
+		&StringFlag{
+			Name:         WebhookType,
+			Usage:        "The payload format posted to the webhook. Available options are: generic, slack and pagerduty.",
+			DefaultValue: Defaults.Webhook.Type,
+		},
+		&StringFlag{
+			Name:  WebhookSecretEnv,
+			Usage: "The name of the environment variable holding the webhook secret, used as a bearer token or PagerDuty routing key.",
+		},
+		&StringSliceFlag{
+			Name:         WebhookLevels,
+			Usage:        "The event levels that are pushed to the webhook.",
+			DefaultValue: Defaults.Webhook.Levels,
+		},
+		&DurationFlag{
+			Name:         WebhookTimeout,
+			Usage:        "The timeout of a single webhook delivery attempt.",
+			DefaultValue: Defaults.Webhook.Timeout,
+		},
+		&IntFlag{
+			Name:         WebhookRetries,
+			Usage:        "How many times a failed webhook delivery is retried before the event is dropped.",
+			DefaultValue: Defaults.Webhook.Retries,
+		},
+		&DurationFlag{
+			Name:         WebhookRetryBackoff,
+			Usage:        "How long to wait between webhook delivery attempts.",
+			DefaultValue: Defaults.Webhook.RetryBackoff,
+		},
+		// SNMP
+		&BoolFlag{
+			Name:         SNMPEnable,
+			Usage:        "Enables the AgentX sub-agent exposing key NGINX metrics to a master SNMP agent.",
+			DefaultValue: Defaults.SNMP.Enable,
+		},
+		&StringFlag{
+			Name:         SNMPAgentXAddress,
+			Usage:        "The AgentX socket of the master SNMP agent, in the format unix:<path> or tcp:<host:port>.",
+			DefaultValue: Defaults.SNMP.AgentXAddress,
+		},
+		&StringFlag{
+			Name:         SNMPBaseOID,
+			Usage:        "The OID subtree the NGINX metrics are registered under.",
+			DefaultValue: Defaults.SNMP.BaseOID,
+		},
+		// Object store
+		&BoolFlag{
+			Name:         ObjectStoreEnable,
+			Usage:        "Enables pulling NGINX config payloads from, and backing up applied versions to, an S3-compatible bucket.",
+			DefaultValue: Defaults.ObjectStore.Enable,
+		},
+		&StringFlag{
+			Name:  ObjectStoreEndpoint,
+			Usage: "The endpoint of the S3-compatible object store. Leave empty to target AWS S3 in the configured region.",
+		},
+		&StringFlag{
+			Name:         ObjectStoreRegion,
+			Usage:        "The region the bucket lives in, used for request signing.",
+			DefaultValue: Defaults.ObjectStore.Region,
+		},
+		&StringFlag{
+			Name:  ObjectStoreBucket,
+			Usage: "The bucket holding the NGINX config payloads.",
+		},
+		&StringFlag{
+			Name:  ObjectStorePrefix,
+			Usage: "The key prefix under which the config payload and backups are kept.",
+		},
+		&StringFlag{
+			Name:  ObjectStoreAccessKeyID,
+			Usage: "The access key ID used to sign object store requests.",
+		},
+		&StringFlag{
+			Name:  ObjectStoreSecretAccessKey,
+			Usage: "The secret access key used to sign object store requests.",
+		},
+		&StringFlag{
+			Name:         ObjectStoreSSE,
+			Usage:        "The server-side encryption mode requested for uploads. Available options are: AES256 and aws:kms.",
+			DefaultValue: Defaults.ObjectStore.SSE,
+		},
+		&StringFlag{
+			Name:  ObjectStoreSSEKMSKeyID,
+			Usage: "The KMS key ID used with the aws:kms server-side encryption mode.",
+		},
+		&DurationFlag{
+			Name:         ObjectStorePollInterval,
+			Usage:        "The interval at which the bucket is polled for an updated config payload.",
+			DefaultValue: Defaults.ObjectStore.PollInterval,
+		},
+		&BoolFlag{
+			Name:         ObjectStoreBackup,
+			Usage:        "Enables backing up the applied NGINX config versions to the bucket.",
+			DefaultValue: Defaults.ObjectStore.Backup,
+		},
+		// NGINX Unit
+		&BoolFlag{
+			Name:         UnitEnable,
+			Usage:        "Enables discovery of an NGINX Unit instance through its control socket.",
+			DefaultValue: Defaults.Unit.Enable,
+		},
+		&StringFlag{
+			Name:         UnitControlSocket,
+			Usage:        "The path of the NGINX Unit control socket.",
+			DefaultValue: Defaults.Unit.ControlSocket,
+		},
+		&DurationFlag{
+			Name:         UnitReportInterval,
+			Usage:        "The interval at which the NGINX Unit instance is checked for changes and metrics are collected.",
+			DefaultValue: Defaults.Unit.ReportInterval,
+		},
+		// Agent update
+		&BoolFlag{
+			Name:         AgentUpdateEnable,
+			Usage:        "Enables replacing the running agent with signed packages pushed by the management server.",
+			DefaultValue: Defaults.AgentUpdate.Enable,
+		},
+		&StringFlag{
+			Name:         AgentUpdatePublicKey,
+			Usage:        "The path of the PEM encoded public key that pushed agent packages must be signed with.",
+			DefaultValue: Defaults.AgentUpdate.PublicKey,
+		},
+		// Disk budget
+		&BoolFlag{
+			Name:         DiskBudgetEnable,
+			Usage:        "Enables the disk budget for agent-generated data, evicting the oldest rotated logs, checkpoints and debug bundles when a store exceeds its quota.",
+			DefaultValue: Defaults.DiskBudget.Enable,
+		},
+		&IntFlag{
+			Name:         DiskBudgetBudgetMB,
+			Usage:        "The total number of megabytes agent-generated data may use on disk across all stores.",
+			DefaultValue: Defaults.DiskBudget.BudgetMB,
+		},
+		&IntFlag{
+			Name:         DiskBudgetWarningPercent,
+			Usage:        "The percentage of the disk budget at which a warning event is published.",
+			DefaultValue: Defaults.DiskBudget.WarningPercent,
+		},
+		&DurationFlag{
+			Name:         DiskBudgetInterval,
+			Usage:        "The interval at which disk usage of agent-generated data is measured and enforced.",
+			DefaultValue: Defaults.DiskBudget.Interval,
+		},
+		&IntFlag{
+			Name:         DiskBudgetLogQuotaMB,
+			Usage:        "The number of megabytes rotated agent log files may use before the oldest are evicted.",
+			DefaultValue: Defaults.DiskBudget.LogQuotaMB,
+		},
+		&IntFlag{
+			Name:         DiskBudgetCheckpointQuotaMB,
+			Usage:        "The number of megabytes log forwarding checkpoints may use before the oldest are evicted.",
+			DefaultValue: Defaults.DiskBudget.CheckpointQuotaMB,
+		},
+		&IntFlag{
+			Name:         DiskBudgetBundleQuotaMB,
+			Usage:        "The number of megabytes collected debug bundles may use before the oldest are evicted.",
+			DefaultValue: Defaults.DiskBudget.BundleQuotaMB,
+		},
+		// Export
+		&BoolFlag{
+			Name:         ExportEnable,
+			Usage:        "Enables the export mode, which writes outbound payloads to a local NDJSON file for air-gapped analysis.",
+			DefaultValue: Defaults.Export.Enable,
+		},
+		&StringFlag{
+			Name:         ExportFile,
+			Usage:        "The NDJSON file the outbound payloads are appended to.",
+			DefaultValue: Defaults.Export.File,
+		},
+		&IntFlag{
+			Name:         ExportMaxSize,
+			Usage:        "The maximum size, in megabytes, the export file can reach before it is rotated. A value of 0 disables rotation.",
+			DefaultValue: Defaults.Export.MaxSize,
+		},
+		&IntFlag{
+			Name:         ExportMaxBackups,
+			Usage:        "The maximum number of rotated export files to keep. Older rotated export files are deleted.",
+			DefaultValue: Defaults.Export.MaxBackups,
+		},
+		// Failover
+		&BoolFlag{
+			Name:         FailoverEnable,
+			Usage:        "Enables control plane failover. The agent probes the active control plane and moves to a backup server when it becomes unreachable.",
+			DefaultValue: Defaults.Failover.Enable,
+		},
+		&StringSliceFlag{
+			Name:  FailoverServers,
+			Usage: "A comma-separated list of backup control plane targets (host:port), in priority order after the primary server.",
+		},
+		&DurationFlag{
+			Name:         FailoverProbeInterval,
+			Usage:        "The interval at which the active control plane target is probed for reachability.",
+			DefaultValue: Defaults.Failover.ProbeInterval,
+		},
+		&IntFlag{
+			Name:         FailoverFailureThreshold,
+			Usage:        "The number of consecutive failed probes after which the agent fails over to a backup control plane.",
+			DefaultValue: Defaults.Failover.FailureThreshold,
+		},
+		// Supervisor
+		&BoolFlag{
+			Name:         SupervisorEnable,
+			Usage:        "Enables the process supervisor mode, where the agent starts NGINX and restarts it on crash.",
+			DefaultValue: Defaults.Supervisor.Enable,
+		},
+		&StringFlag{
+			Name:         SupervisorBinPath,
+			Usage:        "The path of the NGINX binary the supervisor starts.",
+			DefaultValue: Defaults.Supervisor.BinPath,
+		},
+		&DurationFlag{
+			Name:         SupervisorBackoffMin,
+			Usage:        "The delay before the first restart of a crashed NGINX, doubled on every further crash.",
+			DefaultValue: Defaults.Supervisor.BackoffMin,
+		},
+		&DurationFlag{
+			Name:         SupervisorBackoffMax,
+			Usage:        "The longest delay between restarts of a crashing NGINX.",
+			DefaultValue: Defaults.Supervisor.BackoffMax,
+		},
+		&DurationFlag{
+			Name:         SupervisorStableUptime,
+			Usage:        "The time NGINX has to stay up before the restart backoff resets.",
+			DefaultValue: Defaults.Supervisor.StableUptime,
+		},
+		// Bandwidth budget
+		&BoolFlag{
+			Name:         BandwidthBudgetEnable,
+			Usage:        "Enables the bandwidth budget for outbound reporting, deferring metric and event reports when the budget for an interval is spent.",
+			DefaultValue: Defaults.BandwidthBudget.Enable,
+		},
+		&IntFlag{
+			Name:         BandwidthBudgetBytesPerInterval,
+			Usage:        "The number of bytes the agent may send per interval across config uploads, metrics and log forwarding.",
+			DefaultValue: Defaults.BandwidthBudget.BytesPerInterval,
+		},
+		&DurationFlag{
+			Name:         BandwidthBudgetInterval,
+			Usage:        "The interval at which the bandwidth budget is replenished.",
+			DefaultValue: Defaults.BandwidthBudget.Interval,
+		},
 		// Nginx App Protect
 		&DurationFlag{
 			Name:  NginxAppProtectReportInterval,
@@ -397,6 +1299,11 @@ var (
 			Name:  InstanceGroupKey,
 			Usage: "The instance's 'group' value.",
 		},
+		&DurationFlag{
+			Name:         ShutdownGracePeriodKey,
+			Usage:        "The amount of time the NGINX Agent spends draining in-flight messages on shutdown before plugins are closed.",
+			DefaultValue: Defaults.ShutdownGracePeriod,
+		},
 	}
 	deprecatedFlags = []Registrable{
 		&StringFlag{
@@ -0,0 +1,31 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"fmt"
+)
+
+// ValidateFIPSMode verifies that the loaded configuration is compatible with FIPS mode.
+// The agent is expected to fail fast at startup if an incompatible option is configured,
+// rather than silently downgrading the primitives it uses.
+func (c *Config) ValidateFIPSMode() error {
+	if !c.FIPSMode {
+		return nil
+	}
+
+	if c.TLS.SkipVerify {
+		return fmt.Errorf("fips_mode is enabled but tls skip_verify is set, certificate verification cannot be disabled in FIPS mode")
+	}
+
+	if c.IsGrpcServerConfigured() && !c.TLS.Enable {
+		return fmt.Errorf("fips_mode is enabled but tls is disabled, communication with the control plane must use TLS in FIPS mode")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFIPSMode(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		config        *Config
+		expectedError bool
+	}{
+		{
+			testName:      "FIPSModeDisabled",
+			config:        &Config{},
+			expectedError: false,
+		},
+		{
+			testName: "FIPSModeWithTLSEnabled",
+			config: &Config{
+				FIPSMode: true,
+				Server:   Server{Host: "127.0.0.1", GrpcPort: 443},
+				TLS:      TLSConfig{Enable: true},
+			},
+			expectedError: false,
+		},
+		{
+			testName: "FIPSModeWithSkipVerify",
+			config: &Config{
+				FIPSMode: true,
+				TLS:      TLSConfig{Enable: true, SkipVerify: true},
+			},
+			expectedError: true,
+		},
+		{
+			testName: "FIPSModeWithTLSDisabled",
+			config: &Config{
+				FIPSMode: true,
+				Server:   Server{Host: "127.0.0.1", GrpcPort: 443},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			err := tc.config.ValidateFIPSMode()
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
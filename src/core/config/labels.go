@@ -0,0 +1,48 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	// maxLabelCount bounds the number of labels, since every one of them is
+	// attached to each outbound metric and event
+	maxLabelCount = 32
+	// maxLabelKeyLength is the longest allowed label key
+	maxLabelKeyLength = 64
+	// maxLabelValueLength is the longest allowed label value
+	maxLabelValueLength = 256
+)
+
+// labelKeyRegex restricts label keys to names that survive every downstream
+// consumer: metric dimension names, event dimensions and the control plane API.
+var labelKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_.-]*[a-zA-Z0-9])?$`)
+
+// ValidateLabels checks the static labels of the config against the naming and
+// size limits, so a typo in the config file fails at startup rather than being
+// silently attached to every report.
+func (c *Config) ValidateLabels() error {
+	if len(c.Labels) > maxLabelCount {
+		return fmt.Errorf("%d labels configured, at most %d are allowed", len(c.Labels), maxLabelCount)
+	}
+	for key, value := range c.Labels {
+		if len(key) > maxLabelKeyLength {
+			return fmt.Errorf("label key %q is longer than %d characters", key, maxLabelKeyLength)
+		}
+		if !labelKeyRegex.MatchString(key) {
+			return fmt.Errorf("label key %q is invalid, keys must be alphanumeric with interior dots, dashes or underscores", key)
+		}
+		if len(value) > maxLabelValueLength {
+			return fmt.Errorf("value of label %q is longer than %d characters", key, maxLabelValueLength)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLabels(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := 0; i < maxLabelCount+1; i++ {
+		tooMany[strings.Repeat("a", i+1)] = "value"
+	}
+
+	testCases := []struct {
+		testName      string
+		labels        map[string]string
+		expectedError bool
+	}{
+		{
+			testName:      "NoLabels",
+			labels:        nil,
+			expectedError: false,
+		},
+		{
+			testName: "ValidLabels",
+			labels: map[string]string{
+				"team":        "platform",
+				"environment": "production",
+				"data.center": "eu-west-1",
+				"rack_4":      "",
+			},
+			expectedError: false,
+		},
+		{
+			testName:      "InvalidKeyCharacters",
+			labels:        map[string]string{"team name": "platform"},
+			expectedError: true,
+		},
+		{
+			testName:      "KeyStartsWithSeparator",
+			labels:        map[string]string{"-team": "platform"},
+			expectedError: true,
+		},
+		{
+			testName:      "KeyTooLong",
+			labels:        map[string]string{strings.Repeat("a", maxLabelKeyLength+1): "platform"},
+			expectedError: true,
+		},
+		{
+			testName:      "ValueTooLong",
+			labels:        map[string]string{"team": strings.Repeat("a", maxLabelValueLength+1)},
+			expectedError: true,
+		},
+		{
+			testName:      "TooManyLabels",
+			labels:        tooMany,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			err := (&Config{Labels: tc.labels}).ValidateLabels()
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// Maturity levels for agent functionality
+const (
+	MaturityExperimental = "experimental"
+	MaturityDeprecated   = "deprecated"
+)
+
+// MaturityNotice flags one plugin or configuration option as experimental or
+// deprecated, so fleets can audit usage before an upgrade changes it
+type MaturityNotice struct {
+	Name    string
+	Level   string
+	Message string
+}
+
+// experimentalPlugins lists plugins whose behaviour may still change between
+// releases, each with a predicate reporting whether the loaded configuration
+// enables it
+var experimentalPlugins = []struct {
+	name    string
+	message string
+	enabled func(*Config) bool
+}{
+	{"docker_discovery", "Docker discovery is experimental; the reported container metadata may change.", func(c *Config) bool { return c.Docker.Enable }},
+	{"snmp", "The SNMP plugin is experimental; its OID layout may change.", func(c *Config) bool { return c.SNMP.Enable }},
+	{"unit", "NGINX Unit support is experimental; its reported details may change.", func(c *Config) bool { return c.Unit.Enable }},
+	{"object_store", "The object store plugin is experimental; its archive layout may change.", func(c *Config) bool { return c.ObjectStore.Enable }},
+	{"acme", "ACME certificate management is experimental; its renewal behaviour may change.", func(c *Config) bool { return c.Acme.Enabled }},
+}
+
+// MaturityNotices reports every experimental plugin the loaded configuration
+// enables and every deprecated option its configuration file still sets
+func MaturityNotices(conf *Config) []MaturityNotice {
+	var notices []MaturityNotice
+	for _, plugin := range experimentalPlugins {
+		if plugin.enabled(conf) {
+			notices = append(notices, MaturityNotice{Name: plugin.name, Level: MaturityExperimental, Message: plugin.message})
+		}
+	}
+	notices = append(notices, deprecatedOptionsInUse(conf.Path)...)
+	return notices
+}
+
+// LogMaturityNotices warns once at startup about every experimental or
+// deprecated piece of functionality the configuration uses
+func LogMaturityNotices(conf *Config) {
+	for _, notice := range MaturityNotices(conf) {
+		log.Warnf("%s is %s: %s", notice.Name, notice.Level, notice.Message)
+	}
+}
+
+// deprecatedOptionsInUse reads the configuration file and reports every key in
+// it that only a deprecated flag still understands. Deprecated flags given on
+// the command line already warn through the flag library
+func deprecatedOptionsInUse(path string) []MaturityNotice {
+	if path == "" {
+		return nil
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(contents, &root); err != nil || len(root.Content) == 0 {
+		return nil
+	}
+
+	leaves := make(map[string]*yaml.Node)
+	flattenYamlNode(root.Content[0], "", leaves)
+
+	deprecated := deprecatedConfigKeys()
+	var notices []MaturityNotice
+	for key := range leaves {
+		if message, ok := deprecated[strings.ReplaceAll(key, "-", "_")]; ok {
+			notices = append(notices, MaturityNotice{Name: key, Level: MaturityDeprecated, Message: message})
+		}
+	}
+	sort.Slice(notices, func(i, j int) bool { return notices[i].Name < notices[j].Name })
+	return notices
+}
+
+// deprecatedConfigKeys maps each deprecated option to its migration note
+func deprecatedConfigKeys() map[string]string {
+	deprecated := make(map[string]string, len(deprecatedFlags))
+	for _, registrable := range deprecatedFlags {
+		switch f := registrable.(type) {
+		case *StringFlag:
+			deprecated[f.Name] = f.Usage
+		case *BoolFlag:
+			deprecated[f.Name] = f.Usage
+		case *DurationFlag:
+			deprecated[f.Name] = f.Usage
+		}
+	}
+	return deprecated
+}
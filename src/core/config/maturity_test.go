@@ -0,0 +1,46 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaturityNotices(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nginx-agent.conf")
+	contents := "metadata: old-host:443\nmetrics_server: old-host:8000\nlog:\n  level: info\n"
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+
+	conf := &Config{Path: path}
+	conf.Docker.Enable = true
+
+	notices := MaturityNotices(conf)
+	require.Len(t, notices, 3)
+
+	assert.Equal(t, "docker_discovery", notices[0].Name)
+	assert.Equal(t, MaturityExperimental, notices[0].Level)
+
+	assert.Equal(t, "metadata", notices[1].Name)
+	assert.Equal(t, MaturityDeprecated, notices[1].Level)
+	assert.Contains(t, notices[1].Message, "--server-host")
+
+	assert.Equal(t, "metrics_server", notices[2].Name)
+	assert.Equal(t, MaturityDeprecated, notices[2].Level)
+}
+
+func TestMaturityNoticesStableConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nginx-agent.conf")
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: info\n"), 0644))
+
+	assert.Empty(t, MaturityNotices(&Config{Path: path}))
+}
@@ -0,0 +1,88 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	agent_config "github.com/nginx/agent/sdk/v2/agent/config"
+	flag "github.com/spf13/pflag"
+)
+
+// internalConfigKeys are derived at runtime or deliberately not exposed, so no
+// flags are generated for them
+var internalConfigKeys = map[string]struct{}{
+	"agent_id":      {},
+	"server_target": {},
+}
+
+// registerConfigStructFlags walks the Config struct and registers a flag for
+// every leaf that does not have a hand-written one, so each option in the
+// configuration file is also settable with a kebab-case flag and, through the
+// usual binding, an NGINX_AGENT_ prefixed environment variable. Flags override
+// environment variables, which override the configuration file.
+func registerConfigStructFlags(fs *flag.FlagSet) {
+	registerStructFields(fs, reflect.ValueOf(*Defaults), "")
+}
+
+func registerStructFields(fs *flag.FlagSet, value reflect.Value, prefix string) {
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		tag := strings.Split(structType.Field(i).Tag.Get("mapstructure"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+		key := tag
+		if prefix != "" {
+			key = prefix + agent_config.KeyDelimiter + tag
+		}
+		if _, internal := internalConfigKeys[key]; internal {
+			continue
+		}
+		registerFieldFlag(fs, value.Field(i), key)
+	}
+}
+
+func registerFieldFlag(fs *flag.FlagSet, value reflect.Value, key string) {
+	name := strings.NewReplacer("_", "-", ".", "-").Replace(key)
+	usage := fmt.Sprintf("Sets the %s option from the configuration file.", key)
+
+	switch typed := value.Interface().(type) {
+	case time.Duration:
+		if fs.Lookup(name) == nil {
+			fs.Duration(name, typed, usage)
+		}
+	case string:
+		if fs.Lookup(name) == nil {
+			fs.String(name, typed, usage)
+		}
+	case bool:
+		if fs.Lookup(name) == nil {
+			fs.Bool(name, typed, usage)
+		}
+	case int:
+		if fs.Lookup(name) == nil {
+			fs.Int(name, typed, usage)
+		}
+	case []string:
+		if fs.Lookup(name) == nil {
+			fs.StringSlice(name, typed, usage)
+		}
+	case map[string]string:
+		if fs.Lookup(name) == nil {
+			fs.StringToString(name, typed, usage)
+		}
+	default:
+		if value.Kind() == reflect.Struct {
+			registerStructFields(fs, value, key)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sysutils "github.com/nginx/agent/v2/test/utils/system"
+)
+
+func TestGeneratedFlagsCoverConfigStruct(t *testing.T) {
+	curDir, err := os.Getwd()
+	require.NoError(t, err)
+
+	tempConfDeleteFunc, err := sysutils.CopyFile(fmt.Sprintf("%s/%s", testCfgDir, emptyConfigFile), tempCfgFile)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tempConfDeleteFunc()) }()
+
+	tempDynamicDeleteFunc, err := sysutils.CopyFile(fmt.Sprintf("%s/%s", testCfgDir, emptyConfigFile), tempDynamicCfgFile)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tempDynamicDeleteFunc()) }()
+
+	cleanEnv(t, tempCfgFile, fmt.Sprintf("%s/%s", curDir, tempDynamicCfgFile))
+
+	fs := ROOT_COMMAND.Flags()
+
+	// options without hand-written flags get generated kebab-case ones
+	for _, name := range []string{
+		"nginx-debug",
+		"nginx-client-version",
+		"dataplane-status-poll-interval",
+		"disk-budget-enable",
+	} {
+		assert.NotNil(t, fs.Lookup(name), "expected a flag named %s", name)
+	}
+
+	// internal options stay internal
+	assert.Nil(t, fs.Lookup("server-target"))
+	assert.Nil(t, fs.Lookup("agent-id"))
+}
+
+func TestEnvAndFlagOverridePrecedence(t *testing.T) {
+	curDir, err := os.Getwd()
+	require.NoError(t, err)
+
+	tempConfDeleteFunc, err := sysutils.CopyFile(fmt.Sprintf("%s/%s", testCfgDir, emptyConfigFile), tempCfgFile)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tempConfDeleteFunc()) }()
+
+	tempDynamicDeleteFunc, err := sysutils.CopyFile(fmt.Sprintf("%s/%s", testCfgDir, emptyConfigFile), tempDynamicCfgFile)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tempDynamicDeleteFunc()) }()
+
+	cleanEnv(t, tempCfgFile, fmt.Sprintf("%s/%s", curDir, tempDynamicCfgFile))
+
+	// the NGINX_AGENT_ prefix overrides the default
+	require.NoError(t, os.Setenv("NGINX_AGENT_DATAPLANE_STATUS_POLL_INTERVAL", "45s"))
+	require.NoError(t, os.Setenv("NGINX_AGENT_NGINX_DEBUG", "true"))
+	defer os.Unsetenv("NGINX_AGENT_DATAPLANE_STATUS_POLL_INTERVAL")
+	defer os.Unsetenv("NGINX_AGENT_NGINX_DEBUG")
+
+	config, err := GetConfig("12345")
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Second, config.Dataplane.Status.PollInterval)
+	assert.True(t, config.Nginx.Debug)
+
+	// a flag overrides the environment variable
+	require.NoError(t, ROOT_COMMAND.Flags().Set("dataplane-status-poll-interval", "90s"))
+
+	config, err = GetConfig("12345")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, config.Dataplane.Status.PollInterval)
+}
@@ -0,0 +1,82 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WriteRegistrationSettings merges the control plane settings into the
+// configuration file at path, creating the file when it does not exist yet.
+// Settings already in the file are kept, and the result is validated before
+// the helper reports success.
+func WriteRegistrationSettings(path, host string, grpcPort int, token, ca string, skipVerify bool) error {
+	settings := make(map[string]interface{})
+	contents, err := os.ReadFile(path)
+	if err == nil {
+		if err := yaml.Unmarshal(contents, &settings); err != nil {
+			return fmt.Errorf("unable to parse %s: %v", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	server, _ := settings["server"].(map[string]interface{})
+	if server == nil {
+		server = make(map[string]interface{})
+	}
+	server["host"] = host
+	server["grpcPort"] = grpcPort
+	if token != "" {
+		server["token"] = token
+	}
+	settings["server"] = server
+
+	if ca != "" || skipVerify {
+		tlsSettings, _ := settings["tls"].(map[string]interface{})
+		if tlsSettings == nil {
+			tlsSettings = make(map[string]interface{})
+		}
+		tlsSettings["enable"] = true
+		if ca != "" {
+			tlsSettings["ca"] = ca
+		}
+		if skipVerify {
+			tlsSettings["skip_verify"] = true
+		}
+		settings["tls"] = tlsSettings
+	}
+
+	updated, err := yaml.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("unable to serialize configuration: %v", err)
+	}
+
+	// the file may carry the token, so a new file is not world-readable
+	mode := os.FileMode(0640)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(path, updated, mode); err != nil {
+		return err
+	}
+
+	findings, err := CheckConfigFile(path)
+	if err != nil {
+		return err
+	}
+	for _, finding := range findings {
+		if finding.Severity == ErrorSeverity {
+			return fmt.Errorf("%s is not valid, %s", path, finding)
+		}
+	}
+	return nil
+}
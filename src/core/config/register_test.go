@@ -0,0 +1,70 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestWriteRegistrationSettingsCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nginx-agent.conf")
+
+	require.NoError(t, WriteRegistrationSettings(path, "dataplane-manager", 443, "secret", "", false))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+
+	settings := readRegistrationSettings(t, path)
+	server := settings["server"].(map[string]interface{})
+	assert.Equal(t, "dataplane-manager", server["host"])
+	assert.Equal(t, 443, server["grpcPort"])
+	assert.Equal(t, "secret", server["token"])
+	assert.NotContains(t, settings, "tls")
+}
+
+func TestWriteRegistrationSettingsKeepsExistingSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nginx-agent.conf")
+	existing := "log:\n  level: debug\nserver:\n  host: old-host\n  grpcPort: 10000\n"
+	require.NoError(t, os.WriteFile(path, []byte(existing), 0644))
+
+	require.NoError(t, WriteRegistrationSettings(path, "new-host", 443, "", "/etc/ssl/ca.pem", true))
+
+	settings := readRegistrationSettings(t, path)
+	logSettings := settings["log"].(map[string]interface{})
+	assert.Equal(t, "debug", logSettings["level"])
+
+	server := settings["server"].(map[string]interface{})
+	assert.Equal(t, "new-host", server["host"])
+	assert.Equal(t, 443, server["grpcPort"])
+	assert.NotContains(t, server, "token")
+
+	tlsSettings := settings["tls"].(map[string]interface{})
+	assert.Equal(t, true, tlsSettings["enable"])
+	assert.Equal(t, "/etc/ssl/ca.pem", tlsSettings["ca"])
+	assert.Equal(t, true, tlsSettings["skip_verify"])
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+}
+
+func readRegistrationSettings(t *testing.T, path string) map[string]interface{} {
+	t.Helper()
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	settings := make(map[string]interface{})
+	require.NoError(t, yaml.Unmarshal(contents, &settings))
+	return settings
+}
@@ -0,0 +1,55 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"reflect"
+)
+
+// NonReloadableChanges compares the running configuration against a freshly
+// loaded one and returns the names of the changed sections that only take
+// effect after an agent restart, because they control plugin registration or
+// connections that are established once at startup. An empty result means the
+// updated configuration can be applied in place.
+func NonReloadableChanges(current, updated *Config) []string {
+	var changes []string
+	for _, section := range []struct {
+		name             string
+		current, updated interface{}
+	}{
+		{"server", current.Server, updated.Server},
+		{"api", current.AgentAPI, updated.AgentAPI},
+		{"tls", current.TLS, updated.TLS},
+		{"fips_mode", current.FIPSMode, updated.FIPSMode},
+		{"log.path", current.Log.Path, updated.Log.Path},
+		{"log.journal", current.Log.Journal, updated.Log.Journal},
+		{"features", current.Features, updated.Features},
+		{"extensions", current.Extensions, updated.Extensions},
+		{"nginx.socket", current.Nginx.NginxCountingSocket, updated.Nginx.NginxCountingSocket},
+		{"advanced_metrics", current.AdvancedMetrics, updated.AdvancedMetrics},
+		{"nginx_app_protect", current.NginxAppProtect, updated.NginxAppProtect},
+		{"nap_monitoring", current.NAPMonitoring, updated.NAPMonitoring},
+		{"error_log_events.enable", current.ErrorLogEvents.Enable, updated.ErrorLogEvents.Enable},
+		{"log_forwarding", current.LogForwarding, updated.LogForwarding},
+		{"docker", current.Docker, updated.Docker},
+		{"consul", current.Consul, updated.Consul},
+		{"vault", current.Vault, updated.Vault},
+		{"object_store", current.ObjectStore, updated.ObjectStore},
+		{"snmp", current.SNMP, updated.SNMP},
+		{"webhook", current.Webhook, updated.Webhook},
+		{"tracing", current.Tracing, updated.Tracing},
+		{"unit", current.Unit, updated.Unit},
+		{"agent_update", current.AgentUpdate, updated.AgentUpdate},
+		{"acme", current.Acme, updated.Acme},
+	} {
+		if !reflect.DeepEqual(section.current, section.updated) {
+			changes = append(changes, section.name)
+		}
+	}
+	return changes
+}
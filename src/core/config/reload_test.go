@@ -0,0 +1,44 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonReloadableChanges(t *testing.T) {
+	current := &Config{
+		Server:   Server{Host: "127.0.0.1", GrpcPort: 443},
+		Log:      LogConfig{Level: "info", Path: "/var/log/nginx-agent"},
+		Tags:     []string{"prod"},
+		Features: []string{"features_api"},
+	}
+
+	t.Run("identical configs report no changes", func(t *testing.T) {
+		updated := *current
+		assert.Empty(t, NonReloadableChanges(current, &updated))
+	})
+
+	t.Run("reloadable fields report no changes", func(t *testing.T) {
+		updated := *current
+		updated.Log.Level = "debug"
+		updated.Tags = []string{"prod", "edge"}
+		updated.Dataplane.Status.PollInterval = 60
+		assert.Empty(t, NonReloadableChanges(current, &updated))
+	})
+
+	t.Run("restart only fields are reported", func(t *testing.T) {
+		updated := *current
+		updated.Server.Host = "192.168.0.1"
+		updated.Features = []string{"features_api", "features_metrics"}
+		updated.TLS.Enable = true
+		assert.Equal(t, []string{"server", "tls", "features"}, NonReloadableChanges(current, &updated))
+	})
+}
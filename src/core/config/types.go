@@ -8,6 +8,7 @@
 package config
 
 import (
+	"strings"
 	"time"
 
 	advanced_metrics "github.com/nginx/agent/v2/src/extensions/advanced-metrics/pkg/advanced-metrics"
@@ -23,19 +24,43 @@ type Config struct {
 	ConfigDirs            string              `mapstructure:"config-dirs" yaml:"-"`
 	Log                   LogConfig           `mapstructure:"log" yaml:"-"`
 	TLS                   TLSConfig           `mapstructure:"tls" yaml:"-"`
+	FIPSMode              bool                `mapstructure:"fips_mode" yaml:"-"`
 	Nginx                 Nginx               `mapstructure:"nginx" yaml:"-"`
 	Dataplane             Dataplane           `mapstructure:"dataplane" yaml:"-"`
 	AgentMetrics          AgentMetrics        `mapstructure:"metrics" yaml:"-"`
 	Tags                  []string            `mapstructure:"tags" yaml:"tags,omitempty"`
+	Labels                map[string]string   `mapstructure:"labels" yaml:"labels,omitempty"`
 	Features              []string            `mapstructure:"features" yaml:"features,omitempty"`
 	Extensions            []string            `mapstructure:"extensions" yaml:"extensions,omitempty"`
 	Updated               time.Time           `yaml:"-"` // update time of the config file
 	AllowedDirectoriesMap map[string]struct{} `yaml:"-"`
 	DisplayName           string              `mapstructure:"display_name" yaml:"display_name,omitempty"`
 	InstanceGroup         string              `mapstructure:"instance_group" yaml:"instance_group,omitempty"`
+	ShutdownGracePeriod   time.Duration       `mapstructure:"shutdown_grace_period" yaml:"-"`
 	AdvancedMetrics       AdvancedMetrics     `mapstructure:"advanced_metrics" yaml:"advanced_metrics,omitempty"`
 	NginxAppProtect       NginxAppProtect     `mapstructure:"nginx_app_protect" yaml:"nginx_app_protect,omitempty"`
 	NAPMonitoring         NAPMonitoring       `mapstructure:"nap_monitoring" yaml:"nap_monitoring,omitempty"`
+	CertExpiry            CertExpiry          `mapstructure:"cert_expiry" yaml:"-"`
+	ResolverHealth        ResolverHealth      `mapstructure:"resolver_health" yaml:"-"`
+	SyntheticChecks       SyntheticChecks     `mapstructure:"synthetic_checks" yaml:"-"`
+	Acme                  Acme                `mapstructure:"acme" yaml:"-"`
+	ErrorLogEvents        ErrorLogEvents      `mapstructure:"error_log_events" yaml:"-"`
+	LogForwarding         LogForwarding       `mapstructure:"log_forwarding" yaml:"-"`
+	Docker                Docker              `mapstructure:"docker" yaml:"-"`
+	Consul                Consul              `mapstructure:"consul" yaml:"-"`
+	Vault                 Vault               `mapstructure:"vault" yaml:"-"`
+	ObjectStore           ObjectStore         `mapstructure:"object_store" yaml:"-"`
+	SNMP                  SNMP                `mapstructure:"snmp" yaml:"-"`
+	Webhook               Webhook             `mapstructure:"webhook" yaml:"-"`
+	Tracing               Tracing             `mapstructure:"tracing" yaml:"-"`
+	Unit                  Unit                `mapstructure:"unit" yaml:"-"`
+	AgentUpdate           AgentUpdate         `mapstructure:"agent_update" yaml:"-"`
+	DiskBudget            DiskBudget          `mapstructure:"disk_budget" yaml:"-"`
+	BandwidthBudget       BandwidthBudget     `mapstructure:"bandwidth_budget" yaml:"-"`
+	Supervisor            Supervisor          `mapstructure:"supervisor" yaml:"-"`
+	Failover              Failover            `mapstructure:"failover" yaml:"-"`
+	Export                Export              `mapstructure:"export" yaml:"-"`
+	InstancePolicies      []InstancePolicy    `mapstructure:"instance_policies" yaml:"-"`
 }
 
 func (c *Config) IsGrpcServerConfigured() bool {
@@ -59,6 +84,45 @@ func (c *Config) IsFeatureEnabled(feature string) bool {
 	return false
 }
 
+// InstancePolicyFor returns the first instance policy whose selectors all match the
+// given nginx instance, or nil when none matches. A policy without any selector
+// matches nothing rather than everything.
+func (c *Config) InstancePolicyFor(confPath, binPath, processID string) *InstancePolicy {
+	for i := range c.InstancePolicies {
+		policy := &c.InstancePolicies[i]
+		if policy.ConfPathPrefix == "" && policy.BinPathPrefix == "" && policy.ProcessID == "" {
+			continue
+		}
+		if policy.ConfPathPrefix != "" && !strings.HasPrefix(confPath, policy.ConfPathPrefix) {
+			continue
+		}
+		if policy.BinPathPrefix != "" && !strings.HasPrefix(binPath, policy.BinPathPrefix) {
+			continue
+		}
+		if policy.ProcessID != "" && policy.ProcessID != processID {
+			continue
+		}
+		return policy
+	}
+	return nil
+}
+
+// AllowedDirectoriesFor returns the allowed directories of the given instance
+// policy; a nil policy or one without config_dirs falls back to the global
+// allowed directories.
+func (c *Config) AllowedDirectoriesFor(policy *InstancePolicy) map[string]struct{} {
+	if policy == nil || policy.ConfigDirs == "" {
+		return c.AllowedDirectoriesMap
+	}
+	dirs := map[string]struct{}{}
+	for _, dir := range strings.Split(policy.ConfigDirs, ":") {
+		if dir != "" {
+			dirs[dir] = struct{}{}
+		}
+	}
+	return dirs
+}
+
 type Server struct {
 	Host     string `mapstructure:"host" yaml:"-"`
 	GrpcPort int    `mapstructure:"grpcPort" yaml:"-"`
@@ -73,29 +137,50 @@ type AgentAPI struct {
 	Port int    `mapstructure:"port" yaml:"-"`
 	Cert string `mapstructure:"cert" yaml:"-"`
 	Key  string `mapstructure:"key" yaml:"-"`
+	// Token protects the read-only /api/v1 endpoints: when set, requests must
+	// carry it as a bearer token. The legacy endpoints are unaffected.
+	Token string `mapstructure:"auth_token" yaml:"-"`
+	// MetricsNaming selects the metric naming scheme of the metrics endpoint:
+	// agent (the default) or amplify
+	MetricsNaming string `mapstructure:"metrics_naming" yaml:"-"`
 }
 
 // LogConfig for logging
 type LogConfig struct {
-	Level string `mapstructure:"level" yaml:"-"`
-	Path  string `mapstructure:"path" yaml:"-"`
+	Level      string `mapstructure:"level" yaml:"-"`
+	Path       string `mapstructure:"path" yaml:"-"`
+	Format     string `mapstructure:"format" yaml:"-"`
+	Journal    bool   `mapstructure:"journal" yaml:"-"`
+	MaxSize    int    `mapstructure:"max_size" yaml:"-"`
+	MaxBackups int    `mapstructure:"max_backups" yaml:"-"`
+	MaxAge     int    `mapstructure:"max_age" yaml:"-"`
 }
 
 // TLSConfig for securing communications
 type TLSConfig struct {
-	Enable     bool   `mapstructure:"enable" yaml:"-"`
-	Cert       string `mapstructure:"cert" yaml:"-"`
-	Key        string `mapstructure:"key" yaml:"-"`
-	Ca         string `mapstructure:"ca" yaml:"-"`
-	SkipVerify bool   `mapstructure:"skip_verify" yaml:"-"`
+	Enable       bool   `mapstructure:"enable" yaml:"-"`
+	Cert         string `mapstructure:"cert" yaml:"-"`
+	Key          string `mapstructure:"key" yaml:"-"`
+	Ca           string `mapstructure:"ca" yaml:"-"`
+	SkipVerify   bool   `mapstructure:"skip_verify" yaml:"-"`
+	SpiffeSocket string `mapstructure:"spiffe_workload_api_socket" yaml:"-"`
 }
 
 // Nginx settings
 type Nginx struct {
-	ExcludeLogs         string `mapstructure:"exclude_logs" yaml:"-"`
-	Debug               bool   `mapstructure:"debug" yaml:"-"`
-	NginxCountingSocket string `mapstructure:"socket" yaml:"-"`
-	NginxClientVersion  int    `mapstructure:"client_version" yaml:"-"`
+	ExcludeLogs         string   `mapstructure:"exclude_logs" yaml:"-"`
+	Debug               bool     `mapstructure:"debug" yaml:"-"`
+	LogAccessEvents     bool     `mapstructure:"log_access_events" yaml:"-"`
+	BinarySearchPaths   []string `mapstructure:"binary_search_paths" yaml:"-"`
+	NginxCountingSocket string   `mapstructure:"socket" yaml:"-"`
+	NginxClientVersion  int      `mapstructure:"client_version" yaml:"-"`
+	// ConfigCollectWorkers is how many aux files are read concurrently while a
+	// config payload is collected
+	ConfigCollectWorkers int `mapstructure:"config_collect_workers" yaml:"-"`
+	// ConfigApplyFsyncDirs syncs each directory a config apply wrote into once
+	// all of its files are written, making the new entries durable without an
+	// fsync per file
+	ConfigApplyFsyncDirs bool `mapstructure:"config_apply_fsync_dirs" yaml:"-"`
 }
 
 type Dataplane struct {
@@ -114,6 +199,15 @@ type AgentMetrics struct {
 	ReportInterval     time.Duration `mapstructure:"report_interval" yaml:"-"`
 	CollectionInterval time.Duration `mapstructure:"collection_interval" yaml:"-"`
 	Mode               string        `mapstructure:"mode" yaml:"-"`
+	// Naming selects the metric naming scheme used when reporting to the
+	// management server: agent (the default) or amplify
+	Naming string `mapstructure:"naming" yaml:"-"`
+	// CorrectClockSkew shifts metric timestamps onto the control plane clock
+	// when the host clock is detected to be skewed
+	CorrectClockSkew bool `mapstructure:"correct_clock_skew" yaml:"-"`
+	// MaxMessageSize is the largest metrics report message, in bytes, sent in
+	// one grpc message. Reports over the limit are split across messages
+	MaxMessageSize int `mapstructure:"max_message_size" yaml:"-"`
 }
 
 type AdvancedMetrics struct {
@@ -128,6 +222,251 @@ type NginxAppProtect struct {
 	PrecompiledPublication bool          `mapstructure:"precompiled_publication" yaml:"-"`
 }
 
+// CertExpiry settings for certificate expiry monitoring
+type CertExpiry struct {
+	WarningDays  int           `mapstructure:"warning_days" yaml:"-"`
+	CriticalDays int           `mapstructure:"critical_days" yaml:"-"`
+	Interval     time.Duration `mapstructure:"interval" yaml:"-"`
+}
+
+// ResolverHealth settings for DNS resolution monitoring of upstream hostnames
+type ResolverHealth struct {
+	Interval time.Duration `mapstructure:"interval" yaml:"-"`
+}
+
+// SyntheticChecks settings for lightweight HTTP probes against local listeners
+type SyntheticChecks struct {
+	Interval time.Duration    `mapstructure:"interval" yaml:"-"`
+	Checks   []SyntheticCheck `mapstructure:"checks" yaml:"-"`
+}
+
+// SyntheticCheck is one HTTP probe: the request is sent to Address with ServerName
+// as the Host header (and SNI when SSL is set), so a specific server block can be
+// probed through a shared listener.
+type SyntheticCheck struct {
+	Name           string `mapstructure:"name" yaml:"-"`
+	Address        string `mapstructure:"address" yaml:"-"`
+	ServerName     string `mapstructure:"server_name" yaml:"-"`
+	Path           string `mapstructure:"path" yaml:"-"`
+	ExpectedStatus int    `mapstructure:"expected_status" yaml:"-"`
+	SSL            bool   `mapstructure:"ssl" yaml:"-"`
+}
+
+// Acme settings for certificate issuance and renewal via an ACME directory
+type Acme struct {
+	Enabled      bool          `mapstructure:"enabled" yaml:"-"`
+	DirectoryURL string        `mapstructure:"directory_url" yaml:"-"`
+	Email        string        `mapstructure:"email" yaml:"-"`
+	Challenge    string        `mapstructure:"challenge" yaml:"-"`
+	DNSProvider  string        `mapstructure:"dns_provider" yaml:"-"`
+	WebrootPath  string        `mapstructure:"webroot_path" yaml:"-"`
+	CertDir      string        `mapstructure:"cert_dir" yaml:"-"`
+	RenewBefore  time.Duration `mapstructure:"renew_before" yaml:"-"`
+	Interval     time.Duration `mapstructure:"interval" yaml:"-"`
+}
+
+// ErrorLogEvents settings for escalating nginx error log lines into events
+type ErrorLogEvents struct {
+	Enable            bool          `mapstructure:"enable" yaml:"-"`
+	MinLevel          string        `mapstructure:"min_level" yaml:"-"`
+	Patterns          []string      `mapstructure:"patterns" yaml:"-"`
+	ExcerptLength     int           `mapstructure:"excerpt_length" yaml:"-"`
+	MultilinePatterns []string      `mapstructure:"multiline_patterns" yaml:"-"`
+	MultilineTimeout  time.Duration `mapstructure:"multiline_timeout" yaml:"-"`
+}
+
+// LogForwarding settings for shipping access and error logs to an external sink
+type LogForwarding struct {
+	Enable          bool          `mapstructure:"enable" yaml:"-"`
+	Sink            string        `mapstructure:"sink" yaml:"-"`
+	Address         string        `mapstructure:"address" yaml:"-"`
+	KafkaTopic      string        `mapstructure:"kafka_topic" yaml:"-"`
+	BatchSize       int           `mapstructure:"batch_size" yaml:"-"`
+	BatchInterval   time.Duration `mapstructure:"batch_interval" yaml:"-"`
+	QueueSize       int           `mapstructure:"queue_size" yaml:"-"`
+	CheckpointDir   string        `mapstructure:"checkpoint_dir" yaml:"-"`
+	RotationCatchUp bool          `mapstructure:"rotation_catch_up" yaml:"-"`
+}
+
+// Docker settings for discovering nginx instances running in containers
+type Docker struct {
+	Enable       bool          `mapstructure:"enable" yaml:"-"`
+	Socket       string        `mapstructure:"socket" yaml:"-"`
+	PollInterval time.Duration `mapstructure:"poll_interval" yaml:"-"`
+}
+
+// Vault settings for reading agent secrets, like the control-plane token and
+// TLS material, from a Vault server instead of plaintext config fields
+type Vault struct {
+	Enable    bool   `mapstructure:"enable" yaml:"-"`
+	Address   string `mapstructure:"address" yaml:"-"`
+	Namespace string `mapstructure:"namespace" yaml:"-"`
+	// Auth selects the auth method: approle, kubernetes or token
+	Auth         string `mapstructure:"auth" yaml:"-"`
+	RoleID       string `mapstructure:"role_id" yaml:"-"`
+	SecretIDFile string `mapstructure:"secret_id_file" yaml:"-"`
+	Role         string `mapstructure:"role" yaml:"-"`
+	Token        string `mapstructure:"token" yaml:"-"`
+}
+
+// Consul settings for registering the agent and its nginx instances in a
+// Consul catalog with TTL health checks
+type Consul struct {
+	Enable          bool          `mapstructure:"enable" yaml:"-"`
+	Address         string        `mapstructure:"address" yaml:"-"`
+	Token           string        `mapstructure:"token" yaml:"-"`
+	CheckTTL        time.Duration `mapstructure:"check_ttl" yaml:"-"`
+	DeregisterAfter time.Duration `mapstructure:"deregister_after" yaml:"-"`
+}
+
+// Tracing settings for exporting OpenTelemetry spans of config operations
+// over OTLP/HTTP
+type Tracing struct {
+	Enable      bool   `mapstructure:"enable" yaml:"-"`
+	Endpoint    string `mapstructure:"endpoint" yaml:"-"`
+	ServiceName string `mapstructure:"service_name" yaml:"-"`
+}
+
+// Webhook settings for pushing critical events to a generic webhook, Slack or
+// PagerDuty. The secret is named by environment variable rather than held in
+// the config file.
+type Webhook struct {
+	Enable       bool          `mapstructure:"enable" yaml:"-"`
+	URL          string        `mapstructure:"url" yaml:"-"`
+	Type         string        `mapstructure:"type" yaml:"-"` // generic, slack or pagerduty
+	SecretEnv    string        `mapstructure:"secret_env" yaml:"-"`
+	Levels       []string      `mapstructure:"levels" yaml:"-"`
+	Timeout      time.Duration `mapstructure:"timeout" yaml:"-"`
+	Retries      int           `mapstructure:"retries" yaml:"-"`
+	RetryBackoff time.Duration `mapstructure:"retry_backoff" yaml:"-"`
+}
+
+// SNMP settings for exposing key nginx metrics to a master SNMP agent as an
+// AgentX sub-agent
+type SNMP struct {
+	Enable        bool   `mapstructure:"enable" yaml:"-"`
+	AgentXAddress string `mapstructure:"agentx_address" yaml:"-"`
+	BaseOID       string `mapstructure:"base_oid" yaml:"-"`
+}
+
+// ObjectStore settings for pulling nginx config payloads from, and backing up
+// applied versions to, an S3-compatible bucket
+type ObjectStore struct {
+	Enable          bool          `mapstructure:"enable" yaml:"-"`
+	Endpoint        string        `mapstructure:"endpoint" yaml:"-"`
+	Region          string        `mapstructure:"region" yaml:"-"`
+	Bucket          string        `mapstructure:"bucket" yaml:"-"`
+	Prefix          string        `mapstructure:"prefix" yaml:"-"`
+	AccessKeyID     string        `mapstructure:"access_key_id" yaml:"-"`
+	SecretAccessKey string        `mapstructure:"secret_access_key" yaml:"-"`
+	SSE             string        `mapstructure:"sse" yaml:"-"`
+	SSEKMSKeyID     string        `mapstructure:"sse_kms_key_id" yaml:"-"`
+	PollInterval    time.Duration `mapstructure:"poll_interval" yaml:"-"`
+	Backup          bool          `mapstructure:"backup" yaml:"-"`
+}
+
+// Unit settings for discovering an NGINX Unit instance through its control
+// socket and reporting its presence, config checksum and request metrics
+type Unit struct {
+	Enable         bool          `mapstructure:"enable" yaml:"-"`
+	ControlSocket  string        `mapstructure:"control_socket" yaml:"-"`
+	ReportInterval time.Duration `mapstructure:"report_interval" yaml:"-"`
+}
+
+// AgentUpdate settings for accepting signed agent packages pushed by the
+// management server. The public key verifies the package signature before the
+// staged binary replaces the running agent.
+type AgentUpdate struct {
+	Enable    bool   `mapstructure:"enable" yaml:"-"`
+	PublicKey string `mapstructure:"public_key" yaml:"-"`
+}
+
+// DiskBudget settings capping the disk used by agent-generated data: rotated
+// agent logs, log forwarding checkpoints and debug bundles. Each store has a
+// quota carved out of the global budget and is trimmed oldest file first when
+// it runs over; a warning event is published when total usage nears the budget.
+type DiskBudget struct {
+	Enable            bool          `mapstructure:"enable" yaml:"-"`
+	BudgetMB          int           `mapstructure:"budget_mb" yaml:"-"`
+	WarningPercent    int           `mapstructure:"warning_percent" yaml:"-"`
+	Interval          time.Duration `mapstructure:"interval" yaml:"-"`
+	LogQuotaMB        int           `mapstructure:"log_quota_mb" yaml:"-"`
+	CheckpointQuotaMB int           `mapstructure:"checkpoint_quota_mb" yaml:"-"`
+	BundleQuotaMB     int           `mapstructure:"bundle_quota_mb" yaml:"-"`
+}
+
+// Export settings for the export mode, which writes the payloads the agent
+// would send to the control plane (registration, status, metrics, events,
+// config uploads) to a rotating local NDJSON file, so air-gapped installs
+// can batch-transfer telemetry out-of-band
+type Export struct {
+	Enable bool   `mapstructure:"enable" yaml:"-"`
+	File   string `mapstructure:"file" yaml:"-"`
+	// MaxSize is the size, in megabytes, the export file can reach before it
+	// is rotated. A value of 0 disables rotation
+	MaxSize    int `mapstructure:"max_size" yaml:"-"`
+	MaxBackups int `mapstructure:"max_backups" yaml:"-"`
+}
+
+// InstancePolicy overrides parts of the collection policy for the nginx instances
+// it matches, needed when one host runs tenant-isolated instances that must not
+// share the global allowed directories. Policies are evaluated in order and the
+// first match wins; an instance matches when every selector that is set matches,
+// the path prefixes by prefix and the process id exactly.
+type InstancePolicy struct {
+	// ConfPathPrefix selects instances whose conf path starts with this prefix
+	ConfPathPrefix string `mapstructure:"conf_path_prefix" yaml:"-"`
+	// BinPathPrefix selects instances whose binary path starts with this prefix
+	BinPathPrefix string `mapstructure:"bin_path_prefix" yaml:"-"`
+	// ProcessID selects the instance with this master process id
+	ProcessID string `mapstructure:"process_id" yaml:"-"`
+	// ConfigDirs replaces the global config-dirs for matching instances, in the
+	// same colon separated form
+	ConfigDirs string `mapstructure:"config_dirs" yaml:"-"`
+	// DisableAuxCollection leaves the root, alias and certificate files of
+	// matching instances out of the collected config payloads
+	DisableAuxCollection bool `mapstructure:"disable_aux_collection" yaml:"-"`
+	// DisableConfigApply rejects config applies for matching instances
+	DisableConfigApply bool `mapstructure:"disable_config_apply" yaml:"-"`
+}
+
+// Failover settings for running against multiple control planes, needed for
+// disaster recovery setups. Servers lists additional control plane targets in
+// priority order after the primary server; the agent probes the active target
+// and moves to the highest-priority reachable one when it goes away.
+type Failover struct {
+	Enable bool `mapstructure:"enable" yaml:"-"`
+	// Servers are additional control plane targets (host:port) tried in
+	// order when the active one is unreachable
+	Servers          []string      `mapstructure:"servers" yaml:"-"`
+	ProbeInterval    time.Duration `mapstructure:"probe_interval" yaml:"-"`
+	FailureThreshold int           `mapstructure:"failure_threshold" yaml:"-"`
+}
+
+// Supervisor settings for the opt-in process supervisor mode, where the agent
+// owns the NGINX lifecycle: it starts NGINX in the foreground, restarts it on
+// crash with exponential backoff and acts on start, stop and restart
+// commands. Meant for containers where the agent runs as PID 1.
+type Supervisor struct {
+	Enable     bool          `mapstructure:"enable" yaml:"-"`
+	BinPath    string        `mapstructure:"bin_path" yaml:"-"`
+	BackoffMin time.Duration `mapstructure:"backoff_min" yaml:"-"`
+	BackoffMax time.Duration `mapstructure:"backoff_max" yaml:"-"`
+	// StableUptime is how long NGINX has to stay up before the restart
+	// backoff resets
+	StableUptime time.Duration `mapstructure:"stable_uptime" yaml:"-"`
+}
+
+// BandwidthBudget settings bounding the bytes the agent sends to the control
+// plane and log sinks per interval, for satellite or cellular connected edge
+// boxes. Control traffic always goes out; metric and event reports are
+// deferred once the budget for an interval is spent.
+type BandwidthBudget struct {
+	Enable           bool          `mapstructure:"enable" yaml:"-"`
+	BytesPerInterval int           `mapstructure:"bytes_per_interval" yaml:"-"`
+	Interval         time.Duration `mapstructure:"interval" yaml:"-"`
+}
+
 type NAPMonitoring struct {
 	CollectorBufferSize int           `mapstructure:"collector_buffer_size" yaml:"-"`
 	ProcessorBufferSize int           `mapstructure:"processor_buffer_size" yaml:"-"`
@@ -0,0 +1,225 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	agent_config "github.com/nginx/agent/sdk/v2/agent/config"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	WarningSeverity = "warning"
+	ErrorSeverity   = "error"
+)
+
+// ConfigFinding is one problem found in a configuration file: a key the agent
+// does not know, or a value of the wrong type
+type ConfigFinding struct {
+	Key      string
+	Line     int
+	Severity string
+	Message  string
+}
+
+func (f ConfigFinding) String() string {
+	return fmt.Sprintf("line %d: %s: %s: %s", f.Line, f.Severity, f.Key, f.Message)
+}
+
+// CheckConfigFile validates a configuration file against the keys and types
+// the agent understands, so typos surface as findings with line numbers
+// instead of being silently ignored
+func CheckConfigFile(path string) ([]ConfigFinding, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(contents, &root); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", path, err)
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	leaves := make(map[string]*yaml.Node)
+	flattenYamlNode(root.Content[0], "", leaves)
+
+	known := knownConfigKeys()
+	schema := configKeyTypes()
+
+	var findings []ConfigFinding
+	for key, node := range leaves {
+		// the file may spell keys with dashes where viper keys use underscores
+		normalized := strings.ReplaceAll(key, "-", "_")
+		if !isKnownConfigKey(known, normalized) {
+			findings = append(findings, ConfigFinding{
+				Key:      key,
+				Line:     node.Line,
+				Severity: WarningSeverity,
+				Message:  "unknown configuration key, it will be ignored",
+			})
+			continue
+		}
+		if message := checkConfigValueType(schema, normalized, node); message != "" {
+			findings = append(findings, ConfigFinding{
+				Key:      key,
+				Line:     node.Line,
+				Severity: ErrorSeverity,
+				Message:  message,
+			})
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Line < findings[j].Line })
+	return findings, nil
+}
+
+// flattenYamlNode walks a yaml document and collects its leaf values keyed the
+// way viper keys them, lower-cased and joined with the key delimiter
+func flattenYamlNode(node *yaml.Node, prefix string, leaves map[string]*yaml.Node) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode || len(node.Content) == 0 {
+		if prefix != "" {
+			leaves[prefix] = node
+		}
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := strings.ToLower(node.Content[i].Value)
+		if prefix != "" {
+			key = prefix + agent_config.KeyDelimiter + key
+		}
+		flattenYamlNode(node.Content[i+1], key, leaves)
+	}
+}
+
+// knownConfigKeys is every key the agent understands: the registered flags
+// plus everything with a default, such as extension settings
+func knownConfigKeys() map[string]struct{} {
+	known := make(map[string]struct{})
+	for key := range configKeyTypes() {
+		// flags bind to viper with dashes replaced, so both spellings are known
+		known[key] = struct{}{}
+		known[strings.ReplaceAll(key, "-", "_")] = struct{}{}
+	}
+	for _, key := range Viper.AllKeys() {
+		known[key] = struct{}{}
+	}
+	return known
+}
+
+// isKnownConfigKey reports whether a key matches a known key exactly, is a
+// parent of one, or is nested below a known leaf such as a table of limits
+func isKnownConfigKey(known map[string]struct{}, key string) bool {
+	if _, ok := known[key]; ok {
+		return true
+	}
+	for knownKey := range known {
+		if strings.HasPrefix(knownKey, key+agent_config.KeyDelimiter) ||
+			strings.HasPrefix(key, knownKey+agent_config.KeyDelimiter) {
+			return true
+		}
+	}
+	return false
+}
+
+// configKeyTypes builds the expected type of every key that has a typed flag
+func configKeyTypes() map[string]string {
+	schema := make(map[string]string)
+	for _, registrable := range agentFlags {
+		switch f := registrable.(type) {
+		case *StringFlag:
+			schema[f.Name] = "string"
+		case *StringSliceFlag:
+			schema[f.Name] = "list of strings"
+		case *StringMapFlag:
+			schema[f.Name] = "map"
+		case *IntFlag:
+			schema[f.Name] = "integer"
+		case *BoolFlag:
+			schema[f.Name] = "boolean"
+		case *DurationFlag:
+			schema[f.Name] = "duration"
+		}
+	}
+	return schema
+}
+
+// checkConfigValueType reports a message when a value cannot be read as the
+// type its key expects, and an empty string when it can
+func checkConfigValueType(schema map[string]string, key string, node *yaml.Node) string {
+	expected, ok := schema[key]
+	if !ok {
+		return ""
+	}
+
+	switch expected {
+	case "string":
+		if node.Kind != yaml.ScalarNode {
+			return fmt.Sprintf("expected a string, got %s", yamlTypeName(node))
+		}
+	case "integer":
+		if node.Tag != "!!int" {
+			return fmt.Sprintf("expected an integer, got %s", yamlTypeName(node))
+		}
+	case "boolean":
+		if node.Tag != "!!bool" {
+			return fmt.Sprintf("expected a boolean, got %s", yamlTypeName(node))
+		}
+	case "duration":
+		if node.Tag == "!!int" {
+			return ""
+		}
+		if node.Kind != yaml.ScalarNode {
+			return fmt.Sprintf("expected a duration such as 30s or 5m, got %s", yamlTypeName(node))
+		}
+		if _, err := time.ParseDuration(node.Value); err != nil {
+			return fmt.Sprintf("expected a duration such as 30s or 5m, got %q", node.Value)
+		}
+	case "list of strings":
+		// a single scalar is accepted as a one-element list
+		if node.Kind != yaml.SequenceNode && node.Kind != yaml.ScalarNode {
+			return fmt.Sprintf("expected a list of strings, got %s", yamlTypeName(node))
+		}
+	case "map":
+		if node.Kind != yaml.MappingNode {
+			return fmt.Sprintf("expected a map, got %s", yamlTypeName(node))
+		}
+	}
+	return ""
+}
+
+func yamlTypeName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.MappingNode:
+		return "a map"
+	}
+	switch node.Tag {
+	case "!!str":
+		return fmt.Sprintf("the string %q", node.Value)
+	case "!!int", "!!float":
+		return fmt.Sprintf("the number %s", node.Value)
+	case "!!bool":
+		return fmt.Sprintf("the boolean %s", node.Value)
+	case "!!null":
+		return "an empty value"
+	}
+	return node.Value
+}
@@ -0,0 +1,81 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckConfigFile(t *testing.T) {
+	contents := `log:
+  level: info
+  pathh: /var/log/nginx-agent/
+api:
+  port: not-a-port
+dataplane:
+  status:
+    poll_interval: soon
+tags:
+  - staging
+`
+	path := filepath.Join(t.TempDir(), "nginx-agent.conf")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+
+	findings, err := CheckConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, findings, 3)
+
+	assert.Equal(t, "log_pathh", findings[0].Key)
+	assert.Equal(t, 3, findings[0].Line)
+	assert.Equal(t, WarningSeverity, findings[0].Severity)
+	assert.Contains(t, findings[0].Message, "unknown configuration key")
+
+	assert.Equal(t, "api_port", findings[1].Key)
+	assert.Equal(t, 5, findings[1].Line)
+	assert.Equal(t, ErrorSeverity, findings[1].Severity)
+	assert.Contains(t, findings[1].Message, "expected an integer")
+
+	assert.Equal(t, "dataplane_status_poll_interval", findings[2].Key)
+	assert.Equal(t, 8, findings[2].Line)
+	assert.Equal(t, ErrorSeverity, findings[2].Severity)
+	assert.Contains(t, findings[2].Message, "expected a duration")
+}
+
+func TestCheckConfigFileValid(t *testing.T) {
+	contents := `log:
+  level: debug
+server:
+  host: dataplane-manager
+  grpcPort: 443
+api:
+  port: 9090
+dataplane:
+  status:
+    poll_interval: 30s
+config-dirs: /etc/nginx
+`
+	path := filepath.Join(t.TempDir(), "nginx-agent.conf")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+
+	findings, err := CheckConfigFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestCheckConfigFileMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nginx-agent.conf")
+	require.NoError(t, os.WriteFile(path, []byte("log:\n level: [\n"), 0644))
+
+	_, err := CheckConfigFile(path)
+	assert.Error(t, err)
+}
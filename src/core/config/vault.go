@@ -0,0 +1,116 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/v2/src/core/vault"
+)
+
+// vaultReferenceScheme marks a config value that is resolved from Vault,
+// written as vault:<path>#<field>, e.g. vault:secret/data/nginx-agent#token
+const vaultReferenceScheme = "vault:"
+
+// IsVaultReference reports whether the config value is resolved from Vault
+func IsVaultReference(value string) bool {
+	return strings.HasPrefix(value, vaultReferenceScheme)
+}
+
+func parseVaultReference(value string) (path, field string, err error) {
+	path, field, found := strings.Cut(strings.TrimPrefix(value, vaultReferenceScheme), "#")
+	if !found || path == "" || field == "" {
+		return "", "", fmt.Errorf("invalid vault reference %q, expected vault:<path>#<field>", value)
+	}
+	return path, field, nil
+}
+
+// ResolveVaultSecrets logs in to Vault and replaces the vault references in the
+// config with the secrets they point at. The control-plane token is used
+// inline; TLS material is written next to the dynamic config, since the TLS
+// fields are file paths. Token renewal runs until the context is done.
+func ResolveVaultSecrets(ctx context.Context, cfg *Config) error {
+	client := vault.NewClient(cfg.Vault.Address, cfg.Vault.Namespace)
+
+	var err error
+	switch cfg.Vault.Auth {
+	case vault.AuthAppRole:
+		err = client.LoginAppRole(cfg.Vault.RoleID, cfg.Vault.SecretIDFile)
+	case vault.AuthKubernetes:
+		err = client.LoginKubernetes(cfg.Vault.Role, "")
+	case vault.AuthToken:
+		client.SetToken(cfg.Vault.Token)
+	default:
+		err = fmt.Errorf("unsupported vault auth method %q", cfg.Vault.Auth)
+	}
+	if err != nil {
+		return err
+	}
+
+	if IsVaultReference(cfg.Server.Token) {
+		cfg.Server.Token, err = resolveVaultValue(client, cfg.Server.Token)
+		if err != nil {
+			return err
+		}
+	}
+
+	for fileName, target := range map[string]*string{
+		"cert.pem": &cfg.TLS.Cert,
+		"key.pem":  &cfg.TLS.Key,
+		"ca.pem":   &cfg.TLS.Ca,
+	} {
+		if !IsVaultReference(*target) {
+			continue
+		}
+		material, err := resolveVaultValue(client, *target)
+		if err != nil {
+			return err
+		}
+		*target, err = writeVaultMaterial(cfg, fileName, material)
+		if err != nil {
+			return err
+		}
+	}
+
+	if client.StartRenewal(ctx) {
+		log.Debug("Vault token renewal started")
+	}
+	return nil
+}
+
+func resolveVaultValue(client *vault.Client, value string) (string, error) {
+	path, field, err := parseVaultReference(value)
+	if err != nil {
+		return "", err
+	}
+	secret, err := client.ReadSecret(path, field)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve %s: %v", value, err)
+	}
+	return secret, nil
+}
+
+// writeVaultMaterial persists fetched TLS material with owner-only permissions
+// and returns the path the config field should point at
+func writeVaultMaterial(cfg *Config, fileName, material string) (string, error) {
+	dir := filepath.Join(filepath.Dir(cfg.DynamicConfigPath), "vault")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fileName)
+	if err := os.WriteFile(path, []byte(material), 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,81 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVaultReference(t *testing.T) {
+	path, field, err := parseVaultReference("vault:secret/data/nginx-agent#token")
+	require.NoError(t, err)
+	assert.Equal(t, "secret/data/nginx-agent", path)
+	assert.Equal(t, "token", field)
+
+	for _, invalid := range []string{"vault:", "vault:secret/data/nginx-agent", "vault:#token"} {
+		_, _, err := parseVaultReference(invalid)
+		assert.Error(t, err, invalid)
+	}
+
+	assert.True(t, IsVaultReference("vault:secret/data/nginx-agent#token"))
+	assert.False(t, IsVaultReference("plaintext-token"))
+}
+
+func TestResolveVaultSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/secret/data/nginx-agent":
+			w.Write([]byte(`{"data": {"data": {"token": "control-plane-token", "cert": "-----CERT-----"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		DynamicConfigPath: filepath.Join(t.TempDir(), "agent-dynamic.conf"),
+		Server:            Server{Token: "vault:secret/data/nginx-agent#token"},
+		TLS:               TLSConfig{Cert: "vault:secret/data/nginx-agent#cert"},
+		Vault: Vault{
+			Enable:  true,
+			Address: server.URL,
+			Auth:    "token",
+			Token:   "test-token",
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, ResolveVaultSecrets(ctx, cfg))
+
+	assert.Equal(t, "control-plane-token", cfg.Server.Token)
+
+	// TLS material is written to disk and the field points at the file
+	contents, err := os.ReadFile(cfg.TLS.Cert)
+	require.NoError(t, err)
+	assert.Equal(t, "-----CERT-----", string(contents))
+
+	info, err := os.Stat(cfg.TLS.Cert)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestResolveVaultSecretsUnsupportedAuth(t *testing.T) {
+	cfg := &Config{Vault: Vault{Enable: true, Auth: "ldap"}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.Error(t, ResolveVaultSecrets(ctx, cfg))
+}
@@ -0,0 +1,260 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	sdkzip "github.com/nginx/agent/sdk/v2/zip"
+)
+
+// nginxDumpSectionRE marks the start of one configuration file inside the
+// output of nginx -T
+var nginxDumpSectionRE = regexp.MustCompile(`(?m)^# configuration file (.+):$`)
+
+// secretDirectiveRE matches config lines whose directive name suggests the
+// argument is a credential, so the argument is redacted before diffing
+var secretDirectiveRE = regexp.MustCompile(`(?im)^(\s*[\w.]*(?:password|secret|token)[\w.]*\s+)(\S.*?)(;?)$`)
+
+// ConfigDiff collects the configuration of every running NGINX master process
+// and returns a unified diff of it against a reference: the configuration the
+// running master has loaded when against is empty, or the contents of a
+// directory or zip archive an operator supplies. Credential-bearing lines and
+// private key material are redacted on both sides before diffing.
+func ConfigDiff(env Environment, binary NginxBinary, against string) (string, error) {
+	var report strings.Builder
+	instances := 0
+
+	for _, process := range env.Processes() {
+		if !process.IsMaster {
+			continue
+		}
+		detail := binary.GetNginxDetailsFromProcess(process)
+		if detail == nil || detail.GetConfPath() == "" {
+			continue
+		}
+		instances++
+
+		diff, err := diffInstance(env, binary, detail, against)
+		if err != nil {
+			return "", fmt.Errorf("unable to diff config of NGINX instance %s: %w", detail.GetNginxId(), err)
+		}
+		if diff != "" {
+			fmt.Fprintf(&report, "NGINX instance %s (%s):\n%s", detail.GetNginxId(), detail.GetConfPath(), diff)
+		}
+	}
+
+	if instances == 0 {
+		return "", ErrNoNginxInstances
+	}
+	return report.String(), nil
+}
+
+// diffInstance diffs the on-disk configuration of one instance against its
+// reference, keyed by the path relative to the conf directory
+func diffInstance(env Environment, binary NginxBinary, detail *proto.NginxDetails, against string) (string, error) {
+	confDir := filepath.Dir(detail.GetConfPath())
+
+	current, err := collectCurrentConfig(env, binary, detail, confDir)
+	if err != nil {
+		return "", err
+	}
+
+	var reference map[string]string
+	var referenceLabel string
+	if against == "" {
+		reference, err = loadedConfig(detail, confDir)
+		referenceLabel = "running"
+	} else {
+		reference, err = referenceConfig(against)
+		referenceLabel = against
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return unifiedConfigDiff(reference, current, referenceLabel, "on-disk")
+}
+
+// collectCurrentConfig collects the config payload of the instance the same way
+// a config upload does and returns its files keyed by conf-relative path
+func collectCurrentConfig(env Environment, binary NginxBinary, detail *proto.NginxDetails, confDir string) (map[string]string, error) {
+	config, err := binary.ReadConfig(detail.GetConfPath(), detail.GetNginxId(), env.GetSystemUUID())
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]string)
+	for _, payload := range []*proto.ZippedFile{config.GetZconfig(), config.GetZaux()} {
+		if payload == nil {
+			continue
+		}
+		files, err := sdkzip.UnPack(payload)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			name := file.GetName()
+			if !filepath.IsAbs(name) {
+				name = filepath.Join(payload.GetRootDirectory(), name)
+			}
+			current[relativeConfigPath(name, confDir)] = redactConfigContent(string(file.GetContents()))
+		}
+	}
+	return current, nil
+}
+
+// loadedConfig returns the configuration the running master has loaded, parsed
+// out of the configuration dump of nginx -T
+func loadedConfig(detail *proto.NginxDetails, confDir string) (map[string]string, error) {
+	if detail.GetProcessPath() == "" {
+		return nil, fmt.Errorf("process path of the running master is unknown")
+	}
+	output, err := runCmd(detail.GetProcessPath(), "-T")
+	if err != nil {
+		return nil, fmt.Errorf("unable to dump the running configuration: %v", err)
+	}
+
+	dump := output.String()
+	matches := nginxDumpSectionRE.FindAllStringSubmatchIndex(dump, -1)
+	loaded := make(map[string]string, len(matches))
+	for i, match := range matches {
+		path := dump[match[2]:match[3]]
+		start := match[1] + 1
+		end := len(dump)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		loaded[relativeConfigPath(path, confDir)] = redactConfigContent(dump[start:end])
+	}
+	return loaded, nil
+}
+
+// referenceConfig reads a reference configuration from a directory or a zip
+// archive, keyed by the path relative to its root
+func referenceConfig(against string) (map[string]string, error) {
+	info, err := os.Stat(against)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return referenceFromDirectory(against)
+	}
+	return referenceFromZip(against)
+}
+
+func referenceFromDirectory(dir string) (map[string]string, error) {
+	reference := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		reference[relative] = redactConfigContent(string(contents))
+		return nil
+	})
+	return reference, err
+}
+
+func referenceFromZip(path string) (map[string]string, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	reference := make(map[string]string, len(archive.File))
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		contents, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+		reference[filepath.Clean(file.Name)] = redactConfigContent(string(contents))
+	}
+	return reference, nil
+}
+
+// relativeConfigPath keys a config file by its path under the conf directory,
+// falling back to the absolute path for files outside it
+func relativeConfigPath(path, confDir string) string {
+	if relative, err := filepath.Rel(confDir, path); err == nil && !strings.HasPrefix(relative, "..") {
+		return relative
+	}
+	return path
+}
+
+// redactConfigContent masks credential-bearing directive arguments and replaces
+// private key material entirely, so diffs never print secrets
+func redactConfigContent(contents string) string {
+	if strings.Contains(contents, "PRIVATE KEY-----") {
+		return "# [redacted private key material]\n"
+	}
+	return secretDirectiveRE.ReplaceAllString(contents, "${1}[redacted]${3}")
+}
+
+// unifiedConfigDiff renders a unified diff per file over the union of both
+// sides, with files only present on one side diffed against nothing
+func unifiedConfigDiff(reference, current map[string]string, referenceLabel, currentLabel string) (string, error) {
+	paths := make([]string, 0, len(reference)+len(current))
+	seen := make(map[string]struct{}, len(reference)+len(current))
+	for path := range reference {
+		paths = append(paths, path)
+		seen[path] = struct{}{}
+	}
+	for path := range current {
+		if _, ok := seen[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var report strings.Builder
+	for _, path := range paths {
+		before, after := reference[path], current[path]
+		if before == after {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(before),
+			B:        difflib.SplitLines(after),
+			FromFile: fmt.Sprintf("%s %s", referenceLabel, path),
+			ToFile:   fmt.Sprintf("%s %s", currentLabel, path),
+			Context:  3,
+		})
+		if err != nil {
+			return "", err
+		}
+		report.WriteString(diff)
+	}
+	return report.String(), nil
+}
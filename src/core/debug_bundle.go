@@ -0,0 +1,195 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const (
+	// DefaultDebugLogLines is the number of trailing agent log lines included in a
+	// debug bundle when the request does not specify a count
+	DefaultDebugLogLines = 1000
+
+	// debugLogTailBytes bounds how much of the agent log is read when tailing it
+	debugLogTailBytes = 4 * 1024 * 1024
+)
+
+// debugCPUProfileDuration is how long the CPU profile in a debug bundle samples for
+var debugCPUProfileDuration = 5 * time.Second
+
+// redactedSettingKeys are setting names whose values are replaced before the agent
+// configuration is written into a debug bundle
+var redactedSettingKeys = []string{"token", "password", "secret"}
+
+// WriteDebugBundle collects a diagnostic bundle for support cases into w as a gzipped
+// tar archive. The bundle contains pprof CPU and heap profiles, a goroutine dump, the
+// agent configuration with secrets redacted, the trailing logLines lines of the agent
+// log and the full NGINX configuration (nginx -T) of every running master process.
+func WriteDebugBundle(conf *config.Config, env Environment, binary NginxBinary, logLines int, w io.Writer) error {
+	if logLines <= 0 {
+		logLines = DefaultDebugLogLines
+	}
+
+	gzWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzWriter)
+	collected := time.Now()
+
+	addFile := func(name string, contents []byte) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0640,
+			Size:    int64(len(contents)),
+			ModTime: collected,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tarWriter.Write(contents)
+		return err
+	}
+
+	var buf bytes.Buffer
+	if profile := pprof.Lookup("goroutine"); profile != nil {
+		if err := profile.WriteTo(&buf, 2); err == nil {
+			if err := addFile("goroutines.txt", buf.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+
+	buf.Reset()
+	if profile := pprof.Lookup("heap"); profile != nil {
+		if err := profile.WriteTo(&buf, 0); err == nil {
+			if err := addFile("heap.pprof", buf.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+
+	buf.Reset()
+	if err := pprof.StartCPUProfile(&buf); err == nil {
+		time.Sleep(debugCPUProfileDuration)
+		pprof.StopCPUProfile()
+		if err := addFile("cpu.pprof", buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if contents, err := yaml.Marshal(redactSettings(config.Viper.AllSettings())); err == nil {
+		if err := addFile("nginx-agent.conf", contents); err != nil {
+			return err
+		}
+	}
+
+	if contents := tailAgentLog(conf.Log.Path, logLines); len(contents) > 0 {
+		if err := addFile("agent.log", contents); err != nil {
+			return err
+		}
+	}
+
+	for _, process := range env.Processes() {
+		if !process.IsMaster {
+			continue
+		}
+		detail := binary.GetNginxDetailsFromProcess(process)
+		if detail == nil || detail.GetProcessPath() == "" {
+			continue
+		}
+		output, _ := runCmd(detail.GetProcessPath(), "-T")
+		if output == nil {
+			continue
+		}
+		if err := addFile(fmt.Sprintf("nginx-T-%s.txt", detail.GetNginxId()), output.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzWriter.Close()
+}
+
+// DebugBundleName returns the file name a debug bundle collected now is saved under
+func DebugBundleName() string {
+	return fmt.Sprintf("nginx-agent-debug-%s.tar.gz", time.Now().Format("20060102T150405"))
+}
+
+// redactSettings replaces the values of secret-bearing settings, recursing into
+// nested configuration sections
+func redactSettings(settings map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(settings))
+	for name, value := range settings {
+		if nested, ok := value.(map[string]interface{}); ok {
+			redacted[name] = redactSettings(nested)
+			continue
+		}
+		redacted[name] = value
+		for _, secretKey := range redactedSettingKeys {
+			if strings.Contains(strings.ToLower(name), secretKey) {
+				redacted[name] = "<redacted>"
+				break
+			}
+		}
+	}
+	return redacted
+}
+
+// tailAgentLog returns the trailing lines of the agent log, or nil if the log file
+// cannot be read
+func tailAgentLog(logPath string, lines int) []byte {
+	fileInfo, err := os.Stat(logPath)
+	if err != nil {
+		return nil
+	}
+	if fileInfo.IsDir() {
+		logPath = path.Join(logPath, "agent.log")
+		if fileInfo, err = os.Stat(logPath); err != nil {
+			return nil
+		}
+	}
+
+	logFile, err := os.Open(logPath)
+	if err != nil {
+		return nil
+	}
+	defer logFile.Close()
+
+	offset := int64(0)
+	if fileInfo.Size() > debugLogTailBytes {
+		offset = fileInfo.Size() - debugLogTailBytes
+	}
+	if _, err := logFile.Seek(offset, io.SeekStart); err != nil {
+		return nil
+	}
+
+	contents, err := io.ReadAll(logFile)
+	if err != nil {
+		return nil
+	}
+
+	split := strings.Split(strings.TrimRight(string(contents), "\n"), "\n")
+	if len(split) > lines {
+		split = split[len(split)-lines:]
+	}
+	return []byte(strings.Join(split, "\n") + "\n")
+}
@@ -0,0 +1,94 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+func TestWriteDebugBundle(t *testing.T) {
+	originalDuration := debugCPUProfileDuration
+	debugCPUProfileDuration = 50 * time.Millisecond
+	defer func() { debugCPUProfileDuration = originalDuration }()
+
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "agent.log")
+	require.NoError(t, os.WriteFile(logPath, []byte("line one\nline two\nline three\n"), 0640))
+
+	env := &FakeEnvironment{}
+	env.ProcessesReturns(nil)
+
+	var bundle bytes.Buffer
+	err := WriteDebugBundle(&config.Config{Log: config.LogConfig{Path: logPath}}, env, nil, 2, &bundle)
+	require.NoError(t, err)
+
+	gzReader, err := gzip.NewReader(&bundle)
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzReader)
+
+	entries := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		contents, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+		entries[header.Name] = string(contents)
+	}
+
+	assert.Contains(t, entries, "goroutines.txt")
+	assert.Contains(t, entries, "heap.pprof")
+	assert.Contains(t, entries, "cpu.pprof")
+	assert.Contains(t, entries, "nginx-agent.conf")
+	assert.Equal(t, "line two\nline three\n", entries["agent.log"])
+}
+
+func TestRedactSettings(t *testing.T) {
+	redacted := redactSettings(map[string]interface{}{
+		"log": map[string]interface{}{
+			"level": "info",
+		},
+		"api_token": "s3cret",
+		"server": map[string]interface{}{
+			"token": "s3cret",
+			"host":  "localhost",
+		},
+	})
+
+	assert.Equal(t, "<redacted>", redacted["api_token"])
+	server := redacted["server"].(map[string]interface{})
+	assert.Equal(t, "<redacted>", server["token"])
+	assert.Equal(t, "localhost", server["host"])
+	logSettings := redacted["log"].(map[string]interface{})
+	assert.Equal(t, "info", logSettings["level"])
+}
+
+func TestTailAgentLog(t *testing.T) {
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "agent.log")
+	require.NoError(t, os.WriteFile(logPath, []byte("one\ntwo\nthree\n"), 0640))
+
+	// a directory resolves to the default agent.log within it
+	assert.Equal(t, []byte("two\nthree\n"), tailAgentLog(tmpDir, 2))
+	assert.Equal(t, []byte("one\ntwo\nthree\n"), tailAgentLog(logPath, 10))
+	assert.Nil(t, tailAgentLog(filepath.Join(tmpDir, "missing.log"), 10))
+}
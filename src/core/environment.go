@@ -19,7 +19,7 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
-	"syscall"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/klauspost/cpuid/v2"
@@ -29,6 +29,7 @@ import (
 	"github.com/shirou/gopsutil/v3/process"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/nginx/agent/sdk/v2"
 	"github.com/nginx/agent/sdk/v2/files"
 	"github.com/nginx/agent/sdk/v2/proto"
 	"github.com/nginx/agent/v2/src/core/network"
@@ -57,8 +58,36 @@ type ConfigApplyMarker interface {
 	RemoveFromNotExists(string)
 }
 
+var (
+	configApplyFsyncDirsMutex sync.RWMutex
+	configApplyFsyncDirs      bool
+)
+
+// SetConfigApplyFsyncDirs toggles syncing each directory a config apply wrote
+// into once all of its files are written, instead of not syncing at all
+func SetConfigApplyFsyncDirs(enabled bool) {
+	configApplyFsyncDirsMutex.Lock()
+	defer configApplyFsyncDirsMutex.Unlock()
+	configApplyFsyncDirs = enabled
+}
+
+func configApplyFsyncDirsEnabled() bool {
+	configApplyFsyncDirsMutex.RLock()
+	defer configApplyFsyncDirsMutex.RUnlock()
+	return configApplyFsyncDirs
+}
+
 type EnvironmentType struct {
 	host *proto.HostInfo
+	// nginxBinaryPaths are extra directories whose executables are treated as
+	// nginx, for binaries built from source under custom prefixes
+	nginxBinaryPaths []string
+}
+
+// SetNginxBinarySearchPaths configures extra directories in which running
+// executables are considered nginx instances regardless of their name
+func (env *EnvironmentType) SetNginxBinarySearchPaths(paths []string) {
+	env.nginxBinaryPaths = paths
 }
 
 type Process struct {
@@ -104,6 +133,10 @@ func (env *EnvironmentType) NewHostInfo(agentVersion string, tags *[]string, con
 			Release:             releaseInfo(),
 			Tags:                *tags,
 			AgentAccessibleDirs: configDirs,
+			Kubernetes:          GetKubernetesMetadata(),
+			Cloud:               getCloudMetadata(),
+			Virtualization:      env.virtualization(hostInformation),
+			Sysctls:             getSysctls(),
 		}
 
 		log.Tracef("HostInfo created: %v", hostInfoFacacde)
@@ -128,7 +161,7 @@ func (env *EnvironmentType) GetSystemUUID() string {
 			log.Errorf("Unable to read docker container ID: %v", err)
 			return ""
 		}
-		return uuid.NewMD5(uuid.NameSpaceDNS, []byte(containerID)).String()
+		return fingerprintUUID(uuid.NameSpaceDNS, []byte(containerID))
 	}
 
 	hostInfo, err := host.Info()
@@ -136,7 +169,7 @@ func (env *EnvironmentType) GetSystemUUID() string {
 		log.Infof("Unable to read host id from dataplane, defaulting value. Error: %v", err)
 		return ""
 	}
-	return uuid.NewMD5(uuid.Nil, []byte(hostInfo.HostID)).String()
+	return fingerprintUUID(uuid.Nil, []byte(hostInfo.HostID))
 }
 
 func (env *EnvironmentType) ReadDirectory(dir string, ext string) ([]string, error) {
@@ -154,17 +187,64 @@ func (env *EnvironmentType) ReadDirectory(dir string, ext string) ([]string, err
 	return files, nil
 }
 
+// WriteFiles writes the files of a config payload to disk. The directories the
+// files live in are created in one batched pass first, so a payload with
+// thousands of files in the same directory costs one MkdirAll instead of a stat
+// per file, and when directory syncing is enabled each affected directory is
+// fsynced once at the end instead of after every file.
 func (env *EnvironmentType) WriteFiles(backup ConfigApplyMarker, files []*proto.File, confPath string, allowedDirs map[string]struct{}) error {
 	err := allowedFiles(files, allowedDirs)
 	if err != nil {
 		return err
 	}
 
+	directories := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		directories[filepath.Dir(fullFilePath(file, confPath))] = struct{}{}
+	}
+	if err = createDirectories(directories); err != nil {
+		return err
+	}
+
 	for _, file := range files {
 		if err = writeFile(backup, file, confPath); err != nil {
 			return err
 		}
 	}
+
+	if configApplyFsyncDirsEnabled() {
+		return syncDirectories(directories)
+	}
+	return nil
+}
+
+// createDirectories creates every directory the payload writes into, once each
+func createDirectories(directories map[string]struct{}) error {
+	for directory := range directories {
+		if _, err := os.Stat(directory); os.IsNotExist(err) {
+			log.Debugf("Creating directory %s with permissions 755", directory)
+			if err := os.MkdirAll(directory, 0755); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// syncDirectories fsyncs each directory the payload wrote into, making the new
+// directory entries durable without paying for an fsync per file
+func syncDirectories(directories map[string]struct{}) error {
+	for directory := range directories {
+		handle, err := os.Open(directory)
+		if err != nil {
+			return err
+		}
+		err = handle.Sync()
+		handle.Close()
+		if err != nil {
+			return fmt.Errorf("could not sync directory %s: %v", directory, err)
+		}
+	}
 	return nil
 }
 
@@ -293,6 +373,8 @@ func (env *EnvironmentType) DiskDevices() ([]string, error) {
 	switch runtime.GOOS {
 	case "freebsd":
 		return env.getFreeBSDDiskDevices()
+	case "openbsd":
+		return env.getOpenBSDDiskDevices()
 	case "darwin":
 		return []string{}, errors.New("darwin architecture is not supported")
 	default:
@@ -356,6 +438,33 @@ func (env *EnvironmentType) getFreeBSDDiskDevices() ([]string, error) {
 	return devices, nil
 }
 
+// getOpenBSDDiskDevices lists the disks named by the hw.disknames sysctl, whose
+// value looks like "sd0:1a2b3c4d,cd0:,fd0:"
+func (env *EnvironmentType) getOpenBSDDiskDevices() ([]string, error) {
+	devices := []string{}
+
+	sysctlBin, secErr := env.checkUtil("sysctl")
+	if secErr != nil {
+		return devices, secErr
+	}
+
+	outbuf, err := runCmd(sysctlBin, "-n", "hw.disknames")
+	if err != nil {
+		return devices, errors.New("unable to obtain disk list")
+	}
+
+	for _, entry := range strings.Split(strings.TrimSpace(outbuf.String()), ",") {
+		name := strings.SplitN(entry, ":", 2)[0]
+		// skip the floppy and cd devices, which have no io statistics worth collecting
+		if name == "" || strings.HasPrefix(name, "fd") || strings.HasPrefix(name, "cd") {
+			continue
+		}
+		devices = append(devices, name)
+	}
+
+	return devices, nil
+}
+
 func allowedFiles(files []*proto.File, allowedDirs map[string]struct{}) error {
 	for _, file := range files {
 		path := file.GetName()
@@ -372,7 +481,7 @@ func allowedFile(path string, allowedDirs map[string]struct{}) bool {
 		return true
 	}
 	for dir := range allowedDirs {
-		if strings.HasPrefix(path, dir) {
+		if sdk.PathWithinDirectory(path, dir) {
 			return true
 		}
 	}
@@ -381,28 +490,35 @@ func allowedFile(path string, allowedDirs map[string]struct{}) bool {
 
 // writeFile writes the provided file content to disk. If the file.GetName() returns an absolute path, it'll be written
 // to the path. Otherwise, it'll be written to the path relative to the provided confPath.
-func writeFile(backup ConfigApplyMarker, file *proto.File, confPath string) error {
+// fullFilePath resolves the on-disk path of a payload file, which is relative
+// to the conf path unless it is already absolute
+func fullFilePath(file *proto.File, confPath string) string {
 	fileFullPath := file.GetName()
 	if !filepath.IsAbs(fileFullPath) {
 		fileFullPath = filepath.Join(confPath, fileFullPath)
 	}
+	return fileFullPath
+}
+
+// writeFile writes one payload file, expecting its directory to exist already.
+// The file is created with its permissions and truncated in a single open
+// rather than written and chmodded separately.
+func writeFile(backup ConfigApplyMarker, file *proto.File, confPath string) error {
+	fileFullPath := fullFilePath(file, confPath)
 
 	if err := backup.MarkAndSave(fileFullPath); err != nil {
 		return err
 	}
 	permissions := files.GetFileMode(file.GetPermissions())
 
-	directory := filepath.Dir(fileFullPath)
-	_, err := os.Stat(directory)
-	if os.IsNotExist(err) {
-		log.Debugf("Creating directory %s with permissions 755", directory)
-		err = os.MkdirAll(directory, 0755)
-		if err != nil {
-			return err
+	handle, err := os.OpenFile(fileFullPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, permissions)
+	if err == nil {
+		_, err = handle.Write(file.GetContents())
+		if closeErr := handle.Close(); err == nil {
+			err = closeErr
 		}
 	}
-
-	if err := ioutil.WriteFile(fileFullPath, file.GetContents(), permissions); err != nil {
+	if err != nil {
 		// If the file didn't exist originally and failed to be created
 		// Then remove that file from the backup so that the rollback doesn't try to delete the file
 		if _, err := os.Stat(fileFullPath); !errors.Is(err, os.ErrNotExist) {
@@ -421,6 +537,22 @@ func (env *EnvironmentType) FileStat(path string) (os.FileInfo, error) {
 }
 
 // Processes returns a slice of nginx master and nginx worker processes currently running
+// isNginxProcessName reports whether the process name belongs to an nginx
+// worker or master; on Windows the binary runs as nginx.exe
+func isNginxProcessName(name string) bool {
+	return name == "nginx" || strings.EqualFold(name, "nginx.exe")
+}
+
+// underAnyPath reports whether the executable lives in one of the directories
+func underAnyPath(exe string, paths []string) bool {
+	for _, path := range paths {
+		if strings.HasPrefix(exe, strings.TrimSuffix(path, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 func (env *EnvironmentType) Processes() (result []Process) {
 	var processList []Process
 
@@ -436,8 +568,22 @@ func (env *EnvironmentType) Processes() (result []Process) {
 
 		name, _ := p.Name()
 
-		if name == "nginx" {
+		if isNginxProcessName(name) {
 			nginxProcesses[pid] = p
+			continue
+		}
+
+		// renamed or custom built binaries still set the nginx process title
+		cmd, _ := p.Cmdline()
+		if strings.HasPrefix(cmd, "nginx:") {
+			nginxProcesses[pid] = p
+			continue
+		}
+
+		if len(env.nginxBinaryPaths) > 0 {
+			if exe, err := p.Exe(); err == nil && underAnyPath(exe, env.nginxBinaryPaths) {
+				nginxProcesses[pid] = p
+			}
 		}
 	}
 
@@ -598,11 +744,8 @@ func (env *EnvironmentType) checkUtil(util string) (string, error) {
 		return "", err
 	}
 
-	stat, ok := info.Sys().(*syscall.Stat_t)
-	if !ok {
-		return "", fmt.Errorf("unable to determine binary ownership: %s", path)
-	} else if stat.Uid != 0 {
-		return "", fmt.Errorf("binary is not root owned: %s", path)
+	if err := checkBinaryOwnership(info, path); err != nil {
+		return "", err
 	}
 
 	if info.Mode()&(os.ModeSetgid|os.ModeSetuid) != 0 {
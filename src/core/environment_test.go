@@ -8,8 +8,10 @@
 package core
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/nginx/agent/sdk/v2"
@@ -669,3 +671,83 @@ func TestCGroupV1Check(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteFilesFsyncDirs(t *testing.T) {
+	dir := t.TempDir()
+	files := []*proto.File{
+		{
+			Name:        filepath.Join(dir, "nested", "synced.conf"),
+			Contents:    []byte("contents"),
+			Permissions: "0644",
+		},
+	}
+
+	backup, err := sdk.NewConfigApply("", nil)
+	assert.NoError(t, err)
+
+	SetConfigApplyFsyncDirs(true)
+	defer SetConfigApplyFsyncDirs(false)
+
+	env := EnvironmentType{}
+	assert.NoError(t, env.WriteFiles(backup, files, dir, map[string]struct{}{dir: {}}))
+
+	contents, err := os.ReadFile(files[0].GetName())
+	assert.NoError(t, err)
+	assert.Equal(t, files[0].GetContents(), contents)
+}
+
+// benchmarkWriteFilesPayload fabricates a payload of small files spread across
+// a handful of directories, the shape a large config apply has
+func benchmarkWriteFilesPayload(b *testing.B, dir string, fileCount int) []*proto.File {
+	b.Helper()
+	files := make([]*proto.File, 0, fileCount)
+	for i := 0; i < fileCount; i++ {
+		files = append(files, &proto.File{
+			Name:        filepath.Join(dir, fmt.Sprintf("conf.d-%d", i%4), fmt.Sprintf("server-%04d.conf", i)),
+			Contents:    []byte("server { listen 80; }"),
+			Permissions: "0644",
+		})
+	}
+	return files
+}
+
+func BenchmarkWriteFiles(b *testing.B) {
+	dir := b.TempDir()
+	files := benchmarkWriteFilesPayload(b, dir, 500)
+	allowedDirs := map[string]struct{}{dir: {}}
+	env := EnvironmentType{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		backup, err := sdk.NewConfigApply("", nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := env.WriteFiles(backup, files, dir, allowedDirs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWriteFilesFsyncDirs(b *testing.B) {
+	dir := b.TempDir()
+	files := benchmarkWriteFilesPayload(b, dir, 500)
+	allowedDirs := map[string]struct{}{dir: {}}
+	env := EnvironmentType{}
+
+	SetConfigApplyFsyncDirs(true)
+	defer SetConfigApplyFsyncDirs(false)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		backup, err := sdk.NewConfigApply("", nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := env.WriteFiles(backup, files, dir, allowedDirs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+//go:build !windows
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkBinaryOwnership verifies the binary at path is owned by root before the
+// agent executes it
+func checkBinaryOwnership(info os.FileInfo, path string) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fmt.Errorf("unable to determine binary ownership: %s", path)
+	} else if stat.Uid != 0 {
+		return fmt.Errorf("binary is not root owned: %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"crypto/sha256"
+
+	"github.com/google/uuid"
+)
+
+var fipsModeEnabled bool
+
+// EnableFIPSMode restricts the hash algorithms used for fingerprinting to FIPS
+// approved primitives. It is expected to be called once at startup, before any
+// system identifiers are generated.
+func EnableFIPSMode() {
+	fipsModeEnabled = true
+}
+
+// FIPSModeEnabled reports whether FIPS mode has been enabled for this process.
+func FIPSModeEnabled() bool {
+	return fipsModeEnabled
+}
+
+// fingerprintUUID derives a deterministic UUID from the given namespace and data.
+// By default this is an RFC 4122 version 3 (MD5) UUID to keep system identifiers
+// stable for existing installs. In FIPS mode the digest is SHA-256 based instead,
+// as MD5 is not an approved algorithm.
+func fingerprintUUID(space uuid.UUID, data []byte) string {
+	if !fipsModeEnabled {
+		return uuid.NewMD5(space, data).String()
+	}
+
+	h := sha256.New()
+	h.Write(space[:])
+	h.Write(data)
+
+	var id uuid.UUID
+	copy(id[:], h.Sum(nil))
+	id[6] = (id[6] & 0x0f) | 0x80
+	id[8] = (id[8] & 0x3f) | 0x80
+	return id.String()
+}
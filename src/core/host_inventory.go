@@ -0,0 +1,178 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+var (
+	// dmiSysVendorPath names the system vendor, which identifies the cloud
+	// provider without touching the network
+	dmiSysVendorPath = "/sys/class/dmi/id/sys_vendor"
+	// imdsEndpoint is the link-local instance metadata service address shared
+	// by the major cloud providers
+	imdsEndpoint = "http://169.254.169.254"
+	procSysDir   = "/proc/sys"
+	imdsTimeout  = 1 * time.Second
+)
+
+// nginxRelevantSysctls are the kernel tunables that bound nginx connection and
+// file descriptor capacity
+var nginxRelevantSysctls = []string{
+	"net.core.somaxconn",
+	"fs.file-max",
+	"net.ipv4.ip_local_port_range",
+}
+
+// virtualization returns what the host runs under: "container" in a container,
+// the hypervisor reported by the kernel in a guest, or empty on bare metal
+func (env *EnvironmentType) virtualization(hostInformation *host.InfoStat) string {
+	if env.IsContainer() {
+		return "container"
+	}
+	if hostInformation.VirtualizationRole == "guest" {
+		return hostInformation.VirtualizationSystem
+	}
+	return ""
+}
+
+// getSysctls reads the nginx relevant kernel tunables from /proc/sys
+func getSysctls() map[string]string {
+	sysctls := make(map[string]string)
+	for _, name := range nginxRelevantSysctls {
+		path := filepath.Join(procSysDir, strings.ReplaceAll(name, ".", "/"))
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		sysctls[name] = strings.Join(strings.Fields(string(contents)), " ")
+	}
+	return sysctls
+}
+
+// getCloudMetadata identifies the cloud provider from the DMI system vendor and
+// queries its instance metadata service. It returns nil on unknown vendors, so
+// hosts outside the clouds never wait on the link-local address.
+func getCloudMetadata() *proto.CloudMetadata {
+	contents, err := os.ReadFile(dmiSysVendorPath)
+	if err != nil {
+		return nil
+	}
+
+	vendor := strings.ToLower(strings.TrimSpace(string(contents)))
+	switch {
+	case strings.Contains(vendor, "amazon"):
+		return awsMetadata()
+	case strings.Contains(vendor, "google"):
+		return gcpMetadata()
+	case strings.Contains(vendor, "microsoft"):
+		return azureMetadata()
+	}
+	return nil
+}
+
+// imdsRequest performs a single request against the instance metadata service
+// with a short timeout, so an unreachable service cannot stall host info collection
+func imdsRequest(method, path string, headers map[string]string) (string, error) {
+	request, err := http.NewRequest(method, imdsEndpoint+path, nil)
+	if err != nil {
+		return "", err
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	client := &http.Client{Timeout: imdsTimeout}
+	response, err := client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("instance metadata service returned %s for %s", response.Status, path)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func awsMetadata() *proto.CloudMetadata {
+	headers := map[string]string{}
+	// IMDSv2 requires a session token; fall back to v1 when the PUT fails
+	if token, err := imdsRequest(http.MethodPut, "/latest/api/token", map[string]string{
+		"X-aws-ec2-metadata-token-ttl-seconds": "60",
+	}); err == nil {
+		headers["X-aws-ec2-metadata-token"] = token
+	}
+
+	metadata := &proto.CloudMetadata{Provider: "aws"}
+	metadata.InstanceType, _ = imdsRequest(http.MethodGet, "/latest/meta-data/instance-type", headers)
+	metadata.InstanceId, _ = imdsRequest(http.MethodGet, "/latest/meta-data/instance-id", headers)
+	metadata.Region, _ = imdsRequest(http.MethodGet, "/latest/meta-data/placement/region", headers)
+	return metadata
+}
+
+func gcpMetadata() *proto.CloudMetadata {
+	headers := map[string]string{"Metadata-Flavor": "Google"}
+
+	metadata := &proto.CloudMetadata{Provider: "gcp"}
+	// machine type and zone are returned as full resource paths
+	if machineType, err := imdsRequest(http.MethodGet, "/computeMetadata/v1/instance/machine-type", headers); err == nil {
+		metadata.InstanceType = lastPathSegment(machineType)
+	}
+	metadata.InstanceId, _ = imdsRequest(http.MethodGet, "/computeMetadata/v1/instance/id", headers)
+	if zone, err := imdsRequest(http.MethodGet, "/computeMetadata/v1/instance/zone", headers); err == nil {
+		metadata.Region = lastPathSegment(zone)
+	}
+	return metadata
+}
+
+func azureMetadata() *proto.CloudMetadata {
+	body, err := imdsRequest(http.MethodGet, "/metadata/instance/compute?api-version=2021-02-01", map[string]string{
+		"Metadata": "true",
+	})
+	metadata := &proto.CloudMetadata{Provider: "azure"}
+	if err != nil {
+		return metadata
+	}
+
+	var compute struct {
+		VMSize   string `json:"vmSize"`
+		VMID     string `json:"vmId"`
+		Location string `json:"location"`
+	}
+	if err := json.Unmarshal([]byte(body), &compute); err != nil {
+		log.Debugf("Unable to parse azure instance metadata: %v", err)
+		return metadata
+	}
+	metadata.InstanceType = compute.VMSize
+	metadata.InstanceId = compute.VMID
+	metadata.Region = compute.Location
+	return metadata
+}
+
+func lastPathSegment(path string) string {
+	segments := strings.Split(path, "/")
+	return segments[len(segments)-1]
+}
@@ -0,0 +1,89 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSysctls(t *testing.T) {
+	sysDir := t.TempDir()
+	originalProcSysDir := procSysDir
+	defer func() { procSysDir = originalProcSysDir }()
+	procSysDir = sysDir
+
+	require.NoError(t, os.MkdirAll(filepath.Join(sysDir, "net", "core"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(sysDir, "net", "ipv4"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(sysDir, "net", "core", "somaxconn"), []byte("4096\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(sysDir, "net", "ipv4", "ip_local_port_range"), []byte("32768\t60999\n"), 0644))
+
+	sysctls := getSysctls()
+
+	assert.Equal(t, map[string]string{
+		"net.core.somaxconn":           "4096",
+		"net.ipv4.ip_local_port_range": "32768 60999",
+	}, sysctls)
+}
+
+func TestGetCloudMetadataAWS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest/api/token":
+			if r.Method != http.MethodPut {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Write([]byte("test-token"))
+		case "/latest/meta-data/instance-type":
+			w.Write([]byte("c5.large"))
+		case "/latest/meta-data/instance-id":
+			w.Write([]byte("i-0abcd1234"))
+		case "/latest/meta-data/placement/region":
+			w.Write([]byte("eu-west-1"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	vendorFile := filepath.Join(t.TempDir(), "sys_vendor")
+	require.NoError(t, os.WriteFile(vendorFile, []byte("Amazon EC2\n"), 0644))
+
+	originalVendorPath, originalEndpoint := dmiSysVendorPath, imdsEndpoint
+	defer func() {
+		dmiSysVendorPath, imdsEndpoint = originalVendorPath, originalEndpoint
+	}()
+	dmiSysVendorPath = vendorFile
+	imdsEndpoint = server.URL
+
+	metadata := getCloudMetadata()
+
+	require.NotNil(t, metadata)
+	assert.Equal(t, "aws", metadata.Provider)
+	assert.Equal(t, "c5.large", metadata.InstanceType)
+	assert.Equal(t, "i-0abcd1234", metadata.InstanceId)
+	assert.Equal(t, "eu-west-1", metadata.Region)
+}
+
+func TestGetCloudMetadataUnknownVendor(t *testing.T) {
+	vendorFile := filepath.Join(t.TempDir(), "sys_vendor")
+	require.NoError(t, os.WriteFile(vendorFile, []byte("QEMU\n"), 0644))
+
+	originalVendorPath := dmiSysVendorPath
+	defer func() { dmiSysVendorPath = originalVendorPath }()
+	dmiSysVendorPath = vendorFile
+
+	assert.Nil(t, getCloudMetadata())
+}
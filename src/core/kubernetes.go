@@ -0,0 +1,88 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+var (
+	// kubernetesServiceAccountDir is mounted into every pod by the kubelet
+	kubernetesServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	// kubernetesPodInfoDir is the conventional mount point of a downward API
+	// volume exposing the pod labels
+	kubernetesPodInfoDir = "/etc/podinfo"
+)
+
+// IsKubernetes reports whether the agent is running inside a Kubernetes pod
+func IsKubernetes() bool {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return true
+	}
+	if _, err := os.Stat(kubernetesServiceAccountDir); err == nil {
+		return true
+	}
+	return false
+}
+
+// GetKubernetesMetadata describes the pod the agent runs in, read from the
+// downward API: the POD_NAME, POD_NAMESPACE and NODE_NAME environment variables
+// injected via fieldRef, the service account namespace file, and the labels file
+// of a downward API volume mounted at /etc/podinfo. It returns nil outside of
+// Kubernetes.
+func GetKubernetesMetadata() *proto.KubernetesMetadata {
+	if !IsKubernetes() {
+		return nil
+	}
+
+	metadata := &proto.KubernetesMetadata{
+		PodName:   os.Getenv("POD_NAME"),
+		Namespace: os.Getenv("POD_NAMESPACE"),
+		NodeName:  os.Getenv("NODE_NAME"),
+	}
+
+	if metadata.PodName == "" {
+		metadata.PodName = os.Getenv("HOSTNAME")
+	}
+	if metadata.Namespace == "" {
+		if contents, err := os.ReadFile(filepath.Join(kubernetesServiceAccountDir, "namespace")); err == nil {
+			metadata.Namespace = strings.TrimSpace(string(contents))
+		}
+	}
+	if contents, err := os.ReadFile(filepath.Join(kubernetesPodInfoDir, "labels")); err == nil {
+		metadata.Labels = parseDownwardAPILabels(string(contents))
+	}
+
+	return metadata
+}
+
+// parseDownwardAPILabels parses the key="value" lines a downward API volume
+// writes for the pod labels
+func parseDownwardAPILabels(contents string) map[string]string {
+	labels := make(map[string]string)
+	for _, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		labels[key] = value
+	}
+	return labels
+}
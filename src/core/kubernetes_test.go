@@ -0,0 +1,70 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDownwardAPILabels(t *testing.T) {
+	labels := parseDownwardAPILabels(`app="nginx"
+app.kubernetes.io/component="proxy"
+
+malformed line
+tier=backend`)
+
+	assert.Equal(t, map[string]string{
+		"app":                         "nginx",
+		"app.kubernetes.io/component": "proxy",
+		"tier":                        "backend",
+	}, labels)
+}
+
+func TestGetKubernetesMetadata(t *testing.T) {
+	serviceAccountDir := t.TempDir()
+	podInfoDir := t.TempDir()
+
+	originalServiceAccountDir := kubernetesServiceAccountDir
+	originalPodInfoDir := kubernetesPodInfoDir
+	defer func() {
+		kubernetesServiceAccountDir = originalServiceAccountDir
+		kubernetesPodInfoDir = originalPodInfoDir
+	}()
+	kubernetesServiceAccountDir = serviceAccountDir
+	kubernetesPodInfoDir = podInfoDir
+
+	require.NoError(t, os.WriteFile(filepath.Join(serviceAccountDir, "namespace"), []byte("ingress\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(podInfoDir, "labels"), []byte("app=\"nginx\"\n"), 0644))
+	t.Setenv("POD_NAME", "nginx-agent-abc123")
+	t.Setenv("POD_NAMESPACE", "")
+	t.Setenv("NODE_NAME", "worker-1")
+
+	metadata := GetKubernetesMetadata()
+
+	require.NotNil(t, metadata)
+	assert.Equal(t, "nginx-agent-abc123", metadata.PodName)
+	assert.Equal(t, "ingress", metadata.Namespace)
+	assert.Equal(t, "worker-1", metadata.NodeName)
+	assert.Equal(t, map[string]string{"app": "nginx"}, metadata.Labels)
+}
+
+func TestGetKubernetesMetadataOutsideKubernetes(t *testing.T) {
+	originalServiceAccountDir := kubernetesServiceAccountDir
+	defer func() {
+		kubernetesServiceAccountDir = originalServiceAccountDir
+	}()
+	kubernetesServiceAccountDir = filepath.Join(t.TempDir(), "missing")
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	assert.Nil(t, GetKubernetesMetadata())
+}
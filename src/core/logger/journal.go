@@ -0,0 +1,113 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// journalSocket is where journald accepts datagrams in its native protocol
+var journalSocket = "/run/systemd/journal/socket"
+
+// EnableJournal forwards every log entry to the systemd journal with structured
+// fields, in addition to the configured outputs
+func EnableJournal() error {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journalSocket, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("could not connect to the journal: %v", err)
+	}
+	log.AddHook(&journalHook{conn: conn})
+	return nil
+}
+
+// journalHook ships entries to journald using its native protocol, mapping the
+// message, level and the structured logrus fields to journal fields
+type journalHook struct {
+	conn *net.UnixConn
+}
+
+func (h *journalHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h *journalHook) Fire(entry *log.Entry) error {
+	_, err := h.conn.Write(marshalJournalEntry(entry))
+	return err
+}
+
+// marshalJournalEntry serializes the entry into the journald field format:
+// simple fields are KEY=value lines, values containing a newline use the
+// length-prefixed binary encoding
+func marshalJournalEntry(entry *log.Entry) []byte {
+	var payload bytes.Buffer
+	appendJournalField(&payload, "MESSAGE", entry.Message)
+	appendJournalField(&payload, "PRIORITY", journalPriority(entry.Level))
+	appendJournalField(&payload, "SYSLOG_IDENTIFIER", "nginx-agent")
+	for key, value := range entry.Data {
+		appendJournalField(&payload, journalFieldName(key), fmt.Sprintf("%v", value))
+	}
+	return payload.Bytes()
+}
+
+func appendJournalField(payload *bytes.Buffer, key, value string) {
+	if strings.Contains(value, "\n") {
+		payload.WriteString(key)
+		payload.WriteByte('\n')
+		length := make([]byte, 8)
+		binary.LittleEndian.PutUint64(length, uint64(len(value)))
+		payload.Write(length)
+		payload.WriteString(value)
+		payload.WriteByte('\n')
+		return
+	}
+	payload.WriteString(key)
+	payload.WriteByte('=')
+	payload.WriteString(value)
+	payload.WriteByte('\n')
+}
+
+// journalFieldName converts a logrus field name into a valid journal field
+// name, which may only contain uppercase letters, digits and underscores
+func journalFieldName(name string) string {
+	field := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - ('a' - 'A')
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	if field == "" || (field[0] >= '0' && field[0] <= '9') {
+		field = "X" + field
+	}
+	return field
+}
+
+// journalPriority maps a logrus level to a syslog priority understood by journald
+func journalPriority(level log.Level) string {
+	switch level {
+	case log.PanicLevel, log.FatalLevel:
+		return "2"
+	case log.ErrorLevel:
+		return "3"
+	case log.WarnLevel:
+		return "4"
+	case log.InfoLevel:
+		return "6"
+	default:
+		return "7"
+	}
+}
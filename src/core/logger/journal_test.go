@@ -0,0 +1,52 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package logger
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJournalEntry(t *testing.T) {
+	entry := &log.Entry{
+		Message: "config applied",
+		Level:   log.WarnLevel,
+		Data: log.Fields{
+			"nginx-id": "abc123",
+		},
+	}
+
+	payload := string(marshalJournalEntry(entry))
+
+	assert.Contains(t, payload, "MESSAGE=config applied\n")
+	assert.Contains(t, payload, "PRIORITY=4\n")
+	assert.Contains(t, payload, "SYSLOG_IDENTIFIER=nginx-agent\n")
+	assert.Contains(t, payload, "NGINX_ID=abc123\n")
+}
+
+func TestMarshalJournalEntryMultilineValue(t *testing.T) {
+	entry := &log.Entry{
+		Message: "line one\nline two",
+		Level:   log.InfoLevel,
+	}
+
+	payload := marshalJournalEntry(entry)
+
+	// multi-line values use the length-prefixed encoding: KEY \n le64(len) value \n
+	expected := append([]byte("MESSAGE\n"), []byte{17, 0, 0, 0, 0, 0, 0, 0}...)
+	expected = append(expected, []byte("line one\nline two\n")...)
+	assert.Equal(t, string(expected), string(payload[:len(expected)]))
+}
+
+func TestJournalFieldName(t *testing.T) {
+	assert.Equal(t, "NGINX_ID", journalFieldName("nginx-id"))
+	assert.Equal(t, "LEVEL2", journalFieldName("level2"))
+	assert.Equal(t, "X2LEVEL", journalFieldName("2level"))
+}
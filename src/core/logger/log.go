@@ -18,6 +18,9 @@ import (
 const (
 	defaultLogDir  = "/var/log/nginx-agent"
 	defaultLogFile = "agent.log"
+
+	logFormatPlain = "plain"
+	logFormatJSON  = "json"
 )
 
 // SetLogLevel -
@@ -34,8 +37,63 @@ func SetLogLevel(level string) {
 	log.Warnf("Log level is %s", l)
 }
 
+// SetLogFormat configures the formatter log messages are rendered with
+func SetLogFormat(format string) {
+	switch format {
+	case logFormatJSON:
+		log.SetFormatter(&log.JSONFormatter{})
+	case logFormatPlain, "":
+		log.SetFormatter(&log.TextFormatter{})
+	default:
+		log.Warnf("Unknown log format %q, defaulting to plain text", format)
+		log.SetFormatter(&log.TextFormatter{})
+	}
+}
+
 // SetLogFile returns a file descriptor for the log file that must be handled by the caller
 func SetLogFile(logFile string) *os.File {
+	logPath := resolveLogPath(logFile)
+	if logPath == "" {
+		return nil
+	}
+
+	logFileHandle, err := os.OpenFile(logPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+	if err != nil {
+		log.Errorf("Failed to set log file, proceeding to log only to stdout/stderr: %v", err)
+		return nil
+	}
+	log.SetOutput(io.MultiWriter(os.Stdout, logFileHandle))
+	return logFileHandle
+}
+
+// SetRotatingLogFile is SetLogFile with built-in size based rotation: once the log file
+// exceeds maxSizeMB megabytes it is rotated, keeping at most maxBackups rotated files
+// for at most maxAgeDays days. A maxSizeMB of zero disables rotation.
+func SetRotatingLogFile(logFile string, maxSizeMB, maxBackups, maxAgeDays int) io.Closer {
+	if maxSizeMB <= 0 {
+		if logFileHandle := SetLogFile(logFile); logFileHandle != nil {
+			return logFileHandle
+		}
+		return nil
+	}
+
+	logPath := resolveLogPath(logFile)
+	if logPath == "" {
+		return nil
+	}
+
+	writer, err := newRotatingWriter(logPath, maxSizeMB, maxBackups, maxAgeDays)
+	if err != nil {
+		log.Errorf("Failed to set log file, proceeding to log only to stdout/stderr: %v", err)
+		return nil
+	}
+	log.SetOutput(io.MultiWriter(os.Stdout, writer))
+	return writer
+}
+
+// resolveLogPath returns the log file the configured path points at, or an empty
+// string if the path cannot be used
+func resolveLogPath(logFile string) string {
 	logPath := logFile
 	if logFile == "" {
 		logPath = path.Join(defaultLogDir, defaultLogFile)
@@ -44,7 +102,7 @@ func SetLogFile(logFile string) *os.File {
 	fileInfo, err := os.Stat(logPath)
 	if err != nil {
 		log.Errorf("error reading log directory %v", err)
-		return nil
+		return ""
 	}
 
 	if fileInfo.IsDir() {
@@ -52,11 +110,5 @@ func SetLogFile(logFile string) *os.File {
 		logPath = path.Join(logPath, defaultLogFile)
 	}
 
-	logFileHandle, err := os.OpenFile(logPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
-	if err != nil {
-		log.Errorf("Failed to set log file, proceeding to log only to stdout/stderr: %v", err)
-		return nil
-	}
-	log.SetOutput(io.MultiWriter(os.Stdout, logFileHandle))
-	return logFileHandle
+	return logPath
 }
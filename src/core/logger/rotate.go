@@ -0,0 +1,129 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// rotatedTimestampFormat is the suffix appended to rotated log files, chosen so the
+// lexical order of rotated file names matches their age
+const rotatedTimestampFormat = "20060102T150405.000"
+
+// rotatingWriter is an io.WriteCloser that rotates the underlying log file once it
+// exceeds maxSize bytes, keeping a bounded number of timestamped backups
+type rotatingWriter struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	maxAge     time.Duration
+	file       *os.File
+	size       int64
+}
+
+// NewRotatingWriter returns an io.WriteCloser that appends to path and
+// rotates the file once it exceeds maxSizeMB megabytes, keeping up to
+// maxBackups timestamped backups for at most maxAgeDays days
+func NewRotatingWriter(path string, maxSizeMB, maxBackups, maxAgeDays int) (io.WriteCloser, error) {
+	return newRotatingWriter(path, maxSizeMB, maxBackups, maxAgeDays)
+}
+
+func newRotatingWriter(path string, maxSizeMB, maxBackups, maxAgeDays int) (*rotatingWriter, error) {
+	writer := &rotatingWriter{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxBackups: maxBackups,
+		maxAge:     time.Duration(maxAgeDays) * 24 * time.Hour,
+	}
+	if err := writer.open(); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *rotatingWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+
+	size := int64(0)
+	if fileInfo, err := file.Stat(); err == nil {
+		size = fileInfo.Size()
+	}
+
+	w.file = file
+	w.size = size
+	return nil
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
+// rotate moves the current log file aside under a timestamped name, prunes old
+// backups and starts a fresh log file
+func (w *rotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	rotatedPath := fmt.Sprintf("%s.%s", w.path, time.Now().Format(rotatedTimestampFormat))
+	if err := os.Rename(w.path, rotatedPath); err != nil {
+		return err
+	}
+
+	w.prune()
+	return w.open()
+}
+
+// prune deletes rotated log files beyond the backup count or older than the maximum age
+func (w *rotatingWriter) prune() {
+	backups, err := filepath.Glob(w.path + ".*")
+	if err != nil {
+		return
+	}
+	// newest first, as the timestamp suffixes sort lexically by age
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+
+	for i, backup := range backups {
+		if w.maxBackups > 0 && i >= w.maxBackups {
+			os.Remove(backup)
+			continue
+		}
+		if w.maxAge > 0 {
+			if fileInfo, err := os.Stat(backup); err == nil && time.Since(fileInfo.ModTime()) > w.maxAge {
+				os.Remove(backup)
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingWriterRotates(t *testing.T) {
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "agent.log")
+
+	writer, err := newRotatingWriter(logPath, 1, 2, 0)
+	require.NoError(t, err)
+	defer writer.Close()
+	// shrink the threshold so a couple of writes trigger rotation
+	writer.maxSize = 32
+
+	for i := 0; i < 4; i++ {
+		_, err := writer.Write([]byte(strings.Repeat("x", 20) + "\n"))
+		require.NoError(t, err)
+		// keep rotated timestamp suffixes distinct
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	backups, err := filepath.Glob(logPath + ".*")
+	require.NoError(t, err)
+	assert.NotEmpty(t, backups)
+	assert.LessOrEqual(t, len(backups), 2)
+
+	contents, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(contents), 32)
+}
+
+func TestRotatingWriterPrunesOldBackups(t *testing.T) {
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "agent.log")
+
+	oldBackup := logPath + "." + time.Now().Add(-48*time.Hour).Format(rotatedTimestampFormat)
+	require.NoError(t, os.WriteFile(oldBackup, []byte("old"), 0640))
+	require.NoError(t, os.Chtimes(oldBackup, time.Now().Add(-48*time.Hour), time.Now().Add(-48*time.Hour)))
+
+	writer, err := newRotatingWriter(logPath, 1, 5, 1)
+	require.NoError(t, err)
+	defer writer.Close()
+
+	writer.prune()
+
+	_, err = os.Stat(oldBackup)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,39 @@
+//go:build !windows
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package logger
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// WatchLogLevelSignals raises the log level to debug when the agent receives SIGUSR1 and
+// restores the configured level on SIGUSR2, so verbosity can be changed without a restart
+func WatchLogLevelSignals(ctx context.Context, configuredLevel string) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGUSR1, syscall.SIGUSR2)
+	go func() {
+		for {
+			select {
+			case sig := <-sigChan:
+				if sig == syscall.SIGUSR1 {
+					SetLogLevel("debug")
+				} else {
+					SetLogLevel(configuredLevel)
+				}
+			case <-ctx.Done():
+				signal.Stop(sigChan)
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,17 @@
+//go:build windows
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package logger
+
+import "context"
+
+// WatchLogLevelSignals is a no-op on Windows, which has no SIGUSR1/SIGUSR2;
+// the log level can only be changed through the configuration there.
+func WatchLogLevelSignals(ctx context.Context, configuredLevel string) {
+}
@@ -8,9 +8,9 @@
 package metrics
 
 import (
-	"encoding/json"
-	"fmt"
+	"bytes"
 	"regexp"
+	"sync"
 
 	"github.com/nginx/agent/sdk/v2/checksum"
 	"github.com/nginx/agent/sdk/v2/proto"
@@ -27,14 +27,38 @@ type Collections struct {
 	Data  map[string]PerDimension
 }
 
+// checksumBufferPool holds the scratch buffers dimChecksum serialises dimension
+// sets into, so aggregating a report does not allocate per stats entity.
+var checksumBufferPool = sync.Pool{
+	New: func() interface{} {
+		return &bytes.Buffer{}
+	},
+}
+
+// variableMetrics maps the metric names that carry a variable component, such as
+// a slab slot size, onto their aggregation method.
+var variableMetrics = map[*regexp.Regexp]MetricsHandler{
+	regexp.MustCompile(`slab.slots.*.fails`): sum,
+	regexp.MustCompile(`slab.slots.*.free`):  avg,
+	regexp.MustCompile(`slab.slots.*.reqs`):  sum,
+	regexp.MustCompile(`slab.slots.*.used`):  avg,
+}
+
 func dimChecksum(stats *proto.StatsEntity) string {
-	dims := stats.GetDimensions()
-	data, err := json.Marshal(dims)
-	if err == nil {
-		return checksum.HexChecksum(data)
+	buffer := checksumBufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		buffer.Reset()
+		checksumBufferPool.Put(buffer)
+	}()
+
+	for _, dimension := range stats.GetDimensions() {
+		buffer.WriteString(dimension.GetName())
+		buffer.WriteByte('=')
+		buffer.WriteString(dimension.GetValue())
+		buffer.WriteByte(';')
 	}
 
-	return checksum.HexChecksum([]byte(fmt.Sprintf("%#v", dims)))
+	return checksum.HexChecksum(buffer.Bytes())
 }
 
 // SaveCollections loops through one or more reports and get all the raw metrics for the Collections
@@ -69,8 +93,12 @@ func GenerateMetricsReport(metricsCollections Collections) *proto.MetricsReport
 
 	results := make([]*proto.StatsEntity, 0, 200)
 
+	// the calculation map is sizeable, so build it once per report rather than
+	// once per dimension set
+	calMap := GetCalculationMap()
+
 	for _, metricsPerDimension := range metricsCollections.Data {
-		simpleMetrics := getAggregatedSimpleMetric(metricsCollections.Count, metricsPerDimension.RunningSumMap)
+		simpleMetrics := getAggregatedSimpleMetric(metricsCollections.Count, metricsPerDimension.RunningSumMap, calMap)
 		results = append(results, NewStatsEntity(
 			metricsPerDimension.Dimensions,
 			simpleMetrics,
@@ -84,16 +112,7 @@ func GenerateMetricsReport(metricsCollections Collections) *proto.MetricsReport
 	}
 }
 
-func getAggregatedSimpleMetric(count int, internalMap map[string]float64) (simpleMetrics []*proto.SimpleMetric) {
-
-	variableMetrics := map[*regexp.Regexp]MetricsHandler{
-		regexp.MustCompile(`slab.slots.*.fails`): sum,
-		regexp.MustCompile(`slab.slots.*.free`):  avg,
-		regexp.MustCompile(`slab.slots.*.reqs`):  sum,
-		regexp.MustCompile(`slab.slots.*.used`):  avg,
-	}
-
-	calMap := GetCalculationMap()
+func getAggregatedSimpleMetric(count int, internalMap map[string]float64, calMap map[string]string) (simpleMetrics []*proto.SimpleMetric) {
 
 	for name, value := range internalMap {
 		if valueType, ok := calMap[name]; ok {
@@ -8,7 +8,6 @@
 package metrics
 
 import (
-	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -96,13 +95,7 @@ func TestSaveCollections(t *testing.T) {
 			Value: "test-host",
 		},
 	}
-	var dimensionsChecksum string
-	data, err := json.Marshal(dimension1)
-	if err == nil {
-		dimensionsChecksum = checksum.HexChecksum(data)
-	} else {
-		dimensionsChecksum = checksum.HexChecksum([]byte(fmt.Sprintf("%v", dimension1)))
-	}
+	dimensionsChecksum := dimChecksum(&proto.StatsEntity{Dimensions: dimension1})
 	metricsCollections.Data[dimensionsChecksum] = PerDimension{
 		Dimensions:    dimension1,
 		RunningSumMap: make(map[string]float64),
@@ -169,6 +162,53 @@ func TestGenerateAggregationReport(t *testing.T) {
 	}
 }
 
+func BenchmarkDimChecksum(b *testing.B) {
+	stats := &proto.StatsEntity{
+		Dimensions: []*proto.Dimension{
+			{Name: "hostname", Value: "test-host"},
+			{Name: "system_id", Value: "12345678-1234-1234-1234-123456789012"},
+			{Name: "nginx_id", Value: "abcdef0123456789"},
+		},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		dimChecksum(stats)
+	}
+}
+
+func BenchmarkSaveCollections(b *testing.B) {
+	report := &proto.MetricsReport{
+		Meta: &proto.Metadata{},
+		Type: proto.MetricsReport_SYSTEM,
+		Data: []*proto.StatsEntity{
+			{
+				Simplemetrics: []*proto.SimpleMetric{
+					{Name: "system.mem.used", Value: 5},
+					{Name: "system.io.kbs_w", Value: 5.3},
+					{Name: "system.cpu.system", Value: 2.4},
+					{Name: "system.cpu.user", Value: 6.8},
+				},
+				Dimensions: []*proto.Dimension{
+					{Name: "hostname", Value: "test-host"},
+				},
+			},
+		},
+	}
+
+	metricsCollections := Collections{
+		Count: 0,
+		Data:  make(map[string]PerDimension),
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		metricsCollections = SaveCollections(metricsCollections, report)
+	}
+}
+
 func TestAvg(t *testing.T) {
 	result := avg(float64(2.12), 2)
 	assert.Equal(t, float64(1.06), result)
@@ -0,0 +1,85 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package metrics
+
+import (
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+const (
+	// AgentNaming is the default naming scheme, the agent's own metric names
+	AgentNaming = "agent"
+	// AmplifyNaming renames metrics and dimensions to what Amplify dashboards
+	// use, so users migrating from Amplify can keep their dashboards
+	AmplifyNaming = "amplify"
+)
+
+// amplifyMetricNames maps the agent's metric names to Amplify's for the handful
+// that drifted apart. An empty value drops the metric, for agent metrics that
+// never existed in Amplify and would otherwise show up as unknown series.
+var amplifyMetricNames = map[string]string{
+	"nginx.workers.count":    "nginx.workers",
+	"nginx.workers.restarts": "",
+	"nginx.status":           "",
+	"nginx.instance.count":   "",
+}
+
+// amplifyDimensionNames maps the agent's dimension names to the ones Amplify
+// filters on: hosts are identified by their uuid and aliased for display.
+var amplifyDimensionNames = map[string]string{
+	"system_id":    "uuid",
+	"display_name": "alias",
+}
+
+// TranslateReportToAmplify returns a copy of the report with Amplify's metric
+// and dimension names. The input is left untouched, as the same report instance
+// is shared between reporters that may use different naming schemes.
+func TranslateReportToAmplify(report *proto.MetricsReport) *proto.MetricsReport {
+	translated := &proto.MetricsReport{
+		Meta: report.GetMeta(),
+		Type: report.GetType(),
+		Data: make([]*proto.StatsEntity, 0, len(report.GetData())),
+	}
+
+	for _, stats := range report.GetData() {
+		entity := &proto.StatsEntity{
+			Timestamp:     stats.GetTimestamp(),
+			Dimensions:    make([]*proto.Dimension, 0, len(stats.GetDimensions())),
+			Simplemetrics: make([]*proto.SimpleMetric, 0, len(stats.GetSimplemetrics())),
+		}
+
+		for _, dimension := range stats.GetDimensions() {
+			name := dimension.GetName()
+			if amplifyName, ok := amplifyDimensionNames[name]; ok {
+				name = amplifyName
+			}
+			entity.Dimensions = append(entity.Dimensions, &proto.Dimension{
+				Name:  name,
+				Value: dimension.GetValue(),
+			})
+		}
+
+		for _, metric := range stats.GetSimplemetrics() {
+			name := metric.GetName()
+			if amplifyName, ok := amplifyMetricNames[name]; ok {
+				if amplifyName == "" {
+					continue
+				}
+				name = amplifyName
+			}
+			entity.Simplemetrics = append(entity.Simplemetrics, &proto.SimpleMetric{
+				Name:  name,
+				Value: metric.GetValue(),
+			})
+		}
+
+		translated.Data = append(translated.Data, entity)
+	}
+
+	return translated
+}
@@ -0,0 +1,63 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateReportToAmplify(t *testing.T) {
+	report := &proto.MetricsReport{
+		Type: proto.MetricsReport_INSTANCE,
+		Data: []*proto.StatsEntity{
+			{
+				Dimensions: []*proto.Dimension{
+					{Name: "system_id", Value: "my-system-id"},
+					{Name: "hostname", Value: "my-host"},
+					{Name: "display_name", Value: "my-alias"},
+				},
+				Simplemetrics: []*proto.SimpleMetric{
+					{Name: "nginx.workers.count", Value: 4},
+					{Name: "nginx.http.conn.active", Value: 10},
+					{Name: "nginx.status", Value: 1},
+				},
+			},
+		},
+	}
+
+	translated := TranslateReportToAmplify(report)
+
+	require.Len(t, translated.Data, 1)
+	dimensions := map[string]string{}
+	for _, dimension := range translated.Data[0].Dimensions {
+		dimensions[dimension.Name] = dimension.Value
+	}
+	assert.Equal(t, "my-system-id", dimensions["uuid"])
+	assert.Equal(t, "my-alias", dimensions["alias"])
+	assert.Equal(t, "my-host", dimensions["hostname"])
+	assert.NotContains(t, dimensions, "system_id")
+
+	names := map[string]float64{}
+	for _, metric := range translated.Data[0].Simplemetrics {
+		names[metric.Name] = metric.Value
+	}
+	// drifted names are mapped, unchanged names pass through, agent-only
+	// metrics without an Amplify equivalent are dropped
+	assert.Equal(t, float64(4), names["nginx.workers"])
+	assert.Equal(t, float64(10), names["nginx.http.conn.active"])
+	assert.NotContains(t, names, "nginx.workers.count")
+	assert.NotContains(t, names, "nginx.status")
+
+	// the original report is shared with other reporters and stays untouched
+	assert.Equal(t, "nginx.workers.count", report.Data[0].Simplemetrics[0].Name)
+	assert.Equal(t, "system_id", report.Data[0].Dimensions[0].Name)
+}
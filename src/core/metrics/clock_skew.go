@@ -0,0 +1,81 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+// ClockSkewThreshold is the offset between the host clock and the control
+// plane clock below which skew is ignored. Observations include one-way
+// network latency, so the threshold is generous enough not to flag a slow
+// link as a skewed clock.
+const ClockSkewThreshold = 2 * time.Second
+
+// clockSkewDimension annotates the entities of a report that was produced
+// while the host clock was known to be skewed.
+const clockSkewDimension = "clock_skew"
+
+// ApplyClockSkew returns a copy of the report annotated with a clock_skew
+// dimension carrying the observed offset. When correct is set the report and
+// entity timestamps are also shifted onto the control plane clock. The input
+// is left untouched, as the same report instance is shared between reporters.
+func ApplyClockSkew(report *proto.MetricsReport, offset time.Duration, correct bool) *proto.MetricsReport {
+	applied := &proto.MetricsReport{
+		Meta: report.GetMeta(),
+		Type: report.GetType(),
+		Data: make([]*proto.StatsEntity, 0, len(report.GetData())),
+	}
+
+	if correct && report.GetMeta() != nil {
+		meta := *report.GetMeta()
+		meta.Timestamp = shiftTimestamp(meta.GetTimestamp(), offset)
+		applied.Meta = &meta
+	}
+
+	for _, stats := range report.GetData() {
+		entity := &proto.StatsEntity{
+			Timestamp:     stats.GetTimestamp(),
+			Dimensions:    make([]*proto.Dimension, 0, len(stats.GetDimensions())+1),
+			Simplemetrics: stats.GetSimplemetrics(),
+		}
+		if correct {
+			entity.Timestamp = shiftTimestamp(stats.GetTimestamp(), offset)
+		}
+
+		entity.Dimensions = append(entity.Dimensions, stats.GetDimensions()...)
+		entity.Dimensions = append(entity.Dimensions, &proto.Dimension{
+			Name:  clockSkewDimension,
+			Value: offset.String(),
+		})
+
+		applied.Data = append(applied.Data, entity)
+	}
+
+	return applied
+}
+
+// shiftTimestamp returns the timestamp moved by offset. A timestamp that does
+// not convert is returned as is rather than dropped.
+func shiftTimestamp(timestamp *types.Timestamp, offset time.Duration) *types.Timestamp {
+	if timestamp == nil {
+		return nil
+	}
+	converted, err := types.TimestampFromProto(timestamp)
+	if err != nil {
+		return timestamp
+	}
+	shifted, err := types.TimestampProto(converted.Add(offset))
+	if err != nil {
+		return timestamp
+	}
+	return shifted
+}
@@ -0,0 +1,73 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyClockSkewAnnotates(t *testing.T) {
+	timestamp := types.TimestampNow()
+	report := &proto.MetricsReport{
+		Meta: &proto.Metadata{Timestamp: timestamp},
+		Type: proto.MetricsReport_SYSTEM,
+		Data: []*proto.StatsEntity{
+			{
+				Timestamp:     timestamp,
+				Dimensions:    []*proto.Dimension{{Name: "hostname", Value: "test-host"}},
+				Simplemetrics: []*proto.SimpleMetric{{Name: "system.cpu.user", Value: 4.5}},
+			},
+		},
+	}
+
+	applied := ApplyClockSkew(report, 5*time.Second, false)
+
+	require.Len(t, applied.Data, 1)
+	dimensions := applied.Data[0].GetDimensions()
+	require.Len(t, dimensions, 2)
+	assert.Equal(t, "clock_skew", dimensions[1].GetName())
+	assert.Equal(t, "5s", dimensions[1].GetValue())
+
+	// without correction the timestamps are untouched
+	assert.Equal(t, timestamp, applied.GetMeta().GetTimestamp())
+	assert.Equal(t, timestamp, applied.Data[0].GetTimestamp())
+
+	// the input report is shared between reporters and must not change
+	assert.Len(t, report.Data[0].GetDimensions(), 1)
+}
+
+func TestApplyClockSkewCorrects(t *testing.T) {
+	now := time.Now()
+	timestamp, err := types.TimestampProto(now)
+	require.NoError(t, err)
+	report := &proto.MetricsReport{
+		Meta: &proto.Metadata{Timestamp: timestamp},
+		Data: []*proto.StatsEntity{{Timestamp: timestamp}},
+	}
+
+	applied := ApplyClockSkew(report, -3*time.Second, true)
+
+	corrected, err := types.TimestampFromProto(applied.GetMeta().GetTimestamp())
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-3*time.Second).Unix(), corrected.Unix())
+
+	corrected, err = types.TimestampFromProto(applied.Data[0].GetTimestamp())
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-3*time.Second).Unix(), corrected.Unix())
+
+	// the input report keeps its original timestamps
+	original, err := types.TimestampFromProto(report.GetMeta().GetTimestamp())
+	require.NoError(t, err)
+	assert.Equal(t, now.Unix(), original.Unix())
+}
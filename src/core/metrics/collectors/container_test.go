@@ -45,7 +45,7 @@ func TestNewContainerCollector(t *testing.T) {
 
 	assert.Equal(t, len(expectedSourceTypes), len(containerCollector.sources))
 	assert.Equal(t, expectedSourceTypes, sourceTypes)
-	assert.Equal(t, expectedDimensions, containerCollector.dim)
+	assert.Equal(t, expectedDimensions.ToDimensions(), containerCollector.dim.ToDimensions())
 }
 
 func TestContainerCollector_Collect(t *testing.T) {
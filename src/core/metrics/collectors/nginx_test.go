@@ -150,7 +150,8 @@ func TestNewNginxCollector(t *testing.T) {
 			assert.Equal(t, len(tc.expectedSourceTypes), len(nginxCollector.sources))
 			assert.Equal(t, tc.expectedCollectorConfig, nginxCollector.collectorConf)
 			assert.Equal(t, tc.expectedSourceTypes, sourceTypes)
-			assert.Equal(t, tc.expectedDimensions, nginxCollector.dimensions)
+			assert.Equal(t, tc.expectedDimensions.ToDimensions(), nginxCollector.dimensions.ToDimensions())
+			assert.Equal(t, tc.expectedDimensions.NginxAccessLogPaths, nginxCollector.dimensions.NginxAccessLogPaths)
 		})
 	}
 }
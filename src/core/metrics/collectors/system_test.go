@@ -75,7 +75,7 @@ func TestNewSystemCollector(t *testing.T) {
 
 			assert.Equal(t, len(tc.expectedSourceTypes), len(systemCollector.sources))
 			assert.Equal(t, tc.expectedSourceTypes, sourceTypes)
-			assert.Equal(t, tc.expectedDimensions, systemCollector.dim)
+			assert.Equal(t, tc.expectedDimensions.ToDimensions(), systemCollector.dim.ToDimensions())
 		})
 	}
 }
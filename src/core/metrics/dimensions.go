@@ -8,6 +8,7 @@
 package metrics
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/nginx/agent/sdk/v2/proto"
@@ -22,6 +23,13 @@ const (
 	displayNameKey   = "display_name"
 	nginxIDKey       = "nginx_id"
 	instanceGroupKey = "instance_group"
+	podNameKey       = "pod_name"
+	podNamespaceKey  = "pod_namespace"
+	podNodeKey       = "pod_node"
+
+	// labelDimensionPrefix namespaces operator defined labels so they can
+	// never collide with the built-in dimensions
+	labelDimensionPrefix = "label."
 )
 
 // CommonDim is the set of dimensions that apply to all metrics
@@ -32,7 +40,18 @@ type CommonDim struct {
 	PublishedAPI, NginxType, NginxBuild, NginxVersion,
 	NginxBinPath, NginxConfPath string
 
+	PodName, PodNamespace, PodNode string
+
+	// Labels are the operator defined static labels of the config, attached to
+	// every metric for fleet partitioning
+	Labels map[string]string
+
 	NginxAccessLogPaths []string
+
+	// dimensions is built once on construction, since none of the fields it is
+	// derived from change for the lifetime of a CommonDim, and reused by every
+	// ToDimensions call to keep the 1-second collection loops allocation free
+	dimensions []*proto.Dimension
 }
 
 func NewCommonDim(hostInfo *proto.HostInfo, conf *config.Config, nginxId string) *CommonDim {
@@ -48,17 +67,36 @@ func NewCommonDim(hostInfo *proto.HostInfo, conf *config.Config, nginxId string)
 		InstanceGroup: conf.InstanceGroup,
 		DisplayName:   conf.DisplayName,
 		NginxId:       nginxId,
+		Labels:        conf.Labels,
+	}
+
+	if kubernetes := hostInfo.GetKubernetes(); kubernetes != nil {
+		commonDim.PodName = kubernetes.PodName
+		commonDim.PodNamespace = kubernetes.Namespace
+		commonDim.PodNode = kubernetes.NodeName
 	}
 
-	log.Debugf("Common Metric Dimensions: %v", commonDim.ToDimensions())
+	commonDim.dimensions = commonDim.buildDimensions()
+
+	log.Debugf("Common Metric Dimensions: %v", commonDim.dimensions)
 
 	return commonDim
 }
 
 // ToDimensions returns the set of common agent dimensions
-// Ensures dimensions are generated in the same order every time, as required by control plane
+// Ensures dimensions are generated in the same order every time, as required by control plane.
+// The returned slice is shared between calls and is at full capacity, so callers
+// appending their own dimensions always get a fresh backing array.
 func (c *CommonDim) ToDimensions() []*proto.Dimension {
-	return []*proto.Dimension{
+	dimensions := c.dimensions
+	if dimensions == nil {
+		dimensions = c.buildDimensions()
+	}
+	return dimensions[:len(dimensions):len(dimensions)]
+}
+
+func (c *CommonDim) buildDimensions() []*proto.Dimension {
+	dimensions := []*proto.Dimension{
 		{
 			Name:  systemIDKey,
 			Value: c.SystemId,
@@ -84,4 +122,48 @@ func (c *CommonDim) ToDimensions() []*proto.Dimension {
 			Value: c.NginxId,
 		},
 	}
+
+	// pod dimensions only exist on Kubernetes and are constant for the lifetime
+	// of the agent, so appending them keeps the order stable
+	if c.PodName != "" {
+		dimensions = append(dimensions,
+			&proto.Dimension{
+				Name:  podNameKey,
+				Value: c.PodName,
+			},
+			&proto.Dimension{
+				Name:  podNamespaceKey,
+				Value: c.PodNamespace,
+			},
+			&proto.Dimension{
+				Name:  podNodeKey,
+				Value: c.PodNode,
+			},
+		)
+	}
+
+	return append(dimensions, LabelDimensions(c.Labels)...)
+}
+
+// LabelDimensions converts the static labels of the config into dimensions,
+// sorted by key so the dimension order is stable between reports as the
+// control plane requires.
+func LabelDimensions(labels map[string]string) []*proto.Dimension {
+	if len(labels) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	dimensions := make([]*proto.Dimension, 0, len(keys))
+	for _, key := range keys {
+		dimensions = append(dimensions, &proto.Dimension{
+			Name:  labelDimensionPrefix + key,
+			Value: labels[key],
+		})
+	}
+	return dimensions
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/shirou/gopsutil/host"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/nginx/agent/sdk/v2/proto"
 	"github.com/nginx/agent/v2/src/core/config"
@@ -146,3 +147,54 @@ func TestCommonDim_ToDimensions(t *testing.T) {
 		})
 	}
 }
+
+func TestCommonDim_ToDimensionsLabels(t *testing.T) {
+	conf := &config.Config{
+		Labels: map[string]string{
+			"team":        "platform",
+			"environment": "production",
+		},
+	}
+	dims := NewCommonDim(&proto.HostInfo{Hostname: "test-host"}, conf, "").ToDimensions()
+
+	// labels come after the built-in dimensions, prefixed and sorted by key
+	require.True(t, len(dims) >= 2)
+	assert.Equal(t, &proto.Dimension{Name: "label.environment", Value: "production"}, dims[len(dims)-2])
+	assert.Equal(t, &proto.Dimension{Name: "label.team", Value: "platform"}, dims[len(dims)-1])
+}
+
+func TestCommonDim_ToDimensionsAppendSafe(t *testing.T) {
+	h, _ := host.Info()
+	hostInfo := &proto.HostInfo{
+		Hostname:    h.Hostname,
+		DisplayName: h.Hostname,
+		Uuid:        h.HostID,
+	}
+	commonDim := NewCommonDim(hostInfo, &config.Config{}, "")
+
+	// the shared slice is returned at full capacity, so a caller appending its
+	// own dimensions must not leak them into subsequent calls
+	first := append(commonDim.ToDimensions(), &proto.Dimension{Name: "zone", Value: "one"})
+	second := commonDim.ToDimensions()
+
+	assert.Len(t, first, len(second)+1)
+	for _, dimension := range second {
+		assert.NotEqual(t, "zone", dimension.Name)
+	}
+}
+
+func BenchmarkToDimensions(b *testing.B) {
+	h, _ := host.Info()
+	hostInfo := &proto.HostInfo{
+		Hostname:    h.Hostname,
+		DisplayName: h.Hostname,
+		Uuid:        h.HostID,
+	}
+	commonDim := NewCommonDim(hostInfo, &config.Config{}, "12345")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		commonDim.ToDimensions()
+	}
+}
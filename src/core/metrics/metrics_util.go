@@ -14,12 +14,22 @@ import (
 	"sync"
 	"time"
 
+	"github.com/nginx/agent/sdk/v2"
 	"github.com/nginx/agent/sdk/v2/proto"
 	"github.com/nginx/agent/v2/src/core/config"
 
 	"github.com/gogo/protobuf/types"
 )
 
+// clock is the time source for collected samples; tests and replay tooling
+// swap it with SetClock
+var clock sdk.Clock = sdk.NewRealClock()
+
+// SetClock replaces the time source stats entities are stamped with
+func SetClock(c sdk.Clock) {
+	clock = c
+}
+
 type Collector interface {
 	Collect(ctx context.Context, wg *sync.WaitGroup, m chan<- *proto.StatsEntity)
 	UpdateConfig(config *config.Config)
@@ -45,8 +55,12 @@ type NginxCollectorConfig struct {
 }
 
 func NewStatsEntity(dims []*proto.Dimension, samples []*proto.SimpleMetric) *proto.StatsEntity {
+	timestamp, err := types.TimestampProto(clock.Now())
+	if err != nil {
+		timestamp = types.TimestampNow()
+	}
 	return &proto.StatsEntity{
-		Timestamp:     types.TimestampNow(),
+		Timestamp:     timestamp,
 		Dimensions:    dims,
 		Simplemetrics: samples,
 	}
@@ -138,6 +152,8 @@ func GetCalculationMap() map[string]string {
 		"system.net.packets_out.error":                       "sum",
 		"nginx.status":                                       "boolean",
 		"nginx.config.generation":                            "sum",
+		"nginx.http.availability.ratio":                      "avg",
+		"nginx.http.availability.error_streak":               "avg",
 		"nginx.http.gzip.ratio":                              "avg",
 		"nginx.http.status.1xx":                              "sum",
 		"nginx.http.status.2xx":                              "sum",
@@ -170,6 +186,8 @@ func GetCalculationMap() map[string]string {
 		"nginx.http.request.count":                           "sum",
 		"nginx.http.request.current":                         "avg",
 		"nginx.http.request.buffered":                        "sum",
+		"nginx.http.limit_conns.rejected":                    "sum",
+		"nginx.http.limit_reqs.rejected":                     "sum",
 		"nginx.http.v0_9":                                    "sum",
 		"nginx.http.v1_0":                                    "sum",
 		"nginx.http.v1_1":                                    "sum",
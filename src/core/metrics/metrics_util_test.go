@@ -0,0 +1,28 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nginx/agent/sdk/v2"
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStatsEntityUsesClock(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(sdk.NewFakeClock(now))
+	defer SetClock(sdk.NewRealClock())
+
+	entity := NewStatsEntity([]*proto.Dimension{}, []*proto.SimpleMetric{})
+
+	assert.Equal(t, now.Unix(), entity.GetTimestamp().GetSeconds())
+	assert.Equal(t, int32(0), entity.GetTimestamp().GetNanos())
+}
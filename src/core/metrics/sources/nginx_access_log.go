@@ -10,6 +10,7 @@ package sources
 import (
 	"context"
 	"fmt"
+	"net"
 	"regexp"
 	"sort"
 	"strconv"
@@ -64,7 +65,10 @@ func NewNginxAccessLog(
 		[]*proto.StatsEntity{},
 	}
 
-	logs := binary.GetAccessLogs()
+	logs, unreadable := core.FilterReadableLogs(binary.GetAccessLogs())
+	for _, logFile := range unreadable {
+		log.Warnf("Access log %s is not readable with the agent's privileges, disabling its tailer", logFile)
+	}
 
 	for logFile, logFormat := range logs {
 		log.Infof("Adding access log tailer: %s", logFile)
@@ -98,7 +102,10 @@ func (c *NginxAccessLog) Update(dimensions *metrics.CommonDim, collectorConf *me
 			delete(c.logFormats, f)
 		}
 
-		logs := c.binary.GetAccessLogs()
+		logs, unreadable := core.FilterReadableLogs(c.binary.GetAccessLogs())
+		for _, logFile := range unreadable {
+			log.Warnf("Access log %s is not readable with the agent's privileges, disabling its tailer", logFile)
+		}
 
 		for logFile, logFormat := range logs {
 			if _, ok := c.logs[logFile]; !ok {
@@ -124,7 +131,10 @@ func (c *NginxAccessLog) Stop() {
 func (c *NginxAccessLog) collectLogStats(ctx context.Context, m chan<- *proto.StatsEntity) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	logs := c.binary.GetAccessLogs()
+	logs, unreadable := core.FilterReadableLogs(c.binary.GetAccessLogs())
+	for _, logFile := range unreadable {
+		log.Debugf("Access log %s is not readable with the agent's privileges, its tailer stays disabled", logFile)
+	}
 
 	if c.binary.UpdateLogs(c.logFormats, logs) {
 		log.Info("Access logs updated")
@@ -153,17 +163,43 @@ func (c *NginxAccessLog) collectLogStats(ctx context.Context, m chan<- *proto.St
 	c.buf = []*proto.StatsEntity{}
 }
 
+// accessLogTailer is the part of the tailer implementations that logStats consumes
+type accessLogTailer interface {
+	Tail(ctx context.Context, data chan<- map[string]string)
+}
+
 func (c *NginxAccessLog) logStats(ctx context.Context, logFile, logFormat string) {
-	logPattern := convertLogFormat(logFormat)
 	log.Debugf("Collecting from: %s using format: %s", logFile, logFormat)
-	log.Debugf("Pattern used for tailing logs: %s", logPattern)
 
 	httpCounters, upstreamCounters := getDefaultCounters()
 	gzipRatios, requestLengths, requestTimes, upstreamResponseLength, upstreamResponseTimes, upstreamConnectTimes, upstreamHeaderTimes := []float64{}, []float64{}, []float64{}, []float64{}, []float64{}, []float64{}, []float64{}
 
+	// errorStreak is the current run of consecutive 5xx responses, kept across
+	// collection intervals so an ongoing outage is visible in every interval
+	errorStreak := float64(0)
+
 	mu := sync.Mutex{}
 
-	t, err := tailer.NewPatternTailer(logFile, map[string]string{"DEFAULT": logPattern})
+	var t accessLogTailer
+	var err error
+	if strings.HasPrefix(logFile, "syslog:") {
+		address := syslogServerAddress(logFile)
+		if address == "" {
+			log.Warnf("unable to listen for %q: only local syslog servers can be listened for", logFile)
+			return
+		}
+		var patterns map[string]string
+		if !isJSONLogFormat(logFormat) {
+			patterns = map[string]string{"DEFAULT": convertLogFormat(logFormat)}
+		}
+		t, err = tailer.NewSyslogTailer(address, patterns)
+	} else if isJSONLogFormat(logFormat) {
+		t, err = tailer.NewJSONTailer(logFile)
+	} else {
+		logPattern := convertLogFormat(logFormat)
+		log.Debugf("Pattern used for tailing logs: %s", logPattern)
+		t, err = tailer.NewPatternTailer(logFile, map[string]string{"DEFAULT": logPattern})
+	}
 	if err != nil {
 		log.Errorf("unable to tail %q: %v", logFile, err)
 		return
@@ -299,6 +335,14 @@ func (c *NginxAccessLog) logStats(ctx context.Context, logFile, logFormat string
 				if v, err := strconv.Atoi(access.Status); err == nil {
 					n := fmt.Sprintf("status.%dxx", v/100)
 					httpCounters[n] = httpCounters[n] + 1
+					if v >= 500 && v < 600 {
+						errorStreak++
+						if errorStreak > httpCounters["availability.error_streak"] {
+							httpCounters["availability.error_streak"] = errorStreak
+						}
+					} else {
+						errorStreak = 0
+					}
 					if v == 403 || v == 404 || v == 500 || v == 502 || v == 503 || v == 504 {
 						n := fmt.Sprintf("status.%d", v)
 						httpCounters[n] = httpCounters[n] + 1
@@ -350,6 +394,13 @@ func (c *NginxAccessLog) logStats(ctx context.Context, logFile, logFormat string
 			if len(upstreamResponseLength) > 0 {
 				upstreamCounters["upstream.response.length"] = getAverageMetricValue(upstreamResponseLength)
 			}
+
+			totalRequests := httpCounters["status.1xx"] + httpCounters["status.2xx"] + httpCounters["status.3xx"] +
+				httpCounters["status.4xx"] + httpCounters["status.5xx"]
+			if totalRequests > 0 {
+				httpCounters["availability.ratio"] = (totalRequests - httpCounters["status.5xx"]) / totalRequests * 100
+			}
+
 			c.group = "http"
 			simpleMetrics := c.convertSamplesToSimpleMetrics(httpCounters)
 
@@ -454,6 +505,42 @@ func getTimeMetricsMap(metricName string, times []float64, counter map[string]fl
 
 }
 
+// syslogServerAddress extracts the server parameter of an access_log
+// syslog:server=... destination as an address the agent can listen on: a unix
+// socket path, or a local host:port with the syslog default port filled in.
+// It returns an empty string when the destination is not local, since lines
+// sent to another host never arrive here.
+func syslogServerAddress(logName string) string {
+	for _, param := range strings.Split(strings.TrimPrefix(logName, "syslog:"), ",") {
+		if !strings.HasPrefix(param, "server=") {
+			continue
+		}
+		server := strings.TrimPrefix(param, "server=")
+		if strings.HasPrefix(server, "unix:") {
+			return server
+		}
+
+		host, port := server, "514"
+		if h, p, err := net.SplitHostPort(server); err == nil {
+			host, port = h, p
+		}
+		if host != "localhost" {
+			ip := net.ParseIP(host)
+			if ip == nil || !ip.IsLoopback() {
+				return ""
+			}
+		}
+		return net.JoinHostPort(host, port)
+	}
+	return ""
+}
+
+// isJSONLogFormat reports whether the log format writes JSON encoded lines, which is
+// the case when the log_format directive carries the escape=json parameter
+func isJSONLogFormat(logFormat string) bool {
+	return strings.HasPrefix(strings.TrimSpace(logFormat), "escape=json")
+}
+
 // convertLogFormat converts log format into a pattern that can be parsed by the tailer
 func convertLogFormat(logFormat string) string {
 	newLogFormat := strings.ReplaceAll(logFormat, "$remote_addr", "%{IPORHOST:remote_addr}")
@@ -492,39 +579,41 @@ func isOtherMethod(method string) bool {
 
 func getDefaultCounters() (map[string]float64, map[string]float64) {
 	httpCounters := map[string]float64{
-		"gzip.ratio":              0,
-		"method.delete":           0,
-		"method.get":              0,
-		"method.head":             0,
-		"method.options":          0,
-		"method.post":             0,
-		"method.put":              0,
-		"method.others":           0,
-		"request.body_bytes_sent": 0,
-		"request.bytes_sent":      0,
-		"request.length":          0,
-		"request.malformed":       0,
-		"request.time":            0,
-		"request.time.count":      0,
-		"request.time.max":        0,
-		"request.time.median":     0,
-		"request.time.pctl95":     0,
-		"status.403":              0,
-		"status.404":              0,
-		"status.500":              0,
-		"status.502":              0,
-		"status.503":              0,
-		"status.504":              0,
-		"status.discarded":        0,
-		"status.1xx":              0,
-		"status.2xx":              0,
-		"status.3xx":              0,
-		"status.4xx":              0,
-		"status.5xx":              0,
-		"v0_9":                    0,
-		"v1_0":                    0,
-		"v1_1":                    0,
-		"v2":                      0,
+		"availability.ratio":        100,
+		"availability.error_streak": 0,
+		"gzip.ratio":                0,
+		"method.delete":             0,
+		"method.get":                0,
+		"method.head":               0,
+		"method.options":            0,
+		"method.post":               0,
+		"method.put":                0,
+		"method.others":             0,
+		"request.body_bytes_sent":   0,
+		"request.bytes_sent":        0,
+		"request.length":            0,
+		"request.malformed":         0,
+		"request.time":              0,
+		"request.time.count":        0,
+		"request.time.max":          0,
+		"request.time.median":       0,
+		"request.time.pctl95":       0,
+		"status.403":                0,
+		"status.404":                0,
+		"status.500":                0,
+		"status.502":                0,
+		"status.503":                0,
+		"status.504":                0,
+		"status.discarded":          0,
+		"status.1xx":                0,
+		"status.2xx":                0,
+		"status.3xx":                0,
+		"status.4xx":                0,
+		"status.5xx":                0,
+		"v0_9":                      0,
+		"v1_0":                      0,
+		"v1_1":                      0,
+		"v2":                        0,
 	}
 
 	upstreamCounters := map[string]float64{
@@ -9,6 +9,7 @@ package sources
 
 import (
 	"context"
+	"net"
 	"os"
 	"sort"
 	"testing"
@@ -19,12 +20,19 @@ import (
 	"github.com/nginx/agent/v2/src/core/config"
 	"github.com/nginx/agent/v2/src/core/metrics"
 	tutils "github.com/nginx/agent/v2/test/utils"
+	"github.com/trivago/grok"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestAccessLogUpdate(t *testing.T) {
+	// the tailed logs have to exist, unreadable logs are filtered out
+	require.NoError(t, os.WriteFile("/tmp/access.log", []byte(""), 0644))
+	require.NoError(t, os.WriteFile("/tmp/new_access.log", []byte(""), 0644))
+	defer os.Remove("/tmp/access.log")
+	defer os.Remove("/tmp/new_access.log")
+
 	binary := tutils.NewMockNginxBinary()
 	binary.On("GetAccessLogs").Return(map[string]string{"/tmp/access.log": ""}).Once()
 	binary.On("GetAccessLogs").Return(map[string]string{"/tmp/new_access.log": ""}).Once()
@@ -54,6 +62,9 @@ func TestAccessLogUpdate(t *testing.T) {
 }
 
 func TestAccessLogStop(t *testing.T) {
+	require.NoError(t, os.WriteFile("/tmp/access.log", []byte(""), 0644))
+	defer os.Remove("/tmp/access.log")
+
 	binary := tutils.NewMockNginxBinary()
 	binary.On("GetAccessLogs").Return(map[string]string{"/tmp/access.log": ""}).Once()
 
@@ -84,6 +95,14 @@ func TestAccessLogStats(t *testing.T) {
 			},
 			&proto.StatsEntity{
 				Simplemetrics: []*proto.SimpleMetric{
+					{
+						Name:  "nginx.http.availability.ratio",
+						Value: 100,
+					},
+					{
+						Name:  "nginx.http.availability.error_streak",
+						Value: 0,
+					},
 					{
 						Name:  "nginx.http.gzip.ratio",
 						Value: 0,
@@ -312,6 +331,14 @@ func TestAccessLogStats(t *testing.T) {
 			},
 			&proto.StatsEntity{
 				Simplemetrics: []*proto.SimpleMetric{
+					{
+						Name:  "nginx.http.availability.ratio",
+						Value: 100,
+					},
+					{
+						Name:  "nginx.http.availability.error_streak",
+						Value: 0,
+					},
 					{
 						Name:  "nginx.http.gzip.ratio",
 						Value: 0,
@@ -552,6 +579,14 @@ func TestAccessLogStats(t *testing.T) {
 			},
 			&proto.StatsEntity{
 				Simplemetrics: []*proto.SimpleMetric{
+					{
+						Name:  "nginx.http.availability.ratio",
+						Value: 9.0 / 14.0 * 100,
+					},
+					{
+						Name:  "nginx.http.availability.error_streak",
+						Value: 5,
+					},
 					{
 						Name:  "nginx.http.gzip.ratio",
 						Value: 15,
@@ -808,3 +843,138 @@ func TestAccessLogStats(t *testing.T) {
 		})
 	}
 }
+
+func TestAccessLogStatsJSONFormat(t *testing.T) {
+	logFormat := `escape=json{"remote_addr":"$remote_addr","request":"$request","status":"$status","body_bytes_sent":"$body_bytes_sent","server_protocol":"$server_protocol"}`
+	logLines := []string{
+		`{"remote_addr":"127.0.0.1","request":"GET /nginx_status HTTP/1.1","status":"200","body_bytes_sent":"98","server_protocol":"HTTP/1.1"}` + "\n",
+		`{"remote_addr":"127.0.0.1","request":"POST /api/upload HTTP/1.1","status":"502","body_bytes_sent":"150","server_protocol":"HTTP/1.1"}` + "\n",
+	}
+	expectedValues := map[string]float64{
+		"nginx.http.request.body_bytes_sent": 248,
+		"nginx.http.method.get":              1,
+		"nginx.http.method.post":             1,
+		"nginx.http.status.2xx":              1,
+		"nginx.http.status.5xx":              1,
+		"nginx.http.status.502":              1,
+		"nginx.http.v1_1":                    2,
+	}
+
+	binary := core.NewNginxBinary(tutils.NewMockEnvironment(), &config.Config{})
+	collectionDuration := time.Millisecond * 300
+
+	accessLogFile, _ := os.CreateTemp(os.TempDir(), "access.log")
+	defer os.Remove(accessLogFile.Name())
+
+	nginxAccessLog := NewNginxAccessLog(&metrics.CommonDim{}, OSSNamespace, binary, OSSNginxType, collectionDuration)
+	go nginxAccessLog.logStats(context.TODO(), accessLogFile.Name(), logFormat)
+
+	time.Sleep(time.Millisecond * 100)
+
+	for _, logLine := range logLines {
+		_, err := accessLogFile.WriteString(logLine)
+		require.NoError(t, err, "Error writing data to access log")
+	}
+
+	time.Sleep(collectionDuration)
+	accessLogFile.Close()
+
+	require.NotEmpty(t, nginxAccessLog.buf)
+	actualValues := map[string]float64{}
+	for _, metric := range nginxAccessLog.buf[0].GetSimplemetrics() {
+		actualValues[metric.Name] = metric.Value
+	}
+	for name, value := range expectedValues {
+		assert.Equal(t, value, actualValues[name], name)
+	}
+}
+
+func TestIsJSONLogFormat(t *testing.T) {
+	assert.True(t, isJSONLogFormat(`escape=json{"remote_addr":"$remote_addr"}`))
+	assert.True(t, isJSONLogFormat(`escape=json {"remote_addr": "$remote_addr"}`))
+	assert.False(t, isJSONLogFormat(`$remote_addr - $remote_user [$time_local] "$request"`))
+	assert.False(t, isJSONLogFormat(""))
+}
+
+func TestAccessLogStatsSyslog(t *testing.T) {
+	logFormat := `$remote_addr - $remote_user [$time_local] "$request" $status $body_bytes_sent "$http_referer" "$http_user_agent"`
+	logLines := []string{
+		`<190>May 19 09:30:39 test-host nginx: 127.0.0.1 - - [19/May/2022:09:30:39 +0000] "GET /nginx_status HTTP/1.1" 200 98 "-" "Go-http-client/1.1"`,
+		`<190>May 19 09:30:39 test-host nginx: 127.0.0.1 - - [19/May/2022:09:30:40 +0000] "POST /api/upload HTTP/1.1" 502 150 "-" "Go-http-client/1.1"`,
+	}
+	expectedValues := map[string]float64{
+		"nginx.http.request.body_bytes_sent": 248,
+		"nginx.http.method.get":              1,
+		"nginx.http.method.post":             1,
+		"nginx.http.status.2xx":              1,
+		"nginx.http.status.5xx":              1,
+		"nginx.http.status.502":              1,
+	}
+
+	// reserve a free port for the listener
+	reserved, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := reserved.LocalAddr().String()
+	reserved.Close()
+
+	binary := core.NewNginxBinary(tutils.NewMockEnvironment(), &config.Config{})
+	collectionDuration := time.Millisecond * 300
+
+	nginxAccessLog := NewNginxAccessLog(&metrics.CommonDim{}, OSSNamespace, binary, OSSNginxType, collectionDuration)
+	go nginxAccessLog.logStats(context.TODO(), "syslog:server="+address, logFormat)
+
+	time.Sleep(time.Millisecond * 100)
+
+	conn, err := net.Dial("udp", address)
+	require.NoError(t, err)
+	defer conn.Close()
+	for _, logLine := range logLines {
+		_, err := conn.Write([]byte(logLine))
+		require.NoError(t, err, "Error sending data to the syslog listener")
+	}
+
+	time.Sleep(collectionDuration)
+
+	require.NotEmpty(t, nginxAccessLog.buf)
+	actualValues := map[string]float64{}
+	for _, metric := range nginxAccessLog.buf[0].GetSimplemetrics() {
+		actualValues[metric.Name] = metric.Value
+	}
+	for name, value := range expectedValues {
+		assert.Equal(t, value, actualValues[name], name)
+	}
+}
+
+func TestSyslogServerAddress(t *testing.T) {
+	assert.Equal(t, "127.0.0.1:5140", syslogServerAddress("syslog:server=127.0.0.1:5140"))
+	assert.Equal(t, "localhost:514", syslogServerAddress("syslog:server=localhost"))
+	assert.Equal(t, "127.0.0.1:5140", syslogServerAddress("syslog:server=127.0.0.1:5140,facility=local7,tag=nginx"))
+	assert.Equal(t, "unix:/var/log/nginx.sock", syslogServerAddress("syslog:server=unix:/var/log/nginx.sock"))
+	// lines sent to another host never arrive here
+	assert.Equal(t, "", syslogServerAddress("syslog:server=192.168.1.5:514"))
+	assert.Equal(t, "", syslogServerAddress("syslog:facility=local7"))
+}
+
+// FuzzConvertLogFormat hardens log format tokenization: the log_format
+// directive comes straight out of the nginx config, so converting it and
+// compiling the resulting pattern must never panic.
+func FuzzConvertLogFormat(f *testing.F) {
+	f.Add(`$remote_addr - $remote_user [$time_local] "$request" $status $body_bytes_sent "$http_referer" "$http_user_agent" "$http_x_forwarded_for"`)
+	f.Add(`escape=json '{"status": "$status"}'`)
+	f.Add(`[[[$request_time ]]] %{(((`)
+
+	f.Fuzz(func(t *testing.T, logFormat string) {
+		_ = isJSONLogFormat(logFormat)
+
+		converted := convertLogFormat(logFormat)
+		g, err := grok.New(grok.Config{
+			NamedCapturesOnly: false,
+			Patterns:          map[string]string{"DEFAULT": converted},
+		})
+		if err != nil {
+			return
+		}
+		// a format the tailer cannot compile is rejected, not fatal
+		_, _ = g.Compile("%{DEFAULT}")
+	})
+}
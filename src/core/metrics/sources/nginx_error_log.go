@@ -26,6 +26,8 @@ const (
 	UpstreamResponseBufferedMetricName = "upstream.response.buffered"
 	UpstreamRequestFailedMetricName    = "upstream.request.failed"
 	UpstreamResponseFailedMetricName   = "upstream.response.failed"
+	LimitReqsRejectedMetricName        = "http.limit_reqs.rejected"
+	LimitConnsRejectedMetricName       = "http.limit_conns.rejected"
 )
 
 var (
@@ -55,6 +57,11 @@ var (
 			re.MustCompile(`.*upstream sent too big header while reading response header from upstream.*`),
 		},
 	}
+
+	// rejections are logged at error level with the zone in quotes; the dry run
+	// variants insert ", dry run," before the zone and deliberately do not match
+	limitReqsRejectedRegex  = re.MustCompile(`limiting requests, excess: [0-9.]+ by zone "([^"]+)"`)
+	limitConnsRejectedRegex = re.MustCompile(`limiting connections by zone "([^"]+)"`)
 )
 
 type NginxErrorLog struct {
@@ -88,7 +95,10 @@ func NewNginxErrorLog(
 		[]*proto.StatsEntity{},
 	}
 
-	logs := binary.GetErrorLogs()
+	logs, unreadable := core.FilterReadableLogs(binary.GetErrorLogs())
+	for _, logFile := range unreadable {
+		log.Warnf("Error log %s is not readable with the agent's privileges, disabling its tailer", logFile)
+	}
 
 	for logFile, logFormat := range logs {
 		log.Infof("Adding error log tailer: %s", logFile)
@@ -130,7 +140,10 @@ func (c *NginxErrorLog) Update(dimensions *metrics.CommonDim, collectorConf *met
 			delete(c.logs, f)
 		}
 
-		logs := c.binary.GetErrorLogs()
+		logs, unreadable := core.FilterReadableLogs(c.binary.GetErrorLogs())
+		for _, logFile := range unreadable {
+			log.Warnf("Error log %s is not readable with the agent's privileges, disabling its tailer", logFile)
+		}
 
 		// add any new ones
 		for logFile, logFormat := range logs {
@@ -148,7 +161,10 @@ func (c *NginxErrorLog) Update(dimensions *metrics.CommonDim, collectorConf *met
 func (c *NginxErrorLog) collectLogStats(ctx context.Context, m chan<- *proto.StatsEntity) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	logs := c.binary.GetErrorLogs()
+	logs, unreadable := core.FilterReadableLogs(c.binary.GetErrorLogs())
+	for _, logFile := range unreadable {
+		log.Debugf("Error log %s is not readable with the agent's privileges, its tailer stays disabled", logFile)
+	}
 
 	if c.binary.UpdateLogs(c.logFormats, logs) {
 		log.Info("Error logs updated")
@@ -186,6 +202,8 @@ func (c *NginxErrorLog) logStats(ctx context.Context, logFile string) {
 		UpstreamRequestFailedMetricName:    0,
 		UpstreamResponseFailedMetricName:   0,
 	}
+	limitReqsRejections := map[string]float64{}
+	limitConnsRejections := map[string]float64{}
 	mu := sync.Mutex{}
 
 	t, err := tailer.NewTailer(logFile)
@@ -213,6 +231,13 @@ func (c *NginxErrorLog) logStats(ctx context.Context, logFile string) {
 				}
 			}
 
+			if match := limitReqsRejectedRegex.FindStringSubmatch(d); match != nil {
+				limitReqsRejections[match[1]] = limitReqsRejections[match[1]] + 1
+			}
+			if match := limitConnsRejectedRegex.FindStringSubmatch(d); match != nil {
+				limitConnsRejections[match[1]] = limitConnsRejections[match[1]] + 1
+			}
+
 			mu.Unlock()
 
 		case <-tick.C:
@@ -233,6 +258,23 @@ func (c *NginxErrorLog) logStats(ctx context.Context, logFile string) {
 
 			c.buf = append(c.buf, metrics.NewStatsEntity(c.baseDimensions.ToDimensions(), simpleMetrics))
 
+			// the zone rejections are reported as one entity per zone so the zone
+			// can be a dimension, like the Plus API limit metrics
+			for zone, rejections := range limitReqsRejections {
+				dims := append(c.baseDimensions.ToDimensions(), &proto.Dimension{Name: "limit_req_zone", Value: zone})
+				c.buf = append(c.buf, metrics.NewStatsEntity(dims, c.convertSamplesToSimpleMetrics(map[string]float64{
+					LimitReqsRejectedMetricName: rejections,
+				})))
+			}
+			for zone, rejections := range limitConnsRejections {
+				dims := append(c.baseDimensions.ToDimensions(), &proto.Dimension{Name: "limit_conn_zone", Value: zone})
+				c.buf = append(c.buf, metrics.NewStatsEntity(dims, c.convertSamplesToSimpleMetrics(map[string]float64{
+					LimitConnsRejectedMetricName: rejections,
+				})))
+			}
+			limitReqsRejections = map[string]float64{}
+			limitConnsRejections = map[string]float64{}
+
 			mu.Unlock()
 
 		case <-ctx.Done():
@@ -25,6 +25,12 @@ import (
 )
 
 func TestNginxErrorLogUpdate(t *testing.T) {
+	// the tailed logs have to exist, unreadable logs are filtered out
+	require.NoError(t, os.WriteFile("/tmp/error.log", []byte(""), 0644))
+	require.NoError(t, os.WriteFile("/tmp/new_error.log", []byte(""), 0644))
+	defer os.Remove("/tmp/error.log")
+	defer os.Remove("/tmp/new_error.log")
+
 	binary := tutils.NewMockNginxBinary()
 	binary.On("GetErrorLogs").Return(map[string]string{"/tmp/error.log": ""}).Once()
 	binary.On("GetErrorLogs").Return(map[string]string{"/tmp/new_error.log": ""}).Once()
@@ -54,6 +60,9 @@ func TestNginxErrorLogUpdate(t *testing.T) {
 }
 
 func TestNginxErrorLogStop(t *testing.T) {
+	require.NoError(t, os.WriteFile("/tmp/error.log", []byte(""), 0644))
+	defer os.Remove("/tmp/error.log")
+
 	binary := tutils.NewMockNginxBinary()
 	binary.On("GetErrorLogs").Return(map[string]string{"/tmp/error.log": ""}).Once()
 
@@ -141,3 +150,57 @@ func TestErrorLogStats(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorLogLimitRejectionStats(t *testing.T) {
+	logLines := []string{
+		`2023/01/05 13:53:33 [error] 1111#1111: *31 limiting requests, excess: 1.000 by zone "one", client: 127.0.0.1, server: localhost, request: "GET / HTTP/1.1", host: "localhost:8080"`,
+		`2023/01/05 13:53:34 [error] 1111#1111: *32 limiting requests, excess: 2.500 by zone "one", client: 127.0.0.1, server: localhost, request: "GET / HTTP/1.1", host: "localhost:8080"`,
+		`2023/01/05 13:53:35 [error] 1111#1111: *33 limiting requests, excess: 1.000 by zone "two", client: 127.0.0.1, server: localhost, request: "GET / HTTP/1.1", host: "localhost:8080"`,
+		// dry run rejections and delays are not counted
+		`2023/01/05 13:53:36 [error] 1111#1111: *34 limiting requests, dry run, excess: 1.000 by zone "one", client: 127.0.0.1, server: localhost, request: "GET / HTTP/1.1", host: "localhost:8080"`,
+		`2023/01/05 13:53:37 [warn] 1111#1111: *35 delaying request, excess: 0.480, by zone "one", client: 127.0.0.1, server: localhost, request: "GET / HTTP/1.1", host: "localhost:8080"`,
+		`2023/01/05 13:53:38 [error] 1111#1111: *36 limiting connections by zone "addr", client: 127.0.0.1, server: localhost, request: "GET / HTTP/1.1", host: "localhost:8080"`,
+		`2023/01/05 13:53:39 [error] 1111#1111: *37 limiting connections, dry run, by zone "addr", client: 127.0.0.1, server: localhost, request: "GET / HTTP/1.1", host: "localhost:8080"`,
+	}
+
+	binary := core.NewNginxBinary(tutils.NewMockEnvironment(), &config.Config{})
+	collectionDuration := time.Millisecond * 300
+	sleepDuration := time.Millisecond * 100
+
+	errorLogFile, _ := os.CreateTemp(os.TempDir(), "error.log")
+
+	nginxErrorLog := NewNginxErrorLog(&metrics.CommonDim{}, OSSNamespace, binary, OSSNginxType, collectionDuration)
+	go nginxErrorLog.logStats(context.TODO(), errorLogFile.Name())
+
+	time.Sleep(sleepDuration)
+
+	for _, logLine := range logLines {
+		_, err := errorLogFile.WriteString(logLine + "\n")
+		require.NoError(t, err, "Error writing data to error log")
+	}
+
+	time.Sleep(collectionDuration)
+
+	errorLogFile.Close()
+	os.Remove(errorLogFile.Name())
+
+	limitReqRejections := map[string]float64{}
+	limitConnRejections := map[string]float64{}
+	for _, stats := range nginxErrorLog.buf {
+		for _, dimension := range stats.GetDimensions() {
+			switch dimension.Name {
+			case "limit_req_zone":
+				require.Len(t, stats.GetSimplemetrics(), 1)
+				assert.Equal(t, "nginx.http.limit_reqs.rejected", stats.GetSimplemetrics()[0].Name)
+				limitReqRejections[dimension.Value] = stats.GetSimplemetrics()[0].Value
+			case "limit_conn_zone":
+				require.Len(t, stats.GetSimplemetrics(), 1)
+				assert.Equal(t, "nginx.http.limit_conns.rejected", stats.GetSimplemetrics()[0].Name)
+				limitConnRejections[dimension.Value] = stats.GetSimplemetrics()[0].Value
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]float64{"one": 2, "two": 1}, limitReqRejections)
+	assert.Equal(t, map[string]float64{"addr": 1}, limitConnRejections)
+}
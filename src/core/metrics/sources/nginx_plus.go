@@ -11,7 +11,6 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"net/http"
 	"sync"
 
 	"github.com/nginx/agent/sdk/v2/proto"
@@ -43,6 +42,9 @@ type NginxPlus struct {
 	prevStats     *plusclient.Stats
 	init          sync.Once
 	clientVersion int
+	// client is kept across collections so keep-alive connections and the
+	// conditional request cache survive between intervals
+	client *plusclient.NginxClient
 }
 
 func NewNginxPlus(baseDimensions *metrics.CommonDim, nginxNamespace, plusNamespace, plusAPI string, clientVersion int) *NginxPlus {
@@ -52,13 +54,13 @@ func NewNginxPlus(baseDimensions *metrics.CommonDim, nginxNamespace, plusNamespa
 func (c *NginxPlus) Collect(ctx context.Context, wg *sync.WaitGroup, m chan<- *proto.StatsEntity) {
 	defer wg.Done()
 	c.init.Do(func() {
-		cl, err := plusclient.NewNginxClientWithVersion(&http.Client{}, c.plusAPI, c.clientVersion)
+		cl, err := c.ensureClient()
 		if err != nil {
 			log.Errorf("Failed to create plus metrics client: %v", err)
 			SendNginxDownStatus(ctx, c.baseDimensions.ToDimensions(), m)
 			return
 		}
-		c.prevStats, err = cl.GetStats()
+		c.prevStats, err = getPlusStats(cl)
 		if err != nil {
 			log.Warnf("Failed to retrieve plus metrics: %v", err)
 			SendNginxDownStatus(ctx, c.baseDimensions.ToDimensions(), m)
@@ -67,17 +69,19 @@ func (c *NginxPlus) Collect(ctx context.Context, wg *sync.WaitGroup, m chan<- *p
 		}
 	})
 
-	cl, err := plusclient.NewNginxClientWithVersion(&http.Client{}, c.plusAPI, c.clientVersion)
+	cl, err := c.ensureClient()
 	if err != nil {
 		log.Errorf("Failed to create plus metrics client: %v", err)
 		SendNginxDownStatus(ctx, c.baseDimensions.ToDimensions(), m)
 		return
 	}
 
-	stats, err := cl.GetStats()
+	stats, err := getPlusStats(cl)
 	if err != nil {
 		log.Errorf("Failed to retrieve plus metrics: %v", err)
 		SendNginxDownStatus(ctx, c.baseDimensions.ToDimensions(), m)
+		// drop the client so the next collection dials and validates afresh
+		c.client = nil
 		return
 	}
 
@@ -96,8 +100,25 @@ func (c *NginxPlus) Collect(ctx context.Context, wg *sync.WaitGroup, m chan<- *p
 	c.prevStats = stats
 }
 
+// ensureClient returns the plus API client, creating it on the first
+// collection or after the previous one was dropped
+func (c *NginxPlus) ensureClient() (*plusclient.NginxClient, error) {
+	if c.client == nil {
+		cl, err := plusclient.NewNginxClientWithVersion(newPlusHTTPClient(), c.plusAPI, c.clientVersion)
+		if err != nil {
+			return nil, err
+		}
+		c.client = cl
+	}
+	return c.client, nil
+}
+
 func (c *NginxPlus) Update(dimensions *metrics.CommonDim, collectorConf *metrics.NginxCollectorConfig) {
 	c.baseDimensions = dimensions
+	if c.plusAPI != collectorConf.PlusAPI {
+		// the endpoint moved, so the pooled client is no longer valid
+		c.client = nil
+	}
 	c.plusAPI = collectorConf.PlusAPI
 }
 
@@ -103,6 +103,22 @@ func (c *NginxWorker) Collect(ctx context.Context, wg *sync.WaitGroup, m chan<-
 			KbsW = stats.Workers.KbsW
 		}
 
+		prevWorkers := map[string]*WorkerProc{}
+		for _, worker := range c.prevStats[pid].PerWorker {
+			prevWorkers[worker.Pid] = worker
+		}
+
+		// workers with pids we have not seen before were started since the last
+		// collection; a reload replaces every worker, so spikes here line up with
+		// config activity, while a steady trickle without reloads points at
+		// crashing workers
+		restarts := 0
+		for _, worker := range stats.PerWorker {
+			if _, seen := prevWorkers[worker.Pid]; !seen {
+				restarts++
+			}
+		}
+
 		simpleMetrics := c.convertSamplesToSimpleMetrics(map[string]float64{
 			"count":         float64(stats.Workers.Count),
 			"rlimit_nofile": float64(stats.Workers.RlimitNofile),
@@ -115,6 +131,7 @@ func (c *NginxWorker) Collect(ctx context.Context, wg *sync.WaitGroup, m chan<-
 			"mem.rss_pct":   float64(stats.Workers.MemRssPct),
 			"io.kbs_r":      float64(KbsR),
 			"io.kbs_w":      float64(KbsW),
+			"restarts":      float64(restarts),
 		})
 
 		select {
@@ -122,6 +139,30 @@ func (c *NginxWorker) Collect(ctx context.Context, wg *sync.WaitGroup, m chan<-
 		case m <- metrics.NewStatsEntity(c.baseDimensions.ToDimensions(), simpleMetrics):
 		}
 
+		c.group = "workers.process"
+		for _, worker := range stats.PerWorker {
+			prev := prevWorkers[worker.Pid]
+			if prev == nil {
+				// new workers have no baseline, their CPU times since start are the delta
+				prev = &WorkerProc{}
+			}
+
+			workerMetrics := c.convertSamplesToSimpleMetrics(map[string]float64{
+				"cpu.user":    worker.CPUUser - prev.CPUUser,
+				"cpu.system":  worker.CPUSystem - prev.CPUSystem,
+				"mem.rss":     worker.MemRss,
+				"fds_count":   worker.FdsCount,
+				"connections": worker.Connections,
+			})
+
+			dimensions := append(c.baseDimensions.ToDimensions(), &proto.Dimension{Name: "worker_pid", Value: worker.Pid})
+			select {
+			case <-ctx.Done():
+				return
+			case m <- metrics.NewStatsEntity(dimensions, workerMetrics):
+			}
+		}
+
 		c.prevStats[pid] = stats
 	}
 }
@@ -141,7 +182,18 @@ type NginxWorkerClient struct{}
 
 // WorkerStats represents NGINX worker metrics
 type WorkerStats struct {
-	Workers *Workers
+	Workers   *Workers
+	PerWorker []*WorkerProc
+}
+
+// WorkerProc represents metrics for a single nginx worker process
+type WorkerProc struct {
+	Pid         string
+	CPUUser     float64
+	CPUSystem   float64
+	MemRss      float64
+	FdsCount    float64
+	Connections float64
 }
 
 // Workers represents metrics related to child nginx processes
@@ -200,9 +252,13 @@ func (client *NginxWorkerClient) GetWorkerStats(childProcs []*proto.NginxDetails
 			continue
 		}
 
+		worker := &WorkerProc{Pid: nginxDetails.ProcessId}
+
 		if times, err := proc.Times(); err == nil {
 			usr = usr + times.User
 			sys = sys + times.System
+			worker.CPUUser = times.User
+			worker.CPUSystem = times.System
 		} else {
 			log.Debug("unable to get CPU times metrics")
 		}
@@ -210,6 +266,7 @@ func (client *NginxWorkerClient) GetWorkerStats(childProcs []*proto.NginxDetails
 		if memstat, err := proc.MemoryInfo(); err == nil {
 			memRss += float64(memstat.RSS)
 			memVms += float64(memstat.VMS)
+			worker.MemRss = float64(memstat.RSS)
 		} else {
 			log.Debug("unable to get memory info metrics")
 		}
@@ -222,10 +279,19 @@ func (client *NginxWorkerClient) GetWorkerStats(childProcs []*proto.NginxDetails
 
 		if fd, err := proc.NumFDs(); err == nil {
 			fdSum = fdSum + float64(fd)
+			worker.FdsCount = float64(fd)
 		} else {
 			log.Debug("unable to get number of file descriptors used metrics")
 		}
 
+		if connections, err := proc.Connections(); err == nil {
+			worker.Connections = float64(len(connections))
+		} else {
+			log.Debug("unable to get connection metrics")
+		}
+
+		stats.PerWorker = append(stats.PerWorker, worker)
+
 		if rlimit, err := proc.Rlimit(); err == nil {
 			var rlimitMax int32
 			for _, rl := range rlimit {
@@ -121,6 +121,8 @@ func TestNginxWorkerCollector(t *testing.T) {
 			assert.Equal(t, float64(1), metric.Value)
 		case "nginx.workers.cpu.user":
 			assert.Equal(t, float64(0), metric.Value)
+		case "nginx.workers.restarts":
+			assert.Equal(t, float64(0), metric.Value)
 		default:
 			// if there is an unknown metric, we should fail because
 			// we should't have anything but the above
@@ -161,6 +163,8 @@ func TestNginxWorkerCollector(t *testing.T) {
 			assert.Equal(t, float64(1), metric.Value)
 		case "nginx.workers.cpu.user":
 			assert.Equal(t, float64(0), metric.Value)
+		case "nginx.workers.restarts":
+			assert.Equal(t, float64(0), metric.Value)
 		default:
 			// if there is an unknown metric, we should fail because
 			// we should't have anything but the above
@@ -169,6 +173,84 @@ func TestNginxWorkerCollector(t *testing.T) {
 	}
 }
 
+func TestNginxWorkerCollectorPerWorker(t *testing.T) {
+	dimensions := &metrics.CommonDim{
+		SystemId:      systemId,
+		Hostname:      host,
+		InstanceGroup: instanceGroup,
+		DisplayName:   displayName,
+		NginxId:       nginxId,
+	}
+
+	mockBinary := &utils.MockNginxBinary{}
+	mockClient := &MockWorkerClient{}
+
+	n := NewNginxWorker(dimensions, OSSNamespace, mockBinary, mockClient)
+
+	mockBinary.On("GetChildProcesses").Return(procMap)
+
+	// the worker with pid 11 is replaced by pid 12 between collections
+	mockClient.On("GetWorkerStats", procMap["1"]).Return(&WorkerStats{
+		Workers: &Workers{Count: 2.00},
+		PerWorker: []*WorkerProc{
+			{Pid: "10", CPUUser: 1.00, MemRss: 100, FdsCount: 5, Connections: 3},
+			{Pid: "11", CPUUser: 1.00, MemRss: 100, FdsCount: 5, Connections: 3},
+		},
+	}, nil).Twice()
+	mockClient.On("GetWorkerStats", procMap["1"]).Return(&WorkerStats{
+		Workers: &Workers{Count: 2.00},
+		PerWorker: []*WorkerProc{
+			{Pid: "10", CPUUser: 3.00, MemRss: 150, FdsCount: 5, Connections: 4},
+			{Pid: "12", CPUUser: 0.50, MemRss: 90, FdsCount: 5, Connections: 1},
+		},
+	}, nil)
+
+	ctx := context.TODO()
+	m := make(chan *proto.StatsEntity, 10)
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	n.Collect(ctx, &wg, m)
+	// aggregate entity followed by one entity per worker
+	assert.Len(t, m, 3)
+	for len(m) > 0 {
+		<-m
+	}
+
+	wg.Add(1)
+	n.Collect(ctx, &wg, m)
+	assert.Len(t, m, 3)
+
+	aggregate := <-m
+	for _, metric := range aggregate.Simplemetrics {
+		if metric.Name == "nginx.workers.restarts" {
+			assert.Equal(t, float64(1), metric.Value)
+		}
+	}
+
+	workerMetrics := map[string]map[string]float64{}
+	for len(m) > 0 {
+		entity := <-m
+		var workerPid string
+		for _, dimension := range entity.Dimensions {
+			if dimension.Name == "worker_pid" {
+				workerPid = dimension.Value
+			}
+		}
+		assert.NotEmpty(t, workerPid)
+		workerMetrics[workerPid] = map[string]float64{}
+		for _, metric := range entity.Simplemetrics {
+			workerMetrics[workerPid][metric.Name] = metric.Value
+		}
+	}
+
+	assert.Equal(t, float64(2), workerMetrics["10"]["nginx.workers.process.cpu.user"])
+	assert.Equal(t, float64(150), workerMetrics["10"]["nginx.workers.process.mem.rss"])
+	assert.Equal(t, float64(4), workerMetrics["10"]["nginx.workers.process.connections"])
+	// the replacement worker has no baseline, its CPU time since start is reported
+	assert.Equal(t, float64(0.5), workerMetrics["12"]["nginx.workers.process.cpu.user"])
+}
+
 func TestExcludeCacheProcs(t *testing.T) {
 	type testCase struct {
 		input    []byte
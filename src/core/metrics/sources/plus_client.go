@@ -0,0 +1,280 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sources
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	plusclient "github.com/nginxinc/nginx-plus-go-client/client"
+)
+
+const (
+	// plusAPIFetchWorkers caps how many plus API endpoints are fetched concurrently
+	// when the stats are collected
+	plusAPIFetchWorkers = 4
+
+	// plusAPIIdleTimeout is how long idle keep-alive connections to the plus API
+	// are kept for reuse by the next collection
+	plusAPIIdleTimeout = 90 * time.Second
+)
+
+// newPlusHTTPClient returns the http client used to poll the plus API: it keeps
+// idle connections open between one second collection intervals instead of
+// redialling, and revalidates endpoints with conditional requests where the API
+// supplies validators.
+func newPlusHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &conditionalTransport{
+			base: &http.Transport{
+				MaxIdleConnsPerHost: plusAPIFetchWorkers,
+				IdleConnTimeout:     plusAPIIdleTimeout,
+			},
+			entries: make(map[string]*conditionalEntry),
+		},
+	}
+}
+
+// conditionalEntry is the cached representation of one endpoint, keyed by the
+// validators the server handed out with it
+type conditionalEntry struct {
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+// conditionalTransport revalidates GET requests with If-None-Match or
+// If-Modified-Since when a previous response carried an ETag or Last-Modified
+// header, and replays the cached body on 304 Not Modified, so unchanged
+// endpoints are not re-transferred every collection interval.
+type conditionalTransport struct {
+	base    http.RoundTripper
+	mutex   sync.Mutex
+	entries map[string]*conditionalEntry
+}
+
+func (t *conditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	t.mutex.Lock()
+	entry := t.entries[key]
+	t.mutex.Unlock()
+
+	if entry != nil {
+		if entry.etag != "" {
+			req.Header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		return &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Proto:      resp.Proto,
+			ProtoMajor: resp.ProtoMajor,
+			ProtoMinor: resp.ProtoMinor,
+			Header:     entry.header.Clone(),
+			Body:       io.NopCloser(bytes.NewReader(entry.body)),
+			Request:    req,
+		}, nil
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		etag := resp.Header.Get("ETag")
+		lastModified := resp.Header.Get("Last-Modified")
+		if etag != "" || lastModified != "" {
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			t.mutex.Lock()
+			t.entries[key] = &conditionalEntry{
+				etag:         etag,
+				lastModified: lastModified,
+				header:       resp.Header.Clone(),
+				body:         body,
+			}
+			t.mutex.Unlock()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+		}
+	}
+
+	return resp, nil
+}
+
+// getPlusStats assembles the same stats as the client's GetStats, but fetches
+// the endpoints concurrently with at most plusAPIFetchWorkers in flight instead
+// of walking them one at a time. Each fetch writes to its own field of the
+// stats, so only the error needs guarding.
+func getPlusStats(client *plusclient.NginxClient) (*plusclient.Stats, error) {
+	stats := &plusclient.Stats{}
+
+	fetches := []func() error{
+		func() (err error) {
+			info, err := client.GetNginxInfo()
+			if err == nil {
+				stats.NginxInfo = *info
+			}
+			return err
+		},
+		func() (err error) {
+			caches, err := client.GetCaches()
+			if err == nil {
+				stats.Caches = *caches
+			}
+			return err
+		},
+		func() (err error) {
+			processes, err := client.GetProcesses()
+			if err == nil {
+				stats.Processes = *processes
+			}
+			return err
+		},
+		func() (err error) {
+			slabs, err := client.GetSlabs()
+			if err == nil {
+				stats.Slabs = *slabs
+			}
+			return err
+		},
+		func() (err error) {
+			connections, err := client.GetConnections()
+			if err == nil {
+				stats.Connections = *connections
+			}
+			return err
+		},
+		func() (err error) {
+			requests, err := client.GetHTTPRequests()
+			if err == nil {
+				stats.HTTPRequests = *requests
+			}
+			return err
+		},
+		func() (err error) {
+			ssl, err := client.GetSSL()
+			if err == nil {
+				stats.SSL = *ssl
+			}
+			return err
+		},
+		func() (err error) {
+			zones, err := client.GetServerZones()
+			if err == nil {
+				stats.ServerZones = *zones
+			}
+			return err
+		},
+		func() (err error) {
+			upstreams, err := client.GetUpstreams()
+			if err == nil {
+				stats.Upstreams = *upstreams
+			}
+			return err
+		},
+		func() (err error) {
+			zones, err := client.GetStreamServerZones()
+			if err == nil {
+				stats.StreamServerZones = *zones
+			}
+			return err
+		},
+		func() (err error) {
+			upstreams, err := client.GetStreamUpstreams()
+			if err == nil {
+				stats.StreamUpstreams = *upstreams
+			}
+			return err
+		},
+		func() (err error) {
+			stats.StreamZoneSync, err = client.GetStreamZoneSync()
+			return err
+		},
+		func() (err error) {
+			zones, err := client.GetLocationZones()
+			if err == nil {
+				stats.LocationZones = *zones
+			}
+			return err
+		},
+		func() (err error) {
+			resolvers, err := client.GetResolvers()
+			if err == nil {
+				stats.Resolvers = *resolvers
+			}
+			return err
+		},
+		func() (err error) {
+			limits, err := client.GetHTTPLimitReqs()
+			if err == nil {
+				stats.HTTPLimitRequests = *limits
+			}
+			return err
+		},
+		func() (err error) {
+			limits, err := client.GetHTTPConnectionsLimit()
+			if err == nil {
+				stats.HTTPLimitConnections = *limits
+			}
+			return err
+		},
+		func() (err error) {
+			limits, err := client.GetStreamConnectionsLimit()
+			if err == nil {
+				stats.StreamLimitConnections = *limits
+			}
+			return err
+		},
+	}
+
+	sem := make(chan struct{}, plusAPIFetchWorkers)
+	wg := &sync.WaitGroup{}
+	var errMutex sync.Mutex
+	var firstErr error
+
+	for _, fetch := range fetches {
+		wg.Add(1)
+		go func(fetch func() error) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if err := fetch(); err != nil {
+				errMutex.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				errMutex.Unlock()
+			}
+		}(fetch)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, fmt.Errorf("failed to get stats: %w", firstErr)
+	}
+	return stats, nil
+}
@@ -0,0 +1,163 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sources
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	plusclient "github.com/nginxinc/nginx-plus-go-client/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalTransportReplaysNotModified(t *testing.T) {
+	fullResponses := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullResponses++
+		rw.Header().Set("ETag", `"v1"`)
+		_, _ = rw.Write([]byte(`{"version":"1.27.0"}`))
+	}))
+	defer server.Close()
+
+	client := newPlusHTTPClient()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL + "/nginx")
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+
+		// the 304s after the first response are replayed from the cache, so every
+		// round trip looks like a full 200 to the plus client
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `{"version":"1.27.0"}`, string(body))
+	}
+	assert.Equal(t, 1, fullResponses)
+}
+
+func TestConditionalTransportSkipsNonGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Empty(t, req.Header.Get("If-None-Match"))
+		rw.Header().Set("ETag", `"v1"`)
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newPlusHTTPClient()
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(server.URL+"/nginx", "application/json", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+}
+
+func newFakePlusAPIServer(t *testing.T, inFlight *int, maxInFlight *int, mutex *sync.Mutex) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api" {
+			_, _ = rw.Write([]byte(`[4,5,6,7,8]`))
+			return
+		}
+
+		mutex.Lock()
+		*inFlight++
+		if *inFlight > *maxInFlight {
+			*maxInFlight = *inFlight
+		}
+		mutex.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		defer func() {
+			mutex.Lock()
+			*inFlight--
+			mutex.Unlock()
+		}()
+
+		switch req.URL.Path {
+		case "/api/8/nginx":
+			_, _ = rw.Write([]byte(`{"version":"1.27.0","build":"nginx-plus-r32"}`))
+		case "/api/8/connections":
+			_, _ = rw.Write([]byte(`{"accepted":10,"dropped":0,"active":1,"idle":0}`))
+		default:
+			_, _ = rw.Write([]byte(`{}`))
+		}
+	}))
+}
+
+func TestGetPlusStats(t *testing.T) {
+	var mutex sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := newFakePlusAPIServer(t, &inFlight, &maxInFlight, &mutex)
+	defer server.Close()
+
+	client, err := plusclient.NewNginxClientWithVersion(newPlusHTTPClient(), server.URL+"/api", 8)
+	require.NoError(t, err)
+
+	stats, err := getPlusStats(client)
+	require.NoError(t, err)
+	require.NotNil(t, stats)
+
+	assert.Equal(t, "1.27.0", stats.NginxInfo.Version)
+	assert.Equal(t, "nginx-plus-r32", stats.NginxInfo.Build)
+	assert.Equal(t, uint64(10), stats.Connections.Accepted)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.LessOrEqual(t, maxInFlight, plusAPIFetchWorkers)
+	assert.Greater(t, maxInFlight, 1)
+}
+
+func TestGetPlusStatsEndpointError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api":
+			_, _ = rw.Write([]byte(`[4,5,6,7,8]`))
+		case "/api/8/slabs":
+			rw.WriteHeader(http.StatusInternalServerError)
+			_, _ = rw.Write([]byte(`{}`))
+		default:
+			_, _ = rw.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := plusclient.NewNginxClientWithVersion(newPlusHTTPClient(), server.URL+"/api", 8)
+	require.NoError(t, err)
+
+	stats, err := getPlusStats(client)
+	assert.Nil(t, stats)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get stats")
+}
+
+func TestEnsureClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[4,5,6,7,8]`))
+	}))
+	defer server.Close()
+
+	plus := NewNginxPlus(nil, "nginx", "plus", server.URL, 8)
+
+	client, err := plus.ensureClient()
+	require.NoError(t, err)
+	require.NotNil(t, client)
+
+	// the client is reused until the endpoint changes or a collection drops it
+	again, err := plus.ensureClient()
+	require.NoError(t, err)
+	assert.Same(t, client, again)
+}
@@ -9,7 +9,13 @@ package tailer
 
 import (
 	"context"
+	"encoding/json"
 	"io"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/nxadm/tail"
@@ -64,6 +70,22 @@ type PatternTailer struct {
 	gc     *grok.CompiledGrok
 }
 
+// JSONTailer tails a log written with log_format escape=json, decoding each line
+// as a JSON object keyed by variable name instead of tokenizing it with a pattern
+type JSONTailer struct {
+	handle *tail.Tail
+}
+
+// SyslogTailer receives log lines sent to a local syslog destination, for
+// deployments that write access_log syslog:server=... and leave nothing on
+// disk to tail. It listens on the address nginx sends to, strips the syslog
+// header from each datagram and decodes the message like the file tailers do:
+// with the compiled pattern, or as JSON when no patterns are given.
+type SyslogTailer struct {
+	conn net.PacketConn
+	gc   *grok.CompiledGrok
+}
+
 func NewTailer(file string) (*Tailer, error) {
 	t, err := tail.TailFile(file, tailConfig)
 	if err != nil {
@@ -93,6 +115,53 @@ func NewPatternTailer(file string, patterns map[string]string) (*PatternTailer,
 	return &PatternTailer{t, gc}, nil
 }
 
+func NewJSONTailer(file string) (*JSONTailer, error) {
+	t, err := tail.TailFile(file, tailConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONTailer{t}, nil
+}
+
+// NewSyslogTailer listens on address, either host:port for syslog over UDP or
+// unix:/path for a datagram socket. A nil patterns map means the messages are
+// JSON encoded lines.
+func NewSyslogTailer(address string, patterns map[string]string) (*SyslogTailer, error) {
+	var gc *grok.CompiledGrok
+	if patterns != nil {
+		g, err := grok.New(grok.Config{
+			NamedCapturesOnly: false,
+			Patterns:          patterns,
+		})
+		if err != nil {
+			return nil, err
+		}
+		gc, err = g.Compile("%{DEFAULT}")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := listenSyslog(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SyslogTailer{conn, gc}, nil
+}
+
+func listenSyslog(address string) (net.PacketConn, error) {
+	if strings.HasPrefix(address, "unix:") {
+		path := strings.TrimPrefix(address, "unix:")
+		// nginx reconnects on its own, so a stale socket from a previous run
+		// can be replaced
+		os.Remove(path)
+		return net.ListenPacket("unixgram", path)
+	}
+	return net.ListenPacket("udp", address)
+}
+
 func (t *Tailer) Tail(ctx context.Context, data chan<- string) {
 	for {
 		select {
@@ -142,3 +211,116 @@ func (t *PatternTailer) Tail(ctx context.Context, data chan<- map[string]string)
 		}
 	}
 }
+
+func (t *JSONTailer) Tail(ctx context.Context, data chan<- map[string]string) {
+	for {
+		select {
+		case line := <-t.handle.Lines:
+			if line == nil {
+				return
+			}
+			if line.Err != nil {
+				continue
+			}
+
+			l, err := decodeJSONLine(line.Text)
+			if err != nil {
+				log.Debugf("unable to decode JSON log line %q: %v", line.Text, err)
+				continue
+			}
+			data <- l
+
+		case <-ctx.Done():
+			err := ctx.Err()
+			if err != nil {
+				log.Errorf("error in done context Tail %v", err)
+			}
+			log.Info("tailer is done")
+			return
+		}
+	}
+}
+
+func (t *SyslogTailer) Tail(ctx context.Context, data chan<- map[string]string) {
+	// closing the connection is what unblocks ReadFrom when the context ends
+	go func() {
+		<-ctx.Done()
+		t.conn.Close()
+	}()
+
+	buf := make([]byte, 8192)
+	for {
+		n, _, err := t.conn.ReadFrom(buf)
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				log.Info("syslog tailer is done")
+			default:
+				log.Errorf("error reading from syslog listener: %v", err)
+				t.conn.Close()
+			}
+			return
+		}
+
+		message := stripSyslogHeader(strings.TrimRight(string(buf[:n]), "\n"))
+
+		var l map[string]string
+		if t.gc != nil {
+			l = t.gc.ParseString(message)
+		} else {
+			l, err = decodeJSONLine(message)
+			if err != nil {
+				log.Debugf("unable to decode JSON log line %q: %v", message, err)
+				continue
+			}
+		}
+		if l != nil {
+			data <- l
+		}
+	}
+}
+
+// syslogHeader matches the header nginx puts in front of each message:
+// priority, a BSD timestamp, the hostname and the configured tag
+var syslogHeader = regexp.MustCompile(`^<\d{1,3}>[A-Z][a-z]{2}\s+\d{1,2} \d{2}:\d{2}:\d{2} \S+ [^\s:]+: `)
+
+func stripSyslogHeader(message string) string {
+	if header := syslogHeader.FindString(message); header != "" {
+		return message[len(header):]
+	}
+	return message
+}
+
+// decodeJSONLine flattens one JSON encoded log line into the same field name to
+// value map that the pattern tailer produces
+func decodeJSONLine(line string) (map[string]string, error) {
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+
+	fields := map[string]interface{}{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+
+	decoded := make(map[string]string, len(fields))
+	for name, value := range fields {
+		switch v := value.(type) {
+		case string:
+			decoded[name] = v
+		case json.Number:
+			decoded[name] = v.String()
+		case bool:
+			decoded[name] = strconv.FormatBool(v)
+		case nil:
+			decoded[name] = ""
+		default:
+			nested, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			decoded[name] = string(nested)
+		}
+	}
+
+	return decoded, nil
+}
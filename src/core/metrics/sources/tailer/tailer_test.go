@@ -9,6 +9,7 @@ package tailer
 
 import (
 	"context"
+	"net"
 	"os"
 	"testing"
 	"time"
@@ -32,6 +33,29 @@ func TestNewNginxAccessItem(t *testing.T) {
 	assert.Equal(t, "456", actual.BytesSent)
 }
 
+func TestDecodeJSONLine(t *testing.T) {
+	parsed, err := decodeJSONLine(
+		`{"remote_addr":"127.0.0.1","request":"GET /500 HTTP/1.1","status":"500","body_bytes_sent":4,"request_time":0.005,"gzip_ratio":null,"pipelined":false}`,
+	)
+	require.Nil(t, err)
+	assert.Equal(
+		t,
+		map[string]string{
+			"remote_addr":     "127.0.0.1",
+			"request":         "GET /500 HTTP/1.1",
+			"status":          "500",
+			"body_bytes_sent": "4",
+			"request_time":    "0.005",
+			"gzip_ratio":      "",
+			"pipelined":       "false",
+		},
+		parsed,
+	)
+
+	_, err = decodeJSONLine(`127.0.0.1 - - "GET /500 HTTP/1.1" 500 4`)
+	assert.NotNil(t, err)
+}
+
 func TestGrok(t *testing.T) {
 	g, err := grok.New(grok.Config{
 		NamedCapturesOnly: true,
@@ -137,3 +161,105 @@ T:
 	os.Remove(accessLogFile.Name())
 	assert.Equal(t, 1, count)
 }
+
+func TestSyslogTailer(t *testing.T) {
+	tailer, err := NewSyslogTailer("127.0.0.1:0", defaultPatterns)
+	require.Nil(t, err)
+
+	timeoutDuration := time.Millisecond * 300
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	defer cancel()
+
+	data := make(chan map[string]string, 100)
+	go tailer.Tail(ctx, data)
+
+	conn, err := net.Dial("udp", tailer.conn.LocalAddr().String())
+	require.Nil(t, err)
+	defer conn.Close()
+
+	message := `<190>May 19 09:30:39 test-host nginx: 127.0.0.1 - - [19/May/2022:09:30:39 +0000] "GET /nginx_status HTTP/1.1" 500 98 "-" "Go-http-client/1.1"`
+	_, err = conn.Write([]byte(message))
+	require.Nil(t, err)
+
+	select {
+	case d := <-data:
+		assert.Equal(t, "500", d["status"])
+		assert.Equal(t, "GET /nginx_status HTTP/1.1", d["request"])
+	case <-time.After(timeoutDuration):
+		t.Fatalf("Timed out waiting for the syslog message")
+	}
+}
+
+func TestSyslogTailerJSON(t *testing.T) {
+	tailer, err := NewSyslogTailer("127.0.0.1:0", nil)
+	require.Nil(t, err)
+
+	timeoutDuration := time.Millisecond * 300
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	defer cancel()
+
+	data := make(chan map[string]string, 100)
+	go tailer.Tail(ctx, data)
+
+	conn, err := net.Dial("udp", tailer.conn.LocalAddr().String())
+	require.Nil(t, err)
+	defer conn.Close()
+
+	message := `<190>May 19 09:30:39 test-host nginx: {"remote_addr":"127.0.0.1","request":"GET /500 HTTP/1.1","status":"500"}`
+	_, err = conn.Write([]byte(message))
+	require.Nil(t, err)
+
+	select {
+	case d := <-data:
+		assert.Equal(t, "500", d["status"])
+		assert.Equal(t, "GET /500 HTTP/1.1", d["request"])
+	case <-time.After(timeoutDuration):
+		t.Fatalf("Timed out waiting for the syslog message")
+	}
+}
+
+func TestStripSyslogHeader(t *testing.T) {
+	assert.Equal(
+		t,
+		`127.0.0.1 - - "GET / HTTP/1.1" 200 4`,
+		stripSyslogHeader(`<190>Oct  1 22:14:15 host nginx: 127.0.0.1 - - "GET / HTTP/1.1" 200 4`),
+	)
+	// lines without a syslog header pass through untouched
+	assert.Equal(
+		t,
+		`127.0.0.1 - - "GET / HTTP/1.1" 200 4`,
+		stripSyslogHeader(`127.0.0.1 - - "GET / HTTP/1.1" 200 4`),
+	)
+}
+
+func BenchmarkGrokParseAccessLogLine(b *testing.B) {
+	g, err := grok.New(grok.Config{
+		NamedCapturesOnly: true,
+		Patterns:          defaultPatterns,
+	})
+	require.Nil(b, err)
+	gc, err := g.Compile("%{DEFAULT}")
+	require.Nil(b, err)
+
+	line := `127.0.0.1 - - [04/Nov/2020:19:40:38 +0000] "GET /500 HTTP/1.1" 500 4 "-" "curl/7.64.1"`
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if parsed := gc.ParseString(line); parsed == nil {
+			b.Fatal("line did not parse")
+		}
+	}
+}
+
+func BenchmarkDecodeJSONLine(b *testing.B) {
+	line := `{"remote_addr":"127.0.0.1","request":"GET /500 HTTP/1.1","status":"500","body_bytes_sent":4,"request_time":0.005}`
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := decodeJSONLine(line); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
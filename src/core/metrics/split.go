@@ -0,0 +1,64 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package metrics
+
+import (
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+// splitFieldsMargin covers the varint encodings of the split_sequence and
+// split_total fields, which are not known until the chunk count is
+const splitFieldsMargin = 12
+
+// SplitReport splits a metrics report that would exceed maxBytes on the wire
+// into several smaller ones. The stats entities are partitioned in order, so
+// the split is deterministic for a given report. All parts share the metadata
+// of the original report and carry a 1-based SplitSequence together with the
+// SplitTotal count, so a receiver can reassemble the report by collecting
+// messages with the same message id. Reports that fit within maxBytes are
+// returned as-is, as is any report when maxBytes is zero or negative.
+func SplitReport(report *proto.MetricsReport, maxBytes int) []*proto.MetricsReport {
+	if maxBytes <= 0 || report.Size() <= maxBytes {
+		return []*proto.MetricsReport{report}
+	}
+
+	baseSize := (&proto.MetricsReport{Meta: report.GetMeta(), Type: report.GetType()}).Size() + splitFieldsMargin
+
+	var chunks [][]*proto.StatsEntity
+	var current []*proto.StatsEntity
+	size := baseSize
+	for _, entity := range report.GetData() {
+		// the field tag and length prefix of a data entry add a few bytes on
+		// top of the entity itself
+		entitySize := entity.Size() + 5
+		if len(current) > 0 && size+entitySize > maxBytes {
+			chunks = append(chunks, current)
+			current = nil
+			size = baseSize
+		}
+		// an entity too large to fit in any message gets a chunk of its own
+		// rather than being dropped
+		current = append(current, entity)
+		size += entitySize
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+
+	reports := make([]*proto.MetricsReport, 0, len(chunks))
+	for idx, chunk := range chunks {
+		reports = append(reports, &proto.MetricsReport{
+			Meta:          report.GetMeta(),
+			Type:          report.GetType(),
+			Data:          chunk,
+			SplitSequence: uint32(idx + 1),
+			SplitTotal:    uint32(len(chunks)),
+		})
+	}
+	return reports
+}
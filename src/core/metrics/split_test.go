@@ -0,0 +1,88 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+func splitTestReport(entities int) *proto.MetricsReport {
+	data := make([]*proto.StatsEntity, 0, entities)
+	for i := 0; i < entities; i++ {
+		data = append(data, &proto.StatsEntity{
+			Dimensions: []*proto.Dimension{
+				{Name: "hostname", Value: fmt.Sprintf("vhost-%d.example.com", i)},
+			},
+			Simplemetrics: []*proto.SimpleMetric{
+				{Name: "nginx.http.request.count", Value: float64(i)},
+			},
+		})
+	}
+	return &proto.MetricsReport{
+		Meta: &proto.Metadata{MessageId: "report-1"},
+		Type: proto.MetricsReport_INSTANCE,
+		Data: data,
+	}
+}
+
+func TestSplitReportFits(t *testing.T) {
+	report := splitTestReport(3)
+
+	reports := SplitReport(report, 4194304)
+	assert.Len(t, reports, 1)
+	assert.Same(t, report, reports[0])
+	assert.Equal(t, uint32(0), reports[0].GetSplitSequence())
+	assert.Equal(t, uint32(0), reports[0].GetSplitTotal())
+
+	// a zero limit disables splitting
+	assert.Len(t, SplitReport(splitTestReport(100), 0), 1)
+}
+
+func TestSplitReportSplits(t *testing.T) {
+	report := splitTestReport(50)
+	maxBytes := 512
+	assert.Greater(t, report.Size(), maxBytes)
+
+	reports := SplitReport(report, maxBytes)
+	assert.Greater(t, len(reports), 1)
+
+	var reassembled []*proto.StatsEntity
+	for idx, chunk := range reports {
+		assert.LessOrEqual(t, chunk.Size(), maxBytes)
+		assert.Equal(t, report.GetMeta().GetMessageId(), chunk.GetMeta().GetMessageId())
+		assert.Equal(t, report.GetType(), chunk.GetType())
+		assert.Equal(t, uint32(idx+1), chunk.GetSplitSequence())
+		assert.Equal(t, uint32(len(reports)), chunk.GetSplitTotal())
+		reassembled = append(reassembled, chunk.GetData()...)
+	}
+
+	// splitting is deterministic and order preserving
+	assert.Equal(t, report.GetData(), reassembled)
+	assert.Equal(t, reports, SplitReport(report, maxBytes))
+}
+
+func TestSplitReportOversizedEntity(t *testing.T) {
+	report := splitTestReport(2)
+	report.Data = append(report.Data, &proto.StatsEntity{
+		Dimensions: []*proto.Dimension{
+			{Name: "display_name", Value: strings.Repeat("x", 1024)},
+		},
+	})
+
+	// an entity larger than the limit still goes out in a chunk of its own
+	reports := SplitReport(report, 256)
+	assert.Len(t, reports, 2)
+	assert.Len(t, reports[1].GetData(), 1)
+	assert.Greater(t, reports[1].Size(), 256)
+}
@@ -31,6 +31,7 @@ const (
 	hexDigit  = "0123456789abcdef"
 	linuxFile = "/proc/net/route"
 	FREEBSD   = "freebsd"
+	OPENBSD   = "openbsd"
 	SOLARIS   = "solaris"
 	DARWIN    = "darwin"
 	LINUX     = "linux"
@@ -65,6 +66,8 @@ func GetNetOverflow() (float64, error) {
 	switch runtime.GOOS {
 	case FREEBSD:
 		return freeBsdSolaris(Netstat, NetstatFlags, overflows)
+	case OPENBSD:
+		return freeBsdSolaris(Netstat, NetstatFlags, overflows)
 	case SOLARIS:
 		return freeBsdSolaris(Netstat, NetstatFlags, overflows)
 	case DARWIN:
@@ -175,6 +178,8 @@ func getDefaultNetworkInterfaceCrossPlatform() (string, error) {
 	switch runtime.GOOS {
 	case FREEBSD:
 		return getInterfaceUsing(Netstat, NetstatFlags)
+	case OPENBSD:
+		return getInterfaceUsing(Netstat, NetstatFlags)
 	case SOLARIS:
 		return getInterfaceUsing(Netstat, NetstatFlags)
 	case DARWIN:
@@ -11,6 +11,7 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -20,7 +21,6 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -41,6 +41,10 @@ var (
 	unpackMutex sync.Mutex
 	re          = regexp.MustCompile(`(?P<name>\S+)/(?P<version>\S+)`)
 	plusre      = regexp.MustCompile(`(?P<name>\S+)/(?P<version>\S+).\((?P<plus>\S+plus\S+)\)`)
+
+	// ErrNoNginxInstances is returned by operations that need a running NGINX
+	// master process when none is found
+	ErrNoNginxInstances = errors.New("no running NGINX master process found")
 )
 
 type NginxBinary interface {
@@ -55,6 +59,7 @@ type NginxBinary interface {
 	UpdateNginxDetailsFromProcesses(nginxProcesses []Process)
 	WriteConfig(config *proto.NginxConfig) (*sdk.ConfigApply, error)
 	ReadConfig(path, nginxId, systemId string) (*proto.NginxConfig, error)
+	GetParsedConfig(nginxId string) *sdk.ParsedConfig
 	UpdateLogs(existingLogs map[string]string, newLogs map[string]string) bool
 	GetAccessLogs() map[string]string
 	GetErrorLogs() map[string]string
@@ -64,11 +69,13 @@ type NginxBinary interface {
 type NginxBinaryType struct {
 	detailsMapMutex   sync.Mutex
 	workersMapMutex   sync.Mutex
+	parsedConfigMutex sync.Mutex
 	env               Environment
 	config            *config.Config
 	nginxDetailsMap   map[string]*proto.NginxDetails
 	nginxWorkersMap   map[string][]*proto.NginxDetails
 	nginxInfoMap      map[string]*nginxInfo
+	parsedConfigs     map[string]*sdk.ParsedConfig
 	accessLogs        map[string]string
 	errorLogs         map[string]string
 	accessLogsUpdated bool
@@ -92,12 +99,15 @@ type nginxInfo struct {
 }
 
 func NewNginxBinary(env Environment, config *config.Config) *NginxBinaryType {
+	sdk.SetAuxFileIOWorkers(config.Nginx.ConfigCollectWorkers)
+	SetConfigApplyFsyncDirs(config.Nginx.ConfigApplyFsyncDirs)
 	return &NginxBinaryType{
-		env:          env,
-		nginxInfoMap: make(map[string]*nginxInfo),
-		accessLogs:   make(map[string]string),
-		errorLogs:    make(map[string]string),
-		config:       config,
+		env:           env,
+		nginxInfoMap:  make(map[string]*nginxInfo),
+		parsedConfigs: make(map[string]*sdk.ParsedConfig),
+		accessLogs:    make(map[string]string),
+		errorLogs:     make(map[string]string),
+		config:        config,
 	}
 }
 
@@ -124,6 +134,17 @@ func (n *NginxBinaryType) UpdateNginxDetailsFromProcesses(nginxProcesses []Proce
 			n.nginxWorkersMap[nginxDetails.GetNginxId()] = append(n.nginxWorkersMap[nginxDetails.GetNginxId()], nginxDetails)
 		}
 	}
+
+	// attach the worker topology to the master details, so instance details show
+	// the process tree and worker start times
+	for nginxID, details := range n.nginxDetailsMap {
+		for _, worker := range n.nginxWorkersMap[nginxID] {
+			details.Workers = append(details.Workers, &proto.NginxWorkerProcess{
+				ProcessId: worker.ProcessId,
+				StartTime: worker.StartTime,
+			})
+		}
+	}
 }
 
 func (n *NginxBinaryType) GetChildProcesses() map[string][]*proto.NginxDetails {
@@ -158,6 +179,9 @@ func (n *NginxBinaryType) GetNginxDetailsByID(nginxID string) *proto.NginxDetail
 
 func (n *NginxBinaryType) sanitizeProcessPath(nginxProcess *Process) bool {
 	defaulted := false
+	if nginxProcess.Path == "" {
+		nginxProcess.Path = pathFromProcessCommand(nginxProcess.Command)
+	}
 	if nginxProcess.Path == "" {
 		nginxProcess.Path = defaultToNginxCommandForProcessPath()
 		defaulted = true
@@ -203,15 +227,42 @@ func (n *NginxBinaryType) GetNginxDetailsFromProcess(nginxProcess Process) *prot
 		nginxDetailsFacade.ConfPath = path
 	}
 
-	url, err := sdk.GetStatusApiInfo(nginxDetailsFacade.ConfPath)
+	// one parse of the configuration serves both the status API detection and
+	// the loaded module inventory
+	parsed, err := sdk.ParseConfig(nginxDetailsFacade.ConfPath)
+	if err != nil {
+		log.Tracef("Unable to parse the configuration, status API detection and module inventory are unavailable for this system: %v", err)
+		return nginxDetailsFacade
+	}
+
+	url, err := parsed.StatusApi()
 	if err != nil {
 		log.Tracef("Unable to get status api from the configuration: NGINX metrics will be unavailable for this system. please configure a status API to get NGINX metrics: %v", err)
 	}
 	nginxDetailsFacade.StatusUrl = url
 
+	nginxDetailsFacade.LoadedModules = parsed.LoadedModules()
+
 	return nginxDetailsFacade
 }
 
+// pathFromProcessCommand extracts the binary path from an nginx master process
+// title like "nginx: master process /opt/nginx/sbin/nginx -c /etc/nginx.conf",
+// which recovers binaries installed under custom prefixes when the executable
+// path itself could not be read
+func pathFromProcessCommand(command string) string {
+	const masterPrefix = "master process "
+	at := strings.Index(command, masterPrefix)
+	if at == -1 {
+		return ""
+	}
+	fields := strings.Fields(command[at+len(masterPrefix):])
+	if len(fields) == 0 || !filepath.IsAbs(fields[0]) {
+		return ""
+	}
+	return fields[0]
+}
+
 func defaultToNginxCommandForProcessPath() string {
 	log.Debug("Defaulting to NGINX on path")
 
@@ -248,7 +299,7 @@ func (n *NginxBinaryType) Reload(processId, bin string) error {
 		return err
 	}
 
-	err = syscall.Kill(intProcess, syscall.SIGHUP)
+	err = signalNginxReload(intProcess, bin)
 	if err != nil {
 		log.Errorf("Reloading NGINX caused error: %v", err)
 	} else {
@@ -296,7 +347,7 @@ func ensureFilesAllowed(files []*proto.File, allowList map[string]struct{}, path
 		}
 		log.Tracef("checking file %s is allowed", filename)
 		if !allowedFile(filename, allowList) {
-			return fmt.Errorf("the file %s is outside the allowed directory list", filename)
+			return fmt.Errorf("%w: %s", sdk.ErrNotAllowedDirectory, filename)
 		}
 	}
 	return nil
@@ -331,18 +382,24 @@ func (n *NginxBinaryType) WriteConfig(config *proto.NginxConfig) (*sdk.ConfigApp
 		return nil, fmt.Errorf("NGINX instance %s not found", config.ConfigData.NginxId)
 	}
 
+	policy := n.config.InstancePolicyFor(details.ConfPath, details.ProcessPath, details.ProcessId)
+	if policy != nil && policy.DisableConfigApply {
+		return nil, fmt.Errorf("config apply is disabled by instance policy for NGINX instance %s", config.ConfigData.NginxId)
+	}
+	allowedDirectories := n.config.AllowedDirectoriesFor(policy)
+
 	systemNginxConfig, err := sdk.GetNginxConfig(
 		details.ConfPath,
 		config.ConfigData.NginxId,
 		config.ConfigData.SystemId,
-		n.config.AllowedDirectoriesMap,
+		allowedDirectories,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	if !allowedFile(filepath.Dir(details.ConfPath), n.config.AllowedDirectoriesMap) {
-		return nil, fmt.Errorf("config directory %s not allowed", filepath.Dir(details.ConfPath))
+	if !allowedFile(filepath.Dir(details.ConfPath), allowedDirectories) {
+		return nil, fmt.Errorf("%w: config directory %s", sdk.ErrNotAllowedDirectory, filepath.Dir(details.ConfPath))
 	}
 
 	confFiles, auxFiles, err := sdk.GetNginxConfigFiles(config)
@@ -357,12 +414,12 @@ func (n *NginxBinaryType) WriteConfig(config *proto.NginxConfig) (*sdk.ConfigApp
 
 	// Ensure all config files are within the allowed list directories.
 	confDir := filepath.Dir(details.ConfPath)
-	if err := ensureFilesAllowed(confFiles, n.config.AllowedDirectoriesMap, confDir); err != nil {
+	if err := ensureFilesAllowed(confFiles, allowedDirectories, confDir); err != nil {
 		return nil, err
 	}
 
 	// Ensure all aux files are within the allowed list directories.
-	if err := ensureFilesAllowed(auxFiles, n.config.AllowedDirectoriesMap, config.GetZaux().GetRootDirectory()); err != nil {
+	if err := ensureFilesAllowed(auxFiles, allowedDirectories, config.GetZaux().GetRootDirectory()); err != nil {
 		return nil, err
 	}
 
@@ -371,14 +428,14 @@ func (n *NginxBinaryType) WriteConfig(config *proto.NginxConfig) (*sdk.ConfigApp
 
 	log.Info("Updating NGINX config")
 	var configApply *sdk.ConfigApply
-	configApply, err = sdk.NewConfigApply(details.ConfPath, n.config.AllowedDirectoriesMap)
+	configApply, err = sdk.NewConfigApply(details.ConfPath, allowedDirectories)
 	if err != nil {
 		log.Warnf("config_apply error: %s", err)
 		return nil, err
 	}
 
 	// TODO: return to Control Plane that there was a rollback
-	err = n.env.WriteFiles(configApply, confFiles, filepath.Dir(details.ConfPath), n.config.AllowedDirectoriesMap)
+	err = n.env.WriteFiles(configApply, confFiles, filepath.Dir(details.ConfPath), allowedDirectories)
 	if err != nil {
 		log.Warnf("configuration write failed: %s", err)
 		n.writeBackup(config, confFiles, auxFiles)
@@ -387,14 +444,14 @@ func (n *NginxBinaryType) WriteConfig(config *proto.NginxConfig) (*sdk.ConfigApp
 
 	if len(auxFiles) > 0 {
 		auxPath := config.GetZaux().GetRootDirectory()
-		err = n.env.WriteFiles(configApply, auxFiles, auxPath, n.config.AllowedDirectoriesMap)
+		err = n.env.WriteFiles(configApply, auxFiles, auxPath, allowedDirectories)
 		if err != nil {
 			log.Warnf("Auxiliary files write failed: %s", err)
 			return configApply, err
 		}
 	}
 
-	filesToDelete, ok := generateDeleteFromDirectoryMap(config.DirectoryMap, n.config.AllowedDirectoriesMap)
+	filesToDelete, ok := generateDeleteFromDirectoryMap(config.DirectoryMap, allowedDirectories)
 	if ok {
 		log.Debugf("use explicit set action for delete files %s", filesToDelete)
 	} else {
@@ -466,11 +523,34 @@ func generateDeleteFromDirectoryMap(
 }
 
 func (n *NginxBinaryType) ReadConfig(confFile, nginxId, systemId string) (*proto.NginxConfig, error) {
-	configPayload, err := sdk.GetNginxConfig(confFile, nginxId, systemId, n.config.AllowedDirectoriesMap)
+	allowedDirectories := n.config.AllowedDirectoriesMap
+	if details := n.nginxDetailsMap[nginxId]; details != nil {
+		policy := n.config.InstancePolicyFor(details.ConfPath, details.ProcessPath, details.ProcessId)
+		if policy != nil && policy.DisableAuxCollection {
+			// restricting the collection to the conf directory leaves the root,
+			// alias and certificate files of the instance out of the payload
+			allowedDirectories = map[string]struct{}{filepath.Dir(confFile): {}}
+		} else {
+			allowedDirectories = n.config.AllowedDirectoriesFor(policy)
+		}
+	}
+
+	parsed, err := sdk.ParseConfig(confFile)
+	if err != nil {
+		return nil, err
+	}
+
+	configPayload, err := parsed.NginxConfig(nginxId, systemId, allowedDirectories)
 	if err != nil {
 		return nil, err
 	}
 
+	// keep the parse so the rest of the collection cycle, such as the app
+	// protect metadata update, consumes it instead of parsing the config again
+	n.parsedConfigMutex.Lock()
+	n.parsedConfigs[nginxId] = parsed
+	n.parsedConfigMutex.Unlock()
+
 	// get access logs list for analysis
 	accessLogs := AccessLogs(configPayload)
 	// get error logs list for analysis
@@ -485,6 +565,14 @@ func (n *NginxBinaryType) ReadConfig(confFile, nginxId, systemId string) (*proto
 	return configPayload, nil
 }
 
+// GetParsedConfig returns the parse produced by the instance's last ReadConfig,
+// or nil when the instance's config has not been read yet
+func (n *NginxBinaryType) GetParsedConfig(nginxId string) *sdk.ParsedConfig {
+	n.parsedConfigMutex.Lock()
+	defer n.parsedConfigMutex.Unlock()
+	return n.parsedConfigs[nginxId]
+}
+
 func (n *NginxBinaryType) GetAccessLogs() map[string]string {
 	logMutex.Lock()
 	defer logMutex.Unlock()
@@ -795,13 +883,20 @@ func runtimeFromConfigure(configure []string) []string {
 func AccessLogs(p *proto.NginxConfig) map[string]string {
 	var found = make(map[string]string)
 	for _, accessLog := range p.GetAccessLogs().GetAccessLog() {
+		// syslog destinations are not files, the agent listens for them instead
+		if name := strings.Split(accessLog.GetName(), " ")[0]; strings.HasPrefix(name, "syslog:") {
+			found[name] = accessLog.GetFormat()
+			continue
+		}
 		// check if the access log is readable or not
 		if accessLog.GetReadable() && accessLog.GetName() != "off" {
 			name := strings.Split(accessLog.GetName(), " ")[0]
 			format := accessLog.GetFormat()
 			found[name] = format
-		} else {
+		} else if name := strings.Split(accessLog.GetName(), " ")[0]; name == "off" {
 			log.Warnf("NGINX Access log %s is not readable or is disabled. Please make it readable and enabled in order for NGINX metrics to be collected.", accessLog.GetName())
+		} else {
+			log.Warnf("NGINX Access log %s is not readable or is disabled. Please make it readable and enabled in order for NGINX metrics to be collected. %s", accessLog.GetName(), sdk.DiagnoseLogAccess(name))
 		}
 	}
 
@@ -818,7 +913,8 @@ func ErrorLogs(p *proto.NginxConfig) map[string]string {
 			// In the future, different error log formats will be supported
 			found[name] = ""
 		} else {
-			log.Warnf("NGINX Error log %s is not readable or is disabled. Please make it readable and enabled in order for NGINX metrics to be collected.", errorLog.GetName())
+			name := strings.Split(errorLog.GetName(), " ")[0]
+			log.Warnf("NGINX Error log %s is not readable or is disabled. Please make it readable and enabled in order for NGINX metrics to be collected. %s", errorLog.GetName(), sdk.DiagnoseLogAccess(name))
 		}
 	}
 
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/nginx/agent/sdk/v2"
 	"github.com/nginx/agent/sdk/v2/proto"
 	"github.com/nginx/agent/sdk/v2/zip"
 	"github.com/nginx/agent/v2/src/core/config"
@@ -491,6 +492,43 @@ func TestWriteConfig(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestWriteConfigDisabledByInstancePolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	allowedDirs := make(map[string]struct{})
+	allowedDirs[tmpDir] = struct{}{}
+	fakeConfig := config.Config{
+		AllowedDirectoriesMap: allowedDirs,
+		InstancePolicies: []config.InstancePolicy{
+			{
+				ProcessID:          "777",
+				DisableConfigApply: true,
+			},
+		},
+	}
+
+	env := EnvironmentType{}
+	n := NewNginxBinary(&env, &fakeConfig)
+
+	n.nginxDetailsMap = map[string]*proto.NginxDetails{
+		"151d8728e792f42e129337573a21bb30ab3065d59102f075efc2ded28e713ff8": {
+			NginxId:     "151d8728e792f42e129337573a21bb30ab3065d59102f075efc2ded28e713ff8",
+			ConfPath:    filepath.Join(tmpDir, "/nginx.conf"),
+			ProcessId:   "777",
+			ProcessPath: "/usr/sbin/nginx",
+		},
+	}
+
+	nginxConfig, err := buildConfig(tmpDir)
+	if err != nil {
+		t.Fatal("failed to create test config")
+	}
+
+	configApply, err := n.WriteConfig(nginxConfig)
+	assert.Nil(t, configApply)
+	assert.ErrorContains(t, err, "disabled by instance policy")
+}
+
 func TestWriteConfigWithFileAction(t *testing.T) {
 	tmpDir := t.TempDir()
 	expectedExisting := map[string]struct{}{}
@@ -924,6 +962,7 @@ func TestNginxBinaryType_sanitizeProcessPath(t *testing.T) {
 	type testDef struct {
 		desc      string
 		path      string
+		command   string
 		expect    string
 		defaulted bool
 	}
@@ -932,10 +971,16 @@ func TestNginxBinaryType_sanitizeProcessPath(t *testing.T) {
 	for _, def := range []testDef{
 		{desc: "deleted path", path: "/usr/sbin/nginx (deleted)", expect: "/usr/sbin/nginx"},
 		{desc: "no change path", path: "/usr/sbin/nginx", expect: "/usr/sbin/nginx"},
+		{
+			desc:    "empty path with master process title",
+			command: "nginx: master process /opt/nginx/sbin/nginx -c /opt/nginx/conf/nginx.conf",
+			expect:  "/opt/nginx/sbin/nginx",
+		},
 	} {
 		t.Run(def.desc, func(tt *testing.T) {
 			p := Process{
-				Path: def.path,
+				Path:    def.path,
+				Command: def.command,
 			}
 			binary := NginxBinaryType{
 				env: &EnvironmentType{},
@@ -945,3 +990,21 @@ func TestNginxBinaryType_sanitizeProcessPath(t *testing.T) {
 		})
 	}
 }
+
+func TestEnsureFilesAllowedNotAllowedDirectory(t *testing.T) {
+	allowed := map[string]struct{}{"/etc/nginx/": {}}
+
+	err := ensureFilesAllowed([]*proto.File{{Name: "/etc/nginx/nginx.conf"}}, allowed, "/etc/nginx")
+	assert.NoError(t, err)
+
+	err = ensureFilesAllowed([]*proto.File{{Name: "/etc/passwd"}}, allowed, "/etc/nginx")
+	assert.ErrorIs(t, err, sdk.ErrNotAllowedDirectory)
+}
+
+func TestPathFromProcessCommand(t *testing.T) {
+	assert.Equal(t, "/opt/nginx/sbin/nginx", pathFromProcessCommand("nginx: master process /opt/nginx/sbin/nginx"))
+	assert.Equal(t, "/usr/sbin/nginx", pathFromProcessCommand("nginx: master process /usr/sbin/nginx -c /etc/nginx/nginx.conf"))
+	assert.Equal(t, "", pathFromProcessCommand("nginx: worker process"))
+	assert.Equal(t, "", pathFromProcessCommand("nginx: master process nginx"))
+	assert.Equal(t, "", pathFromProcessCommand(""))
+}
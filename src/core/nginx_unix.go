@@ -0,0 +1,17 @@
+//go:build !windows
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import "syscall"
+
+// signalNginxReload asks the nginx master process to reload its configuration
+func signalNginxReload(pid int, bin string) error {
+	return syscall.Kill(pid, syscall.SIGHUP)
+}
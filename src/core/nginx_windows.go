@@ -0,0 +1,18 @@
+//go:build windows
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import "os/exec"
+
+// signalNginxReload asks the nginx master process to reload its configuration.
+// Windows has no SIGHUP, so the reload is requested through the nginx binary.
+func signalNginxReload(pid int, bin string) error {
+	return exec.Command(bin, "-s", "reload").Run()
+}
@@ -0,0 +1,211 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package objectstore provides a minimal client for S3-compatible object
+// storage, covering only the object operations the agent needs. Requests are
+// signed with AWS Signature Version 4 and addressed path-style, so the client
+// works against AWS S3 as well as self-hosted stores such as MinIO.
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// SSES3 asks the store to encrypt the object with a store-managed key
+	SSES3 = "AES256"
+	// SSEKMS asks the store to encrypt the object with a KMS-managed key
+	SSEKMS = "aws:kms"
+
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	serviceName      = "s3"
+	amzDateFormat    = "20060102T150405Z"
+)
+
+// Client performs object operations against a single bucket
+type Client struct {
+	endpoint    *url.URL
+	region      string
+	bucket      string
+	accessKey   string
+	secretKey   string
+	sse         string
+	sseKMSKeyID string
+	http        *http.Client
+	now         func() time.Time
+}
+
+// NewClient builds a client for the given bucket. An empty endpoint targets
+// AWS S3 in the given region; otherwise the endpoint is used as-is, which is
+// how self-hosted S3-compatible stores are reached.
+func NewClient(endpoint, region, bucket, accessKey, secretKey string) (*Client, error) {
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object store endpoint %q: %v", endpoint, err)
+	}
+	if bucket == "" {
+		return nil, fmt.Errorf("object store bucket is required")
+	}
+
+	return &Client{
+		endpoint:  parsed,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		http:      &http.Client{Timeout: 30 * time.Second},
+		now:       time.Now,
+	}, nil
+}
+
+// SetSSE sets the server-side encryption headers sent with uploads. The KMS
+// key ID is only used with the aws:kms mode.
+func (c *Client) SetSSE(mode, kmsKeyID string) {
+	c.sse = mode
+	c.sseKMSKeyID = kmsKeyID
+}
+
+// GetObject downloads an object and returns its contents and ETag
+func (c *Client) GetObject(ctx context.Context, key string) ([]byte, string, error) {
+	resp, err := c.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to read object %s: %v", key, err)
+	}
+	return contents, resp.Header.Get("ETag"), nil
+}
+
+// HeadObject returns the ETag of an object without downloading it, which is
+// how the caller polls for changes cheaply
+func (c *Client) HeadObject(ctx context.Context, key string) (string, error) {
+	resp, err := c.do(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+// PutObject uploads an object, asking for server-side encryption when the
+// client was configured with an SSE mode
+func (c *Client) PutObject(ctx context.Context, key string, contents []byte) error {
+	resp, err := c.do(ctx, http.MethodPut, key, contents)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, key string, body []byte) (*http.Response, error) {
+	objectURL := *c.endpoint
+	objectURL.Path = "/" + c.bucket + "/" + strings.TrimPrefix(key, "/")
+
+	request, err := http.NewRequestWithContext(ctx, method, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if method == http.MethodPut && c.sse != "" {
+		request.Header.Set("x-amz-server-side-encryption", c.sse)
+		if c.sse == SSEKMS && c.sseKMSKeyID != "" {
+			request.Header.Set("x-amz-server-side-encryption-aws-kms-key-id", c.sseKMSKeyID)
+		}
+	}
+	c.sign(request, body)
+
+	resp, err := c.http.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("object store returned %s for %s %s", resp.Status, method, key)
+	}
+	return resp, nil
+}
+
+// sign adds an AWS Signature Version 4 authorization header to the request,
+// signing every header already present on it
+func (c *Client) sign(request *http.Request, body []byte) {
+	payloadHash := hexSHA256(body)
+	amzDate := c.now().UTC().Format(amzDateFormat)
+	dateStamp := amzDate[:8]
+
+	request.Header.Set("x-amz-date", amzDate)
+	request.Header.Set("x-amz-content-sha256", payloadHash)
+
+	headerNames := []string{"host"}
+	canonicalHeaders := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		lower := strings.ToLower(name)
+		headerNames = append(headerNames, lower)
+		canonicalHeaders[lower] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	sort.Strings(headerNames)
+
+	var headers strings.Builder
+	for _, name := range headerNames {
+		fmt.Fprintf(&headers, "%s:%s\n", name, canonicalHeaders[name])
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.Query().Encode(),
+		headers.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{dateStamp, c.region, serviceName, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := []byte("AWS4" + c.secretKey)
+	for _, part := range []string{dateStamp, c.region, serviceName, "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, c.accessKey, scope, signedHeaders, signature))
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,21 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package payloads
+
+import (
+	"time"
+)
+
+// ClockSkew is published when the offset between the host clock and the
+// control plane clock changes enough to matter. Offset is how far the control
+// plane clock is ahead of the host clock, so adding it to a local timestamp
+// yields control plane time.
+type ClockSkew struct {
+	Offset     time.Duration `json:"offset"`
+	ObservedAt time.Time     `json:"observed_at"`
+}
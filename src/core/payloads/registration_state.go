@@ -0,0 +1,45 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package payloads
+
+import (
+	"time"
+)
+
+// RegistrationState is one of the explicit states registration moves through,
+// so its progress can be followed without reading log output.
+type RegistrationState string
+
+const (
+	// RegistrationConnecting is the initial state, the control plane channel is
+	// being established
+	RegistrationConnecting RegistrationState = "connecting"
+	// RegistrationAuthenticating means the connect request is being assembled
+	// and credentials attached
+	RegistrationAuthenticating RegistrationState = "authenticating"
+	// RegistrationRegistering means the connect request is on its way and the
+	// response is awaited
+	RegistrationRegistering RegistrationState = "registering"
+	// RegistrationRegistered means the control plane accepted the registration
+	RegistrationRegistered RegistrationState = "registered"
+	// RegistrationDegraded means no response arrived in time; registration may
+	// still complete when the control plane becomes reachable
+	RegistrationDegraded RegistrationState = "degraded"
+	// RegistrationAuthRejected is terminal: the control plane rejected the
+	// registration and it will not be retried
+	RegistrationAuthRejected RegistrationState = "auth_rejected"
+)
+
+// RegistrationStateTransition is published on every state change of the
+// registration state machine.
+type RegistrationStateTransition struct {
+	From      RegistrationState `json:"from"`
+	To        RegistrationState `json:"to"`
+	Reason    string            `json:"reason"`
+	Timestamp time.Time         `json:"timestamp"`
+}
@@ -10,13 +10,19 @@ package core
 import (
 	"context"
 	"sync"
+	"time"
 
+	log "github.com/sirupsen/logrus"
 	messagebus "github.com/vardius/message-bus"
 )
 
 const (
 	MessageQueueSize = 100
 	MaxPlugins       = 100
+
+	// DefaultDrainTimeout bounds how long the pipe keeps delivering queued
+	// messages after its context is cancelled, before plugins are closed
+	DefaultDrainTimeout = 5 * time.Second
 )
 
 type MessagePipeInterface interface {
@@ -34,6 +40,7 @@ type MessagePipe struct {
 	cancel         context.CancelFunc
 	mu             sync.Mutex
 	bus            messagebus.MessageBus
+	drainTimeout   time.Duration
 }
 
 func NewMessagePipe(ctx context.Context) *MessagePipe {
@@ -44,9 +51,18 @@ func NewMessagePipe(ctx context.Context) *MessagePipe {
 		ctx:            pipeContext,
 		cancel:         pipeCancel,
 		mu:             sync.Mutex{},
+		drainTimeout:   DefaultDrainTimeout,
 	}
 }
 
+// SetDrainTimeout overrides how long Run keeps delivering queued messages
+// after the pipe's context is cancelled
+func (p *MessagePipe) SetDrainTimeout(timeout time.Duration) {
+	p.mu.Lock()
+	p.drainTimeout = timeout
+	p.mu.Unlock()
+}
+
 func (p *MessagePipe) Register(size int, plugins ...Plugin) error {
 	p.mu.Lock()
 
@@ -82,6 +98,7 @@ func (p *MessagePipe) Run() {
 	for {
 		select {
 		case <-p.ctx.Done():
+			p.drain()
 
 			for _, r := range p.plugins {
 				r.Close()
@@ -97,6 +114,33 @@ func (p *MessagePipe) Run() {
 	}
 }
 
+// drain delivers the messages queued at shutdown before any plugin is closed,
+// so in-flight work such as a config apply still sees its follow-up messages.
+// Messages still queued once the drain timeout expires are dropped.
+func (p *MessagePipe) drain() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	deadline := time.NewTimer(p.drainTimeout)
+	defer deadline.Stop()
+
+	for {
+		select {
+		case m := <-p.messageChannel:
+			p.bus.Publish(m.Topic(), m)
+		case <-deadline.C:
+			if queued := len(p.messageChannel); queued > 0 {
+				log.Warnf("Message pipe drain timed out after %v with %d messages still queued", p.drainTimeout, queued)
+			}
+			return
+		default:
+			if len(p.messageChannel) == 0 {
+				return
+			}
+		}
+	}
+}
+
 func (p *MessagePipe) Context() context.Context {
 	return p.ctx
 }
@@ -109,8 +153,17 @@ func (p *MessagePipe) GetPlugins() []Plugin {
 	return p.plugins
 }
 
+// initPlugins initializes all plugins concurrently: the slow start-up work, like
+// nginx binary discovery or host scans, is independent between plugins, and
+// message delivery only starts once every plugin has finished initializing
 func (p *MessagePipe) initPlugins() {
+	var wg sync.WaitGroup
 	for _, r := range p.plugins {
-		r.Init(p)
+		wg.Add(1)
+		go func(plugin Plugin) {
+			defer wg.Done()
+			plugin.Init(p)
+		}(r)
 	}
+	wg.Wait()
 }
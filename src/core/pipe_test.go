@@ -75,3 +75,40 @@ func TestMessagePipe(t *testing.T) {
 
 	plugin.AssertExpectations(t)
 }
+
+func TestMessagePipeDrainsQueuedMessagesOnShutdown(t *testing.T) {
+	messages := []*Message{
+		NewMessage("test.message", 1),
+		NewMessage("test.message", 2),
+		NewMessage("test.message", 3),
+	}
+
+	plugin := new(testPlugin)
+	plugin.On("Init").Times(1)
+	plugin.On("Process").Times(len(messages))
+	plugin.On("Close").Times(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pipelineDone := make(chan bool)
+
+	messagePipe := NewMessagePipe(ctx)
+	messagePipe.SetDrainTimeout(time.Second)
+	err := messagePipe.Register(10, plugin)
+
+	assert.NoError(t, err)
+
+	go func() {
+		messagePipe.Run()
+		pipelineDone <- true
+	}()
+
+	// cancel with the messages still queued: the pipe has to deliver them
+	// during the drain before closing the plugin
+	messagePipe.Process(messages...)
+	cancel()
+	<-pipelineDone
+
+	time.Sleep(10 * time.Millisecond) // for bus delivery being asynchronous
+
+	plugin.AssertExpectations(t)
+}
@@ -0,0 +1,99 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package systemd
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Notify sends a state message to the service manager through the socket named
+// by NOTIFY_SOCKET, as described in sd_notify(3). It is a no-op when the agent
+// is not supervised by systemd.
+func Notify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+	// a leading @ names a socket in the abstract namespace
+	if socket[0] == '@' {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+// NotifyReady tells the service manager that startup is finished
+func NotifyReady() {
+	if err := Notify("READY=1"); err != nil {
+		log.Warnf("Unable to notify service manager of readiness: %v", err)
+	}
+}
+
+// NotifyStatus passes a single line status string to the service manager
+func NotifyStatus(status string) {
+	if err := Notify("STATUS=" + status); err != nil {
+		log.Warnf("Unable to send status to service manager: %v", err)
+	}
+}
+
+// NotifyStopping tells the service manager that the agent has begun shutting down
+func NotifyStopping() {
+	if err := Notify("STOPPING=1"); err != nil {
+		log.Warnf("Unable to notify service manager of shutdown: %v", err)
+	}
+}
+
+// StartWatchdog sends WATCHDOG=1 keep-alives at half the interval the service
+// manager announces through WATCHDOG_USEC, until the context is done. It
+// reports whether a watchdog is armed for this process.
+func StartWatchdog(ctx context.Context) bool {
+	usec := os.Getenv("WATCHDOG_USEC")
+	if usec == "" {
+		return false
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return false
+	}
+
+	interval, err := strconv.ParseInt(usec, 10, 64)
+	if err != nil || interval <= 0 {
+		log.Warnf("Ignoring invalid WATCHDOG_USEC value %q", usec)
+		return false
+	}
+
+	keepAlive := time.Duration(interval) * time.Microsecond / 2
+	go func() {
+		ticker := time.NewTicker(keepAlive)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := Notify("WATCHDOG=1"); err != nil {
+					log.Warnf("Unable to send watchdog keep-alive to service manager: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return true
+}
@@ -0,0 +1,75 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package systemd
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listenNotifySocket(t *testing.T) (*net.UnixConn, string) {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, socket
+}
+
+func readDatagram(t *testing.T, conn *net.UnixConn) string {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buffer := make([]byte, 1024)
+	n, err := conn.Read(buffer)
+	require.NoError(t, err)
+	return string(buffer[:n])
+}
+
+func TestNotify(t *testing.T) {
+	conn, socket := listenNotifySocket(t)
+	t.Setenv("NOTIFY_SOCKET", socket)
+
+	require.NoError(t, Notify("READY=1"))
+	assert.Equal(t, "READY=1", readDatagram(t, conn))
+}
+
+func TestNotifyWithoutSocket(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	assert.NoError(t, Notify("READY=1"))
+}
+
+func TestStartWatchdog(t *testing.T) {
+	conn, socket := listenNotifySocket(t)
+	t.Setenv("NOTIFY_SOCKET", socket)
+	t.Setenv("WATCHDOG_USEC", "100000")
+	t.Setenv("WATCHDOG_PID", "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.True(t, StartWatchdog(ctx))
+	assert.Equal(t, "WATCHDOG=1", readDatagram(t, conn))
+}
+
+func TestStartWatchdogNotConfigured(t *testing.T) {
+	t.Setenv("WATCHDOG_USEC", "")
+	assert.False(t, StartWatchdog(context.Background()))
+
+	t.Setenv("WATCHDOG_USEC", "not-a-number")
+	assert.False(t, StartWatchdog(context.Background()))
+
+	t.Setenv("WATCHDOG_USEC", "100000")
+	t.Setenv("WATCHDOG_PID", "1")
+	assert.False(t, StartWatchdog(context.Background()))
+}
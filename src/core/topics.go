@@ -12,12 +12,14 @@ const (
 	RegistrationPrefix                   = "registration."
 	RegistrationCompletedTopic           = RegistrationPrefix + "completed"
 	RegisterWithDataplaneSoftwareDetails = RegistrationPrefix + "with.dataplane.software.details"
+	RegistrationStateChanged             = RegistrationPrefix + "state.changed"
 	CommNginxConfig                      = "nginx.config"
 	NginxConfigUpload                    = "nginx.config.upload"
 	NginxReload                          = "nginx.reload"
 	NginxReloadComplete                  = "nginx.reload.complete"
 	NginxStart                           = "nginx.start"
 	NginxStop                            = "nginx.stop"
+	NginxRestart                         = "nginx.restart"
 	NginxPluginConfigured                = "nginx.plugin.config"
 	NginxStatusAPIUpdate                 = "nginx.status.api.update"
 	NginxInstancesFound                  = "nginx.instances.found"
@@ -52,5 +54,16 @@ const (
 	ConfigRollbackResponse               = "config.rollback.response"
 	DataplaneSoftwareDetailsUpdated      = "dataplane.software.details.updated"
 	EnableExtension                      = "enable.extension"
+	CertificateDeploy                    = "certificate.deploy"
+	DebugCapture                         = "debug.capture"
+	KeyvalSync                           = "keyval.sync"
+	AgentUpdate                          = "agent.update"
+	UpstreamDrain                        = "upstream.drain"
+	TrafficMirror                        = "traffic.mirror"
+	UpstreamSwitch                       = "upstream.switch"
+	CollectionDegraded                   = "collection.degraded"
+	ClockSkewDetected                    = "clock.skew.detected"
+	ControlPlaneChanged                  = "control.plane.changed"
+	DockerNginxContainersFound           = "docker.nginx.containers.found"
 	AgentAPIConfigApplyResponse          = "agent.api.config.apply.response"
 )
@@ -0,0 +1,142 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// otlpExporter sends finished spans to an OTLP/HTTP endpoint as the JSON
+// encoding of an ExportTraceServiceRequest. Writing the small amount of JSON
+// by hand keeps the agent off the OTLP exporter module and its gRPC
+// dependency tree.
+type otlpExporter struct {
+	url    string
+	client *http.Client
+}
+
+func newOTLPExporter(endpoint string) (*otlpExporter, error) {
+	if _, err := url.Parse(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid tracing endpoint %q: %v", endpoint, err)
+	}
+	return &otlpExporter{
+		url:    strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (e *otlpExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	encodedSpans := make([]map[string]interface{}, 0, len(spans))
+	for _, span := range spans {
+		encodedSpans = append(encodedSpans, encodeSpan(span))
+	}
+
+	// all the agent's spans share one resource and one scope
+	payload, err := json.Marshal(map[string]interface{}{
+		"resourceSpans": []map[string]interface{}{{
+			"resource": map[string]interface{}{
+				"attributes": encodeAttributes(spans[0].Resource().Attributes()),
+			},
+			"scopeSpans": []map[string]interface{}{{
+				"scope": map[string]string{"name": spans[0].InstrumentationScope().Name},
+				"spans": encodedSpans,
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := e.client.Do(request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("tracing endpoint returned %s", response.Status)
+	}
+	return nil
+}
+
+func (e *otlpExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func encodeSpan(span sdktrace.ReadOnlySpan) map[string]interface{} {
+	encoded := map[string]interface{}{
+		"traceId":           span.SpanContext().TraceID().String(),
+		"spanId":            span.SpanContext().SpanID().String(),
+		"name":              span.Name(),
+		"kind":              int(span.SpanKind()),
+		"startTimeUnixNano": strconv.FormatInt(span.StartTime().UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(span.EndTime().UnixNano(), 10),
+		"attributes":        encodeAttributes(span.Attributes()),
+		"status": map[string]interface{}{
+			"code":    encodeStatusCode(span.Status().Code),
+			"message": span.Status().Description,
+		},
+	}
+	if span.Parent().HasSpanID() {
+		encoded["parentSpanId"] = span.Parent().SpanID().String()
+	}
+	return encoded
+}
+
+// encodeStatusCode maps the SDK status codes onto the OTLP wire values,
+// which order OK and ERROR differently
+func encodeStatusCode(code codes.Code) int {
+	switch code {
+	case codes.Ok:
+		return 1
+	case codes.Error:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func encodeAttributes(attributes []attribute.KeyValue) []map[string]interface{} {
+	encoded := make([]map[string]interface{}, 0, len(attributes))
+	for _, kv := range attributes {
+		var value map[string]interface{}
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			value = map[string]interface{}{"boolValue": kv.Value.AsBool()}
+		case attribute.INT64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(kv.Value.AsInt64(), 10)}
+		case attribute.FLOAT64:
+			value = map[string]interface{}{"doubleValue": kv.Value.AsFloat64()}
+		default:
+			value = map[string]interface{}{"stringValue": kv.Value.Emit()}
+		}
+		encoded = append(encoded, map[string]interface{}{"key": string(kv.Key), "value": value})
+	}
+	return encoded
+}
@@ -0,0 +1,71 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package tracing wires the agent into OpenTelemetry. It installs a global
+// tracer provider exporting over OTLP/HTTP, and carries W3C trace context in
+// and out of the places a trace crosses a process boundary, so config
+// operations can be followed end to end from the control plane. When tracing
+// is not initialized the global provider stays a no-op and instrumented code
+// costs nothing.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "nginx-agent"
+
+// Init installs a global tracer provider exporting to the given OTLP/HTTP
+// endpoint and returns its shutdown function, which flushes pending spans
+func Init(endpoint, serviceName string) (func(context.Context) error, error) {
+	exporter, err := newOTLPExporter(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer the agent instruments with, backed by the global
+// provider so it is a no-op until Init has run
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// ContextWithTraceparent resumes a trace from a W3C traceparent header value,
+// the form trace context travels in between processes. An empty or invalid
+// value leaves the context unchanged.
+func ContextWithTraceparent(ctx context.Context, traceparent string) context.Context {
+	if traceparent == "" {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": traceparent})
+}
+
+// Traceparent renders the trace context of ctx as a W3C traceparent header
+// value, or an empty string when ctx carries no span
+func Traceparent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier["traceparent"]
+}
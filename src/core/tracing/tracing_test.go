@@ -0,0 +1,75 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceparentRoundTrip(t *testing.T) {
+	traceparent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+	ctx := ContextWithTraceparent(context.Background(), traceparent)
+	spanContext := trace.SpanContextFromContext(ctx)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", spanContext.TraceID().String())
+	assert.Equal(t, "b7ad6b7169203331", spanContext.SpanID().String())
+	assert.Equal(t, traceparent, Traceparent(ctx))
+
+	// an empty or invalid value leaves the context without a span
+	assert.Equal(t, "", Traceparent(ContextWithTraceparent(context.Background(), "")))
+	assert.Equal(t, "", Traceparent(ContextWithTraceparent(context.Background(), "not-a-traceparent")))
+}
+
+func TestOTLPExport(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(body, &payload))
+	}))
+	defer server.Close()
+
+	shutdown, err := Init(server.URL, "test-agent")
+	assert.NoError(t, err)
+
+	ctx, span := Tracer().Start(context.Background(), "config.apply")
+	_, child := Tracer().Start(ctx, "config.reload")
+	child.End()
+	span.End()
+
+	assert.NoError(t, shutdown(context.Background()))
+
+	resourceSpans := payload["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	resourceAttributes := resourceSpans["resource"].(map[string]interface{})["attributes"].([]interface{})
+	serviceName := resourceAttributes[0].(map[string]interface{})
+	assert.Equal(t, "service.name", serviceName["key"])
+	assert.Equal(t, map[string]interface{}{"stringValue": "test-agent"}, serviceName["value"])
+
+	spans := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})
+	assert.Len(t, spans, 2)
+
+	names := map[string]map[string]interface{}{}
+	for _, s := range spans {
+		encoded := s.(map[string]interface{})
+		names[encoded["name"].(string)] = encoded
+	}
+	assert.Contains(t, names, "config.apply")
+	assert.Contains(t, names, "config.reload")
+	assert.Equal(t, names["config.apply"]["spanId"], names["config.reload"]["parentSpanId"])
+	assert.Equal(t, names["config.apply"]["traceId"], names["config.reload"]["traceId"])
+}
@@ -0,0 +1,118 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package unit is a minimal client for the NGINX Unit control API, spoken as
+// HTTP over the instance's unix control socket. It covers just what the agent
+// reports: whether an instance is there, which version it runs, a checksum of
+// its current configuration and the request counters of its /status endpoint.
+package unit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Status is the subset of Unit's /status endpoint the agent reports. The
+// endpoint exists since Unit 1.28; older versions return a 404 here.
+type Status struct {
+	Connections struct {
+		Accepted uint64 `json:"accepted"`
+		Active   uint64 `json:"active"`
+		Idle     uint64 `json:"idle"`
+		Closed   uint64 `json:"closed"`
+	} `json:"connections"`
+	Requests struct {
+		Total uint64 `json:"total"`
+	} `json:"requests"`
+}
+
+type Client struct {
+	controlSocket string
+	http          *http.Client
+}
+
+func NewClient(controlSocket string) *Client {
+	return &Client{
+		controlSocket: controlSocket,
+		http: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", controlSocket)
+				},
+			},
+		},
+	}
+}
+
+// Version returns the Unit version, taken from the Server header the control
+// API sends with every response
+func (c *Client) Version(ctx context.Context) (string, error) {
+	response, err := c.get(ctx, "/")
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	server := response.Header.Get("Server")
+	if !strings.HasPrefix(server, "unit/") {
+		return "", fmt.Errorf("unexpected Server header %q from the control socket", server)
+	}
+	return strings.TrimPrefix(server, "unit/"), nil
+}
+
+// ConfigChecksum returns a checksum of the instance's current configuration,
+// so config versions can be compared without reporting the config itself
+func (c *Client) ConfigChecksum(ctx context.Context) (string, error) {
+	response, err := c.get(ctx, "/config/")
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("control socket returned %s for the configuration", response.Status)
+	}
+	contents, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(contents)), nil
+}
+
+func (c *Client) Status(ctx context.Context) (*Status, error) {
+	response, err := c.get(ctx, "/status/")
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("control socket returned %s for the status", response.Status)
+	}
+	status := &Status{}
+	if err := json.NewDecoder(response.Body).Decode(status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (c *Client) get(ctx context.Context, path string) (*http.Response, error) {
+	// the host is ignored by Unit but required for a well-formed request
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://unit"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.http.Do(request)
+}
@@ -0,0 +1,225 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// supported auth methods
+const (
+	AuthAppRole    = "approle"
+	AuthKubernetes = "kubernetes"
+	AuthToken      = "token"
+)
+
+// defaultKubernetesJWTPath is where the service account token is mounted in a pod
+const defaultKubernetesJWTPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// Client is a minimal client for the Vault HTTP API, covering login, KV secret
+// reads and token renewal
+type Client struct {
+	address   string
+	namespace string
+	http      *http.Client
+
+	mu            sync.RWMutex
+	token         string
+	leaseDuration time.Duration
+	renewable     bool
+}
+
+// vaultResponse is the common envelope of the Vault API responses the client uses
+type vaultResponse struct {
+	Auth *struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+		Renewable     bool   `json:"renewable"`
+	} `json:"auth"`
+	Data map[string]json.RawMessage `json:"data"`
+}
+
+func NewClient(address, namespace string) *Client {
+	return &Client{
+		address:   strings.TrimSuffix(address, "/"),
+		namespace: namespace,
+		http:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SetToken uses a pre-issued token instead of logging in
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// LoginAppRole authenticates with the AppRole method; the secret ID is read
+// from a file so it does not live in the agent config
+func (c *Client) LoginAppRole(roleID, secretIDFile string) error {
+	contents, err := os.ReadFile(secretIDFile)
+	if err != nil {
+		return fmt.Errorf("unable to read AppRole secret ID file: %v", err)
+	}
+	return c.login("/v1/auth/approle/login", map[string]string{
+		"role_id":   roleID,
+		"secret_id": strings.TrimSpace(string(contents)),
+	})
+}
+
+// LoginKubernetes authenticates with the Kubernetes method using the pod's
+// service account token
+func (c *Client) LoginKubernetes(role, jwtPath string) error {
+	if jwtPath == "" {
+		jwtPath = defaultKubernetesJWTPath
+	}
+	contents, err := os.ReadFile(jwtPath)
+	if err != nil {
+		return fmt.Errorf("unable to read service account token: %v", err)
+	}
+	return c.login("/v1/auth/kubernetes/login", map[string]string{
+		"role": role,
+		"jwt":  strings.TrimSpace(string(contents)),
+	})
+}
+
+func (c *Client) login(path string, payload map[string]string) error {
+	response, err := c.request(http.MethodPost, path, payload)
+	if err != nil {
+		return err
+	}
+	if response.Auth == nil || response.Auth.ClientToken == "" {
+		return fmt.Errorf("vault login at %s returned no client token", path)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = response.Auth.ClientToken
+	c.leaseDuration = time.Duration(response.Auth.LeaseDuration) * time.Second
+	c.renewable = response.Auth.Renewable
+	return nil
+}
+
+// ReadSecret reads one field of the secret at the given mount path, handling
+// both KV version 2 (nested data) and version 1 responses
+func (c *Client) ReadSecret(path, field string) (string, error) {
+	response, err := c.request(http.MethodGet, "/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+
+	data := response.Data
+	if nested, ok := data["data"]; ok {
+		var inner map[string]json.RawMessage
+		if err := json.Unmarshal(nested, &inner); err == nil {
+			data = inner
+		}
+	}
+
+	raw, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no field %q", path, field)
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("field %q of secret %s is not a string: %v", field, path, err)
+	}
+	return value, nil
+}
+
+// StartRenewal keeps the auth token alive by renewing it at half its lease
+// duration until the context is done. It returns false when the token has no
+// renewable lease.
+func (c *Client) StartRenewal(ctx context.Context) bool {
+	c.mu.RLock()
+	interval := c.leaseDuration / 2
+	renewable := c.renewable
+	c.mu.RUnlock()
+
+	if !renewable || interval <= 0 {
+		return false
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.renew(); err != nil {
+					log.Warnf("Unable to renew Vault token: %v", err)
+				}
+			}
+		}
+	}()
+	return true
+}
+
+func (c *Client) renew() error {
+	response, err := c.request(http.MethodPost, "/v1/auth/token/renew-self", nil)
+	if err != nil {
+		return err
+	}
+	if response.Auth != nil && response.Auth.ClientToken != "" {
+		c.mu.Lock()
+		c.token = response.Auth.ClientToken
+		c.leaseDuration = time.Duration(response.Auth.LeaseDuration) * time.Second
+		c.mu.Unlock()
+	}
+	return nil
+}
+
+func (c *Client) request(method, path string, payload interface{}) (*vaultResponse, error) {
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	request, err := http.NewRequest(method, c.address+path, &body)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	if c.token != "" {
+		request.Header.Set("X-Vault-Token", c.token)
+	}
+	c.mu.RUnlock()
+	if c.namespace != "" {
+		request.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+
+	response, err := c.http.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned %s for %s", response.Status, path)
+	}
+
+	parsed := &vaultResponse{}
+	if err := json.NewDecoder(response.Body).Decode(parsed); err != nil {
+		return nil, fmt.Errorf("unable to parse vault response for %s: %v", path, err)
+	}
+	return parsed, nil
+}
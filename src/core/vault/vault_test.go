@@ -0,0 +1,84 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package vault
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func vaultTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/approle/login":
+			w.Write([]byte(`{"auth": {"client_token": "test-token", "lease_duration": 3600, "renewable": true}}`))
+		case "/v1/secret/data/nginx-agent":
+			if r.Header.Get("X-Vault-Token") != "test-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			// KV version 2 nests the fields under data.data
+			w.Write([]byte(`{"data": {"data": {"token": "control-plane-token"}, "metadata": {"version": 1}}}`))
+		case "/v1/kv/nginx-agent":
+			w.Write([]byte(`{"data": {"token": "kv1-token"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLoginAppRoleAndReadSecret(t *testing.T) {
+	server := vaultTestServer(t)
+
+	secretIDFile := filepath.Join(t.TempDir(), "secret-id")
+	require.NoError(t, os.WriteFile(secretIDFile, []byte("secret-id\n"), 0600))
+
+	client := NewClient(server.URL, "")
+	require.NoError(t, client.LoginAppRole("role-id", secretIDFile))
+
+	secret, err := client.ReadSecret("secret/data/nginx-agent", "token")
+	require.NoError(t, err)
+	assert.Equal(t, "control-plane-token", secret)
+
+	// a renewable login lease starts the renewal loop
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.True(t, client.StartRenewal(ctx))
+}
+
+func TestReadSecretKVVersion1(t *testing.T) {
+	server := vaultTestServer(t)
+
+	client := NewClient(server.URL, "")
+	client.SetToken("test-token")
+
+	secret, err := client.ReadSecret("kv/nginx-agent", "token")
+	require.NoError(t, err)
+	assert.Equal(t, "kv1-token", secret)
+
+	_, err = client.ReadSecret("kv/nginx-agent", "missing")
+	assert.Error(t, err)
+}
+
+func TestStartRenewalWithoutLease(t *testing.T) {
+	client := NewClient("http://127.0.0.1:8200", "")
+	client.SetToken("static-token")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.False(t, client.StartRenewal(ctx))
+}
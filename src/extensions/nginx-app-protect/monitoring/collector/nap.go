@@ -10,6 +10,8 @@ package collector
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -67,7 +69,7 @@ func newSyslogServer(logger *logrus.Entry, ip string, port int) (*syslogServer,
 	server.SetFormat(syslog.RFC3164)
 	server.SetHandler(handler)
 
-	addr := fmt.Sprintf("%s:%d", ip, port)
+	addr := net.JoinHostPort(ip, strconv.Itoa(port))
 	err := server.ListenTCP(addr)
 	if err != nil {
 		msg := fmt.Sprintf("Error while configuring syslog server to listen on %s:\n %v", addr, err)
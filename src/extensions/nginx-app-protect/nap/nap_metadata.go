@@ -20,8 +20,10 @@ import (
 )
 
 // UpdateMetadata retrieves the NAP content from the config and writes
-// the metadata
+// the metadata. parsed is the parse of the collection cycle cfg was assembled
+// from, reused rather than parsing the config again; it may be nil.
 func UpdateMetadata(
+	parsed *sdk.ParsedConfig,
 	cfg *proto.NginxConfig,
 	currentPrecompiledPublication bool,
 	wafLocation,
@@ -53,7 +55,7 @@ func UpdateMetadata(
 		return nil
 	}
 
-	policies, profiles := sdk.GetAppProtectPolicyAndSecurityLogFiles(cfg)
+	policies, profiles := sdk.GetAppProtectPolicyAndSecurityLogFiles(parsed, cfg)
 
 	policyBundles := []*BundleMetadata{}
 	profileBundles := []*BundleMetadata{}
@@ -159,10 +159,14 @@ func TestUpdateNapMetadata(t *testing.T) {
 			assert.NoError(t, err)
 			allowedDirs := map[string]struct{}{}
 
-			cfg, err := sdk.GetNginxConfig(configFile, nginxID, systemID, allowedDirs)
+			parsed, err := sdk.ParseConfig(configFile)
+			assert.NoError(t, err)
+
+			cfg, err := parsed.NginxConfig(nginxID, systemID, allowedDirs)
 			assert.NoError(t, err)
 
 			err = UpdateMetadata(
+				parsed,
 				cfg,
 				tc.precompPub,
 				metadataFile,
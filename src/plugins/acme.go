@@ -0,0 +1,462 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/acme"
+
+	sdk "github.com/nginx/agent/sdk/v2"
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const (
+	acmeChallengeHTTP01 = "http-01"
+	acmeChallengeDNS01  = "dns-01"
+
+	acmeAccountKeyFile       = "account.key"
+	acmeChallengeSnippetFile = "acme_challenge_location.conf"
+
+	// acmeChallengeSnippet is written next to the issued certificates so operators can
+	// include it in their port 80 server blocks to serve http-01 challenge files
+	acmeChallengeSnippet = "location /.well-known/acme-challenge/ {\n    root %s;\n}\n"
+
+	defaultMinAcmeInterval = time.Minute
+)
+
+// AcmeDNSProvider solves dns-01 challenges by publishing the validation record in the
+// DNS zone of the domain and removing it once validation completed. Providers register
+// themselves with RegisterAcmeDNSProvider and are selected by name in the agent config.
+type AcmeDNSProvider interface {
+	Present(ctx context.Context, domain, record string) error
+	CleanUp(ctx context.Context, domain, record string) error
+}
+
+var (
+	acmeDNSProvidersMutex sync.Mutex
+	acmeDNSProviders      = make(map[string]AcmeDNSProvider)
+)
+
+// RegisterAcmeDNSProvider makes a dns-01 provider selectable via the acme dns_provider
+// config setting. Registering a provider under an existing name replaces it.
+func RegisterAcmeDNSProvider(name string, provider AcmeDNSProvider) {
+	acmeDNSProvidersMutex.Lock()
+	defer acmeDNSProvidersMutex.Unlock()
+	acmeDNSProviders[name] = provider
+}
+
+func getAcmeDNSProvider(name string) (AcmeDNSProvider, bool) {
+	acmeDNSProvidersMutex.Lock()
+	defer acmeDNSProvidersMutex.Unlock()
+	provider, ok := acmeDNSProviders[name]
+	return provider, ok
+}
+
+// Acme obtains and renews certificates for the server names found in the configs of
+// discovered nginx instances, installs them below the configured cert directory and
+// reloads nginx, rolling the previous files back if the reload fails.
+type Acme struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	ticker          *time.Ticker
+	wg              sync.WaitGroup
+	detailsMutex    sync.Mutex
+	nginxDetails    map[string]*proto.NginxDetails
+}
+
+func NewAcme(conf *config.Config, env core.Environment, binary core.NginxBinary) *Acme {
+	interval := conf.Acme.Interval
+	if interval < defaultMinAcmeInterval {
+		log.Warnf("acme interval set to %s, provided value (%s) less than minimum", defaultMinAcmeInterval, interval)
+		interval = defaultMinAcmeInterval
+	}
+	return &Acme{
+		conf:         conf,
+		env:          env,
+		binary:       binary,
+		ticker:       time.NewTicker(interval),
+		nginxDetails: make(map[string]*proto.NginxDetails),
+	}
+}
+
+func (a *Acme) Init(pipeline core.MessagePipeInterface) {
+	log.Info("Acme initializing")
+	a.messagePipeline = pipeline
+	a.ctx = pipeline.Context()
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		for {
+			select {
+			case <-a.ticker.C:
+				a.checkCertificates()
+			case <-a.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (a *Acme) Close() {
+	log.Info("Acme is wrapping up")
+	a.ticker.Stop()
+}
+
+func (a *Acme) Info() *core.Info {
+	return core.NewInfo("Acme", "v0.0.1")
+}
+
+func (a *Acme) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.NginxInstancesFound):
+		nginxDetailsMap, ok := msg.Data().(map[string]*proto.NginxDetails)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		a.detailsMutex.Lock()
+		a.nginxDetails = nginxDetailsMap
+		a.detailsMutex.Unlock()
+	}
+}
+
+func (a *Acme) Subscriptions() []string {
+	return []string{
+		core.NginxInstancesFound,
+	}
+}
+
+// checkCertificates collects the server names of each known nginx instance and obtains
+// or renews a certificate for every name whose installed certificate is missing or
+// expires within the configured renew_before window.
+func (a *Acme) checkCertificates() {
+	a.detailsMutex.Lock()
+	details := make(map[string]*proto.NginxDetails, len(a.nginxDetails))
+	for nginxID, detail := range a.nginxDetails {
+		details[nginxID] = detail
+	}
+	a.detailsMutex.Unlock()
+
+	for nginxID, detail := range details {
+		serverNames, err := sdk.GetServerNames(detail.GetConfPath())
+		if err != nil {
+			log.Warnf("Acme: unable to read server names for nginx instance %s: %v", nginxID, err)
+			continue
+		}
+
+		for _, serverName := range serverNames {
+			if !a.needsIssuance(serverName) {
+				continue
+			}
+			if err := a.obtainCertificate(serverName, detail); err != nil {
+				log.Errorf("Acme: obtaining certificate for %s failed: %v", serverName, err)
+				continue
+			}
+			log.Infof("Acme: certificate for %s installed in %s", serverName, a.conf.Acme.CertDir)
+		}
+	}
+}
+
+// needsIssuance returns true if no certificate is installed for the domain or the
+// installed certificate expires within the renew_before window.
+func (a *Acme) needsIssuance(domain string) bool {
+	cert, err := sdk.LoadCertificate(a.certPath(domain))
+	if err != nil {
+		return true
+	}
+	return time.Until(cert.NotAfter) < a.conf.Acme.RenewBefore
+}
+
+func (a *Acme) certPath(domain string) string {
+	return filepath.Join(a.conf.Acme.CertDir, domain+".crt")
+}
+
+func (a *Acme) keyPath(domain string) string {
+	return filepath.Join(a.conf.Acme.CertDir, domain+".key")
+}
+
+// obtainCertificate runs a full ACME order for the domain: authorize via the configured
+// challenge type, finalize the order with a fresh key and install the issued chain.
+func (a *Acme) obtainCertificate(domain string, detail *proto.NginxDetails) error {
+	client, err := a.newClient()
+	if err != nil {
+		return err
+	}
+
+	order, err := client.AuthorizeOrder(a.ctx, acme.DomainIDs(domain))
+	if err != nil {
+		return fmt.Errorf("authorize order: %v", err)
+	}
+
+	for _, authzURL := range order.AuthzURLs {
+		if err := a.solveAuthorization(client, authzURL, domain); err != nil {
+			return err
+		}
+	}
+
+	order, err = client.WaitOrder(a.ctx, order.URI)
+	if err != nil {
+		return fmt.Errorf("wait order: %v", err)
+	}
+
+	certKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{domain}}, certKey)
+	if err != nil {
+		return err
+	}
+
+	chain, _, err := client.CreateOrderCert(a.ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return fmt.Errorf("finalize order: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(certKey)
+	if err != nil {
+		return err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return a.installCertificate(domain, chain, keyPEM, detail)
+}
+
+// solveAuthorization answers one pending authorization using the configured challenge
+// type and waits for the ACME server to validate it.
+func (a *Acme) solveAuthorization(client *acme.Client, authzURL, domain string) error {
+	authz, err := client.GetAuthorization(a.ctx, authzURL)
+	if err != nil {
+		return fmt.Errorf("get authorization: %v", err)
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var challenge *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == a.conf.Acme.Challenge {
+			challenge = c
+			break
+		}
+	}
+	if challenge == nil {
+		return fmt.Errorf("no %s challenge offered for %s", a.conf.Acme.Challenge, domain)
+	}
+
+	cleanup, err := a.prepareChallenge(client, challenge, domain)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if _, err = client.Accept(a.ctx, challenge); err != nil {
+		return fmt.Errorf("accept challenge: %v", err)
+	}
+	if _, err = client.WaitAuthorization(a.ctx, authz.URI); err != nil {
+		return fmt.Errorf("wait authorization: %v", err)
+	}
+	return nil
+}
+
+// prepareChallenge stages the challenge material and returns a cleanup function that
+// removes it again once validation completed. For http-01 the key authorization is
+// written below the configured webroot, which nginx serves via the challenge location
+// snippet installed next to the certificates. For dns-01 the configured provider
+// publishes the validation record.
+func (a *Acme) prepareChallenge(client *acme.Client, challenge *acme.Challenge, domain string) (func(), error) {
+	switch a.conf.Acme.Challenge {
+	case acmeChallengeHTTP01:
+		response, err := client.HTTP01ChallengeResponse(challenge.Token)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.installChallengeSnippet(); err != nil {
+			return nil, err
+		}
+		challengeFile := filepath.Join(a.conf.Acme.WebrootPath, client.HTTP01ChallengePath(challenge.Token))
+		if err := os.MkdirAll(filepath.Dir(challengeFile), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(challengeFile, []byte(response), 0644); err != nil {
+			return nil, err
+		}
+		return func() { os.Remove(challengeFile) }, nil
+	case acmeChallengeDNS01:
+		provider, ok := getAcmeDNSProvider(a.conf.Acme.DNSProvider)
+		if !ok {
+			return nil, fmt.Errorf("no dns provider registered with name %q", a.conf.Acme.DNSProvider)
+		}
+		record, err := client.DNS01ChallengeRecord(challenge.Token)
+		if err != nil {
+			return nil, err
+		}
+		if err := provider.Present(a.ctx, domain, record); err != nil {
+			return nil, fmt.Errorf("dns provider present: %v", err)
+		}
+		return func() {
+			if err := provider.CleanUp(a.ctx, domain, record); err != nil {
+				log.Warnf("Acme: dns provider cleanup for %s failed: %v", domain, err)
+			}
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported challenge type %q", a.conf.Acme.Challenge)
+}
+
+// installChallengeSnippet writes the location block serving the http-01 webroot next to
+// the issued certificates, for operators to include in their port 80 server blocks.
+func (a *Acme) installChallengeSnippet() error {
+	if err := os.MkdirAll(a.conf.Acme.CertDir, 0755); err != nil {
+		return err
+	}
+	snippet := fmt.Sprintf(acmeChallengeSnippet, a.conf.Acme.WebrootPath)
+	return os.WriteFile(filepath.Join(a.conf.Acme.CertDir, acmeChallengeSnippetFile), []byte(snippet), 0644)
+}
+
+// installCertificate writes the issued chain and key to the cert directory and reloads
+// nginx. The previous files are kept until the reload succeeded and restored if it
+// fails, so a bad rollout never leaves nginx pointing at half written files.
+func (a *Acme) installCertificate(domain string, chain [][]byte, keyPEM []byte, detail *proto.NginxDetails) error {
+	if err := os.MkdirAll(a.conf.Acme.CertDir, 0755); err != nil {
+		return err
+	}
+
+	certPEM := []byte{}
+	for _, der := range chain {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+
+	certPath, keyPath := a.certPath(domain), a.keyPath(domain)
+	backups, err := backupFiles(certPath, keyPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(certPath, certPEM, 0644); err != nil {
+		restoreFiles(backups)
+		return err
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		restoreFiles(backups)
+		return err
+	}
+
+	if err := a.binary.Reload(detail.GetProcessId(), detail.GetProcessPath()); err != nil {
+		restoreFiles(backups)
+		if reloadErr := a.binary.Reload(detail.GetProcessId(), detail.GetProcessPath()); reloadErr != nil {
+			log.Errorf("Acme: reload after rollback of %s failed: %v", domain, reloadErr)
+		}
+		return fmt.Errorf("reload with new certificate: %v", err)
+	}
+
+	removeFiles(backups)
+	return nil
+}
+
+// newClient builds an ACME client around the persisted account key, creating and
+// registering a new account on first use.
+func (a *Acme) newClient() (*acme.Client, error) {
+	key, err := a.loadOrCreateAccountKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client := &acme.Client{
+		Key:          key,
+		DirectoryURL: a.conf.Acme.DirectoryURL,
+	}
+
+	account := &acme.Account{}
+	if a.conf.Acme.Email != "" {
+		account.Contact = []string{"mailto:" + a.conf.Acme.Email}
+	}
+	if _, err = client.Register(a.ctx, account, acme.AcceptTOS); err != nil && err != acme.ErrAccountAlreadyExists {
+		return nil, fmt.Errorf("register account: %v", err)
+	}
+
+	return client, nil
+}
+
+func (a *Acme) loadOrCreateAccountKey() (*ecdsa.PrivateKey, error) {
+	keyFile := filepath.Join(a.conf.Acme.CertDir, acmeAccountKeyFile)
+
+	if keyPEM, err := os.ReadFile(keyFile); err == nil {
+		block, _ := pem.Decode(keyPEM)
+		if block == nil {
+			return nil, fmt.Errorf("account key %s is not PEM format", keyFile)
+		}
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(a.conf.Acme.CertDir, 0755); err != nil {
+		return nil, err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// backupFiles moves the provided files aside, returning the mapping from backup to
+// original path for restoreFiles and removeFiles. Files that do not exist are skipped.
+func backupFiles(paths ...string) (map[string]string, error) {
+	backups := make(map[string]string)
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		backup := path + ".bak"
+		if err := os.Rename(path, backup); err != nil {
+			restoreFiles(backups)
+			return nil, err
+		}
+		backups[backup] = path
+	}
+	return backups, nil
+}
+
+func restoreFiles(backups map[string]string) {
+	for backup, path := range backups {
+		if err := os.Rename(backup, path); err != nil {
+			log.Errorf("Acme: restoring %s from %s failed: %v", path, backup, err)
+		}
+	}
+}
+
+func removeFiles(backups map[string]string) {
+	for backup := range backups {
+		os.Remove(backup)
+	}
+}
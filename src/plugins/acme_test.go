@@ -0,0 +1,133 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+type fakeDNSProvider struct{}
+
+func (p *fakeDNSProvider) Present(ctx context.Context, domain, record string) error { return nil }
+func (p *fakeDNSProvider) CleanUp(ctx context.Context, domain, record string) error { return nil }
+
+func TestRegisterAcmeDNSProvider(t *testing.T) {
+	provider := &fakeDNSProvider{}
+	RegisterAcmeDNSProvider("test-provider", provider)
+
+	registered, ok := getAcmeDNSProvider("test-provider")
+	assert.True(t, ok)
+	assert.Equal(t, provider, registered)
+
+	_, ok = getAcmeDNSProvider("unknown-provider")
+	assert.False(t, ok)
+}
+
+func TestAcmeNeedsIssuance(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeCert := func(domain string, notAfter time.Time) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+		tml := x509.Certificate{
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     notAfter,
+			SerialNumber: big.NewInt(123123),
+			Subject:      pkix.Name{CommonName: domain},
+			DNSNames:     []string{domain},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, &tml, &tml, &key.PublicKey, key)
+		assert.NoError(t, err)
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, domain+".crt"), certPEM, 0644))
+	}
+
+	writeCert("current.example.com", time.Now().Add(60*24*time.Hour))
+	writeCert("expiring.example.com", time.Now().Add(10*24*time.Hour))
+
+	conf := &config.Config{Acme: config.Defaults.Acme}
+	conf.Acme.CertDir = tmpDir
+
+	acmePlugin := NewAcme(conf, tutils.GetMockEnv(), tutils.NewMockNginxBinary())
+
+	assert.False(t, acmePlugin.needsIssuance("current.example.com"))
+	assert.True(t, acmePlugin.needsIssuance("expiring.example.com"))
+	assert.True(t, acmePlugin.needsIssuance("missing.example.com"))
+}
+
+func TestAcmeBackupAndRestoreFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	certPath := filepath.Join(tmpDir, "example.com.crt")
+	keyPath := filepath.Join(tmpDir, "example.com.key")
+
+	previousCert := []byte("previous cert")
+	assert.NoError(t, os.WriteFile(certPath, previousCert, 0644))
+
+	// only the existing cert is backed up, the missing key file is skipped
+	backups, err := backupFiles(certPath, keyPath)
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+	_, err = os.Stat(certPath)
+	assert.True(t, os.IsNotExist(err))
+
+	// a failed rollout restores the previous file contents
+	assert.NoError(t, os.WriteFile(certPath, []byte("new cert"), 0644))
+	restoreFiles(backups)
+	cert, readErr := os.ReadFile(certPath)
+	assert.NoError(t, readErr)
+	assert.Equal(t, previousCert, cert)
+
+	// a successful rollout discards the backups
+	backups, err = backupFiles(certPath)
+	assert.NoError(t, err)
+	removeFiles(backups)
+	_, err = os.Stat(certPath + ".bak")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestAcmeInstallCertificateReloadSucceeds(t *testing.T) {
+	tmpDir := t.TempDir()
+	domain := "example.com"
+
+	conf := &config.Config{Acme: config.Defaults.Acme}
+	conf.Acme.CertDir = tmpDir
+
+	binary := tutils.NewMockNginxBinary()
+	binary.On("Reload", "123", "/path/to/nginx").Return(nil).Once()
+
+	acmePlugin := NewAcme(conf, tutils.GetMockEnv(), binary)
+
+	detail := tutils.GetDetailsMap()["12345"]
+	err := acmePlugin.installCertificate(domain, [][]byte{{0x01}}, []byte("new key"), detail)
+	assert.NoError(t, err)
+
+	cert, readErr := os.ReadFile(filepath.Join(tmpDir, domain+".crt"))
+	assert.NoError(t, readErr)
+	assert.Contains(t, string(cert), "BEGIN CERTIFICATE")
+
+	keyInfo, statErr := os.Stat(filepath.Join(tmpDir, domain+".key"))
+	assert.NoError(t, statErr)
+	assert.Equal(t, os.FileMode(0600), keyInfo.Mode().Perm())
+
+	binary.AssertExpectations(t)
+}
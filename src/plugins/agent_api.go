@@ -12,6 +12,7 @@ package plugins
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,6 +26,8 @@ import (
 	"github.com/nginx/agent/sdk/v2/proto"
 	"github.com/nginx/agent/v2/src/core"
 	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/metrics"
+	"github.com/nginx/agent/v2/src/core/payloads"
 	prometheus_metrics "github.com/nginx/agent/v2/src/extensions/prometheus-metrics"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -41,22 +44,39 @@ const (
 	pendingStatus = "PENDING"
 	errorStatus   = "ERROR"
 	unknownStatus = "UNKNOWN"
+
+	// maxRegistrationTransitions caps the registration history kept for the
+	// registration endpoint
+	maxRegistrationTransitions = 32
 )
 
 var (
 	instancesRegex    = regexp.MustCompile(`^\/nginx[\/]*$`)
 	configRegex       = regexp.MustCompile(`^\/nginx/config[\/]*$`)
 	configStatusRegex = regexp.MustCompile(`^\/nginx/config/status[\/]*$`)
+	processRegex      = regexp.MustCompile(`^\/nginx/process[\/]*$`)
+
+	apiV1InstancesRegex    = regexp.MustCompile(`^\/api\/v1\/instances[\/]*$`)
+	apiV1HealthRegex       = regexp.MustCompile(`^\/api\/v1\/health[\/]*$`)
+	apiV1ConfigStatusRegex = regexp.MustCompile(`^\/api\/v1\/config\/status[\/]*$`)
+	apiV1ConfigParsedRegex = regexp.MustCompile(`^\/api\/v1\/config\/parsed[\/]*$`)
+	apiV1RegistrationRegex = regexp.MustCompile(`^\/api\/v1\/registration[\/]*$`)
 )
 
 type AgentAPI struct {
-	config       *config.Config
-	env          core.Environment
-	pipeline     core.MessagePipeInterface
-	server       http.Server
-	nginxBinary  core.NginxBinary
-	nginxHandler *NginxHandler
-	exporter     *prometheus_metrics.Exporter
+	config                  *config.Config
+	env                     core.Environment
+	pipeline                core.MessagePipeInterface
+	server                  http.Server
+	nginxBinary             core.NginxBinary
+	nginxHandler            *NginxHandler
+	exporter                *prometheus_metrics.Exporter
+	version                 string
+	registered              bool
+	lastConnected           time.Time
+	registrationState       payloads.RegistrationState
+	registrationTransitions []*payloads.RegistrationStateTransition
+	controlPlane            string
 }
 
 type NginxHandler struct {
@@ -78,6 +98,9 @@ type ParameterRequest struct {
 type AgentAPIConfigApplyRequest struct {
 	correlationId string
 	config        *proto.NginxConfig
+	// traceparent carries the W3C trace context of the request that started
+	// the apply, so the trace continues across the message pipe
+	traceparent string
 }
 
 // swagger:model NginxInstanceResponse
@@ -125,17 +148,113 @@ type AgentAPIConfigApplyStatusResponse struct {
 	Status string `json:"status"`
 }
 
+// swagger:model AgentStatusResponse
+type AgentStatusResponse struct {
+	// Agent version
+	// example: v2.31.0
+	Version string `json:"version"`
+	// Enabled features
+	Features []string `json:"features"`
+	// Enabled extensions
+	Extensions []string `json:"extensions"`
+	// Control plane connectivity
+	Connectivity AgentConnectivityStatus `json:"connectivity"`
+	// NGINX Instances
+	NginxInstances []NginxInstanceStatus `json:"nginx_instances"`
+	// Capabilities the agent is running without because it lacks privileges,
+	// empty when fully functional
+	ReducedFunctionality []core.CapabilityGap `json:"reduced_functionality,omitempty"`
+}
+
+// swagger:model AgentConnectivityStatus
+type AgentConnectivityStatus struct {
+	// Control plane target, empty when no control plane is configured
+	// example: dataplane-manager:443
+	Server string `json:"server"`
+	// Whether the agent has registered with the control plane
+	Registered bool `json:"registered"`
+	// When the agent last connected, RFC 3339
+	LastConnected string `json:"last_connected,omitempty"`
+}
+
+// swagger:model NginxInstanceStatus
+type NginxInstanceStatus struct {
+	// NGINX ID
+	// example: b636d4376dea15405589692d3c5d3869ff3a9b26b0e7bb4bb1aa7e658ace1437
+	NginxId string `json:"nginx_id"`
+	// NGINX version
+	// example: 1.25.3
+	Version string `json:"version"`
+	// Master process ID
+	// example: 8192
+	ProcessId string `json:"process_id"`
+	// Path of the main configuration file
+	// example: /etc/nginx/nginx.conf
+	ConfPath string `json:"conf_path"`
+	// SHA-256 checksum of the main configuration file, so provisioning tools
+	// can assert the config version that is on disk
+	ConfVersion string `json:"conf_version,omitempty"`
+	// Status of the last config apply, when one has happened
+	LastConfigApply *NginxInstanceResponse `json:"last_config_apply,omitempty"`
+}
+
+// swagger:model AgentAPIHealthResponse
+type AgentAPIHealthResponse struct {
+	// Health of the agent, OK when at least one NGINX instance is running
+	// example: OK
+	Status string `json:"status"`
+	// Agent version
+	// example: v2.31.0
+	Version string `json:"version"`
+	// Whether the agent has registered with the control plane
+	Registered bool `json:"registered"`
+	// When the agent last connected, RFC 3339
+	LastConnected string `json:"last_connected,omitempty"`
+	// Number of running NGINX instances
+	// example: 1
+	NginxInstanceCount int `json:"nginx_instance_count"`
+}
+
+// swagger:model NginxConfigStatusEntry
+type NginxConfigStatusEntry struct {
+	// NGINX ID
+	// example: b636d4376dea15405589692d3c5d3869ff3a9b26b0e7bb4bb1aa7e658ace1437
+	NginxId string `json:"nginx_id"`
+	// Correlation ID of the config apply request
+	// example: 6204037c-30e6-408b-8aaa-dd8219860b4b
+	CorrelationId string `json:"correlation_id"`
+	// Message
+	// example: config applied successfully
+	Message string `json:"message"`
+	// Status
+	// example: OK
+	Status string `json:"status"`
+}
+
+// swagger:model RegistrationStatusResponse
+type RegistrationStatusResponse struct {
+	// Current registration state
+	// example: registered
+	State payloads.RegistrationState `json:"state"`
+	// Recent state transitions, oldest first
+	Transitions []*payloads.RegistrationStateTransition `json:"transitions"`
+}
+
 const (
-	contentTypeHeader = "Content-Type"
-	jsonMimeType      = "application/json"
+	contentTypeHeader   = "Content-Type"
+	jsonMimeType        = "application/json"
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
 )
 
-func NewAgentAPI(config *config.Config, env core.Environment, nginxBinary core.NginxBinary) *AgentAPI {
+func NewAgentAPI(config *config.Config, env core.Environment, nginxBinary core.NginxBinary, version string) *AgentAPI {
 	return &AgentAPI{
-		config:      config,
-		env:         env,
-		nginxBinary: nginxBinary,
-		exporter:    prometheus_metrics.NewExporter(&proto.MetricsReport{}),
+		config:            config,
+		env:               env,
+		nginxBinary:       nginxBinary,
+		exporter:          prometheus_metrics.NewExporter(&proto.MetricsReport{}),
+		version:           version,
+		registrationState: payloads.RegistrationConnecting,
 	}
 }
 
@@ -166,6 +285,9 @@ func (a *AgentAPI) Process(message *core.Message) {
 	case core.MetricReport:
 		switch response := message.Data().(type) {
 		case *proto.MetricsReport:
+			if a.config.AgentAPI.MetricsNaming == metrics.AmplifyNaming {
+				response = metrics.TranslateReportToAmplify(response)
+			}
 			a.exporter.SetLatestMetricReport(response)
 		default:
 			log.Warnf("Unknown MetricReport type: %T(%v)", message.Data(), message.Data())
@@ -178,6 +300,24 @@ func (a *AgentAPI) Process(message *core.Message) {
 		default:
 			log.Errorf("Expected the type %T but got %T", &proto.AgentActivityStatus{}, response)
 		}
+	case core.AgentConnected:
+		a.registered = true
+		a.lastConnected = time.Now()
+	case core.ControlPlaneChanged:
+		if target, ok := message.Data().(string); ok {
+			a.controlPlane = target
+		}
+	case core.RegistrationStateChanged:
+		switch transition := message.Data().(type) {
+		case *payloads.RegistrationStateTransition:
+			a.registrationState = transition.To
+			a.registrationTransitions = append(a.registrationTransitions, transition)
+			if len(a.registrationTransitions) > maxRegistrationTransitions {
+				a.registrationTransitions = a.registrationTransitions[len(a.registrationTransitions)-maxRegistrationTransitions:]
+			}
+		default:
+			log.Warnf("Unknown RegistrationStateChanged type: %T(%v)", message.Data(), message.Data())
+		}
 	}
 }
 func (a *AgentAPI) Info() *core.Info {
@@ -191,6 +331,9 @@ func (a *AgentAPI) Subscriptions() []string {
 		core.NginxConfigValidationPending,
 		core.NginxConfigApplyFailed,
 		core.NginxConfigApplySucceeded,
+		core.AgentConnected,
+		core.RegistrationStateChanged,
+		core.ControlPlaneChanged,
 	}
 }
 
@@ -208,6 +351,9 @@ func (a *AgentAPI) createHttpServer() {
 
 	mux.Handle("/metrics/", a.getPrometheusHandler())
 	mux.Handle("/nginx/", a.nginxHandler)
+	mux.HandleFunc("/status", a.serveStatus)
+	mux.HandleFunc("/status/", a.serveStatus)
+	mux.HandleFunc("/api/v1/", a.serveAPIV1)
 
 	handler := cors.New(cors.Options{AllowedMethods: []string{"OPTIONS", "GET", "PUT"}}).Handler(mux)
 	a.server = http.Server{
@@ -250,6 +396,271 @@ func (a *AgentAPI) getPrometheusHandler() http.Handler {
 	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
 }
 
+// swagger:route GET /status/ nginx-agent get-agent-status
+//
+// # Get Agent Status
+//
+// # Returns the agent version, connectivity and NGINX instance status in a stable schema
+//
+// responses:
+//
+//	200: AgentStatusResponse
+func (a *AgentAPI) serveStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set(contentTypeHeader, jsonMimeType)
+
+	status := AgentStatus(a.version, a.config, a.env, a.nginxBinary)
+	if a.controlPlane != "" {
+		// the failover plugin may have moved the agent off the configured server
+		status.Connectivity.Server = a.controlPlane
+	}
+	status.Connectivity.Registered = a.registered
+	if !a.lastConnected.IsZero() {
+		status.Connectivity.LastConnected = a.lastConnected.Format(time.RFC3339)
+	}
+	a.attachLastConfigApply(status.NginxInstances)
+
+	w.WriteHeader(http.StatusOK)
+	if err := writeObjectToResponseBody(w, status); err != nil {
+		log.Warnf("Failed to send agent status payload: %v", err)
+	}
+}
+
+// attachLastConfigApply fills in the status of the last config apply for the
+// instances that have had one
+func (a *AgentAPI) attachLastConfigApply(instances []NginxInstanceStatus) {
+	for i := range instances {
+		if configStatus, ok := a.nginxHandler.configResponseStatuses[instances[i].NginxId]; ok {
+			instances[i].LastConfigApply = &NginxInstanceResponse{
+				NginxId: configStatus.GetNginxId(),
+				Message: configStatus.GetMessage(),
+				Status:  configStatus.GetStatus().String(),
+			}
+		}
+	}
+}
+
+// serveAPIV1 dispatches the read-only /api/v1 endpoints. They only answer GET
+// requests and, when a token is configured, require it as a bearer token, so
+// they can be exposed to load balancers and humans without also exposing the
+// config apply endpoint.
+func (a *AgentAPI) serveAPIV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if token := a.config.AgentAPI.Token; token != "" && r.Header.Get(authorizationHeader) != bearerPrefix+token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set(contentTypeHeader, jsonMimeType)
+
+	var err error
+	switch {
+	case apiV1InstancesRegex.MatchString(r.URL.Path):
+		err = a.serveInstances(w)
+	case apiV1HealthRegex.MatchString(r.URL.Path):
+		err = a.serveHealth(w)
+	case apiV1ConfigStatusRegex.MatchString(r.URL.Path):
+		err = a.serveConfigStatuses(w)
+	case apiV1ConfigParsedRegex.MatchString(r.URL.Path):
+		err = a.serveConfigParsed(w, r)
+	case apiV1RegistrationRegex.MatchString(r.URL.Path):
+		err = a.serveRegistration(w)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Warnf("Failed to send api response: %v", err)
+	}
+}
+
+// swagger:route GET /api/v1/instances nginx-agent get-v1-instances
+//
+// # Get NGINX Instances
+//
+// # Returns the running NGINX instances with their version, config path and last config apply
+//
+// responses:
+//
+//	200: []NginxInstanceStatus
+func (a *AgentAPI) serveInstances(w http.ResponseWriter) error {
+	instances := AgentStatus(a.version, a.config, a.env, a.nginxBinary).NginxInstances
+	a.attachLastConfigApply(instances)
+
+	w.WriteHeader(http.StatusOK)
+	return writeObjectToResponseBody(w, instances)
+}
+
+// swagger:route GET /api/v1/health nginx-agent get-v1-health
+//
+// # Get Agent Health
+//
+// # Returns a small health payload, DEGRADED when no NGINX instance is running
+//
+// responses:
+//
+//	200: AgentAPIHealthResponse
+func (a *AgentAPI) serveHealth(w http.ResponseWriter) error {
+	health := AgentAPIHealthResponse{
+		Status:  okStatus,
+		Version: a.version,
+	}
+	health.Registered = a.registered
+	if !a.lastConnected.IsZero() {
+		health.LastConnected = a.lastConnected.Format(time.RFC3339)
+	}
+	for _, proc := range a.env.Processes() {
+		if proc.IsMaster {
+			health.NginxInstanceCount++
+		}
+	}
+	if health.NginxInstanceCount == 0 {
+		health.Status = "DEGRADED"
+	}
+
+	w.WriteHeader(http.StatusOK)
+	return writeObjectToResponseBody(w, health)
+}
+
+// swagger:route GET /api/v1/config/status nginx-agent get-v1-config-status
+//
+// # Get Config Apply Statuses
+//
+// # Returns the status of the last config apply of every instance that has had one
+//
+// responses:
+//
+//	200: []NginxConfigStatusEntry
+func (a *AgentAPI) serveConfigStatuses(w http.ResponseWriter) error {
+	entries := []NginxConfigStatusEntry{}
+	for _, configStatus := range a.nginxHandler.configResponseStatuses {
+		entries = append(entries, NginxConfigStatusEntry{
+			NginxId:       configStatus.GetNginxId(),
+			CorrelationId: configStatus.GetCorrelationId(),
+			Message:       configStatus.GetMessage(),
+			Status:        configStatus.GetStatus().String(),
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	return writeObjectToResponseBody(w, entries)
+}
+
+// swagger:route GET /api/v1/config/parsed nginx-agent get-v1-config-parsed
+//
+// # Get Parsed Config
+//
+// # Returns the config of an instance as a crossplane JSON payload with includes resolved.
+// # The filter parameter narrows the payload down to the directives matched by a dotted
+// # path of directive names, such as filter=http.server.listen
+//
+// responses:
+//
+//	200: description:crossplane payload, or the matched directives when filtered
+func (a *AgentAPI) serveConfigParsed(w http.ResponseWriter, r *http.Request) error {
+	nginxID := r.URL.Query().Get("nginx_id")
+
+	var detail *proto.NginxDetails
+	for _, nginxDetail := range a.nginxBinary.GetNginxDetailsMapFromProcesses(a.env.Processes()) {
+		if nginxID != "" && nginxDetail.GetNginxId() != nginxID {
+			continue
+		}
+		if detail != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return writeObjectToResponseBody(w, AgentAPICommonResponse{
+				Message: "multiple NGINX instances found, select one with the nginx_id parameter",
+			})
+		}
+		detail = nginxDetail
+	}
+	if detail == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return writeObjectToResponseBody(w, AgentAPICommonResponse{
+			Message: "No NGINX instances found",
+		})
+	}
+
+	payload, err := sdk.ParseConfigPayload(detail.GetConfPath())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return writeObjectToResponseBody(w, AgentAPICommonResponse{
+			Message: err.Error(),
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if filter := r.URL.Query().Get("filter"); filter != "" {
+		return writeObjectToResponseBody(w, sdk.QueryConfigDirectives(payload, filter))
+	}
+	return writeObjectToResponseBody(w, payload)
+}
+
+// swagger:route GET /api/v1/registration nginx-agent get-v1-registration
+//
+// # Get Registration Status
+//
+// # Returns the state of the registration state machine and its recent transitions
+//
+// responses:
+//
+//	200: RegistrationStatusResponse
+func (a *AgentAPI) serveRegistration(w http.ResponseWriter) error {
+	response := RegistrationStatusResponse{
+		State:       a.registrationState,
+		Transitions: a.registrationTransitions,
+	}
+	if response.Transitions == nil {
+		response.Transitions = []*payloads.RegistrationStateTransition{}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	return writeObjectToResponseBody(w, response)
+}
+
+// AgentStatus assembles the agent status from local discovery. The status verb
+// of the CLI uses it as well when there is no running agent to ask, so both
+// paths emit the same schema; connectivity and config apply status beyond what
+// can be discovered locally are filled in by the caller.
+func AgentStatus(version string, conf *config.Config, env core.Environment, nginxBinary core.NginxBinary) *AgentStatusResponse {
+	status := &AgentStatusResponse{
+		Version:    version,
+		Features:   conf.Features,
+		Extensions: conf.Extensions,
+		Connectivity: AgentConnectivityStatus{
+			Server: conf.Server.Target,
+		},
+		NginxInstances: []NginxInstanceStatus{},
+	}
+
+	for _, proc := range env.Processes() {
+		if !proc.IsMaster {
+			continue
+		}
+		nginxDetail := nginxBinary.GetNginxDetailsFromProcess(proc)
+		instance := NginxInstanceStatus{
+			NginxId:   nginxDetail.GetNginxId(),
+			Version:   nginxDetail.GetVersion(),
+			ProcessId: nginxDetail.GetProcessId(),
+			ConfPath:  nginxDetail.GetConfPath(),
+		}
+		if contents, err := os.ReadFile(nginxDetail.GetConfPath()); err == nil {
+			instance.ConfVersion = fmt.Sprintf("%x", sha256.Sum256(contents))
+		}
+		status.NginxInstances = append(status.NginxInstances, instance)
+	}
+
+	if len(status.NginxInstances) > 0 {
+		status.ReducedFunctionality = core.DetectCapabilityGaps(nginxBinary.GetAccessLogs(), nginxBinary.GetErrorLogs())
+	}
+
+	return status
+}
+
 func (h *NginxHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set(contentTypeHeader, jsonMimeType)
 
@@ -284,6 +695,16 @@ func (h *NginxHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			log.Warnf("Failed to get config status: %v", err)
 		}
+	case processRegex.MatchString(r.URL.Path):
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		err := h.processCommand(w, r)
+		if err != nil {
+			log.Warnf("Failed to process nginx process command: %v", err)
+		}
 	default:
 		w.WriteHeader(http.StatusNotFound)
 		_, err := fmt.Fprint(w, []byte("not found"))
@@ -353,7 +774,7 @@ func (h *NginxHandler) updateConfig(w http.ResponseWriter, r *http.Request) erro
 	nginxDetails := h.getNginxDetails()
 
 	for _, nginxDetail := range nginxDetails {
-		err := h.applyNginxConfig(nginxDetail, buf, correlationId)
+		err := h.applyNginxConfig(nginxDetail, buf, correlationId, r.Header.Get("traceparent"))
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			response := AgentAPICommonResponse{
@@ -447,7 +868,15 @@ func (h *NginxHandler) getNginxDetails() []*proto.NginxDetails {
 	return nginxDetails
 }
 
-func (h *NginxHandler) applyNginxConfig(nginxDetail *proto.NginxDetails, buf *bytes.Buffer, correlationId string) error {
+func (h *NginxHandler) applyNginxConfig(nginxDetail *proto.NginxDetails, buf *bytes.Buffer, correlationId, traceparent string) error {
+	return applyRawNginxConfig(h.env, h.nginxBinary, h.config, h.pipeline, nginxDetail, buf.Bytes(), correlationId, traceparent)
+}
+
+// applyRawNginxConfig turns a raw nginx.conf payload into a config apply
+// request for the nginx.go plugin. It is shared by the config apply entry
+// points that receive a plain nginx.conf rather than a zipped config, such as
+// the REST API and the object store poller.
+func applyRawNginxConfig(env core.Environment, nginxBinary core.NginxBinary, conf *config.Config, pipeline core.MessagePipeInterface, nginxDetail *proto.NginxDetails, payload []byte, correlationId, traceparent string) error {
 	fullFilePath := nginxDetail.ConfPath
 
 	// Create backup of nginx.conf file on host
@@ -459,23 +888,23 @@ func (h *NginxHandler) applyNginxConfig(nginxDetail *proto.NginxDetails, buf *by
 	protoFile := &proto.File{
 		Name:        fullFilePath,
 		Permissions: "0755",
-		Contents:    buf.Bytes(),
+		Contents:    payload,
 	}
 
-	configApply, err := sdk.NewConfigApply(protoFile.GetName(), h.config.AllowedDirectoriesMap)
+	configApply, err := sdk.NewConfigApply(protoFile.GetName(), conf.AllowedDirectoriesMap)
 	if err != nil {
 		return fmt.Errorf("unable to write config: %v", err)
 	}
 
 	// Temporarily write the new nginx.conf to disk
-	err = h.env.WriteFiles(configApply, []*proto.File{protoFile}, "", h.config.AllowedDirectoriesMap)
+	err = env.WriteFiles(configApply, []*proto.File{protoFile}, "", conf.AllowedDirectoriesMap)
 	if err != nil {
 		rollbackErr := configApply.Rollback(err)
 		return fmt.Errorf("config rollback failed: %v", rollbackErr)
 	}
 
 	// Create NginxConfig object for new nginx.conf
-	conf, err := h.nginxBinary.ReadConfig(fullFilePath, nginxDetail.NginxId, h.env.GetSystemUUID())
+	nginxConfig, err := nginxBinary.ReadConfig(fullFilePath, nginxDetail.NginxId, env.GetSystemUUID())
 	if err != nil {
 		rollbackErr := configApply.Rollback(err)
 		return fmt.Errorf("unable to read config: %v", rollbackErr)
@@ -489,7 +918,7 @@ func (h *NginxHandler) applyNginxConfig(nginxDetail *proto.NginxDetails, buf *by
 	}
 
 	// Send a config apply request to the nginx.go plugin
-	h.pipeline.Process(core.NewMessage(core.CommNginxConfig, &AgentAPIConfigApplyRequest{correlationId: correlationId, config: conf}))
+	pipeline.Process(core.NewMessage(core.CommNginxConfig, &AgentAPIConfigApplyRequest{correlationId: correlationId, config: nginxConfig, traceparent: traceparent}))
 	return nil
 }
 
@@ -558,6 +987,53 @@ func (h *NginxHandler) getConfigStatus(w http.ResponseWriter, r *http.Request) e
 	return writeObjectToResponseBody(w, agentAPIConfigApplyStatusResponse)
 }
 
+// swagger:route PUT /nginx/process/ nginx-agent nginx-process-command
+//
+// # Start, stop or restart the NGINX process
+//
+// # Requires the process supervisor mode to be enabled
+//
+// responses:
+//
+//	200: AgentAPICommonResponse
+//	400: AgentAPICommonResponse
+func (h *NginxHandler) processCommand(w http.ResponseWriter, r *http.Request) error {
+	correlationId := uuid.New().String()
+
+	if !h.config.Supervisor.Enable {
+		w.WriteHeader(http.StatusBadRequest)
+		return writeObjectToResponseBody(w, AgentAPICommonResponse{
+			CorrelationId: correlationId,
+			Message:       "Process supervisor mode is not enabled",
+		})
+	}
+
+	action := r.URL.Query().Get("action")
+	var topic string
+	switch action {
+	case "start":
+		topic = core.NginxStart
+	case "stop":
+		topic = core.NginxStop
+	case "restart":
+		topic = core.NginxRestart
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return writeObjectToResponseBody(w, AgentAPICommonResponse{
+			CorrelationId: correlationId,
+			Message:       fmt.Sprintf("Unknown action %q, expected start, stop or restart", action),
+		})
+	}
+
+	h.pipeline.Process(core.NewMessage(topic, nil))
+
+	w.WriteHeader(http.StatusOK)
+	return writeObjectToResponseBody(w, AgentAPICommonResponse{
+		CorrelationId: correlationId,
+		Message:       fmt.Sprintf("NGINX %s requested", action),
+	})
+}
+
 func writeObjectToResponseBody(w http.ResponseWriter, response any) error {
 	respBody := new(bytes.Buffer)
 	err := json.NewEncoder(respBody).Encode(response)
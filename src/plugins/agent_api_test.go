@@ -33,6 +33,7 @@ import (
 	"github.com/nginx/agent/sdk/v2/proto"
 	"github.com/nginx/agent/v2/src/core"
 	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/payloads"
 	tutils "github.com/nginx/agent/v2/test/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -58,6 +59,9 @@ func TestAgentAPI_Subscriptions(t *testing.T) {
 		core.NginxConfigValidationPending,
 		core.NginxConfigApplyFailed,
 		core.NginxConfigApplySucceeded,
+		core.AgentConnected,
+		core.RegistrationStateChanged,
+		core.ControlPlaneChanged,
 	}
 
 	agentAPI := AgentAPI{}
@@ -403,6 +407,74 @@ func TestNginxHandler_getConfigStatus(t *testing.T) {
 	}
 }
 
+func TestNginxHandler_processCommand(t *testing.T) {
+	tests := []struct {
+		name               string
+		url                string
+		supervisorEnabled  bool
+		expectedStatusCode int
+		expectedTopic      string
+	}{
+		{
+			name:               "supervisor disabled",
+			url:                "/nginx/process?action=restart",
+			supervisorEnabled:  false,
+			expectedStatusCode: 400,
+		},
+		{
+			name:               "unknown action",
+			url:                "/nginx/process?action=reboot",
+			supervisorEnabled:  true,
+			expectedStatusCode: 400,
+		},
+		{
+			name:               "restart action",
+			url:                "/nginx/process?action=restart",
+			supervisorEnabled:  true,
+			expectedStatusCode: 200,
+			expectedTopic:      core.NginxRestart,
+		},
+		{
+			name:               "stop action",
+			url:                "/nginx/process?action=stop",
+			supervisorEnabled:  true,
+			expectedStatusCode: 200,
+			expectedTopic:      core.NginxStop,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, tt.url, nil)
+
+			conf := &config.Config{Supervisor: config.Supervisor{Enable: tt.supervisorEnabled}}
+			mockMessagePipe := core.NewMockMessagePipe(context.Background())
+			h := &NginxHandler{
+				config:      conf,
+				env:         tutils.GetMockEnv(),
+				pipeline:    mockMessagePipe,
+				nginxBinary: tutils.NewMockNginxBinary(),
+			}
+
+			err := h.processCommand(w, r)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Result().StatusCode)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			messages := mockMessagePipe.GetMessages()
+			if tt.expectedTopic == "" {
+				assert.Len(t, messages, 0)
+			} else {
+				assert.Len(t, messages, 1)
+				assert.Equal(t, tt.expectedTopic, messages[0].Topic())
+			}
+		})
+	}
+}
+
 func TestProcess_metricReport(t *testing.T) {
 	conf := &config.Config{
 		AgentAPI: config.AgentAPI{
@@ -415,7 +487,7 @@ func TestProcess_metricReport(t *testing.T) {
 
 	metricReport := &proto.MetricsReport{Meta: &proto.Metadata{MessageId: "123"}}
 
-	agentAPI := NewAgentAPI(conf, mockEnvironment, mockNginxBinary)
+	agentAPI := NewAgentAPI(conf, mockEnvironment, mockNginxBinary, "")
 
 	// Check that latest metric report isn't set
 	assert.NotEqual(t, metricReport, agentAPI.exporter.GetLatestMetricReport())
@@ -490,7 +562,7 @@ func TestMtls_forApi(t *testing.T) {
 				client.SetTransport(transport)
 			}
 
-			pluginUnderTest := NewAgentAPI(tt.conf, tutils.GetMockEnvWithProcess(), tutils.GetMockNginxBinary())
+			pluginUnderTest := NewAgentAPI(tt.conf, tutils.GetMockEnvWithProcess(), tutils.GetMockNginxBinary(), "")
 			pluginUnderTest.Init(core.NewMockMessagePipe(ctx))
 
 			client.SetRetryCount(3).SetRetryWaitTime(50 * time.Millisecond).SetRetryMaxWaitTime(200 * time.Millisecond)
@@ -565,3 +637,232 @@ func printResult(resp *resty.Response, err error) *resty.Response {
 	fmt.Println()
 	return resp
 }
+
+func TestAgentStatusEndpoint(t *testing.T) {
+	conf := &config.Config{
+		Server:   config.Server{Target: "dataplane-manager:443"},
+		Features: []string{"metrics", "file-watcher"},
+		AgentAPI: config.AgentAPI{Port: 9090},
+	}
+
+	agentAPI := NewAgentAPI(conf, tutils.GetMockEnvWithProcess(), tutils.GetMockNginxBinary(), "v2.31.0")
+	agentAPI.nginxHandler = &NginxHandler{
+		configResponseStatuses: map[string]*proto.NginxConfigStatus{
+			"12345": {
+				NginxId:       "12345",
+				CorrelationId: "correlation-1",
+				Status:        proto.NginxConfigStatus_OK,
+				Message:       "config applied successfully",
+			},
+		},
+	}
+	agentAPI.Process(core.NewMessage(core.AgentConnected, nil))
+
+	w := httptest.NewRecorder()
+	agentAPI.serveStatus(w, httptest.NewRequest(http.MethodGet, "/status/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	status := &AgentStatusResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(status))
+
+	assert.Equal(t, "v2.31.0", status.Version)
+	assert.Equal(t, []string{"metrics", "file-watcher"}, status.Features)
+	assert.Equal(t, "dataplane-manager:443", status.Connectivity.Server)
+	assert.True(t, status.Connectivity.Registered)
+	assert.NotEmpty(t, status.Connectivity.LastConnected)
+
+	require.Len(t, status.NginxInstances, 1)
+	instance := status.NginxInstances[0]
+	assert.Equal(t, "12345", instance.NginxId)
+	assert.Equal(t, "1.2.1", instance.Version)
+	assert.Equal(t, "/var/conf", instance.ConfPath)
+	require.NotNil(t, instance.LastConfigApply)
+	assert.Equal(t, "OK", instance.LastConfigApply.Status)
+
+	w = httptest.NewRecorder()
+	agentAPI.serveStatus(w, httptest.NewRequest(http.MethodPut, "/status/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestAgentStatusLocalDiscovery(t *testing.T) {
+	conf := &config.Config{}
+
+	status := AgentStatus("v2.31.0", conf, tutils.GetMockEnvWithProcess(), tutils.GetMockNginxBinary())
+
+	assert.Equal(t, "v2.31.0", status.Version)
+	assert.Empty(t, status.Connectivity.Server)
+	assert.False(t, status.Connectivity.Registered)
+	require.Len(t, status.NginxInstances, 1)
+	assert.Equal(t, "12345", status.NginxInstances[0].NginxId)
+}
+
+func TestAgentAPIV1Endpoints(t *testing.T) {
+	conf := &config.Config{
+		AgentAPI: config.AgentAPI{Port: 9090, Token: "secret-token"},
+	}
+
+	agentAPI := NewAgentAPI(conf, tutils.GetMockEnvWithProcess(), tutils.GetMockNginxBinary(), "v2.31.0")
+	agentAPI.nginxHandler = &NginxHandler{
+		configResponseStatuses: map[string]*proto.NginxConfigStatus{
+			"12345": {
+				NginxId:       "12345",
+				CorrelationId: "correlation-1",
+				Status:        proto.NginxConfigStatus_OK,
+				Message:       "config applied successfully",
+			},
+		},
+	}
+
+	serve := func(method, path, token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		agentAPI.serveAPIV1(w, r)
+		return w
+	}
+
+	w := serve(http.MethodGet, "/api/v1/health", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serve(http.MethodGet, "/api/v1/health", "wrong-token")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serve(http.MethodPut, "/api/v1/health", "secret-token")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = serve(http.MethodGet, "/api/v1/unknown", "secret-token")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve(http.MethodGet, "/api/v1/health", "secret-token")
+	assert.Equal(t, http.StatusOK, w.Code)
+	health := &AgentAPIHealthResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(health))
+	assert.Equal(t, "OK", health.Status)
+	assert.Equal(t, "v2.31.0", health.Version)
+	assert.Equal(t, 1, health.NginxInstanceCount)
+
+	w = serve(http.MethodGet, "/api/v1/instances", "secret-token")
+	assert.Equal(t, http.StatusOK, w.Code)
+	instances := []NginxInstanceStatus{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&instances))
+	require.Len(t, instances, 1)
+	assert.Equal(t, "12345", instances[0].NginxId)
+	assert.Equal(t, "1.2.1", instances[0].Version)
+	require.NotNil(t, instances[0].LastConfigApply)
+	assert.Equal(t, "OK", instances[0].LastConfigApply.Status)
+
+	w = serve(http.MethodGet, "/api/v1/config/status", "secret-token")
+	assert.Equal(t, http.StatusOK, w.Code)
+	entries := []NginxConfigStatusEntry{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "correlation-1", entries[0].CorrelationId)
+	assert.Equal(t, "OK", entries[0].Status)
+}
+
+func TestAgentAPIV1ConfigParsed(t *testing.T) {
+	confFile := filepath.Join(t.TempDir(), "nginx.conf")
+	require.NoError(t, os.WriteFile(confFile, []byte(`
+events {}
+http {
+    server {
+        listen 443 ssl;
+        server_name example.com;
+    }
+}
+`), 0644))
+
+	binary := tutils.NewMockNginxBinary()
+	binary.On("GetNginxDetailsMapFromProcesses", mock.Anything).Return(map[string]*proto.NginxDetails{
+		"/usr/sbin/nginx": {NginxId: "12345", ConfPath: confFile},
+	})
+	agentAPI := NewAgentAPI(&config.Config{}, tutils.GetMockEnvWithProcess(), binary, "v2.31.0")
+	agentAPI.nginxHandler = &NginxHandler{configResponseStatuses: map[string]*proto.NginxConfigStatus{}}
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		agentAPI.serveAPIV1(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	// the single instance is picked without a nginx_id parameter
+	w := serve("/api/v1/config/parsed")
+	assert.Equal(t, http.StatusOK, w.Code)
+	payload := map[string]interface{}{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&payload))
+	assert.Contains(t, payload, "config")
+
+	w = serve("/api/v1/config/parsed?nginx_id=12345&filter=http.server.listen")
+	assert.Equal(t, http.StatusOK, w.Code)
+	matches := []*sdk.ConfigDirectiveMatch{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&matches))
+	require.Len(t, matches, 1)
+	assert.Equal(t, confFile, matches[0].File)
+	assert.Equal(t, []string{"443", "ssl"}, matches[0].Args)
+
+	w = serve("/api/v1/config/parsed?filter=http.server.proxy_pass")
+	assert.Equal(t, http.StatusOK, w.Code)
+	matches = []*sdk.ConfigDirectiveMatch{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&matches))
+	assert.Empty(t, matches)
+
+	w = serve("/api/v1/config/parsed?nginx_id=other")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAgentAPIV1Registration(t *testing.T) {
+	agentAPI := NewAgentAPI(&config.Config{}, tutils.GetMockEnvWithProcess(), tutils.NewMockNginxBinary(), "v2.31.0")
+
+	serve := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		agentAPI.serveAPIV1(w, httptest.NewRequest(http.MethodGet, "/api/v1/registration", nil))
+		return w
+	}
+
+	w := serve()
+	assert.Equal(t, http.StatusOK, w.Code)
+	response := &RegistrationStatusResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(response))
+	assert.Equal(t, payloads.RegistrationConnecting, response.State)
+	assert.Empty(t, response.Transitions)
+
+	agentAPI.Process(core.NewMessage(core.RegistrationStateChanged, &payloads.RegistrationStateTransition{
+		From:      payloads.RegistrationConnecting,
+		To:        payloads.RegistrationAuthenticating,
+		Reason:    "assembling connect request",
+		Timestamp: time.Now(),
+	}))
+	agentAPI.Process(core.NewMessage(core.RegistrationStateChanged, &payloads.RegistrationStateTransition{
+		From:      payloads.RegistrationAuthenticating,
+		To:        payloads.RegistrationRegistering,
+		Reason:    "connect request sent",
+		Timestamp: time.Now(),
+	}))
+
+	w = serve()
+	assert.Equal(t, http.StatusOK, w.Code)
+	response = &RegistrationStatusResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(response))
+	assert.Equal(t, payloads.RegistrationRegistering, response.State)
+	require.Len(t, response.Transitions, 2)
+	assert.Equal(t, payloads.RegistrationAuthenticating, response.Transitions[0].To)
+}
+
+func TestAgentAPIV1HealthDegraded(t *testing.T) {
+	env := tutils.NewMockEnvironment()
+	env.On("Processes", mock.Anything).Return([]core.Process{})
+	agentAPI := NewAgentAPI(&config.Config{}, env, tutils.NewMockNginxBinary(), "v2.31.0")
+	agentAPI.nginxHandler = &NginxHandler{configResponseStatuses: map[string]*proto.NginxConfigStatus{}}
+
+	// no token configured, so no Authorization header is needed
+	w := httptest.NewRecorder()
+	agentAPI.serveAPIV1(w, httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	health := &AgentAPIHealthResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(health))
+	assert.Equal(t, "DEGRADED", health.Status)
+	assert.Equal(t, 0, health.NginxInstanceCount)
+}
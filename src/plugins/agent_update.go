@@ -0,0 +1,288 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const (
+	// agentUpdateDownloadTimeout bounds the download of an offered package
+	agentUpdateDownloadTimeout = 5 * time.Minute
+	// agentUpdateHealthTimeout bounds the health check of a staged binary
+	agentUpdateHealthTimeout = 30 * time.Second
+	// agentUpdateRestartDelay gives the commander a chance to deliver the
+	// command status before the process is replaced
+	agentUpdateRestartDelay = 2 * time.Second
+)
+
+// AgentUpdater replaces the running agent with a new version offered by the
+// management server. The offered package is only installed when its checksum
+// and signature verify against the configured public key and the staged binary
+// passes a health check; the previous binary is put back when the swap or the
+// exec into the new version fails.
+type AgentUpdater struct {
+	messagePipeline core.MessagePipeInterface
+	conf            *config.Config
+	env             core.Environment
+	version         string
+	updating        bool
+}
+
+func NewAgentUpdater(conf *config.Config, env core.Environment, version string) *AgentUpdater {
+	return &AgentUpdater{
+		conf:    conf,
+		env:     env,
+		version: version,
+	}
+}
+
+func (au *AgentUpdater) Init(pipeline core.MessagePipeInterface) {
+	log.Info("AgentUpdater initializing")
+	au.messagePipeline = pipeline
+}
+
+func (au *AgentUpdater) Close() {
+	log.Info("AgentUpdater is wrapping up")
+}
+
+func (au *AgentUpdater) Info() *core.Info {
+	return core.NewInfo("AgentUpdater", "v0.0.1")
+}
+
+func (au *AgentUpdater) Process(msg *core.Message) {
+	if !msg.Exact(core.AgentUpdate) {
+		return
+	}
+
+	cmd, ok := msg.Data().(*proto.Command)
+	if !ok {
+		log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+		return
+	}
+	au.update(cmd)
+}
+
+func (au *AgentUpdater) Subscriptions() []string {
+	return []string{core.AgentUpdate}
+}
+
+// update stages the offered package and responds with a command status before the
+// process is replaced, so the management server learns the outcome of everything
+// up to the restart itself
+func (au *AgentUpdater) update(cmd *proto.Command) {
+	request := cmd.GetAgentUpdateRequest()
+
+	if au.updating {
+		log.Warn("AgentUpdater: update already in progress, ignoring request")
+		return
+	}
+
+	restart, err := au.stageUpdate(request)
+	if err != nil {
+		log.Errorf("AgentUpdater: update to %s failed: %v", request.GetVersion(), err)
+		au.respond(cmd, proto.CommandStatusResponse_CMD_ERROR, fmt.Sprintf("agent update to %s failed", request.GetVersion()), err.Error())
+		return
+	}
+	if restart == nil {
+		au.respond(cmd, proto.CommandStatusResponse_CMD_OK, fmt.Sprintf("agent already running version %s", request.GetVersion()), "")
+		return
+	}
+
+	au.updating = true
+	au.respond(cmd, proto.CommandStatusResponse_CMD_OK, fmt.Sprintf("agent update to %s staged, restarting", request.GetVersion()), "")
+
+	go func() {
+		time.Sleep(agentUpdateRestartDelay)
+		restart()
+	}()
+}
+
+// stageUpdate verifies and installs the offered binary and returns the restart
+// into the new version, or nil when the agent already runs the offered version
+func (au *AgentUpdater) stageUpdate(request *proto.AgentUpdateRequest) (func(), error) {
+	if request == nil {
+		return nil, fmt.Errorf("agent update request is empty")
+	}
+	if !au.conf.AgentUpdate.Enable {
+		return nil, fmt.Errorf("agent updates are not enabled on this system")
+	}
+	if request.GetVersion() == "" {
+		return nil, fmt.Errorf("agent update request has no version")
+	}
+	if request.GetVersion() == au.version {
+		return nil, nil
+	}
+
+	contents, err := au.fetchPackage(request)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := fmt.Sprintf("%x", sha256.Sum256(contents))
+	if !strings.EqualFold(checksum, request.GetChecksum()) {
+		return nil, fmt.Errorf("package checksum %s does not match the offered checksum", checksum)
+	}
+	if err := verifyPackageSignature(au.conf.AgentUpdate.PublicKey, contents, request.GetSignature()); err != nil {
+		return nil, err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine the running binary: %v", err)
+	}
+
+	staged := executable + ".update"
+	if err := os.WriteFile(staged, contents, 0755); err != nil {
+		return nil, fmt.Errorf("unable to stage the new binary: %v", err)
+	}
+
+	if err := healthCheckBinary(staged); err != nil {
+		os.Remove(staged)
+		return nil, fmt.Errorf("staged binary failed its health check: %v", err)
+	}
+
+	previous := executable + ".previous"
+	if err := os.Rename(executable, previous); err != nil {
+		os.Remove(staged)
+		return nil, fmt.Errorf("unable to set the previous binary aside: %v", err)
+	}
+	if err := os.Rename(staged, executable); err != nil {
+		if restoreErr := os.Rename(previous, executable); restoreErr != nil {
+			log.Errorf("AgentUpdater: restoring the previous binary failed: %v", restoreErr)
+		}
+		os.Remove(staged)
+		return nil, fmt.Errorf("unable to install the new binary: %v", err)
+	}
+
+	log.Infof("AgentUpdater: version %s installed as %s, previous binary kept as %s", request.GetVersion(), executable, previous)
+
+	return func() {
+		log.Infof("AgentUpdater: restarting into version %s", request.GetVersion())
+		if err := execReplacement(executable, os.Args, os.Environ()); err != nil {
+			log.Errorf("AgentUpdater: restarting into the new binary failed, rolling back: %v", err)
+			if restoreErr := os.Rename(previous, executable); restoreErr != nil {
+				log.Errorf("AgentUpdater: restoring the previous binary failed: %v", restoreErr)
+			}
+			au.updating = false
+		}
+	}, nil
+}
+
+func (au *AgentUpdater) fetchPackage(request *proto.AgentUpdateRequest) ([]byte, error) {
+	if len(request.GetPackage()) > 0 {
+		return request.GetPackage(), nil
+	}
+	if request.GetPackageUrl() == "" {
+		return nil, fmt.Errorf("agent update request has neither a package nor a package URL")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), agentUpdateDownloadTimeout)
+	defer cancel()
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, request.GetPackageUrl(), nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download the package: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("package download returned %s", response.Status)
+	}
+	return io.ReadAll(response.Body)
+}
+
+// verifyPackageSignature checks the package signature against the PEM encoded
+// public key the agent is configured with. Ed25519 keys verify the package
+// directly, RSA keys verify a PKCS #1 v1.5 signature over its SHA-256 digest.
+func verifyPackageSignature(publicKeyPath string, contents, signature []byte) error {
+	if publicKeyPath == "" {
+		return fmt.Errorf("no public key configured for agent updates")
+	}
+	if len(signature) == 0 {
+		return fmt.Errorf("the offered package is not signed")
+	}
+
+	keyPEM, err := os.ReadFile(publicKeyPath)
+	if err != nil {
+		return fmt.Errorf("unable to read the public key: %v", err)
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return fmt.Errorf("public key %s is not PEM encoded", publicKeyPath)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("unable to parse the public key: %v", err)
+	}
+
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, contents, signature) {
+			return fmt.Errorf("package signature does not verify")
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(contents)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("package signature does not verify: %v", err)
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+	return nil
+}
+
+// healthCheckBinary runs the staged binary once to make sure it executes on
+// this system before it replaces the running one
+func healthCheckBinary(path string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), agentUpdateHealthTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, path, "--version").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (au *AgentUpdater) respond(cmd *proto.Command, status proto.CommandStatusResponse_CommandStatus, message, errorMessage string) {
+	au.messagePipeline.Process(core.NewMessage(core.CommResponse, &proto.Command{
+		Meta: cmd.GetMeta(),
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_CmdStatus{
+			CmdStatus: &proto.CommandStatusResponse{
+				Status:  status,
+				Message: message,
+				Error:   errorMessage,
+			},
+		},
+	}))
+}
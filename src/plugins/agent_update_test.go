@@ -0,0 +1,150 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+// generateUpdateKey creates an ed25519 key pair, writing the public key PEM
+// into a temp file the plugin can be configured with
+func generateUpdateKey(t *testing.T) (ed25519.PrivateKey, string) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "agent-update.pub")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+
+	return privateKey, keyPath
+}
+
+func TestAgentUpdateRejections(t *testing.T) {
+	privateKey, keyPath := generateUpdateKey(t)
+
+	contents := []byte("new agent binary")
+	checksum := fmt.Sprintf("%x", sha256.Sum256(contents))
+	signature := ed25519.Sign(privateKey, contents)
+
+	testCases := []struct {
+		testName      string
+		conf          config.AgentUpdate
+		request       *proto.AgentUpdateRequest
+		expectedError string
+	}{
+		{
+			testName:      "updates disabled",
+			conf:          config.AgentUpdate{Enable: false, PublicKey: keyPath},
+			request:       &proto.AgentUpdateRequest{Version: "v3.0", Package: contents, Checksum: checksum, Signature: signature},
+			expectedError: "not enabled",
+		},
+		{
+			testName:      "request without a version",
+			conf:          config.AgentUpdate{Enable: true, PublicKey: keyPath},
+			request:       &proto.AgentUpdateRequest{Package: contents, Checksum: checksum, Signature: signature},
+			expectedError: "no version",
+		},
+		{
+			testName:      "checksum mismatch",
+			conf:          config.AgentUpdate{Enable: true, PublicKey: keyPath},
+			request:       &proto.AgentUpdateRequest{Version: "v3.0", Package: contents, Checksum: "0000", Signature: signature},
+			expectedError: "checksum",
+		},
+		{
+			testName:      "unsigned package",
+			conf:          config.AgentUpdate{Enable: true, PublicKey: keyPath},
+			request:       &proto.AgentUpdateRequest{Version: "v3.0", Package: contents, Checksum: checksum},
+			expectedError: "not signed",
+		},
+		{
+			testName:      "tampered package",
+			conf:          config.AgentUpdate{Enable: true, PublicKey: keyPath},
+			request:       &proto.AgentUpdateRequest{Version: "v3.0", Package: []byte("tampered binary!"), Checksum: fmt.Sprintf("%x", sha256.Sum256([]byte("tampered binary!"))), Signature: signature},
+			expectedError: "does not verify",
+		},
+		{
+			testName:      "no public key configured",
+			conf:          config.AgentUpdate{Enable: true},
+			request:       &proto.AgentUpdateRequest{Version: "v3.0", Package: contents, Checksum: checksum, Signature: signature},
+			expectedError: "no public key",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			pluginUnderTest := NewAgentUpdater(&config.Config{AgentUpdate: tc.conf}, tutils.GetMockEnv(), "v2.0")
+			messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+			pluginUnderTest.messagePipeline = messagePipe
+
+			pluginUnderTest.Process(core.NewMessage(core.AgentUpdate, &proto.Command{
+				Meta: &proto.Metadata{MessageId: "123"},
+				Data: &proto.Command_AgentUpdateRequest{AgentUpdateRequest: tc.request},
+			}))
+
+			messages := messagePipe.GetMessages()
+			require.Len(t, messages, 1)
+			response := messages[0].Data().(*proto.Command)
+			assert.Equal(t, proto.CommandStatusResponse_CMD_ERROR, response.GetCmdStatus().GetStatus())
+			assert.Contains(t, response.GetCmdStatus().GetError(), tc.expectedError)
+		})
+	}
+}
+
+func TestAgentUpdateSameVersion(t *testing.T) {
+	_, keyPath := generateUpdateKey(t)
+
+	pluginUnderTest := NewAgentUpdater(&config.Config{AgentUpdate: config.AgentUpdate{Enable: true, PublicKey: keyPath}}, tutils.GetMockEnv(), "v2.0")
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.messagePipeline = messagePipe
+
+	pluginUnderTest.Process(core.NewMessage(core.AgentUpdate, &proto.Command{
+		Meta: &proto.Metadata{MessageId: "123"},
+		Data: &proto.Command_AgentUpdateRequest{AgentUpdateRequest: &proto.AgentUpdateRequest{Version: "v2.0"}},
+	}))
+
+	messages := messagePipe.GetMessages()
+	require.Len(t, messages, 1)
+	response := messages[0].Data().(*proto.Command)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_OK, response.GetCmdStatus().GetStatus())
+	assert.Contains(t, response.GetCmdStatus().GetMessage(), "already running")
+}
+
+func TestHealthCheckBinary(t *testing.T) {
+	healthy := filepath.Join(t.TempDir(), "healthy")
+	require.NoError(t, os.WriteFile(healthy, []byte("#!/bin/sh\nexit 0\n"), 0755))
+	assert.NoError(t, healthCheckBinary(healthy))
+
+	broken := filepath.Join(t.TempDir(), "broken")
+	require.NoError(t, os.WriteFile(broken, []byte("#!/bin/sh\necho unable to start >&2\nexit 1\n"), 0755))
+	err := healthCheckBinary(broken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to start")
+}
+
+func TestAgentUpdateSubscriptions(t *testing.T) {
+	pluginUnderTest := NewAgentUpdater(&config.Config{}, tutils.GetMockEnv(), "v2.0")
+	assert.Equal(t, []string{core.AgentUpdate}, pluginUnderTest.Subscriptions())
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"syscall"
+)
+
+// execReplacement replaces the running process with the new binary, keeping the
+// process ID and the arguments and environment the agent was started with
+func execReplacement(executable string, args, env []string) error {
+	return syscall.Exec(executable, args, env)
+}
@@ -0,0 +1,29 @@
+//go:build windows
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"os"
+)
+
+// execReplacement starts the new binary and exits; Windows has no exec that
+// replaces the running process in place
+func execReplacement(executable string, args, env []string) error {
+	process, err := os.StartProcess(executable, args, &os.ProcAttr{
+		Env:   env,
+		Files: []*os.File{os.Stdin, os.Stdout, os.Stderr},
+	})
+	if err != nil {
+		return err
+	}
+	process.Release()
+	os.Exit(0)
+	return nil
+}
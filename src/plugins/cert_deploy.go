@@ -0,0 +1,240 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2"
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+// CertDeploy installs certificate bundles pushed by the management server. The bundle
+// is only installed when the key pairs with the certificate and the chain verifies,
+// the destination is within the allowed directories, and the previous files are rolled
+// back if the reload of nginx fails.
+type CertDeploy struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	detailsMutex    sync.Mutex
+	nginxDetails    map[string]*proto.NginxDetails
+}
+
+func NewCertDeploy(conf *config.Config, env core.Environment, binary core.NginxBinary) *CertDeploy {
+	return &CertDeploy{
+		conf:         conf,
+		env:          env,
+		binary:       binary,
+		nginxDetails: make(map[string]*proto.NginxDetails),
+	}
+}
+
+func (cd *CertDeploy) Init(pipeline core.MessagePipeInterface) {
+	log.Info("CertDeploy initializing")
+	cd.messagePipeline = pipeline
+	cd.ctx = pipeline.Context()
+}
+
+func (cd *CertDeploy) Close() {
+	log.Info("CertDeploy is wrapping up")
+}
+
+func (cd *CertDeploy) Info() *core.Info {
+	return core.NewInfo("CertDeploy", "v0.0.1")
+}
+
+func (cd *CertDeploy) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.NginxInstancesFound):
+		nginxDetailsMap, ok := msg.Data().(map[string]*proto.NginxDetails)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		cd.detailsMutex.Lock()
+		cd.nginxDetails = nginxDetailsMap
+		cd.detailsMutex.Unlock()
+	case msg.Exact(core.CertificateDeploy):
+		cmd, ok := msg.Data().(*proto.Command)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		cd.deploy(cmd)
+	}
+}
+
+func (cd *CertDeploy) Subscriptions() []string {
+	return []string{
+		core.CertificateDeploy,
+		core.NginxInstancesFound,
+	}
+}
+
+// deploy validates and installs the pushed bundle and responds with a command status
+// describing the outcome.
+func (cd *CertDeploy) deploy(cmd *proto.Command) {
+	request := cmd.GetCertificateDeployRequest()
+
+	if err := cd.installBundle(request); err != nil {
+		log.Errorf("CertDeploy: deploying certificate bundle to %s failed: %v", request.GetPath(), err)
+		cd.respond(cmd, proto.CommandStatusResponse_CMD_ERROR, fmt.Sprintf("certificate deploy to %s failed", request.GetPath()), err.Error())
+		return
+	}
+
+	log.Infof("CertDeploy: certificate bundle installed in %s", request.GetPath())
+	cd.respond(cmd, proto.CommandStatusResponse_CMD_OK, fmt.Sprintf("certificate deploy to %s complete", request.GetPath()), "")
+}
+
+func (cd *CertDeploy) installBundle(request *proto.CertificateDeployRequest) error {
+	if request == nil {
+		return fmt.Errorf("certificate deploy request is empty")
+	}
+	if request.GetName() == "" || strings.Contains(request.GetName(), "/") {
+		return fmt.Errorf("invalid bundle name %q", request.GetName())
+	}
+	if !cd.allowedPath(request.GetPath()) {
+		return fmt.Errorf("path %s is outside the allowed directories", request.GetPath())
+	}
+
+	if err := verifyBundle(request.GetCertificate(), request.GetPrivateKey(), request.GetChain()); err != nil {
+		return err
+	}
+
+	certPath := filepath.Join(request.GetPath(), request.GetName()+".crt")
+	keyPath := filepath.Join(request.GetPath(), request.GetName()+".key")
+
+	if err := os.MkdirAll(request.GetPath(), 0755); err != nil {
+		return err
+	}
+
+	backups, err := backupFiles(certPath, keyPath)
+	if err != nil {
+		return err
+	}
+
+	certPEM := append([]byte{}, request.GetCertificate()...)
+	certPEM = append(certPEM, request.GetChain()...)
+
+	if err := os.WriteFile(certPath, certPEM, 0644); err != nil {
+		restoreFiles(backups)
+		return err
+	}
+	if err := os.WriteFile(keyPath, request.GetPrivateKey(), 0600); err != nil {
+		restoreFiles(backups)
+		return err
+	}
+
+	if detail := cd.detailForInstance(request.GetNginxId()); detail != nil {
+		if err := cd.binary.Reload(detail.GetProcessId(), detail.GetProcessPath()); err != nil {
+			restoreFiles(backups)
+			if reloadErr := cd.binary.Reload(detail.GetProcessId(), detail.GetProcessPath()); reloadErr != nil {
+				log.Errorf("CertDeploy: reload after rollback failed: %v", reloadErr)
+			}
+			return fmt.Errorf("reload with new certificate: %v", err)
+		}
+	}
+
+	removeFiles(backups)
+	return nil
+}
+
+func (cd *CertDeploy) allowedPath(path string) bool {
+	if !filepath.IsAbs(path) {
+		return false
+	}
+	for dir := range cd.conf.AllowedDirectoriesMap {
+		if sdk.PathWithinDirectory(path, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cd *CertDeploy) detailForInstance(nginxID string) *proto.NginxDetails {
+	cd.detailsMutex.Lock()
+	defer cd.detailsMutex.Unlock()
+	return cd.nginxDetails[nginxID]
+}
+
+func (cd *CertDeploy) respond(cmd *proto.Command, status proto.CommandStatusResponse_CommandStatus, message, errorMessage string) {
+	cd.messagePipeline.Process(core.NewMessage(core.CommResponse, &proto.Command{
+		Meta: cmd.GetMeta(),
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_CmdStatus{
+			CmdStatus: &proto.CommandStatusResponse{
+				Status:  status,
+				Message: message,
+				Error:   errorMessage,
+			},
+		},
+	}))
+}
+
+// verifyBundle ensures the private key pairs with the certificate and that the leaf
+// verifies against the provided chain (or the system roots when no chain is provided).
+func verifyBundle(certPEM, keyPEM, chainPEM []byte) error {
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("certificate and key do not pair: %v", err)
+	}
+
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return err
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	intermediates := x509.NewCertPool()
+
+	rest := chainPEM
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("invalid chain certificate: %v", err)
+		}
+		intermediates.AddCert(cert)
+		roots.AddCert(cert)
+	}
+
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return fmt.Errorf("certificate chain verification failed: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+// generateDeployBundle creates a CA and a leaf certificate signed by it, returning the
+// PEM encoded leaf, key and chain.
+func generateDeployBundle(t *testing.T) (certPEM, keyPEM, chainPEM []byte) {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	caTemplate := x509.Certificate{
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(5, 0, 0),
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, &caTemplate, &caTemplate, &caKey.PublicKey, caKey)
+	assert.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	assert.NoError(t, err)
+
+	leafKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	leafTemplate := x509.Certificate{
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, &leafTemplate, caCert, &leafKey.PublicKey, caKey)
+	assert.NoError(t, err)
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(leafKey)})
+	chainPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	return certPEM, keyPEM, chainPEM
+}
+
+func TestCertDeploy(t *testing.T) {
+	certPEM, keyPEM, chainPEM := generateDeployBundle(t)
+	_, otherKeyPEM, _ := generateDeployBundle(t)
+
+	tmpDir := t.TempDir()
+
+	testCases := []struct {
+		testName       string
+		request        *proto.CertificateDeployRequest
+		expectedStatus proto.CommandStatusResponse_CommandStatus
+	}{
+		{
+			testName: "valid bundle is installed",
+			request: &proto.CertificateDeployRequest{
+				NginxId:     "12345",
+				Path:        tmpDir,
+				Name:        "example.com",
+				Certificate: certPEM,
+				PrivateKey:  keyPEM,
+				Chain:       chainPEM,
+			},
+			expectedStatus: proto.CommandStatusResponse_CMD_OK,
+		},
+		{
+			testName: "path outside allowed directories is rejected",
+			request: &proto.CertificateDeployRequest{
+				NginxId:     "12345",
+				Path:        "/etc/passwd-dir",
+				Name:        "example.com",
+				Certificate: certPEM,
+				PrivateKey:  keyPEM,
+				Chain:       chainPEM,
+			},
+			expectedStatus: proto.CommandStatusResponse_CMD_ERROR,
+		},
+		{
+			testName: "mismatched key is rejected",
+			request: &proto.CertificateDeployRequest{
+				NginxId:     "12345",
+				Path:        tmpDir,
+				Name:        "example.com",
+				Certificate: certPEM,
+				PrivateKey:  otherKeyPEM,
+				Chain:       chainPEM,
+			},
+			expectedStatus: proto.CommandStatusResponse_CMD_ERROR,
+		},
+		{
+			testName: "bundle without chain fails verification",
+			request: &proto.CertificateDeployRequest{
+				NginxId:     "12345",
+				Path:        tmpDir,
+				Name:        "example.com",
+				Certificate: certPEM,
+				PrivateKey:  keyPEM,
+			},
+			expectedStatus: proto.CommandStatusResponse_CMD_ERROR,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			conf := &config.Config{
+				AllowedDirectoriesMap: map[string]struct{}{tmpDir: {}},
+			}
+
+			binary := tutils.NewMockNginxBinary()
+			binary.On("Reload", mock.Anything, mock.Anything).Return(nil)
+
+			deploy := NewCertDeploy(conf, tutils.GetMockEnv(), binary)
+			messagePipe := core.SetupMockMessagePipe(t, context.Background(), deploy)
+			deploy.messagePipeline = messagePipe
+			deploy.ctx = messagePipe.Context()
+
+			deploy.Process(core.NewMessage(core.NginxInstancesFound, tutils.GetDetailsMap()))
+			deploy.Process(core.NewMessage(core.CertificateDeploy, &proto.Command{
+				Meta: &proto.Metadata{MessageId: "123"},
+				Data: &proto.Command_CertificateDeployRequest{CertificateDeployRequest: tc.request},
+			}))
+
+			responses := []*proto.Command{}
+			for _, msg := range messagePipe.GetMessages() {
+				if msg.Topic() == core.CommResponse {
+					responses = append(responses, msg.Data().(*proto.Command))
+				}
+			}
+			assert.Len(t, responses, 1)
+			assert.Equal(t, tc.expectedStatus, responses[0].GetCmdStatus().GetStatus())
+
+			if tc.expectedStatus == proto.CommandStatusResponse_CMD_OK {
+				cert, err := os.ReadFile(filepath.Join(tmpDir, "example.com.crt"))
+				assert.NoError(t, err)
+				assert.Equal(t, append(append([]byte{}, certPEM...), chainPEM...), cert)
+
+				keyInfo, err := os.Stat(filepath.Join(tmpDir, "example.com.key"))
+				assert.NoError(t, err)
+				assert.Equal(t, os.FileMode(0600), keyInfo.Mode().Perm())
+			}
+		})
+	}
+}
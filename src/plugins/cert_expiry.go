@@ -0,0 +1,231 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	commonProto "github.com/nginx/agent/sdk/v2/proto/common"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const (
+	CERT_EXPIRY_WARNING_MESSAGE  = "certificate %s expires in %d days (not after %s)"
+	CERT_EXPIRY_CRITICAL_MESSAGE = "certificate %s expires in %d days (not after %s)"
+	CERT_EXPIRED_MESSAGE         = "certificate %s has expired (not after %s)"
+	CERT_HEALTH_DEGRADED_MESSAGE = "certificate health degraded: %d of %d certificates expired or expiring"
+
+	defaultMinCertExpiryInterval = time.Minute
+)
+
+// CertExpiryMonitor periodically evaluates the NotAfter dates of the ssl certificates
+// referenced by discovered nginx instances and emits warning/critical events when a
+// certificate is approaching expiry. The per instance results are aggregated so the
+// control plane can reason about instance health.
+type CertExpiryMonitor struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	meta            *proto.Metadata
+	ticker          *time.Ticker
+	wg              sync.WaitGroup
+	detailsMutex    sync.Mutex
+	nginxDetails    map[string]*proto.NginxDetails
+	reportedLevels  map[string]string
+}
+
+func NewCertExpiryMonitor(conf *config.Config, env core.Environment, binary core.NginxBinary, meta *proto.Metadata) *CertExpiryMonitor {
+	interval := conf.CertExpiry.Interval
+	if interval < defaultMinCertExpiryInterval {
+		log.Warnf("cert expiry interval set to %s, provided value (%s) less than minimum", defaultMinCertExpiryInterval, interval)
+		interval = defaultMinCertExpiryInterval
+	}
+	return &CertExpiryMonitor{
+		conf:           conf,
+		env:            env,
+		binary:         binary,
+		meta:           meta,
+		ticker:         time.NewTicker(interval),
+		nginxDetails:   make(map[string]*proto.NginxDetails),
+		reportedLevels: make(map[string]string),
+	}
+}
+
+func (cem *CertExpiryMonitor) Init(pipeline core.MessagePipeInterface) {
+	log.Info("CertExpiryMonitor initializing")
+	cem.messagePipeline = pipeline
+	cem.ctx = pipeline.Context()
+
+	cem.wg.Add(1)
+	go func() {
+		defer cem.wg.Done()
+		for {
+			select {
+			case <-cem.ticker.C:
+				cem.checkCertificates()
+			case <-cem.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (cem *CertExpiryMonitor) Close() {
+	log.Info("CertExpiryMonitor is wrapping up")
+	cem.ticker.Stop()
+}
+
+func (cem *CertExpiryMonitor) Info() *core.Info {
+	return core.NewInfo("CertExpiryMonitor", "v0.0.1")
+}
+
+func (cem *CertExpiryMonitor) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.NginxInstancesFound):
+		nginxDetailsMap, ok := msg.Data().(map[string]*proto.NginxDetails)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		cem.detailsMutex.Lock()
+		cem.nginxDetails = nginxDetailsMap
+		cem.detailsMutex.Unlock()
+	}
+}
+
+func (cem *CertExpiryMonitor) Subscriptions() []string {
+	return []string{
+		core.NginxInstancesFound,
+	}
+}
+
+// checkCertificates reads the current config of each known nginx instance and emits
+// an event for every certificate that crossed one of the configured day thresholds.
+// A certificate is only re-reported when its severity changes, so a cert sitting at
+// 20 days to expiry does not produce a warning on every tick.
+func (cem *CertExpiryMonitor) checkCertificates() {
+	cem.detailsMutex.Lock()
+	details := make(map[string]*proto.NginxDetails, len(cem.nginxDetails))
+	for nginxID, detail := range cem.nginxDetails {
+		details[nginxID] = detail
+	}
+	cem.detailsMutex.Unlock()
+
+	systemID := cem.env.GetSystemUUID()
+
+	for nginxID, detail := range details {
+		cfg, err := cem.binary.ReadConfig(detail.GetConfPath(), nginxID, systemID)
+		if err != nil {
+			log.Warnf("CertExpiryMonitor: unable to read config for nginx instance %s: %v", nginxID, err)
+			continue
+		}
+
+		events := []*eventsProto.Event{}
+		unhealthy := 0
+		certs := cfg.GetSsl().GetSslCerts()
+
+		for _, cert := range certs {
+			level, message := cem.evaluateCertificate(cert)
+			if level == "" {
+				continue
+			}
+			unhealthy++
+
+			if cem.reportedLevels[cert.GetFingerprint()] == level {
+				continue
+			}
+			cem.reportedLevels[cert.GetFingerprint()] = level
+			events = append(events, cem.createCertEvent(nginxID, level, message))
+		}
+
+		if unhealthy > 0 {
+			events = append(events, cem.createCertEvent(nginxID, WARN_EVENT_LEVEL, fmt.Sprintf(CERT_HEALTH_DEGRADED_MESSAGE, unhealthy, len(certs))))
+		}
+
+		if len(events) > 0 {
+			cem.messagePipeline.Process(core.NewMessage(core.Events, &proto.Command{
+				Meta: cem.meta,
+				Type: proto.Command_NORMAL,
+				Data: &proto.Command_EventReport{
+					EventReport: &eventsProto.EventReport{
+						Events: events,
+					},
+				},
+			}))
+		}
+	}
+}
+
+// evaluateCertificate returns the event level and message for a certificate, or an
+// empty level if the certificate is not within any of the configured thresholds.
+func (cem *CertExpiryMonitor) evaluateCertificate(cert *proto.SslCertificate) (level, message string) {
+	notAfter := time.Unix(cert.GetValidity().GetNotAfter(), 0)
+	untilExpiry := time.Until(notAfter)
+	days := int(untilExpiry.Hours() / 24)
+
+	switch {
+	case untilExpiry <= 0:
+		return CRITICAL_EVENT_LEVEL, fmt.Sprintf(CERT_EXPIRED_MESSAGE, cert.GetFileName(), notAfter.Format(time.RFC3339))
+	case days <= cem.conf.CertExpiry.CriticalDays:
+		return CRITICAL_EVENT_LEVEL, fmt.Sprintf(CERT_EXPIRY_CRITICAL_MESSAGE, cert.GetFileName(), days, notAfter.Format(time.RFC3339))
+	case days <= cem.conf.CertExpiry.WarningDays:
+		return WARN_EVENT_LEVEL, fmt.Sprintf(CERT_EXPIRY_WARNING_MESSAGE, cert.GetFileName(), days, notAfter.Format(time.RFC3339))
+	}
+	return "", ""
+}
+
+func (cem *CertExpiryMonitor) createCertEvent(nginxID, level, message string) *eventsProto.Event {
+	activityEvent := &eventsProto.ActivityEvent{
+		Message: message,
+		Dimensions: []*commonProto.Dimension{
+			{
+				Name:  "nginx_id",
+				Value: nginxID,
+			},
+			{
+				Name:  "system_id",
+				Value: cem.env.GetSystemUUID(),
+			},
+			{
+				Name:  "hostname",
+				Value: cem.env.GetHostname(),
+			},
+			{
+				Name:  "instance_group",
+				Value: cem.conf.InstanceGroup,
+			},
+		},
+	}
+
+	return &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			UUID:          uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Module:        MODULE,
+			Timestamp:     types.TimestampNow(),
+			EventLevel:    level,
+			Type:          NGINX_EVENT_TYPE,
+			Category:      CERTS_CATEGORY,
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: activityEvent,
+		},
+	}
+}
@@ -0,0 +1,107 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func TestCertExpiryMonitor_checkCertificates(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		notAfter       time.Time
+		expectedLevels []string
+	}{
+		{
+			testName:       "CertNotExpiring",
+			notAfter:       time.Now().Add(60 * 24 * time.Hour),
+			expectedLevels: []string{},
+		},
+		{
+			testName:       "CertExpiringWarning",
+			notAfter:       time.Now().Add(20 * 24 * time.Hour),
+			expectedLevels: []string{WARN_EVENT_LEVEL, WARN_EVENT_LEVEL},
+		},
+		{
+			testName:       "CertExpiringCritical",
+			notAfter:       time.Now().Add(3 * 24 * time.Hour),
+			expectedLevels: []string{CRITICAL_EVENT_LEVEL, WARN_EVENT_LEVEL},
+		},
+		{
+			testName:       "CertExpired",
+			notAfter:       time.Now().Add(-24 * time.Hour),
+			expectedLevels: []string{CRITICAL_EVENT_LEVEL, WARN_EVENT_LEVEL},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			conf := &config.Config{CertExpiry: config.Defaults.CertExpiry}
+
+			env := tutils.GetMockEnv()
+
+			nginxConfig := &proto.NginxConfig{
+				Ssl: &proto.SslCertificates{
+					SslCerts: []*proto.SslCertificate{
+						{
+							FileName:    "/etc/nginx/ssl/test.crt",
+							Fingerprint: "ab:cd",
+							Validity: &proto.CertificateDates{
+								NotBefore: time.Now().Add(-24 * time.Hour).Unix(),
+								NotAfter:  tc.notAfter.Unix(),
+							},
+						},
+					},
+				},
+			}
+
+			binary := tutils.NewMockNginxBinary()
+			binary.On("ReadConfig", "/var/conf", "12345", "12345678").Return(nginxConfig, nil)
+
+			monitor := NewCertExpiryMonitor(conf, env, binary, &proto.Metadata{MessageId: "123"})
+			messagePipe := core.SetupMockMessagePipe(t, context.Background(), monitor)
+			monitor.messagePipeline = messagePipe
+			monitor.ctx = messagePipe.Context()
+
+			monitor.Process(core.NewMessage(core.NginxInstancesFound, tutils.GetDetailsMap()))
+			monitor.checkCertificates()
+
+			levels := []string{}
+			for _, msg := range messagePipe.GetMessages() {
+				assert.Equal(t, core.Events, msg.Topic())
+				cmd, ok := msg.Data().(*proto.Command)
+				assert.True(t, ok)
+				for _, event := range cmd.GetEventReport().GetEvents() {
+					assert.Equal(t, CERTS_CATEGORY, event.GetMetadata().GetCategory())
+					levels = append(levels, event.GetMetadata().GetEventLevel())
+				}
+			}
+			assert.Equal(t, tc.expectedLevels, levels)
+
+			// a second evaluation must not re-report certificates at the same severity
+			messagePipe.ClearMessages()
+			monitor.checkCertificates()
+			for _, msg := range messagePipe.GetMessages() {
+				cmd := msg.Data().(*proto.Command)
+				for _, event := range cmd.GetEventReport().GetEvents() {
+					assert.NotContains(t, event.GetData().(*eventsProto.Event_ActivityEvent).ActivityEvent.GetMessage(), "expires in")
+				}
+			}
+		})
+	}
+}
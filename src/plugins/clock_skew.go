@@ -0,0 +1,58 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nginx/agent/v2/src/core/metrics"
+	"github.com/nginx/agent/v2/src/core/payloads"
+	log "github.com/sirupsen/logrus"
+)
+
+// clockSkewMonitor estimates the offset between the host clock and the control
+// plane clock from the timestamps the control plane puts on its commands. The
+// estimate includes one-way network latency, which the detection threshold
+// absorbs.
+type clockSkewMonitor struct {
+	mu       sync.Mutex
+	offset   time.Duration
+	detected bool
+}
+
+// observe takes the remote timestamp of a received command together with the
+// local receipt time, and returns a payload for publishing when the skew
+// crossed the detection threshold in either direction or moved by more than
+// the threshold while detected.
+func (m *clockSkewMonitor) observe(remote, local time.Time) (*payloads.ClockSkew, bool) {
+	offset := remote.Sub(local)
+	detected := offset.Abs() >= metrics.ClockSkewThreshold
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	changed := detected != m.detected || (detected && (offset-m.offset).Abs() >= metrics.ClockSkewThreshold)
+	if !changed {
+		return nil, false
+	}
+
+	if detected {
+		direction := "behind"
+		if offset < 0 {
+			direction = "ahead of"
+		}
+		log.Warnf("Host clock is %s %s the control plane, metric timestamps will be annotated", offset.Abs(), direction)
+	} else if m.detected {
+		log.Info("Host clock is back in sync with the control plane")
+	}
+
+	m.offset = offset
+	m.detected = detected
+	return &payloads.ClockSkew{Offset: offset, ObservedAt: local}, true
+}
@@ -0,0 +1,58 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkewMonitor(t *testing.T) {
+	monitor := &clockSkewMonitor{}
+	local := time.Now()
+
+	// an offset below the threshold is ignored
+	skew, ok := monitor.observe(local.Add(500*time.Millisecond), local)
+	assert.False(t, ok)
+	assert.Nil(t, skew)
+
+	// crossing the threshold is reported
+	skew, ok = monitor.observe(local.Add(10*time.Second), local)
+	require.True(t, ok)
+	assert.Equal(t, 10*time.Second, skew.Offset)
+	assert.Equal(t, local, skew.ObservedAt)
+
+	// a stable skew is not reported again
+	_, ok = monitor.observe(local.Add(10*time.Second+time.Millisecond), local)
+	assert.False(t, ok)
+
+	// a skew that moved by more than the threshold is reported
+	skew, ok = monitor.observe(local.Add(20*time.Second), local)
+	require.True(t, ok)
+	assert.Equal(t, 20*time.Second, skew.Offset)
+
+	// returning under the threshold is reported once, so consumers stop
+	// annotating
+	skew, ok = monitor.observe(local, local)
+	require.True(t, ok)
+	assert.Equal(t, time.Duration(0), skew.Offset)
+	_, ok = monitor.observe(local.Add(time.Second), local)
+	assert.False(t, ok)
+}
+
+func TestClockSkewMonitorHostAhead(t *testing.T) {
+	monitor := &clockSkewMonitor{}
+	local := time.Now()
+
+	skew, ok := monitor.observe(local.Add(-time.Minute), local)
+	require.True(t, ok)
+	assert.Equal(t, -time.Minute, skew.Offset)
+}
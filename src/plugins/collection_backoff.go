@@ -0,0 +1,216 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/google/uuid"
+	"github.com/shirou/gopsutil/cpu"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	commonProto "github.com/nginx/agent/sdk/v2/proto/common"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const (
+	// backoffCheckInterval is how often the host CPU utilisation is sampled
+	backoffCheckInterval = 15 * time.Second
+
+	// backoffHighWatermarkCPU is the overall CPU percentage above which a
+	// sample counts towards entering degraded collection
+	backoffHighWatermarkCPU = 90.0
+
+	// backoffLowWatermarkCPU is the overall CPU percentage below which a
+	// sample counts towards leaving degraded collection
+	backoffLowWatermarkCPU = 75.0
+
+	// backoffDegradeSamples is the number of consecutive samples above the high
+	// watermark before collection is degraded, so short bursts are ignored
+	backoffDegradeSamples = 4
+
+	// backoffRecoverSamples is the number of consecutive samples below the low
+	// watermark before normal collection is restored
+	backoffRecoverSamples = 4
+)
+
+// CollectionBackoff watches the overall CPU utilisation of the host and, when it
+// stays above the high watermark, backs the agent off so it does not compete with
+// nginx for the CPU it is starved of: metrics collection intervals are widened,
+// log parsing and dataplane config rescans are suspended, and an activity event
+// reports the degraded collection. Everything is restored once utilisation stays
+// below the low watermark again.
+type CollectionBackoff struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	conf            *config.Config
+	env             core.Environment
+	meta            *proto.Metadata
+	sample          func() (float64, error)
+	degraded        bool
+	aboveWatermark  int
+	belowWatermark  int
+}
+
+func NewCollectionBackoff(conf *config.Config, env core.Environment, meta *proto.Metadata) *CollectionBackoff {
+	return &CollectionBackoff{
+		conf:   conf,
+		env:    env,
+		meta:   meta,
+		sample: hostCPUPercent,
+	}
+}
+
+// hostCPUPercent returns the overall CPU utilisation of the host since the
+// previous call, across all cores
+func hostCPUPercent() (float64, error) {
+	percentages, err := cpu.Percent(0, false)
+	if err != nil {
+		return 0, err
+	}
+	if len(percentages) == 0 {
+		return 0, fmt.Errorf("no cpu utilisation reported")
+	}
+	return percentages[0], nil
+}
+
+func (cb *CollectionBackoff) Init(pipeline core.MessagePipeInterface) {
+	log.Info("CollectionBackoff initializing")
+	cb.messagePipeline = pipeline
+	cb.ctx = pipeline.Context()
+	go cb.watchLoop()
+}
+
+func (cb *CollectionBackoff) Close() {
+	log.Info("CollectionBackoff is wrapping up")
+}
+
+func (cb *CollectionBackoff) Process(msg *core.Message) {}
+
+func (cb *CollectionBackoff) Info() *core.Info {
+	return core.NewInfo("Collection Backoff", "v0.0.1")
+}
+
+func (cb *CollectionBackoff) Subscriptions() []string {
+	return []string{}
+}
+
+func (cb *CollectionBackoff) watchLoop() {
+	ticker := time.NewTicker(backoffCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-cb.ctx.Done():
+			return
+		case <-ticker.C:
+			percent, err := cb.sample()
+			if err != nil {
+				log.Warnf("Unable to sample host CPU utilisation: %v", err)
+				continue
+			}
+			cb.evaluate(percent)
+		}
+	}
+}
+
+// evaluate feeds one CPU utilisation sample into the backoff state, entering or
+// leaving degraded collection when enough consecutive samples cross a watermark
+func (cb *CollectionBackoff) evaluate(percent float64) {
+	switch {
+	case percent >= backoffHighWatermarkCPU:
+		cb.aboveWatermark++
+		cb.belowWatermark = 0
+		if !cb.degraded && cb.aboveWatermark >= backoffDegradeSamples {
+			cb.degrade(percent)
+		}
+	case percent <= backoffLowWatermarkCPU:
+		cb.belowWatermark++
+		cb.aboveWatermark = 0
+		if cb.degraded && cb.belowWatermark >= backoffRecoverSamples {
+			cb.restore(percent)
+		}
+	default:
+		// between the watermarks nothing changes, so sustained pressure has to
+		// build up again from scratch
+		cb.aboveWatermark = 0
+		cb.belowWatermark = 0
+	}
+}
+
+func (cb *CollectionBackoff) degrade(percent float64) {
+	log.Warnf("Host CPU utilisation has stayed above %.0f%%, degrading collection", backoffHighWatermarkCPU)
+	cb.degraded = true
+	cb.messagePipeline.Process(
+		core.NewMessage(core.CollectionDegraded, true),
+		core.NewMessage(core.FileWatcherEnabled, false),
+	)
+	cb.publishEvent(WARN_EVENT_LEVEL, fmt.Sprintf("collection degraded: host CPU utilisation at %.1f%%, widening collection intervals and suspending log parsing and config rescans", percent))
+}
+
+func (cb *CollectionBackoff) restore(percent float64) {
+	log.Infof("Host CPU utilisation has stayed below %.0f%%, restoring collection", backoffLowWatermarkCPU)
+	cb.degraded = false
+	cb.messagePipeline.Process(
+		core.NewMessage(core.CollectionDegraded, false),
+		core.NewMessage(core.FileWatcherEnabled, true),
+	)
+	cb.publishEvent(INFO_EVENT_LEVEL, fmt.Sprintf("collection restored: host CPU utilisation at %.1f%%", percent))
+}
+
+// publishEvent publishes an activity event with the common dimensions of this system
+func (cb *CollectionBackoff) publishEvent(level, message string) {
+	activityEvent := &eventsProto.ActivityEvent{
+		Message: message,
+		Dimensions: []*commonProto.Dimension{
+			{
+				Name:  "system_id",
+				Value: cb.env.GetSystemUUID(),
+			},
+			{
+				Name:  "hostname",
+				Value: cb.env.GetHostname(),
+			},
+			{
+				Name:  "instance_group",
+				Value: cb.conf.InstanceGroup,
+			},
+		},
+	}
+
+	event := &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			UUID:          uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Module:        MODULE,
+			Timestamp:     types.TimestampNow(),
+			EventLevel:    level,
+			Type:          AGENT_EVENT_TYPE,
+			Category:      STATUS_CATEGORY,
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: activityEvent,
+		},
+	}
+
+	cb.messagePipeline.Process(core.NewMessage(core.Events, &proto.Command{
+		Meta: cb.meta,
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_EventReport{
+			EventReport: &eventsProto.EventReport{
+				Events: []*eventsProto.Event{event},
+			},
+		},
+	}))
+}
@@ -0,0 +1,101 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+// backoffMessages partitions the pipeline messages by topic, unpacking the
+// activity events out of the event reports
+func backoffMessages(t *testing.T, messagePipe *core.MockMessagePipe) (degraded []bool, watcherEnabled []bool, events []*eventsProto.ActivityEvent) {
+	t.Helper()
+	for _, msg := range messagePipe.GetMessages() {
+		switch msg.Topic() {
+		case core.CollectionDegraded:
+			degraded = append(degraded, msg.Data().(bool))
+		case core.FileWatcherEnabled:
+			watcherEnabled = append(watcherEnabled, msg.Data().(bool))
+		case core.Events:
+			cmd := msg.Data().(*proto.Command)
+			for _, event := range cmd.GetEventReport().GetEvents() {
+				events = append(events, event.GetActivityEvent())
+			}
+		}
+	}
+	return degraded, watcherEnabled, events
+}
+
+func TestCollectionBackoffDegradeAndRecover(t *testing.T) {
+	pluginUnderTest := NewCollectionBackoff(&config.Config{}, tutils.GetMockEnv(), &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.messagePipeline = messagePipe
+
+	// a short burst above the high watermark is not enough to degrade
+	for i := 0; i < backoffDegradeSamples-1; i++ {
+		pluginUnderTest.evaluate(95)
+	}
+	assert.Empty(t, messagePipe.GetMessages())
+
+	// a sample between the watermarks resets the run
+	pluginUnderTest.evaluate(80)
+	for i := 0; i < backoffDegradeSamples-1; i++ {
+		pluginUnderTest.evaluate(95)
+	}
+	assert.Empty(t, messagePipe.GetMessages())
+
+	// sustained pressure degrades collection once
+	pluginUnderTest.evaluate(95)
+	pluginUnderTest.evaluate(95)
+
+	degraded, watcherEnabled, events := backoffMessages(t, messagePipe)
+	assert.Equal(t, []bool{true}, degraded)
+	assert.Equal(t, []bool{false}, watcherEnabled)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0].GetMessage(), "collection degraded")
+
+	// sustained recovery restores collection
+	for i := 0; i < backoffRecoverSamples; i++ {
+		pluginUnderTest.evaluate(30)
+	}
+
+	degraded, watcherEnabled, events = backoffMessages(t, messagePipe)
+	assert.Equal(t, []bool{true, false}, degraded)
+	assert.Equal(t, []bool{false, true}, watcherEnabled)
+	require.Len(t, events, 2)
+	assert.Contains(t, events[1].GetMessage(), "collection restored")
+}
+
+func TestCollectionBackoffSubscriptions(t *testing.T) {
+	pluginUnderTest := NewCollectionBackoff(&config.Config{}, tutils.GetMockEnv(), &proto.Metadata{})
+	assert.Equal(t, []string{}, pluginUnderTest.Subscriptions())
+}
+
+func TestMetricsCollectionIntervalWhenDegraded(t *testing.T) {
+	conf := tutils.GetMockAgentConfig()
+	pluginUnderTest := NewMetrics(conf, tutils.GetMockEnvWithProcess(), tutils.GetMockNginxBinary())
+
+	assert.Equal(t, conf.AgentMetrics.CollectionInterval, pluginUnderTest.collectionInterval())
+
+	pluginUnderTest.Process(core.NewMessage(core.CollectionDegraded, true))
+	assert.Equal(t, conf.AgentMetrics.CollectionInterval*degradedCollectionFactor, pluginUnderTest.collectionInterval())
+	assert.True(t, pluginUnderTest.collectorsUpdate.Load())
+
+	pluginUnderTest.Process(core.NewMessage(core.CollectionDegraded, false))
+	assert.Equal(t, conf.AgentMetrics.CollectionInterval, pluginUnderTest.collectionInterval())
+}
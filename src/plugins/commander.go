@@ -10,7 +10,9 @@ package plugins
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/nginx/agent/sdk/v2/client"
 	"github.com/nginx/agent/sdk/v2/proto"
 	"github.com/nginx/agent/v2/src/core"
@@ -18,20 +20,30 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// commandDedupCacheSize bounds how many idempotency keys and their results are
+// remembered; the oldest entries are evicted first
+const commandDedupCacheSize = 256
+
 // Commander plugin is the receiver, dispatcher, and sender of all commands
 type Commander struct {
-	pipeline core.MessagePipeInterface
-	ctx      context.Context
-	cmdr     client.Commander
-	wg       sync.WaitGroup
-	config   *config.Config
+	pipeline    core.MessagePipeInterface
+	ctx         context.Context
+	cmdr        client.Commander
+	wg          sync.WaitGroup
+	config      *config.Config
+	dedup       *commandDedup
+	skewMonitor *clockSkewMonitor
+	budget      *core.BandwidthBudget
 }
 
-func NewCommander(cmdr client.Commander, config *config.Config) *Commander {
+func NewCommander(cmdr client.Commander, config *config.Config, budget *core.BandwidthBudget) *Commander {
 	return &Commander{
-		cmdr:   cmdr,
-		wg:     sync.WaitGroup{},
-		config: config,
+		cmdr:        cmdr,
+		wg:          sync.WaitGroup{},
+		config:      config,
+		dedup:       newCommandDedup(commandDedupCacheSize),
+		skewMonitor: &clockSkewMonitor{},
+		budget:      budget,
 	}
 }
 
@@ -67,6 +79,7 @@ func (c *Commander) Process(msg *core.Message) {
 	case *proto.Command:
 		switch msg.Topic() {
 		case core.CommRegister, core.CommStatus, core.CommResponse, core.Events:
+			c.dedup.complete(cmd.GetMeta().GetMessageId(), cmd)
 			c.sendCommand(c.ctx, cmd)
 		case core.AgentConnected:
 			c.agentRegistered(cmd)
@@ -113,8 +126,24 @@ func (c *Commander) agentRegistered(cmd *proto.Command) {
 	}
 }
 
+// observeClockSkew feeds the timestamp the control plane put on a command into
+// the skew monitor and publishes the new skew estimate when it changed enough
+// to matter.
+func (c *Commander) observeClockSkew(cmd *proto.Command) {
+	remote, err := types.TimestampFromProto(cmd.GetMeta().GetTimestamp())
+	if err != nil {
+		return
+	}
+	if skew, ok := c.skewMonitor.observe(remote, time.Now()); ok {
+		c.pipeline.Process(core.NewMessage(core.ClockSkewDetected, skew))
+	}
+}
+
 func (c *Commander) sendCommand(ctx context.Context, cmd *proto.Command) {
 	log.Debugf("Sending command (messageId=%s), %v", cmd.GetMeta().MessageId, cmd.GetData())
+	// command traffic is never deferred, it only debits the bandwidth budget
+	// so that lower priority reporting backs off
+	c.budget.Allow(core.BandwidthPriorityControl, int64(cmd.Size()))
 	if err := c.cmdr.Send(ctx, client.MessageFromCommand(cmd)); err != nil {
 		log.Errorf("Error sending to command channel %v", err)
 	}
@@ -148,6 +177,8 @@ func (c *Commander) dispatchLoop() {
 		}
 
 		log.Debugf("Command msg from data plane: %v", cmd)
+		c.observeClockSkew(cmd)
+
 		var topic string
 		switch cmd.Data.(type) {
 		case *proto.Command_NginxConfig, *proto.Command_NginxConfigResponse:
@@ -157,6 +188,20 @@ func (c *Commander) dispatchLoop() {
 		case *proto.Command_AgentConfigRequest, *proto.Command_AgentConfig:
 			log.Debugf("agent config %T command data type received and ignored", cmd.Data)
 			topic = core.AgentConfig
+		case *proto.Command_CertificateDeployRequest:
+			topic = core.CertificateDeploy
+		case *proto.Command_DebugCaptureRequest:
+			topic = core.DebugCapture
+		case *proto.Command_KeyvalSyncRequest:
+			topic = core.KeyvalSync
+		case *proto.Command_AgentUpdateRequest:
+			topic = core.AgentUpdate
+		case *proto.Command_UpstreamDrainRequest:
+			topic = core.UpstreamDrain
+		case *proto.Command_TrafficMirrorRequest:
+			topic = core.TrafficMirror
+		case *proto.Command_UpstreamSwitchRequest:
+			topic = core.UpstreamSwitch
 		case *proto.Command_CmdStatus:
 			data := cmd.Data.(*proto.Command_CmdStatus)
 			if data.CmdStatus.Status != proto.CommandStatusResponse_CMD_OK {
@@ -172,6 +217,90 @@ func (c *Commander) dispatchLoop() {
 			continue
 		}
 
+		if key := cmd.GetMeta().GetIdempotencyKey(); key != "" {
+			if !c.dedup.begin(cmd.GetMeta().GetMessageId(), key) {
+				if response, done := c.dedup.response(key); done && response != nil {
+					log.Infof("Duplicate delivery of command (messageId=%s, idempotencyKey=%s), replaying original result", cmd.GetMeta().GetMessageId(), key)
+					c.sendCommand(c.ctx, response)
+				} else {
+					log.Infof("Duplicate delivery of command (messageId=%s, idempotencyKey=%s) while the original is still running, ignoring", cmd.GetMeta().GetMessageId(), key)
+				}
+				continue
+			}
+		}
+
 		c.pipeline.Process(core.NewMessage(topic, cmd))
 	}
 }
+
+// commandDedup remembers the idempotency key of recently dispatched commands
+// and the response each produced, so a redelivered command is answered from
+// the cache instead of being run a second time
+type commandDedup struct {
+	size     int
+	mu       sync.Mutex
+	inFlight map[string]string         // message id of a dispatched command -> its idempotency key
+	results  map[string]*proto.Command // idempotency key -> response of the original delivery, nil until one is sent
+	order    []string                  // idempotency keys in arrival order, for bounded eviction
+}
+
+func newCommandDedup(size int) *commandDedup {
+	return &commandDedup{
+		size:     size,
+		inFlight: make(map[string]string),
+		results:  make(map[string]*proto.Command),
+	}
+}
+
+// begin records the first delivery of key, reporting false when the key was
+// already seen and the command must not be dispatched again
+func (d *commandDedup) begin(messageID, key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.results[key]; ok {
+		return false
+	}
+
+	if len(d.order) >= d.size {
+		oldest := d.order[0]
+		d.order = d.order[1:]
+		delete(d.results, oldest)
+		for id, k := range d.inFlight {
+			if k == oldest {
+				delete(d.inFlight, id)
+			}
+		}
+	}
+
+	d.results[key] = nil
+	d.order = append(d.order, key)
+	d.inFlight[messageID] = key
+	return true
+}
+
+// response returns the result recorded for an already seen key; the result is
+// nil while the original delivery is still being processed
+func (d *commandDedup) response(key string) (*proto.Command, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	response, ok := d.results[key]
+	return response, ok
+}
+
+// complete records the response sent for a dispatched command, keyed back to
+// its idempotency key through the message id. Responses to commands that did
+// not carry a key are ignored.
+func (d *commandDedup) complete(messageID string, response *proto.Command) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key, ok := d.inFlight[messageID]
+	if !ok {
+		return
+	}
+	if _, tracked := d.results[key]; tracked {
+		d.results[key] = response
+	}
+}
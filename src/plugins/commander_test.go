@@ -235,7 +235,7 @@ func TestCommander_Process(t *testing.T) {
 				cmdr.On("Send", mock.Anything, client.MessageFromCommand(test.cmd))
 			}
 
-			pluginUnderTest := NewCommander(cmdr, &config.Config{ClientID: "12345"})
+			pluginUnderTest := NewCommander(cmdr, &config.Config{ClientID: "12345"}, nil)
 			messagePipe := core.SetupMockMessagePipe(t, ctx, pluginUnderTest)
 
 			messagePipe.RunWithoutInit()
@@ -262,15 +262,47 @@ func TestCommander_Process(t *testing.T) {
 func TestCommander_Subscriptions(t *testing.T) {
 	cmdr := tutils.NewMockCommandClient()
 	subs := []string{core.CommRegister, core.CommStatus, core.CommResponse, core.AgentConnected, core.Events}
-	pluginUnderTest := NewCommander(cmdr, &config.Config{})
+	pluginUnderTest := NewCommander(cmdr, &config.Config{}, nil)
 
 	assert.Equal(t, subs, pluginUnderTest.Subscriptions())
 	cmdr.AssertExpectations(t)
 }
 
+func TestCommandDedup(t *testing.T) {
+	dedup := newCommandDedup(2)
+
+	// first delivery is dispatched, the duplicate is not
+	assert.True(t, dedup.begin("msg-1", "key-1"))
+	assert.False(t, dedup.begin("msg-1-retry", "key-1"))
+
+	// no result to replay while the original is still running
+	response, seen := dedup.response("key-1")
+	assert.True(t, seen)
+	assert.Nil(t, response)
+
+	// once the response went out it is replayed for later duplicates
+	result := &proto.Command{Meta: &proto.Metadata{MessageId: "msg-1"}}
+	dedup.complete("msg-1", result)
+	response, seen = dedup.response("key-1")
+	assert.True(t, seen)
+	assert.Equal(t, result, response)
+
+	// a response to a command without a key is ignored
+	dedup.complete("msg-unknown", &proto.Command{})
+	_, seen = dedup.response("")
+	assert.False(t, seen)
+
+	// the cache is bounded, the oldest key is evicted first
+	assert.True(t, dedup.begin("msg-2", "key-2"))
+	assert.True(t, dedup.begin("msg-3", "key-3"))
+	_, seen = dedup.response("key-1")
+	assert.False(t, seen)
+	assert.True(t, dedup.begin("msg-1-again", "key-1"))
+}
+
 func TestCommander_Info(t *testing.T) {
 	cmdr := tutils.NewMockCommandClient()
-	pluginUnderTest := NewCommander(cmdr, &config.Config{})
+	pluginUnderTest := NewCommander(cmdr, &config.Config{}, nil)
 
 	assert.Equal(t, "Commander", pluginUnderTest.Info().Name())
 }
@@ -280,7 +312,7 @@ func TestCommander_Close(t *testing.T) {
 	// setup expectations
 	cmdr.On("Recv").Return(make(<-chan client.Message))
 
-	pluginUnderTest := NewCommander(cmdr, &config.Config{})
+	pluginUnderTest := NewCommander(cmdr, &config.Config{}, nil)
 	ctx, cancel := context.WithCancel(context.Background())
 	messagePipe := core.SetupMockMessagePipe(t, ctx, pluginUnderTest)
 
@@ -0,0 +1,166 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	sdk "github.com/nginx/agent/sdk/v2"
+	"github.com/nginx/agent/sdk/v2/proto"
+	commonProto "github.com/nginx/agent/sdk/v2/proto/common"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+// ConfigConflicts walks the include tree of every discovered nginx instance and
+// reports directives that contradict each other across files — duplicate
+// default_server flags on one listen address, upstream blocks declared twice, and
+// server names served by more than one server block on the same listen. nginx
+// accepts most of these and silently picks a winner, so the conflicts are attached
+// to the config report as structured events rather than failing anything.
+type ConfigConflicts struct {
+	messagePipeline core.MessagePipeInterface
+	conf            *config.Config
+	env             core.Environment
+	meta            *proto.Metadata
+	reportedDigests map[string]string
+}
+
+func NewConfigConflicts(conf *config.Config, env core.Environment, meta *proto.Metadata) *ConfigConflicts {
+	return &ConfigConflicts{
+		conf:            conf,
+		env:             env,
+		meta:            meta,
+		reportedDigests: make(map[string]string),
+	}
+}
+
+func (cc *ConfigConflicts) Init(pipeline core.MessagePipeInterface) {
+	log.Info("ConfigConflicts initializing")
+	cc.messagePipeline = pipeline
+}
+
+func (cc *ConfigConflicts) Close() {
+	log.Info("ConfigConflicts is wrapping up")
+}
+
+func (cc *ConfigConflicts) Info() *core.Info {
+	return core.NewInfo("ConfigConflicts", "v0.0.1")
+}
+
+func (cc *ConfigConflicts) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.NginxInstancesFound):
+		nginxDetailsMap, ok := msg.Data().(map[string]*proto.NginxDetails)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		for _, detail := range nginxDetailsMap {
+			cc.reportConflicts(detail)
+		}
+	}
+}
+
+func (cc *ConfigConflicts) Subscriptions() []string {
+	return []string{
+		core.NginxInstancesFound,
+	}
+}
+
+// reportConflicts analyzes the config of a single nginx instance and emits one event
+// per conflict. A config whose conflicts have not changed since the last instance
+// refresh is not re-reported.
+func (cc *ConfigConflicts) reportConflicts(detail *proto.NginxDetails) {
+	conflicts, err := sdk.DetectConfigConflicts(detail.GetConfPath())
+	if err != nil {
+		log.Warnf("ConfigConflicts: unable to analyze config for nginx instance %s: %v", detail.GetNginxId(), err)
+		return
+	}
+
+	digest := conflictDigest(conflicts)
+	if cc.reportedDigests[detail.GetNginxId()] == digest {
+		return
+	}
+	cc.reportedDigests[detail.GetNginxId()] = digest
+
+	if len(conflicts) == 0 {
+		return
+	}
+
+	events := make([]*eventsProto.Event, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		events = append(events, cc.createConflictEvent(detail.GetNginxId(), conflict))
+	}
+
+	cc.messagePipeline.Process(core.NewMessage(core.Events, &proto.Command{
+		Meta: cc.meta,
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_EventReport{
+			EventReport: &eventsProto.EventReport{
+				Events: events,
+			},
+		},
+	}))
+}
+
+func (cc *ConfigConflicts) createConflictEvent(nginxID string, conflict *sdk.ConfigConflict) *eventsProto.Event {
+	activityEvent := &eventsProto.ActivityEvent{
+		Message: fmt.Sprintf("config conflict: %s (%s:%d)", conflict.Message, conflict.File, conflict.Line),
+		Dimensions: []*commonProto.Dimension{
+			{
+				Name:  "nginx_id",
+				Value: nginxID,
+			},
+			{
+				Name:  "system_id",
+				Value: cc.env.GetSystemUUID(),
+			},
+			{
+				Name:  "hostname",
+				Value: cc.env.GetHostname(),
+			},
+			{
+				Name:  "instance_group",
+				Value: cc.conf.InstanceGroup,
+			},
+		},
+	}
+
+	return &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			UUID:          uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Module:        MODULE,
+			Timestamp:     types.TimestampNow(),
+			EventLevel:    WARN_EVENT_LEVEL,
+			Type:          NGINX_EVENT_TYPE,
+			Category:      CONFIG_CATEGORY,
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: activityEvent,
+		},
+	}
+}
+
+// conflictDigest returns a stable digest of the conflicts, used to suppress
+// duplicate reports for an unchanged config.
+func conflictDigest(conflicts []*sdk.ConfigConflict) string {
+	hash := sha256.New()
+	for _, conflict := range conflicts {
+		fmt.Fprintf(hash, "%s|%s|%s:%d|%s:%d\n", conflict.Type, conflict.Subject, conflict.File, conflict.Line, conflict.FirstFile, conflict.FirstLine)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
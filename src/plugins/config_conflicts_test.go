@@ -0,0 +1,88 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func TestConfigConflictsReportConflicts(t *testing.T) {
+	tmpDir := t.TempDir()
+	confFile := filepath.Join(tmpDir, "nginx.conf")
+	conf := `
+http {
+    server {
+        listen 80 default_server;
+        server_name example.com;
+    }
+    server {
+        listen 80 default_server;
+        server_name example.com;
+    }
+}
+`
+	require.NoError(t, os.WriteFile(confFile, []byte(conf), 0644))
+
+	details := tutils.GetDetailsMap()
+	details["12345"].ConfPath = confFile
+
+	plugin := NewConfigConflicts(&config.Config{}, tutils.GetMockEnv(), &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), plugin)
+	plugin.Init(messagePipe)
+
+	plugin.Process(core.NewMessage(core.NginxInstancesFound, details))
+
+	events := collectActivityEvents(t, messagePipe.GetMessages())
+	require.Len(t, events, 2)
+	for _, event := range events {
+		assert.Equal(t, WARN_EVENT_LEVEL, event.GetMetadata().GetEventLevel())
+		assert.Contains(t, event.GetActivityEvent().GetMessage(), "config conflict")
+	}
+	assert.Contains(t, events[0].GetActivityEvent().GetMessage(), "default server")
+	assert.Contains(t, events[1].GetActivityEvent().GetMessage(), `server name "example.com"`)
+
+	// an unchanged config must not be re-reported
+	messagePipe.ClearMessages()
+	plugin.Process(core.NewMessage(core.NginxInstancesFound, details))
+	assert.Empty(t, collectActivityEvents(t, messagePipe.GetMessages()))
+}
+
+func TestConfigConflictsCleanConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	confFile := filepath.Join(tmpDir, "nginx.conf")
+	conf := `
+http {
+    server {
+        listen 80 default_server;
+        server_name example.com;
+    }
+}
+`
+	require.NoError(t, os.WriteFile(confFile, []byte(conf), 0644))
+
+	details := tutils.GetDetailsMap()
+	details["12345"].ConfPath = confFile
+
+	plugin := NewConfigConflicts(&config.Config{}, tutils.GetMockEnv(), &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), plugin)
+	plugin.Init(messagePipe)
+
+	plugin.Process(core.NewMessage(core.NginxInstancesFound, details))
+	assert.Empty(t, collectActivityEvents(t, messagePipe.GetMessages()))
+}
@@ -0,0 +1,237 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gogo/protobuf/types"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/grpc"
+	"github.com/nginx/agent/sdk/v2/proto"
+	commonProto "github.com/nginx/agent/sdk/v2/proto/common"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/logger"
+)
+
+// reloadDebounce suppresses duplicate reloads when an editor rewrites the
+// config file with several filesystem operations in quick succession
+const reloadDebounce = time.Second
+
+// ConfigReloader reloads the agent's own configuration file without a restart,
+// triggered by SIGHUP or by the file changing on disk. Reloadable settings are
+// picked up by the other plugins through an AgentConfigChanged message, and
+// changed settings that still need a restart are called out in the reload
+// result event.
+type ConfigReloader struct {
+	messagePipeline core.MessagePipeInterface
+	config          *config.Config
+	env             core.Environment
+	watcher         *fsnotify.Watcher
+	sigChan         chan os.Signal
+	lastReload      time.Time
+	ctx             context.Context
+}
+
+func NewConfigReloader(config *config.Config, env core.Environment) *ConfigReloader {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Errorf("Error creating agent config watcher: %v", err)
+	}
+
+	return &ConfigReloader{
+		config:  config,
+		env:     env,
+		watcher: watcher,
+		sigChan: make(chan os.Signal, 1),
+	}
+}
+
+func (r *ConfigReloader) Init(pipeline core.MessagePipeInterface) {
+	log.Info("ConfigReloader initializing")
+	r.messagePipeline = pipeline
+	r.ctx = pipeline.Context()
+
+	if r.watcher != nil {
+		// watch the containing directories so rewrites via rename are seen
+		for _, dir := range r.configDirs() {
+			if err := r.watcher.Add(dir); err != nil {
+				log.Warnf("Unable to watch agent config directory %s: %v", dir, err)
+			}
+		}
+	}
+
+	signal.Notify(r.sigChan, syscall.SIGHUP)
+
+	go r.watchLoop()
+}
+
+func (r *ConfigReloader) Info() *core.Info {
+	return core.NewInfo("Agent Config Reloader", "v0.0.1")
+}
+
+func (r *ConfigReloader) Close() {
+	log.Info("ConfigReloader is wrapping up")
+	signal.Stop(r.sigChan)
+	if r.watcher != nil {
+		r.watcher.Close()
+	}
+}
+
+func (r *ConfigReloader) Process(message *core.Message) {}
+
+func (r *ConfigReloader) Subscriptions() []string {
+	return []string{}
+}
+
+// configDirs returns the directories holding the static and dynamic config files
+func (r *ConfigReloader) configDirs() []string {
+	dirs := []string{}
+	for _, path := range []string{r.config.Path, r.config.DynamicConfigPath} {
+		if path == "" {
+			continue
+		}
+		dir := filepath.Dir(path)
+		if len(dirs) == 0 || dirs[len(dirs)-1] != dir {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+func (r *ConfigReloader) watchLoop() {
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-r.sigChan:
+			log.Info("Received SIGHUP, reloading agent configuration")
+			r.reload()
+		case event := <-r.watcherEvents():
+			if !r.isConfigFile(event.Name) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			log.Infof("Agent configuration file %s changed, reloading", event.Name)
+			r.reload()
+		case err := <-r.watcherErrors():
+			if err != nil {
+				log.Errorf("Agent config watcher error: %v", err)
+			}
+		}
+	}
+}
+
+// watcherEvents returns a nil channel when the watcher could not be created,
+// so the loop still serves SIGHUP
+func (r *ConfigReloader) watcherEvents() chan fsnotify.Event {
+	if r.watcher == nil {
+		return nil
+	}
+	return r.watcher.Events
+}
+
+func (r *ConfigReloader) watcherErrors() chan error {
+	if r.watcher == nil {
+		return nil
+	}
+	return r.watcher.Errors
+}
+
+func (r *ConfigReloader) isConfigFile(name string) bool {
+	return name != "" && (name == r.config.Path || name == r.config.DynamicConfigPath)
+}
+
+func (r *ConfigReloader) reload() {
+	if time.Since(r.lastReload) < reloadDebounce {
+		return
+	}
+	r.lastReload = time.Now()
+
+	updated, err := config.GetConfig(r.config.ClientID)
+	if err != nil {
+		log.Errorf("Failed to reload agent config: %v", err)
+		r.publishReloadResult(ERROR_EVENT_LEVEL, fmt.Sprintf("agent config reload failed: %v", err))
+		return
+	}
+
+	restartOnly := config.NonReloadableChanges(r.config, updated)
+
+	logger.SetLogLevel(updated.Log.Level)
+	logger.SetLogFormat(updated.Log.Format)
+	r.config = updated
+
+	// the other plugins re-read the config from disk when they see this message
+	r.messagePipeline.Process(core.NewMessage(core.AgentConfigChanged, ""))
+
+	if len(restartOnly) > 0 {
+		r.publishReloadResult(WARN_EVENT_LEVEL, fmt.Sprintf(
+			"agent config reloaded, but changes to %s require an agent restart to take effect", strings.Join(restartOnly, ", ")))
+		return
+	}
+	r.publishReloadResult(INFO_EVENT_LEVEL, "agent config reloaded")
+}
+
+func (r *ConfigReloader) publishReloadResult(level, message string) {
+	activityEvent := &eventsProto.ActivityEvent{
+		Message: message,
+		Dimensions: []*commonProto.Dimension{
+			{
+				Name:  "system_id",
+				Value: r.env.GetSystemUUID(),
+			},
+			{
+				Name:  "hostname",
+				Value: r.env.GetHostname(),
+			},
+			{
+				Name:  "instance_group",
+				Value: r.config.InstanceGroup,
+			},
+		},
+	}
+
+	event := &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			UUID:          uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Module:        MODULE,
+			Timestamp:     types.TimestampNow(),
+			EventLevel:    level,
+			Type:          AGENT_EVENT_TYPE,
+			Category:      CONFIG_CATEGORY,
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: activityEvent,
+		},
+	}
+
+	r.messagePipeline.Process(core.NewMessage(core.Events, &proto.Command{
+		Meta: grpc.NewMessageMeta(uuid.NewString()),
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_EventReport{
+			EventReport: &eventsProto.EventReport{
+				Events: []*eventsProto.Event{event},
+			},
+		},
+	}))
+}
@@ -0,0 +1,68 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func TestConfigReloaderSubscriptions(t *testing.T) {
+	pluginUnderTest := NewConfigReloader(&config.Config{}, tutils.GetMockEnv())
+	assert.Equal(t, []string{}, pluginUnderTest.Subscriptions())
+	assert.Equal(t, "Agent Config Reloader", pluginUnderTest.Info().Name())
+	pluginUnderTest.Close()
+}
+
+func TestConfigReloaderIsConfigFile(t *testing.T) {
+	pluginUnderTest := NewConfigReloader(&config.Config{
+		Path:              "/etc/nginx-agent/nginx-agent.conf",
+		DynamicConfigPath: "/var/lib/nginx-agent/agent-dynamic.conf",
+	}, tutils.GetMockEnv())
+	defer pluginUnderTest.Close()
+
+	assert.True(t, pluginUnderTest.isConfigFile("/etc/nginx-agent/nginx-agent.conf"))
+	assert.True(t, pluginUnderTest.isConfigFile("/var/lib/nginx-agent/agent-dynamic.conf"))
+	assert.False(t, pluginUnderTest.isConfigFile("/etc/nginx-agent/nginx-agent.conf.swp"))
+	assert.False(t, pluginUnderTest.isConfigFile(""))
+
+	assert.Equal(t, []string{"/etc/nginx-agent", "/var/lib/nginx-agent"}, pluginUnderTest.configDirs())
+}
+
+func TestConfigReloaderPublishReloadResult(t *testing.T) {
+	pluginUnderTest := NewConfigReloader(&config.Config{InstanceGroup: "my_instances"}, tutils.GetMockEnv())
+	defer pluginUnderTest.Close()
+
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.Init(messagePipe)
+
+	pluginUnderTest.publishReloadResult(WARN_EVENT_LEVEL, "agent config reloaded, but changes to server require an agent restart to take effect")
+
+	messages := messagePipe.GetMessages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, core.Events, messages[0].Topic())
+
+	command, ok := messages[0].Data().(*proto.Command)
+	assert.True(t, ok)
+	events := command.GetEventReport().GetEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, WARN_EVENT_LEVEL, events[0].GetMetadata().GetEventLevel())
+	assert.Equal(t, CONFIG_CATEGORY, events[0].GetMetadata().GetCategory())
+
+	activityEvent, ok := events[0].GetData().(*eventsProto.Event_ActivityEvent)
+	assert.True(t, ok)
+	assert.Contains(t, activityEvent.ActivityEvent.GetMessage(), "require an agent restart")
+}
@@ -0,0 +1,150 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+
+	sdk "github.com/nginx/agent/sdk/v2"
+	"github.com/nginx/agent/sdk/v2/proto"
+)
+
+const (
+	// configTemplateSuffix marks files of a pushed config package that are rendered
+	// on the host before validation; the output drops the suffix
+	configTemplateSuffix = ".tmpl"
+	// configTemplateValuesFile holds the package-supplied values of the templates in
+	// its directory and doubles as their schema: a reference to a value it does not
+	// declare fails the render, and with it the apply, before nginx sees the config
+	configTemplateValuesFile = "agent_template_values.json"
+)
+
+// templateHostFacts are the host-specific values every template can draw on, so one
+// pushed package can be parameterized per machine of a heterogeneous fleet.
+type templateHostFacts struct {
+	Hostname string
+	SystemID string
+	CPUs     int
+	IPs      []string
+	Env      map[string]string
+}
+
+// renderConfigTemplates renders every Go template file of the applied config next to
+// the template, registering the output with the config apply transaction so a failed
+// apply rolls the rendered files back together with the rest of the config. It runs
+// after the files are written and before the config is validated, so nginx -t only
+// ever sees rendered output.
+func (n *Nginx) renderConfigTemplates(config *proto.NginxConfig, configApply *sdk.ConfigApply) error {
+	templates := []string{}
+	for _, directory := range config.GetDirectoryMap().GetDirectories() {
+		for _, file := range directory.GetFiles() {
+			if strings.HasSuffix(file.GetName(), configTemplateSuffix) {
+				templates = append(templates, filepath.Join(directory.GetName(), file.GetName()))
+			}
+		}
+	}
+	if len(templates) == 0 {
+		return nil
+	}
+
+	facts := n.templateHostFacts()
+	for _, templateFile := range templates {
+		if err := renderConfigTemplate(templateFile, facts, configApply); err != nil {
+			return fmt.Errorf("rendering template %s: %v", templateFile, err)
+		}
+	}
+	return nil
+}
+
+func renderConfigTemplate(templateFile string, facts *templateHostFacts, configApply *sdk.ConfigApply) error {
+	content, err := os.ReadFile(templateFile)
+	if err != nil {
+		return err
+	}
+	values, err := loadTemplateValues(filepath.Dir(templateFile))
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New(filepath.Base(templateFile)).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return err
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, map[string]interface{}{
+		"Host":   facts,
+		"Values": values,
+	}); err != nil {
+		return err
+	}
+
+	outputFile := strings.TrimSuffix(templateFile, configTemplateSuffix)
+	if configApply != nil {
+		if err := configApply.MarkAndSave(outputFile); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(outputFile, rendered.Bytes(), 0644)
+}
+
+func loadTemplateValues(dir string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	content, err := os.ReadFile(filepath.Join(dir, configTemplateValuesFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return values, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("invalid values file %s: %v", configTemplateValuesFile, err)
+	}
+	return values, nil
+}
+
+func (n *Nginx) templateHostFacts() *templateHostFacts {
+	facts := &templateHostFacts{
+		Hostname: n.env.GetHostname(),
+		SystemID: n.env.GetSystemUUID(),
+		CPUs:     runtime.NumCPU(),
+		Env:      map[string]string{},
+	}
+	for _, entry := range os.Environ() {
+		if name, value, found := strings.Cut(entry, "="); found {
+			facts.Env[name] = value
+		}
+	}
+
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return facts
+	}
+	for _, networkInterface := range interfaces {
+		if networkInterface.Flags&net.FlagUp == 0 || networkInterface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addresses, err := networkInterface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, address := range addresses {
+			if ipNet, ok := address.(*net.IPNet); ok {
+				facts.IPs = append(facts.IPs, ipNet.IP.String())
+			}
+		}
+	}
+	return facts
+}
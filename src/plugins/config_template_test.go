@@ -0,0 +1,109 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/nginx/agent/sdk/v2"
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func templateTestConfig(dir string, files ...string) *proto.NginxConfig {
+	protoFiles := []*proto.File{}
+	for _, file := range files {
+		protoFiles = append(protoFiles, &proto.File{Name: file})
+	}
+	return &proto.NginxConfig{
+		DirectoryMap: &proto.DirectoryMap{
+			Directories: []*proto.Directory{
+				{Name: dir, Files: protoFiles},
+			},
+		},
+	}
+}
+
+func templateTestPlugin() *Nginx {
+	env := tutils.GetMockEnvWithProcess()
+	env.On("GetHostname").Return("test-host")
+	env.On("GetSystemUUID").Return("456")
+	binary := tutils.NewMockNginxBinary()
+	return NewNginx(tutils.GetMockCommandClient(nil), binary, env, &config.Config{})
+}
+
+func TestRenderConfigTemplates(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.conf.tmpl"),
+		[]byte("# host {{.Host.Hostname}} with {{.Host.CPUs}} cpus\nlisten {{.Values.port}};\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, configTemplateValuesFile),
+		[]byte(`{"port": 8080}`), 0644))
+
+	pluginUnderTest := templateTestPlugin()
+	require.NoError(t, pluginUnderTest.renderConfigTemplates(templateTestConfig(dir, "app.conf.tmpl"), nil))
+
+	rendered, err := os.ReadFile(filepath.Join(dir, "app.conf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "# host test-host with")
+	assert.Contains(t, string(rendered), "listen 8080;")
+}
+
+func TestRenderConfigTemplatesUndeclaredValue(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.conf.tmpl"),
+		[]byte("listen {{.Values.port}};\n"), 0644))
+
+	pluginUnderTest := templateTestPlugin()
+	// the values file doubles as the schema: a reference to a value that is not
+	// declared fails the render before nginx sees the config
+	err := pluginUnderTest.renderConfigTemplates(templateTestConfig(dir, "app.conf.tmpl"), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "app.conf.tmpl")
+
+	_, err = os.Stat(filepath.Join(dir, "app.conf"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRenderConfigTemplatesRollback(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "app.conf")
+	require.NoError(t, os.WriteFile(output, []byte("listen 80;\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.conf.tmpl"),
+		[]byte("listen {{.Values.port}};\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, configTemplateValuesFile),
+		[]byte(`{"port": 8080}`), 0644))
+
+	configApply, err := sdk.NewConfigApply("", map[string]struct{}{dir: {}})
+	require.NoError(t, err)
+
+	pluginUnderTest := templateTestPlugin()
+	require.NoError(t, pluginUnderTest.renderConfigTemplates(templateTestConfig(dir, "app.conf.tmpl"), configApply))
+
+	rendered, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, "listen 8080;\n", string(rendered))
+
+	// the rendered output is part of the apply transaction: a rollback restores the
+	// previous contents
+	require.NoError(t, configApply.Rollback(assert.AnError))
+	restored, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, "listen 80;\n", string(restored))
+}
+
+func TestRenderConfigTemplatesNoTemplates(t *testing.T) {
+	dir := t.TempDir()
+	pluginUnderTest := templateTestPlugin()
+	assert.NoError(t, pluginUnderTest.renderConfigTemplates(templateTestConfig(dir, "nginx.conf"), nil))
+}
@@ -0,0 +1,291 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const (
+	agentConsulServiceName = "nginx-agent"
+	nginxConsulServiceName = "nginx"
+)
+
+// ConsulRegistration registers the agent and the nginx instances it manages as
+// services in a Consul catalog. The services carry TTL health checks that the
+// agent refreshes while the instances stay up, so Consul marks them critical
+// when the agent stops reporting, and everything is deregistered on a clean
+// shutdown.
+type ConsulRegistration struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	cancel          context.CancelFunc
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	client          *consulClient
+	mu              sync.Mutex
+	agentServiceID  string
+	// services maps the registered Consul service IDs to their check IDs
+	services map[string]string
+}
+
+// consulClient is a minimal client for the local Consul agent HTTP API,
+// covering only service registration and TTL check updates
+type consulClient struct {
+	address string
+	token   string
+	http    *http.Client
+}
+
+// consulService is the body of a PUT /v1/agent/service/register request
+type consulService struct {
+	ID    string            `json:"ID"`
+	Name  string            `json:"Name"`
+	Meta  map[string]string `json:"Meta,omitempty"`
+	Check *consulCheck      `json:"Check,omitempty"`
+}
+
+type consulCheck struct {
+	CheckID                        string `json:"CheckID"`
+	TTL                            string `json:"TTL"`
+	DeregisterCriticalServiceAfter string `json:"DeregisterCriticalServiceAfter,omitempty"`
+}
+
+func NewConsulRegistration(conf *config.Config, env core.Environment, binary core.NginxBinary) *ConsulRegistration {
+	return &ConsulRegistration{
+		conf:   conf,
+		env:    env,
+		binary: binary,
+		client: &consulClient{
+			address: conf.Consul.Address,
+			token:   conf.Consul.Token,
+			http:    &http.Client{Timeout: 10 * time.Second},
+		},
+		services: make(map[string]string),
+	}
+}
+
+func (cr *ConsulRegistration) Init(pipeline core.MessagePipeInterface) {
+	log.Info("ConsulRegistration initializing")
+	cr.messagePipeline = pipeline
+	cr.ctx, cr.cancel = context.WithCancel(pipeline.Context())
+
+	if err := cr.registerAgent(); err != nil {
+		log.Errorf("Unable to register the agent in Consul: %v", err)
+	}
+
+	go cr.refreshChecks()
+}
+
+func (cr *ConsulRegistration) Close() {
+	log.Info("ConsulRegistration is wrapping up")
+	if cr.cancel != nil {
+		cr.cancel()
+	}
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	for serviceID := range cr.services {
+		if err := cr.client.deregisterService(serviceID); err != nil {
+			log.Warnf("Unable to deregister Consul service %s: %v", serviceID, err)
+		}
+		delete(cr.services, serviceID)
+	}
+}
+
+func (cr *ConsulRegistration) Info() *core.Info {
+	return core.NewInfo("Consul Registration", "v0.0.1")
+}
+
+func (cr *ConsulRegistration) Process(msg *core.Message) {
+	switch msg.Topic() {
+	case core.NginxDetailProcUpdate:
+		procs, ok := msg.Data().([]core.Process)
+		if !ok {
+			return
+		}
+		cr.syncNginxServices(procs)
+	}
+}
+
+func (cr *ConsulRegistration) Subscriptions() []string {
+	return []string{
+		core.NginxDetailProcUpdate,
+	}
+}
+
+func (cr *ConsulRegistration) registerAgent() error {
+	serviceID := fmt.Sprintf("%s-%s", agentConsulServiceName, cr.env.GetSystemUUID())
+	checkID := serviceID + "-ttl"
+
+	err := cr.client.registerService(&consulService{
+		ID:   serviceID,
+		Name: agentConsulServiceName,
+		Meta: map[string]string{
+			"system_id":      cr.env.GetSystemUUID(),
+			"hostname":       cr.env.GetHostname(),
+			"instance_group": cr.conf.InstanceGroup,
+		},
+		Check: cr.ttlCheck(checkID),
+	})
+	if err != nil {
+		return err
+	}
+
+	cr.mu.Lock()
+	cr.agentServiceID = serviceID
+	cr.services[serviceID] = checkID
+	cr.mu.Unlock()
+	return nil
+}
+
+// syncNginxServices reconciles the registered nginx services with the master
+// processes currently running on the host
+func (cr *ConsulRegistration) syncNginxServices(procs []core.Process) {
+	running := make(map[string]string)
+	for _, proc := range procs {
+		if !proc.IsMaster {
+			continue
+		}
+		details := cr.binary.GetNginxDetailsFromProcess(proc)
+		serviceID := fmt.Sprintf("%s-%s", nginxConsulServiceName, details.GetNginxId())
+		running[serviceID] = details.GetVersion()
+	}
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	for serviceID, version := range running {
+		if _, registered := cr.services[serviceID]; registered {
+			continue
+		}
+		checkID := serviceID + "-ttl"
+		err := cr.client.registerService(&consulService{
+			ID:   serviceID,
+			Name: nginxConsulServiceName,
+			Meta: map[string]string{
+				"system_id":     cr.env.GetSystemUUID(),
+				"hostname":      cr.env.GetHostname(),
+				"nginx_version": version,
+			},
+			Check: cr.ttlCheck(checkID),
+		})
+		if err != nil {
+			log.Errorf("Unable to register nginx instance in Consul: %v", err)
+			continue
+		}
+		log.Infof("Registered Consul service %s", serviceID)
+		cr.services[serviceID] = checkID
+	}
+
+	for serviceID := range cr.services {
+		if _, stillRunning := running[serviceID]; stillRunning || serviceID == cr.agentServiceID {
+			continue
+		}
+		if err := cr.client.deregisterService(serviceID); err != nil {
+			log.Warnf("Unable to deregister Consul service %s: %v", serviceID, err)
+			continue
+		}
+		log.Infof("Deregistered Consul service %s", serviceID)
+		delete(cr.services, serviceID)
+	}
+}
+
+func (cr *ConsulRegistration) ttlCheck(checkID string) *consulCheck {
+	check := &consulCheck{
+		CheckID: checkID,
+		TTL:     cr.conf.Consul.CheckTTL.String(),
+	}
+	if cr.conf.Consul.DeregisterAfter > 0 {
+		check.DeregisterCriticalServiceAfter = cr.conf.Consul.DeregisterAfter.String()
+	}
+	return check
+}
+
+// refreshChecks passes the TTL checks of every registered service at half the
+// check TTL, so the services stay healthy while the instances are up
+func (cr *ConsulRegistration) refreshChecks() {
+	interval := cr.conf.Consul.CheckTTL / 2
+	if interval <= 0 {
+		interval = config.Defaults.Consul.CheckTTL / 2
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-cr.ctx.Done():
+			return
+		case <-ticker.C:
+			cr.mu.Lock()
+			checkIDs := make([]string, 0, len(cr.services))
+			for _, checkID := range cr.services {
+				checkIDs = append(checkIDs, checkID)
+			}
+			cr.mu.Unlock()
+
+			for _, checkID := range checkIDs {
+				if err := cr.client.passCheck(checkID); err != nil {
+					log.Warnf("Unable to update Consul check %s: %v", checkID, err)
+				}
+			}
+		}
+	}
+}
+
+func (c *consulClient) registerService(service *consulService) error {
+	return c.put("/v1/agent/service/register", service)
+}
+
+func (c *consulClient) deregisterService(serviceID string) error {
+	return c.put("/v1/agent/service/deregister/"+serviceID, nil)
+}
+
+func (c *consulClient) passCheck(checkID string) error {
+	return c.put("/v1/agent/check/pass/"+checkID, nil)
+}
+
+func (c *consulClient) put(path string, body interface{}) error {
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			return err
+		}
+	}
+
+	request, err := http.NewRequest(http.MethodPut, c.address+path, &payload)
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		request.Header.Set("X-Consul-Token", c.token)
+	}
+
+	response, err := c.http.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("consul returned %s for %s", response.Status, path)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+// fakeConsul records the service and check API calls the plugin makes
+type fakeConsul struct {
+	mu           sync.Mutex
+	registered   map[string]consulService
+	deregistered []string
+	passed       []string
+}
+
+func (f *fakeConsul) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		switch {
+		case r.URL.Path == "/v1/agent/service/register":
+			var service consulService
+			if err := json.NewDecoder(r.Body).Decode(&service); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			f.registered[service.ID] = service
+		case strings.HasPrefix(r.URL.Path, "/v1/agent/service/deregister/"):
+			f.deregistered = append(f.deregistered, strings.TrimPrefix(r.URL.Path, "/v1/agent/service/deregister/"))
+		case strings.HasPrefix(r.URL.Path, "/v1/agent/check/pass/"):
+			f.passed = append(f.passed, strings.TrimPrefix(r.URL.Path, "/v1/agent/check/pass/"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func consulTestConfig(address string) *config.Config {
+	return &config.Config{
+		InstanceGroup: "my_instances",
+		Consul: config.Consul{
+			Enable:          true,
+			Address:         address,
+			CheckTTL:        30 * time.Second,
+			DeregisterAfter: 10 * time.Minute,
+		},
+	}
+}
+
+func TestConsulRegistration(t *testing.T) {
+	consul := &fakeConsul{registered: map[string]consulService{}}
+	server := httptest.NewServer(consul.handler())
+	defer server.Close()
+
+	env := tutils.GetMockEnv()
+	binary := tutils.NewMockNginxBinary()
+	binary.On("GetNginxDetailsFromProcess", tutils.GetProcesses()[0]).Return(&proto.NginxDetails{
+		NginxId: "1", Version: "1.23.2", ProcessId: "1",
+	})
+
+	pluginUnderTest := NewConsulRegistration(consulTestConfig(server.URL), env, binary)
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.Init(messagePipe)
+
+	agentServiceID := "nginx-agent-" + env.GetSystemUUID()
+	require.Contains(t, consul.registered, agentServiceID)
+	assert.Equal(t, agentConsulServiceName, consul.registered[agentServiceID].Name)
+	assert.Equal(t, "30s", consul.registered[agentServiceID].Check.TTL)
+
+	// a master process appearing registers its nginx instance
+	pluginUnderTest.Process(core.NewMessage(core.NginxDetailProcUpdate, tutils.GetProcesses()))
+	require.Contains(t, consul.registered, "nginx-1")
+	assert.Equal(t, nginxConsulServiceName, consul.registered["nginx-1"].Name)
+	assert.Equal(t, "1.23.2", consul.registered["nginx-1"].Meta["nginx_version"])
+
+	// the master process going away deregisters it, but not the agent
+	pluginUnderTest.Process(core.NewMessage(core.NginxDetailProcUpdate, []core.Process{}))
+	assert.Equal(t, []string{"nginx-1"}, consul.deregistered)
+
+	// shutdown deregisters everything that is left
+	pluginUnderTest.Close()
+	assert.Contains(t, consul.deregistered, agentServiceID)
+}
+
+func TestConsulRegistrationSubscriptions(t *testing.T) {
+	pluginUnderTest := NewConsulRegistration(consulTestConfig("http://127.0.0.1:8500"), tutils.GetMockEnv(), tutils.NewMockNginxBinary())
+	assert.Equal(t, []string{core.NginxDetailProcUpdate}, pluginUnderTest.Subscriptions())
+	assert.Equal(t, "Consul Registration", pluginUnderTest.Info().Name())
+}
@@ -0,0 +1,250 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"fmt"
+	"io"
+	"os"
+	re "regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	commonProto "github.com/nginx/agent/sdk/v2/proto/common"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const (
+	CRASH_LOOP_WARNING_MESSAGE  = "nginx instance %s workers restarted %d times in the last %s%s"
+	CRASH_LOOP_CRITICAL_MESSAGE = "nginx instance %s workers are crash looping: %d restarts in the last %s%s"
+
+	crashLoopWindow            = time.Minute
+	crashLoopWarnRestarts      = 3
+	crashLoopCriticalRestarts  = 6
+	crashLoopErrorLogTailBytes = 16 * 1024
+)
+
+// workerExitRegex matches the line the nginx master logs when a worker dies, which
+// carries the exit code or signal and whether a core was dumped
+var workerExitRegex = re.MustCompile(`worker process \d+ exited (?:on signal \d+|with fatal code \d+|with code \d+)(?: \(core dumped\))?`)
+
+// CrashLoopMonitor watches the worker lifecycle messages of the process watcher for
+// rapid restarts and publishes an escalating event series when workers flap, instead
+// of the silence a crash loop otherwise produces. The last worker exit line from the
+// error log, with its signal or exit code and whether a core was dumped, is attached
+// to the events so the reason travels with the alert.
+type CrashLoopMonitor struct {
+	messagePipeline core.MessagePipeInterface
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	meta            *proto.Metadata
+	mu              sync.Mutex
+	restarts        map[string][]time.Time
+	reportedLevels  map[string]string
+	lastExit        map[string]string
+}
+
+func NewCrashLoopMonitor(conf *config.Config, env core.Environment, binary core.NginxBinary, meta *proto.Metadata) *CrashLoopMonitor {
+	return &CrashLoopMonitor{
+		conf:           conf,
+		env:            env,
+		binary:         binary,
+		meta:           meta,
+		restarts:       make(map[string][]time.Time),
+		reportedLevels: make(map[string]string),
+		lastExit:       make(map[string]string),
+	}
+}
+
+func (clm *CrashLoopMonitor) Init(pipeline core.MessagePipeInterface) {
+	log.Info("CrashLoopMonitor initializing")
+	clm.messagePipeline = pipeline
+}
+
+func (clm *CrashLoopMonitor) Close() {
+	log.Info("CrashLoopMonitor is wrapping up")
+}
+
+func (clm *CrashLoopMonitor) Info() *core.Info {
+	return core.NewInfo("CrashLoopMonitor", "v0.0.1")
+}
+
+func (clm *CrashLoopMonitor) Process(msg *core.Message) {
+	detail, ok := msg.Data().(*proto.NginxDetails)
+	if !ok || detail == nil {
+		return
+	}
+
+	switch {
+	case msg.Exact(core.NginxWorkerProcKilled), msg.Exact(core.NginxMasterProcKilled):
+		clm.captureExitMetadata(detail)
+	case msg.Exact(core.NginxWorkerProcCreated):
+		clm.recordRestart(detail)
+	}
+}
+
+func (clm *CrashLoopMonitor) Subscriptions() []string {
+	return []string{
+		core.NginxWorkerProcCreated,
+		core.NginxWorkerProcKilled,
+		core.NginxMasterProcKilled,
+	}
+}
+
+// captureExitMetadata picks the most recent worker exit line out of the error log
+// tail, so the signal or exit code and the core dump marker are at hand when the
+// restarts that follow cross a threshold.
+func (clm *CrashLoopMonitor) captureExitMetadata(detail *proto.NginxDetails) {
+	for logFile := range clm.binary.GetErrorLogs() {
+		line, found := lastWorkerExitLine(logFile)
+		if !found {
+			continue
+		}
+		clm.mu.Lock()
+		clm.lastExit[detail.GetNginxId()] = line
+		clm.mu.Unlock()
+		return
+	}
+}
+
+// recordRestart counts a worker creation towards the sliding restart window of its
+// instance and escalates from warning to critical as the restarts pile up. Each
+// level is reported once per flapping episode; once the window empties the levels
+// reset so the next episode reports again.
+func (clm *CrashLoopMonitor) recordRestart(detail *proto.NginxDetails) {
+	nginxID := detail.GetNginxId()
+	now := time.Now()
+
+	clm.mu.Lock()
+	recent := []time.Time{}
+	for _, restart := range clm.restarts[nginxID] {
+		if now.Sub(restart) <= crashLoopWindow {
+			recent = append(recent, restart)
+		}
+	}
+	recent = append(recent, now)
+	clm.restarts[nginxID] = recent
+
+	count := len(recent)
+	if count < crashLoopWarnRestarts {
+		clm.reportedLevels[nginxID] = ""
+	}
+
+	var level, message string
+	exitSuffix := ""
+	if lastExit := clm.lastExit[nginxID]; lastExit != "" {
+		exitSuffix = fmt.Sprintf("; last exit: %s", lastExit)
+	}
+	switch {
+	case count >= crashLoopCriticalRestarts && clm.reportedLevels[nginxID] != CRITICAL_EVENT_LEVEL:
+		level = CRITICAL_EVENT_LEVEL
+		message = fmt.Sprintf(CRASH_LOOP_CRITICAL_MESSAGE, nginxID, count, crashLoopWindow, exitSuffix)
+	case count >= crashLoopWarnRestarts && count < crashLoopCriticalRestarts && clm.reportedLevels[nginxID] == "":
+		level = WARN_EVENT_LEVEL
+		message = fmt.Sprintf(CRASH_LOOP_WARNING_MESSAGE, nginxID, count, crashLoopWindow, exitSuffix)
+	}
+	if level != "" {
+		clm.reportedLevels[nginxID] = level
+	}
+	clm.mu.Unlock()
+
+	if level == "" {
+		return
+	}
+
+	log.Warnf("CrashLoopMonitor: %s", message)
+	clm.messagePipeline.Process(core.NewMessage(core.Events, &proto.Command{
+		Meta: clm.meta,
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_EventReport{
+			EventReport: &eventsProto.EventReport{
+				Events: []*eventsProto.Event{clm.createCrashLoopEvent(nginxID, level, message)},
+			},
+		},
+	}))
+}
+
+// lastWorkerExitLine returns the most recent worker exit message in the tail of the
+// given error log.
+func lastWorkerExitLine(logFile string) (string, bool) {
+	file, err := os.Open(logFile)
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return "", false
+	}
+	offset := stat.Size() - crashLoopErrorLogTailBytes
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return "", false
+	}
+	tail, err := io.ReadAll(file)
+	if err != nil {
+		return "", false
+	}
+
+	matches := workerExitRegex.FindAllString(string(tail), -1)
+	if len(matches) == 0 {
+		return "", false
+	}
+	return strings.TrimSpace(matches[len(matches)-1]), true
+}
+
+func (clm *CrashLoopMonitor) createCrashLoopEvent(nginxID, level, message string) *eventsProto.Event {
+	activityEvent := &eventsProto.ActivityEvent{
+		Message: message,
+		Dimensions: []*commonProto.Dimension{
+			{
+				Name:  "nginx_id",
+				Value: nginxID,
+			},
+			{
+				Name:  "system_id",
+				Value: clm.env.GetSystemUUID(),
+			},
+			{
+				Name:  "hostname",
+				Value: clm.env.GetHostname(),
+			},
+			{
+				Name:  "instance_group",
+				Value: clm.conf.InstanceGroup,
+			},
+		},
+	}
+
+	return &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			UUID:          uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Module:        MODULE,
+			Timestamp:     types.TimestampNow(),
+			EventLevel:    level,
+			Type:          NGINX_EVENT_TYPE,
+			Category:      STATUS_CATEGORY,
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: activityEvent,
+		},
+	}
+}
@@ -0,0 +1,109 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func crashLoopEventMessages(messagePipe *core.MockMessagePipe) (levels, messages []string) {
+	for _, msg := range messagePipe.GetMessages() {
+		if msg.Topic() != core.Events {
+			continue
+		}
+		cmd := msg.Data().(*proto.Command)
+		for _, event := range cmd.GetEventReport().GetEvents() {
+			levels = append(levels, event.GetMetadata().GetEventLevel())
+			messages = append(messages, event.GetData().(*eventsProto.Event_ActivityEvent).ActivityEvent.GetMessage())
+		}
+	}
+	return levels, messages
+}
+
+func TestCrashLoopMonitorEscalation(t *testing.T) {
+	errorLog := filepath.Join(t.TempDir(), "error.log")
+	logLine := `2023/01/05 13:53:33 [alert] 1111#1111: worker process 2222 exited on signal 11 (core dumped)`
+	require.NoError(t, os.WriteFile(errorLog, []byte(logLine+"\n"), 0644))
+
+	binary := tutils.NewMockNginxBinary()
+	binary.On("GetErrorLogs").Return(map[string]string{errorLog: ""})
+
+	monitor := NewCrashLoopMonitor(&config.Config{}, tutils.GetMockEnv(), binary, &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), monitor)
+	monitor.messagePipeline = messagePipe
+
+	detail := &proto.NginxDetails{NginxId: "12345"}
+
+	for i := 0; i < crashLoopCriticalRestarts; i++ {
+		monitor.Process(core.NewMessage(core.NginxWorkerProcKilled, detail))
+		monitor.Process(core.NewMessage(core.NginxWorkerProcCreated, detail))
+	}
+
+	levels, messages := crashLoopEventMessages(messagePipe)
+	// one warning when the window fills up, one critical when it keeps going
+	assert.Equal(t, []string{WARN_EVENT_LEVEL, CRITICAL_EVENT_LEVEL}, levels)
+	for _, message := range messages {
+		assert.Contains(t, message, "exited on signal 11 (core dumped)")
+	}
+
+	// staying in the loop does not re-report the critical level
+	messagePipe.ClearMessages()
+	monitor.Process(core.NewMessage(core.NginxWorkerProcCreated, detail))
+	levels, _ = crashLoopEventMessages(messagePipe)
+	assert.Empty(t, levels)
+}
+
+func TestCrashLoopMonitorNoEventsBelowThreshold(t *testing.T) {
+	binary := tutils.NewMockNginxBinary()
+	binary.On("GetErrorLogs").Return(map[string]string{})
+
+	monitor := NewCrashLoopMonitor(&config.Config{}, tutils.GetMockEnv(), binary, &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), monitor)
+	monitor.messagePipeline = messagePipe
+
+	detail := &proto.NginxDetails{NginxId: "12345"}
+	for i := 0; i < crashLoopWarnRestarts-1; i++ {
+		monitor.Process(core.NewMessage(core.NginxWorkerProcCreated, detail))
+	}
+
+	levels, _ := crashLoopEventMessages(messagePipe)
+	assert.Empty(t, levels)
+}
+
+func TestLastWorkerExitLine(t *testing.T) {
+	errorLog := filepath.Join(t.TempDir(), "error.log")
+	content := `2023/01/05 13:53:33 [alert] 1111#1111: worker process 2222 exited with code 2
+2023/01/05 13:53:40 [notice] 1111#1111: signal 17 (SIGCHLD) received from 2223
+2023/01/05 13:53:40 [alert] 1111#1111: worker process 2223 exited on signal 9
+`
+	require.NoError(t, os.WriteFile(errorLog, []byte(content), 0644))
+
+	line, found := lastWorkerExitLine(errorLog)
+	assert.True(t, found)
+	assert.Equal(t, "worker process 2223 exited on signal 9", line)
+
+	_, found = lastWorkerExitLine(filepath.Join(t.TempDir(), "missing.log"))
+	assert.False(t, found)
+}
+
+func TestCrashLoopMonitorSubscriptions(t *testing.T) {
+	monitor := NewCrashLoopMonitor(&config.Config{}, tutils.GetMockEnv(), tutils.GetMockNginxBinary(), &proto.Metadata{MessageId: "123"})
+	assert.Equal(t, []string{core.NginxWorkerProcCreated, core.NginxWorkerProcKilled, core.NginxMasterProcKilled}, monitor.Subscriptions())
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/nginx/agent/sdk/v2"
 	"github.com/nginx/agent/sdk/v2/proto"
 	"github.com/nginx/agent/v2/src/core"
 	"github.com/nginx/agent/v2/src/core/config"
@@ -42,6 +43,7 @@ type DataPlaneStatus struct {
 	nginxConfigActivityStatuses map[string]*proto.AgentActivityStatus
 	napDetailsMutex             sync.RWMutex
 	napHealth                   *proto.DataplaneSoftwareHealth_AppProtectWafHealth
+	clock                       sdk.Clock
 }
 
 const (
@@ -72,6 +74,7 @@ func NewDataPlaneStatus(config *config.Config, meta *proto.Metadata, binary core
 		// Intentionally empty as it will be set later
 		napDetails: nil,
 		napHealth:  &proto.DataplaneSoftwareHealth_AppProtectWafHealth{},
+		clock:      sdk.NewRealClock(),
 	}
 }
 
@@ -180,7 +183,7 @@ func (dps *DataPlaneStatus) healthGoRoutine(pipeline core.MessagePipeInterface)
 func (dps *DataPlaneStatus) dataplaneStatus(forceDetails bool) *proto.DataplaneStatus {
 	processes := dps.env.Processes()
 	log.Tracef("dataplaneStatus: processes %v", processes)
-	forceDetails = forceDetails || time.Now().UTC().Add(-dps.reportInterval).After(dps.lastSendDetails)
+	forceDetails = forceDetails || dps.clock.Now().UTC().Add(-dps.reportInterval).After(dps.lastSendDetails)
 
 	agentActivityStatuses := []*proto.AgentActivityStatus{}
 	for _, nginxConfigActivityStatus := range dps.nginxConfigActivityStatuses {
@@ -227,7 +230,7 @@ func (dps *DataPlaneStatus) hostInfo(send bool) (info *proto.HostInfo) {
 func (dps *DataPlaneStatus) detailsForProcess(processes []core.Process, send bool) (details []*proto.NginxDetails) {
 	log.Tracef("detailsForProcess processes: %v", processes)
 
-	nowUTC := time.Now().UTC()
+	nowUTC := dps.clock.Now().UTC()
 	statusAPIUpdated := false
 	// this sets send if we are forcing details, or it has been 24 hours since the last send
 	for _, p := range processes {
@@ -0,0 +1,157 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	commonProto "github.com/nginx/agent/sdk/v2/proto/common"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+// DebugCapture collects a diagnostic bundle (pprof profiles, goroutine dump, redacted
+// agent config, recent agent log lines and nginx -T output) when the management server
+// requests one, so support cases don't require shell access to the dataplane.
+type DebugCapture struct {
+	messagePipeline core.MessagePipeInterface
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	meta            *proto.Metadata
+	capturing       int32
+}
+
+func NewDebugCapture(conf *config.Config, env core.Environment, binary core.NginxBinary, meta *proto.Metadata) *DebugCapture {
+	return &DebugCapture{
+		conf:   conf,
+		env:    env,
+		binary: binary,
+		meta:   meta,
+	}
+}
+
+func (dc *DebugCapture) Init(pipeline core.MessagePipeInterface) {
+	log.Info("DebugCapture initializing")
+	dc.messagePipeline = pipeline
+}
+
+func (dc *DebugCapture) Close() {
+	log.Info("DebugCapture is wrapping up")
+}
+
+func (dc *DebugCapture) Info() *core.Info {
+	return core.NewInfo("DebugCapture", "v0.0.1")
+}
+
+func (dc *DebugCapture) Subscriptions() []string {
+	return []string{core.DebugCapture}
+}
+
+func (dc *DebugCapture) Process(msg *core.Message) {
+	if !msg.Exact(core.DebugCapture) {
+		return
+	}
+
+	cmd, ok := msg.Data().(*proto.Command)
+	if !ok {
+		log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+		return
+	}
+	request := cmd.GetDebugCaptureRequest()
+	if request == nil {
+		log.Warnf("Debug capture command without a request: %v", cmd)
+		return
+	}
+
+	// profiling sleeps for the CPU sample window, so collect off the message pipe and
+	// refuse overlapping captures
+	if !atomic.CompareAndSwapInt32(&dc.capturing, 0, 1) {
+		log.Warn("Debug capture already in progress, ignoring request")
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&dc.capturing, 0)
+		dc.capture(int(request.GetLogLines()))
+	}()
+}
+
+func (dc *DebugCapture) capture(logLines int) {
+	bundlePath := filepath.Join(os.TempDir(), core.DebugBundleName())
+	bundle, err := os.OpenFile(bundlePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640)
+	if err != nil {
+		log.Errorf("Unable to create debug bundle %s: %v", bundlePath, err)
+		return
+	}
+
+	err = core.WriteDebugBundle(dc.conf, dc.env, dc.binary, logLines, bundle)
+	bundle.Close()
+	if err != nil {
+		log.Errorf("Unable to collect debug bundle %s: %v", bundlePath, err)
+		os.Remove(bundlePath)
+		return
+	}
+
+	log.Infof("Debug bundle written to %s", bundlePath)
+	dc.reportBundle(bundlePath)
+}
+
+// reportBundle publishes an event telling the operator where the bundle was written
+func (dc *DebugCapture) reportBundle(bundlePath string) {
+	activityEvent := &eventsProto.ActivityEvent{
+		Message: fmt.Sprintf("debug bundle collected at %s", bundlePath),
+		Dimensions: []*commonProto.Dimension{
+			{
+				Name:  "system_id",
+				Value: dc.env.GetSystemUUID(),
+			},
+			{
+				Name:  "hostname",
+				Value: dc.env.GetHostname(),
+			},
+			{
+				Name:  "instance_group",
+				Value: dc.conf.InstanceGroup,
+			},
+		},
+	}
+
+	event := &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			UUID:          uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Module:        MODULE,
+			Timestamp:     types.TimestampNow(),
+			EventLevel:    INFO_EVENT_LEVEL,
+			Type:          NGINX_EVENT_TYPE,
+			Category:      STATUS_CATEGORY,
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: activityEvent,
+		},
+	}
+
+	dc.messagePipeline.Process(core.NewMessage(core.Events, &proto.Command{
+		Meta: dc.meta,
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_EventReport{
+			EventReport: &eventsProto.EventReport{
+				Events: []*eventsProto.Event{event},
+			},
+		},
+	}))
+}
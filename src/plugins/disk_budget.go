@@ -0,0 +1,300 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	commonProto "github.com/nginx/agent/sdk/v2/proto/common"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+// activeAgentLogName is the log file the agent is currently writing, which is
+// never evicted; only its rotated backups are
+const activeAgentLogName = "agent.log"
+
+// diskStoreFile is one evictable file in a store
+type diskStoreFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// diskStore is one directory of agent-generated data with its own quota carved
+// out of the global budget. The list function returns only the files eviction
+// may delete, so anything the agent still needs stays out of reach.
+type diskStore struct {
+	name    string
+	quotaMB int
+	list    func() ([]diskStoreFile, error)
+}
+
+// DiskBudget keeps the disk used by agent-generated data, like rotated agent
+// logs, log forwarding checkpoints and debug bundles, inside a global budget.
+// Each store has its own quota and is trimmed oldest file first when it runs
+// over; when the total nears the budget a warning event is published so the
+// operator hears about the pressure before writes start failing.
+type DiskBudget struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	conf            *config.Config
+	env             core.Environment
+	meta            *proto.Metadata
+	stores          []diskStore
+	warned          bool
+}
+
+func NewDiskBudget(conf *config.Config, env core.Environment, meta *proto.Metadata) *DiskBudget {
+	budget := conf.DiskBudget
+	return &DiskBudget{
+		conf: conf,
+		env:  env,
+		meta: meta,
+		stores: []diskStore{
+			{
+				name:    "logs",
+				quotaMB: budget.LogQuotaMB,
+				list: listDiskStore(conf.Log.Path, func(name string) bool {
+					return name != activeAgentLogName
+				}),
+			},
+			{
+				name:    "checkpoints",
+				quotaMB: budget.CheckpointQuotaMB,
+				list:    listDiskStore(conf.LogForwarding.CheckpointDir, nil),
+			},
+			{
+				name:    "debug-bundles",
+				quotaMB: budget.BundleQuotaMB,
+				list: listDiskStore(os.TempDir(), func(name string) bool {
+					return strings.HasPrefix(name, "nginx-agent-debug-") && strings.HasSuffix(name, ".tar.gz")
+				}),
+			},
+		},
+	}
+}
+
+// listDiskStore returns a lister for the regular files directly under dir,
+// keeping only the ones evictable selects. A missing directory is an empty
+// store, not an error.
+func listDiskStore(dir string, evictable func(name string) bool) func() ([]diskStoreFile, error) {
+	return func() ([]diskStoreFile, error) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		var files []diskStoreFile
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() {
+				continue
+			}
+			if evictable != nil && !evictable(entry.Name()) {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+			files = append(files, diskStoreFile{
+				path:    filepath.Join(dir, entry.Name()),
+				size:    info.Size(),
+				modTime: info.ModTime(),
+			})
+		}
+		return files, nil
+	}
+}
+
+func (db *DiskBudget) Init(pipeline core.MessagePipeInterface) {
+	log.Info("DiskBudget initializing")
+	db.messagePipeline = pipeline
+	db.ctx = pipeline.Context()
+	if db.conf.DiskBudget.Enable {
+		go db.watchLoop()
+	}
+}
+
+func (db *DiskBudget) Close() {
+	log.Info("DiskBudget is wrapping up")
+}
+
+func (db *DiskBudget) Process(msg *core.Message) {}
+
+func (db *DiskBudget) Info() *core.Info {
+	return core.NewInfo("Disk Budget", "v0.0.1")
+}
+
+func (db *DiskBudget) Subscriptions() []string {
+	return []string{}
+}
+
+func (db *DiskBudget) watchLoop() {
+	ticker := time.NewTicker(db.conf.DiskBudget.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-db.ctx.Done():
+			return
+		case <-ticker.C:
+			db.check()
+		}
+	}
+}
+
+// check trims every store back inside its quota, then trims the oldest files
+// across all stores while the total is still over the global budget, and warns
+// once when the remaining usage nears it
+func (db *DiskBudget) check() {
+	var remaining []diskStoreFile
+	for _, store := range db.stores {
+		files, err := store.list()
+		if err != nil {
+			log.Warnf("Unable to scan disk store %s: %v", store.name, err)
+			continue
+		}
+		remaining = append(remaining, evictOldest(files, megabytes(store.quotaMB), store.name)...)
+	}
+
+	remaining = evictOldest(remaining, megabytes(db.conf.DiskBudget.BudgetMB), "global")
+
+	var total int64
+	for _, file := range remaining {
+		total += file.size
+	}
+	db.warnWhenNearing(total)
+}
+
+// evictOldest deletes the oldest files until the rest fit within limit and
+// returns the files that were kept. A limit of zero disables the eviction.
+func evictOldest(files []diskStoreFile, limit int64, store string) []diskStoreFile {
+	if limit <= 0 {
+		return files
+	}
+
+	var total int64
+	for _, file := range files {
+		total += file.size
+	}
+	if total <= limit {
+		return files
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	kept := files
+	for len(kept) > 0 && total > limit {
+		file := kept[0]
+		if err := os.Remove(file.path); err != nil {
+			log.Warnf("Unable to evict %s from disk store %s: %v", file.path, store, err)
+			// the file still occupies its space, leave it in the total so the
+			// next oldest files are evicted instead
+			kept = append(kept[1:], file)
+			continue
+		}
+		log.Infof("Evicted %s from disk store %s to stay within its disk budget", file.path, store)
+		total -= file.size
+		kept = kept[1:]
+	}
+	return kept
+}
+
+// warnWhenNearing publishes a warning event the first time usage crosses the
+// warning share of the budget, and re-arms once it falls back below
+func (db *DiskBudget) warnWhenNearing(total int64) {
+	budget := megabytes(db.conf.DiskBudget.BudgetMB)
+	if budget <= 0 {
+		return
+	}
+	warningAt := budget * int64(db.conf.DiskBudget.WarningPercent) / 100
+
+	if total < warningAt {
+		db.warned = false
+		return
+	}
+	if db.warned {
+		return
+	}
+	db.warned = true
+
+	message := fmt.Sprintf(
+		"agent-generated data is using %d MB of its %d MB disk budget; oldest files are evicted once stores exceed their quotas",
+		total/(1024*1024), db.conf.DiskBudget.BudgetMB,
+	)
+	log.Warnf("Nearing the disk budget: %s", message)
+	db.publishEvent(WARN_EVENT_LEVEL, message)
+}
+
+func megabytes(mb int) int64 {
+	return int64(mb) * 1024 * 1024
+}
+
+// publishEvent publishes an activity event with the common dimensions of this system
+func (db *DiskBudget) publishEvent(level, message string) {
+	activityEvent := &eventsProto.ActivityEvent{
+		Message: message,
+		Dimensions: []*commonProto.Dimension{
+			{
+				Name:  "system_id",
+				Value: db.env.GetSystemUUID(),
+			},
+			{
+				Name:  "hostname",
+				Value: db.env.GetHostname(),
+			},
+			{
+				Name:  "instance_group",
+				Value: db.conf.InstanceGroup,
+			},
+		},
+	}
+
+	event := &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			UUID:          uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Module:        MODULE,
+			Timestamp:     types.TimestampNow(),
+			EventLevel:    level,
+			Type:          AGENT_EVENT_TYPE,
+			Category:      STATUS_CATEGORY,
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: activityEvent,
+		},
+	}
+
+	db.messagePipeline.Process(core.NewMessage(core.Events, &proto.Command{
+		Meta: db.meta,
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_EventReport{
+			EventReport: &eventsProto.EventReport{
+				Events: []*eventsProto.Event{event},
+			},
+		},
+	}))
+}
@@ -0,0 +1,167 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+// writeStoreFile fabricates one store file of the given size, aged so that
+// eviction order is under the test's control
+func writeStoreFile(t *testing.T, dir, name string, size int, age time.Duration) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0644))
+	modTime := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	return path
+}
+
+func diskBudgetEvents(t *testing.T, messagePipe *core.MockMessagePipe) []*eventsProto.ActivityEvent {
+	t.Helper()
+	var events []*eventsProto.ActivityEvent
+	for _, msg := range messagePipe.GetMessages() {
+		if msg.Topic() != core.Events {
+			continue
+		}
+		cmd := msg.Data().(*proto.Command)
+		for _, event := range cmd.GetEventReport().GetEvents() {
+			events = append(events, event.GetActivityEvent())
+		}
+	}
+	return events
+}
+
+func TestDiskBudgetEvictsOldestFirst(t *testing.T) {
+	logDir := t.TempDir()
+	checkpointDir := t.TempDir()
+
+	active := writeStoreFile(t, logDir, "agent.log", 768*1024, 0)
+	oldest := writeStoreFile(t, logDir, "agent-20260101T000000.log", 768*1024, 3*time.Hour)
+	middle := writeStoreFile(t, logDir, "agent-20260101T010000.log", 768*1024, 2*time.Hour)
+	newest := writeStoreFile(t, logDir, "agent-20260101T020000.log", 768*1024, time.Hour)
+	checkpoint := writeStoreFile(t, checkpointDir, "checkpoints.json", 1024, time.Hour)
+
+	conf := &config.Config{
+		Log: config.LogConfig{Path: logDir},
+		LogForwarding: config.LogForwarding{
+			CheckpointDir: checkpointDir,
+		},
+		DiskBudget: config.DiskBudget{
+			Enable:            true,
+			BudgetMB:          10,
+			WarningPercent:    80,
+			Interval:          time.Minute,
+			LogQuotaMB:        1,
+			CheckpointQuotaMB: 1,
+		},
+	}
+
+	pluginUnderTest := NewDiskBudget(conf, tutils.GetMockEnv(), &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.messagePipeline = messagePipe
+
+	pluginUnderTest.check()
+
+	// the two oldest rotated logs are evicted to bring the store back inside
+	// its quota; the active log is never touched
+	assert.NoFileExists(t, oldest)
+	assert.NoFileExists(t, middle)
+	assert.FileExists(t, newest)
+	assert.FileExists(t, active)
+	assert.FileExists(t, checkpoint)
+	assert.Empty(t, diskBudgetEvents(t, messagePipe))
+}
+
+func TestDiskBudgetWarnsOnceWhenNearingBudget(t *testing.T) {
+	logDir := t.TempDir()
+
+	writeStoreFile(t, logDir, "agent-20260101T000000.log", 900*1024, time.Hour)
+
+	conf := &config.Config{
+		Log: config.LogConfig{Path: logDir},
+		LogForwarding: config.LogForwarding{
+			CheckpointDir: filepath.Join(logDir, "does-not-exist"),
+		},
+		DiskBudget: config.DiskBudget{
+			Enable:         true,
+			BudgetMB:       1,
+			WarningPercent: 80,
+			Interval:       time.Minute,
+			LogQuotaMB:     1,
+		},
+	}
+
+	pluginUnderTest := NewDiskBudget(conf, tutils.GetMockEnv(), &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.messagePipeline = messagePipe
+
+	// usage stays above the warning share of the budget, but only the first
+	// check publishes the warning event
+	pluginUnderTest.check()
+	pluginUnderTest.check()
+
+	events := diskBudgetEvents(t, messagePipe)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0].GetMessage(), "disk budget")
+
+	// once usage falls back below the warning share the warning re-arms
+	require.NoError(t, os.RemoveAll(logDir))
+	pluginUnderTest.check()
+	pluginUnderTest.warnWhenNearing(megabytes(1))
+
+	events = diskBudgetEvents(t, messagePipe)
+	assert.Len(t, events, 2)
+}
+
+func TestDiskBudgetGlobalBudgetAcrossStores(t *testing.T) {
+	logDir := t.TempDir()
+	checkpointDir := t.TempDir()
+
+	oldCheckpoint := writeStoreFile(t, checkpointDir, "old.json", 512*1024, 2*time.Hour)
+	newLog := writeStoreFile(t, logDir, "agent-20260101T000000.log", 512*1024, time.Hour)
+
+	// neither store is over its own quota, but together the files exceed a
+	// global budget of three quarters of a megabyte: only the older
+	// checkpoint goes
+	remaining := evictOldest([]diskStoreFile{
+		mustStoreFile(t, oldCheckpoint),
+		mustStoreFile(t, newLog),
+	}, 768*1024, "global")
+
+	require.Len(t, remaining, 1)
+	assert.Equal(t, newLog, remaining[0].path)
+	assert.NoFileExists(t, oldCheckpoint)
+	assert.FileExists(t, newLog)
+}
+
+func mustStoreFile(t *testing.T, path string) diskStoreFile {
+	t.Helper()
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return diskStoreFile{path: path, size: info.Size(), modTime: info.ModTime()}
+}
+
+func TestDiskBudgetSubscriptions(t *testing.T) {
+	pluginUnderTest := NewDiskBudget(&config.Config{}, tutils.GetMockEnv(), &proto.Metadata{MessageId: "123"})
+	assert.Empty(t, pluginUnderTest.Subscriptions())
+	assert.Equal(t, "Disk Budget", pluginUnderTest.Info().Name())
+}
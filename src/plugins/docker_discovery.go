@@ -0,0 +1,261 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	dockerClient "github.com/docker/docker/client"
+	"github.com/gogo/protobuf/types"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	sdkGRPC "github.com/nginx/agent/sdk/v2/grpc"
+	"github.com/nginx/agent/sdk/v2/proto"
+	commonProto "github.com/nginx/agent/sdk/v2/proto/common"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+// nginxConfMountDestination is the config directory looked up in the mounts of a
+// discovered container to resolve the host path of its nginx config
+const nginxConfMountDestination = "/etc/nginx"
+
+// DockerNginxContainer describes one nginx instance running in a container on
+// the dataplane host
+type DockerNginxContainer struct {
+	ContainerID string
+	Name        string
+	Image       string
+	// ConfPath is the host path the container's /etc/nginx is mounted from, or
+	// empty when the config is not mounted from the host
+	ConfPath string
+}
+
+// dockerContainerLister is the part of the Docker API client the plugin uses,
+// declared as an interface so tests can supply a fake
+type dockerContainerLister interface {
+	ContainerList(ctx context.Context, options dockerTypes.ContainerListOptions) ([]dockerTypes.Container, error)
+}
+
+// DockerDiscovery periodically lists the containers running on the dataplane
+// host through the Docker API, identifies the ones running nginx, and publishes
+// the set whenever it changes, together with an event per appeared or
+// disappeared container carrying a container_id dimension.
+type DockerDiscovery struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	cancel          context.CancelFunc
+	conf            *config.Config
+	env             core.Environment
+	client          dockerContainerLister
+	containers      map[string]DockerNginxContainer
+}
+
+func NewDockerDiscovery(conf *config.Config, env core.Environment) (*DockerDiscovery, error) {
+	opts := []dockerClient.Opt{dockerClient.FromEnv, dockerClient.WithAPIVersionNegotiation()}
+	if conf.Docker.Socket != "" {
+		opts = append(opts, dockerClient.WithHost(conf.Docker.Socket))
+	}
+	client, err := dockerClient.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not create Docker client: %v", err)
+	}
+
+	return &DockerDiscovery{
+		conf:       conf,
+		env:        env,
+		client:     client,
+		containers: make(map[string]DockerNginxContainer),
+	}, nil
+}
+
+func (dd *DockerDiscovery) Init(pipeline core.MessagePipeInterface) {
+	log.Info("DockerDiscovery initializing")
+	dd.messagePipeline = pipeline
+	dd.ctx, dd.cancel = context.WithCancel(pipeline.Context())
+	go dd.poll()
+}
+
+func (dd *DockerDiscovery) Close() {
+	log.Info("DockerDiscovery is wrapping up")
+	if dd.cancel != nil {
+		dd.cancel()
+	}
+}
+
+func (dd *DockerDiscovery) Info() *core.Info {
+	return core.NewInfo("DockerDiscovery", "v0.0.1")
+}
+
+func (dd *DockerDiscovery) Process(msg *core.Message) {
+}
+
+func (dd *DockerDiscovery) Subscriptions() []string {
+	return []string{}
+}
+
+func (dd *DockerDiscovery) poll() {
+	interval := dd.conf.Docker.PollInterval
+	if interval <= 0 {
+		interval = config.Defaults.Docker.PollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	dd.discover()
+	for {
+		select {
+		case <-ticker.C:
+			dd.discover()
+		case <-dd.ctx.Done():
+			return
+		}
+	}
+}
+
+// discover reconciles the known nginx containers with the ones currently
+// running and publishes the new set when it changed
+func (dd *DockerDiscovery) discover() {
+	listed, err := dd.client.ContainerList(dd.ctx, dockerTypes.ContainerListOptions{})
+	if err != nil {
+		log.Warnf("DockerDiscovery: could not list containers: %v", err)
+		return
+	}
+
+	current := make(map[string]DockerNginxContainer)
+	for _, container := range listed {
+		if !isNginxContainer(container) {
+			continue
+		}
+		current[container.ID] = DockerNginxContainer{
+			ContainerID: container.ID,
+			Name:        containerName(container),
+			Image:       container.Image,
+			ConfPath:    confMountSource(container.Mounts),
+		}
+	}
+
+	changed := false
+	for id, container := range current {
+		if _, ok := dd.containers[id]; !ok {
+			changed = true
+			dd.publishContainerEvent(container, "discovered")
+		}
+	}
+	for id, container := range dd.containers {
+		if _, ok := current[id]; !ok {
+			changed = true
+			dd.publishContainerEvent(container, "no longer running")
+		}
+	}
+
+	if changed {
+		dd.containers = current
+		dd.messagePipeline.Process(core.NewMessage(core.DockerNginxContainersFound, sortedContainers(current)))
+	}
+}
+
+// isNginxContainer reports whether the container runs nginx, judged by its
+// image name or the command it was started with
+func isNginxContainer(container dockerTypes.Container) bool {
+	image := container.Image
+	if at := strings.IndexAny(image, ":@"); at > -1 {
+		image = image[:at]
+	}
+	if image == "nginx" || strings.HasSuffix(image, "/nginx") || strings.Contains(image, "nginx") {
+		return true
+	}
+	return strings.HasPrefix(container.Command, "nginx")
+}
+
+func containerName(container dockerTypes.Container) string {
+	if len(container.Names) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(container.Names[0], "/")
+}
+
+// confMountSource returns the host path the container's nginx config directory
+// is mounted from, when it is
+func confMountSource(mounts []dockerTypes.MountPoint) string {
+	for _, mount := range mounts {
+		if mount.Destination == nginxConfMountDestination || strings.HasPrefix(mount.Destination, nginxConfMountDestination+"/") {
+			return mount.Source
+		}
+	}
+	return ""
+}
+
+// sortedContainers returns the containers ordered by ID, so consumers see a
+// deterministic payload
+func sortedContainers(containers map[string]DockerNginxContainer) []DockerNginxContainer {
+	result := make([]DockerNginxContainer, 0, len(containers))
+	for _, container := range containers {
+		result = append(result, container)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ContainerID < result[j].ContainerID
+	})
+	return result
+}
+
+func (dd *DockerDiscovery) publishContainerEvent(container DockerNginxContainer, what string) {
+	activityEvent := &eventsProto.ActivityEvent{
+		Message: fmt.Sprintf("nginx container %s (image %s) %s", container.Name, container.Image, what),
+		Dimensions: []*commonProto.Dimension{
+			{
+				Name:  "system_id",
+				Value: dd.env.GetSystemUUID(),
+			},
+			{
+				Name:  "hostname",
+				Value: dd.env.GetHostname(),
+			},
+			{
+				Name:  "instance_group",
+				Value: dd.conf.InstanceGroup,
+			},
+			{
+				Name:  "container_id",
+				Value: container.ContainerID,
+			},
+		},
+	}
+
+	event := &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			UUID:          uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Module:        MODULE,
+			Timestamp:     types.TimestampNow(),
+			EventLevel:    INFO_EVENT_LEVEL,
+			Type:          NGINX_EVENT_TYPE,
+			Category:      STATUS_CATEGORY,
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: activityEvent,
+		},
+	}
+
+	dd.messagePipeline.Process(core.NewMessage(core.Events, &proto.Command{
+		Meta: sdkGRPC.NewMessageMeta(uuid.NewString()),
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_EventReport{
+			EventReport: &eventsProto.EventReport{
+				Events: []*eventsProto.Event{event},
+			},
+		},
+	}))
+}
@@ -0,0 +1,96 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"testing"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+type fakeContainerLister struct {
+	containers []dockerTypes.Container
+}
+
+func (f *fakeContainerLister) ContainerList(ctx context.Context, options dockerTypes.ContainerListOptions) ([]dockerTypes.Container, error) {
+	return f.containers, nil
+}
+
+func TestDockerDiscoveryDiscover(t *testing.T) {
+	lister := &fakeContainerLister{
+		containers: []dockerTypes.Container{
+			{
+				ID:      "abc123",
+				Names:   []string{"/web"},
+				Image:   "nginx:1.25",
+				Command: "nginx -g 'daemon off;'",
+				Mounts: []dockerTypes.MountPoint{
+					{Source: "/srv/nginx/conf", Destination: "/etc/nginx"},
+				},
+			},
+			{
+				ID:    "def456",
+				Names: []string{"/db"},
+				Image: "postgres:15",
+			},
+		},
+	}
+
+	plugin := &DockerDiscovery{
+		conf:       &config.Config{},
+		env:        tutils.GetMockEnv(),
+		client:     lister,
+		containers: make(map[string]DockerNginxContainer),
+	}
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), plugin)
+	plugin.messagePipeline = messagePipe
+	plugin.ctx = context.Background()
+
+	plugin.discover()
+
+	var found []DockerNginxContainer
+	for _, msg := range messagePipe.GetMessages() {
+		if msg.Exact(core.DockerNginxContainersFound) {
+			found = msg.Data().([]DockerNginxContainer)
+		}
+	}
+	require.Len(t, found, 1)
+	assert.Equal(t, "abc123", found[0].ContainerID)
+	assert.Equal(t, "web", found[0].Name)
+	assert.Equal(t, "nginx:1.25", found[0].Image)
+	assert.Equal(t, "/srv/nginx/conf", found[0].ConfPath)
+
+	events := collectActivityEvents(t, messagePipe.GetMessages())
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0].GetActivityEvent().GetMessage(), "discovered")
+
+	// a second pass over the unchanged container list publishes nothing new
+	plugin.discover()
+	assert.Len(t, collectActivityEvents(t, messagePipe.GetMessages()), 1)
+
+	// the container going away is reported
+	lister.containers = nil
+	plugin.discover()
+	events = collectActivityEvents(t, messagePipe.GetMessages())
+	require.Len(t, events, 2)
+	assert.Contains(t, events[1].GetActivityEvent().GetMessage(), "no longer running")
+}
+
+func TestIsNginxContainer(t *testing.T) {
+	assert.True(t, isNginxContainer(dockerTypes.Container{Image: "nginx:1.25"}))
+	assert.True(t, isNginxContainer(dockerTypes.Container{Image: "registry.example.com/team/nginx@sha256:abcd"}))
+	assert.True(t, isNginxContainer(dockerTypes.Container{Image: "alpine", Command: "nginx -g 'daemon off;'"}))
+	assert.False(t, isNginxContainer(dockerTypes.Container{Image: "postgres:15", Command: "postgres"}))
+}
@@ -0,0 +1,431 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	commonProto "github.com/nginx/agent/sdk/v2/proto/common"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/metrics/sources/tailer"
+)
+
+// errorLogLevelRegex extracts the severity token of an nginx error log line
+var errorLogLevelRegex = regexp.MustCompile(`\[(emerg|alert|crit|error|warn|notice|info|debug)\]`)
+
+// errorLogTimestampRegex matches the timestamp every nginx error log line starts with;
+// lines without it (stack traces, NAP compiler output) continue the previous diagnostic
+var errorLogTimestampRegex = regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}`)
+
+// njsExceptionRegex matches the message njs logs when a script throws, e.g.
+// "js exception: TypeError: cannot read property of undefined"
+var njsExceptionRegex = regexp.MustCompile(`js exception: (.*)`)
+
+// njsStackFrameRegex matches one frame of an njs stack trace, e.g.
+// "    at handler (/etc/nginx/njs/auth.js:42)", capturing the script and line
+var njsStackFrameRegex = regexp.MustCompile(`\bat \S+ \(([^():]+):(\d+)\)`)
+
+// errorLogSeverityRank orders the nginx error log severities from least to most severe
+var errorLogSeverityRank = map[string]int{
+	"debug":  0,
+	"info":   1,
+	"notice": 2,
+	"warn":   3,
+	"error":  4,
+	"crit":   5,
+	"alert":  6,
+	"emerg":  7,
+}
+
+// ErrorLogTailer follows the error logs of the discovered nginx instances, groups
+// continuation lines of multi-line diagnostics with the line that started them,
+// matches each diagnostic against the configured minimum severity and extra patterns,
+// and publishes a structured event with a bounded excerpt for every match. Rotated
+// logs are reopened automatically by the underlying tailer.
+type ErrorLogTailer struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	meta            *proto.Metadata
+	minRank         int
+	patterns        []*regexp.Regexp
+	multiline       []*regexp.Regexp
+	tailsMutex      sync.Mutex
+	tails           map[string]context.CancelFunc
+	suspended       bool
+}
+
+func NewErrorLogTailer(conf *config.Config, env core.Environment, binary core.NginxBinary, meta *proto.Metadata) *ErrorLogTailer {
+	minRank, ok := errorLogSeverityRank[conf.ErrorLogEvents.MinLevel]
+	if !ok {
+		log.Warnf("unknown error log event level %q, falling back to %q", conf.ErrorLogEvents.MinLevel, config.Defaults.ErrorLogEvents.MinLevel)
+		minRank = errorLogSeverityRank[config.Defaults.ErrorLogEvents.MinLevel]
+	}
+
+	patterns := []*regexp.Regexp{}
+	for _, pattern := range conf.ErrorLogEvents.Patterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Warnf("could not compile error log event pattern %q: %v", pattern, err)
+			continue
+		}
+		patterns = append(patterns, compiled)
+	}
+
+	multiline := []*regexp.Regexp{}
+	for _, pattern := range conf.ErrorLogEvents.MultilinePatterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Warnf("could not compile error log continuation pattern %q: %v", pattern, err)
+			continue
+		}
+		multiline = append(multiline, compiled)
+	}
+
+	return &ErrorLogTailer{
+		conf:      conf,
+		env:       env,
+		binary:    binary,
+		meta:      meta,
+		minRank:   minRank,
+		patterns:  patterns,
+		multiline: multiline,
+		tails:     make(map[string]context.CancelFunc),
+	}
+}
+
+func (elt *ErrorLogTailer) Init(pipeline core.MessagePipeInterface) {
+	log.Info("ErrorLogTailer initializing")
+	elt.messagePipeline = pipeline
+	elt.ctx = pipeline.Context()
+}
+
+func (elt *ErrorLogTailer) Close() {
+	log.Info("ErrorLogTailer is wrapping up")
+	elt.tailsMutex.Lock()
+	defer elt.tailsMutex.Unlock()
+	for logFile, cancel := range elt.tails {
+		cancel()
+		delete(elt.tails, logFile)
+	}
+}
+
+func (elt *ErrorLogTailer) Info() *core.Info {
+	return core.NewInfo("ErrorLogTailer", "v0.0.1")
+}
+
+func (elt *ErrorLogTailer) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.NginxInstancesFound), msg.Exact(core.NginxConfigApplySucceeded):
+		elt.refreshTails()
+	case msg.Exact(core.CollectionDegraded):
+		elt.setSuspended(msg.Data().(bool))
+	}
+}
+
+func (elt *ErrorLogTailer) Subscriptions() []string {
+	return []string{
+		core.NginxInstancesFound,
+		core.NginxConfigApplySucceeded,
+		core.CollectionDegraded,
+	}
+}
+
+// setSuspended stops log parsing while the host is under CPU pressure and
+// resumes it, from the current end of the logs, once the pressure subsides
+func (elt *ErrorLogTailer) setSuspended(suspended bool) {
+	elt.tailsMutex.Lock()
+	elt.suspended = suspended
+	if suspended {
+		log.Info("ErrorLogTailer suspending while collection is degraded")
+		for logFile, cancel := range elt.tails {
+			cancel()
+			delete(elt.tails, logFile)
+		}
+	}
+	elt.tailsMutex.Unlock()
+
+	if !suspended {
+		log.Info("ErrorLogTailer resuming")
+		elt.refreshTails()
+	}
+}
+
+// refreshTails reconciles the running tailers with the error logs currently configured
+// on the dataplane: new logs are tailed from their current end, logs that disappeared
+// from the config are released.
+func (elt *ErrorLogTailer) refreshTails() {
+	errorLogs := elt.binary.GetErrorLogs()
+
+	elt.tailsMutex.Lock()
+	defer elt.tailsMutex.Unlock()
+
+	if elt.suspended {
+		return
+	}
+
+	for logFile := range errorLogs {
+		if _, ok := elt.tails[logFile]; ok {
+			continue
+		}
+		tailCtx, cancel := context.WithCancel(elt.ctx)
+		elt.tails[logFile] = cancel
+		go elt.tailLog(tailCtx, logFile)
+	}
+
+	for logFile, cancel := range elt.tails {
+		if _, ok := errorLogs[logFile]; !ok {
+			cancel()
+			delete(elt.tails, logFile)
+		}
+	}
+}
+
+func (elt *ErrorLogTailer) tailLog(ctx context.Context, logFile string) {
+	t, err := tailer.NewTailer(logFile)
+	if err != nil {
+		log.Warnf("ErrorLogTailer: could not tail error log %s: %v", logFile, err)
+		return
+	}
+
+	data := make(chan string, 1024)
+	go t.Tail(ctx, data)
+
+	timeout := elt.conf.ErrorLogEvents.MultilineTimeout
+	if timeout <= 0 {
+		timeout = config.Defaults.ErrorLogEvents.MultilineTimeout
+	}
+	flushTimer := time.NewTimer(timeout)
+	defer flushTimer.Stop()
+
+	// lines of the diagnostic currently being grouped; it is reported once a new
+	// diagnostic starts or no continuation line arrives within the timeout
+	pending := []string{}
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		group := strings.Join(pending, "\n")
+		pending = pending[:0]
+		// njs exceptions are always escalated, regardless of the configured
+		// minimum severity, so script failures are seen centrally
+		if exception := parseNjsException(group); exception != nil {
+			elt.publishNjsEvent(logFile, exception, group)
+			return
+		}
+		if level, match := elt.evaluateLine(group); match {
+			elt.publishLogEvent(logFile, level, group)
+		}
+	}
+
+	for {
+		select {
+		case line := <-data:
+			if len(pending) > 0 && !elt.isContinuationLine(line) {
+				flush()
+			}
+			pending = append(pending, line)
+			if !flushTimer.Stop() {
+				select {
+				case <-flushTimer.C:
+				default:
+				}
+			}
+			flushTimer.Reset(timeout)
+		case <-flushTimer.C:
+			flush()
+			flushTimer.Reset(timeout)
+		case <-ctx.Done():
+			flush()
+			return
+		}
+	}
+}
+
+// isContinuationLine reports whether the line continues the previous diagnostic
+// rather than starting a new one. Lines without the leading nginx timestamp and
+// lines matching one of the configured continuation patterns are grouped.
+func (elt *ErrorLogTailer) isContinuationLine(line string) bool {
+	if !errorLogTimestampRegex.MatchString(line) {
+		return true
+	}
+	for _, pattern := range elt.multiline {
+		if pattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateLine reports whether the log line should be escalated and at which event
+// level. Lines at or above the configured minimum severity always match, lines
+// matching one of the extra patterns are escalated as warnings regardless of severity.
+func (elt *ErrorLogTailer) evaluateLine(line string) (string, bool) {
+	if match := errorLogLevelRegex.FindStringSubmatch(line); match != nil {
+		if rank := errorLogSeverityRank[match[1]]; rank >= elt.minRank {
+			return errorLogEventLevel(match[1]), true
+		}
+	}
+
+	for _, pattern := range elt.patterns {
+		if pattern.MatchString(line) {
+			return WARN_EVENT_LEVEL, true
+		}
+	}
+
+	return "", false
+}
+
+// errorLogEventLevel maps an nginx error log severity to an event level
+func errorLogEventLevel(severity string) string {
+	switch severity {
+	case "emerg", "alert", "crit":
+		return CRITICAL_EVENT_LEVEL
+	case "error":
+		return ERROR_EVENT_LEVEL
+	case "warn":
+		return WARN_EVENT_LEVEL
+	}
+	return INFO_EVENT_LEVEL
+}
+
+// njsException is a script failure extracted from a grouped error log diagnostic
+type njsException struct {
+	message string
+	script  string
+	line    string
+}
+
+// parseNjsException extracts the njs exception from a grouped diagnostic, with
+// the script name and line of the topmost stack frame when the trace carries
+// one, or nil when the diagnostic is not an njs exception
+func parseNjsException(group string) *njsException {
+	match := njsExceptionRegex.FindStringSubmatch(group)
+	if match == nil {
+		return nil
+	}
+
+	message := match[1]
+	if newline := strings.Index(message, "\n"); newline != -1 {
+		message = message[:newline]
+	}
+
+	exception := &njsException{message: message}
+	if frame := njsStackFrameRegex.FindStringSubmatch(group); frame != nil {
+		exception.script = frame[1]
+		exception.line = frame[2]
+	}
+	return exception
+}
+
+func (elt *ErrorLogTailer) publishNjsEvent(logFile string, exception *njsException, group string) {
+	location := "njs exception"
+	if exception.script != "" {
+		location = fmt.Sprintf("njs exception in %s:%s", exception.script, exception.line)
+	}
+
+	level := ERROR_EVENT_LEVEL
+	if match := errorLogLevelRegex.FindStringSubmatch(group); match != nil {
+		if rank := errorLogSeverityRank[match[1]]; rank > errorLogSeverityRank["error"] {
+			level = errorLogEventLevel(match[1])
+		}
+	}
+
+	elt.publishEvent(level, fmt.Sprintf("%s (error log %s): %s", location, logFile, elt.excerpt(group)), []*commonProto.Dimension{
+		{
+			Name:  "log_path",
+			Value: logFile,
+		},
+		{
+			Name:  "njs_script",
+			Value: exception.script,
+		},
+		{
+			Name:  "njs_line",
+			Value: exception.line,
+		},
+	})
+}
+
+// excerpt bounds a grouped diagnostic to the configured excerpt length
+func (elt *ErrorLogTailer) excerpt(line string) string {
+	if maxLen := elt.conf.ErrorLogEvents.ExcerptLength; maxLen > 0 && len(line) > maxLen {
+		return line[:maxLen] + "..."
+	}
+	return line
+}
+
+func (elt *ErrorLogTailer) publishLogEvent(logFile, level, line string) {
+	elt.publishEvent(level, fmt.Sprintf("error log %s: %s", logFile, elt.excerpt(line)), []*commonProto.Dimension{
+		{
+			Name:  "log_path",
+			Value: logFile,
+		},
+	})
+}
+
+// publishEvent publishes an activity event with the common dimensions of this
+// system followed by the given ones
+func (elt *ErrorLogTailer) publishEvent(level, message string, dimensions []*commonProto.Dimension) {
+	activityEvent := &eventsProto.ActivityEvent{
+		Message: message,
+		Dimensions: append([]*commonProto.Dimension{
+			{
+				Name:  "system_id",
+				Value: elt.env.GetSystemUUID(),
+			},
+			{
+				Name:  "hostname",
+				Value: elt.env.GetHostname(),
+			},
+			{
+				Name:  "instance_group",
+				Value: elt.conf.InstanceGroup,
+			},
+		}, dimensions...),
+	}
+
+	event := &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			UUID:          uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Module:        MODULE,
+			Timestamp:     types.TimestampNow(),
+			EventLevel:    level,
+			Type:          NGINX_EVENT_TYPE,
+			Category:      STATUS_CATEGORY,
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: activityEvent,
+		},
+	}
+
+	elt.messagePipeline.Process(core.NewMessage(core.Events, &proto.Command{
+		Meta: elt.meta,
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_EventReport{
+			EventReport: &eventsProto.EventReport{
+				Events: []*eventsProto.Event{event},
+			},
+		},
+	}))
+}
@@ -0,0 +1,224 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func TestErrorLogTailerEvaluateLine(t *testing.T) {
+	conf := &config.Config{
+		ErrorLogEvents: config.ErrorLogEvents{
+			Enable:   true,
+			MinLevel: "crit",
+			Patterns: []string{`exited on signal \d+`},
+		},
+	}
+	plugin := NewErrorLogTailer(conf, tutils.GetMockEnv(), tutils.NewMockNginxBinary(), &proto.Metadata{MessageId: "123"})
+
+	testCases := []struct {
+		line          string
+		expectedLevel string
+		expectedMatch bool
+	}{
+		{`2023/01/01 12:00:00 [emerg] 1#1: bind() to 0.0.0.0:80 failed`, CRITICAL_EVENT_LEVEL, true},
+		{`2023/01/01 12:00:00 [crit] 1#1: SSL_do_handshake() failed`, CRITICAL_EVENT_LEVEL, true},
+		{`2023/01/01 12:00:00 [error] 1#1: open() failed`, "", false},
+		{`2023/01/01 12:00:00 [notice] 1#1: worker process 12 exited on signal 11`, WARN_EVENT_LEVEL, true},
+		{`2023/01/01 12:00:00 [info] 1#1: client closed connection`, "", false},
+	}
+
+	for _, tc := range testCases {
+		level, match := plugin.evaluateLine(tc.line)
+		assert.Equal(t, tc.expectedMatch, match, tc.line)
+		assert.Equal(t, tc.expectedLevel, level, tc.line)
+	}
+}
+
+func TestErrorLogTailerIsContinuationLine(t *testing.T) {
+	conf := &config.Config{
+		ErrorLogEvents: config.ErrorLogEvents{
+			Enable:            true,
+			MultilinePatterns: []string{`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2} \[\w+\] .*compiler`},
+		},
+	}
+	plugin := NewErrorLogTailer(conf, tutils.GetMockEnv(), tutils.NewMockNginxBinary(), &proto.Metadata{MessageId: "123"})
+
+	assert.False(t, plugin.isContinuationLine(`2023/01/01 12:00:00 [crit] 1#1: SSL_do_handshake() failed`))
+	assert.True(t, plugin.isContinuationLine(`    at handler (/etc/nginx/njs/auth.js:42)`))
+	assert.True(t, plugin.isContinuationLine(`2023/01/01 12:00:01 [notice] 1#1: app-protect compiler output line`))
+}
+
+func TestErrorLogTailerGroupsMultilineEvents(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "error.log")
+	require.NoError(t, os.WriteFile(logFile, []byte{}, 0644))
+
+	conf := &config.Config{
+		ErrorLogEvents: config.ErrorLogEvents{
+			Enable:           true,
+			MinLevel:         "crit",
+			MultilineTimeout: 200 * time.Millisecond,
+		},
+	}
+
+	binary := tutils.NewMockNginxBinary()
+	binary.On("GetErrorLogs").Return(map[string]string{logFile: ""})
+
+	plugin := NewErrorLogTailer(conf, tutils.GetMockEnv(), binary, &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), plugin)
+	plugin.Init(messagePipe)
+	defer plugin.Close()
+
+	plugin.Process(core.NewMessage(core.NginxInstancesFound, nil))
+
+	// give the tailer a moment to seek to the end of the log before appending
+	time.Sleep(500 * time.Millisecond)
+
+	f, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString("2023/01/01 12:00:00 [crit] 1#1: js exception: Error: boom\n" +
+		"    at handler (/etc/nginx/njs/auth.js:42)\n" +
+		"    at main (/etc/nginx/njs/auth.js:7)\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Eventually(t, func() bool {
+		return len(collectActivityEvents(t, messagePipe.GetMessages())) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	events := collectActivityEvents(t, messagePipe.GetMessages())
+	require.Len(t, events, 1)
+	message := events[0].GetActivityEvent().GetMessage()
+	assert.Contains(t, message, "js exception: Error: boom")
+	assert.Contains(t, message, "auth.js:42")
+	assert.Contains(t, message, "auth.js:7")
+}
+
+func TestErrorLogTailerPublishesEvents(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "error.log")
+	require.NoError(t, os.WriteFile(logFile, []byte{}, 0644))
+
+	conf := &config.Config{
+		ErrorLogEvents: config.ErrorLogEvents{
+			Enable:        true,
+			MinLevel:      "crit",
+			ExcerptLength: 40,
+		},
+	}
+
+	binary := tutils.NewMockNginxBinary()
+	binary.On("GetErrorLogs").Return(map[string]string{logFile: ""})
+
+	plugin := NewErrorLogTailer(conf, tutils.GetMockEnv(), binary, &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), plugin)
+	plugin.Init(messagePipe)
+	defer plugin.Close()
+
+	plugin.Process(core.NewMessage(core.NginxInstancesFound, nil))
+
+	// give the tailer a moment to seek to the end of the log before appending
+	time.Sleep(500 * time.Millisecond)
+
+	f, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString("2023/01/01 12:00:00 [crit] 1#1: SSL_do_handshake() failed while reading client request\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Eventually(t, func() bool {
+		return len(collectActivityEvents(t, messagePipe.GetMessages())) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	events := collectActivityEvents(t, messagePipe.GetMessages())
+	require.Len(t, events, 1)
+	assert.Equal(t, CRITICAL_EVENT_LEVEL, events[0].GetMetadata().GetEventLevel())
+	message := events[0].GetActivityEvent().GetMessage()
+	assert.Contains(t, message, logFile)
+	assert.Contains(t, message, "...")
+}
+
+func TestParseNjsException(t *testing.T) {
+	exception := parseNjsException("2023/01/01 12:00:00 [error] 1#1: *5 js exception: TypeError: cannot read property \"id\" of undefined\n" +
+		"    at handler (/etc/nginx/njs/auth.js:42)\n" +
+		"    at main (/etc/nginx/njs/auth.js:7)")
+	require.NotNil(t, exception)
+	assert.Equal(t, `TypeError: cannot read property "id" of undefined`, exception.message)
+	assert.Equal(t, "/etc/nginx/njs/auth.js", exception.script)
+	assert.Equal(t, "42", exception.line)
+
+	// an exception without a stack trace still surfaces, without a location
+	exception = parseNjsException(`2023/01/01 12:00:00 [error] 1#1: js exception: Error: boom`)
+	require.NotNil(t, exception)
+	assert.Equal(t, "Error: boom", exception.message)
+	assert.Empty(t, exception.script)
+
+	assert.Nil(t, parseNjsException(`2023/01/01 12:00:00 [error] 1#1: open() failed`))
+}
+
+func TestErrorLogTailerPublishesNjsEvents(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "error.log")
+	require.NoError(t, os.WriteFile(logFile, []byte{}, 0644))
+
+	// MinLevel emerg would suppress a plain [error] line; njs exceptions are
+	// escalated regardless
+	conf := &config.Config{
+		ErrorLogEvents: config.ErrorLogEvents{
+			Enable:   true,
+			MinLevel: "emerg",
+		},
+	}
+
+	binary := tutils.NewMockNginxBinary()
+	binary.On("GetErrorLogs").Return(map[string]string{logFile: ""})
+
+	plugin := NewErrorLogTailer(conf, tutils.GetMockEnv(), binary, &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), plugin)
+	plugin.Init(messagePipe)
+	defer plugin.Close()
+
+	plugin.Process(core.NewMessage(core.NginxInstancesFound, nil))
+
+	// give the tailer a moment to seek to the end of the log before appending
+	time.Sleep(500 * time.Millisecond)
+
+	f, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString("2023/01/01 12:00:00 [error] 1#1: *5 js exception: TypeError: cannot read property \"id\" of undefined\n" +
+		"    at handler (/etc/nginx/njs/auth.js:42)\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Eventually(t, func() bool {
+		return len(collectActivityEvents(t, messagePipe.GetMessages())) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	events := collectActivityEvents(t, messagePipe.GetMessages())
+	require.Len(t, events, 1)
+	assert.Equal(t, ERROR_EVENT_LEVEL, events[0].GetMetadata().GetEventLevel())
+	assert.Contains(t, events[0].GetActivityEvent().GetMessage(), "njs exception in /etc/nginx/njs/auth.js:42")
+
+	dimensions := map[string]string{}
+	for _, dimension := range events[0].GetActivityEvent().GetDimensions() {
+		dimensions[dimension.Name] = dimension.Value
+	}
+	assert.Equal(t, "/etc/nginx/njs/auth.js", dimensions["njs_script"])
+	assert.Equal(t, "42", dimensions["njs_line"])
+	assert.Equal(t, logFile, dimensions["log_path"])
+}
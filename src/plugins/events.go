@@ -10,6 +10,7 @@ package plugins
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/gogo/protobuf/types"
@@ -48,6 +49,8 @@ const (
 	STATUS_CATEGORY      = "Status"
 	CONFIG_CATEGORY      = "Config"
 	APP_PROTECT_CATEGORY = "AppProtect"
+	CERTS_CATEGORY       = "Certs"
+	RESOLVER_CATEGORY    = "Resolver"
 
 	// Event Levels
 	INFO_EVENT_LEVEL     = "INFO"
@@ -504,6 +507,7 @@ func (e *Events) createActivityEvent(message string, nginxId string) *eventsProt
 			},
 		},
 	}
+	activityEvent.Dimensions = append(activityEvent.Dimensions, labelDimensions(e.conf.Labels)...)
 
 	if nginxId != "" {
 		nginxDim := []*commonProto.Dimension{{Name: "nginx_id", Value: nginxId}}
@@ -525,6 +529,25 @@ func NewAgentEventMeta(version string, pid string) *AgentEventMeta {
 	}
 }
 
+// labelDimensions converts the static labels of the config into event
+// dimensions, sorted by key for a stable order between events.
+func labelDimensions(labels map[string]string) []*commonProto.Dimension {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	dimensions := make([]*commonProto.Dimension, 0, len(keys))
+	for _, key := range keys {
+		dimensions = append(dimensions, &commonProto.Dimension{
+			Name:  "label." + key,
+			Value: labels[key],
+		})
+	}
+	return dimensions
+}
+
 func GenerateAgentStopEventCommand(agentEvent *AgentEventMeta, conf *config.Config, env core.Environment) *proto.Command {
 	activityEvent := &eventsProto.ActivityEvent{
 		Message: fmt.Sprintf(AGENT_STOP_MESSAGE, agentEvent.version, agentEvent.pid, env.GetHostname()),
@@ -547,6 +570,7 @@ func GenerateAgentStopEventCommand(agentEvent *AgentEventMeta, conf *config.Conf
 			},
 		},
 	}
+	activityEvent.Dimensions = append(activityEvent.Dimensions, labelDimensions(conf.Labels)...)
 
 	event := &eventsProto.Event{
 		Metadata: &eventsProto.Metadata{
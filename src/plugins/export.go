@@ -0,0 +1,129 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	protobuf "github.com/gogo/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/logger"
+)
+
+// exportRecord is one NDJSON line of the export file
+type exportRecord struct {
+	ExportedAt string `json:"exported_at"`
+	// Topic the payload was published on
+	Topic string `json:"topic"`
+	// Type is the protobuf message name of the payload
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Export writes the payloads the agent would send to the control plane
+// (registration, status, metrics, events, config uploads) to a rotating
+// local NDJSON file, one JSON-encoded protobuf message per line. Air-gapped
+// installs run with no server configured and batch-transfer the export files
+// out-of-band; installs with a control plane get a local copy of everything
+// that went out.
+type Export struct {
+	conf      *config.Config
+	writer    io.WriteCloser
+	marshaler jsonpb.Marshaler
+}
+
+func NewExport(conf *config.Config) (*Export, error) {
+	var writer io.WriteCloser
+	var err error
+	if conf.Export.MaxSize > 0 {
+		writer, err = logger.NewRotatingWriter(conf.Export.File, conf.Export.MaxSize, conf.Export.MaxBackups, 0)
+	} else {
+		writer, err = os.OpenFile(conf.Export.File, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Export{
+		conf:      conf,
+		writer:    writer,
+		marshaler: jsonpb.Marshaler{OrigName: true},
+	}, nil
+}
+
+func (e *Export) Init(pipeline core.MessagePipeInterface) {
+	log.Infof("Export initializing, writing outbound payloads to %s", e.conf.Export.File)
+}
+
+func (e *Export) Info() *core.Info {
+	return core.NewInfo("Export", "v0.0.1")
+}
+
+func (e *Export) Close() {
+	log.Info("Export is wrapping up")
+	if err := e.writer.Close(); err != nil {
+		log.Warnf("Error closing the export file: %v", err)
+	}
+}
+
+func (e *Export) Process(message *core.Message) {
+	switch data := message.Data().(type) {
+	case []core.Payload:
+		for _, payload := range data {
+			e.export(message.Topic(), payload)
+		}
+	default:
+		e.export(message.Topic(), data)
+	}
+}
+
+func (e *Export) Subscriptions() []string {
+	return []string{
+		core.CommRegister,
+		core.CommStatus,
+		core.CommMetrics,
+		core.NginxConfigUpload,
+	}
+}
+
+func (e *Export) export(topic string, payload interface{}) {
+	message, ok := payload.(protobuf.Message)
+	if !ok {
+		log.Debugf("Export skipping non-protobuf payload of type %T on topic %s", payload, topic)
+		return
+	}
+
+	var encoded bytes.Buffer
+	if err := e.marshaler.Marshal(&encoded, message); err != nil {
+		log.Warnf("Export failed to encode a %T payload: %v", payload, err)
+		return
+	}
+
+	line, err := json.Marshal(exportRecord{
+		ExportedAt: time.Now().UTC().Format(time.RFC3339Nano),
+		Topic:      topic,
+		Type:       protobuf.MessageName(message),
+		Payload:    encoded.Bytes(),
+	})
+	if err != nil {
+		log.Warnf("Export failed to encode a record: %v", err)
+		return
+	}
+
+	if _, err := e.writer.Write(append(line, '\n')); err != nil {
+		log.Errorf("Export failed to write to %s: %v", e.conf.Export.File, err)
+	}
+}
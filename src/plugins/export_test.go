@@ -0,0 +1,81 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+func TestExport_Subscriptions(t *testing.T) {
+	conf := &config.Config{Export: config.Export{File: filepath.Join(t.TempDir(), "export.ndjson")}}
+	pluginUnderTest, err := NewExport(conf)
+	require.NoError(t, err)
+	defer pluginUnderTest.Close()
+
+	assert.Equal(t, []string{core.CommRegister, core.CommStatus, core.CommMetrics, core.NginxConfigUpload}, pluginUnderTest.Subscriptions())
+}
+
+func TestExport_WritesRecords(t *testing.T) {
+	exportFile := filepath.Join(t.TempDir(), "export.ndjson")
+	conf := &config.Config{Export: config.Export{Enable: true, File: exportFile, MaxSize: 1, MaxBackups: 1}}
+
+	pluginUnderTest, err := NewExport(conf)
+	require.NoError(t, err)
+
+	pluginUnderTest.Process(core.NewMessage(core.CommRegister, &proto.AgentConnectRequest{
+		Meta: &proto.AgentMeta{SystemUid: "system-1"},
+	}))
+	pluginUnderTest.Process(core.NewMessage(core.CommMetrics, []core.Payload{&proto.MetricsReport{
+		Meta: &proto.Metadata{MessageId: "report-1", Timestamp: types.TimestampNow()},
+		Type: proto.MetricsReport_INSTANCE,
+		Data: []*proto.StatsEntity{{Simplemetrics: []*proto.SimpleMetric{{Name: "Metric A", Value: 5}}}},
+	}}))
+	// non-protobuf payloads are skipped rather than breaking the file
+	pluginUnderTest.Process(core.NewMessage(core.CommMetrics, "not a payload"))
+	pluginUnderTest.Close()
+
+	file, err := os.Open(exportFile)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var records []exportRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record exportRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, records, 2)
+
+	assert.Equal(t, core.CommRegister, records[0].Topic)
+	assert.Equal(t, "f5.nginx.agent.sdk.AgentConnectRequest", records[0].Type)
+	assert.NotEmpty(t, records[0].ExportedAt)
+
+	assert.Equal(t, core.CommMetrics, records[1].Topic)
+	assert.Equal(t, "f5.nginx.agent.sdk.MetricsReport", records[1].Type)
+
+	report := &proto.MetricsReport{}
+	require.NoError(t, jsonpb.Unmarshal(bytes.NewReader(records[1].Payload), report))
+	assert.Equal(t, "report-1", report.GetMeta().GetMessageId())
+	assert.Len(t, report.GetData(), 1)
+}
@@ -0,0 +1,153 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/client"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const probeTimeout = 5 * time.Second
+
+// Failover keeps the agent attached to a reachable control plane when more
+// than one is configured, which is needed for disaster recovery setups. The
+// configured server is the highest-priority target and the failover servers
+// follow in the order they are listed. The plugin probes the active target
+// and, after a run of failed probes, points the grpc clients at the
+// highest-priority reachable one; the clients reconnect to the new target
+// through their own retry loops. While attached to a lower-priority target
+// the higher-priority ones keep being probed, so the agent fails back as
+// soon as one of them recovers.
+type Failover struct {
+	conf     *config.Config
+	pipeline core.MessagePipeInterface
+	clients  []client.Client
+	targets  []string
+	probe    func(target string) error
+	ticker   *time.Ticker
+	mu       sync.Mutex
+	current  int
+	failures int
+	wg       sync.WaitGroup
+}
+
+func NewFailover(conf *config.Config, clients ...client.Client) *Failover {
+	targets := append([]string{conf.Server.Target}, conf.Failover.Servers...)
+	return &Failover{
+		conf:    conf,
+		clients: clients,
+		targets: targets,
+		ticker:  time.NewTicker(conf.Failover.ProbeInterval),
+		probe: func(target string) error {
+			conn, err := net.DialTimeout("tcp", target, probeTimeout)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		},
+	}
+}
+
+func (f *Failover) Init(pipeline core.MessagePipeInterface) {
+	log.Info("Failover initializing")
+	f.pipeline = pipeline
+
+	f.wg.Add(1)
+	go f.probeLoop(pipeline.Context())
+}
+
+func (f *Failover) Info() *core.Info {
+	return core.NewInfo("Failover", "v0.0.1")
+}
+
+func (f *Failover) Close() {
+	log.Info("Failover is wrapping up")
+	f.ticker.Stop()
+	f.wg.Wait()
+}
+
+func (f *Failover) Process(message *core.Message) {}
+
+func (f *Failover) Subscriptions() []string {
+	return []string{}
+}
+
+func (f *Failover) probeLoop(ctx context.Context) {
+	defer f.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.ticker.C:
+			f.checkTargets()
+		}
+	}
+}
+
+func (f *Failover) checkTargets() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// fail back to a higher-priority control plane as soon as one recovers
+	for idx := 0; idx < f.current; idx++ {
+		if f.probe(f.targets[idx]) == nil {
+			log.Infof("Control plane %s is reachable again, failing back from %s", f.targets[idx], f.targets[f.current])
+			f.switchTo(idx)
+			return
+		}
+	}
+
+	if err := f.probe(f.targets[f.current]); err != nil {
+		f.failures++
+		log.Warnf("Control plane %s is unreachable (%d of %d): %v", f.targets[f.current], f.failures, f.conf.Failover.FailureThreshold, err)
+	} else {
+		f.failures = 0
+		return
+	}
+
+	if f.failures < f.conf.Failover.FailureThreshold {
+		return
+	}
+
+	for idx, target := range f.targets {
+		if idx == f.current {
+			continue
+		}
+		if f.probe(target) == nil {
+			log.Warnf("Failing over from control plane %s to %s", f.targets[f.current], target)
+			f.switchTo(idx)
+			return
+		}
+	}
+
+	log.Error("No reachable control plane found, staying attached to the current one")
+	f.failures = 0
+}
+
+// switchTo points the grpc clients at the given target. The clients pick the
+// new server up on their next reconnect, which the dead connection forces.
+// The caller has to hold f.mu.
+func (f *Failover) switchTo(idx int) {
+	f.current = idx
+	f.failures = 0
+
+	target := f.targets[idx]
+	for _, grpcClient := range f.clients {
+		grpcClient.WithServer(target)
+	}
+	f.pipeline.Process(core.NewMessage(core.ControlPlaneChanged, target))
+}
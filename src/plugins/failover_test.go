@@ -0,0 +1,129 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func failoverTestConfig() *config.Config {
+	return &config.Config{
+		Server: config.Server{
+			Target: "primary:443",
+		},
+		Failover: config.Failover{
+			Enable:           true,
+			Servers:          []string{"dr1:443", "dr2:443"},
+			ProbeInterval:    time.Hour,
+			FailureThreshold: 2,
+		},
+	}
+}
+
+func TestFailover_FailoverAndFailback(t *testing.T) {
+	mockClient := tutils.NewMockCommandClient()
+	mockClient.On("WithServer", mock.Anything)
+
+	pluginUnderTest := NewFailover(failoverTestConfig(), mockClient)
+	messagePipe := core.NewMockMessagePipe(context.Background())
+	pluginUnderTest.pipeline = messagePipe
+
+	down := map[string]bool{}
+	pluginUnderTest.probe = func(target string) error {
+		if down[target] {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	// everything reachable, nothing happens
+	pluginUnderTest.checkTargets()
+	assert.Equal(t, 0, pluginUnderTest.current)
+	assert.Len(t, messagePipe.GetMessages(), 0)
+
+	// one failed probe is below the threshold
+	down["primary:443"] = true
+	pluginUnderTest.checkTargets()
+	assert.Equal(t, 0, pluginUnderTest.current)
+	assert.Equal(t, 1, pluginUnderTest.failures)
+	assert.Len(t, messagePipe.GetMessages(), 0)
+
+	// the second failed probe reaches the threshold and fails over
+	pluginUnderTest.checkTargets()
+	assert.Equal(t, 1, pluginUnderTest.current)
+	messages := messagePipe.GetMessages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, core.ControlPlaneChanged, messages[0].Topic())
+	assert.Equal(t, "dr1:443", messages[0].Data())
+	mockClient.AssertCalled(t, "WithServer", "dr1:443")
+
+	// the primary recovering triggers an immediate failback
+	messagePipe.ClearMessages()
+	down["primary:443"] = false
+	pluginUnderTest.checkTargets()
+	assert.Equal(t, 0, pluginUnderTest.current)
+	messages = messagePipe.GetMessages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "primary:443", messages[0].Data())
+	mockClient.AssertCalled(t, "WithServer", "primary:443")
+}
+
+func TestFailover_SkipsUnreachableBackups(t *testing.T) {
+	mockClient := tutils.NewMockCommandClient()
+	mockClient.On("WithServer", mock.Anything)
+
+	pluginUnderTest := NewFailover(failoverTestConfig(), mockClient)
+	messagePipe := core.NewMockMessagePipe(context.Background())
+	pluginUnderTest.pipeline = messagePipe
+
+	down := map[string]bool{"primary:443": true, "dr1:443": true}
+	pluginUnderTest.probe = func(target string) error {
+		if down[target] {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	pluginUnderTest.checkTargets()
+	pluginUnderTest.checkTargets()
+
+	assert.Equal(t, 2, pluginUnderTest.current)
+	messages := messagePipe.GetMessages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "dr2:443", messages[0].Data())
+	mockClient.AssertCalled(t, "WithServer", "dr2:443")
+}
+
+func TestFailover_StaysWhenNothingReachable(t *testing.T) {
+	mockClient := tutils.NewMockCommandClient()
+
+	pluginUnderTest := NewFailover(failoverTestConfig(), mockClient)
+	messagePipe := core.NewMockMessagePipe(context.Background())
+	pluginUnderTest.pipeline = messagePipe
+
+	pluginUnderTest.probe = func(target string) error {
+		return errors.New("connection refused")
+	}
+
+	pluginUnderTest.checkTargets()
+	pluginUnderTest.checkTargets()
+
+	assert.Equal(t, 0, pluginUnderTest.current)
+	assert.Len(t, messagePipe.GetMessages(), 0)
+	mockClient.AssertNotCalled(t, "WithServer", mock.Anything)
+}
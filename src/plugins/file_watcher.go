@@ -19,6 +19,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/nginx/agent/sdk/v2"
 	"github.com/nginx/agent/v2/src/core"
 	"github.com/nginx/agent/v2/src/core/config"
 )
@@ -75,6 +76,7 @@ func (fw *FileWatcher) Init(pipeline core.MessagePipeInterface) {
 	fw.ctx = fw.messagePipeline.Context()
 
 	for dir := range fw.config.AllowedDirectoriesMap {
+		dir = sdk.NormalizePath(dir)
 		if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
 			log.Debugf("Skipping watching %s: %v", dir, err)
 			continue
@@ -115,12 +117,42 @@ func (fw *FileWatcher) Process(message *core.Message) {
 		if fw.enabled {
 			fw.messagePipeline.Process(core.NewMessage(core.DataplaneFilesChanged, nil))
 		}
+	case core.AgentConfigChanged:
+		fw.syncAgentConfigChange()
 	}
 }
 
 func (fw *FileWatcher) Subscriptions() []string {
 	return []string{
 		core.FileWatcherEnabled,
+		core.AgentConfigChanged,
+	}
+}
+
+// syncAgentConfigChange re-reads the config from disk and starts watching
+// newly allowed directories, so a config reload does not need an agent restart
+// to pick them up
+func (fw *FileWatcher) syncAgentConfigChange() {
+	conf, err := config.GetConfig(fw.config.ClientID)
+	if err != nil {
+		log.Errorf("Failed to load config for updating: %v", err)
+		return
+	}
+	fw.config = conf
+
+	for dir := range fw.config.AllowedDirectoriesMap {
+		dir = sdk.NormalizePath(dir)
+		if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			_ = fw.addWatcher(info, path)
+			return nil
+		})
+		if err != nil {
+			log.Errorf("Error occurred creating watcher for %v: %v", dir, err)
+		}
 	}
 }
 
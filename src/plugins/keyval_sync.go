@@ -0,0 +1,211 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	plusclient "github.com/nginxinc/nginx-plus-go-client/client"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+// KeyvalSync loads key-value pairs pushed by the management server into an NGINX Plus
+// key-value zone through the Plus API, so dynamic data such as blocklists can be
+// driven from the control plane without custom scripts on the dataplane. Entries are
+// added or modified in place, entries missing from the payload are optionally removed,
+// and the current contents of a zone can be exported back instead.
+type KeyvalSync struct {
+	messagePipeline core.MessagePipeInterface
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	detailsMutex    sync.Mutex
+	nginxDetails    map[string]*proto.NginxDetails
+}
+
+func NewKeyvalSync(conf *config.Config, env core.Environment, binary core.NginxBinary) *KeyvalSync {
+	return &KeyvalSync{
+		conf:         conf,
+		env:          env,
+		binary:       binary,
+		nginxDetails: make(map[string]*proto.NginxDetails),
+	}
+}
+
+func (ks *KeyvalSync) Init(pipeline core.MessagePipeInterface) {
+	log.Info("KeyvalSync initializing")
+	ks.messagePipeline = pipeline
+}
+
+func (ks *KeyvalSync) Close() {
+	log.Info("KeyvalSync is wrapping up")
+}
+
+func (ks *KeyvalSync) Info() *core.Info {
+	return core.NewInfo("KeyvalSync", "v0.0.1")
+}
+
+func (ks *KeyvalSync) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.NginxInstancesFound):
+		nginxDetailsMap, ok := msg.Data().(map[string]*proto.NginxDetails)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		ks.detailsMutex.Lock()
+		ks.nginxDetails = nginxDetailsMap
+		ks.detailsMutex.Unlock()
+	case msg.Exact(core.KeyvalSync):
+		cmd, ok := msg.Data().(*proto.Command)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		ks.sync(cmd)
+	}
+}
+
+func (ks *KeyvalSync) Subscriptions() []string {
+	return []string{
+		core.KeyvalSync,
+		core.NginxInstancesFound,
+	}
+}
+
+// sync applies the pushed request against the Plus API of the targeted instance and
+// responds with a command status describing the outcome. An export responds with the
+// current contents of the zone as JSON in the status message.
+func (ks *KeyvalSync) sync(cmd *proto.Command) {
+	request := cmd.GetKeyvalSyncRequest()
+
+	message, err := ks.applyRequest(request)
+	if err != nil {
+		log.Errorf("KeyvalSync: syncing key-value zone %s failed: %v", request.GetZone(), err)
+		ks.respond(cmd, proto.CommandStatusResponse_CMD_ERROR, fmt.Sprintf("key-value zone %s sync failed", request.GetZone()), err.Error())
+		return
+	}
+
+	log.Infof("KeyvalSync: %s", message)
+	ks.respond(cmd, proto.CommandStatusResponse_CMD_OK, message, "")
+}
+
+func (ks *KeyvalSync) applyRequest(request *proto.KeyvalSyncRequest) (string, error) {
+	if request == nil {
+		return "", fmt.Errorf("key-value sync request is empty")
+	}
+	if request.GetZone() == "" {
+		return "", fmt.Errorf("key-value sync request has no zone")
+	}
+
+	client, err := ks.plusClient(request.GetNginxId())
+	if err != nil {
+		return "", err
+	}
+
+	if request.GetExport() {
+		pairs, err := client.GetKeyValPairs(request.GetZone())
+		if err != nil {
+			return "", err
+		}
+		contents, err := json.Marshal(pairs)
+		if err != nil {
+			return "", err
+		}
+		return string(contents), nil
+	}
+
+	desired := map[string]string{}
+	if err := json.Unmarshal(request.GetEntries(), &desired); err != nil {
+		return "", fmt.Errorf("invalid key-value payload: %v", err)
+	}
+
+	current, err := client.GetKeyValPairs(request.GetZone())
+	if err != nil {
+		return "", err
+	}
+
+	var added, updated, removed int
+	for key, value := range desired {
+		existing, found := current[key]
+		switch {
+		case !found:
+			if err := client.AddKeyValPair(request.GetZone(), key, value); err != nil {
+				return "", err
+			}
+			added++
+		case existing != value:
+			if err := client.ModifyKeyValPair(request.GetZone(), key, value); err != nil {
+				return "", err
+			}
+			updated++
+		}
+	}
+
+	if request.GetTruncate() {
+		for key := range current {
+			if _, found := desired[key]; !found {
+				if err := client.DeleteKeyValuePair(request.GetZone(), key); err != nil {
+					return "", err
+				}
+				removed++
+			}
+		}
+	}
+
+	return fmt.Sprintf("key-value zone %s synced: %d added, %d updated, %d removed", request.GetZone(), added, updated, removed), nil
+}
+
+// plusClient returns a Plus API client for the requested instance, or for the only
+// local Plus instance when the request does not name one
+func (ks *KeyvalSync) plusClient(nginxID string) (*plusclient.NginxClient, error) {
+	ks.detailsMutex.Lock()
+	defer ks.detailsMutex.Unlock()
+
+	detail := ks.nginxDetails[nginxID]
+	if detail == nil && nginxID == "" {
+		for _, candidate := range ks.nginxDetails {
+			if candidate.GetPlus().GetEnabled() {
+				detail = candidate
+				break
+			}
+		}
+	}
+	if detail == nil {
+		return nil, fmt.Errorf("no nginx instance found for the request")
+	}
+	if !detail.GetPlus().GetEnabled() {
+		return nil, fmt.Errorf("nginx instance %s is not an NGINX Plus instance", detail.GetNginxId())
+	}
+	if detail.GetStatusUrl() == "" {
+		return nil, fmt.Errorf("no Plus API configured for nginx instance %s", detail.GetNginxId())
+	}
+
+	return plusclient.NewNginxClient(&http.Client{}, detail.GetStatusUrl())
+}
+
+func (ks *KeyvalSync) respond(cmd *proto.Command, status proto.CommandStatusResponse_CommandStatus, message, errorMessage string) {
+	ks.messagePipeline.Process(core.NewMessage(core.CommResponse, &proto.Command{
+		Meta: cmd.GetMeta(),
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_CmdStatus{
+			CmdStatus: &proto.CommandStatusResponse{
+				Status:  status,
+				Message: message,
+				Error:   errorMessage,
+			},
+		},
+	}))
+}
@@ -0,0 +1,183 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+// fakePlusAPI emulates the parts of the Plus API the keyval sync uses: version
+// discovery and the http/keyvals endpoints of a single zone
+type fakePlusAPI struct {
+	mu    sync.Mutex
+	zone  string
+	pairs map[string]string
+}
+
+func (f *fakePlusAPI) serve(t *testing.T) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if r.URL.Path == "/api" {
+			fmt.Fprint(w, `[4,5,6,7,8]`)
+			return
+		}
+		// the client appends a trailing slash to patch requests
+		if !strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/http/keyvals/"+f.zone) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(f.pairs)
+		case http.MethodPost:
+			input := map[string]string{}
+			json.NewDecoder(r.Body).Decode(&input)
+			for key, value := range input {
+				f.pairs[key] = value
+			}
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			// the API deletes a key when its value is patched to null
+			input := map[string]*string{}
+			json.NewDecoder(r.Body).Decode(&input)
+			for key, value := range input {
+				if value == nil {
+					delete(f.pairs, key)
+				} else {
+					f.pairs[key] = *value
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL + "/api"
+}
+
+func keyvalSyncResponses(messagePipe *core.MockMessagePipe) []*proto.Command {
+	responses := []*proto.Command{}
+	for _, msg := range messagePipe.GetMessages() {
+		if msg.Topic() == core.CommResponse {
+			responses = append(responses, msg.Data().(*proto.Command))
+		}
+	}
+	return responses
+}
+
+func keyvalSyncProcess(t *testing.T, details map[string]*proto.NginxDetails, request *proto.KeyvalSyncRequest) []*proto.Command {
+	pluginUnderTest := NewKeyvalSync(&config.Config{}, tutils.GetMockEnv(), tutils.GetMockNginxBinary())
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.messagePipeline = messagePipe
+
+	pluginUnderTest.Process(core.NewMessage(core.NginxInstancesFound, details))
+	pluginUnderTest.Process(core.NewMessage(core.KeyvalSync, &proto.Command{
+		Meta: &proto.Metadata{MessageId: "123"},
+		Data: &proto.Command_KeyvalSyncRequest{KeyvalSyncRequest: request},
+	}))
+
+	return keyvalSyncResponses(messagePipe)
+}
+
+func TestKeyvalSyncLoad(t *testing.T) {
+	api := &fakePlusAPI{
+		zone: "blocklist",
+		pairs: map[string]string{
+			"9.9.9.9": "1",
+			"1.2.3.4": "0",
+		},
+	}
+	details := map[string]*proto.NginxDetails{
+		"12345": {
+			NginxId:   "12345",
+			StatusUrl: api.serve(t),
+			Plus:      &proto.NginxPlusMetaData{Enabled: true},
+		},
+	}
+
+	entries, err := json.Marshal(map[string]string{
+		"1.2.3.4": "1",
+		"5.6.7.8": "1",
+	})
+	require.NoError(t, err)
+
+	responses := keyvalSyncProcess(t, details, &proto.KeyvalSyncRequest{
+		NginxId:  "12345",
+		Zone:     "blocklist",
+		Entries:  entries,
+		Truncate: true,
+	})
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_OK, responses[0].GetCmdStatus().GetStatus())
+	assert.Equal(t, "key-value zone blocklist synced: 1 added, 1 updated, 1 removed", responses[0].GetCmdStatus().GetMessage())
+	assert.Equal(t, map[string]string{"1.2.3.4": "1", "5.6.7.8": "1"}, api.pairs)
+}
+
+func TestKeyvalSyncExport(t *testing.T) {
+	api := &fakePlusAPI{
+		zone:  "blocklist",
+		pairs: map[string]string{"9.9.9.9": "1"},
+	}
+	details := map[string]*proto.NginxDetails{
+		"12345": {
+			NginxId:   "12345",
+			StatusUrl: api.serve(t),
+			Plus:      &proto.NginxPlusMetaData{Enabled: true},
+		},
+	}
+
+	// no nginx_id in the request: the only local Plus instance is used
+	responses := keyvalSyncProcess(t, details, &proto.KeyvalSyncRequest{
+		Zone:   "blocklist",
+		Export: true,
+	})
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_OK, responses[0].GetCmdStatus().GetStatus())
+
+	exported := map[string]string{}
+	require.NoError(t, json.Unmarshal([]byte(responses[0].GetCmdStatus().GetMessage()), &exported))
+	assert.Equal(t, map[string]string{"9.9.9.9": "1"}, exported)
+}
+
+func TestKeyvalSyncRejectsNonPlusInstance(t *testing.T) {
+	responses := keyvalSyncProcess(t, tutils.GetDetailsMap(), &proto.KeyvalSyncRequest{
+		NginxId: "12345",
+		Zone:    "blocklist",
+		Entries: []byte(`{}`),
+	})
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_ERROR, responses[0].GetCmdStatus().GetStatus())
+}
+
+func TestKeyvalSyncSubscriptions(t *testing.T) {
+	pluginUnderTest := NewKeyvalSync(&config.Config{}, tutils.GetMockEnv(), tutils.GetMockNginxBinary())
+	assert.Equal(t, []string{core.KeyvalSync, core.NginxInstancesFound}, pluginUnderTest.Subscriptions())
+}
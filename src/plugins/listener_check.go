@@ -0,0 +1,121 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	psnet "github.com/shirou/gopsutil/v3/net"
+	log "github.com/sirupsen/logrus"
+
+	sdk "github.com/nginx/agent/sdk/v2"
+)
+
+// checkConfigListeners runs the listener pre-checks against the listen directives
+// of the config at confPath, treating a config that cannot be parsed as nothing to
+// check rather than an apply failure.
+func (n *Nginx) checkConfigListeners(confPath string) error {
+	addresses, err := sdk.CollectListenAddresses(confPath)
+	if err != nil {
+		log.Warnf("Listener pre-check skipped: %v", err)
+		return nil
+	}
+
+	nginxPids := map[int32]struct{}{}
+	for _, process := range n.env.Processes() {
+		nginxPids[process.Pid] = struct{}{}
+	}
+	return checkListenAddresses(addresses, nginxPids)
+}
+
+// checkListenAddresses runs the pre-reload listener checks for a pushed config: a
+// listen port already bound by a process other than nginx fails the apply with the
+// conflicting pid, and ports nothing listens on yet are probe-bound to surface bind
+// failures, such as unassigned addresses, before nginx is reloaded into them. Ports
+// the nginx instance itself holds are inherited across a reload and are fine.
+func checkListenAddresses(addresses []sdk.ListenAddress, nginxPids map[int32]struct{}) error {
+	connections, err := psnet.Connections("inet")
+	if err != nil {
+		log.Warnf("Listener pre-check skipped: unable to list listening sockets: %v", err)
+		return nil
+	}
+
+	listenersByPort := map[int][]psnet.ConnectionStat{}
+	for _, connection := range connections {
+		if connection.Status != "LISTEN" {
+			continue
+		}
+		port := int(connection.Laddr.Port)
+		listenersByPort[port] = append(listenersByPort[port], connection)
+	}
+
+	for _, address := range addresses {
+		if address.UDP {
+			// udp listens share ports freely through reuseport, there is nothing
+			// reliable to pre-check
+			continue
+		}
+
+		heldByNginx := false
+		for _, listener := range listenersByPort[address.Port] {
+			if _, ours := nginxPids[listener.Pid]; ours {
+				heldByNginx = true
+				continue
+			}
+			if !listenAddressesOverlap(address.Host, listener.Laddr.IP) {
+				continue
+			}
+			return fmt.Errorf("listen %s conflicts with an existing listener on %s (pid %d)",
+				address, net.JoinHostPort(listener.Laddr.IP, fmt.Sprint(listener.Laddr.Port)), listener.Pid)
+		}
+
+		if heldByNginx || len(listenersByPort[address.Port]) > 0 {
+			continue
+		}
+		if err := probeListen(address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listenAddressesOverlap reports whether a listen on host would contend with an
+// existing listener bound to ip, where an empty host and the unspecified addresses
+// cover everything.
+func listenAddressesOverlap(host, ip string) bool {
+	if host == "" || ip == "0.0.0.0" || ip == "::" || ip == "*" || ip == "" {
+		return true
+	}
+	hostIP := net.ParseIP(host)
+	boundIP := net.ParseIP(ip)
+	if hostIP == nil || boundIP == nil {
+		return host == ip
+	}
+	return hostIP.Equal(boundIP)
+}
+
+// probeListen briefly binds the address to prove nginx will be able to. A permission
+// error is only fatal when the agent runs as root, since an unprivileged agent
+// cannot tell whether the nginx master, which usually runs as root, would succeed.
+func probeListen(address sdk.ListenAddress) error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(address.Host, strconv.Itoa(address.Port)))
+	if err == nil {
+		listener.Close()
+		return nil
+	}
+
+	if errors.Is(err, os.ErrPermission) && os.Geteuid() != 0 {
+		log.Warnf("Listener pre-check: unable to verify that %s is bindable: %v", address, err)
+		return nil
+	}
+	return fmt.Errorf("listen %s is not bindable: %v", address, err)
+}
@@ -0,0 +1,71 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/nginx/agent/sdk/v2"
+)
+
+func TestCheckListenAddresses(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	occupied := []sdk.ListenAddress{{Host: "127.0.0.1", Port: port}}
+
+	// the port is held by this test process, which is not a known nginx pid
+	err = checkListenAddresses(occupied, map[int32]struct{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with an existing listener")
+
+	// the same port is fine when the listener belongs to the nginx instance
+	assert.NoError(t, checkListenAddresses(occupied, map[int32]struct{}{int32(os.Getpid()): {}}))
+
+	// a wildcard listen contends with the bound address as well
+	err = checkListenAddresses([]sdk.ListenAddress{{Host: "", Port: port}}, map[int32]struct{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with an existing listener")
+
+	// a free port passes the bind probe
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	freePort := free.Addr().(*net.TCPAddr).Port
+	require.NoError(t, free.Close())
+	assert.NoError(t, checkListenAddresses([]sdk.ListenAddress{{Host: "127.0.0.1", Port: freePort}}, map[int32]struct{}{}))
+
+	// an address that is not assigned to any interface fails the bind probe
+	err = checkListenAddresses([]sdk.ListenAddress{{Host: "192.0.2.1", Port: freePort}}, map[int32]struct{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not bindable")
+}
+
+func TestListenAddressesOverlap(t *testing.T) {
+	for _, tt := range []struct {
+		host     string
+		ip       string
+		expected bool
+	}{
+		{"", "127.0.0.1", true},
+		{"127.0.0.1", "0.0.0.0", true},
+		{"127.0.0.1", "::", true},
+		{"127.0.0.1", "127.0.0.1", true},
+		{"127.0.0.1", "127.0.0.2", false},
+		{"localhost", "localhost", true},
+		{"localhost", "127.0.0.2", false},
+	} {
+		assert.Equal(t, tt.expected, listenAddressesOverlap(tt.host, tt.ip), "host %q ip %q", tt.host, tt.ip)
+	}
+}
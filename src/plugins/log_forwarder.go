@@ -0,0 +1,498 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nxadm/tail"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const logForwarderPositionsFile = "log-forwarder-positions.json"
+
+// logPosition is the persisted checkpoint of one tailed log file. The inode
+// disambiguates the file the offset belongs to, so rotation by rename is detected and
+// the replacement file is not resumed at a stale offset.
+type logPosition struct {
+	Inode     uint64    `json:"inode"`
+	Offset    int64     `json:"offset"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// LogForwarder tails the access and error logs of the discovered nginx instances and
+// ships the lines to the configured sink in batches. Lines are buffered in a bounded
+// queue while the sink is slow or unavailable, and file positions are checkpointed
+// after every shipped batch so a restart resumes where forwarding left off.
+type LogForwarder struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	conf            *config.Config
+	binary          core.NginxBinary
+	sink            logSink
+	queue           chan logEntry
+
+	positionsMutex sync.Mutex
+	positions      map[string]logPosition
+
+	tailsMutex sync.Mutex
+	tails      map[string]context.CancelFunc
+
+	dropped uint64
+	wg      sync.WaitGroup
+	budget  *core.BandwidthBudget
+}
+
+func NewLogForwarder(conf *config.Config, env core.Environment, binary core.NginxBinary, budget *core.BandwidthBudget) (*LogForwarder, error) {
+	sink, err := newLogSink(conf, env.GetHostname())
+	if err != nil {
+		return nil, err
+	}
+
+	forwarder := &LogForwarder{
+		conf:      conf,
+		binary:    binary,
+		sink:      sink,
+		queue:     make(chan logEntry, conf.LogForwarding.QueueSize),
+		positions: make(map[string]logPosition),
+		tails:     make(map[string]context.CancelFunc),
+		budget:    budget,
+	}
+	forwarder.loadPositions()
+
+	return forwarder, nil
+}
+
+func (lf *LogForwarder) Init(pipeline core.MessagePipeInterface) {
+	log.Info("LogForwarder initializing")
+	lf.messagePipeline = pipeline
+	lf.ctx = pipeline.Context()
+
+	lf.wg.Add(1)
+	go lf.ship()
+}
+
+func (lf *LogForwarder) Close() {
+	log.Info("LogForwarder is wrapping up")
+	lf.tailsMutex.Lock()
+	for logFile, cancel := range lf.tails {
+		cancel()
+		delete(lf.tails, logFile)
+	}
+	lf.tailsMutex.Unlock()
+
+	lf.savePositions()
+	if err := lf.sink.Close(); err != nil {
+		log.Warnf("LogForwarder: error closing sink: %v", err)
+	}
+}
+
+func (lf *LogForwarder) Info() *core.Info {
+	return core.NewInfo("LogForwarder", "v0.0.1")
+}
+
+func (lf *LogForwarder) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.NginxInstancesFound), msg.Exact(core.NginxConfigApplySucceeded):
+		lf.refreshTails()
+	}
+}
+
+func (lf *LogForwarder) Subscriptions() []string {
+	return []string{
+		core.NginxInstancesFound,
+		core.NginxConfigApplySucceeded,
+	}
+}
+
+// refreshTails reconciles the running tailers with the access and error logs currently
+// configured on the dataplane
+func (lf *LogForwarder) refreshTails() {
+	logs := map[string]struct{}{}
+	for logFile := range lf.binary.GetAccessLogs() {
+		logs[logFile] = struct{}{}
+	}
+	for logFile := range lf.binary.GetErrorLogs() {
+		logs[logFile] = struct{}{}
+	}
+
+	lf.tailsMutex.Lock()
+	defer lf.tailsMutex.Unlock()
+
+	for logFile := range logs {
+		if _, ok := lf.tails[logFile]; ok {
+			continue
+		}
+		tailCtx, cancel := context.WithCancel(lf.ctx)
+		lf.tails[logFile] = cancel
+		go lf.tailLog(tailCtx, logFile)
+	}
+
+	for logFile, cancel := range lf.tails {
+		if _, ok := logs[logFile]; !ok {
+			cancel()
+			delete(lf.tails, logFile)
+		}
+	}
+}
+
+// tailLog follows a single log file from its checkpointed position, enqueueing every
+// line. When the queue is full the line is dropped, so a stalled sink cannot block the
+// tailer or grow memory without bound.
+func (lf *LogForwarder) tailLog(ctx context.Context, logFile string) {
+	offset := lf.resumeOffset(logFile)
+	inode := currentInode(logFile)
+
+	t, err := tail.TailFile(logFile, tail.Config{
+		Follow: true,
+		ReOpen: true,
+		Poll:   true,
+		Location: &tail.SeekInfo{
+			Offset: offset,
+			Whence: io.SeekStart,
+		},
+	})
+	if err != nil {
+		log.Warnf("LogForwarder: could not tail log %s: %v", logFile, err)
+		return
+	}
+	defer t.Cleanup()
+
+	lastPosition := offset
+	for {
+		select {
+		case line := <-t.Lines:
+			if line == nil {
+				return
+			}
+			if line.Err != nil {
+				continue
+			}
+			position, err := t.Tell()
+			if err != nil {
+				position = 0
+			}
+			// a position running backwards means the tailer reopened the file after a
+			// rotation or truncation, so the offsets now belong to a different inode
+			if position < lastPosition {
+				inode = currentInode(logFile)
+			}
+			lastPosition = position
+			entry := logEntry{
+				logFile:   logFile,
+				line:      line.Text,
+				timestamp: time.Now(),
+				offset:    position,
+				inode:     inode,
+			}
+			select {
+			case lf.queue <- entry:
+			default:
+				lf.positionsMutex.Lock()
+				lf.dropped++
+				lf.positionsMutex.Unlock()
+			}
+		case <-ctx.Done():
+			err := t.Stop()
+			if err != nil {
+				log.Warnf("LogForwarder: error stopping tail of %s: %v", logFile, err)
+			}
+			return
+		}
+	}
+}
+
+// batchBytes estimates the wire size of a batch as the length of its lines;
+// sink framing overhead is ignored.
+func batchBytes(batch []logEntry) int64 {
+	var size int64
+	for _, entry := range batch {
+		size += int64(len(entry.line))
+	}
+	return size
+}
+
+// ship drains the queue into batches and delivers them to the sink. A batch is shipped
+// when it reaches the configured size or when the batch interval elapses; a failed
+// batch is retried on the next flush and dropped once it exceeds twice the batch size.
+func (lf *LogForwarder) ship() {
+	defer lf.wg.Done()
+
+	ticker := time.NewTicker(lf.conf.LogForwarding.BatchInterval)
+	defer ticker.Stop()
+
+	batch := []logEntry{}
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		// a batch held back by the bandwidth budget keeps accumulating and is
+		// bounded by the same oversize guard as a failing sink
+		if !lf.budget.Allow(core.BandwidthPriorityEvents, batchBytes(batch)) {
+			log.Debugf("LogForwarder: bandwidth budget spent, deferring %d log lines", len(batch))
+			if len(batch) > 2*lf.conf.LogForwarding.BatchSize {
+				lf.positionsMutex.Lock()
+				lf.dropped += uint64(len(batch))
+				lf.positionsMutex.Unlock()
+				batch = batch[:0]
+			}
+			return
+		}
+		if err := lf.sink.Ship(batch); err != nil {
+			log.Warnf("LogForwarder: could not ship %d log lines: %v", len(batch), err)
+			if len(batch) > 2*lf.conf.LogForwarding.BatchSize {
+				lf.positionsMutex.Lock()
+				lf.dropped += uint64(len(batch))
+				lf.positionsMutex.Unlock()
+				batch = batch[:0]
+			}
+			return
+		}
+		lf.commitPositions(batch)
+		batch = batch[:0]
+		lf.reportDropped()
+	}
+
+	for {
+		select {
+		case entry := <-lf.queue:
+			batch = append(batch, entry)
+			if len(batch) >= lf.conf.LogForwarding.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-lf.ctx.Done():
+			flush()
+			return
+		}
+	}
+}
+
+func (lf *LogForwarder) reportDropped() {
+	lf.positionsMutex.Lock()
+	dropped := lf.dropped
+	lf.dropped = 0
+	lf.positionsMutex.Unlock()
+	if dropped > 0 {
+		log.Warnf("LogForwarder: dropped %d log lines while the sink was unavailable or slow", dropped)
+	}
+}
+
+// commitPositions records the highest shipped offset of every file in the batch and
+// persists the checkpoint file. An entry from a different inode than the recorded one
+// replaces the record, since the offsets of the old and the new file are unrelated.
+func (lf *LogForwarder) commitPositions(batch []logEntry) {
+	now := time.Now()
+	lf.positionsMutex.Lock()
+	for _, entry := range batch {
+		position := lf.positions[entry.logFile]
+		if entry.inode == position.Inode && entry.offset <= position.Offset {
+			continue
+		}
+		lf.positions[entry.logFile] = logPosition{
+			Inode:     entry.inode,
+			Offset:    entry.offset,
+			Timestamp: now,
+		}
+	}
+	lf.positionsMutex.Unlock()
+
+	lf.savePositions()
+}
+
+// resumeOffset returns the offset tailing of the log file starts at. The checkpointed
+// offset is only resumed when it still belongs to the file at the path: when the file
+// was truncated underneath it (copytruncate rotation) tailing restarts at the
+// beginning, and when the inode changed (rotation by rename) the unread remainder of
+// the rotated file is optionally forwarded first.
+func (lf *LogForwarder) resumeOffset(logFile string) int64 {
+	lf.positionsMutex.Lock()
+	position, ok := lf.positions[logFile]
+	lf.positionsMutex.Unlock()
+	if !ok {
+		return 0
+	}
+
+	info, err := os.Stat(logFile)
+	if err != nil {
+		return 0
+	}
+	inode := inodeOf(info)
+
+	if position.Inode == 0 || position.Inode == inode {
+		if info.Size() < position.Offset {
+			log.Infof("LogForwarder: %s was truncated, restarting from the beginning", logFile)
+			return 0
+		}
+		return position.Offset
+	}
+
+	if lf.conf.LogForwarding.RotationCatchUp {
+		lf.catchUpFromRotated(logFile, position)
+	}
+	return 0
+}
+
+// catchUpFromRotated forwards the lines written after the checkpointed offset to the
+// rotated-away file. The rotated file is located by inode among the siblings of the
+// log file; compressed archives created after the checkpoint are considered as well.
+func (lf *LogForwarder) catchUpFromRotated(logFile string, position logPosition) {
+	candidates, err := filepath.Glob(logFile + "*")
+	if err != nil {
+		return
+	}
+
+	for _, candidate := range candidates {
+		if candidate == logFile {
+			continue
+		}
+		info, err := os.Stat(candidate)
+		if err != nil {
+			continue
+		}
+
+		if inodeOf(info) == position.Inode {
+			lf.forwardRemainder(logFile, candidate, position.Offset, false)
+			return
+		}
+		if strings.HasSuffix(candidate, ".gz") && info.ModTime().After(position.Timestamp) {
+			lf.forwardRemainder(logFile, candidate, position.Offset, true)
+			return
+		}
+	}
+
+	log.Infof("LogForwarder: rotated file for %s not found, starting over from the new file", logFile)
+}
+
+// forwardRemainder enqueues every line of the rotated file after the checkpointed
+// offset. The entries keep the rotated file's inode, so the checkpoint of the live
+// log file is not advanced by them.
+func (lf *LogForwarder) forwardRemainder(logFile, rotatedFile string, offset int64, compressed bool) {
+	f, err := os.Open(rotatedFile)
+	if err != nil {
+		log.Warnf("LogForwarder: could not open rotated file %s: %v", rotatedFile, err)
+		return
+	}
+	defer f.Close()
+
+	var reader io.Reader = f
+	if compressed {
+		gzReader, err := gzip.NewReader(f)
+		if err != nil {
+			log.Warnf("LogForwarder: could not read compressed rotated file %s: %v", rotatedFile, err)
+			return
+		}
+		defer gzReader.Close()
+		if _, err := io.CopyN(ioutil.Discard, gzReader, offset); err != nil {
+			return
+		}
+		reader = gzReader
+	} else {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			log.Warnf("LogForwarder: could not seek in rotated file %s: %v", rotatedFile, err)
+			return
+		}
+	}
+
+	forwarded := 0
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := logEntry{
+			logFile:   logFile,
+			line:      scanner.Text(),
+			timestamp: time.Now(),
+		}
+		select {
+		case lf.queue <- entry:
+			forwarded++
+		default:
+			lf.positionsMutex.Lock()
+			lf.dropped++
+			lf.positionsMutex.Unlock()
+		}
+	}
+	log.Infof("LogForwarder: forwarded %d lines from rotated file %s", forwarded, rotatedFile)
+}
+
+// currentInode returns the inode of the file at the path, or zero when unavailable
+func currentInode(logFile string) uint64 {
+	info, err := os.Stat(logFile)
+	if err != nil {
+		return 0
+	}
+	return inodeOf(info)
+}
+
+func (lf *LogForwarder) positionsFilePath() string {
+	return filepath.Join(lf.conf.LogForwarding.CheckpointDir, logForwarderPositionsFile)
+}
+
+func (lf *LogForwarder) loadPositions() {
+	contents, err := os.ReadFile(lf.positionsFilePath())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warnf("LogForwarder: could not read checkpoint file: %v", err)
+		}
+		return
+	}
+
+	lf.positionsMutex.Lock()
+	defer lf.positionsMutex.Unlock()
+	if err := json.Unmarshal(contents, &lf.positions); err != nil {
+		// checkpoint files written before inode tracking hold plain offsets
+		legacy := map[string]int64{}
+		if legacyErr := json.Unmarshal(contents, &legacy); legacyErr != nil {
+			log.Warnf("LogForwarder: could not parse checkpoint file: %v", err)
+			lf.positions = make(map[string]logPosition)
+			return
+		}
+		lf.positions = make(map[string]logPosition, len(legacy))
+		for logFile, offset := range legacy {
+			lf.positions[logFile] = logPosition{Offset: offset}
+		}
+	}
+}
+
+func (lf *LogForwarder) savePositions() {
+	lf.positionsMutex.Lock()
+	contents, err := json.Marshal(lf.positions)
+	lf.positionsMutex.Unlock()
+	if err != nil {
+		log.Warnf("LogForwarder: could not serialize checkpoint: %v", err)
+		return
+	}
+
+	if err := os.MkdirAll(lf.conf.LogForwarding.CheckpointDir, 0755); err != nil {
+		log.Warnf("LogForwarder: could not create checkpoint directory: %v", err)
+		return
+	}
+
+	tmpFile := lf.positionsFilePath() + ".tmp"
+	if err := os.WriteFile(tmpFile, contents, 0644); err != nil {
+		log.Warnf("LogForwarder: could not write checkpoint file: %v", err)
+		return
+	}
+	if err := os.Rename(tmpFile, lf.positionsFilePath()); err != nil {
+		log.Warnf("LogForwarder: could not replace checkpoint file: %v", err)
+	}
+}
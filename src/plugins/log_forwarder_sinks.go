@@ -0,0 +1,220 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const (
+	sinkSyslog = "syslog"
+	sinkLoki   = "loki"
+	sinkKafka  = "kafka"
+
+	// syslogPriority is local0.info, the conventional facility for forwarded service logs
+	syslogPriority = 134
+
+	sinkShipTimeout = 30 * time.Second
+)
+
+// logEntry is a single log line read from one of the tailed nginx logs, together with
+// the offset directly after the line in the source file.
+type logEntry struct {
+	logFile   string
+	line      string
+	timestamp time.Time
+	offset    int64
+	inode     uint64
+}
+
+// logSink ships batches of log lines to an external destination. Ship either delivers
+// the complete batch or returns an error, in which case the caller retries the batch.
+type logSink interface {
+	Ship(entries []logEntry) error
+	Close() error
+}
+
+// newLogSink returns the sink configured under log_forwarding.sink
+func newLogSink(conf *config.Config, hostname string) (logSink, error) {
+	switch conf.LogForwarding.Sink {
+	case sinkSyslog:
+		return &syslogSink{address: conf.LogForwarding.Address, hostname: hostname}, nil
+	case sinkLoki:
+		return &lokiSink{
+			pushURL:  strings.TrimSuffix(conf.LogForwarding.Address, "/") + "/loki/api/v1/push",
+			hostname: hostname,
+			client:   &http.Client{Timeout: sinkShipTimeout},
+		}, nil
+	case sinkKafka:
+		if conf.LogForwarding.KafkaTopic == "" {
+			return nil, fmt.Errorf("log forwarding sink %q requires a kafka topic", sinkKafka)
+		}
+		return &kafkaSink{
+			writer: &kafka.Writer{
+				Addr:     kafka.TCP(strings.Split(conf.LogForwarding.Address, ",")...),
+				Topic:    conf.LogForwarding.KafkaTopic,
+				Balancer: &kafka.LeastBytes{},
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown log forwarding sink %q", conf.LogForwarding.Sink)
+}
+
+// syslogSink ships log lines as RFC5424 messages over a TCP connection, reconnecting
+// on delivery errors.
+type syslogSink struct {
+	address  string
+	hostname string
+	conn     net.Conn
+}
+
+func (s *syslogSink) Ship(entries []logEntry) error {
+	if s.conn == nil {
+		conn, err := net.DialTimeout("tcp", s.address, sinkShipTimeout)
+		if err != nil {
+			return fmt.Errorf("could not connect to syslog sink (%s): %w", s.address, err)
+		}
+		s.conn = conn
+	}
+
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		buf.WriteString(formatRFC5424(s.hostname, entry))
+		buf.WriteByte('\n')
+	}
+
+	if err := s.conn.SetWriteDeadline(time.Now().Add(sinkShipTimeout)); err != nil {
+		return err
+	}
+	if _, err := s.conn.Write(buf.Bytes()); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("could not write to syslog sink (%s): %w", s.address, err)
+	}
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// formatRFC5424 renders a log entry as a RFC5424 syslog message with the log file
+// base name as the message id
+func formatRFC5424(hostname string, entry logEntry) string {
+	return fmt.Sprintf("<%d>1 %s %s nginx - %s - %s",
+		syslogPriority,
+		entry.timestamp.Format(time.RFC3339),
+		hostname,
+		filepath.Base(entry.logFile),
+		entry.line,
+	)
+}
+
+// lokiSink ships log lines to the Loki push API, one stream per source log file
+type lokiSink struct {
+	pushURL  string
+	hostname string
+	client   *http.Client
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+func (s *lokiSink) Ship(entries []logEntry) error {
+	streams := make(map[string]*lokiStream)
+	order := []string{}
+	for _, entry := range entries {
+		stream, ok := streams[entry.logFile]
+		if !ok {
+			stream = &lokiStream{
+				Stream: map[string]string{
+					"job":      "nginx-agent",
+					"host":     s.hostname,
+					"filename": entry.logFile,
+				},
+			}
+			streams[entry.logFile] = stream
+			order = append(order, entry.logFile)
+		}
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(entry.timestamp.UnixNano(), 10),
+			entry.line,
+		})
+	}
+
+	request := lokiPushRequest{}
+	for _, logFile := range order {
+		request.Streams = append(request.Streams, *streams[logFile])
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.client.Post(s.pushURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("could not push to loki sink (%s): %w", s.pushURL, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("loki sink (%s) returned status %d", s.pushURL, response.StatusCode)
+	}
+	return nil
+}
+
+func (s *lokiSink) Close() error {
+	return nil
+}
+
+// kafkaSink publishes log lines to a kafka topic, keyed by source log file so the
+// lines of one log stay ordered within a partition
+type kafkaSink struct {
+	writer *kafka.Writer
+}
+
+func (s *kafkaSink) Ship(entries []logEntry) error {
+	messages := make([]kafka.Message, 0, len(entries))
+	for _, entry := range entries {
+		messages = append(messages, kafka.Message{
+			Key:   []byte(entry.logFile),
+			Value: []byte(entry.line),
+			Time:  entry.timestamp,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sinkShipTimeout)
+	defer cancel()
+	return s.writer.WriteMessages(ctx, messages...)
+}
+
+func (s *kafkaSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,260 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+type fakeLogSink struct {
+	mutex   sync.Mutex
+	batches [][]logEntry
+}
+
+func (s *fakeLogSink) Ship(entries []logEntry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	batch := make([]logEntry, len(entries))
+	copy(batch, entries)
+	s.batches = append(s.batches, batch)
+	return nil
+}
+
+func (s *fakeLogSink) Close() error {
+	return nil
+}
+
+func (s *fakeLogSink) lines() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	lines := []string{}
+	for _, batch := range s.batches {
+		for _, entry := range batch {
+			lines = append(lines, entry.line)
+		}
+	}
+	return lines
+}
+
+func TestNewLogSink(t *testing.T) {
+	conf := &config.Config{LogForwarding: config.LogForwarding{Sink: "syslog", Address: "localhost:514"}}
+	sink, err := newLogSink(conf, "test-host")
+	assert.NoError(t, err)
+	assert.IsType(t, &syslogSink{}, sink)
+
+	conf.LogForwarding.Sink = "kafka"
+	_, err = newLogSink(conf, "test-host")
+	assert.Error(t, err, "kafka sink requires a topic")
+
+	conf.LogForwarding.Sink = "carrier-pigeon"
+	_, err = newLogSink(conf, "test-host")
+	assert.Error(t, err)
+}
+
+func TestFormatRFC5424(t *testing.T) {
+	timestamp := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	message := formatRFC5424("test-host", logEntry{
+		logFile:   "/var/log/nginx/access.log",
+		line:      `127.0.0.1 - - "GET / HTTP/1.1" 200`,
+		timestamp: timestamp,
+	})
+	assert.Equal(t, `<134>1 2023-01-01T12:00:00Z test-host nginx - access.log - 127.0.0.1 - - "GET / HTTP/1.1" 200`, message)
+}
+
+func TestLokiSinkShip(t *testing.T) {
+	received := make(chan lokiPushRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/loki/api/v1/push", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		request := lokiPushRequest{}
+		assert.NoError(t, json.Unmarshal(body, &request))
+		received <- request
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	conf := &config.Config{LogForwarding: config.LogForwarding{Sink: "loki", Address: server.URL}}
+	sink, err := newLogSink(conf, "test-host")
+	require.NoError(t, err)
+
+	err = sink.Ship([]logEntry{
+		{logFile: "/var/log/nginx/access.log", line: "line one", timestamp: time.Now()},
+		{logFile: "/var/log/nginx/access.log", line: "line two", timestamp: time.Now()},
+		{logFile: "/var/log/nginx/error.log", line: "line three", timestamp: time.Now()},
+	})
+	require.NoError(t, err)
+
+	request := <-received
+	require.Len(t, request.Streams, 2)
+	assert.Equal(t, "/var/log/nginx/access.log", request.Streams[0].Stream["filename"])
+	assert.Equal(t, "test-host", request.Streams[0].Stream["host"])
+	assert.Len(t, request.Streams[0].Values, 2)
+	assert.Equal(t, "line one", request.Streams[0].Values[0][1])
+	assert.Len(t, request.Streams[1].Values, 1)
+}
+
+func TestLogForwarderShipsAndCheckpoints(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "access.log")
+	require.NoError(t, os.WriteFile(logFile, []byte{}, 0644))
+
+	conf := &config.Config{
+		LogForwarding: config.LogForwarding{
+			Enable:        true,
+			Sink:          "syslog",
+			BatchSize:     2,
+			BatchInterval: 100 * time.Millisecond,
+			QueueSize:     100,
+			CheckpointDir: tmpDir,
+		},
+	}
+
+	binary := tutils.NewMockNginxBinary()
+	binary.On("GetAccessLogs").Return(map[string]string{logFile: ""})
+	binary.On("GetErrorLogs").Return(map[string]string{})
+
+	forwarder, err := NewLogForwarder(conf, tutils.GetMockEnv(), binary, nil)
+	require.NoError(t, err)
+	sink := &fakeLogSink{}
+	forwarder.sink = sink
+
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), forwarder)
+	forwarder.Init(messagePipe)
+	defer forwarder.Close()
+
+	forwarder.Process(core.NewMessage(core.NginxInstancesFound, nil))
+
+	// give the tailer a moment to open the log before appending
+	time.Sleep(500 * time.Millisecond)
+
+	f, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString("first line\nsecond line\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Eventually(t, func() bool {
+		return len(sink.lines()) == 2
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.Equal(t, []string{"first line", "second line"}, sink.lines())
+
+	// the checkpoint must record the shipped position so a restart resumes there
+	assert.Eventually(t, func() bool {
+		contents, err := os.ReadFile(filepath.Join(tmpDir, logForwarderPositionsFile))
+		if err != nil {
+			return false
+		}
+		positions := map[string]logPosition{}
+		if err := json.Unmarshal(contents, &positions); err != nil {
+			return false
+		}
+		return positions[logFile].Offset == int64(len("first line\nsecond line\n")) && positions[logFile].Inode != 0
+	}, 10*time.Second, 100*time.Millisecond)
+
+	restarted, err := NewLogForwarder(conf, tutils.GetMockEnv(), binary, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("first line\nsecond line\n")), restarted.resumeOffset(logFile))
+}
+
+func TestLogForwarderResumeOffsetRotation(t *testing.T) {
+	newForwarder := func(t *testing.T, tmpDir string) *LogForwarder {
+		conf := &config.Config{
+			LogForwarding: config.LogForwarding{
+				Sink:            "syslog",
+				QueueSize:       100,
+				CheckpointDir:   tmpDir,
+				RotationCatchUp: true,
+			},
+		}
+		forwarder, err := NewLogForwarder(conf, tutils.GetMockEnv(), tutils.NewMockNginxBinary(), nil)
+		require.NoError(t, err)
+		return forwarder
+	}
+
+	drainQueue := func(forwarder *LogForwarder) []string {
+		lines := []string{}
+		for {
+			select {
+			case entry := <-forwarder.queue:
+				lines = append(lines, entry.line)
+			default:
+				return lines
+			}
+		}
+	}
+
+	t.Run("Copytruncate", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		logFile := filepath.Join(tmpDir, "access.log")
+		require.NoError(t, os.WriteFile(logFile, []byte("short\n"), 0644))
+
+		forwarder := newForwarder(t, tmpDir)
+		forwarder.positions[logFile] = logPosition{Inode: currentInode(logFile), Offset: 100}
+
+		assert.Equal(t, int64(0), forwarder.resumeOffset(logFile))
+		assert.Empty(t, drainQueue(forwarder))
+	})
+
+	t.Run("RenamedFile", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		logFile := filepath.Join(tmpDir, "access.log")
+		require.NoError(t, os.WriteFile(logFile, []byte("shipped line\nunshipped line\n"), 0644))
+		rotatedInode := currentInode(logFile)
+
+		require.NoError(t, os.Rename(logFile, logFile+".1"))
+		require.NoError(t, os.WriteFile(logFile, []byte{}, 0644))
+
+		forwarder := newForwarder(t, tmpDir)
+		forwarder.positions[logFile] = logPosition{Inode: rotatedInode, Offset: int64(len("shipped line\n"))}
+
+		assert.Equal(t, int64(0), forwarder.resumeOffset(logFile))
+		assert.Equal(t, []string{"unshipped line"}, drainQueue(forwarder))
+	})
+
+	t.Run("CompressedArchive", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		logFile := filepath.Join(tmpDir, "access.log")
+
+		var compressed bytes.Buffer
+		gzWriter := gzip.NewWriter(&compressed)
+		_, err := gzWriter.Write([]byte("shipped line\nunshipped line\n"))
+		require.NoError(t, err)
+		require.NoError(t, gzWriter.Close())
+		require.NoError(t, os.WriteFile(logFile+".1.gz", compressed.Bytes(), 0644))
+		require.NoError(t, os.WriteFile(logFile, []byte{}, 0644))
+
+		forwarder := newForwarder(t, tmpDir)
+		forwarder.positions[logFile] = logPosition{
+			Inode:     12345,
+			Offset:    int64(len("shipped line\n")),
+			Timestamp: time.Now().Add(-time.Hour),
+		}
+
+		assert.Equal(t, int64(0), forwarder.resumeOffset(logFile))
+		assert.Equal(t, []string{"unshipped line"}, drainQueue(forwarder))
+	})
+}
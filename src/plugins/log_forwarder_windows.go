@@ -0,0 +1,18 @@
+//go:build windows
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import "os"
+
+// inodeOf returns zero on Windows, which has no inodes; rotation is then
+// detected purely from the shrinking file offset.
+func inodeOf(info os.FileInfo) uint64 {
+	return 0
+}
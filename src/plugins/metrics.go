@@ -24,11 +24,16 @@ import (
 	"github.com/nginx/agent/v2/src/core/metrics/collectors"
 )
 
+// degradedCollectionFactor is how much wider the collection interval becomes
+// while the host is under sustained CPU pressure
+const degradedCollectionFactor = 4
+
 type Metrics struct {
 	pipeline                 core.MessagePipeInterface
 	registrationComplete     *atomic.Bool
 	collectorsReady          *atomic.Bool
 	collectorsUpdate         *atomic.Bool
+	degraded                 *atomic.Bool
 	ticker                   *time.Ticker
 	interval                 time.Duration
 	collectors               []metrics.Collector
@@ -51,6 +56,7 @@ func NewMetrics(config *config.Config, env core.Environment, binary core.NginxBi
 		registrationComplete:     atomic.NewBool(false),
 		collectorsReady:          atomic.NewBool(false),
 		collectorsUpdate:         atomic.NewBool(false),
+		degraded:                 atomic.NewBool(false),
 		ticker:                   time.NewTicker(config.AgentMetrics.CollectionInterval),
 		interval:                 config.AgentMetrics.CollectionInterval,
 		buf:                      make(chan *proto.StatsEntity, 4096),
@@ -101,6 +107,12 @@ func (m *Metrics) Process(msg *core.Message) {
 		m.updateCollectorsConfig()
 		return
 
+	case msg.Exact(core.CollectionDegraded):
+		// widen or restore the collection interval once the current report cycle finishes
+		m.degraded.Store(msg.Data().(bool))
+		m.collectorsUpdate.Store(true)
+		return
+
 	case msg.Exact(core.NginxPluginConfigured):
 		m.registerStatsSources()
 		return
@@ -159,6 +171,7 @@ func (m *Metrics) Subscriptions() []string {
 	return []string{
 		core.RegistrationCompletedTopic,
 		core.AgentCollectorsUpdate,
+		core.CollectionDegraded,
 		core.AgentConfigChanged,
 		core.NginxStatusAPIUpdate,
 		core.NginxPluginConfigured,
@@ -194,7 +207,7 @@ func (m *Metrics) metricsGoroutine() {
 
 			m.pipeline.Process(core.NewMessage(core.MetricReport, report))
 			if m.collectorsUpdate.Load() {
-				m.ticker = time.NewTicker(m.conf.AgentMetrics.CollectionInterval)
+				m.ticker = time.NewTicker(m.collectionInterval())
 				m.collectorsUpdate.Store(false)
 			}
 		case err := <-m.errors:
@@ -203,6 +216,16 @@ func (m *Metrics) metricsGoroutine() {
 	}
 }
 
+// collectionInterval returns the interval collection should tick at, widened
+// while the host is under sustained CPU pressure
+func (m *Metrics) collectionInterval() time.Duration {
+	interval := m.conf.AgentMetrics.CollectionInterval
+	if m.degraded.Load() {
+		interval = interval * degradedCollectionFactor
+	}
+	return interval
+}
+
 func (m *Metrics) collectStats() (stats []*proto.StatsEntity) {
 	// setups a collect duration of half-time of the poll interval
 	ctx, cancel := context.WithTimeout(m.ctx, m.interval/2)
@@ -18,21 +18,39 @@ import (
 	"github.com/nginx/agent/sdk/v2/proto"
 	models "github.com/nginx/agent/sdk/v2/proto/events"
 	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/metrics"
+	"github.com/nginx/agent/v2/src/core/payloads"
 )
 
+// maxDeferredPayloads bounds the reports held back by the bandwidth budget;
+// the oldest are dropped first when the backlog runs over
+const maxDeferredPayloads = 100
+
 type MetricsSender struct {
-	reporter    client.MetricReporter
-	pipeline    core.MessagePipeInterface
-	ctx         context.Context
-	started     *atomic.Bool
-	readyToSend *atomic.Bool
+	reporter         client.MetricReporter
+	pipeline         core.MessagePipeInterface
+	ctx              context.Context
+	started          *atomic.Bool
+	readyToSend      *atomic.Bool
+	amplifyNaming    bool
+	correctClockSkew bool
+	maxMessageSize   int
+	clockSkew        *atomic.Duration
+	budget           *core.BandwidthBudget
+	deferred         []core.Payload
 }
 
-func NewMetricsSender(reporter client.MetricReporter) *MetricsSender {
+func NewMetricsSender(reporter client.MetricReporter, conf *config.Config, budget *core.BandwidthBudget) *MetricsSender {
 	return &MetricsSender{
-		reporter:    reporter,
-		started:     atomic.NewBool(false),
-		readyToSend: atomic.NewBool(false),
+		reporter:         reporter,
+		started:          atomic.NewBool(false),
+		readyToSend:      atomic.NewBool(false),
+		amplifyNaming:    conf.AgentMetrics.Naming == metrics.AmplifyNaming,
+		correctClockSkew: conf.AgentMetrics.CorrectClockSkew,
+		maxMessageSize:   conf.AgentMetrics.MaxMessageSize,
+		clockSkew:        atomic.NewDuration(0),
+		budget:           budget,
 	}
 }
 
@@ -62,44 +80,107 @@ func (r *MetricsSender) Process(msg *core.Message) {
 		return
 	}
 
+	if msg.Exact(core.ClockSkewDetected) {
+		if skew, ok := msg.Data().(*payloads.ClockSkew); ok {
+			r.clockSkew.Store(skew.Offset)
+		}
+		return
+	}
+
 	if msg.Exact(core.CommMetrics) {
-		payloads, ok := msg.Data().([]core.Payload)
+		newPayloads, ok := msg.Data().([]core.Payload)
 		if !ok {
 			log.Warnf("Failed to coerce Message to []Payload: %v", msg.Data())
 			return
 		}
-		for _, p := range payloads {
+		// reports deferred by the bandwidth budget go out first, so ordering is
+		// preserved when the budget recovers. They were already transformed and
+		// split before being held back, so they go straight to the send stage
+		// rather than through the transforms again
+		deferred := r.deferred
+		r.deferred = nil
+		for _, p := range deferred {
+			if !r.readyToSend.Load() {
+				continue
+			}
+			r.sendPrepared(p)
+		}
+
+		for _, p := range newPayloads {
 			if !r.readyToSend.Load() {
 				continue
 			}
 
 			switch report := p.(type) {
 			case *proto.MetricsReport:
-				message := client.MessageFromMetrics(report)
-				err := r.reporter.Send(r.ctx, message)
-
-				if err != nil {
-					log.Errorf("Failed to send MetricsReport: %v, data: %+v", err, report)
-				} else {
-					log.Tracef("MetricsReport sent, %v", report)
+				if r.amplifyNaming {
+					report = metrics.TranslateReportToAmplify(report)
 				}
-			case *models.EventReport:
-				err := r.reporter.Send(r.ctx, client.MessageFromEvents(report))
-				if err != nil {
-					l := len(report.Events)
-					var sb strings.Builder
-					for i := 0; i < l-1; i++ {
-						sb.WriteString(report.Events[i].GetSecurityViolationEvent().SupportID)
-						sb.WriteString(", ")
-					}
-					sb.WriteString(report.Events[l-1].GetSecurityViolationEvent().SupportID)
-					log.Errorf("Failed to send EventReport with error: %v, supportID list: %s", err, sb.String())
+				if skew := r.clockSkew.Load(); skew.Abs() >= metrics.ClockSkewThreshold {
+					report = metrics.ApplyClockSkew(report, skew, r.correctClockSkew)
 				}
+				chunks := metrics.SplitReport(report, r.maxMessageSize)
+				if len(chunks) > 1 {
+					log.Infof("MetricsReport with %d stats entities exceeds the message size limit of %d bytes, split into %d messages", len(report.Data), r.maxMessageSize, len(chunks))
+				}
+				for _, chunk := range chunks {
+					r.sendPrepared(chunk)
+				}
+			case *models.EventReport:
+				r.sendPrepared(report)
 			}
 		}
 	}
 }
 
+// sendPrepared sends a fully prepared report, deferring it when the bandwidth
+// budget is spent. A deferred report is re-checked against the budget on the next
+// cycle as is, so it is never transformed or split twice.
+func (r *MetricsSender) sendPrepared(p core.Payload) {
+	switch report := p.(type) {
+	case *proto.MetricsReport:
+		if !r.budget.Allow(core.BandwidthPriorityMetrics, int64(report.Size())) {
+			r.deferPayload(report)
+			return
+		}
+		message := client.MessageFromMetrics(report)
+		err := r.reporter.Send(r.ctx, message)
+
+		if err != nil {
+			log.Errorf("Failed to send MetricsReport: %v, data: %+v", err, report)
+		} else {
+			log.Tracef("MetricsReport sent, %v", report)
+		}
+	case *models.EventReport:
+		if !r.budget.Allow(core.BandwidthPriorityEvents, int64(report.Size())) {
+			r.deferPayload(report)
+			return
+		}
+		err := r.reporter.Send(r.ctx, client.MessageFromEvents(report))
+		if err != nil {
+			l := len(report.Events)
+			var sb strings.Builder
+			for i := 0; i < l-1; i++ {
+				sb.WriteString(report.Events[i].GetSecurityViolationEvent().SupportID)
+				sb.WriteString(", ")
+			}
+			sb.WriteString(report.Events[l-1].GetSecurityViolationEvent().SupportID)
+			log.Errorf("Failed to send EventReport with error: %v, supportID list: %s", err, sb.String())
+		}
+	}
+}
+
+// deferPayload holds a report back until the bandwidth budget allows sending
+// it, dropping the oldest report once the backlog is full.
+func (r *MetricsSender) deferPayload(p core.Payload) {
+	log.Debugf("Bandwidth budget spent, deferring report of type %T", p)
+	r.deferred = append(r.deferred, p)
+	if len(r.deferred) > maxDeferredPayloads {
+		log.Warnf("Dropping oldest deferred report of type %T, %d reports are already waiting on the bandwidth budget", r.deferred[0], maxDeferredPayloads)
+		r.deferred = r.deferred[1:]
+	}
+}
+
 func (r *MetricsSender) Subscriptions() []string {
-	return []string{core.CommMetrics, core.RegistrationCompletedTopic}
+	return []string{core.CommMetrics, core.RegistrationCompletedTopic, core.ClockSkewDetected}
 }
@@ -16,9 +16,13 @@ import (
 	"github.com/gogo/protobuf/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/nginx/agent/sdk/v2/client"
 	"github.com/nginx/agent/sdk/v2/proto"
 	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/payloads"
 	tutils "github.com/nginx/agent/v2/test/utils"
 )
 
@@ -42,7 +46,7 @@ func TestMetricsSenderSendMetrics(t *testing.T) {
 			ctx := context.TODO()
 			mockMetricsReportClient := tutils.NewMockMetricsReportClient()
 			mockMetricsReportClient.Mock.On("Send", ctx, mock.Anything).Return(test.err)
-			pluginUnderTest := NewMetricsSender(mockMetricsReportClient)
+			pluginUnderTest := NewMetricsSender(mockMetricsReportClient, &config.Config{}, nil)
 
 			assert.False(t, pluginUnderTest.started.Load())
 			assert.False(t, pluginUnderTest.readyToSend.Load())
@@ -74,7 +78,85 @@ func TestMetricsSenderSendMetrics(t *testing.T) {
 	}
 }
 
+func TestMetricsSenderDefersWhenOverBudget(t *testing.T) {
+	ctx := context.TODO()
+	mockMetricsReportClient := tutils.NewMockMetricsReportClient()
+	mockMetricsReportClient.Mock.On("Send", ctx, mock.Anything).Return(nil)
+
+	// a budget too small for any report
+	budget := core.NewBandwidthBudget(1, time.Minute)
+	pluginUnderTest := NewMetricsSender(mockMetricsReportClient, &config.Config{}, budget)
+	pluginUnderTest.Init(core.NewMockMessagePipe(ctx))
+	pluginUnderTest.Process(core.NewMessage(core.RegistrationCompletedTopic, nil))
+	pluginUnderTest.Process(core.NewMessage(core.ClockSkewDetected, &payloads.ClockSkew{Offset: 5 * time.Second}))
+
+	report := &proto.MetricsReport{
+		Meta: &proto.Metadata{Timestamp: types.TimestampNow()},
+		Type: proto.MetricsReport_INSTANCE,
+		Data: []*proto.StatsEntity{{Simplemetrics: []*proto.SimpleMetric{{Name: "Metric A", Value: 5}}}},
+	}
+
+	pluginUnderTest.Process(core.NewMessage(core.CommMetrics, []core.Payload{report}))
+	assert.Len(t, pluginUnderTest.deferred, 1)
+	assert.Equal(t, 0, len(mockMetricsReportClient.Mock.Calls))
+
+	// a second cycle over budget must not run the deferred report through the
+	// transforms again
+	pluginUnderTest.Process(core.NewMessage(core.CommMetrics, []core.Payload{}))
+	assert.Len(t, pluginUnderTest.deferred, 1)
+
+	// once the budget allows it, the deferred report goes out with the next batch
+	budget = core.NewBandwidthBudget(1024, time.Minute)
+	pluginUnderTest.budget = budget
+	pluginUnderTest.Process(core.NewMessage(core.CommMetrics, []core.Payload{}))
+	assert.Empty(t, pluginUnderTest.deferred)
+	assert.True(t, mockMetricsReportClient.AssertNumberOfCalls(t, "Send", 1))
+
+	// the clock skew dimension was applied exactly once despite the deferrals
+	sent, ok := mockMetricsReportClient.Mock.Calls[0].Arguments.Get(1).(client.Message).Raw().(*proto.MetricsReport)
+	require.True(t, ok)
+	skewDimensions := 0
+	for _, dimension := range sent.GetData()[0].GetDimensions() {
+		if dimension.Name == "clock_skew" {
+			skewDimensions++
+		}
+	}
+	assert.Equal(t, 1, skewDimensions)
+
+	pluginUnderTest.Close()
+}
+
+func TestMetricsSenderSplitsLargeReport(t *testing.T) {
+	ctx := context.TODO()
+	mockMetricsReportClient := tutils.NewMockMetricsReportClient()
+	mockMetricsReportClient.Mock.On("Send", ctx, mock.Anything).Return(nil)
+
+	conf := &config.Config{AgentMetrics: config.AgentMetrics{MaxMessageSize: 512}}
+	pluginUnderTest := NewMetricsSender(mockMetricsReportClient, conf, nil)
+	pluginUnderTest.Init(core.NewMockMessagePipe(ctx))
+	pluginUnderTest.Process(core.NewMessage(core.RegistrationCompletedTopic, nil))
+
+	metricData := make([]*proto.StatsEntity, 0, 50)
+	for i := 0; i < 50; i++ {
+		metricData = append(metricData, &proto.StatsEntity{
+			Dimensions:    []*proto.Dimension{{Name: "hostname", Value: "some-very-long-virtual-host-name.example.com"}},
+			Simplemetrics: []*proto.SimpleMetric{{Name: "Metric A", Value: float64(i)}},
+		})
+	}
+	report := &proto.MetricsReport{
+		Meta: &proto.Metadata{Timestamp: types.TimestampNow()},
+		Type: proto.MetricsReport_INSTANCE,
+		Data: metricData,
+	}
+	assert.Greater(t, report.Size(), 512)
+
+	pluginUnderTest.Process(core.NewMessage(core.CommMetrics, []core.Payload{report}))
+
+	assert.Greater(t, len(mockMetricsReportClient.Mock.Calls), 1)
+	pluginUnderTest.Close()
+}
+
 func TestMetricsSenderSubscriptions(t *testing.T) {
-	pluginUnderTest := NewMetricsSender(tutils.NewMockMetricsReportClient())
-	assert.Equal(t, []string{core.CommMetrics, core.RegistrationCompletedTopic}, pluginUnderTest.Subscriptions())
+	pluginUnderTest := NewMetricsSender(tutils.NewMockMetricsReportClient(), &config.Config{}, nil)
+	assert.Equal(t, []string{core.CommMetrics, core.RegistrationCompletedTopic, core.ClockSkewDetected}, pluginUnderTest.Subscriptions())
 }
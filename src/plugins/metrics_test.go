@@ -343,6 +343,7 @@ func TestMetrics_Subscriptions(t *testing.T) {
 	subs := []string{
 		core.RegistrationCompletedTopic,
 		core.AgentCollectorsUpdate,
+		core.CollectionDegraded,
 		core.AgentConfigChanged,
 		core.NginxStatusAPIUpdate,
 		core.NginxPluginConfigured,
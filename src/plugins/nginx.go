@@ -12,7 +12,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gogo/protobuf/types"
@@ -26,8 +30,11 @@ import (
 
 	agent_config "github.com/nginx/agent/sdk/v2/agent/config"
 	"github.com/nginx/agent/sdk/v2/proto"
+	commonProto "github.com/nginx/agent/sdk/v2/proto/common"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
 	"github.com/nginx/agent/v2/src/core"
 	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/tracing"
 	"github.com/nginx/agent/v2/src/extensions/nginx-app-protect/nap"
 )
 
@@ -56,6 +63,10 @@ type Nginx struct {
 	wafLocation                        string
 	wafAttackSignaturesVersion         string
 	wafThreatCampaignsVersion          string
+	tracesMutex                        sync.Mutex
+	// configApplyTraces holds the root span context of each in-flight config
+	// apply, keyed by correlation ID
+	configApplyTraces map[string]context.Context
 }
 
 type ConfigRollbackResponse struct {
@@ -95,6 +106,7 @@ func NewNginx(cmdr client.Commander, nginxBinary core.NginxBinary, env core.Envi
 		isFeatureNginxConfigEnabled:        isFeatureNginxConfigEnabled,
 		configApplyStatusChannel:           make(chan *proto.Command_NginxConfigResponse, 1),
 		wafLocation:                        nap.APP_PROTECT_METADATA_FILE_PATH,
+		configApplyTraces:                  make(map[string]context.Context),
 	}
 }
 
@@ -119,7 +131,12 @@ func (n *Nginx) Process(message *core.Message) {
 		case *proto.Command:
 			n.processCmd(cmd)
 		case *AgentAPIConfigApplyRequest:
+			ctx := tracing.ContextWithTraceparent(context.Background(), cmd.traceparent)
+			n.startConfigApplyTrace(ctx, cmd.correlationId, cmd.config.GetConfigData().GetNginxId())
 			status := n.writeConfigAndReloadNginx(cmd.correlationId, cmd.config, proto.NginxConfigAction_APPLY)
+			if errMessage := status.NginxConfigResponse.GetStatus().GetError(); errMessage != "" {
+				n.endConfigApplyTrace(cmd.correlationId, errors.New(errMessage))
+			}
 			if status.NginxConfigResponse.GetStatus().GetMessage() != configAppliedProcessedResponse {
 				n.messagePipeline.Process(core.NewMessage(core.AgentAPIConfigApplyResponse, status))
 			}
@@ -219,8 +236,13 @@ func (n *Nginx) uploadConfig(config *proto.ConfigDescriptor, messageId string) e
 		return err
 	}
 
+	if n.config.Nginx.LogAccessEvents {
+		n.reportUnreadableLogs(cfg)
+	}
+
 	if n.isNAPEnabled {
 		err = nap.UpdateMetadata(
+			n.nginxBinary.GetParsedConfig(config.GetNginxId()),
 			cfg,
 			n.isNAPPrecompiledPublicationEnabled,
 			n.wafLocation,
@@ -238,6 +260,10 @@ func (n *Nginx) uploadConfig(config *proto.ConfigDescriptor, messageId string) e
 		}
 	}
 
+	// cap the per-directory file listings so a huge static root does not push
+	// the upload past the message size limits
+	sdk.SummarizeDirectoryMap(cfg.GetDirectoryMap(), sdk.DefaultMaxDirectoryMapFiles)
+
 	if err := n.cmdr.Upload(context.Background(), cfg, messageId); err != nil {
 		log.Errorf("Unable to upload nginx config : %v", err)
 		return err
@@ -246,6 +272,75 @@ func (n *Nginx) uploadConfig(config *proto.ConfigDescriptor, messageId string) e
 	return nil
 }
 
+// reportUnreadableLogs publishes a warning event for every log file in the config
+// that the agent cannot read, with a diagnostic of the file's ownership and the
+// remediation that would grant access.
+func (n *Nginx) reportUnreadableLogs(cfg *proto.NginxConfig) {
+	for _, accessLog := range cfg.GetAccessLogs().GetAccessLog() {
+		name := strings.Split(accessLog.GetName(), " ")[0]
+		if accessLog.GetReadable() || name == "off" {
+			continue
+		}
+		n.publishLogAccessEvent("access", name)
+	}
+	for _, errorLog := range cfg.GetErrorLogs().GetErrorLog() {
+		name := strings.Split(errorLog.GetName(), " ")[0]
+		if errorLog.GetReadable() {
+			continue
+		}
+		n.publishLogAccessEvent("error", name)
+	}
+}
+
+func (n *Nginx) publishLogAccessEvent(logType, logFile string) {
+	activityEvent := &eventsProto.ActivityEvent{
+		Message: fmt.Sprintf("nginx %s log %s is not readable by the agent: %s", logType, logFile, sdk.DiagnoseLogAccess(logFile)),
+		Dimensions: []*commonProto.Dimension{
+			{
+				Name:  "system_id",
+				Value: n.env.GetSystemUUID(),
+			},
+			{
+				Name:  "hostname",
+				Value: n.env.GetHostname(),
+			},
+			{
+				Name:  "instance_group",
+				Value: n.config.InstanceGroup,
+			},
+			{
+				Name:  "log_path",
+				Value: logFile,
+			},
+		},
+	}
+
+	event := &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			UUID:          uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Module:        MODULE,
+			Timestamp:     types.TimestampNow(),
+			EventLevel:    WARN_EVENT_LEVEL,
+			Type:          NGINX_EVENT_TYPE,
+			Category:      STATUS_CATEGORY,
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: activityEvent,
+		},
+	}
+
+	n.messagePipeline.Process(core.NewMessage(core.Events, &proto.Command{
+		Meta: grpc.NewMessageMeta(uuid.NewString()),
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_EventReport{
+			EventReport: &eventsProto.EventReport{
+				Events: []*eventsProto.Event{event},
+			},
+		},
+	}))
+}
+
 func (n *Nginx) processDataplaneSoftwareDetails(details *proto.DataplaneSoftwareDetails_AppProtectWafDetails) {
 	log.Tracef("software details updated software %+v", details)
 
@@ -310,18 +405,21 @@ func (n *Nginx) applyConfig(cmd *proto.Command, cfg *proto.Command_NginxConfig)
 		},
 	}
 
+	n.startConfigApplyTrace(context.Background(), cmd.GetMeta().MessageId, cfg.NginxConfig.GetConfigData().GetNginxId())
+
+	endDownload := n.traceConfigApplyStep(cmd.GetMeta().MessageId, "config.download")
 	config, err := n.cmdr.Download(context.Background(), cmd.GetMeta())
+	endDownload(err)
 	if err != nil {
+		n.endConfigApplyTrace(cmd.GetMeta().MessageId, err)
 		status.NginxConfigResponse.Status = newErrStatus("Config apply failed (download): " + err.Error()).CmdStatus
 		return status
 	}
 
-	if err != nil {
-		status.NginxConfigResponse.Status = newErrStatus("Config apply failed: " + err.Error()).CmdStatus
-		return status
-	}
-
 	status = n.writeConfigAndReloadNginx(cmd.Meta.MessageId, config, cmd.GetNginxConfig().GetAction())
+	if errMessage := status.NginxConfigResponse.GetStatus().GetError(); errMessage != "" {
+		n.endConfigApplyTrace(cmd.Meta.MessageId, errors.New(errMessage))
+	}
 
 	log.Debug("Config Apply Complete")
 	return status
@@ -355,25 +453,20 @@ func (n *Nginx) writeConfigAndReloadNginx(correlationId string, config *proto.Ng
 	if action != proto.NginxConfigAction_FORCE {
 		if isNapInPayload(config.GetDirectoryMap(), action, n.wafLocation) {
 			if aux := config.GetZaux(); aux != nil && len(aux.Contents) > 0 {
-				auxFiles, err := zip.UnPack(aux)
+				auxReader, err := zip.NewReader(aux)
 				if err != nil {
 					status.NginxConfigResponse.Status = newErrStatus(fmt.Sprintf("Config apply failed (preflight): not able to read unpack aux files %v", config.GetZaux())).CmdStatus
 					return status
 				}
-				for _, file := range auxFiles {
-					if filepath.Base(file.GetName()) == filepath.Base(n.wafLocation) {
-						var napMetaData nap.Metadata
-
-						err := json.Unmarshal(file.GetContents(), &napMetaData)
-						if err != nil {
-							status.NginxConfigResponse.Status = newErrStatus(fmt.Sprintf("Config apply failed (preflight): not able to read WAF file in metadata %v", config.GetConfigData())).CmdStatus
-							return status
-						}
-						if napMetaData.NapVersion != "" && n.wafVersion != napMetaData.NapVersion {
-							status.NginxConfigResponse.Status = newErrStatus(fmt.Sprintf("Config apply failed (preflight): config metadata mismatch %v", config.GetConfigData())).CmdStatus
-							return status
-						}
-					}
+				napMetaData, err := napMetadataFromAux(auxReader, n.wafLocation)
+				auxReader.Close()
+				if err != nil {
+					status.NginxConfigResponse.Status = newErrStatus(fmt.Sprintf("Config apply failed (preflight): not able to read WAF file in metadata %v", config.GetConfigData())).CmdStatus
+					return status
+				}
+				if napMetaData != nil && napMetaData.NapVersion != "" && n.wafVersion != napMetaData.NapVersion {
+					status.NginxConfigResponse.Status = newErrStatus(fmt.Sprintf("Config apply failed (preflight): config metadata mismatch %v", config.GetConfigData())).CmdStatus
+					return status
 				}
 			}
 		}
@@ -388,7 +481,9 @@ func (n *Nginx) writeConfigAndReloadNginx(correlationId string, config *proto.Ng
 		return n.handleErrorStatus(status, message)
 	}
 
+	endWriteFiles := n.traceConfigApplyStep(correlationId, "config.write-files")
 	configApply, err := n.nginxBinary.WriteConfig(config)
+	endWriteFiles(err)
 	if err != nil {
 		if configApply != nil {
 			succeeded := true
@@ -411,6 +506,31 @@ func (n *Nginx) writeConfigAndReloadNginx(correlationId string, config *proto.Ng
 		return n.handleErrorStatus(status, message)
 	}
 
+	endRender := n.traceConfigApplyStep(correlationId, "config.render-templates")
+	renderErr := n.renderConfigTemplates(config, configApply)
+	endRender(renderErr)
+	if renderErr != nil {
+		if configApply != nil {
+			succeeded := true
+
+			if rollbackErr := configApply.Rollback(renderErr); rollbackErr != nil {
+				log.Errorf("Config rollback failed: %v", rollbackErr)
+				succeeded = false
+			}
+
+			configRollbackResponse := ConfigRollbackResponse{
+				succeeded:     succeeded,
+				correlationId: correlationId,
+				timestamp:     types.TimestampNow(),
+				nginxDetails:  nginx,
+			}
+			n.messagePipeline.Process(core.NewMessage(core.ConfigRollbackResponse, configRollbackResponse))
+		}
+
+		message := fmt.Sprintf("Config apply failed (render): " + renderErr.Error())
+		return n.handleErrorStatus(status, message)
+	}
+
 	go n.validateConfig(nginx, correlationId, config, configApply)
 
 	// If the NGINX config can be validated with the validationTimeout the result will be returned straight away.
@@ -438,16 +558,95 @@ func isNapInPayload(directoryMap *proto.DirectoryMap, action proto.NginxConfigAc
 	return false
 }
 
+// napMetadataFromAux streams the aux payload and decodes just the WAF metadata file,
+// so the preflight check does not unpack the whole payload into memory
+func napMetadataFromAux(auxReader *zip.Reader, wafLocation string) (*nap.Metadata, error) {
+	var metadata *nap.Metadata
+	var metadataErr error
+	auxReader.RangeFileReaders(func(err error, path string, mode os.FileMode, r io.Reader) bool {
+		if err != nil {
+			metadataErr = err
+			return false
+		}
+		if filepath.Base(path) != filepath.Base(wafLocation) {
+			return true
+		}
+		napMetaData := &nap.Metadata{}
+		if metadataErr = json.NewDecoder(r).Decode(napMetaData); metadataErr != nil {
+			return false
+		}
+		metadata = napMetaData
+		return false
+	})
+	if metadataErr != nil {
+		return nil, metadataErr
+	}
+	return metadata, nil
+}
+
+// checkConfigCompatibility lints the config against the version and the compiled-in
+// modules of the instance before nginx -t runs, failing the apply on directives the
+// build cannot know and logging the findings that are only deprecations. A config
+// that cannot be linted is left to nginx -t.
+func (n *Nginx) checkConfigCompatibility(detail *proto.NginxDetails) error {
+	findings, err := sdk.LintConfigCompatibility(detail.GetConfPath(), detail.GetVersion(), detail.GetConfigureArgs())
+	if err != nil {
+		log.Warnf("Unable to lint config compatibility: %v", err)
+		return nil
+	}
+
+	incompatibilities := []string{}
+	for _, finding := range findings {
+		if finding.Severity == sdk.CompatSeverityError {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("%s (%s:%d)", finding.Message, finding.File, finding.Line))
+			continue
+		}
+		log.Warnf("Compatibility lint: %s (%s:%d)", finding.Message, finding.File, finding.Line)
+	}
+	if len(incompatibilities) > 0 {
+		return fmt.Errorf("compatibility lint failed: %s", strings.Join(incompatibilities, "; "))
+	}
+	return nil
+}
+
+// warnMissingModules logs a warning for every module the config loads that is not
+// present on disk, which is the most common reason the validation that follows
+// fails with an obscure message.
+func (n *Nginx) warnMissingModules(confPath string) {
+	modules, err := sdk.CollectLoadedModules(confPath)
+	if err != nil {
+		log.Debugf("Unable to check the loaded modules of %s: %v", confPath, err)
+		return
+	}
+	for _, module := range modules {
+		if !module.GetExists() {
+			log.Warnf("Config references module %s that is not present on disk (%s)", module.GetName(), module.GetPath())
+		}
+	}
+}
+
 // This function will run a nginx config validation in a separate go routine. If the validation takes less than 15 seconds then the result is returned straight away,
 // otherwise nil is returned and the validation continues on in the background until it is complete. The result is always added to the message pipeline for other plugins
 // to use.
 func (n *Nginx) validateConfig(nginx *proto.NginxDetails, correlationId string, config *proto.NginxConfig, configApply *sdk.ConfigApply) {
 	start := time.Now()
 
-	err := n.nginxBinary.ValidateConfig(nginx.NginxId, nginx.ProcessPath, nginx.ConfPath, config, configApply)
+	n.warnMissingModules(nginx.GetConfPath())
+
+	endValidate := n.traceConfigApplyStep(correlationId, "config.validate")
+	err := n.checkConfigCompatibility(nginx)
+	if err == nil {
+		err = n.nginxBinary.ValidateConfig(nginx.NginxId, nginx.ProcessPath, nginx.ConfPath, config, configApply)
+	}
+	if err == nil {
+		if listenErr := n.checkConfigListeners(nginx.GetConfPath()); listenErr != nil {
+			err = fmt.Errorf("listener pre-check failed: %v", listenErr)
+		}
+	}
 	if err == nil {
 		_, err = n.nginxBinary.ReadConfig(nginx.GetConfPath(), config.GetConfigData().GetNginxId(), n.env.GetSystemUUID())
 	}
+	endValidate(err)
 
 	elapsedTime := time.Since(start)
 	log.Tracef("nginx config validation took %s to complete", elapsedTime)
@@ -516,10 +715,16 @@ func (n *Nginx) completeConfigApply(response *NginxConfigValidationResponse) *pr
 	log.Debug("Enabling file watcher")
 	n.messagePipeline.Process(core.NewMessage(core.FileWatcherEnabled, true))
 
+	endReload := n.traceConfigApplyStep(response.correlationId, "config.reload")
+	impact := n.beginReloadImpact(response.nginxDetails)
 	reloadErr := n.nginxBinary.Reload(response.nginxDetails.ProcessId, response.nginxDetails.ProcessPath)
+	endReload(reloadErr)
 	if reloadErr != nil {
 		nginxConfigStatusMessage = fmt.Sprintf("Config apply failed (write): %v", reloadErr)
 		log.Errorf(nginxConfigStatusMessage)
+	} else if summary := impact.measure(n.env); summary != "" {
+		log.Infof("Reload impact for nginx instance %s: %s", response.nginxDetails.GetNginxId(), summary)
+		nginxConfigStatusMessage = fmt.Sprintf("%s (%s)", nginxConfigStatusMessage, summary)
 	}
 
 	nginxReloadEventMeta := NginxReloadResponse{
@@ -558,12 +763,15 @@ func (n *Nginx) completeConfigApply(response *NginxConfigValidationResponse) *pr
 		status.NginxConfigResponse.Status = newOKStatus(agentActivityStatus.GetNginxConfigStatus().GetMessage()).CmdStatus
 	}
 
+	n.endConfigApplyTrace(response.correlationId, reloadErr)
+
 	log.Debug("Config Apply Complete")
 
 	return status
 }
 
 func (n *Nginx) rollbackConfigApply(response *NginxConfigValidationResponse) {
+	n.endConfigApplyTrace(response.correlationId, response.err)
 	nginxConfigStatusMessage := fmt.Sprintf("Config apply failed (write): %v", response.err.Error())
 	log.Error(nginxConfigStatusMessage)
 
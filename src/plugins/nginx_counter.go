@@ -57,7 +57,9 @@ func NewNginxCounter(conf *config.Config, nginxBinary core.NginxBinary, env core
 
 func (nc *NginxCounter) Init(pipeline core.MessagePipeInterface) {
 	log.Infof("NGINX Counter initializing %v", nc.conf.Nginx)
-	nc.serverAddress = strings.Split(nc.conf.Nginx.NginxCountingSocket, ":")
+	// split into network and address only, so tcp addresses with IPv6 literals
+	// like tcp:[::1]:8090 keep their host and port together
+	nc.serverAddress = strings.SplitN(nc.conf.Nginx.NginxCountingSocket, ":", 2)
 	nc.ctx = pipeline.Context()
 	if nc.serverAddress[0] == "unix" {
 		if err := os.RemoveAll(nc.serverAddress[1]); err != nil {
@@ -0,0 +1,81 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nginx/agent/v2/src/core/tracing"
+)
+
+// A config apply crosses goroutines and pipe messages between receiving the
+// command and reloading nginx, so the root span of each apply is kept in a map
+// keyed by correlation ID and the steps look their parent up there. With
+// tracing disabled the global tracer is a no-op and these helpers cost a map
+// access.
+
+// startConfigApplyTrace opens the root span for a config apply, resuming the
+// control-plane trace when ctx carries one. A second start for the same
+// correlation ID joins the span already open.
+func (n *Nginx) startConfigApplyTrace(ctx context.Context, correlationId, nginxId string) {
+	n.tracesMutex.Lock()
+	defer n.tracesMutex.Unlock()
+
+	if _, started := n.configApplyTraces[correlationId]; started {
+		return
+	}
+	ctx, _ = tracing.Tracer().Start(ctx, "config.apply", trace.WithAttributes(
+		attribute.String("correlation_id", correlationId),
+		attribute.String("nginx_id", nginxId),
+	))
+	n.configApplyTraces[correlationId] = ctx
+}
+
+// traceConfigApplyStep opens a child span under the apply's root span and
+// returns the function that closes it with the step's outcome
+func (n *Nginx) traceConfigApplyStep(correlationId, name string) func(error) {
+	n.tracesMutex.Lock()
+	ctx, started := n.configApplyTraces[correlationId]
+	n.tracesMutex.Unlock()
+	if !started {
+		ctx = context.Background()
+	}
+
+	_, span := tracing.Tracer().Start(ctx, name)
+	return func(err error) {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// endConfigApplyTrace closes the apply's root span with its outcome. Calling
+// it again for the same correlation ID is a no-op, so every failure path can
+// end the trace it reached.
+func (n *Nginx) endConfigApplyTrace(correlationId string, err error) {
+	n.tracesMutex.Lock()
+	ctx, started := n.configApplyTraces[correlationId]
+	delete(n.configApplyTraces, correlationId)
+	n.tracesMutex.Unlock()
+	if !started {
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+	span.End()
+}
@@ -0,0 +1,183 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license
+ * found in the LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/objectstore"
+)
+
+// objectStoreConfigKey is the object the poller watches for a new nginx.conf
+// payload, relative to the configured prefix
+const objectStoreConfigKey = "nginx.conf"
+
+// ObjectStore pulls nginx config payloads from an S3-compatible bucket and
+// backs up the applied versions to it, so deployments without a control plane
+// can distribute and archive config through object storage. The config object
+// is polled by ETag and applied through the same in-process request the REST
+// API uses; every successful apply is uploaded under a backup/ prefix keyed by
+// nginx ID and correlation ID.
+type ObjectStore struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	cancel          context.CancelFunc
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	client          *objectstore.Client
+	appliedETag     string
+}
+
+func NewObjectStore(conf *config.Config, env core.Environment, binary core.NginxBinary) *ObjectStore {
+	return &ObjectStore{
+		conf:   conf,
+		env:    env,
+		binary: binary,
+	}
+}
+
+func (o *ObjectStore) Init(pipeline core.MessagePipeInterface) {
+	log.Info("ObjectStore initializing")
+	o.messagePipeline = pipeline
+	o.ctx, o.cancel = context.WithCancel(pipeline.Context())
+
+	client, err := objectstore.NewClient(
+		o.conf.ObjectStore.Endpoint,
+		o.conf.ObjectStore.Region,
+		o.conf.ObjectStore.Bucket,
+		o.conf.ObjectStore.AccessKeyID,
+		o.conf.ObjectStore.SecretAccessKey,
+	)
+	if err != nil {
+		log.Errorf("Unable to set up the object store client: %v", err)
+		return
+	}
+	client.SetSSE(o.conf.ObjectStore.SSE, o.conf.ObjectStore.SSEKMSKeyID)
+	o.client = client
+
+	go o.pollConfig()
+}
+
+func (o *ObjectStore) Close() {
+	log.Info("ObjectStore is wrapping up")
+	if o.cancel != nil {
+		o.cancel()
+	}
+}
+
+func (o *ObjectStore) Info() *core.Info {
+	return core.NewInfo("Object Store", "v0.0.1")
+}
+
+func (o *ObjectStore) Process(msg *core.Message) {
+	switch msg.Topic() {
+	case core.NginxConfigApplySucceeded:
+		if !o.conf.ObjectStore.Backup || o.client == nil {
+			return
+		}
+		if status, ok := msg.Data().(*proto.AgentActivityStatus); ok {
+			o.backupAppliedConfig(status.GetNginxConfigStatus())
+		}
+	}
+}
+
+func (o *ObjectStore) Subscriptions() []string {
+	return []string{
+		core.NginxConfigApplySucceeded,
+	}
+}
+
+// pollConfig watches the config object for changes by ETag and applies every
+// version it has not applied yet, including the one already in the bucket when
+// the agent starts, so a payload uploaded while the agent was down still lands
+func (o *ObjectStore) pollConfig() {
+	key := path.Join(o.conf.ObjectStore.Prefix, objectStoreConfigKey)
+	ticker := time.NewTicker(o.conf.ObjectStore.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			etag, err := o.client.HeadObject(o.ctx, key)
+			if err != nil {
+				log.Debugf("Unable to check the object store config payload: %v", err)
+				continue
+			}
+			if etag == o.appliedETag {
+				continue
+			}
+			if err := o.applyConfigObject(key); err != nil {
+				log.Errorf("Unable to apply the object store config payload: %v", err)
+				continue
+			}
+			o.appliedETag = etag
+		case <-o.ctx.Done():
+			return
+		}
+	}
+}
+
+func (o *ObjectStore) applyConfigObject(key string) error {
+	payload, etag, err := o.client.GetObject(o.ctx, key)
+	if err != nil {
+		return err
+	}
+
+	correlationId := uuid.New().String()
+	log.Infof("Applying config payload %s (ETag %s) from the object store, correlation ID %s", key, etag, correlationId)
+
+	for _, proc := range o.env.Processes() {
+		if !proc.IsMaster {
+			continue
+		}
+		nginxDetail := o.binary.GetNginxDetailsFromProcess(proc)
+		if err := applyRawNginxConfig(o.env, o.binary, o.conf, o.messagePipeline, nginxDetail, payload, correlationId, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backupAppliedConfig uploads the nginx.conf that was just applied, keyed by
+// nginx ID and correlation ID so every applied version stays retrievable
+func (o *ObjectStore) backupAppliedConfig(status *proto.NginxConfigStatus) {
+	for _, proc := range o.env.Processes() {
+		if !proc.IsMaster {
+			continue
+		}
+		nginxDetail := o.binary.GetNginxDetailsFromProcess(proc)
+		if status.GetNginxId() != "" && status.GetNginxId() != nginxDetail.GetNginxId() {
+			continue
+		}
+
+		contents, err := os.ReadFile(nginxDetail.GetConfPath())
+		if err != nil {
+			log.Warnf("Unable to read the applied config for backup: %v", err)
+			continue
+		}
+
+		key := path.Join(o.conf.ObjectStore.Prefix, "backup", nginxDetail.GetNginxId(),
+			fmt.Sprintf("%s-%s.conf", time.Now().UTC().Format("20060102T150405Z"), status.GetCorrelationId()))
+		if err := o.client.PutObject(o.ctx, key, contents); err != nil {
+			log.Warnf("Unable to back up the applied config to the object store: %v", err)
+			continue
+		}
+		log.Infof("Backed up the applied config to %s", key)
+	}
+}
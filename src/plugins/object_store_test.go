@@ -0,0 +1,180 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+// fakeBucket records the objects the plugin uploads and serves the ones the
+// test seeds
+type fakeBucket struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	sse     map[string]string
+}
+
+func (f *fakeBucket) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			contents, _ := io.ReadAll(r.Body)
+			f.objects[r.URL.Path] = contents
+			f.sse[r.URL.Path] = r.Header.Get("x-amz-server-side-encryption")
+		case http.MethodGet, http.MethodHead:
+			contents, found := f.objects[r.URL.Path]
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("ETag", `"etag-1"`)
+			if r.Method == http.MethodGet {
+				w.Write(contents)
+			}
+		}
+	})
+}
+
+func (f *fakeBucket) uploadedKeys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var keys []string
+	for key := range f.objects {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func objectStoreTestConfig(endpoint string) *config.Config {
+	return &config.Config{
+		ObjectStore: config.ObjectStore{
+			Enable:          true,
+			Endpoint:        endpoint,
+			Region:          "us-east-1",
+			Bucket:          "configs",
+			Prefix:          "agent",
+			AccessKeyID:     "access-key",
+			SecretAccessKey: "secret-key",
+			SSE:             "AES256",
+			PollInterval:    time.Hour,
+			Backup:          true,
+		},
+	}
+}
+
+func TestObjectStoreBackup(t *testing.T) {
+	bucket := &fakeBucket{objects: map[string][]byte{}, sse: map[string]string{}}
+	server := httptest.NewServer(bucket.handler())
+	defer server.Close()
+
+	confPath := filepath.Join(t.TempDir(), "nginx.conf")
+	require.NoError(t, os.WriteFile(confPath, []byte("events {}\n"), 0644))
+
+	env := tutils.GetMockEnvWithProcess()
+	binary := tutils.NewMockNginxBinary()
+	binary.On("GetNginxDetailsFromProcess", mock.Anything).Return(&proto.NginxDetails{
+		NginxId: "1", ConfPath: confPath,
+	})
+
+	pluginUnderTest := NewObjectStore(objectStoreTestConfig(server.URL), env, binary)
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.Init(messagePipe)
+	defer pluginUnderTest.Close()
+
+	pluginUnderTest.Process(core.NewMessage(core.NginxConfigApplySucceeded, &proto.AgentActivityStatus{
+		Status: &proto.AgentActivityStatus_NginxConfigStatus{
+			NginxConfigStatus: &proto.NginxConfigStatus{
+				CorrelationId: "correlation-1",
+				Status:        proto.NginxConfigStatus_OK,
+				NginxId:       "1",
+			},
+		},
+	}))
+
+	keys := bucket.uploadedKeys()
+	require.Len(t, keys, 1)
+	assert.True(t, strings.HasPrefix(keys[0], "/configs/agent/backup/1/"), keys[0])
+	assert.True(t, strings.HasSuffix(keys[0], "-correlation-1.conf"), keys[0])
+	assert.Equal(t, "events {}\n", string(bucket.objects[keys[0]]))
+	assert.Equal(t, "AES256", bucket.sse[keys[0]])
+
+	// an apply for a different nginx instance is not backed up again
+	pluginUnderTest.Process(core.NewMessage(core.NginxConfigApplySucceeded, &proto.AgentActivityStatus{
+		Status: &proto.AgentActivityStatus_NginxConfigStatus{
+			NginxConfigStatus: &proto.NginxConfigStatus{
+				CorrelationId: "correlation-2",
+				Status:        proto.NginxConfigStatus_OK,
+				NginxId:       "other",
+			},
+		},
+	}))
+	assert.Len(t, bucket.uploadedKeys(), 1)
+}
+
+func TestObjectStoreApplyConfigObject(t *testing.T) {
+	bucket := &fakeBucket{objects: map[string][]byte{}, sse: map[string]string{}}
+	server := httptest.NewServer(bucket.handler())
+	defer server.Close()
+
+	confPath := filepath.Join(t.TempDir(), "nginx.conf")
+	require.NoError(t, os.WriteFile(confPath, []byte("events {}\n"), 0644))
+	bucket.objects["/configs/agent/nginx.conf"] = []byte("worker_processes 2;\nevents {}\n")
+
+	env := tutils.GetMockEnvWithProcess()
+	env.On("WriteFiles", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	binary := tutils.NewMockNginxBinary()
+	binary.On("GetNginxDetailsFromProcess", mock.Anything).Return(&proto.NginxDetails{
+		NginxId: "1", ConfPath: confPath,
+	})
+	binary.On("ReadConfig", confPath, "1", mock.Anything).Return(&proto.NginxConfig{}, nil)
+
+	pluginUnderTest := NewObjectStore(objectStoreTestConfig(server.URL), env, binary)
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.Init(messagePipe)
+	defer pluginUnderTest.Close()
+
+	require.NoError(t, pluginUnderTest.applyConfigObject("agent/nginx.conf"))
+
+	// the payload was turned into a config apply request for the nginx plugin
+	messages := messagePipe.GetMessages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, core.CommNginxConfig, messages[0].Topic())
+	_, ok := messages[0].Data().(*AgentAPIConfigApplyRequest)
+	assert.True(t, ok)
+
+	// the original nginx.conf was put back after reading the new payload
+	contents, err := os.ReadFile(confPath)
+	require.NoError(t, err)
+	assert.Equal(t, "events {}\n", string(contents))
+}
+
+func TestObjectStoreSubscriptions(t *testing.T) {
+	pluginUnderTest := NewObjectStore(objectStoreTestConfig("http://127.0.0.1:9000"), tutils.GetMockEnv(), tutils.NewMockNginxBinary())
+	assert.Equal(t, []string{core.NginxConfigApplySucceeded}, pluginUnderTest.Subscriptions())
+	assert.Equal(t, "Object Store", pluginUnderTest.Info().Name())
+}
@@ -28,6 +28,9 @@ const (
 	dataplaneSoftwareDetailsMaxWaitTime = time.Duration(5 * time.Second)
 	// Time between attempts to gather DataplaneSoftwareDetails
 	softwareDetailsOperationInterval = time.Duration(1 * time.Second)
+	// Time to wait for a registration response before the state machine moves
+	// to degraded
+	registrationResponseTimeout = time.Duration(2 * time.Minute)
 )
 
 type OneTimeRegistration struct {
@@ -37,10 +40,12 @@ type OneTimeRegistration struct {
 	config                        *config.Config
 	env                           core.Environment
 	host                          *proto.HostInfo
+	hostReady                     chan struct{}
 	binary                        core.NginxBinary
 	dataplaneSoftwareDetails      map[string]*proto.DataplaneSoftwareDetails
 	pipeline                      core.MessagePipeInterface
 	dataplaneSoftwareDetailsMutex sync.Mutex
+	stateMachine                  *registrationStateMachine
 }
 
 func NewOneTimeRegistration(
@@ -50,19 +55,27 @@ func NewOneTimeRegistration(
 	meta *proto.Metadata,
 	version string,
 ) *OneTimeRegistration {
-	// this might be slow so do on startup
-	host := env.NewHostInfo(version, &config.Tags, config.ConfigDirs, true)
-	return &OneTimeRegistration{
+	registration := &OneTimeRegistration{
 		tags:                          &config.Tags,
 		agentVersion:                  version,
 		meta:                          meta,
 		config:                        config,
 		env:                           env,
-		host:                          host,
+		hostReady:                     make(chan struct{}),
 		binary:                        binary,
 		dataplaneSoftwareDetails:      make(map[string]*proto.DataplaneSoftwareDetails),
 		dataplaneSoftwareDetailsMutex: sync.Mutex{},
+		stateMachine:                  newRegistrationStateMachine(),
 	}
+
+	// collecting host info walks disks and interfaces, which can be slow, so it
+	// runs while the rest of the agent starts up; registration waits for it
+	go func() {
+		registration.host = env.NewHostInfo(version, &config.Tags, config.ConfigDirs, true)
+		close(registration.hostReady)
+	}()
+
+	return registration
 }
 
 func (r *OneTimeRegistration) Init(pipeline core.MessagePipeInterface) {
@@ -83,6 +96,10 @@ func (r *OneTimeRegistration) Process(msg *core.Message) {
 	switch {
 	case msg.Exact(core.RegistrationCompletedTopic):
 		log.Info("OneTimeRegistration completed")
+	case msg.Exact(core.AgentConnected):
+		if cmd, ok := msg.Data().(*proto.Command); ok {
+			r.handleConnectResponse(cmd)
+		}
 	case msg.Exact(core.RegisterWithDataplaneSoftwareDetails):
 		switch data := msg.Data().(type) {
 		case *payloads.RegisterWithDataplaneSoftwareDetailsPayload:
@@ -97,6 +114,35 @@ func (r *OneTimeRegistration) Subscriptions() []string {
 	return []string{
 		core.RegistrationCompletedTopic,
 		core.RegisterWithDataplaneSoftwareDetails,
+		core.AgentConnected,
+	}
+}
+
+// setState moves the registration state machine and publishes the transition,
+// so the agent API can expose registration progress.
+func (r *OneTimeRegistration) setState(to payloads.RegistrationState, reason string) {
+	if transition, ok := r.stateMachine.transition(to, reason); ok {
+		r.pipeline.Process(core.NewMessage(core.RegistrationStateChanged, transition))
+	}
+}
+
+// handleConnectResponse resolves the registration state from the connect
+// response of the control plane. A rejection is terminal: the agent stops
+// treating registration as retriable instead of hammering the server.
+func (r *OneTimeRegistration) handleConnectResponse(cmd *proto.Command) {
+	response, ok := cmd.Data.(*proto.Command_AgentConnectResponse)
+	if !ok {
+		return
+	}
+
+	status := response.AgentConnectResponse.GetStatus()
+	switch status.GetStatusCode() {
+	case proto.AgentConnectStatus_CONNECT_REJECTED_OTHER, proto.AgentConnectStatus_CONNECT_REJECTED_DUP_ID:
+		r.setState(payloads.RegistrationAuthRejected,
+			fmt.Sprintf("control plane rejected the registration (%s: %s), registration will not be retried",
+				status.GetStatusCode(), status.GetMessage()))
+	default:
+		r.setState(payloads.RegistrationRegistered, "control plane accepted the registration")
 	}
 }
 
@@ -128,17 +174,12 @@ func (r *OneTimeRegistration) registerAgent() {
 	for _, proc := range r.env.Processes() {
 		// only need master process for registration
 		if proc.IsMaster {
-			nginxDetails := r.binary.GetNginxDetailsFromProcess(proc)
-			details = append(details, nginxDetails)
-			// Reading nginx config during registration to populate nginx fields like access/error logs, etc.
-			_, err := r.binary.ReadConfig(nginxDetails.GetConfPath(), nginxDetails.NginxId, r.env.GetSystemUUID())
-			if err != nil {
-				log.Warnf("Unable to read config for NGINX instance %s, %v", nginxDetails.NginxId, err)
-			}
+			details = append(details, r.binary.GetNginxDetailsFromProcess(proc))
 		} else {
 			log.Tracef("NGINX non-master process: %d", proc.Pid)
 		}
 	}
+
 	if len(details) == 0 {
 		log.Info("No master process found")
 	}
@@ -148,6 +189,11 @@ func (r *OneTimeRegistration) registerAgent() {
 		updated = types.TimestampNow()
 	}
 	log.Infof("Registering %s", r.env.GetSystemUUID())
+	r.setState(payloads.RegistrationAuthenticating, "assembling connect request")
+
+	// host info is collected in the background from the constructor, and it is
+	// the only remaining piece the connect request waits for
+	<-r.hostReady
 
 	agentConnectRequest := &proto.Command{
 		Meta: r.meta,
@@ -162,6 +208,7 @@ func (r *OneTimeRegistration) registerAgent() {
 					InstanceGroup: r.config.InstanceGroup,
 					Updated:       updated,
 					SystemUid:     r.env.GetSystemUUID(),
+					Labels:        r.config.Labels,
 					AgentDetails: &proto.AgentDetails{
 						Features:   r.config.Features,
 						Extensions: r.config.Extensions,
@@ -171,6 +218,7 @@ func (r *OneTimeRegistration) registerAgent() {
 				},
 				Details:                  details,
 				DataplaneSoftwareDetails: r.dataplaneSoftwareDetailsSlice(),
+				Maturity:                 maturityReport(r.config),
 			},
 		},
 	}
@@ -181,6 +229,26 @@ func (r *OneTimeRegistration) registerAgent() {
 		core.NewMessage(core.CommRegister, agentConnectRequest),
 		core.NewMessage(core.RegistrationCompletedTopic, nil),
 	)
+	r.setState(payloads.RegistrationRegistering, "connect request sent")
+
+	time.AfterFunc(registrationResponseTimeout, func() {
+		if r.stateMachine.current() == payloads.RegistrationRegistering {
+			r.setState(payloads.RegistrationDegraded,
+				fmt.Sprintf("no registration response within %s", registrationResponseTimeout))
+		}
+	})
+
+	// Reading the nginx configs populates nginx fields like access/error logs, but
+	// walks the whole config payload, so it happens once the connect request is on
+	// its way rather than on the critical path to registration.
+	go func() {
+		for _, nginxDetails := range details {
+			_, err := r.binary.ReadConfig(nginxDetails.GetConfPath(), nginxDetails.NginxId, r.env.GetSystemUUID())
+			if err != nil {
+				log.Warnf("Unable to read config for NGINX instance %s, %v", nginxDetails.NginxId, err)
+			}
+		}
+	}()
 }
 
 // waitAndRegister checks in a retry loop if the plugins enabled that transmit dataplane
@@ -255,6 +323,26 @@ func (r *OneTimeRegistration) dataplaneSoftwareDetailsSlice() []*proto.Dataplane
 	return allDetails
 }
 
+// maturityReport converts the maturity notices of the loaded configuration into
+// their protobuf form, so the management server can audit experimental and
+// deprecated usage across a fleet.
+func maturityReport(conf *config.Config) []*proto.AgentMaturity {
+	notices := config.MaturityNotices(conf)
+	report := make([]*proto.AgentMaturity, 0, len(notices))
+	for _, notice := range notices {
+		level := proto.AgentMaturity_EXPERIMENTAL
+		if notice.Level == config.MaturityDeprecated {
+			level = proto.AgentMaturity_DEPRECATED
+		}
+		report = append(report, &proto.AgentMaturity{
+			Name:    notice.Name,
+			Level:   level,
+			Message: notice.Message,
+		})
+	}
+	return report
+}
+
 // getPluginsReportingDataplaneSoftwareDetails returns a list of plugin names that
 // are enabled which transmit dataplane software details based off the config passed.
 func getPluginsReportingDataplaneSoftwareDetails(conf config.Config) []string {
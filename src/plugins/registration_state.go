@@ -0,0 +1,74 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nginx/agent/v2/src/core/payloads"
+	log "github.com/sirupsen/logrus"
+)
+
+// registrationTransitions is the set of allowed state changes. A state that is
+// absent, like auth_rejected, is terminal.
+var registrationTransitions = map[payloads.RegistrationState][]payloads.RegistrationState{
+	payloads.RegistrationConnecting:     {payloads.RegistrationAuthenticating, payloads.RegistrationDegraded},
+	payloads.RegistrationAuthenticating: {payloads.RegistrationRegistering, payloads.RegistrationDegraded},
+	payloads.RegistrationRegistering:    {payloads.RegistrationRegistered, payloads.RegistrationDegraded, payloads.RegistrationAuthRejected},
+	payloads.RegistrationDegraded:       {payloads.RegistrationRegistered, payloads.RegistrationAuthRejected},
+	payloads.RegistrationRegistered:     {payloads.RegistrationDegraded},
+}
+
+// registrationStateMachine tracks which explicit state registration is in, so
+// failures show up as a state instead of opaque log spam.
+type registrationStateMachine struct {
+	mu    sync.Mutex
+	state payloads.RegistrationState
+}
+
+func newRegistrationStateMachine() *registrationStateMachine {
+	return &registrationStateMachine{state: payloads.RegistrationConnecting}
+}
+
+// current returns the state the machine is in.
+func (m *registrationStateMachine) current() payloads.RegistrationState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state
+}
+
+// transition moves the machine to the given state and returns the transition
+// for publishing. Disallowed transitions, including any attempt to leave a
+// terminal state, are rejected and leave the machine unchanged.
+func (m *registrationStateMachine) transition(to payloads.RegistrationState, reason string) (*payloads.RegistrationStateTransition, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	allowed := false
+	for _, next := range registrationTransitions[m.state] {
+		if next == to {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		log.Debugf("Registration state transition %s -> %s not allowed, staying in %s", m.state, to, m.state)
+		return nil, false
+	}
+
+	transition := &payloads.RegistrationStateTransition{
+		From:      m.state,
+		To:        to,
+		Reason:    reason,
+		Timestamp: time.Now(),
+	}
+	log.Infof("Registration state changed from %s to %s: %s", transition.From, transition.To, reason)
+	m.state = to
+	return transition, true
+}
@@ -0,0 +1,81 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"testing"
+
+	"github.com/nginx/agent/v2/src/core/payloads"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistrationStateMachine_HappyPath(t *testing.T) {
+	machine := newRegistrationStateMachine()
+	assert.Equal(t, payloads.RegistrationConnecting, machine.current())
+
+	transition, ok := machine.transition(payloads.RegistrationAuthenticating, "assembling connect request")
+	assert.True(t, ok)
+	assert.Equal(t, payloads.RegistrationConnecting, transition.From)
+	assert.Equal(t, payloads.RegistrationAuthenticating, transition.To)
+	assert.Equal(t, "assembling connect request", transition.Reason)
+	assert.False(t, transition.Timestamp.IsZero())
+
+	_, ok = machine.transition(payloads.RegistrationRegistering, "connect request sent")
+	assert.True(t, ok)
+
+	_, ok = machine.transition(payloads.RegistrationRegistered, "control plane accepted the registration")
+	assert.True(t, ok)
+	assert.Equal(t, payloads.RegistrationRegistered, machine.current())
+}
+
+func TestRegistrationStateMachine_InvalidTransition(t *testing.T) {
+	machine := newRegistrationStateMachine()
+
+	transition, ok := machine.transition(payloads.RegistrationRegistered, "skipping ahead")
+	assert.False(t, ok)
+	assert.Nil(t, transition)
+	assert.Equal(t, payloads.RegistrationConnecting, machine.current())
+}
+
+func TestRegistrationStateMachine_AuthRejectedIsTerminal(t *testing.T) {
+	machine := newRegistrationStateMachine()
+
+	_, ok := machine.transition(payloads.RegistrationAuthenticating, "assembling connect request")
+	assert.True(t, ok)
+	_, ok = machine.transition(payloads.RegistrationRegistering, "connect request sent")
+	assert.True(t, ok)
+	_, ok = machine.transition(payloads.RegistrationAuthRejected, "control plane rejected the registration")
+	assert.True(t, ok)
+
+	for _, to := range []payloads.RegistrationState{
+		payloads.RegistrationConnecting,
+		payloads.RegistrationAuthenticating,
+		payloads.RegistrationRegistering,
+		payloads.RegistrationRegistered,
+		payloads.RegistrationDegraded,
+	} {
+		_, ok = machine.transition(to, "retrying")
+		assert.False(t, ok)
+	}
+	assert.Equal(t, payloads.RegistrationAuthRejected, machine.current())
+}
+
+func TestRegistrationStateMachine_DegradedRecovers(t *testing.T) {
+	machine := newRegistrationStateMachine()
+
+	_, ok := machine.transition(payloads.RegistrationAuthenticating, "assembling connect request")
+	assert.True(t, ok)
+	_, ok = machine.transition(payloads.RegistrationRegistering, "connect request sent")
+	assert.True(t, ok)
+	_, ok = machine.transition(payloads.RegistrationDegraded, "no registration response")
+	assert.True(t, ok)
+
+	_, ok = machine.transition(payloads.RegistrationRegistered, "control plane accepted the registration")
+	assert.True(t, ok)
+	assert.Equal(t, payloads.RegistrationRegistered, machine.current())
+}
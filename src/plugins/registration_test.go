@@ -18,6 +18,7 @@ import (
 	"github.com/nginx/agent/sdk/v2/proto"
 	"github.com/nginx/agent/v2/src/core"
 	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/payloads"
 
 	tutils "github.com/nginx/agent/v2/test/utils"
 )
@@ -29,7 +30,7 @@ func TestRegistration_Process(t *testing.T) {
 	}{
 		{
 			name:                 "test registration",
-			expectedMessageCount: 2,
+			expectedMessageCount: 4,
 		},
 	}
 	t.Parallel()
@@ -60,12 +61,17 @@ func TestRegistration_Process(t *testing.T) {
 			messages := messagePipe.GetProcessedMessages()
 			assert.Len(tt, messages, test.expectedMessageCount)
 
-			assert.Equal(tt, messages[0].Topic(), core.CommRegister)
+			assert.Equal(tt, messages[0].Topic(), core.RegistrationStateChanged)
+
+			assert.Equal(tt, messages[1].Topic(), core.CommRegister)
 			// host info checked elsewhere
-			assert.NotNil(tt, messages[0].Data())
+			assert.NotNil(tt, messages[1].Data())
+
+			assert.Equal(tt, messages[2].Topic(), core.RegistrationCompletedTopic)
+			assert.Nil(tt, messages[2].Data())
 
-			assert.Equal(tt, messages[1].Topic(), core.RegistrationCompletedTopic)
-			assert.Nil(tt, messages[1].Data())
+			assert.Equal(tt, messages[3].Topic(), core.RegistrationStateChanged)
+			assert.Equal(tt, payloads.RegistrationRegistering, pluginUnderTest.stateMachine.current())
 		})
 	}
 }
@@ -82,7 +88,7 @@ func TestRegistration_DataplaneReady(t *testing.T) {
 func TestRegistration_Subscriptions(t *testing.T) {
 	pluginUnderTest := NewOneTimeRegistration(tutils.GetMockAgentConfig(), nil, tutils.GetMockEnv(), nil, "")
 
-	assert.Equal(t, []string{core.RegistrationCompletedTopic, core.RegisterWithDataplaneSoftwareDetails}, pluginUnderTest.Subscriptions())
+	assert.Equal(t, []string{core.RegistrationCompletedTopic, core.RegisterWithDataplaneSoftwareDetails, core.AgentConnected}, pluginUnderTest.Subscriptions())
 }
 
 func TestRegistration_Info(t *testing.T) {
@@ -0,0 +1,109 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	psnet "github.com/shirou/gopsutil/v3/net"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+)
+
+const (
+	reloadImpactTimeout      = 10 * time.Second
+	reloadImpactPollInterval = 200 * time.Millisecond
+)
+
+// reloadImpact tracks the workers of an instance across a reload so the apply
+// result can say how long the old workers took to shut down and roughly how many
+// connections that cut off. The connection count is an estimate: each old worker's
+// established connections are sampled while it drains, and whatever a worker still
+// held at the last sample before it exited is counted as dropped. A worker that
+// drains gracefully runs that number down to zero on its own, so the residue
+// approximates what worker_shutdown_timeout or a kill force-closed.
+type reloadImpact struct {
+	started time.Time
+	// last observed established connection count per old worker pid
+	conns map[int32]int
+}
+
+// beginReloadImpact snapshots the current workers of the instance that is about to
+// be reloaded, keyed off the master pid so workers of other instances on the host
+// are left out.
+func (n *Nginx) beginReloadImpact(detail *proto.NginxDetails) *reloadImpact {
+	impact := &reloadImpact{
+		started: time.Now(),
+		conns:   make(map[int32]int),
+	}
+
+	masterPid, err := strconv.ParseInt(detail.GetProcessId(), 10, 32)
+	if err != nil {
+		return impact
+	}
+
+	for _, process := range n.env.Processes() {
+		if process.IsMaster || process.ParentPid != int32(masterPid) {
+			continue
+		}
+		impact.conns[process.Pid] = countEstablishedConnections(process.Pid)
+	}
+	return impact
+}
+
+// measure waits, bounded by reloadImpactTimeout, for the old workers captured at
+// the start of the reload to exit and returns a one line summary for the apply
+// result. An empty string means there was nothing to measure.
+func (impact *reloadImpact) measure(env core.Environment) string {
+	if len(impact.conns) == 0 {
+		return ""
+	}
+
+	deadline := impact.started.Add(reloadImpactTimeout)
+	for {
+		alive := map[int32]struct{}{}
+		for _, process := range env.Processes() {
+			if _, ok := impact.conns[process.Pid]; ok {
+				alive[process.Pid] = struct{}{}
+			}
+		}
+		for pid := range alive {
+			impact.conns[pid] = countEstablishedConnections(pid)
+		}
+
+		if len(alive) == 0 {
+			dropped := 0
+			for _, count := range impact.conns {
+				dropped += count
+			}
+			return fmt.Sprintf("old workers exited in %s, %d connections dropped",
+				time.Since(impact.started).Round(time.Millisecond), dropped)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Sprintf("%d old workers still shutting down after %s", len(alive), reloadImpactTimeout)
+		}
+		time.Sleep(reloadImpactPollInterval)
+	}
+}
+
+func countEstablishedConnections(pid int32) int {
+	connections, err := psnet.ConnectionsPid("tcp", pid)
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, connection := range connections {
+		if connection.Status == "ESTABLISHED" {
+			count++
+		}
+	}
+	return count
+}
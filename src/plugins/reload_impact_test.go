@@ -0,0 +1,63 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func TestReloadImpactMeasure(t *testing.T) {
+	withWorkers := []core.Process{
+		{Pid: 1, Name: "12345", IsMaster: true},
+		{Pid: 1000001, ParentPid: 1, Name: "worker-1", IsMaster: false},
+		{Pid: 1000002, ParentPid: 1, Name: "worker-2", IsMaster: false},
+	}
+	masterOnly := []core.Process{
+		{Pid: 1, Name: "12345", IsMaster: true},
+	}
+
+	env := tutils.NewMockEnvironment()
+	// the constructor reads the process list once before the impact snapshot does
+	env.On("Processes", mock.Anything).Return(withWorkers).Times(2)
+	env.On("Processes", mock.Anything).Return(masterOnly)
+
+	pluginUnderTest := NewNginx(tutils.GetMockCommandClient(nil), tutils.GetMockNginxBinary(), env, &config.Config{})
+
+	impact := pluginUnderTest.beginReloadImpact(&proto.NginxDetails{NginxId: "12345", ProcessId: "1"})
+	assert.Len(t, impact.conns, 2)
+
+	start := time.Now()
+	summary := impact.measure(env)
+	assert.Contains(t, summary, "old workers exited in")
+	assert.Contains(t, summary, "0 connections dropped")
+	// the workers were already gone on the first poll, so no waiting happened
+	assert.Less(t, time.Since(start), reloadImpactTimeout)
+}
+
+func TestReloadImpactNoWorkers(t *testing.T) {
+	env := tutils.NewMockEnvironment()
+	env.On("Processes", mock.Anything).Return([]core.Process{})
+
+	pluginUnderTest := NewNginx(tutils.GetMockCommandClient(nil), tutils.GetMockNginxBinary(), env, &config.Config{})
+
+	impact := pluginUnderTest.beginReloadImpact(&proto.NginxDetails{NginxId: "12345", ProcessId: "1"})
+	assert.Empty(t, impact.measure(env))
+
+	// an unparsable master pid leaves nothing to measure rather than failing the apply
+	impact = pluginUnderTest.beginReloadImpact(&proto.NginxDetails{NginxId: "12345", ProcessId: "not-a-pid"})
+	assert.Empty(t, impact.measure(env))
+}
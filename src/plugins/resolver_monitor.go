@@ -0,0 +1,290 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	sdk "github.com/nginx/agent/sdk/v2"
+	"github.com/nginx/agent/sdk/v2/proto"
+	commonProto "github.com/nginx/agent/sdk/v2/proto/common"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/metrics"
+)
+
+const (
+	RESOLVER_FAILURE_MESSAGE   = "resolution of upstream host %s failed via %s: %v"
+	RESOLVER_RECOVERED_MESSAGE = "resolution of upstream host %s recovered via %s"
+
+	defaultMinResolverInterval = 30 * time.Second
+	resolverLookupTimeout      = 5 * time.Second
+	systemResolverLabel        = "system resolver"
+)
+
+// ResolverMonitor periodically resolves the upstream hostnames found in the config of
+// each discovered nginx instance, through the configured resolver directives when
+// there are any, and reports lookup latency and failure metrics. A hostname that
+// stops resolving raises an error event, since broken resolution is a common silent
+// cause of 502s, and a recovery is reported once it resolves again.
+type ResolverMonitor struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	meta            *proto.Metadata
+	ticker          *time.Ticker
+	wg              sync.WaitGroup
+	detailsMutex    sync.Mutex
+	nginxDetails    map[string]*proto.NginxDetails
+	failing         map[string]bool
+}
+
+func NewResolverMonitor(conf *config.Config, env core.Environment, binary core.NginxBinary, meta *proto.Metadata) *ResolverMonitor {
+	interval := conf.ResolverHealth.Interval
+	if interval < defaultMinResolverInterval {
+		log.Warnf("resolver health interval set to %s, provided value (%s) less than minimum", defaultMinResolverInterval, interval)
+		interval = defaultMinResolverInterval
+	}
+	return &ResolverMonitor{
+		conf:         conf,
+		env:          env,
+		binary:       binary,
+		meta:         meta,
+		ticker:       time.NewTicker(interval),
+		nginxDetails: make(map[string]*proto.NginxDetails),
+		failing:      make(map[string]bool),
+	}
+}
+
+func (rm *ResolverMonitor) Init(pipeline core.MessagePipeInterface) {
+	log.Info("ResolverMonitor initializing")
+	rm.messagePipeline = pipeline
+	rm.ctx = pipeline.Context()
+
+	rm.wg.Add(1)
+	go func() {
+		defer rm.wg.Done()
+		for {
+			select {
+			case <-rm.ticker.C:
+				rm.checkResolution()
+			case <-rm.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (rm *ResolverMonitor) Close() {
+	log.Info("ResolverMonitor is wrapping up")
+	rm.ticker.Stop()
+}
+
+func (rm *ResolverMonitor) Info() *core.Info {
+	return core.NewInfo("ResolverMonitor", "v0.0.1")
+}
+
+func (rm *ResolverMonitor) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.NginxInstancesFound):
+		nginxDetailsMap, ok := msg.Data().(map[string]*proto.NginxDetails)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		rm.detailsMutex.Lock()
+		rm.nginxDetails = nginxDetailsMap
+		rm.detailsMutex.Unlock()
+	}
+}
+
+func (rm *ResolverMonitor) Subscriptions() []string {
+	return []string{
+		core.NginxInstancesFound,
+	}
+}
+
+// checkResolution resolves the upstream hostnames of each known nginx instance and
+// reports the lookup counts, failures and latency as metrics. A hostname is only
+// reported as an event when its state changes, so a host that stays broken does not
+// raise an error on every tick.
+func (rm *ResolverMonitor) checkResolution() {
+	rm.detailsMutex.Lock()
+	details := make(map[string]*proto.NginxDetails, len(rm.nginxDetails))
+	for nginxID, detail := range rm.nginxDetails {
+		details[nginxID] = detail
+	}
+	rm.detailsMutex.Unlock()
+
+	for nginxID, detail := range details {
+		targets, err := sdk.CollectResolutionTargets(detail.GetConfPath())
+		if err != nil {
+			log.Warnf("ResolverMonitor: unable to parse config for nginx instance %s: %v", nginxID, err)
+			continue
+		}
+		if len(targets.Hostnames) == 0 {
+			continue
+		}
+
+		resolver, resolverLabel := rm.resolverFor(targets.Resolvers)
+
+		var lookups, failures float64
+		var totalLatency time.Duration
+		events := []*eventsProto.Event{}
+
+		for _, host := range targets.Hostnames {
+			lookups++
+			latency, err := rm.lookup(resolver, host)
+			key := nginxID + ":" + host
+
+			if err != nil {
+				failures++
+				if !rm.failing[key] {
+					rm.failing[key] = true
+					events = append(events, rm.createResolverEvent(nginxID, ERROR_EVENT_LEVEL, fmt.Sprintf(RESOLVER_FAILURE_MESSAGE, host, resolverLabel, err)))
+				}
+				continue
+			}
+
+			totalLatency += latency
+			if rm.failing[key] {
+				rm.failing[key] = false
+				events = append(events, rm.createResolverEvent(nginxID, INFO_EVENT_LEVEL, fmt.Sprintf(RESOLVER_RECOVERED_MESSAGE, host, resolverLabel)))
+			}
+		}
+
+		rm.messagePipeline.Process(core.NewMessage(core.MetricReport, rm.buildMetricReport(nginxID, lookups, failures, totalLatency)))
+
+		if len(events) > 0 {
+			rm.messagePipeline.Process(core.NewMessage(core.Events, &proto.Command{
+				Meta: rm.meta,
+				Type: proto.Command_NORMAL,
+				Data: &proto.Command_EventReport{
+					EventReport: &eventsProto.EventReport{
+						Events: events,
+					},
+				},
+			}))
+		}
+	}
+}
+
+// resolverFor returns a resolver that queries the first configured resolver
+// directive address, or the system resolver when the config does not set one, which
+// matches where nginx itself would send its queries.
+func (rm *ResolverMonitor) resolverFor(resolvers []string) (*net.Resolver, string) {
+	if len(resolvers) == 0 {
+		return net.DefaultResolver, systemResolverLabel
+	}
+
+	address := resolvers[0]
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(strings.Trim(address, "[]"), "53")
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := net.Dialer{}
+			return dialer.DialContext(ctx, network, address)
+		},
+	}, address
+}
+
+func (rm *ResolverMonitor) lookup(resolver *net.Resolver, host string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(rm.ctx, resolverLookupTimeout)
+	defer cancel()
+
+	start := time.Now()
+	addresses, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		return 0, err
+	}
+	if len(addresses) == 0 {
+		return 0, fmt.Errorf("no addresses returned for %s", host)
+	}
+	return time.Since(start), nil
+}
+
+func (rm *ResolverMonitor) buildMetricReport(nginxID string, lookups, failures float64, totalLatency time.Duration) *proto.MetricsReport {
+	samples := []*proto.SimpleMetric{
+		{Name: "resolver.lookups", Value: lookups},
+		{Name: "resolver.lookup.failures", Value: failures},
+	}
+	if successes := lookups - failures; successes > 0 {
+		samples = append(samples, &proto.SimpleMetric{
+			Name:  "resolver.lookup.time",
+			Value: float64(totalLatency.Milliseconds()) / successes,
+		})
+	}
+
+	dimensions := []*proto.Dimension{
+		{Name: "system_id", Value: rm.env.GetSystemUUID()},
+		{Name: "hostname", Value: rm.env.GetHostname()},
+		{Name: "nginx_id", Value: nginxID},
+	}
+
+	return &proto.MetricsReport{
+		Meta: &proto.Metadata{
+			Timestamp: types.TimestampNow(),
+		},
+		Type: proto.MetricsReport_SYSTEM,
+		Data: []*proto.StatsEntity{metrics.NewStatsEntity(dimensions, samples)},
+	}
+}
+
+func (rm *ResolverMonitor) createResolverEvent(nginxID, level, message string) *eventsProto.Event {
+	activityEvent := &eventsProto.ActivityEvent{
+		Message: message,
+		Dimensions: []*commonProto.Dimension{
+			{
+				Name:  "nginx_id",
+				Value: nginxID,
+			},
+			{
+				Name:  "system_id",
+				Value: rm.env.GetSystemUUID(),
+			},
+			{
+				Name:  "hostname",
+				Value: rm.env.GetHostname(),
+			},
+			{
+				Name:  "instance_group",
+				Value: rm.conf.InstanceGroup,
+			},
+		},
+	}
+
+	return &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			UUID:          uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Module:        MODULE,
+			Timestamp:     types.TimestampNow(),
+			EventLevel:    level,
+			Type:          NGINX_EVENT_TYPE,
+			Category:      RESOLVER_CATEGORY,
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: activityEvent,
+		},
+	}
+}
@@ -0,0 +1,131 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func TestResolverMonitor_checkResolution(t *testing.T) {
+	confFile := filepath.Join(t.TempDir(), "nginx.conf")
+	conf := `
+http {
+    upstream backend {
+        server localhost:8080;
+        server does-not-exist.invalid:8080;
+    }
+}
+`
+	require.NoError(t, os.WriteFile(confFile, []byte(conf), 0644))
+
+	details := map[string]*proto.NginxDetails{
+		"12345": {
+			NginxId:  "12345",
+			ConfPath: confFile,
+		},
+	}
+
+	monitor := NewResolverMonitor(&config.Config{ResolverHealth: config.Defaults.ResolverHealth}, tutils.GetMockEnv(), tutils.GetMockNginxBinary(), &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), monitor)
+	monitor.messagePipeline = messagePipe
+	monitor.ctx = messagePipe.Context()
+
+	monitor.Process(core.NewMessage(core.NginxInstancesFound, details))
+	monitor.checkResolution()
+
+	var reports []*proto.MetricsReport
+	levels := []string{}
+	for _, msg := range messagePipe.GetMessages() {
+		switch msg.Topic() {
+		case core.MetricReport:
+			reports = append(reports, msg.Data().(*proto.MetricsReport))
+		case core.Events:
+			cmd := msg.Data().(*proto.Command)
+			for _, event := range cmd.GetEventReport().GetEvents() {
+				assert.Equal(t, RESOLVER_CATEGORY, event.GetMetadata().GetCategory())
+				levels = append(levels, event.GetMetadata().GetEventLevel())
+			}
+		}
+	}
+
+	require.Len(t, reports, 1)
+	samples := map[string]float64{}
+	for _, metric := range reports[0].GetData()[0].GetSimplemetrics() {
+		samples[metric.Name] = metric.Value
+	}
+	assert.Equal(t, float64(2), samples["resolver.lookups"])
+	assert.Equal(t, float64(1), samples["resolver.lookup.failures"])
+
+	// only the broken hostname raises an event, and only on the transition
+	assert.Equal(t, []string{ERROR_EVENT_LEVEL}, levels)
+
+	messagePipe.ClearMessages()
+	monitor.checkResolution()
+	for _, msg := range messagePipe.GetMessages() {
+		assert.NotEqual(t, core.Events, msg.Topic())
+	}
+}
+
+func TestResolverMonitor_recovery(t *testing.T) {
+	confFile := filepath.Join(t.TempDir(), "nginx.conf")
+	conf := `
+http {
+    upstream backend {
+        server localhost:8080;
+    }
+}
+`
+	require.NoError(t, os.WriteFile(confFile, []byte(conf), 0644))
+
+	details := map[string]*proto.NginxDetails{
+		"12345": {
+			NginxId:  "12345",
+			ConfPath: confFile,
+		},
+	}
+
+	monitor := NewResolverMonitor(&config.Config{ResolverHealth: config.Defaults.ResolverHealth}, tutils.GetMockEnv(), tutils.GetMockNginxBinary(), &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), monitor)
+	monitor.messagePipeline = messagePipe
+	monitor.ctx = messagePipe.Context()
+
+	// the hostname was failing on a previous tick and resolves again now
+	monitor.failing["12345:localhost"] = true
+
+	monitor.Process(core.NewMessage(core.NginxInstancesFound, details))
+	monitor.checkResolution()
+
+	levels := []string{}
+	for _, msg := range messagePipe.GetMessages() {
+		if msg.Topic() != core.Events {
+			continue
+		}
+		cmd := msg.Data().(*proto.Command)
+		for _, event := range cmd.GetEventReport().GetEvents() {
+			levels = append(levels, event.GetMetadata().GetEventLevel())
+		}
+	}
+	assert.Equal(t, []string{INFO_EVENT_LEVEL}, levels)
+	assert.False(t, monitor.failing["12345:localhost"])
+}
+
+func TestResolverMonitorSubscriptions(t *testing.T) {
+	monitor := NewResolverMonitor(&config.Config{}, tutils.GetMockEnv(), tutils.GetMockNginxBinary(), &proto.Metadata{MessageId: "123"})
+	assert.Equal(t, []string{core.NginxInstancesFound}, monitor.Subscriptions())
+}
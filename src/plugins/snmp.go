@@ -0,0 +1,163 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/agentx"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+// snmpObject ties an OID under the configured subtree to the metrics that
+// feed it. Metrics are matched by name suffix so the mapping holds for both
+// the OSS and the Plus namespace. Counter objects accumulate the per-interval
+// deltas the metrics pipeline reports into the monotonic values SNMP expects;
+// gauge objects track the latest reported value.
+type snmpObject struct {
+	subID        uint32
+	valueType    uint16
+	metricSuffix string
+}
+
+// snmpObjects is the fixed schema of the subtree, covering connections,
+// requests and upstream peer health
+var snmpObjects = []snmpObject{
+	{1, agentx.TypeGauge32, ".http.conn.active"},
+	{2, agentx.TypeCounter64, ".http.conn.accepted"},
+	{3, agentx.TypeCounter64, ".http.conn.dropped"},
+	{4, agentx.TypeCounter64, ".http.request.count"},
+	{5, agentx.TypeGauge32, ".http.request.current"},
+	{6, agentx.TypeGauge32, ".http.upstream.peers.total.up"},
+	{7, agentx.TypeGauge32, ".http.upstream.peers.total.down"},
+	{8, agentx.TypeGauge32, ".http.upstream.peers.total.unavail"},
+}
+
+// SNMP runs an AgentX sub-agent that exposes key nginx metrics to a master
+// SNMP agent, for deployments that still monitor over SNMP. The values are
+// derived from the reports the metrics pipeline already produces, so no extra
+// collection happens on the dataplane.
+type SNMP struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	conf   *config.Config
+	mu     sync.Mutex
+	values map[uint32]uint64
+}
+
+func NewSNMP(conf *config.Config) *SNMP {
+	return &SNMP{
+		conf:   conf,
+		values: make(map[uint32]uint64),
+	}
+}
+
+func (s *SNMP) Init(pipeline core.MessagePipeInterface) {
+	log.Info("SNMP initializing")
+	s.ctx, s.cancel = context.WithCancel(pipeline.Context())
+
+	baseOID, err := agentx.ParseOID(s.conf.SNMP.BaseOID)
+	if err != nil {
+		log.Errorf("Unable to set up the SNMP sub-agent: %v", err)
+		return
+	}
+
+	// split into network and address only, so tcp addresses keep host and
+	// port together
+	address := strings.SplitN(s.conf.SNMP.AgentXAddress, ":", 2)
+	if len(address) != 2 {
+		log.Warn("Can not parse the AgentX address, is the configuration correct?")
+		return
+	}
+
+	scalars := make([]agentx.Scalar, 0, len(snmpObjects))
+	for _, object := range snmpObjects {
+		subID := object.subID
+		scalars = append(scalars, agentx.Scalar{
+			// scalar objects are addressed with a .0 instance suffix
+			OID:  baseOID.Append(subID, 0),
+			Type: object.valueType,
+			Get:  func() uint64 { return s.value(subID) },
+		})
+	}
+
+	subAgent := agentx.NewSubAgent(address[0], address[1], "nginx-agent", baseOID, scalars)
+	go func() {
+		if err := subAgent.Run(s.ctx); err != nil {
+			log.Warnf("SNMP sub-agent stopped: %v", err)
+		}
+	}()
+}
+
+func (s *SNMP) Close() {
+	log.Info("SNMP is wrapping up")
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+func (s *SNMP) Info() *core.Info {
+	return core.NewInfo("SNMP", "v0.0.1")
+}
+
+func (s *SNMP) Process(msg *core.Message) {
+	switch msg.Topic() {
+	case core.MetricReport:
+		if report, ok := msg.Data().(*proto.MetricsReport); ok {
+			s.updateValues(report)
+		}
+	}
+}
+
+func (s *SNMP) Subscriptions() []string {
+	return []string{
+		core.MetricReport,
+	}
+}
+
+// updateValues folds a metrics report into the served values, summing across
+// the report's entities so multiple nginx instances roll up into one subtree
+func (s *SNMP) updateValues(report *proto.MetricsReport) {
+	sums := make(map[uint32]float64)
+	seen := make(map[uint32]bool)
+	for _, entity := range report.GetData() {
+		for _, metric := range entity.GetSimplemetrics() {
+			for _, object := range snmpObjects {
+				if strings.HasSuffix(metric.GetName(), object.metricSuffix) {
+					sums[object.subID] += metric.GetValue()
+					seen[object.subID] = true
+				}
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, object := range snmpObjects {
+		if !seen[object.subID] {
+			continue
+		}
+		if object.valueType == agentx.TypeCounter64 {
+			s.values[object.subID] += uint64(sums[object.subID])
+		} else {
+			s.values[object.subID] = uint64(sums[object.subID])
+		}
+	}
+}
+
+func (s *SNMP) value(subID uint32) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.values[subID]
+}
@@ -0,0 +1,54 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+func snmpTestReport(connActive, connAccepted float64) *proto.MetricsReport {
+	return &proto.MetricsReport{
+		Data: []*proto.StatsEntity{
+			{
+				Simplemetrics: []*proto.SimpleMetric{
+					{Name: "nginx.http.conn.active", Value: connActive},
+					{Name: "nginx.http.conn.accepted", Value: connAccepted},
+				},
+			},
+		},
+	}
+}
+
+func TestSNMPUpdateValues(t *testing.T) {
+	pluginUnderTest := NewSNMP(&config.Config{SNMP: config.SNMP{Enable: true}})
+
+	pluginUnderTest.Process(core.NewMessage(core.MetricReport, snmpTestReport(3, 5)))
+	// gauges hold the latest value, counters accumulate the reported deltas
+	assert.Equal(t, uint64(3), pluginUnderTest.value(1))
+	assert.Equal(t, uint64(5), pluginUnderTest.value(2))
+
+	pluginUnderTest.Process(core.NewMessage(core.MetricReport, snmpTestReport(1, 7)))
+	assert.Equal(t, uint64(1), pluginUnderTest.value(1))
+	assert.Equal(t, uint64(12), pluginUnderTest.value(2))
+
+	// a metric missing from a report leaves the last value in place
+	pluginUnderTest.Process(core.NewMessage(core.MetricReport, &proto.MetricsReport{}))
+	assert.Equal(t, uint64(1), pluginUnderTest.value(1))
+}
+
+func TestSNMPSubscriptions(t *testing.T) {
+	pluginUnderTest := NewSNMP(&config.Config{})
+	assert.Equal(t, []string{core.MetricReport}, pluginUnderTest.Subscriptions())
+	assert.Equal(t, "SNMP", pluginUnderTest.Info().Name())
+}
@@ -0,0 +1,190 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+// Supervisor owns the nginx process lifecycle when the agent runs in
+// supervisor mode: it starts nginx in the foreground, restarts it on crash
+// with exponential backoff and acts on start, stop and restart commands.
+// It is meant for containers where the agent runs as PID 1 and there is no
+// init system to keep nginx up.
+type Supervisor struct {
+	conf           *config.Config
+	ctx            context.Context
+	startCommand   func(binPath string) *exec.Cmd
+	mu             sync.Mutex
+	cmd            *exec.Cmd
+	startedAt      time.Time
+	backoff        time.Duration
+	generation     int
+	restartPending bool
+	wg             sync.WaitGroup
+}
+
+func NewSupervisor(conf *config.Config) *Supervisor {
+	return &Supervisor{
+		conf:    conf,
+		backoff: conf.Supervisor.BackoffMin,
+		startCommand: func(binPath string) *exec.Cmd {
+			// nginx has to stay in the foreground so the supervisor can
+			// reap it and observe crashes
+			return exec.Command(binPath, "-g", "daemon off;")
+		},
+	}
+}
+
+func (s *Supervisor) Init(pipeline core.MessagePipeInterface) {
+	log.Info("Supervisor initializing")
+	s.ctx = pipeline.Context()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.startNginx()
+}
+
+func (s *Supervisor) Info() *core.Info {
+	return core.NewInfo("Supervisor", "v0.0.1")
+}
+
+func (s *Supervisor) Close() {
+	log.Info("Supervisor is wrapping up")
+	s.mu.Lock()
+	s.stopNginx()
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Supervisor) Process(message *core.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch message.Topic() {
+	case core.NginxStart:
+		s.startNginx()
+	case core.NginxStop:
+		s.stopNginx()
+	case core.NginxRestart:
+		if s.cmd == nil {
+			s.startNginx()
+			return
+		}
+		// the monitor restarts nginx as soon as the old master exits
+		s.restartPending = true
+		if err := signalNginxQuit(s.cmd.Process, s.conf.Supervisor.BinPath); err != nil {
+			log.Errorf("Supervisor failed to signal nginx to quit: %v", err)
+			s.restartPending = false
+		}
+	}
+}
+
+func (s *Supervisor) Subscriptions() []string {
+	return []string{
+		core.NginxStart,
+		core.NginxStop,
+		core.NginxRestart,
+	}
+}
+
+// startNginx launches nginx and a monitor goroutine reaping it. The caller
+// has to hold s.mu.
+func (s *Supervisor) startNginx() {
+	if s.cmd != nil {
+		log.Debug("Supervisor asked to start nginx, but it is already running")
+		return
+	}
+
+	cmd := s.startCommand(s.conf.Supervisor.BinPath)
+	if err := cmd.Start(); err != nil {
+		log.Errorf("Supervisor failed to start nginx: %v", err)
+		return
+	}
+	log.Infof("Supervisor started nginx (pid %d)", cmd.Process.Pid)
+
+	s.cmd = cmd
+	s.startedAt = time.Now()
+
+	s.wg.Add(1)
+	go s.monitorNginx(cmd, s.generation)
+}
+
+// stopNginx signals nginx to quit gracefully without restarting it. The
+// caller has to hold s.mu.
+func (s *Supervisor) stopNginx() {
+	if s.cmd == nil {
+		return
+	}
+
+	// bumping the generation tells the monitor this exit is deliberate
+	s.generation++
+	s.restartPending = false
+	if err := signalNginxQuit(s.cmd.Process, s.conf.Supervisor.BinPath); err != nil {
+		log.Errorf("Supervisor failed to signal nginx to quit: %v", err)
+	}
+}
+
+// monitorNginx reaps the nginx process and decides what happens on exit:
+// nothing for a deliberate stop, an immediate restart for a requested
+// restart, and a restart after backoff for a crash.
+func (s *Supervisor) monitorNginx(cmd *exec.Cmd, generation int) {
+	defer s.wg.Done()
+
+	err := cmd.Wait()
+
+	s.mu.Lock()
+	s.cmd = nil
+	uptime := time.Since(s.startedAt)
+
+	if generation != s.generation {
+		s.mu.Unlock()
+		log.Info("Supervisor stopped nginx")
+		return
+	}
+
+	if s.restartPending {
+		s.restartPending = false
+		log.Info("Supervisor restarting nginx")
+		s.startNginx()
+		s.mu.Unlock()
+		return
+	}
+
+	if uptime >= s.conf.Supervisor.StableUptime {
+		s.backoff = s.conf.Supervisor.BackoffMin
+	}
+	backoff := s.backoff
+	s.backoff *= 2
+	if s.backoff > s.conf.Supervisor.BackoffMax {
+		s.backoff = s.conf.Supervisor.BackoffMax
+	}
+	s.mu.Unlock()
+
+	log.Warnf("Supervisor detected nginx exit after %s (%v), restarting in %s", uptime, err, backoff)
+
+	select {
+	case <-s.ctx.Done():
+		return
+	case <-time.After(backoff):
+	}
+
+	s.mu.Lock()
+	if generation == s.generation {
+		s.startNginx()
+	}
+	s.mu.Unlock()
+}
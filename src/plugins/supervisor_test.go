@@ -0,0 +1,104 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"os/exec"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+func supervisorTestConfig() *config.Config {
+	return &config.Config{
+		Supervisor: config.Supervisor{
+			Enable:       true,
+			BinPath:      "/usr/sbin/nginx",
+			BackoffMin:   10 * time.Millisecond,
+			BackoffMax:   50 * time.Millisecond,
+			StableUptime: time.Hour,
+		},
+	}
+}
+
+func TestSupervisor_Subscriptions(t *testing.T) {
+	pluginUnderTest := NewSupervisor(supervisorTestConfig())
+	assert.Equal(t, []string{core.NginxStart, core.NginxStop, core.NginxRestart}, pluginUnderTest.Subscriptions())
+}
+
+func TestSupervisor_RestartsOnCrash(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var starts int32
+	pluginUnderTest := NewSupervisor(supervisorTestConfig())
+	pluginUnderTest.startCommand = func(binPath string) *exec.Cmd {
+		atomic.AddInt32(&starts, 1)
+		// exits immediately, simulating a crashing nginx
+		return exec.Command("/bin/true")
+	}
+
+	pluginUnderTest.Init(core.NewMockMessagePipe(ctx))
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&starts) >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	pluginUnderTest.Close()
+}
+
+func TestSupervisor_StopWithoutRestart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var starts int32
+	pluginUnderTest := NewSupervisor(supervisorTestConfig())
+	pluginUnderTest.startCommand = func(binPath string) *exec.Cmd {
+		atomic.AddInt32(&starts, 1)
+		return exec.Command("/bin/sleep", "10")
+	}
+
+	pluginUnderTest.Init(core.NewMockMessagePipe(ctx))
+	pluginUnderTest.Process(core.NewMessage(core.NginxStop, nil))
+	pluginUnderTest.wg.Wait()
+
+	// a deliberate stop must not trigger the crash restart path
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&starts))
+
+	pluginUnderTest.Close()
+}
+
+func TestSupervisor_RestartCommand(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var starts int32
+	pluginUnderTest := NewSupervisor(supervisorTestConfig())
+	pluginUnderTest.startCommand = func(binPath string) *exec.Cmd {
+		atomic.AddInt32(&starts, 1)
+		return exec.Command("/bin/sleep", "10")
+	}
+
+	pluginUnderTest.Init(core.NewMockMessagePipe(ctx))
+	pluginUnderTest.Process(core.NewMessage(core.NginxRestart, nil))
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&starts) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	pluginUnderTest.Process(core.NewMessage(core.NginxStop, nil))
+	pluginUnderTest.Close()
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"os"
+	"syscall"
+)
+
+// signalNginxQuit asks the nginx master process to shut down gracefully
+func signalNginxQuit(proc *os.Process, bin string) error {
+	return proc.Signal(syscall.SIGQUIT)
+}
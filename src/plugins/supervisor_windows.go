@@ -0,0 +1,21 @@
+//go:build windows
+
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"os"
+	"os/exec"
+)
+
+// signalNginxQuit asks the nginx master process to shut down gracefully.
+// Windows has no SIGQUIT, so the nginx binary delivers the signal instead.
+func signalNginxQuit(proc *os.Process, bin string) error {
+	return exec.Command(bin, "-s", "quit").Run()
+}
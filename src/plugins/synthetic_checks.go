@@ -0,0 +1,239 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/metrics"
+)
+
+const (
+	defaultMinSyntheticInterval = 10 * time.Second
+	syntheticCheckTimeout       = 10 * time.Second
+	syntheticDefaultStatus      = http.StatusOK
+)
+
+// SyntheticChecks runs the lightweight HTTP probes declared in the agent config
+// against local listeners and reports their latency and outcome as metrics carrying
+// the server_name dimension, so operators can watch individual server blocks without
+// external monitoring. The probe connects to the configured address but sends the
+// server name as Host header and SNI, which is how a specific server block behind a
+// shared listener is reached.
+type SyntheticChecks struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	conf            *config.Config
+	env             core.Environment
+	ticker          *time.Ticker
+	wg              sync.WaitGroup
+	confMutex       sync.Mutex
+}
+
+func NewSyntheticChecks(conf *config.Config, env core.Environment) *SyntheticChecks {
+	interval := conf.SyntheticChecks.Interval
+	if interval < defaultMinSyntheticInterval {
+		log.Warnf("synthetic checks interval set to %s, provided value (%s) less than minimum", defaultMinSyntheticInterval, interval)
+		interval = defaultMinSyntheticInterval
+	}
+	return &SyntheticChecks{
+		conf:   conf,
+		env:    env,
+		ticker: time.NewTicker(interval),
+	}
+}
+
+func (sc *SyntheticChecks) Init(pipeline core.MessagePipeInterface) {
+	log.Info("SyntheticChecks initializing")
+	sc.messagePipeline = pipeline
+	sc.ctx = pipeline.Context()
+
+	sc.wg.Add(1)
+	go func() {
+		defer sc.wg.Done()
+		for {
+			select {
+			case <-sc.ticker.C:
+				sc.runChecks()
+			case <-sc.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (sc *SyntheticChecks) Close() {
+	log.Info("SyntheticChecks is wrapping up")
+	sc.ticker.Stop()
+}
+
+func (sc *SyntheticChecks) Info() *core.Info {
+	return core.NewInfo("SyntheticChecks", "v0.0.1")
+}
+
+func (sc *SyntheticChecks) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.AgentConfigChanged):
+		sc.syncAgentConfigChange()
+	}
+}
+
+func (sc *SyntheticChecks) Subscriptions() []string {
+	return []string{
+		core.AgentConfigChanged,
+	}
+}
+
+func (sc *SyntheticChecks) syncAgentConfigChange() {
+	conf, err := config.GetConfig(sc.env.GetSystemUUID())
+	if err != nil {
+		log.Errorf("Failed to load config for updating: %v", err)
+		return
+	}
+	log.Debugf("SyntheticChecks is updating to a new config - %v", conf)
+
+	sc.confMutex.Lock()
+	sc.conf = conf
+	sc.confMutex.Unlock()
+}
+
+// runChecks probes every configured check once and reports one stats entity per
+// check, so each server_name keeps its own latency and success series.
+func (sc *SyntheticChecks) runChecks() {
+	sc.confMutex.Lock()
+	checks := sc.conf.SyntheticChecks.Checks
+	sc.confMutex.Unlock()
+
+	if len(checks) == 0 {
+		return
+	}
+
+	entities := []*proto.StatsEntity{}
+	for _, check := range checks {
+		if check.Address == "" {
+			log.Warnf("SyntheticChecks: check %q has no address, skipping", check.Name)
+			continue
+		}
+		entities = append(entities, sc.runCheck(check))
+	}
+	if len(entities) == 0 {
+		return
+	}
+
+	sc.messagePipeline.Process(core.NewMessage(core.MetricReport, &proto.MetricsReport{
+		Meta: &proto.Metadata{
+			Timestamp: types.TimestampNow(),
+		},
+		Type: proto.MetricsReport_SYSTEM,
+		Data: entities,
+	}))
+}
+
+func (sc *SyntheticChecks) runCheck(check config.SyntheticCheck) *proto.StatsEntity {
+	expected := check.ExpectedStatus
+	if expected == 0 {
+		expected = syntheticDefaultStatus
+	}
+
+	latency, status, err := sc.probe(check)
+
+	success := float64(0)
+	samples := []*proto.SimpleMetric{}
+	if err != nil {
+		log.Debugf("SyntheticChecks: check %q against %s failed: %v", check.Name, check.Address, err)
+	} else {
+		if status == expected {
+			success = 1
+		}
+		samples = append(samples,
+			&proto.SimpleMetric{Name: "synthetic.http.latency", Value: float64(latency.Milliseconds())},
+			&proto.SimpleMetric{Name: "synthetic.http.status", Value: float64(status)},
+		)
+	}
+	samples = append(samples, &proto.SimpleMetric{Name: "synthetic.http.success", Value: success})
+
+	name := check.Name
+	if name == "" {
+		name = check.Address + check.Path
+	}
+	dimensions := []*proto.Dimension{
+		{Name: "system_id", Value: sc.env.GetSystemUUID()},
+		{Name: "hostname", Value: sc.env.GetHostname()},
+		{Name: "server_name", Value: check.ServerName},
+		{Name: "synthetic_check", Value: name},
+	}
+	return metrics.NewStatsEntity(dimensions, samples)
+}
+
+// probe sends one request for the check and returns how long the round trip took
+// and the response status.
+func (sc *SyntheticChecks) probe(check config.SyntheticCheck) (time.Duration, int, error) {
+	serverName := check.ServerName
+	if serverName == "" {
+		if host, _, err := net.SplitHostPort(check.Address); err == nil {
+			serverName = host
+		} else {
+			serverName = check.Address
+		}
+	}
+
+	scheme := "http"
+	if check.SSL {
+		scheme = "https"
+	}
+	path := check.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	transport := &http.Transport{
+		// the request is always sent to the configured local listener, whatever
+		// host the URL carries
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := net.Dialer{}
+			return dialer.DialContext(ctx, network, check.Address)
+		},
+		TLSClientConfig: &tls.Config{
+			ServerName: serverName,
+			// the probe checks the server block answers, certificate validity is
+			// the cert expiry monitor's job
+			InsecureSkipVerify: true,
+		},
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   syntheticCheckTimeout,
+	}
+	defer transport.CloseIdleConnections()
+
+	req, err := http.NewRequestWithContext(sc.ctx, http.MethodGet, fmt.Sprintf("%s://%s%s", scheme, serverName, path), nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	return time.Since(start), resp.StatusCode, nil
+}
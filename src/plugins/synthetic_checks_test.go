@@ -0,0 +1,125 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func TestSyntheticChecks_runChecks(t *testing.T) {
+	hosts := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts = append(hosts, r.Host)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	conf := &config.Config{
+		SyntheticChecks: config.SyntheticChecks{
+			Interval: config.Defaults.SyntheticChecks.Interval,
+			Checks: []config.SyntheticCheck{
+				{Name: "frontpage", Address: address, ServerName: "app.example.com", Path: "/"},
+				{Name: "missing", Address: address, ServerName: "app.example.com", Path: "/missing"},
+				{Name: "gone", Address: "127.0.0.1:1", ServerName: "app.example.com", Path: "/"},
+			},
+		},
+	}
+
+	pluginUnderTest := NewSyntheticChecks(conf, tutils.GetMockEnv())
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.messagePipeline = messagePipe
+	pluginUnderTest.ctx = messagePipe.Context()
+
+	pluginUnderTest.runChecks()
+
+	var report *proto.MetricsReport
+	for _, msg := range messagePipe.GetMessages() {
+		if msg.Topic() == core.MetricReport {
+			report = msg.Data().(*proto.MetricsReport)
+		}
+	}
+	require.NotNil(t, report)
+	require.Len(t, report.GetData(), 3)
+
+	// the probes carry the server name as Host header
+	assert.Equal(t, []string{"app.example.com", "app.example.com"}, hosts)
+
+	results := map[string]map[string]float64{}
+	for _, entity := range report.GetData() {
+		dims := map[string]string{}
+		for _, dim := range entity.GetDimensions() {
+			dims[dim.Name] = dim.Value
+		}
+		assert.Equal(t, "app.example.com", dims["server_name"])
+		samples := map[string]float64{}
+		for _, metric := range entity.GetSimplemetrics() {
+			samples[metric.Name] = metric.Value
+		}
+		results[dims["synthetic_check"]] = samples
+	}
+
+	assert.Equal(t, float64(1), results["frontpage"]["synthetic.http.success"])
+	assert.Equal(t, float64(http.StatusOK), results["frontpage"]["synthetic.http.status"])
+	// an unexpected status is a failed check, even though the probe got an answer
+	assert.Equal(t, float64(0), results["missing"]["synthetic.http.success"])
+	assert.Equal(t, float64(http.StatusNotFound), results["missing"]["synthetic.http.status"])
+	// an unreachable listener reports only the failure
+	assert.Equal(t, float64(0), results["gone"]["synthetic.http.success"])
+	assert.NotContains(t, results["gone"], "synthetic.http.status")
+}
+
+func TestSyntheticChecks_expectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	conf := &config.Config{
+		SyntheticChecks: config.SyntheticChecks{
+			Interval: config.Defaults.SyntheticChecks.Interval,
+			Checks: []config.SyntheticCheck{
+				{Name: "login", Address: address, ServerName: "app.example.com", Path: "/login", ExpectedStatus: http.StatusUnauthorized},
+			},
+		},
+	}
+
+	pluginUnderTest := NewSyntheticChecks(conf, tutils.GetMockEnv())
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.messagePipeline = messagePipe
+	pluginUnderTest.ctx = messagePipe.Context()
+
+	entity := pluginUnderTest.runCheck(conf.SyntheticChecks.Checks[0])
+	samples := map[string]float64{}
+	for _, metric := range entity.GetSimplemetrics() {
+		samples[metric.Name] = metric.Value
+	}
+	assert.Equal(t, float64(1), samples["synthetic.http.success"])
+}
+
+func TestSyntheticChecksSubscriptions(t *testing.T) {
+	pluginUnderTest := NewSyntheticChecks(&config.Config{}, tutils.GetMockEnv())
+	assert.Equal(t, []string{core.AgentConfigChanged}, pluginUnderTest.Subscriptions())
+}
@@ -0,0 +1,175 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	sdk "github.com/nginx/agent/sdk/v2"
+	"github.com/nginx/agent/sdk/v2/proto"
+	commonProto "github.com/nginx/agent/sdk/v2/proto/common"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+// TLSPosture analyzes the ssl_protocols, ssl_ciphers, OCSP stapling, HSTS and session
+// ticket settings of every TLS enabled server block of the discovered nginx instances
+// and reports the findings as events, so security teams get actionable posture
+// information without scanning the dataplane externally.
+type TLSPosture struct {
+	messagePipeline core.MessagePipeInterface
+	conf            *config.Config
+	env             core.Environment
+	meta            *proto.Metadata
+	reportedDigests map[string]string
+}
+
+func NewTLSPosture(conf *config.Config, env core.Environment, meta *proto.Metadata) *TLSPosture {
+	return &TLSPosture{
+		conf:            conf,
+		env:             env,
+		meta:            meta,
+		reportedDigests: make(map[string]string),
+	}
+}
+
+func (tp *TLSPosture) Init(pipeline core.MessagePipeInterface) {
+	log.Info("TLSPosture initializing")
+	tp.messagePipeline = pipeline
+}
+
+func (tp *TLSPosture) Close() {
+	log.Info("TLSPosture is wrapping up")
+}
+
+func (tp *TLSPosture) Info() *core.Info {
+	return core.NewInfo("TLSPosture", "v0.0.1")
+}
+
+func (tp *TLSPosture) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.NginxInstancesFound):
+		nginxDetailsMap, ok := msg.Data().(map[string]*proto.NginxDetails)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		for _, detail := range nginxDetailsMap {
+			tp.reportPosture(detail)
+		}
+	}
+}
+
+func (tp *TLSPosture) Subscriptions() []string {
+	return []string{
+		core.NginxInstancesFound,
+	}
+}
+
+// reportPosture analyzes the config of a single nginx instance and emits one event per
+// finding. The findings of each instance are only re-reported when they change, so a
+// stable config does not produce the same report on every instance refresh.
+func (tp *TLSPosture) reportPosture(detail *proto.NginxDetails) {
+	findings, err := sdk.AnalyzeTLSPosture(detail.GetConfPath())
+	if err != nil {
+		log.Warnf("TLSPosture: unable to analyze config for nginx instance %s: %v", detail.GetNginxId(), err)
+		return
+	}
+
+	digest := postureDigest(findings)
+	if tp.reportedDigests[detail.GetNginxId()] == digest {
+		return
+	}
+	tp.reportedDigests[detail.GetNginxId()] = digest
+
+	if len(findings) == 0 {
+		return
+	}
+
+	events := make([]*eventsProto.Event, 0, len(findings))
+	for _, finding := range findings {
+		events = append(events, tp.createPostureEvent(detail.GetNginxId(), finding))
+	}
+
+	tp.messagePipeline.Process(core.NewMessage(core.Events, &proto.Command{
+		Meta: tp.meta,
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_EventReport{
+			EventReport: &eventsProto.EventReport{
+				Events: events,
+			},
+		},
+	}))
+}
+
+func (tp *TLSPosture) createPostureEvent(nginxID string, finding *sdk.TLSPostureFinding) *eventsProto.Event {
+	level := INFO_EVENT_LEVEL
+	if finding.Severity == sdk.TLSPostureSeverityWarning {
+		level = WARN_EVENT_LEVEL
+	}
+
+	serverNames := strings.Join(finding.ServerNames, ",")
+	if serverNames == "" {
+		serverNames = "(unnamed)"
+	}
+
+	activityEvent := &eventsProto.ActivityEvent{
+		Message: fmt.Sprintf("tls posture: server %s, %s: %s", serverNames, finding.Directive, finding.Message),
+		Dimensions: []*commonProto.Dimension{
+			{
+				Name:  "nginx_id",
+				Value: nginxID,
+			},
+			{
+				Name:  "system_id",
+				Value: tp.env.GetSystemUUID(),
+			},
+			{
+				Name:  "hostname",
+				Value: tp.env.GetHostname(),
+			},
+			{
+				Name:  "instance_group",
+				Value: tp.conf.InstanceGroup,
+			},
+		},
+	}
+
+	return &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			UUID:          uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Module:        MODULE,
+			Timestamp:     types.TimestampNow(),
+			EventLevel:    level,
+			Type:          NGINX_EVENT_TYPE,
+			Category:      CONFIG_CATEGORY,
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: activityEvent,
+		},
+	}
+}
+
+// postureDigest returns a stable digest of the findings, used to suppress duplicate
+// reports for an unchanged config.
+func postureDigest(findings []*sdk.TLSPostureFinding) string {
+	hash := sha256.New()
+	for _, finding := range findings {
+		fmt.Fprintf(hash, "%s|%s|%s|%s\n", strings.Join(finding.ServerNames, ","), finding.Directive, finding.Severity, finding.Message)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,76 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func TestTLSPostureReportPosture(t *testing.T) {
+	tmpDir := t.TempDir()
+	confFile := filepath.Join(tmpDir, "nginx.conf")
+	conf := `
+http {
+    server {
+        listen 443 ssl;
+        server_name weak.example.com;
+        ssl_protocols TLSv1 TLSv1.2;
+        ssl_stapling on;
+        ssl_session_tickets off;
+        add_header Strict-Transport-Security "max-age=31536000";
+    }
+}
+`
+	require.NoError(t, os.WriteFile(confFile, []byte(conf), 0644))
+
+	details := tutils.GetDetailsMap()
+	details["12345"].ConfPath = confFile
+
+	plugin := NewTLSPosture(&config.Config{}, tutils.GetMockEnv(), &proto.Metadata{MessageId: "123"})
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), plugin)
+	plugin.Init(messagePipe)
+
+	plugin.Process(core.NewMessage(core.NginxInstancesFound, details))
+
+	events := collectActivityEvents(t, messagePipe.GetMessages())
+	require.Len(t, events, 1)
+	assert.Equal(t, WARN_EVENT_LEVEL, events[0].GetMetadata().GetEventLevel())
+	assert.Contains(t, events[0].GetActivityEvent().GetMessage(), "ssl_protocols")
+	assert.Contains(t, events[0].GetActivityEvent().GetMessage(), "weak.example.com")
+
+	// an unchanged config must not be re-reported
+	messagePipe.ClearMessages()
+	plugin.Process(core.NewMessage(core.NginxInstancesFound, details))
+	assert.Empty(t, collectActivityEvents(t, messagePipe.GetMessages()))
+}
+
+func collectActivityEvents(t *testing.T, messages []*core.Message) []*eventsProto.Event {
+	t.Helper()
+	events := []*eventsProto.Event{}
+	for _, msg := range messages {
+		if !msg.Exact(core.Events) {
+			continue
+		}
+		cmd, ok := msg.Data().(*proto.Command)
+		require.True(t, ok)
+		events = append(events, cmd.GetEventReport().GetEvents()...)
+	}
+	return events
+}
@@ -0,0 +1,364 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	sdk "github.com/nginx/agent/sdk/v2"
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const (
+	// mirrorLocationPath is the internal location the mirror directive points at
+	mirrorLocationPath = "/_agent_mirror"
+	// mirrorIncludePrefix names the generated include files so they are recognisable
+	// in the conf directory and in the server block
+	mirrorIncludePrefix = "agent_mirror_"
+	mirrorIncludeMarker = "# traffic mirror managed by nginx-agent"
+)
+
+// TrafficMirror sets up and tears down traffic mirroring for a managed server block
+// on request of the management server, so production traffic can be shadowed to a
+// test backend. The mirror location is generated into a dedicated include file that
+// is wired into the server block with a single include line; the modified config is
+// validated before the reload and both files are rolled back when validation or the
+// reload fails.
+type TrafficMirror struct {
+	messagePipeline core.MessagePipeInterface
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	detailsMutex    sync.Mutex
+	nginxDetails    map[string]*proto.NginxDetails
+}
+
+func NewTrafficMirror(conf *config.Config, env core.Environment, binary core.NginxBinary) *TrafficMirror {
+	return &TrafficMirror{
+		conf:         conf,
+		env:          env,
+		binary:       binary,
+		nginxDetails: make(map[string]*proto.NginxDetails),
+	}
+}
+
+func (tm *TrafficMirror) Init(pipeline core.MessagePipeInterface) {
+	log.Info("TrafficMirror initializing")
+	tm.messagePipeline = pipeline
+}
+
+func (tm *TrafficMirror) Close() {
+	log.Info("TrafficMirror is wrapping up")
+}
+
+func (tm *TrafficMirror) Info() *core.Info {
+	return core.NewInfo("TrafficMirror", "v0.0.1")
+}
+
+func (tm *TrafficMirror) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.NginxInstancesFound):
+		nginxDetailsMap, ok := msg.Data().(map[string]*proto.NginxDetails)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		tm.detailsMutex.Lock()
+		tm.nginxDetails = nginxDetailsMap
+		tm.detailsMutex.Unlock()
+	case msg.Exact(core.TrafficMirror):
+		cmd, ok := msg.Data().(*proto.Command)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		tm.mirror(cmd)
+	}
+}
+
+func (tm *TrafficMirror) Subscriptions() []string {
+	return []string{
+		core.TrafficMirror,
+		core.NginxInstancesFound,
+	}
+}
+
+func (tm *TrafficMirror) mirror(cmd *proto.Command) {
+	request := cmd.GetTrafficMirrorRequest()
+
+	message, err := tm.applyRequest(request)
+	if err != nil {
+		log.Errorf("TrafficMirror: mirror change for server %s failed: %v", request.GetServerName(), err)
+		tm.respond(cmd, proto.CommandStatusResponse_CMD_ERROR, fmt.Sprintf("traffic mirror change for server %s failed", request.GetServerName()), err.Error())
+		return
+	}
+
+	log.Infof("TrafficMirror: %s", message)
+	tm.respond(cmd, proto.CommandStatusResponse_CMD_OK, message, "")
+}
+
+func (tm *TrafficMirror) applyRequest(request *proto.TrafficMirrorRequest) (string, error) {
+	if request == nil {
+		return "", fmt.Errorf("traffic mirror request is empty")
+	}
+
+	serverName := request.GetServerName()
+	if !validMirrorServerName(serverName) {
+		return "", fmt.Errorf("invalid server name %q", serverName)
+	}
+
+	detail, err := tm.instance(request.GetNginxId())
+	if err != nil {
+		return "", err
+	}
+
+	location, err := sdk.FindServerBlock(detail.GetConfPath(), serverName)
+	if err != nil {
+		return "", err
+	}
+
+	includeFile := filepath.Join(filepath.Dir(detail.GetConfPath()), mirrorIncludePrefix+mirrorFileName(serverName)+".conf")
+
+	switch request.GetAction() {
+	case proto.TrafficMirrorRequest_SET:
+		if err := validMirrorBackend(request.GetBackend()); err != nil {
+			return "", err
+		}
+		if err := tm.setMirror(detail, location, includeFile, serverName, request.GetBackend()); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("traffic of server %s is mirrored to %s", serverName, request.GetBackend()), nil
+	case proto.TrafficMirrorRequest_REMOVE:
+		if err := tm.removeMirror(detail, location, includeFile, serverName); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("traffic mirror of server %s removed", serverName), nil
+	}
+
+	return "", fmt.Errorf("unknown traffic mirror action %v", request.GetAction())
+}
+
+// setMirror writes the mirror include file and wires it into the server block, then
+// validates the modified config and reloads. Both files are restored when any step
+// fails, so a broken mirror request never leaves the config changed.
+func (tm *TrafficMirror) setMirror(detail *proto.NginxDetails, location *sdk.ServerBlockLocation, includeFile, serverName, backend string) error {
+	content, err := os.ReadFile(location.File)
+	if err != nil {
+		return err
+	}
+	updated, err := insertMirrorInclude(string(content), location.Line, includeFile)
+	if err != nil {
+		return err
+	}
+
+	_, statErr := os.Stat(includeFile)
+	createdInclude := os.IsNotExist(statErr)
+
+	backups, err := backupFiles(location.File, includeFile)
+	if err != nil {
+		return err
+	}
+	rollback := func() {
+		restoreFiles(backups)
+		if createdInclude {
+			os.Remove(includeFile)
+		}
+	}
+
+	if err := os.WriteFile(includeFile, []byte(mirrorSnippet(serverName, backend)), 0644); err != nil {
+		rollback()
+		return err
+	}
+	if err := os.WriteFile(location.File, []byte(updated), 0644); err != nil {
+		rollback()
+		return err
+	}
+
+	if err := tm.validateAndReload(detail, rollback); err != nil {
+		return err
+	}
+
+	removeFiles(backups)
+	return nil
+}
+
+// removeMirror drops the include line from the server block and moves the include
+// file aside, then validates and reloads; the previous state is restored when either
+// fails.
+func (tm *TrafficMirror) removeMirror(detail *proto.NginxDetails, location *sdk.ServerBlockLocation, includeFile, serverName string) error {
+	content, err := os.ReadFile(location.File)
+	if err != nil {
+		return err
+	}
+	updated, removed := removeMirrorInclude(string(content), includeFile)
+	if !removed {
+		return fmt.Errorf("no traffic mirror configured for server %s", serverName)
+	}
+
+	backups, err := backupFiles(location.File, includeFile)
+	if err != nil {
+		return err
+	}
+	rollback := func() {
+		restoreFiles(backups)
+	}
+
+	if err := os.WriteFile(location.File, []byte(updated), 0644); err != nil {
+		rollback()
+		return err
+	}
+
+	if err := tm.validateAndReload(detail, rollback); err != nil {
+		return err
+	}
+
+	removeFiles(backups)
+	return nil
+}
+
+func (tm *TrafficMirror) validateAndReload(detail *proto.NginxDetails, rollback func()) error {
+	if err := tm.binary.ValidateConfig(detail.GetNginxId(), detail.GetProcessPath(), detail.GetConfPath(), nil, nil); err != nil {
+		rollback()
+		return fmt.Errorf("validation of the modified config failed: %v", err)
+	}
+	if err := tm.binary.Reload(detail.GetProcessId(), detail.GetProcessPath()); err != nil {
+		rollback()
+		if reloadErr := tm.binary.Reload(detail.GetProcessId(), detail.GetProcessPath()); reloadErr != nil {
+			log.Errorf("TrafficMirror: reload after rollback failed: %v", reloadErr)
+		}
+		return fmt.Errorf("reload with the mirror change: %v", err)
+	}
+	return nil
+}
+
+func (tm *TrafficMirror) instance(nginxID string) (*proto.NginxDetails, error) {
+	tm.detailsMutex.Lock()
+	defer tm.detailsMutex.Unlock()
+
+	detail := tm.nginxDetails[nginxID]
+	if detail == nil && nginxID == "" && len(tm.nginxDetails) == 1 {
+		for _, candidate := range tm.nginxDetails {
+			detail = candidate
+		}
+	}
+	if detail == nil {
+		return nil, fmt.Errorf("no nginx instance found for the request")
+	}
+	if detail.GetConfPath() == "" {
+		return nil, fmt.Errorf("no config path known for nginx instance %s", detail.GetNginxId())
+	}
+	return detail, nil
+}
+
+// mirrorSnippet renders the dedicated include file: the mirror directive pointing at
+// an internal location that forwards the copied requests to the backend.
+func mirrorSnippet(serverName, backend string) string {
+	return fmt.Sprintf(`# Traffic mirror for %s, generated by nginx-agent. Do not edit.
+mirror %s;
+mirror_request_body on;
+location = %s {
+    internal;
+    proxy_pass %s$request_uri;
+}
+`, serverName, mirrorLocationPath, mirrorLocationPath, backend)
+}
+
+// insertMirrorInclude wires the include file into the server block that starts at
+// serverLine, right after its opening brace. A server block that already includes
+// the file is left as it is.
+func insertMirrorInclude(content string, serverLine int, includeFile string) (string, error) {
+	includeLine := fmt.Sprintf("    include %s; %s", includeFile, mirrorIncludeMarker)
+
+	lines := strings.Split(content, "\n")
+	for _, line := range lines {
+		if strings.Contains(line, includeFile) {
+			return content, nil
+		}
+	}
+
+	for i := serverLine - 1; i < len(lines); i++ {
+		if strings.Contains(lines[i], "{") {
+			updated := append([]string{}, lines[:i+1]...)
+			updated = append(updated, includeLine)
+			updated = append(updated, lines[i+1:]...)
+			return strings.Join(updated, "\n"), nil
+		}
+	}
+	return "", fmt.Errorf("no opening brace found for the server block at line %d", serverLine)
+}
+
+// removeMirrorInclude drops the include line that wires the mirror file into the
+// server block and reports whether one was found.
+func removeMirrorInclude(content, includeFile string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	kept := []string{}
+	removed := false
+	for _, line := range lines {
+		if strings.Contains(line, "include") && strings.Contains(line, includeFile) {
+			removed = true
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n"), removed
+}
+
+func validMirrorServerName(serverName string) bool {
+	if serverName == "" {
+		return false
+	}
+	for _, r := range serverName {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '.' || r == '-' || r == '_' || r == '*':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validMirrorBackend rejects backends that could not be a proxy_pass URL or that
+// would break out of the generated directive.
+func validMirrorBackend(backend string) error {
+	if !strings.HasPrefix(backend, "http://") && !strings.HasPrefix(backend, "https://") {
+		return fmt.Errorf("backend %q is not an http or https URL", backend)
+	}
+	if strings.ContainsAny(backend, " \t\r\n;{}#\"'") {
+		return fmt.Errorf("backend %q contains characters that are not allowed", backend)
+	}
+	return nil
+}
+
+// mirrorFileName makes the server name safe to use in the include file name; the
+// wildcard of a catch-all server name is the only character that needs replacing.
+func mirrorFileName(serverName string) string {
+	return strings.ReplaceAll(serverName, "*", "_")
+}
+
+func (tm *TrafficMirror) respond(cmd *proto.Command, status proto.CommandStatusResponse_CommandStatus, message, errorMessage string) {
+	tm.messagePipeline.Process(core.NewMessage(core.CommResponse, &proto.Command{
+		Meta: cmd.GetMeta(),
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_CmdStatus{
+			CmdStatus: &proto.CommandStatusResponse{
+				Status:  status,
+				Message: message,
+				Error:   errorMessage,
+			},
+		},
+	}))
+}
@@ -0,0 +1,228 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func writeMirrorTestConfig(t *testing.T) (confFile string) {
+	t.Helper()
+	dir := t.TempDir()
+	confFile = filepath.Join(dir, "nginx.conf")
+	require.NoError(t, os.WriteFile(confFile, []byte(`events {}
+http {
+    server {
+        listen 80;
+        server_name app.example.com;
+    }
+}
+`), 0644))
+	return confFile
+}
+
+func mirrorTestPlugin(t *testing.T, confFile string, binary *tutils.MockNginxBinary) (*TrafficMirror, *core.MockMessagePipe) {
+	t.Helper()
+	pluginUnderTest := NewTrafficMirror(&config.Config{}, tutils.GetMockEnv(), binary)
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.messagePipeline = messagePipe
+	pluginUnderTest.nginxDetails = map[string]*proto.NginxDetails{
+		"12345": {NginxId: "12345", ProcessId: "1", ProcessPath: "/usr/sbin/nginx", ConfPath: confFile},
+	}
+	return pluginUnderTest, messagePipe
+}
+
+func mirrorResponses(messagePipe *core.MockMessagePipe) []*proto.CommandStatusResponse {
+	responses := []*proto.CommandStatusResponse{}
+	for _, msg := range messagePipe.GetMessages() {
+		if msg.Topic() == core.CommResponse {
+			responses = append(responses, msg.Data().(*proto.Command).GetCmdStatus())
+		}
+	}
+	return responses
+}
+
+func TestTrafficMirrorSetAndRemove(t *testing.T) {
+	confFile := writeMirrorTestConfig(t)
+	includeFile := filepath.Join(filepath.Dir(confFile), "agent_mirror_app.example.com.conf")
+
+	binary := tutils.NewMockNginxBinary()
+	binary.On("ValidateConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	binary.On("Reload", mock.Anything, mock.Anything)
+
+	pluginUnderTest, messagePipe := mirrorTestPlugin(t, confFile, binary)
+
+	pluginUnderTest.Process(core.NewMessage(core.TrafficMirror, &proto.Command{
+		Meta: &proto.Metadata{MessageId: "123"},
+		Data: &proto.Command_TrafficMirrorRequest{
+			TrafficMirrorRequest: &proto.TrafficMirrorRequest{
+				NginxId:    "12345",
+				ServerName: "app.example.com",
+				Backend:    "http://shadow.internal:8080",
+				Action:     proto.TrafficMirrorRequest_SET,
+			},
+		},
+	}))
+
+	responses := mirrorResponses(messagePipe)
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_OK, responses[0].GetStatus())
+	assert.Contains(t, responses[0].GetMessage(), "mirrored to http://shadow.internal:8080")
+
+	snippet, err := os.ReadFile(includeFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(snippet), "mirror /_agent_mirror;")
+	assert.Contains(t, string(snippet), "proxy_pass http://shadow.internal:8080$request_uri;")
+
+	conf, err := os.ReadFile(confFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(conf), fmt.Sprintf("include %s;", includeFile))
+	// the include line sits inside the server block, after its opening brace
+	assert.Regexp(t, `server \{\n    include `, string(conf))
+
+	// setting the mirror again re-points the backend without a second include line
+	messagePipe.ClearMessages()
+	pluginUnderTest.Process(core.NewMessage(core.TrafficMirror, &proto.Command{
+		Meta: &proto.Metadata{MessageId: "124"},
+		Data: &proto.Command_TrafficMirrorRequest{
+			TrafficMirrorRequest: &proto.TrafficMirrorRequest{
+				NginxId:    "12345",
+				ServerName: "app.example.com",
+				Backend:    "http://shadow2.internal:8080",
+				Action:     proto.TrafficMirrorRequest_SET,
+			},
+		},
+	}))
+	responses = mirrorResponses(messagePipe)
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_OK, responses[0].GetStatus())
+	snippet, err = os.ReadFile(includeFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(snippet), "shadow2.internal")
+	conf, err = os.ReadFile(confFile)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(conf), "include "+includeFile))
+
+	// removal drops the include line and the generated file
+	messagePipe.ClearMessages()
+	pluginUnderTest.Process(core.NewMessage(core.TrafficMirror, &proto.Command{
+		Meta: &proto.Metadata{MessageId: "125"},
+		Data: &proto.Command_TrafficMirrorRequest{
+			TrafficMirrorRequest: &proto.TrafficMirrorRequest{
+				NginxId:    "12345",
+				ServerName: "app.example.com",
+				Action:     proto.TrafficMirrorRequest_REMOVE,
+			},
+		},
+	}))
+	responses = mirrorResponses(messagePipe)
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_OK, responses[0].GetStatus())
+	conf, err = os.ReadFile(confFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(conf), includeFile)
+	_, err = os.Stat(includeFile)
+	assert.True(t, os.IsNotExist(err))
+
+	// removing again reports that no mirror is configured
+	messagePipe.ClearMessages()
+	pluginUnderTest.Process(core.NewMessage(core.TrafficMirror, &proto.Command{
+		Meta: &proto.Metadata{MessageId: "126"},
+		Data: &proto.Command_TrafficMirrorRequest{
+			TrafficMirrorRequest: &proto.TrafficMirrorRequest{
+				NginxId:    "12345",
+				ServerName: "app.example.com",
+				Action:     proto.TrafficMirrorRequest_REMOVE,
+			},
+		},
+	}))
+	responses = mirrorResponses(messagePipe)
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_ERROR, responses[0].GetStatus())
+	assert.Contains(t, responses[0].GetError(), "no traffic mirror configured")
+}
+
+func TestTrafficMirrorValidationFailureRollsBack(t *testing.T) {
+	confFile := writeMirrorTestConfig(t)
+	original, err := os.ReadFile(confFile)
+	require.NoError(t, err)
+	includeFile := filepath.Join(filepath.Dir(confFile), "agent_mirror_app.example.com.conf")
+
+	binary := tutils.NewMockNginxBinary()
+	binary.On("ValidateConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("unknown directive"))
+
+	pluginUnderTest, messagePipe := mirrorTestPlugin(t, confFile, binary)
+
+	pluginUnderTest.Process(core.NewMessage(core.TrafficMirror, &proto.Command{
+		Meta: &proto.Metadata{MessageId: "123"},
+		Data: &proto.Command_TrafficMirrorRequest{
+			TrafficMirrorRequest: &proto.TrafficMirrorRequest{
+				NginxId:    "12345",
+				ServerName: "app.example.com",
+				Backend:    "http://shadow.internal:8080",
+				Action:     proto.TrafficMirrorRequest_SET,
+			},
+		},
+	}))
+
+	responses := mirrorResponses(messagePipe)
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_ERROR, responses[0].GetStatus())
+	assert.Contains(t, responses[0].GetError(), "validation of the modified config failed")
+
+	// both the server block and the generated include are back to the previous state
+	conf, err := os.ReadFile(confFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(conf))
+	_, err = os.Stat(includeFile)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestTrafficMirrorRejectsBadRequests(t *testing.T) {
+	confFile := writeMirrorTestConfig(t)
+	pluginUnderTest, messagePipe := mirrorTestPlugin(t, confFile, tutils.NewMockNginxBinary())
+
+	badRequests := []*proto.TrafficMirrorRequest{
+		{NginxId: "12345", ServerName: "app.example.com", Backend: "ftp://shadow.internal", Action: proto.TrafficMirrorRequest_SET},
+		{NginxId: "12345", ServerName: "app.example.com", Backend: "http://shadow;}location/{", Action: proto.TrafficMirrorRequest_SET},
+		{NginxId: "12345", ServerName: "app example com", Backend: "http://shadow.internal", Action: proto.TrafficMirrorRequest_SET},
+		{NginxId: "12345", ServerName: "missing.example.com", Backend: "http://shadow.internal", Action: proto.TrafficMirrorRequest_SET},
+		{NginxId: "99999", ServerName: "app.example.com", Backend: "http://shadow.internal", Action: proto.TrafficMirrorRequest_SET},
+	}
+	for _, request := range badRequests {
+		pluginUnderTest.Process(core.NewMessage(core.TrafficMirror, &proto.Command{
+			Meta: &proto.Metadata{MessageId: "123"},
+			Data: &proto.Command_TrafficMirrorRequest{TrafficMirrorRequest: request},
+		}))
+	}
+
+	responses := mirrorResponses(messagePipe)
+	require.Len(t, responses, len(badRequests))
+	for _, response := range responses {
+		assert.Equal(t, proto.CommandStatusResponse_CMD_ERROR, response.GetStatus())
+	}
+}
+
+func TestTrafficMirrorSubscriptions(t *testing.T) {
+	pluginUnderTest := NewTrafficMirror(&config.Config{}, tutils.GetMockEnv(), tutils.GetMockNginxBinary())
+	assert.Equal(t, []string{core.TrafficMirror, core.NginxInstancesFound}, pluginUnderTest.Subscriptions())
+}
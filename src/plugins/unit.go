@@ -0,0 +1,179 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/metrics"
+	"github.com/nginx/agent/v2/src/core/unit"
+)
+
+const (
+	unitPluginName    = "NGINX Unit"
+	unitPluginVersion = "v0.0.1"
+)
+
+// Unit discovers an NGINX Unit instance through its control socket, reporting
+// its presence and configuration checksum as dataplane software details and
+// its connection and request counters as metrics. Counters are reported as
+// deltas between polls, the way the nginx metric sources report them.
+type Unit struct {
+	messagePipeline core.MessagePipeInterface
+	ctx             context.Context
+	cancel          context.CancelFunc
+	conf            *config.Config
+	env             core.Environment
+	client          *unit.Client
+	unitId          string
+	lastDetails     *proto.UnitDetails
+	lastStatus      *unit.Status
+}
+
+func NewUnit(conf *config.Config, env core.Environment) *Unit {
+	return &Unit{
+		conf: conf,
+		env:  env,
+	}
+}
+
+func (u *Unit) Init(pipeline core.MessagePipeInterface) {
+	log.Infof("%s plugin initializing", unitPluginName)
+	u.messagePipeline = pipeline
+	u.ctx, u.cancel = context.WithCancel(pipeline.Context())
+	u.client = unit.NewClient(u.conf.Unit.ControlSocket)
+	u.unitId = core.GenerateNginxID("%s_%s", u.env.GetSystemUUID(), u.conf.Unit.ControlSocket)
+
+	go u.monitor()
+}
+
+func (u *Unit) Close() {
+	log.Infof("%s plugin is wrapping up", unitPluginName)
+	if u.cancel != nil {
+		u.cancel()
+	}
+}
+
+func (u *Unit) Process(msg *core.Message) {}
+
+func (u *Unit) Info() *core.Info {
+	return core.NewInfo(unitPluginName, unitPluginVersion)
+}
+
+func (u *Unit) Subscriptions() []string {
+	return []string{}
+}
+
+func (u *Unit) monitor() {
+	u.report()
+
+	ticker := time.NewTicker(u.conf.Unit.ReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			u.report()
+		case <-u.ctx.Done():
+			return
+		}
+	}
+}
+
+// report checks the instance once, publishing updated software details when
+// the instance, its version or its configuration changed, and a metric report
+// when its status endpoint is available
+func (u *Unit) report() {
+	details, err := u.gatherDetails()
+	if err != nil {
+		if u.lastDetails != nil {
+			log.Warnf("NGINX Unit instance is no longer reachable through %s: %v", u.conf.Unit.ControlSocket, err)
+			u.lastDetails = nil
+			u.lastStatus = nil
+		} else {
+			log.Debugf("No NGINX Unit instance reachable through %s: %v", u.conf.Unit.ControlSocket, err)
+		}
+		return
+	}
+
+	if u.lastDetails == nil {
+		log.Infof("Found NGINX Unit %s through %s", details.GetVersion(), u.conf.Unit.ControlSocket)
+	}
+	if u.lastDetails == nil ||
+		u.lastDetails.GetVersion() != details.GetVersion() ||
+		u.lastDetails.GetConfigChecksum() != details.GetConfigChecksum() {
+		u.messagePipeline.Process(core.NewMessage(core.DataplaneSoftwareDetailsUpdated,
+			&proto.DataplaneSoftwareDetails_UnitDetails{UnitDetails: details}))
+	}
+	u.lastDetails = details
+
+	status, err := u.client.Status(u.ctx)
+	if err != nil {
+		// /status exists since Unit 1.28
+		log.Debugf("Unable to collect NGINX Unit status: %v", err)
+		u.lastStatus = nil
+		return
+	}
+	u.messagePipeline.Process(core.NewMessage(core.MetricReport, u.buildMetricReport(status)))
+	u.lastStatus = status
+}
+
+func (u *Unit) gatherDetails() (*proto.UnitDetails, error) {
+	version, err := u.client.Version(u.ctx)
+	if err != nil {
+		return nil, err
+	}
+	checksum, err := u.client.ConfigChecksum(u.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.UnitDetails{
+		UnitId:         u.unitId,
+		Version:        version,
+		ControlSocket:  u.conf.Unit.ControlSocket,
+		ConfigChecksum: checksum,
+	}, nil
+}
+
+func (u *Unit) buildMetricReport(status *unit.Status) *proto.MetricsReport {
+	samples := []*proto.SimpleMetric{
+		{Name: "unit.connections.active", Value: float64(status.Connections.Active)},
+		{Name: "unit.connections.idle", Value: float64(status.Connections.Idle)},
+	}
+	// counters are deltas between polls, so the first sighting only
+	// establishes the baseline
+	if u.lastStatus != nil {
+		samples = append(samples,
+			&proto.SimpleMetric{Name: "unit.connections.accepted", Value: float64(status.Connections.Accepted - u.lastStatus.Connections.Accepted)},
+			&proto.SimpleMetric{Name: "unit.connections.closed", Value: float64(status.Connections.Closed - u.lastStatus.Connections.Closed)},
+			&proto.SimpleMetric{Name: "unit.requests.count", Value: float64(status.Requests.Total - u.lastStatus.Requests.Total)},
+		)
+	}
+
+	dimensions := []*proto.Dimension{
+		{Name: "system_id", Value: u.env.GetSystemUUID()},
+		{Name: "hostname", Value: u.env.GetHostname()},
+		{Name: "unit_id", Value: u.unitId},
+	}
+
+	return &proto.MetricsReport{
+		Meta: &proto.Metadata{
+			Timestamp: types.TimestampNow(),
+		},
+		Type: proto.MetricsReport_SYSTEM,
+		Data: []*proto.StatsEntity{metrics.NewStatsEntity(dimensions, samples)},
+	}
+}
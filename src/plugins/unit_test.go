@@ -0,0 +1,152 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	"github.com/nginx/agent/v2/src/core/unit"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+// fakeUnitControl emulates the Unit control API on a unix socket
+type fakeUnitControl struct {
+	mu       sync.Mutex
+	config   string
+	requests uint64
+}
+
+func (f *fakeUnitControl) serve(t *testing.T) string {
+	socket := filepath.Join(t.TempDir(), "control.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "unit/1.32.0")
+		fmt.Fprint(w, `{"certificates":{},"config":{}}`)
+	})
+	mux.HandleFunc("/config/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Server", "unit/1.32.0")
+		fmt.Fprint(w, f.config)
+	})
+	mux.HandleFunc("/status/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Server", "unit/1.32.0")
+		fmt.Fprintf(w, `{"connections":{"accepted":%d,"active":2,"idle":1,"closed":%d},"requests":{"total":%d}}`,
+			f.requests, f.requests, f.requests)
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Close() })
+
+	return socket
+}
+
+func (f *fakeUnitControl) set(config string, requests uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = config
+	f.requests = requests
+}
+
+func TestUnitReport(t *testing.T) {
+	control := &fakeUnitControl{config: `{"listeners":{}}`}
+	socket := control.serve(t)
+
+	conf := &config.Config{
+		Unit: config.Unit{
+			Enable:         true,
+			ControlSocket:  socket,
+			ReportInterval: time.Hour,
+		},
+	}
+
+	pluginUnderTest := NewUnit(conf, tutils.GetMockEnv())
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.messagePipeline = messagePipe
+	pluginUnderTest.ctx = context.Background()
+	pluginUnderTest.client = unit.NewClient(socket)
+	pluginUnderTest.unitId = core.GenerateNginxID("%s_%s", "12345678", socket)
+
+	// the first report announces the instance and establishes the counter baseline
+	pluginUnderTest.report()
+
+	messages := messagePipe.GetMessages()
+	require.Len(t, messages, 2)
+
+	assert.Equal(t, core.DataplaneSoftwareDetailsUpdated, messages[0].Topic())
+	details, ok := messages[0].Data().(*proto.DataplaneSoftwareDetails_UnitDetails)
+	require.True(t, ok)
+	assert.Equal(t, "1.32.0", details.UnitDetails.GetVersion())
+	assert.Equal(t, socket, details.UnitDetails.GetControlSocket())
+	assert.NotEmpty(t, details.UnitDetails.GetConfigChecksum())
+	firstChecksum := details.UnitDetails.GetConfigChecksum()
+
+	assert.Equal(t, core.MetricReport, messages[1].Topic())
+	report, ok := messages[1].Data().(*proto.MetricsReport)
+	require.True(t, ok)
+	baseline := simpleMetricMap(report)
+	assert.Equal(t, float64(2), baseline["unit.connections.active"])
+	assert.NotContains(t, baseline, "unit.requests.count")
+
+	// a changed config and counters produce new details and counter deltas
+	control.set(`{"listeners":{"*:8080":{"pass":"applications/app"}}}`, 7)
+	pluginUnderTest.report()
+
+	messages = messagePipe.GetMessages()
+	require.Len(t, messages, 4)
+
+	details, ok = messages[2].Data().(*proto.DataplaneSoftwareDetails_UnitDetails)
+	require.True(t, ok)
+	assert.NotEqual(t, firstChecksum, details.UnitDetails.GetConfigChecksum())
+
+	report, ok = messages[3].Data().(*proto.MetricsReport)
+	require.True(t, ok)
+	samples := simpleMetricMap(report)
+	assert.Equal(t, float64(7), samples["unit.requests.count"])
+	assert.Equal(t, float64(7), samples["unit.connections.accepted"])
+
+	// nothing changed, so only metrics are reported
+	pluginUnderTest.report()
+	messages = messagePipe.GetMessages()
+	require.Len(t, messages, 5)
+	assert.Equal(t, core.MetricReport, messages[4].Topic())
+}
+
+func TestUnitSubscriptions(t *testing.T) {
+	pluginUnderTest := NewUnit(&config.Config{}, tutils.GetMockEnv())
+	assert.Empty(t, pluginUnderTest.Subscriptions())
+}
+
+func simpleMetricMap(report *proto.MetricsReport) map[string]float64 {
+	values := map[string]float64{}
+	for _, entity := range report.GetData() {
+		for _, metric := range entity.GetSimplemetrics() {
+			values[metric.GetName()] = metric.GetValue()
+		}
+	}
+	return values
+}
@@ -0,0 +1,302 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	plusclient "github.com/nginxinc/nginx-plus-go-client/client"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+// UpstreamDrain changes the administrative state of a named member of an NGINX Plus
+// upstream through the Plus API, so nodes can be drained out of rotation from the
+// management server before maintenance and brought back afterwards without touching
+// the configuration. The resulting state of the member is reported back in the
+// command status.
+type UpstreamDrain struct {
+	messagePipeline core.MessagePipeInterface
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	detailsMutex    sync.Mutex
+	nginxDetails    map[string]*proto.NginxDetails
+}
+
+func NewUpstreamDrain(conf *config.Config, env core.Environment, binary core.NginxBinary) *UpstreamDrain {
+	return &UpstreamDrain{
+		conf:         conf,
+		env:          env,
+		binary:       binary,
+		nginxDetails: make(map[string]*proto.NginxDetails),
+	}
+}
+
+func (ud *UpstreamDrain) Init(pipeline core.MessagePipeInterface) {
+	log.Info("UpstreamDrain initializing")
+	ud.messagePipeline = pipeline
+}
+
+func (ud *UpstreamDrain) Close() {
+	log.Info("UpstreamDrain is wrapping up")
+}
+
+func (ud *UpstreamDrain) Info() *core.Info {
+	return core.NewInfo("UpstreamDrain", "v0.0.1")
+}
+
+func (ud *UpstreamDrain) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.NginxInstancesFound):
+		nginxDetailsMap, ok := msg.Data().(map[string]*proto.NginxDetails)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		ud.detailsMutex.Lock()
+		ud.nginxDetails = nginxDetailsMap
+		ud.detailsMutex.Unlock()
+	case msg.Exact(core.UpstreamDrain):
+		cmd, ok := msg.Data().(*proto.Command)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		ud.drain(cmd)
+	}
+}
+
+func (ud *UpstreamDrain) Subscriptions() []string {
+	return []string{
+		core.UpstreamDrain,
+		core.NginxInstancesFound,
+	}
+}
+
+// drain applies the requested state change against the Plus API of the targeted
+// instance and responds with a command status describing the resulting state of the
+// upstream server.
+func (ud *UpstreamDrain) drain(cmd *proto.Command) {
+	request := cmd.GetUpstreamDrainRequest()
+
+	message, err := ud.applyRequest(request)
+	if err != nil {
+		log.Errorf("UpstreamDrain: changing the state of upstream %s server %s failed: %v", request.GetUpstream(), request.GetServer(), err)
+		ud.respond(cmd, proto.CommandStatusResponse_CMD_ERROR, fmt.Sprintf("upstream %s server %s state change failed", request.GetUpstream(), request.GetServer()), err.Error())
+		return
+	}
+
+	log.Infof("UpstreamDrain: %s", message)
+	ud.respond(cmd, proto.CommandStatusResponse_CMD_OK, message, "")
+}
+
+func (ud *UpstreamDrain) applyRequest(request *proto.UpstreamDrainRequest) (string, error) {
+	if request == nil {
+		return "", fmt.Errorf("upstream drain request is empty")
+	}
+	if request.GetUpstream() == "" {
+		return "", fmt.Errorf("upstream drain request has no upstream")
+	}
+	if request.GetServer() == "" {
+		return "", fmt.Errorf("upstream drain request has no server")
+	}
+
+	detail, err := ud.plusInstance(request.GetNginxId())
+	if err != nil {
+		return "", err
+	}
+	client, err := plusclient.NewNginxClient(&http.Client{}, detail.GetStatusUrl())
+	if err != nil {
+		return "", err
+	}
+
+	if request.GetStream() {
+		return ud.applyStream(client, detail.GetStatusUrl(), request)
+	}
+	return ud.applyHTTP(client, detail.GetStatusUrl(), request)
+}
+
+func (ud *UpstreamDrain) applyHTTP(client *plusclient.NginxClient, statusURL string, request *proto.UpstreamDrainRequest) (string, error) {
+	id, err := findHTTPUpstreamServer(client, request.GetUpstream(), request.GetServer())
+	if err != nil {
+		return "", err
+	}
+
+	// the plus client omits a false drain flag from its patch payloads, which would
+	// leave a draining server stuck, so the body is marshalled here with both flags
+	// explicit
+	state := struct {
+		Down  bool `json:"down"`
+		Drain bool `json:"drain"`
+	}{
+		Down:  request.GetState() == proto.UpstreamDrainRequest_DOWN,
+		Drain: request.GetState() == proto.UpstreamDrainRequest_DRAIN,
+	}
+	path := fmt.Sprintf("http/upstreams/%v/servers/%v", request.GetUpstream(), id)
+	if err := patchUpstreamServer(statusURL, client.Version(), path, state); err != nil {
+		return "", err
+	}
+
+	servers, err := client.GetHTTPServers(request.GetUpstream())
+	if err != nil {
+		return "", err
+	}
+	for _, server := range servers {
+		if server.Server == request.GetServer() {
+			return fmt.Sprintf("upstream %s server %s is now %s", request.GetUpstream(), request.GetServer(), httpServerState(server)), nil
+		}
+	}
+	return "", fmt.Errorf("server %s disappeared from upstream %s", request.GetServer(), request.GetUpstream())
+}
+
+func (ud *UpstreamDrain) applyStream(client *plusclient.NginxClient, statusURL string, request *proto.UpstreamDrainRequest) (string, error) {
+	// stream upstream servers cannot drain, they only switch between up and down
+	if request.GetState() == proto.UpstreamDrainRequest_DRAIN {
+		return "", fmt.Errorf("stream upstream %s does not support drain", request.GetUpstream())
+	}
+
+	id, err := findStreamUpstreamServer(client, request.GetUpstream(), request.GetServer())
+	if err != nil {
+		return "", err
+	}
+
+	state := struct {
+		Down bool `json:"down"`
+	}{
+		Down: request.GetState() == proto.UpstreamDrainRequest_DOWN,
+	}
+	path := fmt.Sprintf("stream/upstreams/%v/servers/%v", request.GetUpstream(), id)
+	if err := patchUpstreamServer(statusURL, client.Version(), path, state); err != nil {
+		return "", err
+	}
+
+	servers, err := client.GetStreamServers(request.GetUpstream())
+	if err != nil {
+		return "", err
+	}
+	for _, server := range servers {
+		if server.Server == request.GetServer() {
+			state := "up"
+			if server.Down != nil && *server.Down {
+				state = "down"
+			}
+			return fmt.Sprintf("stream upstream %s server %s is now %s", request.GetUpstream(), request.GetServer(), state), nil
+		}
+	}
+	return "", fmt.Errorf("server %s disappeared from stream upstream %s", request.GetServer(), request.GetUpstream())
+}
+
+func findHTTPUpstreamServer(client *plusclient.NginxClient, upstream, address string) (int, error) {
+	servers, err := client.GetHTTPServers(upstream)
+	if err != nil {
+		return 0, err
+	}
+	for _, server := range servers {
+		if server.Server == address {
+			return server.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("server %s not found in upstream %s", address, upstream)
+}
+
+func findStreamUpstreamServer(client *plusclient.NginxClient, upstream, address string) (int, error) {
+	servers, err := client.GetStreamServers(upstream)
+	if err != nil {
+		return 0, err
+	}
+	for _, server := range servers {
+		if server.Server == address {
+			return server.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("server %s not found in stream upstream %s", address, upstream)
+}
+
+func patchUpstreamServer(statusURL string, version int, path string, state interface{}) error {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/%d/%s", statusURL, version, path), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := (&http.Client{}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to patch upstream server %s: %v", path, resp.Status)
+	}
+	return nil
+}
+
+func httpServerState(server plusclient.UpstreamServer) string {
+	switch {
+	case server.Drain:
+		return "draining"
+	case server.Down != nil && *server.Down:
+		return "down"
+	default:
+		return "up"
+	}
+}
+
+// plusInstance returns the details of the requested instance, or of the only local
+// Plus instance when the request does not name one
+func (ud *UpstreamDrain) plusInstance(nginxID string) (*proto.NginxDetails, error) {
+	ud.detailsMutex.Lock()
+	defer ud.detailsMutex.Unlock()
+
+	detail := ud.nginxDetails[nginxID]
+	if detail == nil && nginxID == "" {
+		for _, candidate := range ud.nginxDetails {
+			if candidate.GetPlus().GetEnabled() {
+				detail = candidate
+				break
+			}
+		}
+	}
+	if detail == nil {
+		return nil, fmt.Errorf("no nginx instance found for the request")
+	}
+	if !detail.GetPlus().GetEnabled() {
+		return nil, fmt.Errorf("nginx instance %s is not an NGINX Plus instance", detail.GetNginxId())
+	}
+	if detail.GetStatusUrl() == "" {
+		return nil, fmt.Errorf("no Plus API configured for nginx instance %s", detail.GetNginxId())
+	}
+
+	return detail, nil
+}
+
+func (ud *UpstreamDrain) respond(cmd *proto.Command, status proto.CommandStatusResponse_CommandStatus, message, errorMessage string) {
+	ud.messagePipeline.Process(core.NewMessage(core.CommResponse, &proto.Command{
+		Meta: cmd.GetMeta(),
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_CmdStatus{
+			CmdStatus: &proto.CommandStatusResponse{
+				Status:  status,
+				Message: message,
+				Error:   errorMessage,
+			},
+		},
+	}))
+}
@@ -0,0 +1,226 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+type fakeUpstreamServer struct {
+	ID     int    `json:"id"`
+	Server string `json:"server"`
+	Down   bool   `json:"down"`
+	Drain  bool   `json:"drain"`
+}
+
+// fakeUpstreamAPI emulates the parts of the Plus API the upstream drain uses:
+// version discovery and the server endpoints of one http and one stream upstream
+type fakeUpstreamAPI struct {
+	mu      sync.Mutex
+	name    string
+	servers []*fakeUpstreamServer
+	stream  bool
+}
+
+func (f *fakeUpstreamAPI) serve(t *testing.T) string {
+	base := "/http/upstreams/" + f.name + "/servers"
+	if f.stream {
+		base = "/stream/upstreams/" + f.name + "/servers"
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if r.URL.Path == "/api" {
+			fmt.Fprint(w, `[4,5,6,7,8]`)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, base):
+			json.NewEncoder(w).Encode(f.servers)
+		case r.Method == http.MethodPatch:
+			for _, member := range f.servers {
+				if !strings.HasSuffix(r.URL.Path, fmt.Sprintf("%s/%d", base, member.ID)) {
+					continue
+				}
+				// a patch only changes the fields present in the body
+				json.NewDecoder(r.Body).Decode(member)
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL + "/api"
+}
+
+func upstreamDrainProcess(t *testing.T, details map[string]*proto.NginxDetails, request *proto.UpstreamDrainRequest) []*proto.Command {
+	pluginUnderTest := NewUpstreamDrain(&config.Config{}, tutils.GetMockEnv(), tutils.GetMockNginxBinary())
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.messagePipeline = messagePipe
+
+	pluginUnderTest.Process(core.NewMessage(core.NginxInstancesFound, details))
+	pluginUnderTest.Process(core.NewMessage(core.UpstreamDrain, &proto.Command{
+		Meta: &proto.Metadata{MessageId: "123"},
+		Data: &proto.Command_UpstreamDrainRequest{UpstreamDrainRequest: request},
+	}))
+
+	return keyvalSyncResponses(messagePipe)
+}
+
+func upstreamDrainDetails(api *fakeUpstreamAPI, t *testing.T) map[string]*proto.NginxDetails {
+	return map[string]*proto.NginxDetails{
+		"12345": {
+			NginxId:   "12345",
+			StatusUrl: api.serve(t),
+			Plus:      &proto.NginxPlusMetaData{Enabled: true},
+		},
+	}
+}
+
+func TestUpstreamDrainHTTP(t *testing.T) {
+	api := &fakeUpstreamAPI{
+		name: "backend",
+		servers: []*fakeUpstreamServer{
+			{ID: 0, Server: "10.0.0.1:80"},
+			{ID: 1, Server: "10.0.0.2:80"},
+		},
+	}
+
+	responses := upstreamDrainProcess(t, upstreamDrainDetails(api, t), &proto.UpstreamDrainRequest{
+		NginxId:  "12345",
+		Upstream: "backend",
+		Server:   "10.0.0.2:80",
+		State:    proto.UpstreamDrainRequest_DRAIN,
+	})
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_OK, responses[0].GetCmdStatus().GetStatus())
+	assert.Equal(t, "upstream backend server 10.0.0.2:80 is now draining", responses[0].GetCmdStatus().GetMessage())
+	assert.True(t, api.servers[1].Drain)
+	assert.False(t, api.servers[0].Drain)
+}
+
+func TestUpstreamDrainHTTPBackUp(t *testing.T) {
+	api := &fakeUpstreamAPI{
+		name: "backend",
+		servers: []*fakeUpstreamServer{
+			{ID: 0, Server: "10.0.0.1:80", Down: true, Drain: true},
+		},
+	}
+
+	responses := upstreamDrainProcess(t, upstreamDrainDetails(api, t), &proto.UpstreamDrainRequest{
+		NginxId:  "12345",
+		Upstream: "backend",
+		Server:   "10.0.0.1:80",
+		State:    proto.UpstreamDrainRequest_UP,
+	})
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_OK, responses[0].GetCmdStatus().GetStatus())
+	assert.Equal(t, "upstream backend server 10.0.0.1:80 is now up", responses[0].GetCmdStatus().GetMessage())
+	assert.False(t, api.servers[0].Down)
+	assert.False(t, api.servers[0].Drain)
+}
+
+func TestUpstreamDrainStreamDown(t *testing.T) {
+	api := &fakeUpstreamAPI{
+		name:    "tcp_backend",
+		stream:  true,
+		servers: []*fakeUpstreamServer{{ID: 0, Server: "10.0.0.1:5432"}},
+	}
+
+	responses := upstreamDrainProcess(t, upstreamDrainDetails(api, t), &proto.UpstreamDrainRequest{
+		NginxId:  "12345",
+		Upstream: "tcp_backend",
+		Server:   "10.0.0.1:5432",
+		State:    proto.UpstreamDrainRequest_DOWN,
+		Stream:   true,
+	})
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_OK, responses[0].GetCmdStatus().GetStatus())
+	assert.Equal(t, "stream upstream tcp_backend server 10.0.0.1:5432 is now down", responses[0].GetCmdStatus().GetMessage())
+	assert.True(t, api.servers[0].Down)
+}
+
+func TestUpstreamDrainStreamRejectsDrain(t *testing.T) {
+	api := &fakeUpstreamAPI{
+		name:    "tcp_backend",
+		stream:  true,
+		servers: []*fakeUpstreamServer{{ID: 0, Server: "10.0.0.1:5432"}},
+	}
+
+	responses := upstreamDrainProcess(t, upstreamDrainDetails(api, t), &proto.UpstreamDrainRequest{
+		NginxId:  "12345",
+		Upstream: "tcp_backend",
+		Server:   "10.0.0.1:5432",
+		State:    proto.UpstreamDrainRequest_DRAIN,
+		Stream:   true,
+	})
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_ERROR, responses[0].GetCmdStatus().GetStatus())
+	assert.Contains(t, responses[0].GetCmdStatus().GetError(), "does not support drain")
+}
+
+func TestUpstreamDrainUnknownServer(t *testing.T) {
+	api := &fakeUpstreamAPI{
+		name:    "backend",
+		servers: []*fakeUpstreamServer{{ID: 0, Server: "10.0.0.1:80"}},
+	}
+
+	responses := upstreamDrainProcess(t, upstreamDrainDetails(api, t), &proto.UpstreamDrainRequest{
+		NginxId:  "12345",
+		Upstream: "backend",
+		Server:   "10.0.0.9:80",
+		State:    proto.UpstreamDrainRequest_DOWN,
+	})
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_ERROR, responses[0].GetCmdStatus().GetStatus())
+	assert.Contains(t, responses[0].GetCmdStatus().GetError(), "not found in upstream backend")
+}
+
+func TestUpstreamDrainRejectsNonPlusInstance(t *testing.T) {
+	responses := upstreamDrainProcess(t, tutils.GetDetailsMap(), &proto.UpstreamDrainRequest{
+		NginxId:  "12345",
+		Upstream: "backend",
+		Server:   "10.0.0.1:80",
+		State:    proto.UpstreamDrainRequest_DOWN,
+	})
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_ERROR, responses[0].GetCmdStatus().GetStatus())
+}
+
+func TestUpstreamDrainSubscriptions(t *testing.T) {
+	pluginUnderTest := NewUpstreamDrain(&config.Config{}, tutils.GetMockEnv(), tutils.GetMockNginxBinary())
+	assert.Equal(t, []string{core.UpstreamDrain, core.NginxInstancesFound}, pluginUnderTest.Subscriptions())
+}
@@ -0,0 +1,354 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	sdk "github.com/nginx/agent/sdk/v2"
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const (
+	// upstreamIncludePrefix names the generated include files holding the server list
+	// of a switched upstream
+	upstreamIncludePrefix = "agent_upstream_"
+	upstreamIncludeMarker = "# upstream switch managed by nginx-agent"
+	upstreamProbeTimeout  = 5 * time.Second
+)
+
+// UpstreamSwitch replaces the server list of a designated upstream in one step, so
+// blue/green deployments can be driven from the management server. The new list is
+// generated into a dedicated include file that is wired into the upstream block in
+// place of the previous server directives, the modified config is validated before
+// the reload, and the switch is rolled back when the new servers fail the
+// post-switch health probes.
+type UpstreamSwitch struct {
+	messagePipeline core.MessagePipeInterface
+	conf            *config.Config
+	env             core.Environment
+	binary          core.NginxBinary
+	detailsMutex    sync.Mutex
+	nginxDetails    map[string]*proto.NginxDetails
+}
+
+func NewUpstreamSwitch(conf *config.Config, env core.Environment, binary core.NginxBinary) *UpstreamSwitch {
+	return &UpstreamSwitch{
+		conf:         conf,
+		env:          env,
+		binary:       binary,
+		nginxDetails: make(map[string]*proto.NginxDetails),
+	}
+}
+
+func (us *UpstreamSwitch) Init(pipeline core.MessagePipeInterface) {
+	log.Info("UpstreamSwitch initializing")
+	us.messagePipeline = pipeline
+}
+
+func (us *UpstreamSwitch) Close() {
+	log.Info("UpstreamSwitch is wrapping up")
+}
+
+func (us *UpstreamSwitch) Info() *core.Info {
+	return core.NewInfo("UpstreamSwitch", "v0.0.1")
+}
+
+func (us *UpstreamSwitch) Process(msg *core.Message) {
+	switch {
+	case msg.Exact(core.NginxInstancesFound):
+		nginxDetailsMap, ok := msg.Data().(map[string]*proto.NginxDetails)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		us.detailsMutex.Lock()
+		us.nginxDetails = nginxDetailsMap
+		us.detailsMutex.Unlock()
+	case msg.Exact(core.UpstreamSwitch):
+		cmd, ok := msg.Data().(*proto.Command)
+		if !ok {
+			log.Warnf("Invalid message received, %T, for topic, %s", msg.Data(), msg.Topic())
+			return
+		}
+		us.switchUpstream(cmd)
+	}
+}
+
+func (us *UpstreamSwitch) Subscriptions() []string {
+	return []string{
+		core.UpstreamSwitch,
+		core.NginxInstancesFound,
+	}
+}
+
+func (us *UpstreamSwitch) switchUpstream(cmd *proto.Command) {
+	request := cmd.GetUpstreamSwitchRequest()
+
+	if err := us.applyRequest(request); err != nil {
+		log.Errorf("UpstreamSwitch: switching upstream %s failed: %v", request.GetUpstream(), err)
+		us.respond(cmd, proto.CommandStatusResponse_CMD_ERROR, fmt.Sprintf("switch of upstream %s failed", request.GetUpstream()), err.Error())
+		return
+	}
+
+	message := fmt.Sprintf("upstream %s switched to %d servers", request.GetUpstream(), len(request.GetServers()))
+	log.Infof("UpstreamSwitch: %s", message)
+	us.respond(cmd, proto.CommandStatusResponse_CMD_OK, message, "")
+}
+
+func (us *UpstreamSwitch) applyRequest(request *proto.UpstreamSwitchRequest) error {
+	if request == nil {
+		return fmt.Errorf("upstream switch request is empty")
+	}
+	if !validUpstreamName(request.GetUpstream()) {
+		return fmt.Errorf("invalid upstream name %q", request.GetUpstream())
+	}
+	if len(request.GetServers()) == 0 {
+		return fmt.Errorf("the new server list is empty")
+	}
+	for _, server := range request.GetServers() {
+		if err := validUpstreamServer(server); err != nil {
+			return err
+		}
+	}
+
+	detail, err := us.instance(request.GetNginxId())
+	if err != nil {
+		return err
+	}
+
+	location, err := sdk.FindUpstreamBlock(detail.GetConfPath(), request.GetUpstream())
+	if err != nil {
+		return err
+	}
+
+	includeFile := filepath.Join(filepath.Dir(detail.GetConfPath()), upstreamIncludePrefix+request.GetUpstream()+".conf")
+
+	content, err := os.ReadFile(location.File)
+	if err != nil {
+		return err
+	}
+	updated, err := rewriteUpstreamBlock(string(content), location.Line, includeFile)
+	if err != nil {
+		return err
+	}
+
+	_, statErr := os.Stat(includeFile)
+	createdInclude := os.IsNotExist(statErr)
+
+	backups, err := backupFiles(location.File, includeFile)
+	if err != nil {
+		return err
+	}
+	rollback := func() {
+		restoreFiles(backups)
+		if createdInclude {
+			os.Remove(includeFile)
+		}
+	}
+
+	if err := os.WriteFile(includeFile, []byte(upstreamSnippet(request)), 0644); err != nil {
+		rollback()
+		return err
+	}
+	if err := os.WriteFile(location.File, []byte(updated), 0644); err != nil {
+		rollback()
+		return err
+	}
+
+	if err := us.binary.ValidateConfig(detail.GetNginxId(), detail.GetProcessPath(), detail.GetConfPath(), nil, nil); err != nil {
+		rollback()
+		return fmt.Errorf("validation of the switched config failed: %v", err)
+	}
+	if err := us.binary.Reload(detail.GetProcessId(), detail.GetProcessPath()); err != nil {
+		rollback()
+		if reloadErr := us.binary.Reload(detail.GetProcessId(), detail.GetProcessPath()); reloadErr != nil {
+			log.Errorf("UpstreamSwitch: reload after rollback failed: %v", reloadErr)
+		}
+		return fmt.Errorf("reload with the switched upstream: %v", err)
+	}
+
+	if err := probeUpstreamServers(request.GetServers(), request.GetProbePath()); err != nil {
+		rollback()
+		if reloadErr := us.binary.Reload(detail.GetProcessId(), detail.GetProcessPath()); reloadErr != nil {
+			log.Errorf("UpstreamSwitch: reload after rollback failed: %v", reloadErr)
+		}
+		return fmt.Errorf("switch rolled back: %v", err)
+	}
+
+	removeFiles(backups)
+	return nil
+}
+
+func (us *UpstreamSwitch) instance(nginxID string) (*proto.NginxDetails, error) {
+	us.detailsMutex.Lock()
+	defer us.detailsMutex.Unlock()
+
+	detail := us.nginxDetails[nginxID]
+	if detail == nil && nginxID == "" && len(us.nginxDetails) == 1 {
+		for _, candidate := range us.nginxDetails {
+			detail = candidate
+		}
+	}
+	if detail == nil {
+		return nil, fmt.Errorf("no nginx instance found for the request")
+	}
+	if detail.GetConfPath() == "" {
+		return nil, fmt.Errorf("no config path known for nginx instance %s", detail.GetNginxId())
+	}
+	return detail, nil
+}
+
+// upstreamSnippet renders the dedicated include file holding the new server list.
+func upstreamSnippet(request *proto.UpstreamSwitchRequest) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "# Server list of upstream %s, generated by nginx-agent. Do not edit.\n", request.GetUpstream())
+	for _, server := range request.GetServers() {
+		fmt.Fprintf(&builder, "server %s", server.GetAddress())
+		if server.GetWeight() > 0 {
+			fmt.Fprintf(&builder, " weight=%d", server.GetWeight())
+		}
+		if server.GetBackup() {
+			builder.WriteString(" backup")
+		}
+		builder.WriteString(";\n")
+	}
+	return builder.String()
+}
+
+// rewriteUpstreamBlock replaces the server directives of the upstream block that
+// starts at upstreamLine with a single include of the generated file, leaving every
+// other directive of the block in place.
+func rewriteUpstreamBlock(content string, upstreamLine int, includeFile string) (string, error) {
+	includeLine := fmt.Sprintf("    include %s; %s", includeFile, upstreamIncludeMarker)
+
+	lines := strings.Split(content, "\n")
+	depth := 0
+	opened := false
+	start, end := -1, -1
+	for i := upstreamLine - 1; i < len(lines); i++ {
+		depth += strings.Count(lines[i], "{") - strings.Count(lines[i], "}")
+		if !opened && strings.Contains(lines[i], "{") {
+			opened = true
+			start = i
+		}
+		if opened && depth <= 0 {
+			end = i
+			break
+		}
+	}
+	if !opened || end == -1 {
+		return "", fmt.Errorf("no block found for the upstream at line %d", upstreamLine)
+	}
+	if end == start {
+		return "", fmt.Errorf("the upstream block at line %d is written on a single line", upstreamLine)
+	}
+
+	updated := append([]string{}, lines[:start+1]...)
+	updated = append(updated, includeLine)
+	for i := start + 1; i <= end; i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, "server ") || strings.HasPrefix(trimmed, "server\t") {
+			continue
+		}
+		if strings.Contains(lines[i], includeFile) {
+			continue
+		}
+		updated = append(updated, lines[i])
+	}
+	updated = append(updated, lines[end+1:]...)
+	return strings.Join(updated, "\n"), nil
+}
+
+// probeUpstreamServers checks that the new servers answer after the switch. Backup
+// servers are skipped since they are allowed to be down while the primaries serve.
+func probeUpstreamServers(servers []*proto.UpstreamSwitchRequest_Server, probePath string) error {
+	client := &http.Client{Timeout: upstreamProbeTimeout}
+	for _, server := range servers {
+		if server.GetBackup() {
+			continue
+		}
+		if probePath == "" {
+			conn, err := net.DialTimeout("tcp", server.GetAddress(), upstreamProbeTimeout)
+			if err != nil {
+				return fmt.Errorf("health probe of %s failed: %v", server.GetAddress(), err)
+			}
+			conn.Close()
+			continue
+		}
+
+		path := probePath
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		resp, err := client.Get(fmt.Sprintf("http://%s%s", server.GetAddress(), path))
+		if err != nil {
+			return fmt.Errorf("health probe of %s failed: %v", server.GetAddress(), err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("health probe of %s returned status %d", server.GetAddress(), resp.StatusCode)
+		}
+	}
+	return nil
+}
+
+func validUpstreamName(upstream string) bool {
+	if upstream == "" {
+		return false
+	}
+	for _, r := range upstream {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '.' || r == '-' || r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validUpstreamServer rejects addresses that could not be a server directive
+// argument or that would break out of the generated directive.
+func validUpstreamServer(server *proto.UpstreamSwitchRequest_Server) error {
+	if server.GetAddress() == "" {
+		return fmt.Errorf("a server in the new list has no address")
+	}
+	if strings.ContainsAny(server.GetAddress(), " \t\r\n;{}#\"'") {
+		return fmt.Errorf("server address %q contains characters that are not allowed", server.GetAddress())
+	}
+	if server.GetWeight() < 0 {
+		return fmt.Errorf("server %s has a negative weight", server.GetAddress())
+	}
+	return nil
+}
+
+func (us *UpstreamSwitch) respond(cmd *proto.Command, status proto.CommandStatusResponse_CommandStatus, message, errorMessage string) {
+	us.messagePipeline.Process(core.NewMessage(core.CommResponse, &proto.Command{
+		Meta: cmd.GetMeta(),
+		Type: proto.Command_NORMAL,
+		Data: &proto.Command_CmdStatus{
+			CmdStatus: &proto.CommandStatusResponse{
+				Status:  status,
+				Message: message,
+				Error:   errorMessage,
+			},
+		},
+	}))
+}
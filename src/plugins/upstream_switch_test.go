@@ -0,0 +1,203 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func writeSwitchTestConfig(t *testing.T) (confFile string) {
+	t.Helper()
+	dir := t.TempDir()
+	confFile = filepath.Join(dir, "nginx.conf")
+	require.NoError(t, os.WriteFile(confFile, []byte(`events {}
+http {
+    upstream backend {
+        least_conn;
+        server 10.0.0.1:8080;
+        server 10.0.0.2:8080 backup;
+    }
+    server {
+        listen 80;
+    }
+}
+`), 0644))
+	return confFile
+}
+
+func switchTestPlugin(t *testing.T, confFile string, binary *tutils.MockNginxBinary) (*UpstreamSwitch, *core.MockMessagePipe) {
+	t.Helper()
+	pluginUnderTest := NewUpstreamSwitch(&config.Config{}, tutils.GetMockEnv(), binary)
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.messagePipeline = messagePipe
+	pluginUnderTest.nginxDetails = map[string]*proto.NginxDetails{
+		"12345": {NginxId: "12345", ProcessId: "1", ProcessPath: "/usr/sbin/nginx", ConfPath: confFile},
+	}
+	return pluginUnderTest, messagePipe
+}
+
+func switchCommand(upstream, probePath string, servers ...*proto.UpstreamSwitchRequest_Server) *proto.Command {
+	return &proto.Command{
+		Meta: &proto.Metadata{MessageId: "123"},
+		Data: &proto.Command_UpstreamSwitchRequest{
+			UpstreamSwitchRequest: &proto.UpstreamSwitchRequest{
+				NginxId:   "12345",
+				Upstream:  upstream,
+				Servers:   servers,
+				ProbePath: probePath,
+			},
+		},
+	}
+}
+
+func TestUpstreamSwitch(t *testing.T) {
+	green := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer green.Close()
+	greenAddress := strings.TrimPrefix(green.URL, "http://")
+
+	confFile := writeSwitchTestConfig(t)
+	includeFile := filepath.Join(filepath.Dir(confFile), "agent_upstream_backend.conf")
+
+	binary := tutils.NewMockNginxBinary()
+	binary.On("ValidateConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	binary.On("Reload", mock.Anything, mock.Anything)
+
+	pluginUnderTest, messagePipe := switchTestPlugin(t, confFile, binary)
+
+	pluginUnderTest.Process(core.NewMessage(core.UpstreamSwitch, switchCommand("backend", "/health",
+		&proto.UpstreamSwitchRequest_Server{Address: greenAddress, Weight: 3},
+		&proto.UpstreamSwitchRequest_Server{Address: "127.0.0.1:1", Backup: true},
+	)))
+
+	responses := mirrorResponses(messagePipe)
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_OK, responses[0].GetStatus())
+	assert.Contains(t, responses[0].GetMessage(), "switched to 2 servers")
+
+	snippet, err := os.ReadFile(includeFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(snippet), fmt.Sprintf("server %s weight=3;", greenAddress))
+	// a backup server may be down, so it is listed but was not probed
+	assert.Contains(t, string(snippet), "server 127.0.0.1:1 backup;")
+
+	conf, err := os.ReadFile(confFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(conf), fmt.Sprintf("include %s;", includeFile))
+	// the old server directives are gone, other upstream directives stay
+	assert.NotContains(t, string(conf), "10.0.0.1:8080")
+	assert.NotContains(t, string(conf), "10.0.0.2:8080")
+	assert.Contains(t, string(conf), "least_conn;")
+
+	// a second switch rewrites the include file without a second include line
+	messagePipe.ClearMessages()
+	pluginUnderTest.Process(core.NewMessage(core.UpstreamSwitch, switchCommand("backend", "/health",
+		&proto.UpstreamSwitchRequest_Server{Address: greenAddress},
+	)))
+	responses = mirrorResponses(messagePipe)
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_OK, responses[0].GetStatus())
+	conf, err = os.ReadFile(confFile)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(conf), "include "+includeFile))
+	snippet, err = os.ReadFile(includeFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(snippet), "weight=3")
+}
+
+func TestUpstreamSwitchProbeFailureRollsBack(t *testing.T) {
+	confFile := writeSwitchTestConfig(t)
+	original, err := os.ReadFile(confFile)
+	require.NoError(t, err)
+	includeFile := filepath.Join(filepath.Dir(confFile), "agent_upstream_backend.conf")
+
+	binary := tutils.NewMockNginxBinary()
+	binary.On("ValidateConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	binary.On("Reload", mock.Anything, mock.Anything)
+
+	pluginUnderTest, messagePipe := switchTestPlugin(t, confFile, binary)
+
+	// nothing listens on the new address, so the probe fails and the switch is undone
+	pluginUnderTest.Process(core.NewMessage(core.UpstreamSwitch, switchCommand("backend", "",
+		&proto.UpstreamSwitchRequest_Server{Address: "127.0.0.1:1"},
+	)))
+
+	responses := mirrorResponses(messagePipe)
+	require.Len(t, responses, 1)
+	assert.Equal(t, proto.CommandStatusResponse_CMD_ERROR, responses[0].GetStatus())
+	assert.Contains(t, responses[0].GetError(), "switch rolled back")
+
+	conf, err := os.ReadFile(confFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(conf))
+	_, err = os.Stat(includeFile)
+	assert.True(t, os.IsNotExist(err))
+
+	// the rollback reloads the restored config: one reload for the switch, one back
+	binary.AssertNumberOfCalls(t, "Reload", 2)
+}
+
+func TestUpstreamSwitchRejectsBadRequests(t *testing.T) {
+	confFile := writeSwitchTestConfig(t)
+	pluginUnderTest, messagePipe := switchTestPlugin(t, confFile, tutils.NewMockNginxBinary())
+
+	badCommands := []*proto.Command{
+		switchCommand("backend", ""),
+		switchCommand("bad name", "", &proto.UpstreamSwitchRequest_Server{Address: "10.0.0.3:8080"}),
+		switchCommand("backend", "", &proto.UpstreamSwitchRequest_Server{Address: "10.0.0.3:8080; }"}),
+		switchCommand("missing", "", &proto.UpstreamSwitchRequest_Server{Address: "10.0.0.3:8080"}),
+	}
+	for _, cmd := range badCommands {
+		pluginUnderTest.Process(core.NewMessage(core.UpstreamSwitch, cmd))
+	}
+
+	responses := mirrorResponses(messagePipe)
+	require.Len(t, responses, len(badCommands))
+	for _, response := range responses {
+		assert.Equal(t, proto.CommandStatusResponse_CMD_ERROR, response.GetStatus())
+	}
+}
+
+func TestRewriteUpstreamBlock(t *testing.T) {
+	content := `http {
+    upstream backend {
+        server 10.0.0.1:8080;
+        keepalive 32;
+    }
+}`
+	updated, err := rewriteUpstreamBlock(content, 2, "/etc/nginx/agent_upstream_backend.conf")
+	require.NoError(t, err)
+	assert.NotContains(t, updated, "10.0.0.1:8080")
+	assert.Contains(t, updated, "include /etc/nginx/agent_upstream_backend.conf;")
+	assert.Contains(t, updated, "keepalive 32;")
+
+	_, err = rewriteUpstreamBlock("upstream backend { server 10.0.0.1:8080; }", 1, "/tmp/x.conf")
+	assert.Error(t, err)
+}
+
+func TestUpstreamSwitchSubscriptions(t *testing.T) {
+	pluginUnderTest := NewUpstreamSwitch(&config.Config{}, tutils.GetMockEnv(), tutils.GetMockNginxBinary())
+	assert.Equal(t, []string{core.UpstreamSwitch, core.NginxInstancesFound}, pluginUnderTest.Subscriptions())
+}
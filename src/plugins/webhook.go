@@ -0,0 +1,223 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+)
+
+const (
+	webhookTypeGeneric   = "generic"
+	webhookTypeSlack     = "slack"
+	webhookTypePagerDuty = "pagerduty"
+
+	// webhookQueueSize bounds the events waiting for delivery, so a slow or
+	// unreachable endpoint cannot back up the message pipe
+	webhookQueueSize = 100
+)
+
+// Webhook pushes selected events to an HTTP endpoint, so failures like a
+// config apply going wrong, a certificate about to expire or nginx going down
+// reach an on-call channel without a control plane in between. Payloads are
+// shaped for a generic JSON webhook, Slack or PagerDuty; delivery happens off
+// the message pipe with retries, and the endpoint secret is read from an
+// environment variable rather than the config file.
+type Webhook struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	conf   *config.Config
+	env    core.Environment
+	client *http.Client
+	secret string
+	levels map[string]struct{}
+	queue  chan *eventsProto.Event
+}
+
+func NewWebhook(conf *config.Config, env core.Environment) *Webhook {
+	levels := make(map[string]struct{})
+	for _, level := range conf.Webhook.Levels {
+		levels[level] = struct{}{}
+	}
+	return &Webhook{
+		conf:   conf,
+		env:    env,
+		client: &http.Client{Timeout: conf.Webhook.Timeout},
+		levels: levels,
+		queue:  make(chan *eventsProto.Event, webhookQueueSize),
+	}
+}
+
+func (w *Webhook) Init(pipeline core.MessagePipeInterface) {
+	log.Info("Webhook initializing")
+	w.ctx, w.cancel = context.WithCancel(pipeline.Context())
+
+	if w.conf.Webhook.SecretEnv != "" {
+		w.secret = os.Getenv(w.conf.Webhook.SecretEnv)
+		if w.secret == "" {
+			log.Warnf("The webhook secret environment variable %s is not set", w.conf.Webhook.SecretEnv)
+		}
+	}
+
+	go w.deliver()
+}
+
+func (w *Webhook) Close() {
+	log.Info("Webhook is wrapping up")
+	if w.cancel != nil {
+		w.cancel()
+	}
+}
+
+func (w *Webhook) Info() *core.Info {
+	return core.NewInfo("Webhook", "v0.0.1")
+}
+
+func (w *Webhook) Process(msg *core.Message) {
+	switch msg.Topic() {
+	case core.Events:
+		command, ok := msg.Data().(*proto.Command)
+		if !ok {
+			return
+		}
+		for _, event := range command.GetEventReport().GetEvents() {
+			if _, wanted := w.levels[event.GetMetadata().GetEventLevel()]; !wanted {
+				continue
+			}
+			select {
+			case w.queue <- event:
+			default:
+				log.Warn("Webhook delivery queue is full, dropping event")
+			}
+		}
+	}
+}
+
+func (w *Webhook) Subscriptions() []string {
+	return []string{
+		core.Events,
+	}
+}
+
+// deliver posts the queued events one at a time, retrying each with a fixed
+// backoff before giving up on it
+func (w *Webhook) deliver() {
+	for {
+		select {
+		case event := <-w.queue:
+			payload, err := w.buildPayload(event)
+			if err != nil {
+				log.Errorf("Unable to build the webhook payload: %v", err)
+				continue
+			}
+
+			for attempt := 0; attempt <= w.conf.Webhook.Retries; attempt++ {
+				if attempt > 0 {
+					select {
+					case <-time.After(w.conf.Webhook.RetryBackoff):
+					case <-w.ctx.Done():
+						return
+					}
+				}
+				if err = w.post(payload); err == nil {
+					break
+				}
+				log.Warnf("Webhook delivery attempt %d failed: %v", attempt+1, err)
+			}
+			if err != nil {
+				log.Errorf("Dropping event after %d webhook delivery attempts", w.conf.Webhook.Retries+1)
+			}
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *Webhook) post(payload []byte) error {
+	request, err := http.NewRequestWithContext(w.ctx, http.MethodPost, w.conf.Webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	// PagerDuty takes the secret in the payload as the routing key instead
+	if w.secret != "" && w.conf.Webhook.Type != webhookTypePagerDuty {
+		request.Header.Set("Authorization", "Bearer "+w.secret)
+	}
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", response.Status)
+	}
+	return nil
+}
+
+func (w *Webhook) buildPayload(event *eventsProto.Event) ([]byte, error) {
+	metadata := event.GetMetadata()
+	message := event.GetActivityEvent().GetMessage()
+	timestamp := time.Unix(metadata.GetTimestamp().GetSeconds(), int64(metadata.GetTimestamp().GetNanos())).UTC()
+
+	switch w.conf.Webhook.Type {
+	case webhookTypeSlack:
+		return json.Marshal(map[string]string{
+			"text": fmt.Sprintf("[%s] %s: %s", metadata.GetEventLevel(), w.env.GetHostname(), message),
+		})
+	case webhookTypePagerDuty:
+		return json.Marshal(map[string]interface{}{
+			"routing_key":  w.secret,
+			"event_action": "trigger",
+			"dedup_key":    metadata.GetCorrelationID(),
+			"payload": map[string]string{
+				"summary":   message,
+				"source":    w.env.GetHostname(),
+				"severity":  pagerDutySeverity(metadata.GetEventLevel()),
+				"timestamp": timestamp.Format(time.RFC3339),
+				"group":     metadata.GetCategory(),
+			},
+		})
+	default:
+		return json.Marshal(map[string]string{
+			"module":         metadata.GetModule(),
+			"type":           metadata.GetType(),
+			"category":       metadata.GetCategory(),
+			"level":          metadata.GetEventLevel(),
+			"message":        message,
+			"hostname":       w.env.GetHostname(),
+			"correlation_id": metadata.GetCorrelationID(),
+			"timestamp":      timestamp.Format(time.RFC3339),
+		})
+	}
+}
+
+func pagerDutySeverity(level string) string {
+	switch level {
+	case CRITICAL_EVENT_LEVEL:
+		return "critical"
+	case ERROR_EVENT_LEVEL:
+		return "error"
+	case WARN_EVENT_LEVEL:
+		return "warning"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,164 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/sdk/v2/proto"
+	eventsProto "github.com/nginx/agent/sdk/v2/proto/events"
+	"github.com/nginx/agent/v2/src/core"
+	"github.com/nginx/agent/v2/src/core/config"
+	tutils "github.com/nginx/agent/v2/test/utils"
+)
+
+func webhookTestConfig(url, webhookType string) *config.Config {
+	return &config.Config{
+		Webhook: config.Webhook{
+			Enable:       true,
+			URL:          url,
+			Type:         webhookType,
+			SecretEnv:    "TEST_WEBHOOK_SECRET",
+			Levels:       []string{WARN_EVENT_LEVEL, ERROR_EVENT_LEVEL, CRITICAL_EVENT_LEVEL},
+			Timeout:      time.Second,
+			Retries:      2,
+			RetryBackoff: time.Millisecond,
+		},
+	}
+}
+
+func webhookTestEvent(level, message string) *eventsProto.Event {
+	return &eventsProto.Event{
+		Metadata: &eventsProto.Metadata{
+			Module:        MODULE,
+			EventLevel:    level,
+			Type:          AGENT_EVENT_TYPE,
+			Category:      CONFIG_CATEGORY,
+			CorrelationID: "correlation-1",
+		},
+		Data: &eventsProto.Event_ActivityEvent{
+			ActivityEvent: &eventsProto.ActivityEvent{Message: message},
+		},
+	}
+}
+
+func webhookEventsMessage(events ...*eventsProto.Event) *core.Message {
+	return core.NewMessage(core.Events, &proto.Command{
+		Data: &proto.Command_EventReport{
+			EventReport: &eventsProto.EventReport{Events: events},
+		},
+	})
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []map[string]string
+	var authorizations []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(raw, &body))
+		mu.Lock()
+		bodies = append(bodies, body)
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_WEBHOOK_SECRET", "hook-secret")
+
+	pluginUnderTest := NewWebhook(webhookTestConfig(server.URL, "generic"), tutils.GetMockEnv())
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.Init(messagePipe)
+	defer pluginUnderTest.Close()
+
+	// only the configured levels are delivered
+	pluginUnderTest.Process(webhookEventsMessage(
+		webhookTestEvent(INFO_EVENT_LEVEL, "agent started"),
+		webhookTestEvent(ERROR_EVENT_LEVEL, "config apply failed"),
+	))
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(bodies) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "config apply failed", bodies[0]["message"])
+	assert.Equal(t, ERROR_EVENT_LEVEL, bodies[0]["level"])
+	assert.Equal(t, CONFIG_CATEGORY, bodies[0]["category"])
+	assert.Equal(t, "Bearer hook-secret", authorizations[0])
+}
+
+func TestWebhookRetry(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	pluginUnderTest := NewWebhook(webhookTestConfig(server.URL, "generic"), tutils.GetMockEnv())
+	messagePipe := core.SetupMockMessagePipe(t, context.Background(), pluginUnderTest)
+	pluginUnderTest.Init(messagePipe)
+	defer pluginUnderTest.Close()
+
+	pluginUnderTest.Process(webhookEventsMessage(webhookTestEvent(CRITICAL_EVENT_LEVEL, "nginx is down")))
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return attempts == 3
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWebhookPayloads(t *testing.T) {
+	slack := NewWebhook(webhookTestConfig("http://127.0.0.1:1", "slack"), tutils.GetMockEnv())
+	payload, err := slack.buildPayload(webhookTestEvent(WARN_EVENT_LEVEL, "certificate expiring"))
+	require.NoError(t, err)
+	var slackBody map[string]string
+	require.NoError(t, json.Unmarshal(payload, &slackBody))
+	assert.Equal(t, "[WARN] test-host: certificate expiring", slackBody["text"])
+
+	pagerDuty := NewWebhook(webhookTestConfig("http://127.0.0.1:1", "pagerduty"), tutils.GetMockEnv())
+	pagerDuty.secret = "routing-key"
+	payload, err = pagerDuty.buildPayload(webhookTestEvent(CRITICAL_EVENT_LEVEL, "nginx is down"))
+	require.NoError(t, err)
+	var pagerDutyBody map[string]interface{}
+	require.NoError(t, json.Unmarshal(payload, &pagerDutyBody))
+	assert.Equal(t, "routing-key", pagerDutyBody["routing_key"])
+	assert.Equal(t, "trigger", pagerDutyBody["event_action"])
+	inner := pagerDutyBody["payload"].(map[string]interface{})
+	assert.Equal(t, "nginx is down", inner["summary"])
+	assert.Equal(t, "critical", inner["severity"])
+}
+
+func TestWebhookSubscriptions(t *testing.T) {
+	pluginUnderTest := NewWebhook(webhookTestConfig("http://127.0.0.1:1", "generic"), tutils.GetMockEnv())
+	assert.Equal(t, []string{core.Events}, pluginUnderTest.Subscriptions())
+	assert.Equal(t, "Webhook", pluginUnderTest.Info().Name())
+}
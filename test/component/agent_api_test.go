@@ -63,7 +63,7 @@ func TestGetNginxInstances(t *testing.T) {
 			mockNginxBinary := tutils.NewMockNginxBinary()
 			mockNginxBinary.On("GetNginxDetailsFromProcess", mock.Anything).Return(tt.nginxDetails)
 
-			agentAPI := plugins.NewAgentAPI(conf, mockEnvironment, mockNginxBinary)
+			agentAPI := plugins.NewAgentAPI(conf, mockEnvironment, mockNginxBinary, "")
 			agentAPI.Init(core.NewMockMessagePipe(context.TODO()))
 
 			client := resty.New()
@@ -105,7 +105,7 @@ func TestInvalidPath(t *testing.T) {
 	mockEnvironment := tutils.NewMockEnvironment()
 	mockNginxBinary := tutils.NewMockNginxBinary()
 
-	agentAPI := plugins.NewAgentAPI(conf, mockEnvironment, mockNginxBinary)
+	agentAPI := plugins.NewAgentAPI(conf, mockEnvironment, mockNginxBinary, "")
 	agentAPI.Init(core.NewMockMessagePipe(context.TODO()))
 
 	client := resty.New()
@@ -133,7 +133,7 @@ func TestMetrics(t *testing.T) {
 	mockEnvironment := tutils.NewMockEnvironment()
 	mockNginxBinary := tutils.NewMockNginxBinary()
 
-	agentAPI := plugins.NewAgentAPI(conf, mockEnvironment, mockNginxBinary)
+	agentAPI := plugins.NewAgentAPI(conf, mockEnvironment, mockNginxBinary, "")
 	agentAPI.Init(core.NewMockMessagePipe(context.TODO()))
 	agentAPI.Process(core.NewMessage(core.MetricReport, &proto.MetricsReport{
 		Meta: &proto.Metadata{
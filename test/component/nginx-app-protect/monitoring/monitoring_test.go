@@ -107,7 +107,7 @@ func TestNAPMonitoring(t *testing.T) {
 		return
 	}
 
-	metricsSender := plugins.NewMetricsSender(reporter)
+	metricsSender := plugins.NewMetricsSender(reporter, &config.Config{}, nil)
 
 	env := tutils.NewMockEnvironment()
 	env.On("NewHostInfo", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything).Return(&sdkPb.HostInfo{
@@ -40,6 +40,8 @@ func GetMockNginxBinary() *MockNginxBinary {
 	binary.On("GetNginxDetailsMapFromProcesses", mock.Anything).Return(GetDetailsMap())
 	binary.On("GetNginxIDForProcess", mock.Anything).Return(GetDetailsMap())
 	binary.On("GetNginxDetailsFromProcess", mock.Anything).Return(GetDetailsMap()["12345"])
+	binary.On("GetAccessLogs").Return(map[string]string{})
+	binary.On("GetErrorLogs").Return(map[string]string{})
 
 	return binary
 }
@@ -69,6 +71,12 @@ func (m *MockNginxBinary) ReadConfig(path, nginxId, systemId string) (*proto.Ngi
 	return config, err
 }
 
+func (m *MockNginxBinary) GetParsedConfig(nginxId string) *sdk.ParsedConfig {
+	args := m.Called(nginxId)
+	parsed, _ := args.Get(0).(*sdk.ParsedConfig)
+	return parsed
+}
+
 func (m *MockNginxBinary) Start(nginxId, bin string) error {
 	m.Called(nginxId, bin)
 	return nil